@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -9,13 +10,20 @@ import (
 	_ "gomall/docs"
 
 	"gomall/db"
+	"gomall/db/sqlc"
 	"gomall/internal/cache"
+	"gomall/internal/common/middleware"
 	"gomall/internal/config"
 	"gomall/internal/domain/category"
 	"gomall/internal/domain/inventory"
 	"gomall/internal/domain/order"
 	"gomall/internal/domain/product"
+	"gomall/internal/domain/purchase"
+	"gomall/internal/domain/question"
+	"gomall/internal/domain/review"
 	"gomall/internal/domain/user"
+	"gomall/internal/search"
+	"gomall/internal/storage"
 	"gomall/utils/mail"
 	"gomall/utils/token"
 
@@ -73,6 +81,27 @@ func main() {
 	}
 	log.Println("✅ Connected to Redis successfully")
 
+	if cfg.Cache.L1.Enabled {
+		tiered, err := cache.NewTieredCache(cache.NewMemoryCache(cache.MemoryConfig{
+			MaxEntries: cfg.Cache.L1.MaxEntries,
+			MaxBytes:   cfg.Cache.L1.MaxBytes,
+		}), cacheClient, cache.TieredConfig{
+			L1TTL:   cfg.Cache.L1.TTL,
+			Channel: cfg.Cache.L1.Channel,
+		})
+		if err != nil {
+			log.Fatalf("Failed to start in-process cache: %v", err)
+		}
+		defer tiered.Close()
+		cacheClient = tiered
+		log.Println("✅ In-process cache enabled in front of Redis")
+	}
+
+	locker, err := cache.NewLocker(cacheClient)
+	if err != nil {
+		log.Fatalf("Failed to create locker: %v", err)
+	}
+
 	// 4. Create Token Maker
 	tokenMaker, err := token.NewJWTMaker(cfg.JWT.Secret)
 	if err != nil {
@@ -84,45 +113,89 @@ func main() {
 		cfg.Email.SenderEmail,
 		cfg.Email.SenderPassword)
 
-	// 5. Initialize User domain
+	// 5. Initialize Product domain
+	productRepo := product.NewRepository(pool)
+	searchIndex, err := search.NewIndex(cfg.Search, sqlc.NewStore(pool))
+	if err != nil {
+		log.Fatalf("Failed to open search index: %v", err)
+	}
+	defer searchIndex.Close()
+	blob, err := storage.NewBlob(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to open blob storage: %v", err)
+	}
+	productService := product.NewService(productRepo, cacheClient, cfg.Cache, searchIndex, cfg.Search, blob, cfg.Image, cfg.HotProducts, cfg.RelatedProducts)
+	productHandler := product.NewHandler(productService, cfg.Image.MaxUploadSize, tokenMaker)
+
+	// Initialize User domain; logging in merges the visitor's product browsing history
 	userRepo := user.NewRepository(pool)
-	userService := user.NewService(cfg, userRepo, tokenMaker, emailSender)
+	userService := user.NewService(cfg, userRepo, tokenMaker, emailSender, productService)
 	userHandler := user.NewHandler(userService, tokenMaker)
 
-	// Initialize Product domain
-	productRepo := product.NewRepository(pool)
-	productService := product.NewService(productRepo)
-	productHandler := product.NewHandler(productService)
-
 	// Initialize Category domain
 	categoryRepo := category.NewRepository(pool)
-	categoryService := category.NewService(categoryRepo)
+	categoryService := category.NewService(categoryRepo, cacheClient, cfg.Cache)
 	categoryHandler := category.NewHandler(categoryService)
 
 	// Inventory
 	inventoryRepo := inventory.NewRepository(pool)
-	inventoryService := inventory.NewService(inventoryRepo)
-	inventoryHandler := inventory.NewHandler(inventoryService)
+	lowStockNotifier := inventory.NewLowStockNotifier(cfg.Alert.LowStock, inventoryRepo, cacheClient, emailSender)
+	inventoryService := inventory.NewService(inventoryRepo, lowStockNotifier, cfg.Inventory.Reorder)
+	inventoryHandler := inventory.NewHandler(inventoryService, tokenMaker)
 
 	// Order
 	orderRepo := order.NewRepository(pool)
 	orderService := order.NewService(orderRepo, inventoryService, productService)
 	orderHandler := order.NewHandler(orderService)
 
+	// Purchase
+	purchaseRepo := purchase.NewRepository(pool)
+	purchaseService := purchase.NewService(purchaseRepo, inventoryService)
+	purchaseHandler := purchase.NewHandler(purchaseService)
+
+	// Review
+	reviewRepo := review.NewRepository(pool)
+	reviewService := review.NewService(reviewRepo, cfg.Review)
+	reviewHandler := review.NewHandler(reviewService, tokenMaker)
+
+	// Question
+	questionRepo := question.NewRepository(pool)
+	questionService := question.NewService(questionRepo, emailSender)
+	questionHandler := question.NewHandler(questionService, tokenMaker)
+
 	// 6. Init Router
 	gin.SetMode(cfg.Server.Mode)
 	r := gin.Default()
+	// Client IPs key rate limits and guest sessions, so forwarded headers are only believed from known proxies
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %v", err)
+	}
 
 	//Swagger
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Uploaded files; the path must match storage.local.base_url
+	if localBlob, ok := blob.(*storage.LocalBlob); ok {
+		r.Static("/uploads", localBlob.Root())
+	}
+
 	// Health Check
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	rateLimiter, err := cache.NewRateLimiter(cacheClient)
+	if err != nil {
+		log.Fatalf("Failed to create rate limiter: %v", err)
+	}
+	rateLimit, err := middleware.RateLimitMiddleware(cfg.RateLimit, rateLimiter, tokenMaker)
+	if err != nil {
+		log.Fatalf("Invalid rate limit config: %v", err)
+	}
+
 	// API Route
 	api := r.Group("/api/v1")
+	api.Use(rateLimit)
 	{
 		// Register User Route
 		userHandler.RegisterRoutes(api)
@@ -139,20 +212,35 @@ func main() {
 		//Register Order Route
 		orderHandler.RegisterRoutes(api)
 
+		// Register Purchase Route
+		purchaseHandler.RegisterRoutes(api)
+
+		// Register Review Route
+		reviewHandler.RegisterRoutes(api)
+
+		// Register Question Route
+		questionHandler.RegisterRoutes(api)
+
 	}
 
-	go startInventoryCleanupJob(inventoryService)
+	go startInventoryCleanupJob(inventoryService, locker)
+	go startReorderSuggestionJob(inventoryService, locker, cfg.Inventory.Reorder.RefreshInterval)
+	go startViewFlushJob(productService, cfg.Cache.ViewFlushInterval)
+	go startHotRankingJob(productService, locker, cfg.HotProducts.MaintainInterval)
+	go startRelatedProductsJob(productService, locker, cfg.RelatedProducts.RefreshInterval)
 
 	// 7. Start Service
 	log.Printf("🚀 Server starting on %s", cfg.Server.Port)
-	log.Printf("📦 Redis cache client initialized: %v", cacheClient != nil)
 	if err := r.Run(cfg.Server.Port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
 
 
-func startInventoryCleanupJob(inventoryService inventory.Service){
+// jobLockTTL is how long a job's lock outlives a crashed instance; held locks are renewed while the job runs
+const jobLockTTL = time.Minute
+
+func startInventoryCleanupJob(inventoryService inventory.Service, locker *cache.Locker){
 	ticker:=time.NewTicker(5*time.Minute)
 	defer ticker.Stop()
 
@@ -162,8 +250,11 @@ func startInventoryCleanupJob(inventoryService inventory.Service){
 		select{
 		case <-ticker.C:
 			ctx,cancel:=context.WithTimeout(context.Background(),30*time.Second)
-			err:=inventoryService.CleanupExpiredReservations(ctx)
-			if err!=nil{
+			// Only one instance cleans up at a time
+			err:=locker.WithLock(ctx, cache.CacheKeys.Lock("job:inventory-cleanup"), jobLockTTL, inventoryService.CleanupExpiredReservations)
+			if errors.Is(err, cache.ErrLockHeld){
+				log.Println("Inventory cleanup is running on another instance, skipping")
+			}else if err!=nil{
 				log.Printf("Failed to cleanup expired reservations: %v",err)
 			}else{
 				log.Println("Successfully cleanned up expired reservations")
@@ -171,4 +262,112 @@ func startInventoryCleanupJob(inventoryService inventory.Service){
 			cancel()
 		}
 	}
-}
\ No newline at end of file
+}
+
+func startReorderSuggestionJob(inventoryService inventory.Service, locker *cache.Locker, interval time.Duration) {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("Reorder suggestion job started, running every %s", interval)
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		var result *inventory.ReorderRefreshResponse
+		err := locker.WithLock(ctx, cache.CacheKeys.Lock("job:reorder-suggestions"), jobLockTTL, func(ctx context.Context) error {
+			var err error
+			result, err = inventoryService.RefreshReorderSuggestions(ctx)
+			return err
+		})
+		if errors.Is(err, cache.ErrLockHeld) {
+			log.Println("Reorder suggestions are being refreshed on another instance, skipping")
+		} else if err != nil {
+			log.Printf("Failed to refresh reorder suggestions: %v", err)
+		} else {
+			log.Printf("Refreshed reorder suggestions for %d products, updated %d thresholds", result.Products, result.ThresholdsUpdated)
+		}
+		cancel()
+	}
+}
+
+func startViewFlushJob(productService product.Service, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("View flush job started, running every %s", interval)
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if _, err := productService.FlushViews(ctx); err != nil {
+			log.Printf("Failed to flush product views: %v", err)
+		}
+		cancel()
+	}
+}
+
+func startHotRankingJob(productService product.Service, locker *cache.Locker, interval time.Duration) {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("Hot product ranking job started, running every %s", interval)
+
+	// Run once at startup so missing rankings are rebuilt without waiting for the first tick
+	maintain := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		var rebuilt int
+		err := locker.WithLock(ctx, cache.CacheKeys.Lock("job:hot-products"), jobLockTTL, func(ctx context.Context) error {
+			var err error
+			rebuilt, err = productService.MaintainHotRankings(ctx)
+			return err
+		})
+		if errors.Is(err, cache.ErrLockHeld) {
+			log.Println("Hot product rankings are being maintained on another instance, skipping")
+		} else if err != nil {
+			log.Printf("Failed to maintain hot product rankings: %v", err)
+		} else if rebuilt > 0 {
+			log.Printf("Rebuilt hot product rankings for %d windows", rebuilt)
+		}
+	}
+
+	maintain()
+	for range ticker.C {
+		maintain()
+	}
+}
+
+func startRelatedProductsJob(productService product.Service, locker *cache.Locker, interval time.Duration) {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("Related products job started, running every %s", interval)
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		var stored int64
+		err := locker.WithLock(ctx, cache.CacheKeys.Lock("job:related-products"), jobLockTTL, func(ctx context.Context) error {
+			var err error
+			stored, err = productService.RefreshRelatedProducts(ctx)
+			return err
+		})
+		if errors.Is(err, cache.ErrLockHeld) {
+			log.Println("Related products are being refreshed on another instance, skipping")
+		} else if err != nil {
+			log.Printf("Failed to refresh related products: %v", err)
+		} else {
+			log.Printf("Refreshed related products, stored %d associations", stored)
+		}
+		cancel()
+	}
+}
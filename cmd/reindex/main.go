@@ -0,0 +1,42 @@
+// Command reindex rebuilds the product search index from the database.
+// With the embedded backend, stop the API first: the index file is owned by one process.
+package main
+
+import (
+	"context"
+	"log"
+
+	"gomall/db"
+	"gomall/db/sqlc"
+	"gomall/internal/config"
+	"gomall/internal/domain/product"
+	"gomall/internal/search"
+)
+
+func main() {
+	cfg, err := config.Load("config/config.yaml")
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	pool, err := db.NewPostgresPool(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	searchIndex, err := search.NewIndex(cfg.Search, sqlc.NewStore(pool))
+	if err != nil {
+		log.Fatalf("Failed to open search index: %v", err)
+	}
+	defer searchIndex.Close()
+
+	// Reindexing reads products only, so the service needs no cache or blob storage
+	productService := product.NewService(product.NewRepository(pool), nil, cfg.Cache, searchIndex, cfg.Search, nil, cfg.Image, cfg.HotProducts, cfg.RelatedProducts)
+
+	count, err := productService.ReindexSearch(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to reindex products: %v", err)
+	}
+	log.Printf("✅ Indexed %d products (backend: %s)", count, cfg.Search.Backend)
+}
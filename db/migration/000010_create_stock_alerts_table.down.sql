@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trigger_update_stock_alerts_updated_at ON stock_alerts;
+
+DROP TABLE IF EXISTS stock_alerts;
@@ -0,0 +1,22 @@
+-- Stock alerts table: one row per low-stock episode of a product
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    available_stock INT NOT NULL,
+    threshold INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'resolved')),
+    notified_at TIMESTAMPTZ,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- At most one active alert per product
+CREATE UNIQUE INDEX idx_stock_alerts_active_product ON stock_alerts(product_id) WHERE status = 'active';
+CREATE INDEX idx_stock_alerts_status ON stock_alerts(status);
+CREATE INDEX idx_stock_alerts_created_at ON stock_alerts(created_at DESC);
+
+CREATE TRIGGER trigger_update_stock_alerts_updated_at
+    BEFORE UPDATE ON stock_alerts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
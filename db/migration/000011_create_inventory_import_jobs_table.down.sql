@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_inventory_logs_reference;
+
+ALTER TABLE inventory_logs
+    DROP COLUMN IF EXISTS reference_id,
+    DROP COLUMN IF EXISTS reference_type;
+
+DROP TRIGGER IF EXISTS trigger_update_inventory_import_jobs_updated_at ON inventory_import_jobs;
+
+DROP TABLE IF EXISTS inventory_import_jobs;
@@ -0,0 +1,32 @@
+-- Inventory import jobs table: tracks bulk CSV stock imports
+CREATE TABLE IF NOT EXISTS inventory_import_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    filename VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    total_rows INT NOT NULL DEFAULT 0,
+    processed_rows INT NOT NULL DEFAULT 0,
+    succeeded_rows INT NOT NULL DEFAULT 0,
+    failed_rows INT NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',
+    error_message VARCHAR(500),
+    operator_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_import_jobs_status ON inventory_import_jobs(status);
+CREATE INDEX idx_inventory_import_jobs_created_at ON inventory_import_jobs(created_at DESC);
+
+CREATE TRIGGER trigger_update_inventory_import_jobs_updated_at
+    BEFORE UPDATE ON inventory_import_jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
+
+-- Link inventory logs to the business document that caused them (import job, stocktake, purchase order...)
+ALTER TABLE inventory_logs
+    ADD COLUMN reference_type VARCHAR(30),
+    ADD COLUMN reference_id BIGINT;
+
+CREATE INDEX idx_inventory_logs_reference ON inventory_logs(reference_type, reference_id) WHERE reference_type IS NOT NULL;
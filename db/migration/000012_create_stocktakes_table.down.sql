@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trigger_update_stocktake_items_updated_at ON stocktake_items;
+DROP TRIGGER IF EXISTS trigger_update_stocktakes_updated_at ON stocktakes;
+
+DROP TABLE IF EXISTS stocktake_items;
+DROP TABLE IF EXISTS stocktakes;
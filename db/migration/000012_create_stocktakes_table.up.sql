@@ -0,0 +1,46 @@
+-- Stocktakes table: a physical count session (cycle count)
+CREATE TABLE IF NOT EXISTS stocktakes (
+    id BIGSERIAL PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'approved', 'cancelled')),
+    category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL,
+    note VARCHAR(500),
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    approved_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    approved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stocktakes_status ON stocktakes(status);
+CREATE INDEX idx_stocktakes_created_at ON stocktakes(created_at DESC);
+
+CREATE TRIGGER trigger_update_stocktakes_updated_at
+    BEFORE UPDATE ON stocktakes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
+
+-- Stocktake items table: one counted line per product in a session
+CREATE TABLE IF NOT EXISTS stocktake_items (
+    id BIGSERIAL PRIMARY KEY,
+    stocktake_id BIGINT NOT NULL REFERENCES stocktakes(id) ON DELETE CASCADE,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    expected_quantity INT NOT NULL,
+    counted_quantity INT CHECK (counted_quantity >= 0),
+    counted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    counted_at TIMESTAMPTZ,
+    variance INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_stocktake_product UNIQUE (stocktake_id, product_id)
+);
+
+CREATE INDEX idx_stocktake_items_stocktake_id ON stocktake_items(stocktake_id);
+CREATE INDEX idx_stocktake_items_product_id ON stocktake_items(product_id);
+
+CREATE TRIGGER trigger_update_stocktake_items_updated_at
+    BEFORE UPDATE ON stocktake_items
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
+
+COMMENT ON COLUMN stocktake_items.expected_quantity IS 'available_stock + reserved_stock when the session was opened';
+COMMENT ON COLUMN stocktake_items.variance IS 'Posted variance (counted - system total), set on approval';
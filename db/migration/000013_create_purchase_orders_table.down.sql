@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS purchase_receipts;
+
+DROP TRIGGER IF EXISTS trigger_update_purchase_order_items_updated_at ON purchase_order_items;
+DROP TABLE IF EXISTS purchase_order_items;
+
+DROP TRIGGER IF EXISTS trigger_update_purchase_orders_updated_at ON purchase_orders;
+DROP TABLE IF EXISTS purchase_orders;
+
+DROP TRIGGER IF EXISTS trigger_update_suppliers_updated_at ON suppliers;
+DROP TABLE IF EXISTS suppliers;
@@ -0,0 +1,88 @@
+-- Suppliers table
+CREATE TABLE IF NOT EXISTS suppliers (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    contact_name VARCHAR(100),
+    email VARCHAR(255),
+    phone VARCHAR(50),
+    address VARCHAR(500),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_suppliers_name ON suppliers(name);
+CREATE INDEX idx_suppliers_is_active ON suppliers(is_active);
+
+CREATE TRIGGER trigger_update_suppliers_updated_at
+    BEFORE UPDATE ON suppliers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
+
+-- Purchase orders table
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id BIGSERIAL PRIMARY KEY,
+    po_no VARCHAR(64) NOT NULL UNIQUE,
+    supplier_id BIGINT NOT NULL REFERENCES suppliers(id) ON DELETE RESTRICT,
+    status VARCHAR(30) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'ordered', 'partially_received', 'received', 'cancelled')),
+    expected_date DATE,
+    total_cost BIGINT NOT NULL DEFAULT 0 CHECK (total_cost >= 0),
+    note VARCHAR(500),
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ordered_at TIMESTAMPTZ,
+    received_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders(supplier_id);
+CREATE INDEX idx_purchase_orders_status ON purchase_orders(status);
+CREATE INDEX idx_purchase_orders_created_at ON purchase_orders(created_at DESC);
+
+CREATE TRIGGER trigger_update_purchase_orders_updated_at
+    BEFORE UPDATE ON purchase_orders
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
+
+-- Purchase order items table
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+    id BIGSERIAL PRIMARY KEY,
+    purchase_order_id BIGINT NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    received_quantity INT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    unit_cost BIGINT NOT NULL CHECK (unit_cost >= 0),
+    expected_date DATE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_purchase_order_product UNIQUE (purchase_order_id, product_id),
+    CONSTRAINT check_received_quantity CHECK (received_quantity <= quantity)
+);
+
+CREATE INDEX idx_purchase_order_items_purchase_order_id ON purchase_order_items(purchase_order_id);
+CREATE INDEX idx_purchase_order_items_product_id ON purchase_order_items(product_id);
+
+CREATE TRIGGER trigger_update_purchase_order_items_updated_at
+    BEFORE UPDATE ON purchase_order_items
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
+
+-- Purchase receipts table: landed-cost trail, one row per received line
+CREATE TABLE IF NOT EXISTS purchase_receipts (
+    id BIGSERIAL PRIMARY KEY,
+    purchase_order_id BIGINT NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    purchase_order_item_id BIGINT NOT NULL REFERENCES purchase_order_items(id) ON DELETE CASCADE,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_cost BIGINT NOT NULL CHECK (unit_cost >= 0),
+    on_hand_before INT NOT NULL,
+    cost_price_before BIGINT,
+    cost_price_after BIGINT NOT NULL,
+    received_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_purchase_receipts_purchase_order_id ON purchase_receipts(purchase_order_id);
+CREATE INDEX idx_purchase_receipts_product_id ON purchase_receipts(product_id, created_at DESC);
+
+COMMENT ON COLUMN purchase_receipts.cost_price_after IS 'Weighted average cost per unit after this receipt';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_inventory_logs_product_created_at;
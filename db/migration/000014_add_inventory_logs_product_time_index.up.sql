@@ -0,0 +1,2 @@
+-- Supports point-in-time stock lookups and per-product movement reports
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_product_created_at ON inventory_logs(product_id, created_at DESC, id DESC);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_update_reorder_settings_updated_at ON reorder_settings;
+
+DROP TABLE IF EXISTS reorder_suggestions;
+DROP TABLE IF EXISTS reorder_settings;
@@ -0,0 +1,35 @@
+-- Reorder settings table: per-category lead time and safety stock overrides
+CREATE TABLE IF NOT EXISTS reorder_settings (
+    category_id BIGINT PRIMARY KEY REFERENCES categories(id) ON DELETE CASCADE,
+    lead_time_days INT NOT NULL CHECK (lead_time_days > 0),
+    safety_stock_days INT NOT NULL DEFAULT 0 CHECK (safety_stock_days >= 0),
+    auto_apply BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trigger_update_reorder_settings_updated_at
+    BEFORE UPDATE ON reorder_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
+
+-- Reorder suggestions table: latest computed reorder point per product
+CREATE TABLE IF NOT EXISTS reorder_suggestions (
+    product_id BIGINT PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    category_id BIGINT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    window_days INT NOT NULL,
+    units_sold INT NOT NULL DEFAULT 0,
+    daily_velocity DOUBLE PRECISION NOT NULL DEFAULT 0,
+    lead_time_days INT NOT NULL,
+    safety_stock_days INT NOT NULL,
+    reorder_point INT NOT NULL CHECK (reorder_point >= 0),
+    reorder_quantity INT NOT NULL CHECK (reorder_quantity >= 0),
+    current_threshold INT,
+    available_stock INT NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reorder_suggestions_category_id ON reorder_suggestions(category_id);
+
+COMMENT ON COLUMN reorder_suggestions.units_sold IS 'Greater of ordered units (non-cancelled orders) and deducted units over the window';
+COMMENT ON COLUMN reorder_suggestions.reorder_quantity IS 'Units needed to reach the order-up-to level from available stock';
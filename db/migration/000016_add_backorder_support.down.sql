@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS trigger_update_inventory_backorders_updated_at ON inventory_backorders;
+DROP TABLE IF EXISTS inventory_backorders;
+
+UPDATE inventory_reservations SET status = 'active' WHERE status = 'held';
+ALTER TABLE inventory_reservations DROP CONSTRAINT IF EXISTS inventory_reservations_status_check;
+ALTER TABLE inventory_reservations ADD CONSTRAINT inventory_reservations_status_check
+    CHECK (status IN ('active', 'confirmed', 'cancelled', 'expired'));
+
+UPDATE orders SET status = 'pending' WHERE status = 'backordered';
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('pending', 'paid', 'shipped', 'completed', 'cancelled', 'refunded'));
+
+ALTER TABLE inventory
+    DROP COLUMN IF EXISTS backordered_stock,
+    DROP COLUMN IF EXISTS preorder_release_at,
+    DROP COLUMN IF EXISTS backorder_limit,
+    DROP COLUMN IF EXISTS stock_policy;
@@ -0,0 +1,39 @@
+-- Per-product stock policy: deny (default), backorder up to a limit, or pre-order until a release date
+ALTER TABLE inventory
+    ADD COLUMN stock_policy VARCHAR(20) NOT NULL DEFAULT 'deny' CHECK (stock_policy IN ('deny', 'backorder', 'preorder')),
+    ADD COLUMN backorder_limit INT CHECK (backorder_limit >= 0),
+    ADD COLUMN preorder_release_at TIMESTAMPTZ,
+    ADD COLUMN backordered_stock INT NOT NULL DEFAULT 0 CHECK (backordered_stock >= 0);
+
+COMMENT ON COLUMN inventory.backorder_limit IS 'Maximum outstanding backordered units; NULL means unlimited';
+COMMENT ON COLUMN inventory.backordered_stock IS 'Units on pending backorders waiting for stock';
+
+-- Orders waiting for stock
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('pending', 'backordered', 'paid', 'shipped', 'completed', 'cancelled', 'refunded'));
+
+-- Held reservations belong to backordered orders and do not expire until the order becomes payable
+ALTER TABLE inventory_reservations DROP CONSTRAINT IF EXISTS inventory_reservations_status_check;
+ALTER TABLE inventory_reservations ADD CONSTRAINT inventory_reservations_status_check
+    CHECK (status IN ('active', 'held', 'confirmed', 'cancelled', 'expired'));
+
+-- Backorders table: order lines accepted without stock, allocated FIFO as stock arrives
+CREATE TABLE IF NOT EXISTS inventory_backorders (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'allocated', 'cancelled')),
+    allocated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_backorders_pending ON inventory_backorders(product_id, id) WHERE status = 'pending';
+CREATE INDEX idx_inventory_backorders_order_id ON inventory_backorders(order_id);
+
+CREATE TRIGGER trigger_update_inventory_backorders_updated_at
+    BEFORE UPDATE ON inventory_backorders
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
@@ -0,0 +1,28 @@
+ALTER TABLE carts DROP CONSTRAINT IF EXISTS carts_user_product_sku_key;
+DELETE FROM carts WHERE sku_id IS NOT NULL;
+ALTER TABLE carts DROP COLUMN IF EXISTS sku_id;
+ALTER TABLE carts ADD CONSTRAINT carts_user_id_product_id_key UNIQUE (user_id, product_id);
+
+ALTER TABLE order_items DROP COLUMN IF EXISTS sku_id;
+
+DROP INDEX IF EXISTS idx_inventory_backorders_pending;
+ALTER TABLE inventory_backorders DROP COLUMN IF EXISTS sku_id;
+CREATE INDEX idx_inventory_backorders_pending ON inventory_backorders(product_id, id) WHERE status = 'pending';
+
+ALTER TABLE inventory_reservations DROP CONSTRAINT IF EXISTS inventory_reservations_product_sku_order_key;
+DELETE FROM inventory_reservations WHERE sku_id IS NOT NULL;
+ALTER TABLE inventory_reservations DROP COLUMN IF EXISTS sku_id;
+ALTER TABLE inventory_reservations ADD CONSTRAINT inventory_reservations_product_id_order_id_key UNIQUE (product_id, order_id);
+
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS sku_id;
+
+DROP INDEX IF EXISTS idx_inventory_sku_id;
+DROP INDEX IF EXISTS idx_inventory_product_level;
+DELETE FROM inventory WHERE sku_id IS NOT NULL;
+ALTER TABLE inventory DROP COLUMN IF EXISTS sku_id;
+ALTER TABLE inventory ADD CONSTRAINT inventory_product_id_key UNIQUE (product_id);
+
+DROP TRIGGER IF EXISTS trigger_update_product_skus_updated_at ON product_skus;
+DROP TABLE IF EXISTS product_skus;
+DROP TABLE IF EXISTS product_option_values;
+DROP TABLE IF EXISTS product_options;
@@ -0,0 +1,78 @@
+-- Product options: the variant dimensions of a product, e.g. size or colour
+CREATE TABLE IF NOT EXISTS product_options (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (product_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS product_option_values (
+    id BIGSERIAL PRIMARY KEY,
+    option_id BIGINT NOT NULL REFERENCES product_options(id) ON DELETE CASCADE,
+    value VARCHAR(100) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (option_id, value)
+);
+
+-- Product SKUs: one sellable combination of option values with its own price and stock
+CREATE TABLE IF NOT EXISTS product_skus (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    sku_code VARCHAR(64) NOT NULL,
+    price BIGINT NOT NULL CHECK (price >= 0),
+    origin_price BIGINT NOT NULL DEFAULT 0 CHECK (origin_price >= 0),
+    image_url VARCHAR(500),
+    barcode VARCHAR(64),
+    options JSONB NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+COMMENT ON COLUMN product_skus.options IS 'Option name to value, e.g. {"size": "M", "colour": "Red"}';
+
+CREATE UNIQUE INDEX idx_product_skus_sku_code ON product_skus(sku_code) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_product_skus_barcode ON product_skus(barcode) WHERE deleted_at IS NULL AND barcode IS NOT NULL;
+CREATE UNIQUE INDEX idx_product_skus_options ON product_skus(product_id, options) WHERE deleted_at IS NULL;
+CREATE INDEX idx_product_skus_product_id ON product_skus(product_id) WHERE deleted_at IS NULL;
+
+CREATE TRIGGER trigger_update_product_skus_updated_at
+    BEFORE UPDATE ON product_skus
+    FOR EACH ROW
+    EXECUTE FUNCTION update_inventory_updated_at();
+
+-- Inventory is tracked per SKU; rows without a SKU hold stock for products without variants
+ALTER TABLE inventory
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE RESTRICT;
+ALTER TABLE inventory DROP CONSTRAINT IF EXISTS inventory_product_id_key;
+CREATE UNIQUE INDEX idx_inventory_product_level ON inventory(product_id) WHERE sku_id IS NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_inventory_sku_id ON inventory(sku_id) WHERE sku_id IS NOT NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN inventory.sku_id IS 'NULL for products without variants';
+
+ALTER TABLE inventory_logs
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE RESTRICT;
+
+ALTER TABLE inventory_reservations
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE RESTRICT;
+ALTER TABLE inventory_reservations DROP CONSTRAINT IF EXISTS inventory_reservations_product_id_order_id_key;
+ALTER TABLE inventory_reservations ADD CONSTRAINT inventory_reservations_product_sku_order_key
+    UNIQUE NULLS NOT DISTINCT (product_id, sku_id, order_id);
+
+ALTER TABLE inventory_backorders
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE RESTRICT;
+DROP INDEX IF EXISTS idx_inventory_backorders_pending;
+CREATE INDEX idx_inventory_backorders_pending ON inventory_backorders(product_id, sku_id, id) WHERE status = 'pending';
+
+ALTER TABLE order_items
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE RESTRICT;
+
+ALTER TABLE carts
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE CASCADE;
+ALTER TABLE carts DROP CONSTRAINT IF EXISTS carts_user_id_product_id_key;
+ALTER TABLE carts ADD CONSTRAINT carts_user_product_sku_key
+    UNIQUE NULLS NOT DISTINCT (user_id, product_id, sku_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_search_vector;
+DROP TRIGGER IF EXISTS trigger_update_products_search_vector ON products;
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS update_products_search_vector();
+DROP FUNCTION IF EXISTS search_segment(TEXT);
@@ -0,0 +1,62 @@
+-- Splits runs of CJK characters into overlapping bigrams so the 'simple' parser,
+-- which only breaks on whitespace and punctuation, can index Chinese, Japanese and Korean text.
+-- Must stay in sync with search.BigramSegmenter.
+CREATE OR REPLACE FUNCTION search_segment(input TEXT)
+RETURNS TEXT AS $$
+DECLARE
+    segmented TEXT := '';
+    run TEXT;
+    i INT;
+BEGIN
+    IF input IS NULL THEN
+        RETURN '';
+    END IF;
+
+    FOR run IN
+        SELECT m[1] FROM regexp_matches(
+            input,
+            '([\u3040-\u30ff\u3400-\u4dbf\u4e00-\u9fff\uac00-\ud7af\uf900-\ufaff]+|[^\u3040-\u30ff\u3400-\u4dbf\u4e00-\u9fff\uac00-\ud7af\uf900-\ufaff]+)',
+            'g'
+        ) AS m
+    LOOP
+        IF run ~ '^[\u3040-\u30ff\u3400-\u4dbf\u4e00-\u9fff\uac00-\ud7af\uf900-\ufaff]' AND char_length(run) > 1 THEN
+            FOR i IN 1 .. char_length(run) - 1 LOOP
+                segmented := segmented || ' ' || substr(run, i, 2);
+            END LOOP;
+        ELSE
+            segmented := segmented || ' ' || run;
+        END IF;
+    END LOOP;
+
+    RETURN segmented;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- To switch to a dictionary-based parser (e.g. zhparser) replace this function in a new migration,
+-- re-run the backfill below and set search.ts_config / search.segmenter to match.
+CREATE OR REPLACE FUNCTION update_products_search_vector()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', search_segment(NEW.name)), 'A') ||
+        setweight(to_tsvector('simple', search_segment(NEW.brand)), 'B') ||
+        setweight(to_tsvector('simple', search_segment(NEW.description)), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE products ADD COLUMN search_vector TSVECTOR NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN products.search_vector IS 'Maintained by trigger: name (A), brand (B), description (C)';
+
+CREATE TRIGGER trigger_update_products_search_vector
+    BEFORE INSERT OR UPDATE OF name, brand, description ON products
+    FOR EACH ROW
+    EXECUTE FUNCTION update_products_search_vector();
+
+UPDATE products SET search_vector =
+    setweight(to_tsvector('simple', search_segment(name)), 'A') ||
+    setweight(to_tsvector('simple', search_segment(brand)), 'B') ||
+    setweight(to_tsvector('simple', search_segment(description)), 'C');
+
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
@@ -0,0 +1,2 @@
+-- Backs containment filters (specifications @> '{"color": "red"}') on product listings
+CREATE INDEX idx_products_specifications ON products USING GIN (specifications jsonb_path_ops) WHERE deleted_at IS NULL;
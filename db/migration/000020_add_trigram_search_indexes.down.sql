@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_categories_name_trgm;
+DROP INDEX IF EXISTS idx_products_brand_trgm;
+DROP INDEX IF EXISTS idx_products_name_trgm;
+-- pg_trgm is left installed as other objects may depend on it
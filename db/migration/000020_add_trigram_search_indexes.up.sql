@@ -0,0 +1,6 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Back typeahead (ILIKE '%q%' and word similarity) and spelling suggestions
+CREATE INDEX idx_products_name_trgm ON products USING GIN (name gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX idx_products_brand_trgm ON products USING GIN (brand gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX idx_categories_name_trgm ON categories USING GIN (name gin_trgm_ops) WHERE deleted_at IS NULL;
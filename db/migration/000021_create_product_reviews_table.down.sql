@@ -0,0 +1,6 @@
+ALTER TABLE products
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS rating_total;
+
+DROP TABLE IF EXISTS product_review_votes;
+DROP TABLE IF EXISTS product_reviews;
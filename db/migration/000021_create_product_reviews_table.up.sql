@@ -0,0 +1,32 @@
+-- Product reviews: one per completed order item
+CREATE TABLE IF NOT EXISTS product_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    order_item_id BIGINT NOT NULL UNIQUE REFERENCES order_items(id) ON DELETE RESTRICT,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    content TEXT NOT NULL,
+    images TEXT[] NOT NULL DEFAULT '{}',
+    helpful_count INT NOT NULL DEFAULT 0 CHECK (helpful_count >= 0),
+    reply TEXT,
+    replied_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+
+CREATE INDEX idx_product_reviews_product_created ON product_reviews(product_id, created_at DESC);
+CREATE INDEX idx_product_reviews_product_helpful ON product_reviews(product_id, helpful_count DESC);
+CREATE INDEX idx_product_reviews_user_id ON product_reviews(user_id);
+
+-- Helpful votes: one per user per review
+CREATE TABLE IF NOT EXISTS product_review_votes (
+    review_id BIGINT NOT NULL REFERENCES product_reviews(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (review_id, user_id)
+    );
+
+-- Aggregated rating kept on the product; the average is rating_total / rating_count
+ALTER TABLE products
+    ADD COLUMN rating_total BIGINT NOT NULL DEFAULT 0 CHECK (rating_total >= 0),
+    ADD COLUMN rating_count INT NOT NULL DEFAULT 0 CHECK (rating_count >= 0);
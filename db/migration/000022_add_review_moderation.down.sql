@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS product_review_moderation_logs;
+
+DROP INDEX IF EXISTS idx_product_reviews_status;
+DROP INDEX IF EXISTS idx_product_reviews_fingerprint;
+DROP INDEX IF EXISTS idx_product_reviews_user_created;
+DROP INDEX IF EXISTS idx_product_reviews_product_helpful;
+DROP INDEX IF EXISTS idx_product_reviews_product_created;
+CREATE INDEX idx_product_reviews_product_created ON product_reviews(product_id, created_at DESC);
+CREATE INDEX idx_product_reviews_product_helpful ON product_reviews(product_id, helpful_count DESC);
+CREATE INDEX idx_product_reviews_user_id ON product_reviews(user_id);
+
+-- Reviews that never passed moderation must not be published by the rollback
+DELETE FROM product_reviews WHERE status <> 'approved';
+
+ALTER TABLE product_reviews
+    DROP COLUMN IF EXISTS moderated_at,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS reject_reason,
+    DROP COLUMN IF EXISTS content_fingerprint,
+    DROP COLUMN IF EXISTS flag_reasons,
+    DROP COLUMN IF EXISTS status;
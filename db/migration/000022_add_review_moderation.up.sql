@@ -0,0 +1,37 @@
+-- Moderation state: existing reviews were published before moderation, so they start approved
+ALTER TABLE product_reviews
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'approved' CHECK (status IN ('pending', 'approved', 'rejected')),
+    ADD COLUMN flag_reasons TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN content_fingerprint VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN reject_reason TEXT,
+    ADD COLUMN moderated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN moderated_at TIMESTAMPTZ;
+
+ALTER TABLE product_reviews ALTER COLUMN status SET DEFAULT 'pending';
+
+COMMENT ON COLUMN product_reviews.flag_reasons IS 'Screener rules the review broke when submitted';
+COMMENT ON COLUMN product_reviews.content_fingerprint IS 'SHA-256 of the normalized content, for duplicate detection';
+
+-- Only approved reviews are listed publicly
+DROP INDEX IF EXISTS idx_product_reviews_product_created;
+DROP INDEX IF EXISTS idx_product_reviews_product_helpful;
+DROP INDEX IF EXISTS idx_product_reviews_user_id;
+CREATE INDEX idx_product_reviews_product_created ON product_reviews(product_id, created_at DESC) WHERE status = 'approved';
+CREATE INDEX idx_product_reviews_product_helpful ON product_reviews(product_id, helpful_count DESC) WHERE status = 'approved';
+CREATE INDEX idx_product_reviews_user_created ON product_reviews(user_id, created_at DESC);
+CREATE INDEX idx_product_reviews_fingerprint ON product_reviews(content_fingerprint, created_at DESC);
+CREATE INDEX idx_product_reviews_status ON product_reviews(status, created_at);
+
+-- Audit log of screener and admin decisions
+CREATE TABLE IF NOT EXISTS product_review_moderation_logs (
+    id BIGSERIAL PRIMARY KEY,
+    review_id BIGINT NOT NULL REFERENCES product_reviews(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('auto_approved', 'flagged', 'approved', 'rejected')),
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    operator_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+
+CREATE INDEX idx_product_review_moderation_logs_review_id ON product_review_moderation_logs(review_id, id);
@@ -0,0 +1,35 @@
+-- Product Q&A: questions asked on a product page
+CREATE TABLE IF NOT EXISTS product_questions (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    answer_count INT NOT NULL DEFAULT 0 CHECK (answer_count >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+
+CREATE INDEX idx_product_questions_product_created ON product_questions(product_id, created_at DESC);
+
+-- Answers from buyers of the product or staff
+CREATE TABLE IF NOT EXISTS product_answers (
+    id BIGSERIAL PRIMARY KEY,
+    question_id BIGINT NOT NULL REFERENCES product_questions(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    is_staff BOOLEAN NOT NULL DEFAULT FALSE,
+    is_buyer BOOLEAN NOT NULL DEFAULT FALSE,
+    upvote_count INT NOT NULL DEFAULT 0 CHECK (upvote_count >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+
+CREATE INDEX idx_product_answers_question_upvotes ON product_answers(question_id, upvote_count DESC, id);
+
+-- Upvotes: one per user per answer
+CREATE TABLE IF NOT EXISTS product_answer_votes (
+    answer_id BIGINT NOT NULL REFERENCES product_answers(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (answer_id, user_id)
+    );
@@ -0,0 +1,12 @@
+DELETE FROM product_images WHERE parent_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_product_images_parent_id;
+
+ALTER TABLE product_images
+    DROP COLUMN IF EXISTS size_bytes,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS content_type,
+    DROP COLUMN IF EXISTS storage_key,
+    DROP COLUMN IF EXISTS rendition,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,12 @@
+-- Uploaded images are stored as an original plus thumbnail renditions.
+-- The original keeps parent_id NULL; each thumbnail points at its original.
+ALTER TABLE product_images
+    ADD COLUMN parent_id BIGINT REFERENCES product_images(id) ON DELETE CASCADE,
+    ADD COLUMN rendition VARCHAR(20) NOT NULL DEFAULT 'original',
+    ADD COLUMN storage_key VARCHAR(500),
+    ADD COLUMN content_type VARCHAR(50),
+    ADD COLUMN width INT,
+    ADD COLUMN height INT,
+    ADD COLUMN size_bytes BIGINT;
+
+CREATE INDEX idx_product_images_parent_id ON product_images(parent_id) WHERE parent_id IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,14 @@
+-- "Customers also bought" associations, recomputed periodically from order co-occurrence.
+-- support counts the orders containing both products; confidence is support divided by
+-- the number of orders containing product_id, i.e. how often its buyers also bought the other.
+CREATE TABLE IF NOT EXISTS product_associations (
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    related_product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    support INT NOT NULL CHECK (support > 0),
+    confidence DOUBLE PRECISION NOT NULL CHECK (confidence > 0 AND confidence <= 1),
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, related_product_id),
+    CHECK (product_id <> related_product_id)
+);
+
+CREATE INDEX idx_product_associations_ranking ON product_associations(product_id, confidence DESC, support DESC);
@@ -0,0 +1,6 @@
+ALTER TABLE purchase_receipts DROP COLUMN IF EXISTS sku_id;
+
+ALTER TABLE purchase_order_items DROP CONSTRAINT IF EXISTS unique_purchase_order_product_sku;
+DELETE FROM purchase_order_items WHERE sku_id IS NOT NULL;
+ALTER TABLE purchase_order_items DROP COLUMN IF EXISTS sku_id;
+ALTER TABLE purchase_order_items ADD CONSTRAINT unique_purchase_order_product UNIQUE (purchase_order_id, product_id);
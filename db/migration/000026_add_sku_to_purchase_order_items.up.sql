@@ -0,0 +1,9 @@
+-- Purchase order lines name the SKU they restock; NULL for products without variants
+ALTER TABLE purchase_order_items
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE RESTRICT;
+ALTER TABLE purchase_order_items DROP CONSTRAINT IF EXISTS unique_purchase_order_product;
+ALTER TABLE purchase_order_items ADD CONSTRAINT unique_purchase_order_product_sku
+    UNIQUE NULLS NOT DISTINCT (purchase_order_id, product_id, sku_id);
+
+ALTER TABLE purchase_receipts
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE RESTRICT;
@@ -0,0 +1,3 @@
+-- Role carried in the user's tokens; staff and admin accounts are promoted by updating this column
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'staff', 'admin'));
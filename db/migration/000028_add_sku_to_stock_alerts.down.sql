@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_stock_alerts_active_unit;
+DELETE FROM stock_alerts WHERE sku_id IS NOT NULL;
+ALTER TABLE stock_alerts DROP COLUMN IF EXISTS sku_id;
+CREATE UNIQUE INDEX idx_stock_alerts_active_product ON stock_alerts(product_id) WHERE status = 'active';
@@ -0,0 +1,5 @@
+-- Low-stock alerts belong to the inventory row that ran low: the product-level row or one SKU's
+ALTER TABLE stock_alerts
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE CASCADE;
+DROP INDEX IF EXISTS idx_stock_alerts_active_product;
+CREATE UNIQUE INDEX idx_stock_alerts_active_unit ON stock_alerts(product_id, sku_id) NULLS NOT DISTINCT WHERE status = 'active';
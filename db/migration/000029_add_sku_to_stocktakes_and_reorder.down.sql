@@ -0,0 +1,9 @@
+ALTER TABLE reorder_suggestions DROP CONSTRAINT IF EXISTS unique_reorder_suggestion_product_sku;
+DELETE FROM reorder_suggestions WHERE sku_id IS NOT NULL;
+ALTER TABLE reorder_suggestions DROP COLUMN IF EXISTS sku_id;
+ALTER TABLE reorder_suggestions ADD PRIMARY KEY (product_id);
+
+ALTER TABLE stocktake_items DROP CONSTRAINT IF EXISTS unique_stocktake_product_sku;
+DELETE FROM stocktake_items WHERE sku_id IS NOT NULL;
+ALTER TABLE stocktake_items DROP COLUMN IF EXISTS sku_id;
+ALTER TABLE stocktake_items ADD CONSTRAINT unique_stocktake_product UNIQUE (stocktake_id, product_id);
@@ -0,0 +1,13 @@
+-- Stocktakes and reorder suggestions cover every inventory row: the product-level row of a product
+-- without variants or one row per SKU. sku_id is NULL for the product-level row.
+ALTER TABLE stocktake_items
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE RESTRICT;
+ALTER TABLE stocktake_items DROP CONSTRAINT IF EXISTS unique_stocktake_product;
+ALTER TABLE stocktake_items ADD CONSTRAINT unique_stocktake_product_sku
+    UNIQUE NULLS NOT DISTINCT (stocktake_id, product_id, sku_id);
+
+ALTER TABLE reorder_suggestions
+    ADD COLUMN sku_id BIGINT REFERENCES product_skus(id) ON DELETE CASCADE;
+ALTER TABLE reorder_suggestions DROP CONSTRAINT IF EXISTS reorder_suggestions_pkey;
+ALTER TABLE reorder_suggestions ADD CONSTRAINT unique_reorder_suggestion_product_sku
+    UNIQUE NULLS NOT DISTINCT (product_id, sku_id);
@@ -13,6 +13,7 @@ import (
 	context "context"
 	sqlc "gomall/db/sqlc"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -42,747 +43,3649 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// ActivateOrderReservations mocks base method.
+func (m *MockStore) ActivateOrderReservations(ctx context.Context, arg sqlc.ActivateOrderReservationsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateOrderReservations", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateOrderReservations indicates an expected call of ActivateOrderReservations.
+func (mr *MockStoreMockRecorder) ActivateOrderReservations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateOrderReservations", reflect.TypeOf((*MockStore)(nil).ActivateOrderReservations), ctx, arg)
+}
+
+// AddAvailableStock mocks base method.
+func (m *MockStore) AddAvailableStock(ctx context.Context, arg sqlc.AddAvailableStockParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAvailableStock", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAvailableStock indicates an expected call of AddAvailableStock.
+func (mr *MockStoreMockRecorder) AddAvailableStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAvailableStock", reflect.TypeOf((*MockStore)(nil).AddAvailableStock), ctx, arg)
+}
+
+// AddBackorderedStock mocks base method.
+func (m *MockStore) AddBackorderedStock(ctx context.Context, arg sqlc.AddBackorderedStockParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBackorderedStock", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBackorderedStock indicates an expected call of AddBackorderedStock.
+func (mr *MockStoreMockRecorder) AddBackorderedStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBackorderedStock", reflect.TypeOf((*MockStore)(nil).AddBackorderedStock), ctx, arg)
+}
+
+// AddProductAnswerUpvoteCount mocks base method.
+func (m *MockStore) AddProductAnswerUpvoteCount(ctx context.Context, arg sqlc.AddProductAnswerUpvoteCountParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductAnswerUpvoteCount", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProductAnswerUpvoteCount indicates an expected call of AddProductAnswerUpvoteCount.
+func (mr *MockStoreMockRecorder) AddProductAnswerUpvoteCount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductAnswerUpvoteCount", reflect.TypeOf((*MockStore)(nil).AddProductAnswerUpvoteCount), ctx, arg)
+}
+
+// AddProductReviewHelpfulCount mocks base method.
+func (m *MockStore) AddProductReviewHelpfulCount(ctx context.Context, arg sqlc.AddProductReviewHelpfulCountParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductReviewHelpfulCount", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProductReviewHelpfulCount indicates an expected call of AddProductReviewHelpfulCount.
+func (mr *MockStoreMockRecorder) AddProductReviewHelpfulCount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductReviewHelpfulCount", reflect.TypeOf((*MockStore)(nil).AddProductReviewHelpfulCount), ctx, arg)
+}
+
+// AddProductViews mocks base method.
+func (m *MockStore) AddProductViews(ctx context.Context, arg sqlc.AddProductViewsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductViews", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProductViews indicates an expected call of AddProductViews.
+func (mr *MockStoreMockRecorder) AddProductViews(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductViews", reflect.TypeOf((*MockStore)(nil).AddProductViews), ctx, arg)
+}
+
+// AddStocktakeItemsForCategory mocks base method.
+func (m *MockStore) AddStocktakeItemsForCategory(ctx context.Context, arg sqlc.AddStocktakeItemsForCategoryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStocktakeItemsForCategory", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStocktakeItemsForCategory indicates an expected call of AddStocktakeItemsForCategory.
+func (mr *MockStoreMockRecorder) AddStocktakeItemsForCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStocktakeItemsForCategory", reflect.TypeOf((*MockStore)(nil).AddStocktakeItemsForCategory), ctx, arg)
+}
+
+// AddStocktakeItemsForProducts mocks base method.
+func (m *MockStore) AddStocktakeItemsForProducts(ctx context.Context, arg sqlc.AddStocktakeItemsForProductsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStocktakeItemsForProducts", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStocktakeItemsForProducts indicates an expected call of AddStocktakeItemsForProducts.
+func (mr *MockStoreMockRecorder) AddStocktakeItemsForProducts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStocktakeItemsForProducts", reflect.TypeOf((*MockStore)(nil).AddStocktakeItemsForProducts), ctx, arg)
+}
+
 // AddToCart mocks base method.
 func (m *MockStore) AddToCart(ctx context.Context, arg sqlc.AddToCartParams) (sqlc.Cart, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddToCart", ctx, arg)
-	ret0, _ := ret[0].(sqlc.Cart)
+	ret := m.ctrl.Call(m, "AddToCart", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToCart indicates an expected call of AddToCart.
+func (mr *MockStoreMockRecorder) AddToCart(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockStore)(nil).AddToCart), ctx, arg)
+}
+
+// AllocateBackorderedStock mocks base method.
+func (m *MockStore) AllocateBackorderedStock(ctx context.Context, arg sqlc.AllocateBackorderedStockParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateBackorderedStock", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateBackorderedStock indicates an expected call of AllocateBackorderedStock.
+func (mr *MockStoreMockRecorder) AllocateBackorderedStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateBackorderedStock", reflect.TypeOf((*MockStore)(nil).AllocateBackorderedStock), ctx, arg)
+}
+
+// ApplyReorderPointsToCategory mocks base method.
+func (m *MockStore) ApplyReorderPointsToCategory(ctx context.Context, categoryID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyReorderPointsToCategory", ctx, categoryID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyReorderPointsToCategory indicates an expected call of ApplyReorderPointsToCategory.
+func (mr *MockStoreMockRecorder) ApplyReorderPointsToCategory(ctx, categoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyReorderPointsToCategory", reflect.TypeOf((*MockStore)(nil).ApplyReorderPointsToCategory), ctx, categoryID)
+}
+
+// ApproveStocktake mocks base method.
+func (m *MockStore) ApproveStocktake(ctx context.Context, arg sqlc.ApproveStocktakeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveStocktake", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveStocktake indicates an expected call of ApproveStocktake.
+func (mr *MockStoreMockRecorder) ApproveStocktake(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveStocktake", reflect.TypeOf((*MockStore)(nil).ApproveStocktake), ctx, arg)
+}
+
+// BlockSession mocks base method.
+func (m *MockStore) BlockSession(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockSession indicates an expected call of BlockSession.
+func (mr *MockStoreMockRecorder) BlockSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSession", reflect.TypeOf((*MockStore)(nil).BlockSession), ctx, id)
+}
+
+// CancelOrder mocks base method.
+func (m *MockStore) CancelOrder(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockStoreMockRecorder) CancelOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockStore)(nil).CancelOrder), ctx, id)
+}
+
+// CancelOrderBackorders mocks base method.
+func (m *MockStore) CancelOrderBackorders(ctx context.Context, orderID int64) ([]sqlc.InventoryBackorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrderBackorders", ctx, orderID)
+	ret0, _ := ret[0].([]sqlc.InventoryBackorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrderBackorders indicates an expected call of CancelOrderBackorders.
+func (mr *MockStoreMockRecorder) CancelOrderBackorders(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrderBackorders", reflect.TypeOf((*MockStore)(nil).CancelOrderBackorders), ctx, orderID)
+}
+
+// CancelReservation mocks base method.
+func (m *MockStore) CancelReservation(ctx context.Context, orderID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReservation", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelReservation indicates an expected call of CancelReservation.
+func (mr *MockStoreMockRecorder) CancelReservation(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReservation", reflect.TypeOf((*MockStore)(nil).CancelReservation), ctx, orderID)
+}
+
+// CancelStocktake mocks base method.
+func (m *MockStore) CancelStocktake(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelStocktake", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelStocktake indicates an expected call of CancelStocktake.
+func (mr *MockStoreMockRecorder) CancelStocktake(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelStocktake", reflect.TypeOf((*MockStore)(nil).CancelStocktake), ctx, id)
+}
+
+// CleanExpiredSessions mocks base method.
+func (m *MockStore) CleanExpiredSessions(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanExpiredSessions", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanExpiredSessions indicates an expected call of CleanExpiredSessions.
+func (mr *MockStoreMockRecorder) CleanExpiredSessions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanExpiredSessions", reflect.TypeOf((*MockStore)(nil).CleanExpiredSessions), ctx)
+}
+
+// ClearCart mocks base method.
+func (m *MockStore) ClearCart(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCart", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockStoreMockRecorder) ClearCart(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockStore)(nil).ClearCart), ctx, userID)
+}
+
+// ConfirmReservation mocks base method.
+func (m *MockStore) ConfirmReservation(ctx context.Context, orderID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmReservation", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmReservation indicates an expected call of ConfirmReservation.
+func (mr *MockStoreMockRecorder) ConfirmReservation(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmReservation", reflect.TypeOf((*MockStore)(nil).ConfirmReservation), ctx, orderID)
+}
+
+// CountActiveStockAlerts mocks base method.
+func (m *MockStore) CountActiveStockAlerts(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveStockAlerts", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveStockAlerts indicates an expected call of CountActiveStockAlerts.
+func (mr *MockStoreMockRecorder) CountActiveStockAlerts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveStockAlerts", reflect.TypeOf((*MockStore)(nil).CountActiveStockAlerts), ctx)
+}
+
+// CountBackorders mocks base method.
+func (m *MockStore) CountBackorders(ctx context.Context, arg sqlc.CountBackordersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBackorders", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBackorders indicates an expected call of CountBackorders.
+func (mr *MockStoreMockRecorder) CountBackorders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBackorders", reflect.TypeOf((*MockStore)(nil).CountBackorders), ctx, arg)
+}
+
+// CountCartItems mocks base method.
+func (m *MockStore) CountCartItems(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCartItems", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCartItems indicates an expected call of CountCartItems.
+func (mr *MockStoreMockRecorder) CountCartItems(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCartItems", reflect.TypeOf((*MockStore)(nil).CountCartItems), ctx, userID)
+}
+
+// CountCategoryChildren mocks base method.
+func (m *MockStore) CountCategoryChildren(ctx context.Context, parentID *int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCategoryChildren", ctx, parentID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCategoryChildren indicates an expected call of CountCategoryChildren.
+func (mr *MockStoreMockRecorder) CountCategoryChildren(ctx, parentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCategoryChildren", reflect.TypeOf((*MockStore)(nil).CountCategoryChildren), ctx, parentID)
+}
+
+// CountDuplicateReviews mocks base method.
+func (m *MockStore) CountDuplicateReviews(ctx context.Context, arg sqlc.CountDuplicateReviewsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDuplicateReviews", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDuplicateReviews indicates an expected call of CountDuplicateReviews.
+func (mr *MockStoreMockRecorder) CountDuplicateReviews(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDuplicateReviews", reflect.TypeOf((*MockStore)(nil).CountDuplicateReviews), ctx, arg)
+}
+
+// CountInventories mocks base method.
+func (m *MockStore) CountInventories(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountInventories", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInventories indicates an expected call of CountInventories.
+func (mr *MockStoreMockRecorder) CountInventories(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInventories", reflect.TypeOf((*MockStore)(nil).CountInventories), ctx)
+}
+
+// CountInventoryLogsByProductID mocks base method.
+func (m *MockStore) CountInventoryLogsByProductID(ctx context.Context, productID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountInventoryLogsByProductID", ctx, productID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInventoryLogsByProductID indicates an expected call of CountInventoryLogsByProductID.
+func (mr *MockStoreMockRecorder) CountInventoryLogsByProductID(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInventoryLogsByProductID", reflect.TypeOf((*MockStore)(nil).CountInventoryLogsByProductID), ctx, productID)
+}
+
+// CountInventoryMovementReport mocks base method.
+func (m *MockStore) CountInventoryMovementReport(ctx context.Context, arg sqlc.CountInventoryMovementReportParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountInventoryMovementReport", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInventoryMovementReport indicates an expected call of CountInventoryMovementReport.
+func (mr *MockStoreMockRecorder) CountInventoryMovementReport(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInventoryMovementReport", reflect.TypeOf((*MockStore)(nil).CountInventoryMovementReport), ctx, arg)
+}
+
+// CountLowStockInventories mocks base method.
+func (m *MockStore) CountLowStockInventories(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLowStockInventories", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLowStockInventories indicates an expected call of CountLowStockInventories.
+func (mr *MockStoreMockRecorder) CountLowStockInventories(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLowStockInventories", reflect.TypeOf((*MockStore)(nil).CountLowStockInventories), ctx)
+}
+
+// CountOutOfStockAlerts mocks base method.
+func (m *MockStore) CountOutOfStockAlerts(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOutOfStockAlerts", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOutOfStockAlerts indicates an expected call of CountOutOfStockAlerts.
+func (mr *MockStoreMockRecorder) CountOutOfStockAlerts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOutOfStockAlerts", reflect.TypeOf((*MockStore)(nil).CountOutOfStockAlerts), ctx)
+}
+
+// CountOutstandingPurchaseOrderItems mocks base method.
+func (m *MockStore) CountOutstandingPurchaseOrderItems(ctx context.Context, purchaseOrderID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOutstandingPurchaseOrderItems", ctx, purchaseOrderID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOutstandingPurchaseOrderItems indicates an expected call of CountOutstandingPurchaseOrderItems.
+func (mr *MockStoreMockRecorder) CountOutstandingPurchaseOrderItems(ctx, purchaseOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOutstandingPurchaseOrderItems", reflect.TypeOf((*MockStore)(nil).CountOutstandingPurchaseOrderItems), ctx, purchaseOrderID)
+}
+
+// CountProductAnswers mocks base method.
+func (m *MockStore) CountProductAnswers(ctx context.Context, questionID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductAnswers", ctx, questionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductAnswers indicates an expected call of CountProductAnswers.
+func (mr *MockStoreMockRecorder) CountProductAnswers(ctx, questionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductAnswers", reflect.TypeOf((*MockStore)(nil).CountProductAnswers), ctx, questionID)
+}
+
+// CountProductIndex mocks base method.
+func (m *MockStore) CountProductIndex(ctx context.Context, arg sqlc.CountProductIndexParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductIndex", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductIndex indicates an expected call of CountProductIndex.
+func (mr *MockStoreMockRecorder) CountProductIndex(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductIndex", reflect.TypeOf((*MockStore)(nil).CountProductIndex), ctx, arg)
+}
+
+// CountProductQuestions mocks base method.
+func (m *MockStore) CountProductQuestions(ctx context.Context, productID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductQuestions", ctx, productID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductQuestions indicates an expected call of CountProductQuestions.
+func (mr *MockStoreMockRecorder) CountProductQuestions(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductQuestions", reflect.TypeOf((*MockStore)(nil).CountProductQuestions), ctx, productID)
+}
+
+// CountProductReviews mocks base method.
+func (m *MockStore) CountProductReviews(ctx context.Context, productID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductReviews", ctx, productID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductReviews indicates an expected call of CountProductReviews.
+func (mr *MockStoreMockRecorder) CountProductReviews(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductReviews", reflect.TypeOf((*MockStore)(nil).CountProductReviews), ctx, productID)
+}
+
+// CountProductSkus mocks base method.
+func (m *MockStore) CountProductSkus(ctx context.Context, productID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductSkus", ctx, productID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductSkus indicates an expected call of CountProductSkus.
+func (mr *MockStoreMockRecorder) CountProductSkus(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductSkus", reflect.TypeOf((*MockStore)(nil).CountProductSkus), ctx, productID)
+}
+
+// CountProductsByCategory mocks base method.
+func (m *MockStore) CountProductsByCategory(ctx context.Context, categoryID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsByCategory", ctx, categoryID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsByCategory indicates an expected call of CountProductsByCategory.
+func (mr *MockStoreMockRecorder) CountProductsByCategory(ctx, categoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsByCategory", reflect.TypeOf((*MockStore)(nil).CountProductsByCategory), ctx, categoryID)
+}
+
+// CountPurchaseOrders mocks base method.
+func (m *MockStore) CountPurchaseOrders(ctx context.Context, arg sqlc.CountPurchaseOrdersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPurchaseOrders", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPurchaseOrders indicates an expected call of CountPurchaseOrders.
+func (mr *MockStoreMockRecorder) CountPurchaseOrders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPurchaseOrders", reflect.TypeOf((*MockStore)(nil).CountPurchaseOrders), ctx, arg)
+}
+
+// CountRecentReviewsByUser mocks base method.
+func (m *MockStore) CountRecentReviewsByUser(ctx context.Context, arg sqlc.CountRecentReviewsByUserParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecentReviewsByUser", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecentReviewsByUser indicates an expected call of CountRecentReviewsByUser.
+func (mr *MockStoreMockRecorder) CountRecentReviewsByUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecentReviewsByUser", reflect.TypeOf((*MockStore)(nil).CountRecentReviewsByUser), ctx, arg)
+}
+
+// CountReorderSuggestions mocks base method.
+func (m *MockStore) CountReorderSuggestions(ctx context.Context, arg sqlc.CountReorderSuggestionsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReorderSuggestions", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReorderSuggestions indicates an expected call of CountReorderSuggestions.
+func (mr *MockStoreMockRecorder) CountReorderSuggestions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReorderSuggestions", reflect.TypeOf((*MockStore)(nil).CountReorderSuggestions), ctx, arg)
+}
+
+// CountResolvedStockAlertsSince mocks base method.
+func (m *MockStore) CountResolvedStockAlertsSince(ctx context.Context, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountResolvedStockAlertsSince", ctx, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountResolvedStockAlertsSince indicates an expected call of CountResolvedStockAlertsSince.
+func (mr *MockStoreMockRecorder) CountResolvedStockAlertsSince(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountResolvedStockAlertsSince", reflect.TypeOf((*MockStore)(nil).CountResolvedStockAlertsSince), ctx, since)
+}
+
+// CountReviewsByStatus mocks base method.
+func (m *MockStore) CountReviewsByStatus(ctx context.Context, status string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReviewsByStatus", ctx, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReviewsByStatus indicates an expected call of CountReviewsByStatus.
+func (mr *MockStoreMockRecorder) CountReviewsByStatus(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReviewsByStatus", reflect.TypeOf((*MockStore)(nil).CountReviewsByStatus), ctx, status)
+}
+
+// CountStocktakes mocks base method.
+func (m *MockStore) CountStocktakes(ctx context.Context, status *string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStocktakes", ctx, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStocktakes indicates an expected call of CountStocktakes.
+func (mr *MockStoreMockRecorder) CountStocktakes(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStocktakes", reflect.TypeOf((*MockStore)(nil).CountStocktakes), ctx, status)
+}
+
+// CountSuppliers mocks base method.
+func (m *MockStore) CountSuppliers(ctx context.Context, isActive *bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSuppliers", ctx, isActive)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSuppliers indicates an expected call of CountSuppliers.
+func (mr *MockStoreMockRecorder) CountSuppliers(ctx, isActive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSuppliers", reflect.TypeOf((*MockStore)(nil).CountSuppliers), ctx, isActive)
+}
+
+// CountUncountedStocktakeItems mocks base method.
+func (m *MockStore) CountUncountedStocktakeItems(ctx context.Context, stocktakeID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUncountedStocktakeItems", ctx, stocktakeID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUncountedStocktakeItems indicates an expected call of CountUncountedStocktakeItems.
+func (mr *MockStoreMockRecorder) CountUncountedStocktakeItems(ctx, stocktakeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUncountedStocktakeItems", reflect.TypeOf((*MockStore)(nil).CountUncountedStocktakeItems), ctx, stocktakeID)
+}
+
+// CountUserOrders mocks base method.
+func (m *MockStore) CountUserOrders(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserOrders", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserOrders indicates an expected call of CountUserOrders.
+func (mr *MockStoreMockRecorder) CountUserOrders(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserOrders", reflect.TypeOf((*MockStore)(nil).CountUserOrders), ctx, userID)
+}
+
+// CountUsers mocks base method.
+func (m *MockStore) CountUsers(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockStoreMockRecorder) CountUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockStore)(nil).CountUsers), ctx)
+}
+
+// CreateBackorder mocks base method.
+func (m *MockStore) CreateBackorder(ctx context.Context, arg sqlc.CreateBackorderParams) (sqlc.InventoryBackorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBackorder", ctx, arg)
+	ret0, _ := ret[0].(sqlc.InventoryBackorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBackorder indicates an expected call of CreateBackorder.
+func (mr *MockStoreMockRecorder) CreateBackorder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBackorder", reflect.TypeOf((*MockStore)(nil).CreateBackorder), ctx, arg)
+}
+
+// CreateCategory mocks base method.
+func (m *MockStore) CreateCategory(ctx context.Context, arg sqlc.CreateCategoryParams) (sqlc.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockStoreMockRecorder) CreateCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), ctx, arg)
+}
+
+// CreateImportJob mocks base method.
+func (m *MockStore) CreateImportJob(ctx context.Context, arg sqlc.CreateImportJobParams) (sqlc.InventoryImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportJob", ctx, arg)
+	ret0, _ := ret[0].(sqlc.InventoryImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImportJob indicates an expected call of CreateImportJob.
+func (mr *MockStoreMockRecorder) CreateImportJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportJob", reflect.TypeOf((*MockStore)(nil).CreateImportJob), ctx, arg)
+}
+
+// CreateInventory mocks base method.
+func (m *MockStore) CreateInventory(ctx context.Context, arg sqlc.CreateInventoryParams) (sqlc.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInventory", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInventory indicates an expected call of CreateInventory.
+func (mr *MockStoreMockRecorder) CreateInventory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInventory", reflect.TypeOf((*MockStore)(nil).CreateInventory), ctx, arg)
+}
+
+// CreateInventoryLog mocks base method.
+func (m *MockStore) CreateInventoryLog(ctx context.Context, arg sqlc.CreateInventoryLogParams) (sqlc.InventoryLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInventoryLog", ctx, arg)
+	ret0, _ := ret[0].(sqlc.InventoryLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInventoryLog indicates an expected call of CreateInventoryLog.
+func (mr *MockStoreMockRecorder) CreateInventoryLog(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInventoryLog", reflect.TypeOf((*MockStore)(nil).CreateInventoryLog), ctx, arg)
+}
+
+// CreateInventoryReservation mocks base method.
+func (m *MockStore) CreateInventoryReservation(ctx context.Context, arg sqlc.CreateInventoryReservationParams) (sqlc.InventoryReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInventoryReservation", ctx, arg)
+	ret0, _ := ret[0].(sqlc.InventoryReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInventoryReservation indicates an expected call of CreateInventoryReservation.
+func (mr *MockStoreMockRecorder) CreateInventoryReservation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInventoryReservation", reflect.TypeOf((*MockStore)(nil).CreateInventoryReservation), ctx, arg)
+}
+
+// CreateOrder mocks base method.
+func (m *MockStore) CreateOrder(ctx context.Context, arg sqlc.CreateOrderParams) (sqlc.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockStoreMockRecorder) CreateOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockStore)(nil).CreateOrder), ctx, arg)
+}
+
+// CreateOrderItem mocks base method.
+func (m *MockStore) CreateOrderItem(ctx context.Context, arg sqlc.CreateOrderItemParams) (sqlc.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderItem", ctx, arg)
+	ret0, _ := ret[0].(sqlc.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderItem indicates an expected call of CreateOrderItem.
+func (mr *MockStoreMockRecorder) CreateOrderItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItem", reflect.TypeOf((*MockStore)(nil).CreateOrderItem), ctx, arg)
+}
+
+// CreateProduct mocks base method.
+func (m *MockStore) CreateProduct(ctx context.Context, arg sqlc.CreateProductParams) (sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockStoreMockRecorder) CreateProduct(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), ctx, arg)
+}
+
+// CreateProductAnswer mocks base method.
+func (m *MockStore) CreateProductAnswer(ctx context.Context, arg sqlc.CreateProductAnswerParams) (sqlc.ProductAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductAnswer", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductAnswer indicates an expected call of CreateProductAnswer.
+func (mr *MockStoreMockRecorder) CreateProductAnswer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductAnswer", reflect.TypeOf((*MockStore)(nil).CreateProductAnswer), ctx, arg)
+}
+
+// CreateProductAnswerVote mocks base method.
+func (m *MockStore) CreateProductAnswerVote(ctx context.Context, arg sqlc.CreateProductAnswerVoteParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductAnswerVote", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductAnswerVote indicates an expected call of CreateProductAnswerVote.
+func (mr *MockStoreMockRecorder) CreateProductAnswerVote(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductAnswerVote", reflect.TypeOf((*MockStore)(nil).CreateProductAnswerVote), ctx, arg)
+}
+
+// CreateProductImage mocks base method.
+func (m *MockStore) CreateProductImage(ctx context.Context, arg sqlc.CreateProductImageParams) (sqlc.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductImage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductImage indicates an expected call of CreateProductImage.
+func (mr *MockStoreMockRecorder) CreateProductImage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductImage", reflect.TypeOf((*MockStore)(nil).CreateProductImage), ctx, arg)
+}
+
+// CreateProductOption mocks base method.
+func (m *MockStore) CreateProductOption(ctx context.Context, arg sqlc.CreateProductOptionParams) (sqlc.ProductOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductOption", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductOption indicates an expected call of CreateProductOption.
+func (mr *MockStoreMockRecorder) CreateProductOption(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductOption", reflect.TypeOf((*MockStore)(nil).CreateProductOption), ctx, arg)
+}
+
+// CreateProductOptionValue mocks base method.
+func (m *MockStore) CreateProductOptionValue(ctx context.Context, arg sqlc.CreateProductOptionValueParams) (sqlc.ProductOptionValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductOptionValue", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductOptionValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductOptionValue indicates an expected call of CreateProductOptionValue.
+func (mr *MockStoreMockRecorder) CreateProductOptionValue(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductOptionValue", reflect.TypeOf((*MockStore)(nil).CreateProductOptionValue), ctx, arg)
+}
+
+// CreateProductQuestion mocks base method.
+func (m *MockStore) CreateProductQuestion(ctx context.Context, arg sqlc.CreateProductQuestionParams) (sqlc.ProductQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductQuestion", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductQuestion indicates an expected call of CreateProductQuestion.
+func (mr *MockStoreMockRecorder) CreateProductQuestion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductQuestion", reflect.TypeOf((*MockStore)(nil).CreateProductQuestion), ctx, arg)
+}
+
+// CreateProductReview mocks base method.
+func (m *MockStore) CreateProductReview(ctx context.Context, arg sqlc.CreateProductReviewParams) (sqlc.ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductReview", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductReview indicates an expected call of CreateProductReview.
+func (mr *MockStoreMockRecorder) CreateProductReview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductReview", reflect.TypeOf((*MockStore)(nil).CreateProductReview), ctx, arg)
+}
+
+// CreateProductReviewVote mocks base method.
+func (m *MockStore) CreateProductReviewVote(ctx context.Context, arg sqlc.CreateProductReviewVoteParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductReviewVote", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductReviewVote indicates an expected call of CreateProductReviewVote.
+func (mr *MockStoreMockRecorder) CreateProductReviewVote(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductReviewVote", reflect.TypeOf((*MockStore)(nil).CreateProductReviewVote), ctx, arg)
+}
+
+// CreateProductSku mocks base method.
+func (m *MockStore) CreateProductSku(ctx context.Context, arg sqlc.CreateProductSkuParams) (sqlc.ProductSku, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductSku", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductSku)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductSku indicates an expected call of CreateProductSku.
+func (mr *MockStoreMockRecorder) CreateProductSku(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductSku", reflect.TypeOf((*MockStore)(nil).CreateProductSku), ctx, arg)
+}
+
+// CreatePurchaseOrder mocks base method.
+func (m *MockStore) CreatePurchaseOrder(ctx context.Context, arg sqlc.CreatePurchaseOrderParams) (sqlc.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrder", ctx, arg)
+	ret0, _ := ret[0].(sqlc.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrder indicates an expected call of CreatePurchaseOrder.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrder", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrder), ctx, arg)
+}
+
+// CreatePurchaseOrderItem mocks base method.
+func (m *MockStore) CreatePurchaseOrderItem(ctx context.Context, arg sqlc.CreatePurchaseOrderItemParams) (sqlc.PurchaseOrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderItem", ctx, arg)
+	ret0, _ := ret[0].(sqlc.PurchaseOrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrderItem indicates an expected call of CreatePurchaseOrderItem.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrderItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderItem", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrderItem), ctx, arg)
+}
+
+// CreatePurchaseReceipt mocks base method.
+func (m *MockStore) CreatePurchaseReceipt(ctx context.Context, arg sqlc.CreatePurchaseReceiptParams) (sqlc.PurchaseReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseReceipt", ctx, arg)
+	ret0, _ := ret[0].(sqlc.PurchaseReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseReceipt indicates an expected call of CreatePurchaseReceipt.
+func (mr *MockStoreMockRecorder) CreatePurchaseReceipt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseReceipt", reflect.TypeOf((*MockStore)(nil).CreatePurchaseReceipt), ctx, arg)
+}
+
+// CreateReviewModerationLog mocks base method.
+func (m *MockStore) CreateReviewModerationLog(ctx context.Context, arg sqlc.CreateReviewModerationLogParams) (sqlc.ProductReviewModerationLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReviewModerationLog", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductReviewModerationLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReviewModerationLog indicates an expected call of CreateReviewModerationLog.
+func (mr *MockStoreMockRecorder) CreateReviewModerationLog(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReviewModerationLog", reflect.TypeOf((*MockStore)(nil).CreateReviewModerationLog), ctx, arg)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(ctx context.Context, arg sqlc.CreateSessionParams) (sqlc.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+}
+
+// CreateStockAlert mocks base method.
+func (m *MockStore) CreateStockAlert(ctx context.Context, arg sqlc.CreateStockAlertParams) (sqlc.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockAlert", ctx, arg)
+	ret0, _ := ret[0].(sqlc.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockAlert indicates an expected call of CreateStockAlert.
+func (mr *MockStoreMockRecorder) CreateStockAlert(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockAlert", reflect.TypeOf((*MockStore)(nil).CreateStockAlert), ctx, arg)
+}
+
+// CreateStocktake mocks base method.
+func (m *MockStore) CreateStocktake(ctx context.Context, arg sqlc.CreateStocktakeParams) (sqlc.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStocktake", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStocktake indicates an expected call of CreateStocktake.
+func (mr *MockStoreMockRecorder) CreateStocktake(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStocktake", reflect.TypeOf((*MockStore)(nil).CreateStocktake), ctx, arg)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockStore) CreateSupplier(ctx context.Context, arg sqlc.CreateSupplierParams) (sqlc.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockStoreMockRecorder) CreateSupplier(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockStore)(nil).CreateSupplier), ctx, arg)
+}
+
+// CreateUploadedProductImage mocks base method.
+func (m *MockStore) CreateUploadedProductImage(ctx context.Context, arg sqlc.CreateUploadedProductImageParams) (sqlc.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUploadedProductImage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUploadedProductImage indicates an expected call of CreateUploadedProductImage.
+func (mr *MockStoreMockRecorder) CreateUploadedProductImage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUploadedProductImage", reflect.TypeOf((*MockStore)(nil).CreateUploadedProductImage), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+}
+
+// CreateVerificationCode mocks base method.
+func (m *MockStore) CreateVerificationCode(ctx context.Context, arg sqlc.CreateVerificationCodeParams) (sqlc.VerificationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVerificationCode", ctx, arg)
+	ret0, _ := ret[0].(sqlc.VerificationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVerificationCode indicates an expected call of CreateVerificationCode.
+func (mr *MockStoreMockRecorder) CreateVerificationCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerificationCode", reflect.TypeOf((*MockStore)(nil).CreateVerificationCode), ctx, arg)
+}
+
+// DecrementProductStock mocks base method.
+func (m *MockStore) DecrementProductStock(ctx context.Context, arg sqlc.DecrementProductStockParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementProductStock", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrementProductStock indicates an expected call of DecrementProductStock.
+func (mr *MockStoreMockRecorder) DecrementProductStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementProductStock", reflect.TypeOf((*MockStore)(nil).DecrementProductStock), ctx, arg)
+}
+
+// DeductReservedStock mocks base method.
+func (m *MockStore) DeductReservedStock(ctx context.Context, arg sqlc.DeductReservedStockParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeductReservedStock", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeductReservedStock indicates an expected call of DeductReservedStock.
+func (mr *MockStoreMockRecorder) DeductReservedStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeductReservedStock", reflect.TypeOf((*MockStore)(nil).DeductReservedStock), ctx, arg)
+}
+
+// DeleteCartItem mocks base method.
+func (m *MockStore) DeleteCartItem(ctx context.Context, arg sqlc.DeleteCartItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCartItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCartItem indicates an expected call of DeleteCartItem.
+func (mr *MockStoreMockRecorder) DeleteCartItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartItem", reflect.TypeOf((*MockStore)(nil).DeleteCartItem), ctx, arg)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockStore) DeleteCategory(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockStoreMockRecorder) DeleteCategory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), ctx, id)
+}
+
+// DeleteExpiredCodes mocks base method.
+func (m *MockStore) DeleteExpiredCodes(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredCodes", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredCodes indicates an expected call of DeleteExpiredCodes.
+func (mr *MockStoreMockRecorder) DeleteExpiredCodes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredCodes", reflect.TypeOf((*MockStore)(nil).DeleteExpiredCodes), ctx)
+}
+
+// DeleteInventory mocks base method.
+func (m *MockStore) DeleteInventory(ctx context.Context, productID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInventory", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInventory indicates an expected call of DeleteInventory.
+func (mr *MockStoreMockRecorder) DeleteInventory(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInventory", reflect.TypeOf((*MockStore)(nil).DeleteInventory), ctx, productID)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockStore) DeleteProduct(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockStoreMockRecorder) DeleteProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockStore)(nil).DeleteProduct), ctx, id)
+}
+
+// DeleteProductAnswerVote mocks base method.
+func (m *MockStore) DeleteProductAnswerVote(ctx context.Context, arg sqlc.DeleteProductAnswerVoteParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductAnswerVote", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductAnswerVote indicates an expected call of DeleteProductAnswerVote.
+func (mr *MockStoreMockRecorder) DeleteProductAnswerVote(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductAnswerVote", reflect.TypeOf((*MockStore)(nil).DeleteProductAnswerVote), ctx, arg)
+}
+
+// DeleteProductAssociations mocks base method.
+func (m *MockStore) DeleteProductAssociations(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductAssociations", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductAssociations indicates an expected call of DeleteProductAssociations.
+func (mr *MockStoreMockRecorder) DeleteProductAssociations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductAssociations", reflect.TypeOf((*MockStore)(nil).DeleteProductAssociations), ctx)
+}
+
+// DeleteProductImage mocks base method.
+func (m *MockStore) DeleteProductImage(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductImage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductImage indicates an expected call of DeleteProductImage.
+func (mr *MockStoreMockRecorder) DeleteProductImage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductImage", reflect.TypeOf((*MockStore)(nil).DeleteProductImage), ctx, id)
+}
+
+// DeleteProductImages mocks base method.
+func (m *MockStore) DeleteProductImages(ctx context.Context, productID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductImages", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductImages indicates an expected call of DeleteProductImages.
+func (mr *MockStoreMockRecorder) DeleteProductImages(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductImages", reflect.TypeOf((*MockStore)(nil).DeleteProductImages), ctx, productID)
+}
+
+// DeleteProductOptions mocks base method.
+func (m *MockStore) DeleteProductOptions(ctx context.Context, productID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductOptions", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductOptions indicates an expected call of DeleteProductOptions.
+func (mr *MockStoreMockRecorder) DeleteProductOptions(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductOptions", reflect.TypeOf((*MockStore)(nil).DeleteProductOptions), ctx, productID)
+}
+
+// DeleteProductReviewVote mocks base method.
+func (m *MockStore) DeleteProductReviewVote(ctx context.Context, arg sqlc.DeleteProductReviewVoteParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductReviewVote", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductReviewVote indicates an expected call of DeleteProductReviewVote.
+func (mr *MockStoreMockRecorder) DeleteProductReviewVote(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductReviewVote", reflect.TypeOf((*MockStore)(nil).DeleteProductReviewVote), ctx, arg)
+}
+
+// DeleteProductSku mocks base method.
+func (m *MockStore) DeleteProductSku(ctx context.Context, arg sqlc.DeleteProductSkuParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductSku", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductSku indicates an expected call of DeleteProductSku.
+func (mr *MockStoreMockRecorder) DeleteProductSku(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductSku", reflect.TypeOf((*MockStore)(nil).DeleteProductSku), ctx, arg)
+}
+
+// DeleteReorderSetting mocks base method.
+func (m *MockStore) DeleteReorderSetting(ctx context.Context, categoryID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReorderSetting", ctx, categoryID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReorderSetting indicates an expected call of DeleteReorderSetting.
+func (mr *MockStoreMockRecorder) DeleteReorderSetting(ctx, categoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReorderSetting", reflect.TypeOf((*MockStore)(nil).DeleteReorderSetting), ctx, categoryID)
+}
+
+// DeleteReservation mocks base method.
+func (m *MockStore) DeleteReservation(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReservation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReservation indicates an expected call of DeleteReservation.
+func (mr *MockStoreMockRecorder) DeleteReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReservation", reflect.TypeOf((*MockStore)(nil).DeleteReservation), ctx, id)
+}
+
+// DeleteSession mocks base method.
+func (m *MockStore) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockStoreMockRecorder) DeleteSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), ctx, id)
+}
+
+// DeleteSkuInventory mocks base method.
+func (m *MockStore) DeleteSkuInventory(ctx context.Context, skuID *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSkuInventory", ctx, skuID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSkuInventory indicates an expected call of DeleteSkuInventory.
+func (mr *MockStoreMockRecorder) DeleteSkuInventory(ctx, skuID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSkuInventory", reflect.TypeOf((*MockStore)(nil).DeleteSkuInventory), ctx, skuID)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStore) DeleteUser(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStoreMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), ctx, id)
+}
+
+// DeleteUserSessions mocks base method.
+func (m *MockStore) DeleteUserSessions(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessions", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions.
+func (mr *MockStoreMockRecorder) DeleteUserSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockStore)(nil).DeleteUserSessions), ctx, userID)
+}
+
+// ExecTx mocks base method.
+func (m *MockStore) ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecTx indicates an expected call of ExecTx.
+func (mr *MockStoreMockRecorder) ExecTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTx", reflect.TypeOf((*MockStore)(nil).ExecTx), ctx, fn)
+}
+
+// FinishImportJob mocks base method.
+func (m *MockStore) FinishImportJob(ctx context.Context, arg sqlc.FinishImportJobParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishImportJob", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishImportJob indicates an expected call of FinishImportJob.
+func (mr *MockStoreMockRecorder) FinishImportJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishImportJob", reflect.TypeOf((*MockStore)(nil).FinishImportJob), ctx, arg)
+}
+
+// GetActiveReservationsByProductID mocks base method.
+func (m *MockStore) GetActiveReservationsByProductID(ctx context.Context, productID int64) ([]sqlc.InventoryReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveReservationsByProductID", ctx, productID)
+	ret0, _ := ret[0].([]sqlc.InventoryReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveReservationsByProductID indicates an expected call of GetActiveReservationsByProductID.
+func (mr *MockStoreMockRecorder) GetActiveReservationsByProductID(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveReservationsByProductID", reflect.TypeOf((*MockStore)(nil).GetActiveReservationsByProductID), ctx, productID)
+}
+
+// GetActiveStockAlert mocks base method.
+func (m *MockStore) GetActiveStockAlert(ctx context.Context, arg sqlc.GetActiveStockAlertParams) (sqlc.GetActiveStockAlertRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveStockAlert", ctx, arg)
+	ret0, _ := ret[0].(sqlc.GetActiveStockAlertRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveStockAlert indicates an expected call of GetActiveStockAlert.
+func (mr *MockStoreMockRecorder) GetActiveStockAlert(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveStockAlert", reflect.TypeOf((*MockStore)(nil).GetActiveStockAlert), ctx, arg)
+}
+
+// GetCartByUserID mocks base method.
+func (m *MockStore) GetCartByUserID(ctx context.Context, userID int64) ([]sqlc.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartByUserID", ctx, userID)
+	ret0, _ := ret[0].([]sqlc.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartByUserID indicates an expected call of GetCartByUserID.
+func (mr *MockStoreMockRecorder) GetCartByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByUserID", reflect.TypeOf((*MockStore)(nil).GetCartByUserID), ctx, userID)
+}
+
+// GetCartItem mocks base method.
+func (m *MockStore) GetCartItem(ctx context.Context, arg sqlc.GetCartItemParams) (sqlc.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartItem", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartItem indicates an expected call of GetCartItem.
+func (mr *MockStoreMockRecorder) GetCartItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItem", reflect.TypeOf((*MockStore)(nil).GetCartItem), ctx, arg)
+}
+
+// GetCartItemByProduct mocks base method.
+func (m *MockStore) GetCartItemByProduct(ctx context.Context, arg sqlc.GetCartItemByProductParams) (sqlc.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartItemByProduct", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartItemByProduct indicates an expected call of GetCartItemByProduct.
+func (mr *MockStoreMockRecorder) GetCartItemByProduct(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItemByProduct", reflect.TypeOf((*MockStore)(nil).GetCartItemByProduct), ctx, arg)
+}
+
+// GetCategoryByID mocks base method.
+func (m *MockStore) GetCategoryByID(ctx context.Context, id int64) (sqlc.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByID", ctx, id)
+	ret0, _ := ret[0].(sqlc.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByID indicates an expected call of GetCategoryByID.
+func (mr *MockStoreMockRecorder) GetCategoryByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockStore)(nil).GetCategoryByID), ctx, id)
+}
+
+// GetCategoryBySlug mocks base method.
+func (m *MockStore) GetCategoryBySlug(ctx context.Context, slug *string) (sqlc.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryBySlug", ctx, slug)
+	ret0, _ := ret[0].(sqlc.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryBySlug indicates an expected call of GetCategoryBySlug.
+func (mr *MockStoreMockRecorder) GetCategoryBySlug(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryBySlug", reflect.TypeOf((*MockStore)(nil).GetCategoryBySlug), ctx, slug)
+}
+
+// GetCategoryChildren mocks base method.
+func (m *MockStore) GetCategoryChildren(ctx context.Context, parentID *int64) ([]sqlc.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryChildren", ctx, parentID)
+	ret0, _ := ret[0].([]sqlc.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryChildren indicates an expected call of GetCategoryChildren.
+func (mr *MockStoreMockRecorder) GetCategoryChildren(ctx, parentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryChildren", reflect.TypeOf((*MockStore)(nil).GetCategoryChildren), ctx, parentID)
+}
+
+// GetConflictingProductSku mocks base method.
+func (m *MockStore) GetConflictingProductSku(ctx context.Context, arg sqlc.GetConflictingProductSkuParams) (sqlc.ProductSku, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConflictingProductSku", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductSku)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConflictingProductSku indicates an expected call of GetConflictingProductSku.
+func (mr *MockStoreMockRecorder) GetConflictingProductSku(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConflictingProductSku", reflect.TypeOf((*MockStore)(nil).GetConflictingProductSku), ctx, arg)
+}
+
+// GetExpiredReservations mocks base method.
+func (m *MockStore) GetExpiredReservations(ctx context.Context, limit int32) ([]sqlc.InventoryReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredReservations", ctx, limit)
+	ret0, _ := ret[0].([]sqlc.InventoryReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredReservations indicates an expected call of GetExpiredReservations.
+func (mr *MockStoreMockRecorder) GetExpiredReservations(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredReservations", reflect.TypeOf((*MockStore)(nil).GetExpiredReservations), ctx, limit)
+}
+
+// GetFirstInventoryLogAfter mocks base method.
+func (m *MockStore) GetFirstInventoryLogAfter(ctx context.Context, arg sqlc.GetFirstInventoryLogAfterParams) (sqlc.InventoryLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirstInventoryLogAfter", ctx, arg)
+	ret0, _ := ret[0].(sqlc.InventoryLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirstInventoryLogAfter indicates an expected call of GetFirstInventoryLogAfter.
+func (mr *MockStoreMockRecorder) GetFirstInventoryLogAfter(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirstInventoryLogAfter", reflect.TypeOf((*MockStore)(nil).GetFirstInventoryLogAfter), ctx, arg)
+}
+
+// GetHotProductActivity mocks base method.
+func (m *MockStore) GetHotProductActivity(ctx context.Context, arg sqlc.GetHotProductActivityParams) ([]sqlc.GetHotProductActivityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotProductActivity", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.GetHotProductActivityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotProductActivity indicates an expected call of GetHotProductActivity.
+func (mr *MockStoreMockRecorder) GetHotProductActivity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotProductActivity", reflect.TypeOf((*MockStore)(nil).GetHotProductActivity), ctx, arg)
+}
+
+// GetImagesByProductIDs mocks base method.
+func (m *MockStore) GetImagesByProductIDs(ctx context.Context, dollar_1 []int64) ([]sqlc.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImagesByProductIDs", ctx, dollar_1)
+	ret0, _ := ret[0].([]sqlc.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImagesByProductIDs indicates an expected call of GetImagesByProductIDs.
+func (mr *MockStoreMockRecorder) GetImagesByProductIDs(ctx, dollar_1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImagesByProductIDs", reflect.TypeOf((*MockStore)(nil).GetImagesByProductIDs), ctx, dollar_1)
+}
+
+// GetImportJob mocks base method.
+func (m *MockStore) GetImportJob(ctx context.Context, id int64) (sqlc.InventoryImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", ctx, id)
+	ret0, _ := ret[0].(sqlc.InventoryImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockStoreMockRecorder) GetImportJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockStore)(nil).GetImportJob), ctx, id)
+}
+
+// GetInventoriesByProductIDs mocks base method.
+func (m *MockStore) GetInventoriesByProductIDs(ctx context.Context, productIds []int64) ([]sqlc.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoriesByProductIDs", ctx, productIds)
+	ret0, _ := ret[0].([]sqlc.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoriesByProductIDs indicates an expected call of GetInventoriesByProductIDs.
+func (mr *MockStoreMockRecorder) GetInventoriesByProductIDs(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoriesByProductIDs", reflect.TypeOf((*MockStore)(nil).GetInventoriesByProductIDs), ctx, productIds)
+}
+
+// GetInventoriesBySkuIDs mocks base method.
+func (m *MockStore) GetInventoriesBySkuIDs(ctx context.Context, skuIds []int64) ([]sqlc.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoriesBySkuIDs", ctx, skuIds)
+	ret0, _ := ret[0].([]sqlc.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoriesBySkuIDs indicates an expected call of GetInventoriesBySkuIDs.
+func (mr *MockStoreMockRecorder) GetInventoriesBySkuIDs(ctx, skuIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoriesBySkuIDs", reflect.TypeOf((*MockStore)(nil).GetInventoriesBySkuIDs), ctx, skuIds)
+}
+
+// GetInventoryByID mocks base method.
+func (m *MockStore) GetInventoryByID(ctx context.Context, id int64) (sqlc.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryByID", ctx, id)
+	ret0, _ := ret[0].(sqlc.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryByID indicates an expected call of GetInventoryByID.
+func (mr *MockStoreMockRecorder) GetInventoryByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryByID", reflect.TypeOf((*MockStore)(nil).GetInventoryByID), ctx, id)
+}
+
+// GetInventoryByProductID mocks base method.
+func (m *MockStore) GetInventoryByProductID(ctx context.Context, productID int64) (sqlc.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryByProductID", ctx, productID)
+	ret0, _ := ret[0].(sqlc.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryByProductID indicates an expected call of GetInventoryByProductID.
+func (mr *MockStoreMockRecorder) GetInventoryByProductID(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryByProductID", reflect.TypeOf((*MockStore)(nil).GetInventoryByProductID), ctx, productID)
+}
+
+// GetInventoryBySkuID mocks base method.
+func (m *MockStore) GetInventoryBySkuID(ctx context.Context, skuID *int64) (sqlc.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryBySkuID", ctx, skuID)
+	ret0, _ := ret[0].(sqlc.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryBySkuID indicates an expected call of GetInventoryBySkuID.
+func (mr *MockStoreMockRecorder) GetInventoryBySkuID(ctx, skuID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryBySkuID", reflect.TypeOf((*MockStore)(nil).GetInventoryBySkuID), ctx, skuID)
+}
+
+// GetInventoryLogsByOrderID mocks base method.
+func (m *MockStore) GetInventoryLogsByOrderID(ctx context.Context, orderID int64) ([]sqlc.InventoryLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryLogsByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]sqlc.InventoryLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryLogsByOrderID indicates an expected call of GetInventoryLogsByOrderID.
+func (mr *MockStoreMockRecorder) GetInventoryLogsByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryLogsByOrderID", reflect.TypeOf((*MockStore)(nil).GetInventoryLogsByOrderID), ctx, orderID)
+}
+
+// GetInventoryLogsByProductID mocks base method.
+func (m *MockStore) GetInventoryLogsByProductID(ctx context.Context, arg sqlc.GetInventoryLogsByProductIDParams) ([]sqlc.InventoryLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryLogsByProductID", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.InventoryLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryLogsByProductID indicates an expected call of GetInventoryLogsByProductID.
+func (mr *MockStoreMockRecorder) GetInventoryLogsByProductID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryLogsByProductID", reflect.TypeOf((*MockStore)(nil).GetInventoryLogsByProductID), ctx, arg)
+}
+
+// GetInventoryLogsByReference mocks base method.
+func (m *MockStore) GetInventoryLogsByReference(ctx context.Context, arg sqlc.GetInventoryLogsByReferenceParams) ([]sqlc.InventoryLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryLogsByReference", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.InventoryLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryLogsByReference indicates an expected call of GetInventoryLogsByReference.
+func (mr *MockStoreMockRecorder) GetInventoryLogsByReference(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryLogsByReference", reflect.TypeOf((*MockStore)(nil).GetInventoryLogsByReference), ctx, arg)
+}
+
+// GetInventoryMovementReport mocks base method.
+func (m *MockStore) GetInventoryMovementReport(ctx context.Context, arg sqlc.GetInventoryMovementReportParams) ([]sqlc.GetInventoryMovementReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryMovementReport", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.GetInventoryMovementReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryMovementReport indicates an expected call of GetInventoryMovementReport.
+func (mr *MockStoreMockRecorder) GetInventoryMovementReport(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryMovementReport", reflect.TypeOf((*MockStore)(nil).GetInventoryMovementReport), ctx, arg)
+}
+
+// GetInventoryReservationByID mocks base method.
+func (m *MockStore) GetInventoryReservationByID(ctx context.Context, id int64) (sqlc.InventoryReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryReservationByID", ctx, id)
+	ret0, _ := ret[0].(sqlc.InventoryReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryReservationByID indicates an expected call of GetInventoryReservationByID.
+func (mr *MockStoreMockRecorder) GetInventoryReservationByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryReservationByID", reflect.TypeOf((*MockStore)(nil).GetInventoryReservationByID), ctx, id)
+}
+
+// GetInventoryReservationByOrderID mocks base method.
+func (m *MockStore) GetInventoryReservationByOrderID(ctx context.Context, orderID int64) ([]sqlc.InventoryReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryReservationByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]sqlc.InventoryReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryReservationByOrderID indicates an expected call of GetInventoryReservationByOrderID.
+func (mr *MockStoreMockRecorder) GetInventoryReservationByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryReservationByOrderID", reflect.TypeOf((*MockStore)(nil).GetInventoryReservationByOrderID), ctx, orderID)
+}
+
+// GetLastInventoryLogAtOrBefore mocks base method.
+func (m *MockStore) GetLastInventoryLogAtOrBefore(ctx context.Context, arg sqlc.GetLastInventoryLogAtOrBeforeParams) (sqlc.InventoryLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastInventoryLogAtOrBefore", ctx, arg)
+	ret0, _ := ret[0].(sqlc.InventoryLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastInventoryLogAtOrBefore indicates an expected call of GetLastInventoryLogAtOrBefore.
+func (mr *MockStoreMockRecorder) GetLastInventoryLogAtOrBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastInventoryLogAtOrBefore", reflect.TypeOf((*MockStore)(nil).GetLastInventoryLogAtOrBefore), ctx, arg)
+}
+
+// GetLatestVerificationCode mocks base method.
+func (m *MockStore) GetLatestVerificationCode(ctx context.Context, arg sqlc.GetLatestVerificationCodeParams) (sqlc.VerificationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestVerificationCode", ctx, arg)
+	ret0, _ := ret[0].(sqlc.VerificationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestVerificationCode indicates an expected call of GetLatestVerificationCode.
+func (mr *MockStoreMockRecorder) GetLatestVerificationCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestVerificationCode", reflect.TypeOf((*MockStore)(nil).GetLatestVerificationCode), ctx, arg)
+}
+
+// GetLowStockProducts mocks base method.
+func (m *MockStore) GetLowStockProducts(ctx context.Context, arg sqlc.GetLowStockProductsParams) ([]sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLowStockProducts", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLowStockProducts indicates an expected call of GetLowStockProducts.
+func (mr *MockStoreMockRecorder) GetLowStockProducts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLowStockProducts", reflect.TypeOf((*MockStore)(nil).GetLowStockProducts), ctx, arg)
+}
+
+// GetOrderByID mocks base method.
+func (m *MockStore) GetOrderByID(ctx context.Context, id int64) (sqlc.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderByID", ctx, id)
+	ret0, _ := ret[0].(sqlc.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderByID indicates an expected call of GetOrderByID.
+func (mr *MockStoreMockRecorder) GetOrderByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockStore)(nil).GetOrderByID), ctx, id)
+}
+
+// GetOrderByOrderNo mocks base method.
+func (m *MockStore) GetOrderByOrderNo(ctx context.Context, orderNo string) (sqlc.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderByOrderNo", ctx, orderNo)
+	ret0, _ := ret[0].(sqlc.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderByOrderNo indicates an expected call of GetOrderByOrderNo.
+func (mr *MockStoreMockRecorder) GetOrderByOrderNo(ctx, orderNo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByOrderNo", reflect.TypeOf((*MockStore)(nil).GetOrderByOrderNo), ctx, orderNo)
+}
+
+// GetOrderItems mocks base method.
+func (m *MockStore) GetOrderItems(ctx context.Context, orderID int64) ([]sqlc.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItems", ctx, orderID)
+	ret0, _ := ret[0].([]sqlc.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItems indicates an expected call of GetOrderItems.
+func (mr *MockStoreMockRecorder) GetOrderItems(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItems", reflect.TypeOf((*MockStore)(nil).GetOrderItems), ctx, orderID)
+}
+
+// GetOrderItemsByIDs mocks base method.
+func (m *MockStore) GetOrderItemsByIDs(ctx context.Context, dollar_1 []int64) ([]sqlc.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItemsByIDs", ctx, dollar_1)
+	ret0, _ := ret[0].([]sqlc.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemsByIDs indicates an expected call of GetOrderItemsByIDs.
+func (mr *MockStoreMockRecorder) GetOrderItemsByIDs(ctx, dollar_1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemsByIDs", reflect.TypeOf((*MockStore)(nil).GetOrderItemsByIDs), ctx, dollar_1)
+}
+
+// GetProductAnswer mocks base method.
+func (m *MockStore) GetProductAnswer(ctx context.Context, id int64) (sqlc.ProductAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductAnswer", ctx, id)
+	ret0, _ := ret[0].(sqlc.ProductAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductAnswer indicates an expected call of GetProductAnswer.
+func (mr *MockStoreMockRecorder) GetProductAnswer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductAnswer", reflect.TypeOf((*MockStore)(nil).GetProductAnswer), ctx, id)
+}
+
+// GetProductByID mocks base method.
+func (m *MockStore) GetProductByID(ctx context.Context, id int64) (sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByID", ctx, id)
+	ret0, _ := ret[0].(sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByID indicates an expected call of GetProductByID.
+func (mr *MockStoreMockRecorder) GetProductByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockStore)(nil).GetProductByID), ctx, id)
+}
+
+// GetProductCostForUpdate mocks base method.
+func (m *MockStore) GetProductCostForUpdate(ctx context.Context, id int64) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductCostForUpdate", ctx, id)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductCostForUpdate indicates an expected call of GetProductCostForUpdate.
+func (mr *MockStoreMockRecorder) GetProductCostForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCostForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductCostForUpdate), ctx, id)
+}
+
+// GetProductImage mocks base method.
+func (m *MockStore) GetProductImage(ctx context.Context, id int64) (sqlc.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductImage", ctx, id)
+	ret0, _ := ret[0].(sqlc.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductImage indicates an expected call of GetProductImage.
+func (mr *MockStoreMockRecorder) GetProductImage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImage", reflect.TypeOf((*MockStore)(nil).GetProductImage), ctx, id)
+}
+
+// GetProductImageRenditions mocks base method.
+func (m *MockStore) GetProductImageRenditions(ctx context.Context, productID int64) ([]sqlc.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductImageRenditions", ctx, productID)
+	ret0, _ := ret[0].([]sqlc.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductImageRenditions indicates an expected call of GetProductImageRenditions.
+func (mr *MockStoreMockRecorder) GetProductImageRenditions(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImageRenditions", reflect.TypeOf((*MockStore)(nil).GetProductImageRenditions), ctx, productID)
+}
+
+// GetProductImages mocks base method.
+func (m *MockStore) GetProductImages(ctx context.Context, productID int64) ([]sqlc.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductImages", ctx, productID)
+	ret0, _ := ret[0].([]sqlc.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductImages indicates an expected call of GetProductImages.
+func (mr *MockStoreMockRecorder) GetProductImages(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImages", reflect.TypeOf((*MockStore)(nil).GetProductImages), ctx, productID)
+}
+
+// GetProductLiveFields mocks base method.
+func (m *MockStore) GetProductLiveFields(ctx context.Context, id int64) (sqlc.GetProductLiveFieldsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductLiveFields", ctx, id)
+	ret0, _ := ret[0].(sqlc.GetProductLiveFieldsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductLiveFields indicates an expected call of GetProductLiveFields.
+func (mr *MockStoreMockRecorder) GetProductLiveFields(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductLiveFields", reflect.TypeOf((*MockStore)(nil).GetProductLiveFields), ctx, id)
+}
+
+// GetProductMainImage mocks base method.
+func (m *MockStore) GetProductMainImage(ctx context.Context, productID int64) (sqlc.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductMainImage", ctx, productID)
+	ret0, _ := ret[0].(sqlc.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductMainImage indicates an expected call of GetProductMainImage.
+func (mr *MockStoreMockRecorder) GetProductMainImage(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductMainImage", reflect.TypeOf((*MockStore)(nil).GetProductMainImage), ctx, productID)
+}
+
+// GetProductOnHandStock mocks base method.
+func (m *MockStore) GetProductOnHandStock(ctx context.Context, productID int64) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductOnHandStock", ctx, productID)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductOnHandStock indicates an expected call of GetProductOnHandStock.
+func (mr *MockStoreMockRecorder) GetProductOnHandStock(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductOnHandStock", reflect.TypeOf((*MockStore)(nil).GetProductOnHandStock), ctx, productID)
+}
+
+// GetProductQuestion mocks base method.
+func (m *MockStore) GetProductQuestion(ctx context.Context, id int64) (sqlc.ProductQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductQuestion", ctx, id)
+	ret0, _ := ret[0].(sqlc.ProductQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductQuestion indicates an expected call of GetProductQuestion.
+func (mr *MockStoreMockRecorder) GetProductQuestion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductQuestion", reflect.TypeOf((*MockStore)(nil).GetProductQuestion), ctx, id)
+}
+
+// GetProductQuestionAsker mocks base method.
+func (m *MockStore) GetProductQuestionAsker(ctx context.Context, id int64) (sqlc.GetProductQuestionAskerRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductQuestionAsker", ctx, id)
+	ret0, _ := ret[0].(sqlc.GetProductQuestionAskerRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductQuestionAsker indicates an expected call of GetProductQuestionAsker.
+func (mr *MockStoreMockRecorder) GetProductQuestionAsker(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductQuestionAsker", reflect.TypeOf((*MockStore)(nil).GetProductQuestionAsker), ctx, id)
+}
+
+// GetProductReview mocks base method.
+func (m *MockStore) GetProductReview(ctx context.Context, id int64) (sqlc.ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductReview", ctx, id)
+	ret0, _ := ret[0].(sqlc.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductReview indicates an expected call of GetProductReview.
+func (mr *MockStoreMockRecorder) GetProductReview(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductReview", reflect.TypeOf((*MockStore)(nil).GetProductReview), ctx, id)
+}
+
+// GetProductReviewForUpdate mocks base method.
+func (m *MockStore) GetProductReviewForUpdate(ctx context.Context, id int64) (sqlc.ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductReviewForUpdate", ctx, id)
+	ret0, _ := ret[0].(sqlc.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductReviewForUpdate indicates an expected call of GetProductReviewForUpdate.
+func (mr *MockStoreMockRecorder) GetProductReviewForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductReviewForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductReviewForUpdate), ctx, id)
+}
+
+// GetProductSalesVelocity mocks base method.
+func (m *MockStore) GetProductSalesVelocity(ctx context.Context, since time.Time) ([]sqlc.GetProductSalesVelocityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductSalesVelocity", ctx, since)
+	ret0, _ := ret[0].([]sqlc.GetProductSalesVelocityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductSalesVelocity indicates an expected call of GetProductSalesVelocity.
+func (mr *MockStoreMockRecorder) GetProductSalesVelocity(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductSalesVelocity", reflect.TypeOf((*MockStore)(nil).GetProductSalesVelocity), ctx, since)
+}
+
+// GetProductSku mocks base method.
+func (m *MockStore) GetProductSku(ctx context.Context, id int64) (sqlc.ProductSku, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductSku", ctx, id)
+	ret0, _ := ret[0].(sqlc.ProductSku)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductSku indicates an expected call of GetProductSku.
+func (mr *MockStoreMockRecorder) GetProductSku(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductSku", reflect.TypeOf((*MockStore)(nil).GetProductSku), ctx, id)
+}
+
+// GetProductsByIDs mocks base method.
+func (m *MockStore) GetProductsByIDs(ctx context.Context, dollar_1 []int64) ([]sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsByIDs", ctx, dollar_1)
+	ret0, _ := ret[0].([]sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsByIDs indicates an expected call of GetProductsByIDs.
+func (mr *MockStoreMockRecorder) GetProductsByIDs(ctx, dollar_1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByIDs", reflect.TypeOf((*MockStore)(nil).GetProductsByIDs), ctx, dollar_1)
+}
+
+// GetPurchaseOrder mocks base method.
+func (m *MockStore) GetPurchaseOrder(ctx context.Context, id int64) (sqlc.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrder", ctx, id)
+	ret0, _ := ret[0].(sqlc.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrder indicates an expected call of GetPurchaseOrder.
+func (mr *MockStoreMockRecorder) GetPurchaseOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrder", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrder), ctx, id)
+}
+
+// GetPurchaseOrderForUpdate mocks base method.
+func (m *MockStore) GetPurchaseOrderForUpdate(ctx context.Context, id int64) (sqlc.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderForUpdate", ctx, id)
+	ret0, _ := ret[0].(sqlc.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrderForUpdate indicates an expected call of GetPurchaseOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetPurchaseOrderForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrderForUpdate), ctx, id)
+}
+
+// GetReviewableOrderItem mocks base method.
+func (m *MockStore) GetReviewableOrderItem(ctx context.Context, id int64) (sqlc.GetReviewableOrderItemRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewableOrderItem", ctx, id)
+	ret0, _ := ret[0].(sqlc.GetReviewableOrderItemRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewableOrderItem indicates an expected call of GetReviewableOrderItem.
+func (mr *MockStoreMockRecorder) GetReviewableOrderItem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewableOrderItem", reflect.TypeOf((*MockStore)(nil).GetReviewableOrderItem), ctx, id)
+}
+
+// GetRootCategories mocks base method.
+func (m *MockStore) GetRootCategories(ctx context.Context) ([]sqlc.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootCategories", ctx)
+	ret0, _ := ret[0].([]sqlc.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRootCategories indicates an expected call of GetRootCategories.
+func (mr *MockStoreMockRecorder) GetRootCategories(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootCategories", reflect.TypeOf((*MockStore)(nil).GetRootCategories), ctx)
+}
+
+// GetSelectedCartItems mocks base method.
+func (m *MockStore) GetSelectedCartItems(ctx context.Context, userID int64) ([]sqlc.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSelectedCartItems", ctx, userID)
+	ret0, _ := ret[0].([]sqlc.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSelectedCartItems indicates an expected call of GetSelectedCartItems.
+func (mr *MockStoreMockRecorder) GetSelectedCartItems(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSelectedCartItems", reflect.TypeOf((*MockStore)(nil).GetSelectedCartItems), ctx, userID)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (sqlc.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, id)
+	ret0, _ := ret[0].(sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+}
+
+// GetStocktake mocks base method.
+func (m *MockStore) GetStocktake(ctx context.Context, id int64) (sqlc.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktake", ctx, id)
+	ret0, _ := ret[0].(sqlc.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktake indicates an expected call of GetStocktake.
+func (mr *MockStoreMockRecorder) GetStocktake(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktake", reflect.TypeOf((*MockStore)(nil).GetStocktake), ctx, id)
+}
+
+// GetSupplier mocks base method.
+func (m *MockStore) GetSupplier(ctx context.Context, id int64) (sqlc.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplier", ctx, id)
+	ret0, _ := ret[0].(sqlc.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplier indicates an expected call of GetSupplier.
+func (mr *MockStoreMockRecorder) GetSupplier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockStore)(nil).GetSupplier), ctx, id)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserByID mocks base method.
+func (m *MockStore) GetUserByID(ctx context.Context, id int64) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockStoreMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockStore)(nil).GetUserByID), ctx, id)
+}
+
+// GetUserByPhone mocks base method.
+func (m *MockStore) GetUserByPhone(ctx context.Context, phone *string) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByPhone", ctx, phone)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByPhone indicates an expected call of GetUserByPhone.
+func (mr *MockStoreMockRecorder) GetUserByPhone(ctx, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByPhone", reflect.TypeOf((*MockStore)(nil).GetUserByPhone), ctx, phone)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockStore) GetUserByUsername(ctx context.Context, username string) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockStoreMockRecorder) GetUserByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), ctx, username)
+}
+
+// GetUserSessions mocks base method.
+func (m *MockStore) GetUserSessions(ctx context.Context, userID int64) ([]sqlc.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessions", ctx, userID)
+	ret0, _ := ret[0].([]sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessions indicates an expected call of GetUserSessions.
+func (mr *MockStoreMockRecorder) GetUserSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockStore)(nil).GetUserSessions), ctx, userID)
+}
+
+// GetVerificationCode mocks base method.
+func (m *MockStore) GetVerificationCode(ctx context.Context, arg sqlc.GetVerificationCodeParams) (sqlc.VerificationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerificationCode", ctx, arg)
+	ret0, _ := ret[0].(sqlc.VerificationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerificationCode indicates an expected call of GetVerificationCode.
+func (mr *MockStoreMockRecorder) GetVerificationCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerificationCode", reflect.TypeOf((*MockStore)(nil).GetVerificationCode), ctx, arg)
+}
+
+// HasPurchasedProduct mocks base method.
+func (m *MockStore) HasPurchasedProduct(ctx context.Context, arg sqlc.HasPurchasedProductParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPurchasedProduct", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPurchasedProduct indicates an expected call of HasPurchasedProduct.
+func (mr *MockStoreMockRecorder) HasPurchasedProduct(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPurchasedProduct", reflect.TypeOf((*MockStore)(nil).HasPurchasedProduct), ctx, arg)
+}
+
+// HoldOrderReservations mocks base method.
+func (m *MockStore) HoldOrderReservations(ctx context.Context, orderID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldOrderReservations", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HoldOrderReservations indicates an expected call of HoldOrderReservations.
+func (mr *MockStoreMockRecorder) HoldOrderReservations(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldOrderReservations", reflect.TypeOf((*MockStore)(nil).HoldOrderReservations), ctx, orderID)
+}
+
+// IncrementProductSales mocks base method.
+func (m *MockStore) IncrementProductSales(ctx context.Context, arg sqlc.IncrementProductSalesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementProductSales", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementProductSales indicates an expected call of IncrementProductSales.
+func (mr *MockStoreMockRecorder) IncrementProductSales(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementProductSales", reflect.TypeOf((*MockStore)(nil).IncrementProductSales), ctx, arg)
+}
+
+// IncrementQuestionAnswerCount mocks base method.
+func (m *MockStore) IncrementQuestionAnswerCount(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementQuestionAnswerCount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementQuestionAnswerCount indicates an expected call of IncrementQuestionAnswerCount.
+func (mr *MockStoreMockRecorder) IncrementQuestionAnswerCount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementQuestionAnswerCount", reflect.TypeOf((*MockStore)(nil).IncrementQuestionAnswerCount), ctx, id)
+}
+
+// InsertProductAssociations mocks base method.
+func (m *MockStore) InsertProductAssociations(ctx context.Context, arg sqlc.InsertProductAssociationsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertProductAssociations", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertProductAssociations indicates an expected call of InsertProductAssociations.
+func (mr *MockStoreMockRecorder) InsertProductAssociations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProductAssociations", reflect.TypeOf((*MockStore)(nil).InsertProductAssociations), ctx, arg)
+}
+
+// ListActiveSkusByProductIDs mocks base method.
+func (m *MockStore) ListActiveSkusByProductIDs(ctx context.Context, productIds []int64) ([]sqlc.ProductSku, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSkusByProductIDs", ctx, productIds)
+	ret0, _ := ret[0].([]sqlc.ProductSku)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSkusByProductIDs indicates an expected call of ListActiveSkusByProductIDs.
+func (mr *MockStoreMockRecorder) ListActiveSkusByProductIDs(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSkusByProductIDs", reflect.TypeOf((*MockStore)(nil).ListActiveSkusByProductIDs), ctx, productIds)
+}
+
+// ListActiveStockAlerts mocks base method.
+func (m *MockStore) ListActiveStockAlerts(ctx context.Context, arg sqlc.ListActiveStockAlertsParams) ([]sqlc.ListActiveStockAlertsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveStockAlerts", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListActiveStockAlertsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveStockAlerts indicates an expected call of ListActiveStockAlerts.
+func (mr *MockStoreMockRecorder) ListActiveStockAlerts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveStockAlerts", reflect.TypeOf((*MockStore)(nil).ListActiveStockAlerts), ctx, arg)
+}
+
+// ListAlsoBoughtProducts mocks base method.
+func (m *MockStore) ListAlsoBoughtProducts(ctx context.Context, arg sqlc.ListAlsoBoughtProductsParams) ([]sqlc.ListAlsoBoughtProductsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlsoBoughtProducts", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListAlsoBoughtProductsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlsoBoughtProducts indicates an expected call of ListAlsoBoughtProducts.
+func (mr *MockStoreMockRecorder) ListAlsoBoughtProducts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlsoBoughtProducts", reflect.TypeOf((*MockStore)(nil).ListAlsoBoughtProducts), ctx, arg)
+}
+
+// ListBackorders mocks base method.
+func (m *MockStore) ListBackorders(ctx context.Context, arg sqlc.ListBackordersParams) ([]sqlc.InventoryBackorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBackorders", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.InventoryBackorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBackorders indicates an expected call of ListBackorders.
+func (mr *MockStoreMockRecorder) ListBackorders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBackorders", reflect.TypeOf((*MockStore)(nil).ListBackorders), ctx, arg)
+}
+
+// ListCategories mocks base method.
+func (m *MockStore) ListCategories(ctx context.Context, dollar_1 bool) ([]sqlc.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", ctx, dollar_1)
+	ret0, _ := ret[0].([]sqlc.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockStoreMockRecorder) ListCategories(ctx, dollar_1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), ctx, dollar_1)
+}
+
+// ListCategoryBestSellers mocks base method.
+func (m *MockStore) ListCategoryBestSellers(ctx context.Context, arg sqlc.ListCategoryBestSellersParams) ([]sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryBestSellers", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryBestSellers indicates an expected call of ListCategoryBestSellers.
+func (mr *MockStoreMockRecorder) ListCategoryBestSellers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryBestSellers", reflect.TypeOf((*MockStore)(nil).ListCategoryBestSellers), ctx, arg)
+}
+
+// ListFeaturedProducts mocks base method.
+func (m *MockStore) ListFeaturedProducts(ctx context.Context, arg sqlc.ListFeaturedProductsParams) ([]sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeaturedProducts", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeaturedProducts indicates an expected call of ListFeaturedProducts.
+func (mr *MockStoreMockRecorder) ListFeaturedProducts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeaturedProducts", reflect.TypeOf((*MockStore)(nil).ListFeaturedProducts), ctx, arg)
+}
+
+// ListInventories mocks base method.
+func (m *MockStore) ListInventories(ctx context.Context, arg sqlc.ListInventoriesParams) ([]sqlc.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInventories", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInventories indicates an expected call of ListInventories.
+func (mr *MockStoreMockRecorder) ListInventories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInventories", reflect.TypeOf((*MockStore)(nil).ListInventories), ctx, arg)
+}
+
+// ListInventoriesForExport mocks base method.
+func (m *MockStore) ListInventoriesForExport(ctx context.Context, arg sqlc.ListInventoriesForExportParams) ([]sqlc.ListInventoriesForExportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInventoriesForExport", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListInventoriesForExportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInventoriesForExport indicates an expected call of ListInventoriesForExport.
+func (mr *MockStoreMockRecorder) ListInventoriesForExport(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInventoriesForExport", reflect.TypeOf((*MockStore)(nil).ListInventoriesForExport), ctx, arg)
+}
+
+// ListLowStockInventories mocks base method.
+func (m *MockStore) ListLowStockInventories(ctx context.Context, arg sqlc.ListLowStockInventoriesParams) ([]sqlc.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLowStockInventories", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLowStockInventories indicates an expected call of ListLowStockInventories.
+func (mr *MockStoreMockRecorder) ListLowStockInventories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLowStockInventories", reflect.TypeOf((*MockStore)(nil).ListLowStockInventories), ctx, arg)
+}
+
+// ListPendingBackordersForUpdate mocks base method.
+func (m *MockStore) ListPendingBackordersForUpdate(ctx context.Context, arg sqlc.ListPendingBackordersForUpdateParams) ([]sqlc.InventoryBackorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingBackordersForUpdate", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.InventoryBackorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingBackordersForUpdate indicates an expected call of ListPendingBackordersForUpdate.
+func (mr *MockStoreMockRecorder) ListPendingBackordersForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingBackordersForUpdate", reflect.TypeOf((*MockStore)(nil).ListPendingBackordersForUpdate), ctx, arg)
+}
+
+// ListProductAnswers mocks base method.
+func (m *MockStore) ListProductAnswers(ctx context.Context, arg sqlc.ListProductAnswersParams) ([]sqlc.ListProductAnswersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductAnswers", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListProductAnswersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductAnswers indicates an expected call of ListProductAnswers.
+func (mr *MockStoreMockRecorder) ListProductAnswers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductAnswers", reflect.TypeOf((*MockStore)(nil).ListProductAnswers), ctx, arg)
+}
+
+// ListProductCategoryIDs mocks base method.
+func (m *MockStore) ListProductCategoryIDs(ctx context.Context) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductCategoryIDs", ctx)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductCategoryIDs indicates an expected call of ListProductCategoryIDs.
+func (mr *MockStoreMockRecorder) ListProductCategoryIDs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductCategoryIDs", reflect.TypeOf((*MockStore)(nil).ListProductCategoryIDs), ctx)
+}
+
+// ListProductOptionValues mocks base method.
+func (m *MockStore) ListProductOptionValues(ctx context.Context, productID int64) ([]sqlc.ListProductOptionValuesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductOptionValues", ctx, productID)
+	ret0, _ := ret[0].([]sqlc.ListProductOptionValuesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductOptionValues indicates an expected call of ListProductOptionValues.
+func (mr *MockStoreMockRecorder) ListProductOptionValues(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductOptionValues", reflect.TypeOf((*MockStore)(nil).ListProductOptionValues), ctx, productID)
+}
+
+// ListProductQuestions mocks base method.
+func (m *MockStore) ListProductQuestions(ctx context.Context, arg sqlc.ListProductQuestionsParams) ([]sqlc.ListProductQuestionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductQuestions", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListProductQuestionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductQuestions indicates an expected call of ListProductQuestions.
+func (mr *MockStoreMockRecorder) ListProductQuestions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductQuestions", reflect.TypeOf((*MockStore)(nil).ListProductQuestions), ctx, arg)
+}
+
+// ListProductReviews mocks base method.
+func (m *MockStore) ListProductReviews(ctx context.Context, arg sqlc.ListProductReviewsParams) ([]sqlc.ListProductReviewsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductReviews", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListProductReviewsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductReviews indicates an expected call of ListProductReviews.
+func (mr *MockStoreMockRecorder) ListProductReviews(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductReviews", reflect.TypeOf((*MockStore)(nil).ListProductReviews), ctx, arg)
+}
+
+// ListProductSkuStock mocks base method.
+func (m *MockStore) ListProductSkuStock(ctx context.Context, productID int64) ([]sqlc.ListProductSkuStockRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductSkuStock", ctx, productID)
+	ret0, _ := ret[0].([]sqlc.ListProductSkuStockRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductSkuStock indicates an expected call of ListProductSkuStock.
+func (mr *MockStoreMockRecorder) ListProductSkuStock(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductSkuStock", reflect.TypeOf((*MockStore)(nil).ListProductSkuStock), ctx, productID)
+}
+
+// ListProductSkus mocks base method.
+func (m *MockStore) ListProductSkus(ctx context.Context, productID int64) ([]sqlc.ListProductSkusRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductSkus", ctx, productID)
+	ret0, _ := ret[0].([]sqlc.ListProductSkusRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductSkus indicates an expected call of ListProductSkus.
+func (mr *MockStoreMockRecorder) ListProductSkus(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductSkus", reflect.TypeOf((*MockStore)(nil).ListProductSkus), ctx, productID)
+}
+
+// ListProductSkusByCodes mocks base method.
+func (m *MockStore) ListProductSkusByCodes(ctx context.Context, skuCodes []string) ([]sqlc.ListProductSkusByCodesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductSkusByCodes", ctx, skuCodes)
+	ret0, _ := ret[0].([]sqlc.ListProductSkusByCodesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductSkusByCodes indicates an expected call of ListProductSkusByCodes.
+func (mr *MockStoreMockRecorder) ListProductSkusByCodes(ctx, skuCodes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductSkusByCodes", reflect.TypeOf((*MockStore)(nil).ListProductSkusByCodes), ctx, skuCodes)
+}
+
+// ListProductsByCategory mocks base method.
+func (m *MockStore) ListProductsByCategory(ctx context.Context, arg sqlc.ListProductsByCategoryParams) ([]sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsByCategory", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductsByCategory indicates an expected call of ListProductsByCategory.
+func (mr *MockStoreMockRecorder) ListProductsByCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByCategory", reflect.TypeOf((*MockStore)(nil).ListProductsByCategory), ctx, arg)
+}
+
+// ListProductsByPriceRange mocks base method.
+func (m *MockStore) ListProductsByPriceRange(ctx context.Context, arg sqlc.ListProductsByPriceRangeParams) ([]sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsByPriceRange", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductsByPriceRange indicates an expected call of ListProductsByPriceRange.
+func (mr *MockStoreMockRecorder) ListProductsByPriceRange(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByPriceRange", reflect.TypeOf((*MockStore)(nil).ListProductsByPriceRange), ctx, arg)
+}
+
+// ListProductsForIndex mocks base method.
+func (m *MockStore) ListProductsForIndex(ctx context.Context, arg sqlc.ListProductsForIndexParams) ([]sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsForIndex", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductsForIndex indicates an expected call of ListProductsForIndex.
+func (mr *MockStoreMockRecorder) ListProductsForIndex(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsForIndex", reflect.TypeOf((*MockStore)(nil).ListProductsForIndex), ctx, arg)
+}
+
+// ListPurchaseOrderItems mocks base method.
+func (m *MockStore) ListPurchaseOrderItems(ctx context.Context, purchaseOrderID int64) ([]sqlc.ListPurchaseOrderItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseOrderItems", ctx, purchaseOrderID)
+	ret0, _ := ret[0].([]sqlc.ListPurchaseOrderItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseOrderItems indicates an expected call of ListPurchaseOrderItems.
+func (mr *MockStoreMockRecorder) ListPurchaseOrderItems(ctx, purchaseOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrderItems", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrderItems), ctx, purchaseOrderID)
+}
+
+// ListPurchaseOrders mocks base method.
+func (m *MockStore) ListPurchaseOrders(ctx context.Context, arg sqlc.ListPurchaseOrdersParams) ([]sqlc.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseOrders", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.PurchaseOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AddToCart indicates an expected call of AddToCart.
-func (mr *MockStoreMockRecorder) AddToCart(ctx, arg any) *gomock.Call {
+// ListPurchaseOrders indicates an expected call of ListPurchaseOrders.
+func (mr *MockStoreMockRecorder) ListPurchaseOrders(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockStore)(nil).AddToCart), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrders", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrders), ctx, arg)
 }
 
-// BlockSession mocks base method.
-func (m *MockStore) BlockSession(ctx context.Context, id uuid.UUID) error {
+// ListPurchaseReceipts mocks base method.
+func (m *MockStore) ListPurchaseReceipts(ctx context.Context, purchaseOrderID int64) ([]sqlc.PurchaseReceipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BlockSession", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListPurchaseReceipts", ctx, purchaseOrderID)
+	ret0, _ := ret[0].([]sqlc.PurchaseReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// BlockSession indicates an expected call of BlockSession.
-func (mr *MockStoreMockRecorder) BlockSession(ctx, id any) *gomock.Call {
+// ListPurchaseReceipts indicates an expected call of ListPurchaseReceipts.
+func (mr *MockStoreMockRecorder) ListPurchaseReceipts(ctx, purchaseOrderID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSession", reflect.TypeOf((*MockStore)(nil).BlockSession), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseReceipts", reflect.TypeOf((*MockStore)(nil).ListPurchaseReceipts), ctx, purchaseOrderID)
 }
 
-// CleanExpiredSessions mocks base method.
-func (m *MockStore) CleanExpiredSessions(ctx context.Context) error {
+// ListReorderSettings mocks base method.
+func (m *MockStore) ListReorderSettings(ctx context.Context) ([]sqlc.ReorderSetting, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CleanExpiredSessions", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListReorderSettings", ctx)
+	ret0, _ := ret[0].([]sqlc.ReorderSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CleanExpiredSessions indicates an expected call of CleanExpiredSessions.
-func (mr *MockStoreMockRecorder) CleanExpiredSessions(ctx any) *gomock.Call {
+// ListReorderSettings indicates an expected call of ListReorderSettings.
+func (mr *MockStoreMockRecorder) ListReorderSettings(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanExpiredSessions", reflect.TypeOf((*MockStore)(nil).CleanExpiredSessions), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReorderSettings", reflect.TypeOf((*MockStore)(nil).ListReorderSettings), ctx)
 }
 
-// ClearCart mocks base method.
-func (m *MockStore) ClearCart(ctx context.Context, userID int64) error {
+// ListReorderSuggestions mocks base method.
+func (m *MockStore) ListReorderSuggestions(ctx context.Context, arg sqlc.ListReorderSuggestionsParams) ([]sqlc.ListReorderSuggestionsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ClearCart", ctx, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListReorderSuggestions", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListReorderSuggestionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ClearCart indicates an expected call of ClearCart.
-func (mr *MockStoreMockRecorder) ClearCart(ctx, userID any) *gomock.Call {
+// ListReorderSuggestions indicates an expected call of ListReorderSuggestions.
+func (mr *MockStoreMockRecorder) ListReorderSuggestions(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockStore)(nil).ClearCart), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReorderSuggestions", reflect.TypeOf((*MockStore)(nil).ListReorderSuggestions), ctx, arg)
 }
 
-// CountCartItems mocks base method.
-func (m *MockStore) CountCartItems(ctx context.Context, userID int64) (int64, error) {
+// ListReviewModerationLogs mocks base method.
+func (m *MockStore) ListReviewModerationLogs(ctx context.Context, reviewID int64) ([]sqlc.ProductReviewModerationLog, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountCartItems", ctx, userID)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListReviewModerationLogs", ctx, reviewID)
+	ret0, _ := ret[0].([]sqlc.ProductReviewModerationLog)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountCartItems indicates an expected call of CountCartItems.
-func (mr *MockStoreMockRecorder) CountCartItems(ctx, userID any) *gomock.Call {
+// ListReviewModerationLogs indicates an expected call of ListReviewModerationLogs.
+func (mr *MockStoreMockRecorder) ListReviewModerationLogs(ctx, reviewID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCartItems", reflect.TypeOf((*MockStore)(nil).CountCartItems), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewModerationLogs", reflect.TypeOf((*MockStore)(nil).ListReviewModerationLogs), ctx, reviewID)
 }
 
-// CountProducts mocks base method.
-func (m *MockStore) CountProducts(ctx context.Context) (int64, error) {
+// ListReviewsByStatus mocks base method.
+func (m *MockStore) ListReviewsByStatus(ctx context.Context, arg sqlc.ListReviewsByStatusParams) ([]sqlc.ListReviewsByStatusRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountProducts", ctx)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListReviewsByStatus", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListReviewsByStatusRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountProducts indicates an expected call of CountProducts.
-func (mr *MockStoreMockRecorder) CountProducts(ctx any) *gomock.Call {
+// ListReviewsByStatus indicates an expected call of ListReviewsByStatus.
+func (mr *MockStoreMockRecorder) ListReviewsByStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProducts", reflect.TypeOf((*MockStore)(nil).CountProducts), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewsByStatus", reflect.TypeOf((*MockStore)(nil).ListReviewsByStatus), ctx, arg)
 }
 
-// CountUsers mocks base method.
-func (m *MockStore) CountUsers(ctx context.Context) (int64, error) {
+// ListSpellingSuggestions mocks base method.
+func (m *MockStore) ListSpellingSuggestions(ctx context.Context, arg sqlc.ListSpellingSuggestionsParams) ([]sqlc.ListSpellingSuggestionsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUsers", ctx)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListSpellingSuggestions", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListSpellingSuggestionsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountUsers indicates an expected call of CountUsers.
-func (mr *MockStoreMockRecorder) CountUsers(ctx any) *gomock.Call {
+// ListSpellingSuggestions indicates an expected call of ListSpellingSuggestions.
+func (mr *MockStoreMockRecorder) ListSpellingSuggestions(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockStore)(nil).CountUsers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSpellingSuggestions", reflect.TypeOf((*MockStore)(nil).ListSpellingSuggestions), ctx, arg)
 }
 
-// CreateProduct mocks base method.
-func (m *MockStore) CreateProduct(ctx context.Context, arg sqlc.CreateProductParams) (sqlc.Product, error) {
+// ListStocktakeItems mocks base method.
+func (m *MockStore) ListStocktakeItems(ctx context.Context, stocktakeID int64) ([]sqlc.ListStocktakeItemsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProduct", ctx, arg)
-	ret0, _ := ret[0].(sqlc.Product)
+	ret := m.ctrl.Call(m, "ListStocktakeItems", ctx, stocktakeID)
+	ret0, _ := ret[0].([]sqlc.ListStocktakeItemsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateProduct indicates an expected call of CreateProduct.
-func (mr *MockStoreMockRecorder) CreateProduct(ctx, arg any) *gomock.Call {
+// ListStocktakeItems indicates an expected call of ListStocktakeItems.
+func (mr *MockStoreMockRecorder) ListStocktakeItems(ctx, stocktakeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStocktakeItems", reflect.TypeOf((*MockStore)(nil).ListStocktakeItems), ctx, stocktakeID)
 }
 
-// CreateProductImage mocks base method.
-func (m *MockStore) CreateProductImage(ctx context.Context, arg sqlc.CreateProductImageParams) (sqlc.ProductImage, error) {
+// ListStocktakes mocks base method.
+func (m *MockStore) ListStocktakes(ctx context.Context, arg sqlc.ListStocktakesParams) ([]sqlc.Stocktake, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProductImage", ctx, arg)
-	ret0, _ := ret[0].(sqlc.ProductImage)
+	ret := m.ctrl.Call(m, "ListStocktakes", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Stocktake)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateProductImage indicates an expected call of CreateProductImage.
-func (mr *MockStoreMockRecorder) CreateProductImage(ctx, arg any) *gomock.Call {
+// ListStocktakes indicates an expected call of ListStocktakes.
+func (mr *MockStoreMockRecorder) ListStocktakes(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductImage", reflect.TypeOf((*MockStore)(nil).CreateProductImage), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStocktakes", reflect.TypeOf((*MockStore)(nil).ListStocktakes), ctx, arg)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(ctx context.Context, arg sqlc.CreateSessionParams) (sqlc.Session, error) {
+// ListSuppliers mocks base method.
+func (m *MockStore) ListSuppliers(ctx context.Context, arg sqlc.ListSuppliersParams) ([]sqlc.Supplier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
-	ret0, _ := ret[0].(sqlc.Session)
+	ret := m.ctrl.Call(m, "ListSuppliers", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Supplier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
+// ListSuppliers indicates an expected call of ListSuppliers.
+func (mr *MockStoreMockRecorder) ListSuppliers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuppliers", reflect.TypeOf((*MockStore)(nil).ListSuppliers), ctx, arg)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+// ListTopAnswersByQuestionIDs mocks base method.
+func (m *MockStore) ListTopAnswersByQuestionIDs(ctx context.Context, arg sqlc.ListTopAnswersByQuestionIDsParams) ([]sqlc.ListTopAnswersByQuestionIDsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
-	ret0, _ := ret[0].(sqlc.User)
+	ret := m.ctrl.Call(m, "ListTopAnswersByQuestionIDs", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListTopAnswersByQuestionIDsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+// ListTopAnswersByQuestionIDs indicates an expected call of ListTopAnswersByQuestionIDs.
+func (mr *MockStoreMockRecorder) ListTopAnswersByQuestionIDs(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopAnswersByQuestionIDs", reflect.TypeOf((*MockStore)(nil).ListTopAnswersByQuestionIDs), ctx, arg)
 }
 
-// CreateVerificationCode mocks base method.
-func (m *MockStore) CreateVerificationCode(ctx context.Context, arg sqlc.CreateVerificationCodeParams) (sqlc.VerificationCode, error) {
+// ListUserOrders mocks base method.
+func (m *MockStore) ListUserOrders(ctx context.Context, arg sqlc.ListUserOrdersParams) ([]sqlc.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVerificationCode", ctx, arg)
-	ret0, _ := ret[0].(sqlc.VerificationCode)
+	ret := m.ctrl.Call(m, "ListUserOrders", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateVerificationCode indicates an expected call of CreateVerificationCode.
-func (mr *MockStoreMockRecorder) CreateVerificationCode(ctx, arg any) *gomock.Call {
+// ListUserOrders indicates an expected call of ListUserOrders.
+func (mr *MockStoreMockRecorder) ListUserOrders(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerificationCode", reflect.TypeOf((*MockStore)(nil).CreateVerificationCode), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserOrders", reflect.TypeOf((*MockStore)(nil).ListUserOrders), ctx, arg)
 }
 
-// DecrementProductStock mocks base method.
-func (m *MockStore) DecrementProductStock(ctx context.Context, arg sqlc.DecrementProductStockParams) error {
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(ctx context.Context, arg sqlc.ListUsersParams) ([]sqlc.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DecrementProductStock", ctx, arg)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListUsers", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DecrementProductStock indicates an expected call of DecrementProductStock.
-func (mr *MockStoreMockRecorder) DecrementProductStock(ctx, arg any) *gomock.Call {
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementProductStock", reflect.TypeOf((*MockStore)(nil).DecrementProductStock), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), ctx, arg)
 }
 
-// DeleteCartItem mocks base method.
-func (m *MockStore) DeleteCartItem(ctx context.Context, arg sqlc.DeleteCartItemParams) error {
+// MarkBackorderAllocated mocks base method.
+func (m *MockStore) MarkBackorderAllocated(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCartItem", ctx, arg)
+	ret := m.ctrl.Call(m, "MarkBackorderAllocated", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteCartItem indicates an expected call of DeleteCartItem.
-func (mr *MockStoreMockRecorder) DeleteCartItem(ctx, arg any) *gomock.Call {
+// MarkBackorderAllocated indicates an expected call of MarkBackorderAllocated.
+func (mr *MockStoreMockRecorder) MarkBackorderAllocated(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartItem", reflect.TypeOf((*MockStore)(nil).DeleteCartItem), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBackorderAllocated", reflect.TypeOf((*MockStore)(nil).MarkBackorderAllocated), ctx, id)
 }
 
-// DeleteExpiredCodes mocks base method.
-func (m *MockStore) DeleteExpiredCodes(ctx context.Context) error {
+// MarkCodeAsUsed mocks base method.
+func (m *MockStore) MarkCodeAsUsed(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteExpiredCodes", ctx)
+	ret := m.ctrl.Call(m, "MarkCodeAsUsed", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteExpiredCodes indicates an expected call of DeleteExpiredCodes.
-func (mr *MockStoreMockRecorder) DeleteExpiredCodes(ctx any) *gomock.Call {
+// MarkCodeAsUsed indicates an expected call of MarkCodeAsUsed.
+func (mr *MockStoreMockRecorder) MarkCodeAsUsed(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredCodes", reflect.TypeOf((*MockStore)(nil).DeleteExpiredCodes), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCodeAsUsed", reflect.TypeOf((*MockStore)(nil).MarkCodeAsUsed), ctx, id)
 }
 
-// DeleteProduct mocks base method.
-func (m *MockStore) DeleteProduct(ctx context.Context, id int64) error {
+// MarkStockAlertNotified mocks base method.
+func (m *MockStore) MarkStockAlertNotified(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
+	ret := m.ctrl.Call(m, "MarkStockAlertNotified", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockStoreMockRecorder) DeleteProduct(ctx, id any) *gomock.Call {
+// MarkStockAlertNotified indicates an expected call of MarkStockAlertNotified.
+func (mr *MockStoreMockRecorder) MarkStockAlertNotified(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockStore)(nil).DeleteProduct), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkStockAlertNotified", reflect.TypeOf((*MockStore)(nil).MarkStockAlertNotified), ctx, id)
 }
 
-// DeleteProductImage mocks base method.
-func (m *MockStore) DeleteProductImage(ctx context.Context, id int64) error {
+// PromoteBackorderedOrder mocks base method.
+func (m *MockStore) PromoteBackorderedOrder(ctx context.Context, id int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProductImage", ctx, id)
+	ret := m.ctrl.Call(m, "PromoteBackorderedOrder", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteBackorderedOrder indicates an expected call of PromoteBackorderedOrder.
+func (mr *MockStoreMockRecorder) PromoteBackorderedOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteBackorderedOrder", reflect.TypeOf((*MockStore)(nil).PromoteBackorderedOrder), ctx, id)
+}
+
+// ReceivePurchaseOrderItem mocks base method.
+func (m *MockStore) ReceivePurchaseOrderItem(ctx context.Context, arg sqlc.ReceivePurchaseOrderItemParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceivePurchaseOrderItem", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceivePurchaseOrderItem indicates an expected call of ReceivePurchaseOrderItem.
+func (mr *MockStoreMockRecorder) ReceivePurchaseOrderItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivePurchaseOrderItem", reflect.TypeOf((*MockStore)(nil).ReceivePurchaseOrderItem), ctx, arg)
+}
+
+// RecordStocktakeCount mocks base method.
+func (m *MockStore) RecordStocktakeCount(ctx context.Context, arg sqlc.RecordStocktakeCountParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordStocktakeCount", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordStocktakeCount indicates an expected call of RecordStocktakeCount.
+func (mr *MockStoreMockRecorder) RecordStocktakeCount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordStocktakeCount", reflect.TypeOf((*MockStore)(nil).RecordStocktakeCount), ctx, arg)
+}
+
+// RefreshProductRating mocks base method.
+func (m *MockStore) RefreshProductRating(ctx context.Context, productID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshProductRating", ctx, productID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteProductImage indicates an expected call of DeleteProductImage.
-func (mr *MockStoreMockRecorder) DeleteProductImage(ctx, id any) *gomock.Call {
+// RefreshProductRating indicates an expected call of RefreshProductRating.
+func (mr *MockStoreMockRecorder) RefreshProductRating(ctx, productID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductImage", reflect.TypeOf((*MockStore)(nil).DeleteProductImage), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshProductRating", reflect.TypeOf((*MockStore)(nil).RefreshProductRating), ctx, productID)
 }
 
-// DeleteProductImages mocks base method.
-func (m *MockStore) DeleteProductImages(ctx context.Context, productID int64) error {
+// RefreshPurchaseOrderTotal mocks base method.
+func (m *MockStore) RefreshPurchaseOrderTotal(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProductImages", ctx, productID)
+	ret := m.ctrl.Call(m, "RefreshPurchaseOrderTotal", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteProductImages indicates an expected call of DeleteProductImages.
-func (mr *MockStoreMockRecorder) DeleteProductImages(ctx, productID any) *gomock.Call {
+// RefreshPurchaseOrderTotal indicates an expected call of RefreshPurchaseOrderTotal.
+func (mr *MockStoreMockRecorder) RefreshPurchaseOrderTotal(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductImages", reflect.TypeOf((*MockStore)(nil).DeleteProductImages), ctx, productID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshPurchaseOrderTotal", reflect.TypeOf((*MockStore)(nil).RefreshPurchaseOrderTotal), ctx, id)
 }
 
-// DeleteSession mocks base method.
-func (m *MockStore) DeleteSession(ctx context.Context, id uuid.UUID) error {
+// ReleaseBackorderedStock mocks base method.
+func (m *MockStore) ReleaseBackorderedStock(ctx context.Context, arg sqlc.ReleaseBackorderedStockParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSession", ctx, id)
+	ret := m.ctrl.Call(m, "ReleaseBackorderedStock", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteSession indicates an expected call of DeleteSession.
-func (mr *MockStoreMockRecorder) DeleteSession(ctx, id any) *gomock.Call {
+// ReleaseBackorderedStock indicates an expected call of ReleaseBackorderedStock.
+func (mr *MockStoreMockRecorder) ReleaseBackorderedStock(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseBackorderedStock", reflect.TypeOf((*MockStore)(nil).ReleaseBackorderedStock), ctx, arg)
 }
 
-// DeleteUser mocks base method.
-func (m *MockStore) DeleteUser(ctx context.Context, id int64) error {
+// ReleaseReservedStock mocks base method.
+func (m *MockStore) ReleaseReservedStock(ctx context.Context, arg sqlc.ReleaseReservedStockParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret := m.ctrl.Call(m, "ReleaseReservedStock", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockStoreMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+// ReleaseReservedStock indicates an expected call of ReleaseReservedStock.
+func (mr *MockStoreMockRecorder) ReleaseReservedStock(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservedStock", reflect.TypeOf((*MockStore)(nil).ReleaseReservedStock), ctx, arg)
 }
 
-// DeleteUserSessions mocks base method.
-func (m *MockStore) DeleteUserSessions(ctx context.Context, userID int64) error {
+// ReplyProductReview mocks base method.
+func (m *MockStore) ReplyProductReview(ctx context.Context, arg sqlc.ReplyProductReviewParams) (sqlc.ProductReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserSessions", ctx, userID)
+	ret := m.ctrl.Call(m, "ReplyProductReview", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplyProductReview indicates an expected call of ReplyProductReview.
+func (mr *MockStoreMockRecorder) ReplyProductReview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyProductReview", reflect.TypeOf((*MockStore)(nil).ReplyProductReview), ctx, arg)
+}
+
+// ReserveStock mocks base method.
+func (m *MockStore) ReserveStock(ctx context.Context, arg sqlc.ReserveStockParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveStock", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteUserSessions indicates an expected call of DeleteUserSessions.
-func (mr *MockStoreMockRecorder) DeleteUserSessions(ctx, userID any) *gomock.Call {
+// ReserveStock indicates an expected call of ReserveStock.
+func (mr *MockStoreMockRecorder) ReserveStock(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockStore)(nil).DeleteUserSessions), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStock", reflect.TypeOf((*MockStore)(nil).ReserveStock), ctx, arg)
 }
 
-// ExecTx mocks base method.
-func (m *MockStore) ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+// ResolveStockAlerts mocks base method.
+func (m *MockStore) ResolveStockAlerts(ctx context.Context, arg sqlc.ResolveStockAlertsParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExecTx", ctx, fn)
+	ret := m.ctrl.Call(m, "ResolveStockAlerts", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ExecTx indicates an expected call of ExecTx.
-func (mr *MockStoreMockRecorder) ExecTx(ctx, fn any) *gomock.Call {
+// ResolveStockAlerts indicates an expected call of ResolveStockAlerts.
+func (mr *MockStoreMockRecorder) ResolveStockAlerts(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTx", reflect.TypeOf((*MockStore)(nil).ExecTx), ctx, fn)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveStockAlerts", reflect.TypeOf((*MockStore)(nil).ResolveStockAlerts), ctx, arg)
 }
 
-// GetCartByUserID mocks base method.
-func (m *MockStore) GetCartByUserID(ctx context.Context, userID int64) ([]sqlc.Cart, error) {
+// ReviewExistsForOrderItem mocks base method.
+func (m *MockStore) ReviewExistsForOrderItem(ctx context.Context, orderItemID int64) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartByUserID", ctx, userID)
-	ret0, _ := ret[0].([]sqlc.Cart)
+	ret := m.ctrl.Call(m, "ReviewExistsForOrderItem", ctx, orderItemID)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCartByUserID indicates an expected call of GetCartByUserID.
-func (mr *MockStoreMockRecorder) GetCartByUserID(ctx, userID any) *gomock.Call {
+// ReviewExistsForOrderItem indicates an expected call of ReviewExistsForOrderItem.
+func (mr *MockStoreMockRecorder) ReviewExistsForOrderItem(ctx, orderItemID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByUserID", reflect.TypeOf((*MockStore)(nil).GetCartByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewExistsForOrderItem", reflect.TypeOf((*MockStore)(nil).ReviewExistsForOrderItem), ctx, orderItemID)
 }
 
-// GetCartItem mocks base method.
-func (m *MockStore) GetCartItem(ctx context.Context, arg sqlc.GetCartItemParams) (sqlc.Cart, error) {
+// SearchIndexBrandFacets mocks base method.
+func (m *MockStore) SearchIndexBrandFacets(ctx context.Context, arg sqlc.SearchIndexBrandFacetsParams) ([]sqlc.SearchIndexBrandFacetsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartItem", ctx, arg)
-	ret0, _ := ret[0].(sqlc.Cart)
+	ret := m.ctrl.Call(m, "SearchIndexBrandFacets", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SearchIndexBrandFacetsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCartItem indicates an expected call of GetCartItem.
-func (mr *MockStoreMockRecorder) GetCartItem(ctx, arg any) *gomock.Call {
+// SearchIndexBrandFacets indicates an expected call of SearchIndexBrandFacets.
+func (mr *MockStoreMockRecorder) SearchIndexBrandFacets(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItem", reflect.TypeOf((*MockStore)(nil).GetCartItem), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIndexBrandFacets", reflect.TypeOf((*MockStore)(nil).SearchIndexBrandFacets), ctx, arg)
 }
 
-// GetCartItemByProduct mocks base method.
-func (m *MockStore) GetCartItemByProduct(ctx context.Context, arg sqlc.GetCartItemByProductParams) (sqlc.Cart, error) {
+// SearchIndexPriceFacets mocks base method.
+func (m *MockStore) SearchIndexPriceFacets(ctx context.Context, arg sqlc.SearchIndexPriceFacetsParams) ([]sqlc.SearchIndexPriceFacetsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartItemByProduct", ctx, arg)
-	ret0, _ := ret[0].(sqlc.Cart)
+	ret := m.ctrl.Call(m, "SearchIndexPriceFacets", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SearchIndexPriceFacetsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCartItemByProduct indicates an expected call of GetCartItemByProduct.
-func (mr *MockStoreMockRecorder) GetCartItemByProduct(ctx, arg any) *gomock.Call {
+// SearchIndexPriceFacets indicates an expected call of SearchIndexPriceFacets.
+func (mr *MockStoreMockRecorder) SearchIndexPriceFacets(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItemByProduct", reflect.TypeOf((*MockStore)(nil).GetCartItemByProduct), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIndexPriceFacets", reflect.TypeOf((*MockStore)(nil).SearchIndexPriceFacets), ctx, arg)
+}
+
+// SearchIndexSpecFacets mocks base method.
+func (m *MockStore) SearchIndexSpecFacets(ctx context.Context, arg sqlc.SearchIndexSpecFacetsParams) ([]sqlc.SearchIndexSpecFacetsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchIndexSpecFacets", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SearchIndexSpecFacetsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchIndexSpecFacets indicates an expected call of SearchIndexSpecFacets.
+func (mr *MockStoreMockRecorder) SearchIndexSpecFacets(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIndexSpecFacets", reflect.TypeOf((*MockStore)(nil).SearchIndexSpecFacets), ctx, arg)
+}
+
+// SearchProductIndex mocks base method.
+func (m *MockStore) SearchProductIndex(ctx context.Context, arg sqlc.SearchProductIndexParams) ([]sqlc.SearchProductIndexRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchProductIndex", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SearchProductIndexRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchProductIndex indicates an expected call of SearchProductIndex.
+func (mr *MockStoreMockRecorder) SearchProductIndex(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProductIndex", reflect.TypeOf((*MockStore)(nil).SearchProductIndex), ctx, arg)
+}
+
+// SetStocktakeItemVariance mocks base method.
+func (m *MockStore) SetStocktakeItemVariance(ctx context.Context, arg sqlc.SetStocktakeItemVarianceParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStocktakeItemVariance", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStocktakeItemVariance indicates an expected call of SetStocktakeItemVariance.
+func (mr *MockStoreMockRecorder) SetStocktakeItemVariance(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStocktakeItemVariance", reflect.TypeOf((*MockStore)(nil).SetStocktakeItemVariance), ctx, arg)
+}
+
+// StartImportJob mocks base method.
+func (m *MockStore) StartImportJob(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImportJob", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartImportJob indicates an expected call of StartImportJob.
+func (mr *MockStoreMockRecorder) StartImportJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImportJob", reflect.TypeOf((*MockStore)(nil).StartImportJob), ctx, id)
 }
 
-// GetLatestVerificationCode mocks base method.
-func (m *MockStore) GetLatestVerificationCode(ctx context.Context, arg sqlc.GetLatestVerificationCodeParams) (sqlc.VerificationCode, error) {
+// SuggestBrands mocks base method.
+func (m *MockStore) SuggestBrands(ctx context.Context, arg sqlc.SuggestBrandsParams) ([]sqlc.SuggestBrandsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLatestVerificationCode", ctx, arg)
-	ret0, _ := ret[0].(sqlc.VerificationCode)
+	ret := m.ctrl.Call(m, "SuggestBrands", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SuggestBrandsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLatestVerificationCode indicates an expected call of GetLatestVerificationCode.
-func (mr *MockStoreMockRecorder) GetLatestVerificationCode(ctx, arg any) *gomock.Call {
+// SuggestBrands indicates an expected call of SuggestBrands.
+func (mr *MockStoreMockRecorder) SuggestBrands(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestVerificationCode", reflect.TypeOf((*MockStore)(nil).GetLatestVerificationCode), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestBrands", reflect.TypeOf((*MockStore)(nil).SuggestBrands), ctx, arg)
 }
 
-// GetProductByID mocks base method.
-func (m *MockStore) GetProductByID(ctx context.Context, id int64) (sqlc.Product, error) {
+// SuggestCategories mocks base method.
+func (m *MockStore) SuggestCategories(ctx context.Context, arg sqlc.SuggestCategoriesParams) ([]sqlc.SuggestCategoriesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductByID", ctx, id)
-	ret0, _ := ret[0].(sqlc.Product)
+	ret := m.ctrl.Call(m, "SuggestCategories", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SuggestCategoriesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductByID indicates an expected call of GetProductByID.
-func (mr *MockStoreMockRecorder) GetProductByID(ctx, id any) *gomock.Call {
+// SuggestCategories indicates an expected call of SuggestCategories.
+func (mr *MockStoreMockRecorder) SuggestCategories(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockStore)(nil).GetProductByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestCategories", reflect.TypeOf((*MockStore)(nil).SuggestCategories), ctx, arg)
 }
 
-// GetProductImages mocks base method.
-func (m *MockStore) GetProductImages(ctx context.Context, productID int64) ([]sqlc.ProductImage, error) {
+// SuggestProductNames mocks base method.
+func (m *MockStore) SuggestProductNames(ctx context.Context, arg sqlc.SuggestProductNamesParams) ([]sqlc.SuggestProductNamesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductImages", ctx, productID)
-	ret0, _ := ret[0].([]sqlc.ProductImage)
+	ret := m.ctrl.Call(m, "SuggestProductNames", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SuggestProductNamesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductImages indicates an expected call of GetProductImages.
-func (mr *MockStoreMockRecorder) GetProductImages(ctx, productID any) *gomock.Call {
+// SuggestProductNames indicates an expected call of SuggestProductNames.
+func (mr *MockStoreMockRecorder) SuggestProductNames(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImages", reflect.TypeOf((*MockStore)(nil).GetProductImages), ctx, productID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestProductNames", reflect.TypeOf((*MockStore)(nil).SuggestProductNames), ctx, arg)
 }
 
-// GetProductMainImage mocks base method.
-func (m *MockStore) GetProductMainImage(ctx context.Context, productID int64) (sqlc.ProductImage, error) {
+// UpdateAllCartSelected mocks base method.
+func (m *MockStore) UpdateAllCartSelected(ctx context.Context, arg sqlc.UpdateAllCartSelectedParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductMainImage", ctx, productID)
-	ret0, _ := ret[0].(sqlc.ProductImage)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateAllCartSelected", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetProductMainImage indicates an expected call of GetProductMainImage.
-func (mr *MockStoreMockRecorder) GetProductMainImage(ctx, productID any) *gomock.Call {
+// UpdateAllCartSelected indicates an expected call of UpdateAllCartSelected.
+func (mr *MockStoreMockRecorder) UpdateAllCartSelected(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductMainImage", reflect.TypeOf((*MockStore)(nil).GetProductMainImage), ctx, productID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAllCartSelected", reflect.TypeOf((*MockStore)(nil).UpdateAllCartSelected), ctx, arg)
 }
 
-// GetSelectedCartItems mocks base method.
-func (m *MockStore) GetSelectedCartItems(ctx context.Context, userID int64) ([]sqlc.Cart, error) {
+// UpdateCartQuantity mocks base method.
+func (m *MockStore) UpdateCartQuantity(ctx context.Context, arg sqlc.UpdateCartQuantityParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSelectedCartItems", ctx, userID)
-	ret0, _ := ret[0].([]sqlc.Cart)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateCartQuantity", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetSelectedCartItems indicates an expected call of GetSelectedCartItems.
-func (mr *MockStoreMockRecorder) GetSelectedCartItems(ctx, userID any) *gomock.Call {
+// UpdateCartQuantity indicates an expected call of UpdateCartQuantity.
+func (mr *MockStoreMockRecorder) UpdateCartQuantity(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSelectedCartItems", reflect.TypeOf((*MockStore)(nil).GetSelectedCartItems), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartQuantity", reflect.TypeOf((*MockStore)(nil).UpdateCartQuantity), ctx, arg)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (sqlc.Session, error) {
+// UpdateCartSelected mocks base method.
+func (m *MockStore) UpdateCartSelected(ctx context.Context, arg sqlc.UpdateCartSelectedParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", ctx, id)
-	ret0, _ := ret[0].(sqlc.Session)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateCartSelected", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+// UpdateCartSelected indicates an expected call of UpdateCartSelected.
+func (mr *MockStoreMockRecorder) UpdateCartSelected(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartSelected", reflect.TypeOf((*MockStore)(nil).UpdateCartSelected), ctx, arg)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
+// UpdateCategory mocks base method.
+func (m *MockStore) UpdateCategory(ctx context.Context, arg sqlc.UpdateCategoryParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
-	ret0, _ := ret[0].(sqlc.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockStoreMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockStoreMockRecorder) UpdateCategory(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), ctx, arg)
 }
 
-// GetUserByID mocks base method.
-func (m *MockStore) GetUserByID(ctx context.Context, id int64) (sqlc.User, error) {
+// UpdateImportJobProgress mocks base method.
+func (m *MockStore) UpdateImportJobProgress(ctx context.Context, arg sqlc.UpdateImportJobProgressParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
-	ret0, _ := ret[0].(sqlc.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateImportJobProgress", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockStoreMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+// UpdateImportJobProgress indicates an expected call of UpdateImportJobProgress.
+func (mr *MockStoreMockRecorder) UpdateImportJobProgress(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockStore)(nil).GetUserByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImportJobProgress", reflect.TypeOf((*MockStore)(nil).UpdateImportJobProgress), ctx, arg)
 }
 
-// GetUserByPhone mocks base method.
-func (m *MockStore) GetUserByPhone(ctx context.Context, phone *string) (sqlc.User, error) {
+// UpdateInventoryStock mocks base method.
+func (m *MockStore) UpdateInventoryStock(ctx context.Context, arg sqlc.UpdateInventoryStockParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByPhone", ctx, phone)
-	ret0, _ := ret[0].(sqlc.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateInventoryStock", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserByPhone indicates an expected call of GetUserByPhone.
-func (mr *MockStoreMockRecorder) GetUserByPhone(ctx, phone any) *gomock.Call {
+// UpdateInventoryStock indicates an expected call of UpdateInventoryStock.
+func (mr *MockStoreMockRecorder) UpdateInventoryStock(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByPhone", reflect.TypeOf((*MockStore)(nil).GetUserByPhone), ctx, phone)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventoryStock", reflect.TypeOf((*MockStore)(nil).UpdateInventoryStock), ctx, arg)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockStore) GetUserByUsername(ctx context.Context, username string) (sqlc.User, error) {
+// UpdateLowStockThreshold mocks base method.
+func (m *MockStore) UpdateLowStockThreshold(ctx context.Context, arg sqlc.UpdateLowStockThresholdParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
-	ret0, _ := ret[0].(sqlc.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateLowStockThreshold", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserByUsername indicates an expected call of GetUserByUsername.
-func (mr *MockStoreMockRecorder) GetUserByUsername(ctx, username any) *gomock.Call {
+// UpdateLowStockThreshold indicates an expected call of UpdateLowStockThreshold.
+func (mr *MockStoreMockRecorder) UpdateLowStockThreshold(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLowStockThreshold", reflect.TypeOf((*MockStore)(nil).UpdateLowStockThreshold), ctx, arg)
 }
 
-// GetUserSessions mocks base method.
-func (m *MockStore) GetUserSessions(ctx context.Context, userID int64) ([]sqlc.Session, error) {
+// UpdateOrderPaymentStatus mocks base method.
+func (m *MockStore) UpdateOrderPaymentStatus(ctx context.Context, arg sqlc.UpdateOrderPaymentStatusParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserSessions", ctx, userID)
-	ret0, _ := ret[0].([]sqlc.Session)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateOrderPaymentStatus", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserSessions indicates an expected call of GetUserSessions.
-func (mr *MockStoreMockRecorder) GetUserSessions(ctx, userID any) *gomock.Call {
+// UpdateOrderPaymentStatus indicates an expected call of UpdateOrderPaymentStatus.
+func (mr *MockStoreMockRecorder) UpdateOrderPaymentStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockStore)(nil).GetUserSessions), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderPaymentStatus", reflect.TypeOf((*MockStore)(nil).UpdateOrderPaymentStatus), ctx, arg)
 }
 
-// GetVerificationCode mocks base method.
-func (m *MockStore) GetVerificationCode(ctx context.Context, arg sqlc.GetVerificationCodeParams) (sqlc.VerificationCode, error) {
+// UpdateOrderShipStatus mocks base method.
+func (m *MockStore) UpdateOrderShipStatus(ctx context.Context, arg sqlc.UpdateOrderShipStatusParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVerificationCode", ctx, arg)
-	ret0, _ := ret[0].(sqlc.VerificationCode)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateOrderShipStatus", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetVerificationCode indicates an expected call of GetVerificationCode.
-func (mr *MockStoreMockRecorder) GetVerificationCode(ctx, arg any) *gomock.Call {
+// UpdateOrderShipStatus indicates an expected call of UpdateOrderShipStatus.
+func (mr *MockStoreMockRecorder) UpdateOrderShipStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerificationCode", reflect.TypeOf((*MockStore)(nil).GetVerificationCode), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderShipStatus", reflect.TypeOf((*MockStore)(nil).UpdateOrderShipStatus), ctx, arg)
 }
 
-// IncrementProductSales mocks base method.
-func (m *MockStore) IncrementProductSales(ctx context.Context, arg sqlc.IncrementProductSalesParams) error {
+// UpdateOrderStatus mocks base method.
+func (m *MockStore) UpdateOrderStatus(ctx context.Context, arg sqlc.UpdateOrderStatusParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IncrementProductSales", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IncrementProductSales indicates an expected call of IncrementProductSales.
-func (mr *MockStoreMockRecorder) IncrementProductSales(ctx, arg any) *gomock.Call {
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockStoreMockRecorder) UpdateOrderStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementProductSales", reflect.TypeOf((*MockStore)(nil).IncrementProductSales), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockStore)(nil).UpdateOrderStatus), ctx, arg)
 }
 
-// IncrementProductViews mocks base method.
-func (m *MockStore) IncrementProductViews(ctx context.Context, id int64) error {
+// UpdateProduct mocks base method.
+func (m *MockStore) UpdateProduct(ctx context.Context, arg sqlc.UpdateProductParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IncrementProductViews", ctx, id)
+	ret := m.ctrl.Call(m, "UpdateProduct", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IncrementProductViews indicates an expected call of IncrementProductViews.
-func (mr *MockStoreMockRecorder) IncrementProductViews(ctx, id any) *gomock.Call {
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockStoreMockRecorder) UpdateProduct(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementProductViews", reflect.TypeOf((*MockStore)(nil).IncrementProductViews), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockStore)(nil).UpdateProduct), ctx, arg)
 }
 
-// ListFeaturedProducts mocks base method.
-func (m *MockStore) ListFeaturedProducts(ctx context.Context, arg sqlc.ListFeaturedProductsParams) ([]sqlc.Product, error) {
+// UpdateProductCostPrice mocks base method.
+func (m *MockStore) UpdateProductCostPrice(ctx context.Context, arg sqlc.UpdateProductCostPriceParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListFeaturedProducts", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Product)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateProductCostPrice", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListFeaturedProducts indicates an expected call of ListFeaturedProducts.
-func (mr *MockStoreMockRecorder) ListFeaturedProducts(ctx, arg any) *gomock.Call {
+// UpdateProductCostPrice indicates an expected call of UpdateProductCostPrice.
+func (mr *MockStoreMockRecorder) UpdateProductCostPrice(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeaturedProducts", reflect.TypeOf((*MockStore)(nil).ListFeaturedProducts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductCostPrice", reflect.TypeOf((*MockStore)(nil).UpdateProductCostPrice), ctx, arg)
 }
 
-// ListProducts mocks base method.
-func (m *MockStore) ListProducts(ctx context.Context, arg sqlc.ListProductsParams) ([]sqlc.Product, error) {
+// UpdateProductImage mocks base method.
+func (m *MockStore) UpdateProductImage(ctx context.Context, arg sqlc.UpdateProductImageParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProducts", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Product)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateProductImage", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListProducts indicates an expected call of ListProducts.
-func (mr *MockStoreMockRecorder) ListProducts(ctx, arg any) *gomock.Call {
+// UpdateProductImage indicates an expected call of UpdateProductImage.
+func (mr *MockStoreMockRecorder) UpdateProductImage(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockStore)(nil).ListProducts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductImage", reflect.TypeOf((*MockStore)(nil).UpdateProductImage), ctx, arg)
 }
 
-// ListProductsByCategory mocks base method.
-func (m *MockStore) ListProductsByCategory(ctx context.Context, arg sqlc.ListProductsByCategoryParams) ([]sqlc.Product, error) {
+// UpdateProductReviewStatus mocks base method.
+func (m *MockStore) UpdateProductReviewStatus(ctx context.Context, arg sqlc.UpdateProductReviewStatusParams) (sqlc.ProductReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductsByCategory", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Product)
+	ret := m.ctrl.Call(m, "UpdateProductReviewStatus", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductReview)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProductsByCategory indicates an expected call of ListProductsByCategory.
-func (mr *MockStoreMockRecorder) ListProductsByCategory(ctx, arg any) *gomock.Call {
+// UpdateProductReviewStatus indicates an expected call of UpdateProductReviewStatus.
+func (mr *MockStoreMockRecorder) UpdateProductReviewStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByCategory", reflect.TypeOf((*MockStore)(nil).ListProductsByCategory), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductReviewStatus", reflect.TypeOf((*MockStore)(nil).UpdateProductReviewStatus), ctx, arg)
 }
 
-// ListUsers mocks base method.
-func (m *MockStore) ListUsers(ctx context.Context, arg sqlc.ListUsersParams) ([]sqlc.User, error) {
+// UpdateProductSku mocks base method.
+func (m *MockStore) UpdateProductSku(ctx context.Context, arg sqlc.UpdateProductSkuParams) (sqlc.ProductSku, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.User)
+	ret := m.ctrl.Call(m, "UpdateProductSku", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ProductSku)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockStoreMockRecorder) ListUsers(ctx, arg any) *gomock.Call {
+// UpdateProductSku indicates an expected call of UpdateProductSku.
+func (mr *MockStoreMockRecorder) UpdateProductSku(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductSku", reflect.TypeOf((*MockStore)(nil).UpdateProductSku), ctx, arg)
 }
 
-// MarkCodeAsUsed mocks base method.
-func (m *MockStore) MarkCodeAsUsed(ctx context.Context, id int64) error {
+// UpdateProductStock mocks base method.
+func (m *MockStore) UpdateProductStock(ctx context.Context, arg sqlc.UpdateProductStockParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkCodeAsUsed", ctx, id)
+	ret := m.ctrl.Call(m, "UpdateProductStock", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// MarkCodeAsUsed indicates an expected call of MarkCodeAsUsed.
-func (mr *MockStoreMockRecorder) MarkCodeAsUsed(ctx, id any) *gomock.Call {
+// UpdateProductStock indicates an expected call of UpdateProductStock.
+func (mr *MockStoreMockRecorder) UpdateProductStock(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCodeAsUsed", reflect.TypeOf((*MockStore)(nil).MarkCodeAsUsed), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductStock", reflect.TypeOf((*MockStore)(nil).UpdateProductStock), ctx, arg)
 }
 
-// SearchProducts mocks base method.
-func (m *MockStore) SearchProducts(ctx context.Context, arg sqlc.SearchProductsParams) ([]sqlc.Product, error) {
+// UpdateProductStockWithVersion mocks base method.
+func (m *MockStore) UpdateProductStockWithVersion(ctx context.Context, arg sqlc.UpdateProductStockWithVersionParams) (sqlc.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchProducts", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Product)
+	ret := m.ctrl.Call(m, "UpdateProductStockWithVersion", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchProducts indicates an expected call of SearchProducts.
-func (mr *MockStoreMockRecorder) SearchProducts(ctx, arg any) *gomock.Call {
+// UpdateProductStockWithVersion indicates an expected call of UpdateProductStockWithVersion.
+func (mr *MockStoreMockRecorder) UpdateProductStockWithVersion(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockStore)(nil).SearchProducts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductStockWithVersion", reflect.TypeOf((*MockStore)(nil).UpdateProductStockWithVersion), ctx, arg)
 }
 
-// UpdateAllCartSelected mocks base method.
-func (m *MockStore) UpdateAllCartSelected(ctx context.Context, arg sqlc.UpdateAllCartSelectedParams) error {
+// UpdateProductsStatus mocks base method.
+func (m *MockStore) UpdateProductsStatus(ctx context.Context, arg sqlc.UpdateProductsStatusParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAllCartSelected", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateProductsStatus", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateAllCartSelected indicates an expected call of UpdateAllCartSelected.
-func (mr *MockStoreMockRecorder) UpdateAllCartSelected(ctx, arg any) *gomock.Call {
+// UpdateProductsStatus indicates an expected call of UpdateProductsStatus.
+func (mr *MockStoreMockRecorder) UpdateProductsStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAllCartSelected", reflect.TypeOf((*MockStore)(nil).UpdateAllCartSelected), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductsStatus", reflect.TypeOf((*MockStore)(nil).UpdateProductsStatus), ctx, arg)
 }
 
-// UpdateCartQuantity mocks base method.
-func (m *MockStore) UpdateCartQuantity(ctx context.Context, arg sqlc.UpdateCartQuantityParams) error {
+// UpdatePurchaseOrderStatus mocks base method.
+func (m *MockStore) UpdatePurchaseOrderStatus(ctx context.Context, arg sqlc.UpdatePurchaseOrderStatusParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCartQuantity", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrderStatus", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateCartQuantity indicates an expected call of UpdateCartQuantity.
-func (mr *MockStoreMockRecorder) UpdateCartQuantity(ctx, arg any) *gomock.Call {
+// UpdatePurchaseOrderStatus indicates an expected call of UpdatePurchaseOrderStatus.
+func (mr *MockStoreMockRecorder) UpdatePurchaseOrderStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartQuantity", reflect.TypeOf((*MockStore)(nil).UpdateCartQuantity), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrderStatus", reflect.TypeOf((*MockStore)(nil).UpdatePurchaseOrderStatus), ctx, arg)
 }
 
-// UpdateCartSelected mocks base method.
-func (m *MockStore) UpdateCartSelected(ctx context.Context, arg sqlc.UpdateCartSelectedParams) error {
+// UpdateReservationStatus mocks base method.
+func (m *MockStore) UpdateReservationStatus(ctx context.Context, arg sqlc.UpdateReservationStatusParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCartSelected", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateReservationStatus", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateCartSelected indicates an expected call of UpdateCartSelected.
-func (mr *MockStoreMockRecorder) UpdateCartSelected(ctx, arg any) *gomock.Call {
+// UpdateReservationStatus indicates an expected call of UpdateReservationStatus.
+func (mr *MockStoreMockRecorder) UpdateReservationStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartSelected", reflect.TypeOf((*MockStore)(nil).UpdateCartSelected), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReservationStatus", reflect.TypeOf((*MockStore)(nil).UpdateReservationStatus), ctx, arg)
 }
 
-// UpdateProduct mocks base method.
-func (m *MockStore) UpdateProduct(ctx context.Context, arg sqlc.UpdateProductParams) error {
+// UpdateStockAlertLevel mocks base method.
+func (m *MockStore) UpdateStockAlertLevel(ctx context.Context, arg sqlc.UpdateStockAlertLevelParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProduct", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateStockAlertLevel", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateProduct indicates an expected call of UpdateProduct.
-func (mr *MockStoreMockRecorder) UpdateProduct(ctx, arg any) *gomock.Call {
+// UpdateStockAlertLevel indicates an expected call of UpdateStockAlertLevel.
+func (mr *MockStoreMockRecorder) UpdateStockAlertLevel(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockStore)(nil).UpdateProduct), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStockAlertLevel", reflect.TypeOf((*MockStore)(nil).UpdateStockAlertLevel), ctx, arg)
 }
 
-// UpdateProductImage mocks base method.
-func (m *MockStore) UpdateProductImage(ctx context.Context, arg sqlc.UpdateProductImageParams) error {
+// UpdateStockPolicy mocks base method.
+func (m *MockStore) UpdateStockPolicy(ctx context.Context, arg sqlc.UpdateStockPolicyParams) (sqlc.Inventory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProductImage", ctx, arg)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateStockPolicy", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateProductImage indicates an expected call of UpdateProductImage.
-func (mr *MockStoreMockRecorder) UpdateProductImage(ctx, arg any) *gomock.Call {
+// UpdateStockPolicy indicates an expected call of UpdateStockPolicy.
+func (mr *MockStoreMockRecorder) UpdateStockPolicy(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductImage", reflect.TypeOf((*MockStore)(nil).UpdateProductImage), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStockPolicy", reflect.TypeOf((*MockStore)(nil).UpdateStockPolicy), ctx, arg)
 }
 
-// UpdateProductStock mocks base method.
-func (m *MockStore) UpdateProductStock(ctx context.Context, arg sqlc.UpdateProductStockParams) error {
+// UpdateSupplier mocks base method.
+func (m *MockStore) UpdateSupplier(ctx context.Context, arg sqlc.UpdateSupplierParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProductStock", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateSupplier", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateProductStock indicates an expected call of UpdateProductStock.
-func (mr *MockStoreMockRecorder) UpdateProductStock(ctx, arg any) *gomock.Call {
+// UpdateSupplier indicates an expected call of UpdateSupplier.
+func (mr *MockStoreMockRecorder) UpdateSupplier(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductStock", reflect.TypeOf((*MockStore)(nil).UpdateProductStock), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSupplier", reflect.TypeOf((*MockStore)(nil).UpdateSupplier), ctx, arg)
 }
 
 // UpdateUser mocks base method.
@@ -841,6 +3744,35 @@ func (mr *MockStoreMockRecorder) UpdateUserStatus(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockStore)(nil).UpdateUserStatus), ctx, arg)
 }
 
+// UpsertReorderSetting mocks base method.
+func (m *MockStore) UpsertReorderSetting(ctx context.Context, arg sqlc.UpsertReorderSettingParams) (sqlc.ReorderSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertReorderSetting", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ReorderSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertReorderSetting indicates an expected call of UpsertReorderSetting.
+func (mr *MockStoreMockRecorder) UpsertReorderSetting(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertReorderSetting", reflect.TypeOf((*MockStore)(nil).UpsertReorderSetting), ctx, arg)
+}
+
+// UpsertReorderSuggestions mocks base method.
+func (m *MockStore) UpsertReorderSuggestions(ctx context.Context, arg sqlc.UpsertReorderSuggestionsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertReorderSuggestions", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertReorderSuggestions indicates an expected call of UpsertReorderSuggestions.
+func (mr *MockStoreMockRecorder) UpsertReorderSuggestions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertReorderSuggestions", reflect.TypeOf((*MockStore)(nil).UpsertReorderSuggestions), ctx, arg)
+}
+
 // VerifyUserEmail mocks base method.
 func (m *MockStore) VerifyUserEmail(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
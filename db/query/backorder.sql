@@ -0,0 +1,46 @@
+-- Backorder Queries
+
+-- name: CreateBackorder :one
+INSERT INTO inventory_backorders (
+    product_id,
+    sku_id,
+    order_id,
+    quantity
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListPendingBackordersForUpdate :many
+SELECT * FROM inventory_backorders
+WHERE product_id = sqlc.arg(product_id)
+    AND sku_id IS NOT DISTINCT FROM sqlc.narg(sku_id)
+    AND status = 'pending'
+ORDER BY id
+FOR UPDATE;
+
+-- name: ListBackorders :many
+SELECT * FROM inventory_backorders
+WHERE product_id = sqlc.arg(product_id)
+    AND (sqlc.narg(sku_id)::bigint IS NULL OR sku_id = sqlc.narg(sku_id)::bigint)
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar)
+ORDER BY id
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountBackorders :one
+SELECT COUNT(*) FROM inventory_backorders
+WHERE product_id = sqlc.arg(product_id)
+    AND (sqlc.narg(sku_id)::bigint IS NULL OR sku_id = sqlc.narg(sku_id)::bigint)
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar);
+
+-- name: MarkBackorderAllocated :exec
+UPDATE inventory_backorders
+SET
+    status = 'allocated',
+    allocated_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- name: CancelOrderBackorders :many
+UPDATE inventory_backorders
+SET status = 'cancelled'
+WHERE order_id = $1 AND status = 'pending'
+RETURNING *;
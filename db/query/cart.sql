@@ -1,7 +1,7 @@
 -- name: AddToCart :one
-INSERT INTO carts (user_id, product_id, quantity, selected)
-VALUES ($1, $2, $3, TRUE)
-    ON CONFLICT (user_id, product_id)
+INSERT INTO carts (user_id, product_id, sku_id, quantity, selected)
+VALUES ($1, $2, $3, $4, TRUE)
+    ON CONFLICT (user_id, product_id, sku_id)
   DO UPDATE SET
     quantity = carts.quantity + EXCLUDED.quantity,
              updated_at = NOW()
@@ -19,7 +19,10 @@ WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 
 -- name: GetCartItemByProduct :one
 SELECT * FROM carts
-WHERE user_id = $1 AND product_id = $2 AND deleted_at IS NULL
+WHERE user_id = sqlc.arg(user_id)
+    AND product_id = sqlc.arg(product_id)
+    AND sku_id IS NOT DISTINCT FROM sqlc.narg(sku_id)
+    AND deleted_at IS NULL
     LIMIT 1;
 
 -- name: UpdateCartQuantity :exec
@@ -3,15 +3,29 @@
 -- name: CreateInventory :one
 INSERT INTO inventory (
     product_id,
+    sku_id,
     available_stock,
     reserved_stock,
     low_stock_threshold
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetInventoryByProductID :one
 SELECT * FROM inventory
+WHERE product_id = $1 AND sku_id IS NULL AND deleted_at IS NULL;
+
+-- name: GetInventoryBySkuID :one
+SELECT * FROM inventory
+WHERE sku_id = $1 AND deleted_at IS NULL;
+
+-- name: GetInventoriesBySkuIDs :many
+SELECT * FROM inventory
+WHERE sku_id = ANY(sqlc.arg(sku_ids)::bigint[]) AND deleted_at IS NULL;
+
+-- name: GetProductOnHandStock :one
+SELECT COALESCE(SUM(available_stock + reserved_stock), 0)::int AS on_hand
+FROM inventory
 WHERE product_id = $1 AND deleted_at IS NULL;
 
 -- name: GetInventoryByID :one
@@ -30,6 +44,29 @@ WHERE available_stock <= low_stock_threshold AND deleted_at IS NULL
 ORDER BY available_stock ASC
 LIMIT $1 OFFSET $2;
 
+-- name: GetInventoriesByProductIDs :many
+SELECT * FROM inventory
+WHERE product_id = ANY(sqlc.arg(product_ids)::bigint[]) AND sku_id IS NULL AND deleted_at IS NULL;
+
+-- name: ListInventoriesForExport :many
+SELECT
+    i.id,
+    i.product_id,
+    i.sku_id,
+    s.sku_code,
+    p.name AS product_name,
+    i.available_stock,
+    i.reserved_stock,
+    i.total_stock,
+    i.low_stock_threshold,
+    i.updated_at
+FROM inventory i
+JOIN products p ON p.id = i.product_id
+LEFT JOIN product_skus s ON s.id = i.sku_id
+WHERE i.id > sqlc.arg(after_id) AND i.deleted_at IS NULL
+ORDER BY i.id
+LIMIT sqlc.arg(batch_size);
+
 -- name: CountInventories :one
 SELECT COUNT(*) FROM inventory
 WHERE deleted_at IS NULL;
@@ -45,7 +82,7 @@ SET
     reserved_stock = $2,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $3 AND version = $4 AND deleted_at IS NULL;
+WHERE id = $3 AND version = $4 AND deleted_at IS NULL;
 
 -- name: ReserveStock :exec
 UPDATE inventory
@@ -54,11 +91,55 @@ SET
     reserved_stock = reserved_stock + $1,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $2
+WHERE id = $2
     AND available_stock >= $1
     AND version = $3
     AND deleted_at IS NULL;
 
+-- name: UpdateStockPolicy :one
+UPDATE inventory
+SET
+    stock_policy = sqlc.arg(stock_policy),
+    backorder_limit = sqlc.narg(backorder_limit),
+    preorder_release_at = sqlc.narg(preorder_release_at),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+RETURNING *;
+
+-- name: AddBackorderedStock :execrows
+UPDATE inventory
+SET
+    backordered_stock = backordered_stock + sqlc.arg(quantity),
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND version = sqlc.arg(version)
+    AND deleted_at IS NULL;
+
+-- name: AllocateBackorderedStock :execrows
+UPDATE inventory
+SET
+    available_stock = available_stock - sqlc.arg(quantity),
+    reserved_stock = reserved_stock + sqlc.arg(quantity),
+    backordered_stock = backordered_stock - sqlc.arg(quantity),
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND available_stock >= sqlc.arg(quantity)
+    AND backordered_stock >= sqlc.arg(quantity)
+    AND version = sqlc.arg(version)
+    AND deleted_at IS NULL;
+
+-- name: ReleaseBackorderedStock :exec
+UPDATE inventory
+SET
+    backordered_stock = backordered_stock - sqlc.arg(quantity),
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND backordered_stock >= sqlc.arg(quantity)
+    AND deleted_at IS NULL;
+
 -- name: ReleaseReservedStock :exec
 UPDATE inventory
 SET
@@ -66,7 +147,7 @@ SET
     reserved_stock = reserved_stock - $1,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $2
+WHERE id = $2
     AND reserved_stock >= $1
     AND version = $3
     AND deleted_at IS NULL;
@@ -77,7 +158,7 @@ SET
     reserved_stock = reserved_stock - $1,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $2
+WHERE id = $2
     AND reserved_stock >= $1
     AND version = $3
     AND deleted_at IS NULL;
@@ -88,14 +169,14 @@ SET
     available_stock = available_stock + $1,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $2 AND deleted_at IS NULL;
+WHERE id = $2 AND deleted_at IS NULL;
 
 -- name: UpdateLowStockThreshold :exec
 UPDATE inventory
 SET
     low_stock_threshold = $1,
     updated_at = NOW()
-WHERE product_id = $2 AND deleted_at IS NULL;
+WHERE id = $2 AND deleted_at IS NULL;
 
 -- name: DeleteInventory :exec
 UPDATE inventory
@@ -103,11 +184,18 @@ SET
     deleted_at = NOW()
 WHERE product_id = $1 AND deleted_at IS NULL;
 
+-- name: DeleteSkuInventory :exec
+UPDATE inventory
+SET
+    deleted_at = NOW()
+WHERE sku_id = $1 AND deleted_at IS NULL;
+
 -- Inventory Logs Queries
 
 -- name: CreateInventoryLog :one
 INSERT INTO inventory_logs (
     product_id,
+    sku_id,
     order_id,
     change_type,
     quantity_change,
@@ -116,9 +204,11 @@ INSERT INTO inventory_logs (
     before_reserved,
     after_reserved,
     reason,
-    operator_id
+    operator_id,
+    reference_type,
+    reference_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 ) RETURNING *;
 
 -- name: GetInventoryLogsByProductID :many
@@ -136,17 +226,69 @@ ORDER BY created_at DESC;
 SELECT COUNT(*) FROM inventory_logs
 WHERE product_id = $1;
 
+-- name: GetLastInventoryLogAtOrBefore :one
+SELECT * FROM inventory_logs
+WHERE product_id = sqlc.arg(product_id)
+    AND sku_id IS NOT DISTINCT FROM sqlc.narg(sku_id)
+    AND created_at <= sqlc.arg(as_of)
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- name: GetFirstInventoryLogAfter :one
+SELECT * FROM inventory_logs
+WHERE product_id = sqlc.arg(product_id)
+    AND sku_id IS NOT DISTINCT FROM sqlc.narg(sku_id)
+    AND created_at > sqlc.arg(as_of)
+ORDER BY created_at, id
+LIMIT 1;
+
+-- name: GetInventoryMovementReport :many
+SELECT
+    l.product_id,
+    p.name AS product_name,
+    date_trunc(sqlc.arg(period)::text, l.created_at)::timestamptz AS period_start,
+    COALESCE(SUM(l.quantity_change) FILTER (WHERE l.change_type = 'restock'), 0)::bigint AS restocked,
+    COALESCE(SUM(ABS(l.quantity_change)) FILTER (WHERE l.change_type = 'reserve'), 0)::bigint AS reserved,
+    COALESCE(SUM(ABS(l.quantity_change)) FILTER (WHERE l.change_type = 'release'), 0)::bigint AS released,
+    COALESCE(SUM(ABS(l.quantity_change)) FILTER (WHERE l.change_type = 'deduct'), 0)::bigint AS deducted,
+    COALESCE(SUM(l.quantity_change) FILTER (WHERE l.change_type = 'adjust'), 0)::bigint AS adjusted,
+    SUM(l.after_available - l.before_available)::bigint AS net_available_change,
+    SUM(l.after_reserved - l.before_reserved)::bigint AS net_reserved_change,
+    COUNT(*) AS movement_count
+FROM inventory_logs l
+JOIN products p ON p.id = l.product_id
+WHERE l.created_at >= sqlc.arg(from_time) AND l.created_at < sqlc.arg(to_time)
+  AND (sqlc.narg(product_id)::bigint IS NULL OR l.product_id = sqlc.narg(product_id)::bigint)
+GROUP BY l.product_id, p.name, period_start
+ORDER BY period_start, l.product_id
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountInventoryMovementReport :one
+SELECT COUNT(*) FROM (
+    SELECT 1
+    FROM inventory_logs l
+    WHERE l.created_at >= sqlc.arg(from_time) AND l.created_at < sqlc.arg(to_time)
+      AND (sqlc.narg(product_id)::bigint IS NULL OR l.product_id = sqlc.narg(product_id)::bigint)
+    GROUP BY l.product_id, date_trunc(sqlc.arg(period)::text, l.created_at)
+) AS periods;
+
+-- name: GetInventoryLogsByReference :many
+SELECT * FROM inventory_logs
+WHERE reference_type = $1 AND reference_id = $2
+ORDER BY id;
+
 -- Inventory Reservations Queries
 
 -- name: CreateInventoryReservation :one
 INSERT INTO inventory_reservations (
     product_id,
+    sku_id,
     order_id,
     quantity,
     status,
     expires_at
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetInventoryReservationByID :one
@@ -176,15 +318,30 @@ UPDATE inventory_reservations
 SET
     status = 'confirmed',
     updated_at = NOW()
-WHERE order_id = $1 AND status = 'active' AND deleted_at IS NULL;
+WHERE order_id = $1 AND status IN ('active', 'held') AND deleted_at IS NULL;
 
 -- name: CancelReservation :exec
 UPDATE inventory_reservations
 SET
     status = 'cancelled',
     updated_at = NOW()
+WHERE order_id = $1 AND status IN ('active', 'held') AND deleted_at IS NULL;
+
+-- name: HoldOrderReservations :exec
+UPDATE inventory_reservations
+SET
+    status = 'held',
+    updated_at = NOW()
 WHERE order_id = $1 AND status = 'active' AND deleted_at IS NULL;
 
+-- name: ActivateOrderReservations :exec
+UPDATE inventory_reservations
+SET
+    status = 'active',
+    expires_at = $2,
+    updated_at = NOW()
+WHERE order_id = $1 AND status = 'held' AND deleted_at IS NULL;
+
 -- name: GetExpiredReservations :many
 SELECT * FROM inventory_reservations
 WHERE status = 'active'
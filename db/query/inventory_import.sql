@@ -0,0 +1,41 @@
+-- Inventory Import Jobs Queries
+
+-- name: CreateImportJob :one
+INSERT INTO inventory_import_jobs (
+    filename,
+    total_rows,
+    operator_id
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetImportJob :one
+SELECT * FROM inventory_import_jobs
+WHERE id = $1;
+
+-- name: StartImportJob :exec
+UPDATE inventory_import_jobs
+SET
+    status = 'running',
+    started_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- name: UpdateImportJobProgress :exec
+UPDATE inventory_import_jobs
+SET
+    processed_rows = $1,
+    succeeded_rows = $2,
+    failed_rows = $3,
+    errors = $4,
+    updated_at = NOW()
+WHERE id = $5;
+
+-- name: FinishImportJob :exec
+UPDATE inventory_import_jobs
+SET
+    status = $1,
+    error_message = $2,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $3;
@@ -69,19 +69,33 @@ SET
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: PromoteBackorderedOrder :execrows
+UPDATE orders o
+SET
+    status = 'pending',
+    updated_at = NOW()
+WHERE o.id = $1
+    AND o.status = 'backordered'
+    AND o.deleted_at IS NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM inventory_backorders b
+        WHERE b.order_id = o.id AND b.status = 'pending'
+    );
+
 -- Order Items Queries
 
 -- name: CreateOrderItem :one
 INSERT INTO order_items (
     order_id,
     product_id,
+    sku_id,
     product_name,
     product_image,
     quantity,
     unit_price,
     total_price
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING *;
 
 -- name: GetOrderItems :many
@@ -12,14 +12,6 @@ SELECT * FROM products
 WHERE id = $1 AND deleted_at IS NULL
     LIMIT 1;
 
--- name: ListProducts :many
-SELECT * FROM products
-WHERE deleted_at IS NULL
-  AND (sqlc.narg('category_id')::bigint IS NULL OR category_id = sqlc.narg('category_id'))
-  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
-ORDER BY created_at DESC
-    LIMIT $1 OFFSET $2;
-
 -- name: ListProductsByCategory :many
 SELECT * FROM products
 WHERE category_id = $1
@@ -51,6 +43,18 @@ SET
     updated_at = NOW()
 WHERE id = sqlc.arg('id') AND deleted_at IS NULL;
 
+-- name: GetProductCostForUpdate :one
+SELECT cost_price FROM products
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: UpdateProductCostPrice :exec
+UPDATE products
+SET
+    cost_price = $1,
+    updated_at = NOW()
+WHERE id = $2;
+
 -- name: UpdateProductStock :exec
 UPDATE products
 SET
@@ -67,33 +71,137 @@ WHERE id = $2
   AND stock >= $1
   AND deleted_at IS NULL;
 
--- name: IncrementProductViews :exec
-UPDATE products
-SET view_count = view_count + 1
+-- name: GetProductLiveFields :one
+-- Detail fields moved by orders, reviews and view counts; read on every detail request instead of cached
+SELECT stock, sales_count, view_count, rating_total, rating_count
+FROM products
 WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: AddProductViews :exec
+-- Applies batched view counts; product_ids and views are parallel arrays
+UPDATE products AS p
+SET view_count = p.view_count + v.views
+FROM (
+    SELECT unnest(@product_ids::bigint[]) AS id, unnest(@views::int[]) AS views
+) AS v
+WHERE p.id = v.id AND p.deleted_at IS NULL;
+
 -- name: IncrementProductSales :exec
 UPDATE products
 SET sales_count = sales_count + $1
 WHERE id = $2 AND deleted_at IS NULL;
 
+-- name: GetHotProductActivity :many
+-- Paid quantities per product since a cutoff, decayed to now with the given half-life;
+-- used to rebuild the hot product rankings
+SELECT p.id AS product_id,
+       p.category_id,
+       s.score::float8 AS sales_score
+FROM products p
+JOIN (
+    SELECT oi.product_id,
+           SUM(oi.quantity * power(2, -EXTRACT(EPOCH FROM NOW() - COALESCE(o.paid_at, o.updated_at)) / @half_life_seconds::float8)) AS score
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.status IN ('paid', 'shipped', 'completed')
+      AND COALESCE(o.paid_at, o.updated_at) >= @since::timestamptz
+    GROUP BY oi.product_id
+) s ON s.product_id = p.id
+WHERE p.deleted_at IS NULL;
+
+-- name: ListProductCategoryIDs :many
+SELECT DISTINCT category_id FROM products
+WHERE deleted_at IS NULL;
+
+-- name: DeleteProductAssociations :exec
+DELETE FROM product_associations;
+
+-- name: InsertProductAssociations :execrows
+-- Pairs of products bought in the same order since a cutoff, keeping for each product the
+-- max_per_product strongest pairs that meet both thresholds
+INSERT INTO product_associations (product_id, related_product_id, support, confidence, computed_at)
+SELECT ranked.product_id, ranked.related_product_id, ranked.support, ranked.confidence, NOW()
+FROM (
+    SELECT pairs.product_id,
+           pairs.related_product_id,
+           pairs.support,
+           pairs.support::float8 / totals.orders AS confidence,
+           ROW_NUMBER() OVER (
+               PARTITION BY pairs.product_id
+               ORDER BY pairs.support::float8 / totals.orders DESC, pairs.support DESC, pairs.related_product_id
+           ) AS position
+    FROM (
+        SELECT a.product_id, b.product_id AS related_product_id, COUNT(DISTINCT a.order_id)::int AS support
+        FROM order_items a
+        JOIN order_items b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+        JOIN orders o ON o.id = a.order_id
+        WHERE o.created_at >= sqlc.arg(since)::timestamptz
+          AND o.status NOT IN ('cancelled', 'refunded')
+          AND o.deleted_at IS NULL
+          AND a.deleted_at IS NULL
+          AND b.deleted_at IS NULL
+        GROUP BY a.product_id, b.product_id
+    ) pairs
+    JOIN (
+        SELECT oi.product_id, COUNT(DISTINCT oi.order_id) AS orders
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        WHERE o.created_at >= sqlc.arg(since)::timestamptz
+          AND o.status NOT IN ('cancelled', 'refunded')
+          AND o.deleted_at IS NULL
+          AND oi.deleted_at IS NULL
+        GROUP BY oi.product_id
+    ) totals ON totals.product_id = pairs.product_id
+    WHERE pairs.support >= sqlc.arg(min_support)::int
+      AND pairs.support::float8 / totals.orders >= sqlc.arg(min_confidence)::float8
+) ranked
+WHERE ranked.position <= sqlc.arg(max_per_product)::int;
+
+-- name: ListAlsoBoughtProducts :many
+-- Products bought together with a product, strongest first, that can be bought right now
+SELECT sqlc.embed(p), a.support, a.confidence
+FROM product_associations a
+JOIN products p ON p.id = a.related_product_id
+JOIN (
+    -- Available stock summed over the product-level row and the rows of active SKUs
+    SELECT i.product_id, SUM(i.available_stock) AS available_stock
+    FROM inventory i
+    LEFT JOIN product_skus s ON s.id = i.sku_id
+    WHERE i.deleted_at IS NULL
+      AND (i.sku_id IS NULL OR (s.is_active AND s.deleted_at IS NULL))
+    GROUP BY i.product_id
+) stock ON stock.product_id = p.id
+WHERE a.product_id = $1
+  AND p.status = 'published'
+  AND stock.available_stock > 0
+  AND p.deleted_at IS NULL
+ORDER BY a.confidence DESC, a.support DESC, p.id
+LIMIT $2;
+
+-- name: ListCategoryBestSellers :many
+-- Published, in-stock best sellers of a category, skipping the given products
+SELECT p.* FROM products p
+JOIN (
+    SELECT i.product_id, SUM(i.available_stock) AS available_stock
+    FROM inventory i
+    LEFT JOIN product_skus s ON s.id = i.sku_id
+    WHERE i.deleted_at IS NULL
+      AND (i.sku_id IS NULL OR (s.is_active AND s.deleted_at IS NULL))
+    GROUP BY i.product_id
+) stock ON stock.product_id = p.id
+WHERE p.category_id = sqlc.arg(category_id)
+  AND p.id <> ALL(sqlc.arg(exclude_ids)::bigint[])
+  AND p.status = 'published'
+  AND stock.available_stock > 0
+  AND p.deleted_at IS NULL
+ORDER BY p.sales_count DESC, p.id
+LIMIT sqlc.arg(row_limit);
+
 -- name: DeleteProduct :exec
 UPDATE products
 SET deleted_at = NOW()
 WHERE id = $1;
 
--- name: SearchProducts :many
-SELECT * FROM products
-WHERE deleted_at IS NULL
-  AND status = 'published'
-  AND (name ILIKE '%' || $1 || '%' OR description ILIKE '%' || $1 || '%')
-ORDER BY sales_count DESC
-    LIMIT $2 OFFSET $3;
-
--- name: CountProducts :one
-SELECT COUNT(*) FROM products
-WHERE deleted_at IS NULL;
-
 -- Product Images
 
 -- name: CreateProductImage :one
@@ -104,11 +212,30 @@ INSERT INTO product_images (
          )
     RETURNING *;
 
+-- name: CreateUploadedProductImage :one
+-- Records an uploaded original (parent_id NULL) or one of its thumbnail renditions
+INSERT INTO product_images (
+    product_id, parent_id, rendition, image_url, storage_key,
+    content_type, width, height, size_bytes, sort, is_main
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+         )
+    RETURNING *;
+
+-- name: GetProductImage :one
+SELECT * FROM product_images
+WHERE id = $1 AND deleted_at IS NULL;
+
 -- name: GetProductImages :many
 SELECT * FROM product_images
-WHERE product_id = $1 AND deleted_at IS NULL
+WHERE product_id = $1 AND parent_id IS NULL AND deleted_at IS NULL
 ORDER BY sort ASC, id ASC;
 
+-- name: GetProductImageRenditions :many
+SELECT * FROM product_images
+WHERE product_id = $1 AND parent_id IS NOT NULL AND deleted_at IS NULL
+ORDER BY parent_id ASC, width ASC;
+
 -- name: GetProductMainImage :one
 SELECT * FROM product_images
 WHERE product_id = $1 AND is_main = TRUE AND deleted_at IS NULL
@@ -126,7 +253,7 @@ WHERE id = sqlc.arg('id') AND deleted_at IS NULL;
 -- name: DeleteProductImage :exec
 UPDATE product_images
 SET deleted_at = NOW()
-WHERE id = $1;
+WHERE id = $1 OR parent_id = $1;
 
 -- name: DeleteProductImages :exec
 UPDATE product_images
@@ -135,6 +262,13 @@ WHERE product_id = $1;
 
 -- Batch Operations
 
+-- name: ListProductsForIndex :many
+SELECT * FROM products
+WHERE deleted_at IS NULL
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(batch_size);
+
 -- name: GetProductsByIDs :many
 SELECT * FROM products
 WHERE id = ANY($1::bigint[])
@@ -144,6 +278,7 @@ ORDER BY sales_count DESC;
 -- name: GetImagesByProductIDs :many
 SELECT * FROM product_images
 WHERE product_id = ANY($1::bigint[])
+  AND parent_id IS NULL
   AND deleted_at IS NULL
 ORDER BY product_id ASC, sort ASC, id ASC;
 
@@ -0,0 +1,110 @@
+-- Product Option Queries
+
+-- name: CreateProductOption :one
+INSERT INTO product_options (
+    product_id,
+    name,
+    position
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: CreateProductOptionValue :one
+INSERT INTO product_option_values (
+    option_id,
+    value,
+    position
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ListProductOptionValues :many
+SELECT
+    o.id AS option_id,
+    o.name AS option_name,
+    o.position AS option_position,
+    v.id AS value_id,
+    v.value,
+    v.position AS value_position
+FROM product_options o
+JOIN product_option_values v ON v.option_id = o.id
+WHERE o.product_id = $1
+ORDER BY o.position, o.id, v.position, v.id;
+
+-- name: DeleteProductOptions :exec
+DELETE FROM product_options
+WHERE product_id = $1;
+
+-- Product SKU Queries
+
+-- name: CreateProductSku :one
+INSERT INTO product_skus (
+    product_id,
+    sku_code,
+    price,
+    origin_price,
+    image_url,
+    barcode,
+    options
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetProductSku :one
+SELECT * FROM product_skus
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetConflictingProductSku :one
+SELECT * FROM product_skus
+WHERE deleted_at IS NULL
+    AND (sku_code = sqlc.arg(sku_code) OR barcode = sqlc.narg(barcode))
+LIMIT 1;
+
+-- name: ListProductSkus :many
+SELECT
+    s.*,
+    COALESCE(i.available_stock, 0)::int AS available_stock
+FROM product_skus s
+LEFT JOIN inventory i ON i.sku_id = s.id AND i.deleted_at IS NULL
+WHERE s.product_id = $1 AND s.deleted_at IS NULL
+ORDER BY s.id;
+
+-- name: ListProductSkuStock :many
+-- Current available stock of a product's SKUs, read live for the cached product detail
+SELECT s.id, COALESCE(i.available_stock, 0)::int AS available_stock
+FROM product_skus s
+LEFT JOIN inventory i ON i.sku_id = s.id AND i.deleted_at IS NULL
+WHERE s.product_id = $1 AND s.deleted_at IS NULL;
+
+-- name: ListActiveSkusByProductIDs :many
+SELECT * FROM product_skus
+WHERE product_id = ANY(sqlc.arg(product_ids)::bigint[])
+    AND is_active = TRUE
+    AND deleted_at IS NULL
+ORDER BY product_id, id;
+
+-- name: ListProductSkusByCodes :many
+SELECT id, product_id, sku_code FROM product_skus
+WHERE sku_code = ANY(sqlc.arg(sku_codes)::text[]) AND deleted_at IS NULL;
+
+-- name: CountProductSkus :one
+SELECT COUNT(*) FROM product_skus
+WHERE product_id = $1 AND deleted_at IS NULL;
+
+-- name: UpdateProductSku :one
+UPDATE product_skus
+SET
+    price = COALESCE(sqlc.narg(price), price),
+    origin_price = COALESCE(sqlc.narg(origin_price), origin_price),
+    image_url = COALESCE(sqlc.narg(image_url), image_url),
+    barcode = COALESCE(sqlc.narg(barcode), barcode),
+    is_active = COALESCE(sqlc.narg(is_active), is_active),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND product_id = sqlc.arg(product_id) AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteProductSku :execrows
+UPDATE product_skus
+SET
+    deleted_at = NOW()
+WHERE id = $1 AND product_id = $2 AND deleted_at IS NULL;
@@ -0,0 +1,55 @@
+-- Typeahead and spelling suggestions (pg_trgm)
+-- pattern is the escaped keyword wrapped as '%q%', prefix_pattern as 'q%'
+
+-- name: SuggestProductNames :many
+SELECT p.id, p.name, word_similarity(sqlc.arg(keyword)::text, p.name)::REAL AS score
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND p.status = 'published'
+  AND (p.name ILIKE sqlc.arg(pattern)::text OR sqlc.arg(keyword)::text <% p.name)
+ORDER BY (p.name ILIKE sqlc.arg(prefix_pattern)::text) DESC, score DESC, p.sales_count DESC, p.id
+LIMIT sqlc.arg(max_results);
+
+-- name: SuggestBrands :many
+SELECT p.brand::text AS brand, COUNT(*) AS product_count
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND p.status = 'published'
+  AND p.brand IS NOT NULL
+  AND (p.brand ILIKE sqlc.arg(pattern)::text OR sqlc.arg(keyword)::text <% p.brand)
+GROUP BY p.brand
+ORDER BY bool_or(p.brand ILIKE sqlc.arg(prefix_pattern)::text) DESC,
+         MAX(word_similarity(sqlc.arg(keyword)::text, p.brand)) DESC,
+         product_count DESC
+LIMIT sqlc.arg(max_results);
+
+-- name: SuggestCategories :many
+SELECT c.id, c.name, c.slug
+FROM categories c
+WHERE c.deleted_at IS NULL
+  AND c.is_active = TRUE
+  AND (c.name ILIKE sqlc.arg(pattern)::text OR sqlc.arg(keyword)::text <% c.name)
+ORDER BY (c.name ILIKE sqlc.arg(prefix_pattern)::text) DESC,
+         word_similarity(sqlc.arg(keyword)::text, c.name) DESC,
+         c.sort, c.id
+LIMIT sqlc.arg(max_results);
+
+-- name: ListSpellingSuggestions :many
+-- Candidates are whole brand and category names plus the individual words of product names
+SELECT t.term::text AS term, similarity(t.term, sqlc.arg(keyword)::text)::REAL AS score
+FROM (
+    SELECT DISTINCT lower(w.word) AS term
+    FROM products p
+    CROSS JOIN LATERAL regexp_split_to_table(p.name, '\s+') AS w(word)
+    WHERE p.deleted_at IS NULL AND p.status = 'published'
+    UNION
+    SELECT lower(p.brand) FROM products p
+    WHERE p.deleted_at IS NULL AND p.status = 'published' AND p.brand IS NOT NULL
+    UNION
+    SELECT lower(c.name) FROM categories c
+    WHERE c.deleted_at IS NULL AND c.is_active = TRUE
+) t
+WHERE t.term % sqlc.arg(keyword)::text
+  AND t.term <> lower(sqlc.arg(keyword)::text)
+ORDER BY score DESC, t.term
+LIMIT sqlc.arg(max_results);
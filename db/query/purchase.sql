@@ -0,0 +1,141 @@
+-- name: CreateSupplier :one
+INSERT INTO suppliers (
+    name,
+    contact_name,
+    email,
+    phone,
+    address
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetSupplier :one
+SELECT * FROM suppliers
+WHERE id = $1;
+
+-- name: ListSuppliers :many
+SELECT * FROM suppliers
+WHERE sqlc.narg(is_active)::boolean IS NULL OR is_active = sqlc.narg(is_active)::boolean
+ORDER BY name
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountSuppliers :one
+SELECT COUNT(*) FROM suppliers
+WHERE sqlc.narg(is_active)::boolean IS NULL OR is_active = sqlc.narg(is_active)::boolean;
+
+-- name: UpdateSupplier :exec
+UPDATE suppliers
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    contact_name = COALESCE(sqlc.narg('contact_name'), contact_name),
+    email = COALESCE(sqlc.narg('email'), email),
+    phone = COALESCE(sqlc.narg('phone'), phone),
+    address = COALESCE(sqlc.narg('address'), address),
+    is_active = COALESCE(sqlc.narg('is_active'), is_active),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id');
+
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (
+    po_no,
+    supplier_id,
+    expected_date,
+    note,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetPurchaseOrder :one
+SELECT * FROM purchase_orders
+WHERE id = $1;
+
+-- name: GetPurchaseOrderForUpdate :one
+SELECT * FROM purchase_orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListPurchaseOrders :many
+SELECT * FROM purchase_orders
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar)
+  AND (sqlc.narg(supplier_id)::bigint IS NULL OR supplier_id = sqlc.narg(supplier_id)::bigint)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountPurchaseOrders :one
+SELECT COUNT(*) FROM purchase_orders
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar)
+  AND (sqlc.narg(supplier_id)::bigint IS NULL OR supplier_id = sqlc.narg(supplier_id)::bigint);
+
+-- name: UpdatePurchaseOrderStatus :exec
+UPDATE purchase_orders
+SET
+    status = sqlc.arg(status),
+    ordered_at = CASE WHEN sqlc.arg(status) = 'ordered' THEN NOW() ELSE ordered_at END,
+    received_at = CASE WHEN sqlc.arg(status) = 'received' THEN NOW() ELSE received_at END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id);
+
+-- name: RefreshPurchaseOrderTotal :exec
+UPDATE purchase_orders po
+SET
+    total_cost = (
+        SELECT COALESCE(SUM(poi.quantity::bigint * poi.unit_cost), 0)::bigint
+        FROM purchase_order_items poi
+        WHERE poi.purchase_order_id = po.id
+    ),
+    updated_at = NOW()
+WHERE po.id = $1;
+
+-- name: CreatePurchaseOrderItem :one
+INSERT INTO purchase_order_items (
+    purchase_order_id,
+    product_id,
+    sku_id,
+    quantity,
+    unit_cost,
+    expected_date
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListPurchaseOrderItems :many
+SELECT
+    poi.*,
+    p.name AS product_name
+FROM purchase_order_items poi
+JOIN products p ON p.id = poi.product_id
+WHERE poi.purchase_order_id = $1
+ORDER BY poi.id;
+
+-- name: ReceivePurchaseOrderItem :execrows
+UPDATE purchase_order_items
+SET
+    received_quantity = received_quantity + sqlc.arg(quantity),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND received_quantity + sqlc.arg(quantity) <= quantity;
+
+-- name: CountOutstandingPurchaseOrderItems :one
+SELECT COUNT(*) FROM purchase_order_items
+WHERE purchase_order_id = $1 AND received_quantity < quantity;
+
+-- name: CreatePurchaseReceipt :one
+INSERT INTO purchase_receipts (
+    purchase_order_id,
+    purchase_order_item_id,
+    product_id,
+    sku_id,
+    quantity,
+    unit_cost,
+    on_hand_before,
+    cost_price_before,
+    cost_price_after,
+    received_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: ListPurchaseReceipts :many
+SELECT * FROM purchase_receipts
+WHERE purchase_order_id = $1
+ORDER BY id;
@@ -0,0 +1,105 @@
+-- name: CreateProductQuestion :one
+INSERT INTO product_questions (
+    product_id,
+    user_id,
+    content
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetProductQuestion :one
+SELECT * FROM product_questions
+WHERE id = $1;
+
+-- name: GetProductQuestionAsker :one
+-- The question with its asker and product, for answer notifications
+SELECT sqlc.embed(q), u.username, u.email, p.name AS product_name
+FROM product_questions q
+JOIN users u ON u.id = q.user_id
+JOIN products p ON p.id = q.product_id
+WHERE q.id = $1;
+
+-- name: ListProductQuestions :many
+SELECT sqlc.embed(q), u.username, u.avatar
+FROM product_questions q
+JOIN users u ON u.id = q.user_id
+WHERE q.product_id = sqlc.arg(product_id)
+ORDER BY q.created_at DESC, q.id DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountProductQuestions :one
+SELECT COUNT(*) FROM product_questions
+WHERE product_id = $1;
+
+-- name: IncrementQuestionAnswerCount :exec
+UPDATE product_questions
+SET answer_count = answer_count + 1
+WHERE id = $1;
+
+-- name: HasPurchasedProduct :one
+-- Whether the user has a paid, shipped or completed order containing the product
+SELECT EXISTS (
+    SELECT 1
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.user_id = sqlc.arg(user_id)
+      AND oi.product_id = sqlc.arg(product_id)
+      AND o.status IN ('paid', 'shipped', 'completed')
+      AND oi.deleted_at IS NULL
+      AND o.deleted_at IS NULL
+);
+
+-- name: CreateProductAnswer :one
+INSERT INTO product_answers (
+    question_id,
+    user_id,
+    content,
+    is_staff,
+    is_buyer
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetProductAnswer :one
+SELECT * FROM product_answers
+WHERE id = $1;
+
+-- name: ListProductAnswers :many
+SELECT sqlc.embed(a), u.username, u.avatar
+FROM product_answers a
+JOIN users u ON u.id = a.user_id
+WHERE a.question_id = sqlc.arg(question_id)
+ORDER BY a.upvote_count DESC, a.id
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountProductAnswers :one
+SELECT COUNT(*) FROM product_answers
+WHERE question_id = $1;
+
+-- name: ListTopAnswersByQuestionIDs :many
+-- The most upvoted answers of each question, up to per_question each
+SELECT a.id, a.question_id, a.user_id, a.content, a.is_staff, a.is_buyer, a.upvote_count, a.created_at, u.username, u.avatar
+FROM (
+    SELECT product_answers.*,
+           ROW_NUMBER() OVER (PARTITION BY question_id ORDER BY upvote_count DESC, id) AS position
+    FROM product_answers
+    WHERE question_id = ANY(sqlc.arg(question_ids)::bigint[])
+) a
+JOIN users u ON u.id = a.user_id
+WHERE a.position <= sqlc.arg(per_question)::int
+ORDER BY a.question_id, a.position;
+
+-- name: CreateProductAnswerVote :execrows
+INSERT INTO product_answer_votes (answer_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteProductAnswerVote :execrows
+DELETE FROM product_answer_votes
+WHERE answer_id = $1 AND user_id = $2;
+
+-- name: AddProductAnswerUpvoteCount :one
+UPDATE product_answers
+SET upvote_count = upvote_count + sqlc.arg(delta)::int
+WHERE id = sqlc.arg(id)
+RETURNING upvote_count;
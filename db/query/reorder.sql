@@ -0,0 +1,148 @@
+-- Reorder Queries
+
+-- name: UpsertReorderSetting :one
+INSERT INTO reorder_settings (
+    category_id,
+    lead_time_days,
+    safety_stock_days,
+    auto_apply
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (category_id) DO UPDATE
+SET
+    lead_time_days = EXCLUDED.lead_time_days,
+    safety_stock_days = EXCLUDED.safety_stock_days,
+    auto_apply = EXCLUDED.auto_apply
+RETURNING *;
+
+-- name: ListReorderSettings :many
+SELECT * FROM reorder_settings
+ORDER BY category_id;
+
+-- name: DeleteReorderSetting :execrows
+DELETE FROM reorder_settings
+WHERE category_id = $1;
+
+-- name: GetProductSalesVelocity :many
+-- One row per inventory row; sales of a SKU count toward that SKU's row only
+SELECT
+    i.product_id,
+    i.sku_id,
+    p.category_id,
+    i.available_stock,
+    i.low_stock_threshold,
+    COALESCE(ordered.units, 0)::bigint AS ordered_units,
+    COALESCE(deducted.units, 0)::bigint AS deducted_units
+FROM inventory i
+JOIN products p ON p.id = i.product_id
+LEFT JOIN (
+    SELECT oi.product_id, oi.sku_id, SUM(oi.quantity) AS units
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.created_at >= sqlc.arg(since)::timestamptz
+      AND o.status NOT IN ('cancelled', 'refunded')
+      AND o.deleted_at IS NULL
+      AND oi.deleted_at IS NULL
+    GROUP BY oi.product_id, oi.sku_id
+) ordered ON ordered.product_id = i.product_id AND ordered.sku_id IS NOT DISTINCT FROM i.sku_id
+LEFT JOIN (
+    SELECT l.product_id, l.sku_id, SUM(ABS(l.quantity_change)) AS units
+    FROM inventory_logs l
+    WHERE l.change_type = 'deduct'
+      AND l.created_at >= sqlc.arg(since)::timestamptz
+    GROUP BY l.product_id, l.sku_id
+) deducted ON deducted.product_id = i.product_id AND deducted.sku_id IS NOT DISTINCT FROM i.sku_id
+WHERE i.deleted_at IS NULL
+  AND p.deleted_at IS NULL
+ORDER BY i.product_id, i.sku_id NULLS FIRST;
+
+-- name: UpsertReorderSuggestions :exec
+-- Saves a whole refresh in one statement; the array arguments are parallel, one element per
+-- inventory row, with a sku_id of 0 for the product-level row. current_threshold is read from that row.
+INSERT INTO reorder_suggestions (
+    product_id,
+    sku_id,
+    category_id,
+    window_days,
+    units_sold,
+    daily_velocity,
+    lead_time_days,
+    safety_stock_days,
+    reorder_point,
+    reorder_quantity,
+    current_threshold,
+    available_stock,
+    computed_at
+)
+SELECT
+    v.product_id,
+    i.sku_id,
+    v.category_id,
+    sqlc.arg(window_days)::int,
+    v.units_sold,
+    v.daily_velocity,
+    v.lead_time_days,
+    v.safety_stock_days,
+    v.reorder_point,
+    v.reorder_quantity,
+    i.low_stock_threshold,
+    v.available_stock,
+    NOW()
+FROM (
+    SELECT
+        unnest(@product_ids::bigint[]) AS product_id,
+        NULLIF(unnest(@sku_ids::bigint[]), 0) AS sku_id,
+        unnest(@category_ids::bigint[]) AS category_id,
+        unnest(@units_sold::int[]) AS units_sold,
+        unnest(@daily_velocities::double precision[]) AS daily_velocity,
+        unnest(@lead_time_days::int[]) AS lead_time_days,
+        unnest(@safety_stock_days::int[]) AS safety_stock_days,
+        unnest(@reorder_points::int[]) AS reorder_point,
+        unnest(@reorder_quantities::int[]) AS reorder_quantity,
+        unnest(@available_stocks::int[]) AS available_stock
+) AS v
+JOIN inventory i ON i.product_id = v.product_id AND i.sku_id IS NOT DISTINCT FROM v.sku_id AND i.deleted_at IS NULL
+ON CONFLICT (product_id, sku_id) DO UPDATE
+SET
+    category_id = EXCLUDED.category_id,
+    window_days = EXCLUDED.window_days,
+    units_sold = EXCLUDED.units_sold,
+    daily_velocity = EXCLUDED.daily_velocity,
+    lead_time_days = EXCLUDED.lead_time_days,
+    safety_stock_days = EXCLUDED.safety_stock_days,
+    reorder_point = EXCLUDED.reorder_point,
+    reorder_quantity = EXCLUDED.reorder_quantity,
+    current_threshold = EXCLUDED.current_threshold,
+    available_stock = EXCLUDED.available_stock,
+    computed_at = EXCLUDED.computed_at;
+
+-- name: ListReorderSuggestions :many
+SELECT
+    s.*,
+    p.name AS product_name,
+    k.sku_code
+FROM reorder_suggestions s
+JOIN products p ON p.id = s.product_id
+LEFT JOIN product_skus k ON k.id = s.sku_id
+WHERE (sqlc.narg(category_id)::bigint IS NULL OR s.category_id = sqlc.narg(category_id)::bigint)
+  AND (NOT sqlc.arg(needs_reorder)::boolean OR s.available_stock <= s.reorder_point)
+ORDER BY s.reorder_quantity DESC, s.product_id, s.sku_id NULLS FIRST
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountReorderSuggestions :one
+SELECT COUNT(*) FROM reorder_suggestions s
+WHERE (sqlc.narg(category_id)::bigint IS NULL OR s.category_id = sqlc.narg(category_id)::bigint)
+  AND (NOT sqlc.arg(needs_reorder)::boolean OR s.available_stock <= s.reorder_point);
+
+-- name: ApplyReorderPointsToCategory :execrows
+-- A zero reorder point means no recent sales; it is skipped so low-stock alerts stay on
+UPDATE inventory i
+SET low_stock_threshold = s.reorder_point
+FROM reorder_suggestions s
+WHERE s.product_id = i.product_id
+  AND s.sku_id IS NOT DISTINCT FROM i.sku_id
+  AND s.category_id = sqlc.arg(category_id)
+  AND i.deleted_at IS NULL
+  AND s.reorder_point > 0
+  AND i.low_stock_threshold IS DISTINCT FROM s.reorder_point;
@@ -0,0 +1,139 @@
+-- name: GetReviewableOrderItem :one
+SELECT oi.id, oi.product_id, o.user_id, o.status
+FROM order_items oi
+JOIN orders o ON o.id = oi.order_id
+WHERE oi.id = $1
+  AND oi.deleted_at IS NULL
+  AND o.deleted_at IS NULL
+FOR UPDATE OF oi;
+
+-- name: ReviewExistsForOrderItem :one
+SELECT EXISTS (
+    SELECT 1 FROM product_reviews
+    WHERE order_item_id = $1
+);
+
+-- name: CreateProductReview :one
+INSERT INTO product_reviews (
+    product_id,
+    user_id,
+    order_item_id,
+    rating,
+    content,
+    images,
+    content_fingerprint,
+    status,
+    flag_reasons
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetProductReview :one
+SELECT * FROM product_reviews
+WHERE id = $1;
+
+-- name: GetProductReviewForUpdate :one
+SELECT * FROM product_reviews
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListProductReviews :many
+SELECT sqlc.embed(r), u.username, u.avatar
+FROM product_reviews r
+JOIN users u ON u.id = r.user_id
+WHERE r.product_id = sqlc.arg(product_id)
+  AND r.status = 'approved'
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::text = 'helpful' THEN r.helpful_count END DESC,
+    r.created_at DESC,
+    r.id DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountProductReviews :one
+SELECT COUNT(*) FROM product_reviews
+WHERE product_id = $1 AND status = 'approved';
+
+-- name: CreateProductReviewVote :execrows
+INSERT INTO product_review_votes (review_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteProductReviewVote :execrows
+DELETE FROM product_review_votes
+WHERE review_id = $1 AND user_id = $2;
+
+-- name: AddProductReviewHelpfulCount :one
+UPDATE product_reviews
+SET helpful_count = helpful_count + sqlc.arg(delta)::int
+WHERE id = sqlc.arg(id)
+RETURNING helpful_count;
+
+-- name: ReplyProductReview :one
+UPDATE product_reviews
+SET
+    reply = $2,
+    replied_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: RefreshProductRating :exec
+-- Recompute the product's aggregated rating from its approved reviews
+UPDATE products
+SET
+    rating_total = stats.total,
+    rating_count = stats.count
+FROM (
+    SELECT COALESCE(SUM(rating), 0)::bigint AS total, COUNT(*)::int AS count
+    FROM product_reviews
+    WHERE product_id = sqlc.arg(product_id) AND status = 'approved'
+) AS stats
+WHERE products.id = sqlc.arg(product_id);
+
+-- name: CountRecentReviewsByUser :one
+SELECT COUNT(*) FROM product_reviews
+WHERE user_id = sqlc.arg(user_id) AND created_at >= sqlc.arg(since);
+
+-- name: CountDuplicateReviews :one
+SELECT COUNT(*) FROM product_reviews
+WHERE content_fingerprint = sqlc.arg(content_fingerprint) AND created_at >= sqlc.arg(since);
+
+-- name: UpdateProductReviewStatus :one
+UPDATE product_reviews
+SET
+    status = sqlc.arg(status),
+    reject_reason = sqlc.narg(reject_reason),
+    moderated_by = sqlc.narg(moderated_by),
+    moderated_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListReviewsByStatus :many
+SELECT sqlc.embed(r), u.username, u.avatar
+FROM product_reviews r
+JOIN users u ON u.id = r.user_id
+WHERE r.status = sqlc.arg(status)
+ORDER BY r.created_at, r.id
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountReviewsByStatus :one
+SELECT COUNT(*) FROM product_reviews
+WHERE status = $1;
+
+-- name: CreateReviewModerationLog :one
+INSERT INTO product_review_moderation_logs (
+    review_id,
+    action,
+    from_status,
+    to_status,
+    reasons,
+    operator_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListReviewModerationLogs :many
+SELECT * FROM product_review_moderation_logs
+WHERE review_id = $1
+ORDER BY id;
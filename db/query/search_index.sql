@@ -0,0 +1,116 @@
+-- Postgres search index (see internal/search.PostgresIndex)
+-- query is pre-segmented by the application and parsed with websearch syntax; NULL lists without text matching.
+-- spec_filters maps each attribute to the candidate documents it may contain (OR within, AND across);
+-- spec_contains merges single-valued attributes so the GIN index can narrow the scan.
+
+-- name: SearchProductIndex :many
+SELECT p.id, COALESCE(ts_rank(p.search_vector, websearch_to_tsquery(sqlc.arg('ts_config')::text::regconfig, sqlc.narg('query')::text)), 0)::REAL AS score
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND (sqlc.narg('query')::text IS NULL OR p.search_vector @@ websearch_to_tsquery(sqlc.arg('ts_config')::text::regconfig, sqlc.narg('query')::text))
+  AND (sqlc.narg('category_id')::bigint IS NULL OR p.category_id = sqlc.narg('category_id'))
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status'))
+  AND (sqlc.narg('brands')::text[] IS NULL OR p.brand = ANY(sqlc.narg('brands')::text[]))
+  AND (sqlc.narg('min_price')::bigint IS NULL OR p.price >= sqlc.narg('min_price'))
+  AND (sqlc.narg('max_price')::bigint IS NULL OR p.price < sqlc.narg('max_price'))
+  AND (sqlc.narg('spec_contains')::jsonb IS NULL OR p.specifications @> sqlc.narg('spec_contains')::jsonb)
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each(sqlc.narg('spec_filters')::jsonb) AS f(key, docs)
+      WHERE NOT EXISTS (
+          SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+          WHERE p.specifications @> d.doc
+      )
+  )
+ORDER BY
+    CASE WHEN sqlc.arg('sort')::text = 'relevance' THEN ts_rank(p.search_vector, websearch_to_tsquery(sqlc.arg('ts_config')::text::regconfig, sqlc.narg('query')::text)) END DESC NULLS LAST,
+    CASE WHEN sqlc.arg('sort')::text = 'price_asc' THEN p.price END ASC,
+    CASE WHEN sqlc.arg('sort')::text = 'price_desc' THEN p.price END DESC,
+    CASE WHEN sqlc.arg('sort')::text IN ('relevance', 'sales') THEN p.sales_count END DESC,
+    CASE WHEN sqlc.arg('sort')::text = 'newest' THEN p.created_at END DESC,
+    p.id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountProductIndex :one
+SELECT COUNT(*) FROM products p
+WHERE p.deleted_at IS NULL
+  AND (sqlc.narg('query')::text IS NULL OR p.search_vector @@ websearch_to_tsquery(sqlc.arg('ts_config')::text::regconfig, sqlc.narg('query')::text))
+  AND (sqlc.narg('category_id')::bigint IS NULL OR p.category_id = sqlc.narg('category_id'))
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status'))
+  AND (sqlc.narg('brands')::text[] IS NULL OR p.brand = ANY(sqlc.narg('brands')::text[]))
+  AND (sqlc.narg('min_price')::bigint IS NULL OR p.price >= sqlc.narg('min_price'))
+  AND (sqlc.narg('max_price')::bigint IS NULL OR p.price < sqlc.narg('max_price'))
+  AND (sqlc.narg('spec_contains')::jsonb IS NULL OR p.specifications @> sqlc.narg('spec_contains')::jsonb)
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each(sqlc.narg('spec_filters')::jsonb) AS f(key, docs)
+      WHERE NOT EXISTS (
+          SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+          WHERE p.specifications @> d.doc
+      )
+  );
+
+-- Each facet applies every active filter except its own, so sibling values keep their counts.
+
+-- name: SearchIndexSpecFacets :many
+SELECT s.key::text AS name, s.value::text AS value, COUNT(*) AS product_count
+FROM products p
+CROSS JOIN LATERAL jsonb_each_text(
+    CASE WHEN jsonb_typeof(p.specifications) = 'object' THEN p.specifications ELSE '{}'::jsonb END
+) AS s(key, value)
+WHERE p.deleted_at IS NULL
+  AND (sqlc.narg('query')::text IS NULL OR p.search_vector @@ websearch_to_tsquery(sqlc.arg('ts_config')::text::regconfig, sqlc.narg('query')::text))
+  AND (sqlc.narg('category_id')::bigint IS NULL OR p.category_id = sqlc.narg('category_id'))
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status'))
+  AND (sqlc.narg('brands')::text[] IS NULL OR p.brand = ANY(sqlc.narg('brands')::text[]))
+  AND (sqlc.narg('min_price')::bigint IS NULL OR p.price >= sqlc.narg('min_price'))
+  AND (sqlc.narg('max_price')::bigint IS NULL OR p.price < sqlc.narg('max_price'))
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each(sqlc.narg('spec_filters')::jsonb) AS f(key, docs)
+      WHERE f.key <> s.key
+        AND NOT EXISTS (
+            SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+            WHERE p.specifications @> d.doc
+        )
+  )
+GROUP BY s.key, s.value
+ORDER BY s.key, product_count DESC, s.value;
+
+-- name: SearchIndexBrandFacets :many
+SELECT p.brand::text AS brand, COUNT(*) AS product_count
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND (sqlc.narg('query')::text IS NULL OR p.search_vector @@ websearch_to_tsquery(sqlc.arg('ts_config')::text::regconfig, sqlc.narg('query')::text))
+  AND (sqlc.narg('category_id')::bigint IS NULL OR p.category_id = sqlc.narg('category_id'))
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status'))
+  AND (sqlc.narg('min_price')::bigint IS NULL OR p.price >= sqlc.narg('min_price'))
+  AND (sqlc.narg('max_price')::bigint IS NULL OR p.price < sqlc.narg('max_price'))
+  AND (sqlc.narg('spec_contains')::jsonb IS NULL OR p.specifications @> sqlc.narg('spec_contains')::jsonb)
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each(sqlc.narg('spec_filters')::jsonb) AS f(key, docs)
+      WHERE NOT EXISTS (
+          SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+          WHERE p.specifications @> d.doc
+      )
+  )
+  AND p.brand IS NOT NULL
+GROUP BY p.brand
+ORDER BY product_count DESC, p.brand;
+
+-- name: SearchIndexPriceFacets :many
+-- bucket is width_bucket over the ascending boundaries: 0 is below the first, len(boundaries) above the last
+SELECT width_bucket(p.price, sqlc.arg(boundaries)::bigint[])::int AS bucket, COUNT(*) AS product_count
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND (sqlc.narg('query')::text IS NULL OR p.search_vector @@ websearch_to_tsquery(sqlc.arg('ts_config')::text::regconfig, sqlc.narg('query')::text))
+  AND (sqlc.narg('category_id')::bigint IS NULL OR p.category_id = sqlc.narg('category_id'))
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status'))
+  AND (sqlc.narg('brands')::text[] IS NULL OR p.brand = ANY(sqlc.narg('brands')::text[]))
+  AND (sqlc.narg('spec_contains')::jsonb IS NULL OR p.specifications @> sqlc.narg('spec_contains')::jsonb)
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each(sqlc.narg('spec_filters')::jsonb) AS f(key, docs)
+      WHERE NOT EXISTS (
+          SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+          WHERE p.specifications @> d.doc
+      )
+  )
+GROUP BY bucket
+ORDER BY bucket;
@@ -0,0 +1,79 @@
+-- Stock Alerts Queries
+
+-- name: CreateStockAlert :one
+INSERT INTO stock_alerts (
+    product_id,
+    sku_id,
+    available_stock,
+    threshold
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (product_id, sku_id) WHERE status = 'active' DO NOTHING
+RETURNING *;
+
+-- name: GetActiveStockAlert :one
+SELECT sa.*, p.name AS product_name, s.sku_code
+FROM stock_alerts sa
+JOIN products p ON p.id = sa.product_id
+LEFT JOIN product_skus s ON s.id = sa.sku_id
+WHERE sa.product_id = sqlc.arg(product_id)
+    AND sa.sku_id IS NOT DISTINCT FROM sqlc.narg(sku_id)
+    AND sa.status = 'active';
+
+-- name: UpdateStockAlertLevel :exec
+UPDATE stock_alerts
+SET
+    available_stock = sqlc.arg(available_stock),
+    updated_at = NOW()
+WHERE product_id = sqlc.arg(product_id)
+    AND sku_id IS NOT DISTINCT FROM sqlc.narg(sku_id)
+    AND status = 'active';
+
+-- name: MarkStockAlertNotified :exec
+UPDATE stock_alerts
+SET
+    notified_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: ResolveStockAlerts :exec
+UPDATE stock_alerts
+SET
+    status = 'resolved',
+    available_stock = sqlc.arg(available_stock),
+    resolved_at = NOW(),
+    updated_at = NOW()
+WHERE product_id = sqlc.arg(product_id)
+    AND sku_id IS NOT DISTINCT FROM sqlc.narg(sku_id)
+    AND status = 'active';
+
+-- name: ListActiveStockAlerts :many
+SELECT
+    sa.id,
+    sa.product_id,
+    p.name AS product_name,
+    sa.sku_id,
+    s.sku_code,
+    sa.available_stock,
+    sa.threshold,
+    sa.notified_at,
+    sa.created_at
+FROM stock_alerts sa
+JOIN products p ON p.id = sa.product_id
+LEFT JOIN product_skus s ON s.id = sa.sku_id
+WHERE sa.status = 'active'
+ORDER BY sa.available_stock ASC, sa.created_at ASC
+LIMIT $1 OFFSET $2;
+
+-- name: CountActiveStockAlerts :one
+SELECT COUNT(*) FROM stock_alerts
+WHERE status = 'active';
+
+-- name: CountOutOfStockAlerts :one
+SELECT COUNT(*) FROM stock_alerts
+WHERE status = 'active' AND available_stock = 0;
+
+-- name: CountResolvedStockAlertsSince :one
+SELECT COUNT(*) FROM stock_alerts
+WHERE status = 'resolved' AND resolved_at >= sqlc.arg(since)::timestamptz;
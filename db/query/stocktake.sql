@@ -0,0 +1,94 @@
+-- Stocktake Queries
+
+-- name: CreateStocktake :one
+INSERT INTO stocktakes (
+    category_id,
+    note,
+    created_by
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetStocktake :one
+SELECT * FROM stocktakes
+WHERE id = $1;
+
+-- name: ListStocktakes :many
+SELECT * FROM stocktakes
+WHERE sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountStocktakes :one
+SELECT COUNT(*) FROM stocktakes
+WHERE sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar;
+
+-- name: ApproveStocktake :execrows
+UPDATE stocktakes
+SET
+    status = 'approved',
+    approved_by = $1,
+    approved_at = NOW(),
+    updated_at = NOW()
+WHERE id = $2 AND status = 'open';
+
+-- name: CancelStocktake :execrows
+UPDATE stocktakes
+SET
+    status = 'cancelled',
+    updated_at = NOW()
+WHERE id = $1 AND status = 'open';
+
+-- Stocktake Items Queries
+
+-- name: AddStocktakeItemsForProducts :execrows
+-- One item per inventory row: the product-level row, or each SKU's row for products with variants
+INSERT INTO stocktake_items (stocktake_id, product_id, sku_id, expected_quantity)
+SELECT sqlc.arg(stocktake_id), i.product_id, i.sku_id, i.available_stock + i.reserved_stock
+FROM inventory i
+WHERE i.product_id = ANY(sqlc.arg(product_ids)::bigint[]) AND i.deleted_at IS NULL
+ON CONFLICT (stocktake_id, product_id, sku_id) DO NOTHING;
+
+-- name: AddStocktakeItemsForCategory :execrows
+INSERT INTO stocktake_items (stocktake_id, product_id, sku_id, expected_quantity)
+SELECT sqlc.arg(stocktake_id), i.product_id, i.sku_id, i.available_stock + i.reserved_stock
+FROM inventory i
+JOIN products p ON p.id = i.product_id
+WHERE p.category_id = sqlc.arg(category_id) AND p.deleted_at IS NULL AND i.deleted_at IS NULL
+ON CONFLICT (stocktake_id, product_id, sku_id) DO NOTHING;
+
+-- name: RecordStocktakeCount :execrows
+UPDATE stocktake_items
+SET
+    counted_quantity = sqlc.narg(counted_quantity),
+    counted_by = sqlc.narg(counted_by),
+    counted_at = NOW(),
+    updated_at = NOW()
+WHERE stocktake_id = sqlc.arg(stocktake_id)
+    AND product_id = sqlc.arg(product_id)
+    AND sku_id IS NOT DISTINCT FROM sqlc.narg(sku_id);
+
+-- name: ListStocktakeItems :many
+SELECT
+    si.*,
+    p.name AS product_name,
+    s.sku_code,
+    i.available_stock,
+    i.reserved_stock
+FROM stocktake_items si
+JOIN products p ON p.id = si.product_id
+LEFT JOIN product_skus s ON s.id = si.sku_id
+JOIN inventory i ON i.product_id = si.product_id AND i.sku_id IS NOT DISTINCT FROM si.sku_id AND i.deleted_at IS NULL
+WHERE si.stocktake_id = $1
+ORDER BY si.product_id, si.sku_id NULLS FIRST;
+
+-- name: CountUncountedStocktakeItems :one
+SELECT COUNT(*) FROM stocktake_items
+WHERE stocktake_id = $1 AND counted_quantity IS NULL;
+
+-- name: SetStocktakeItemVariance :exec
+UPDATE stocktake_items
+SET
+    variance = $1,
+    updated_at = NOW()
+WHERE id = $2;
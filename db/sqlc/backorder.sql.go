@@ -0,0 +1,219 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: backorder.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const cancelOrderBackorders = `-- name: CancelOrderBackorders :many
+UPDATE inventory_backorders
+SET status = 'cancelled'
+WHERE order_id = $1 AND status = 'pending'
+RETURNING id, product_id, order_id, quantity, status, allocated_at, created_at, updated_at, sku_id
+`
+
+func (q *Queries) CancelOrderBackorders(ctx context.Context, orderID int64) ([]InventoryBackorder, error) {
+	rows, err := q.db.Query(ctx, cancelOrderBackorders, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InventoryBackorder{}
+	for rows.Next() {
+		var i InventoryBackorder
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.OrderID,
+			&i.Quantity,
+			&i.Status,
+			&i.AllocatedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SkuID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countBackorders = `-- name: CountBackorders :one
+SELECT COUNT(*) FROM inventory_backorders
+WHERE product_id = $1
+    AND ($2::bigint IS NULL OR sku_id = $2::bigint)
+    AND ($3::varchar IS NULL OR status = $3::varchar)
+`
+
+type CountBackordersParams struct {
+	ProductID int64   `db:"product_id" json:"product_id"`
+	SkuID     *int64  `db:"sku_id" json:"sku_id"`
+	Status    *string `db:"status" json:"status"`
+}
+
+func (q *Queries) CountBackorders(ctx context.Context, arg CountBackordersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countBackorders, arg.ProductID, arg.SkuID, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createBackorder = `-- name: CreateBackorder :one
+
+INSERT INTO inventory_backorders (
+    product_id,
+    sku_id,
+    order_id,
+    quantity
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, product_id, order_id, quantity, status, allocated_at, created_at, updated_at, sku_id
+`
+
+type CreateBackorderParams struct {
+	ProductID int64  `db:"product_id" json:"product_id"`
+	SkuID     *int64 `db:"sku_id" json:"sku_id"`
+	OrderID   int64  `db:"order_id" json:"order_id"`
+	Quantity  int32  `db:"quantity" json:"quantity"`
+}
+
+// Backorder Queries
+func (q *Queries) CreateBackorder(ctx context.Context, arg CreateBackorderParams) (InventoryBackorder, error) {
+	row := q.db.QueryRow(ctx, createBackorder,
+		arg.ProductID,
+		arg.SkuID,
+		arg.OrderID,
+		arg.Quantity,
+	)
+	var i InventoryBackorder
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.OrderID,
+		&i.Quantity,
+		&i.Status,
+		&i.AllocatedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SkuID,
+	)
+	return i, err
+}
+
+const listBackorders = `-- name: ListBackorders :many
+SELECT id, product_id, order_id, quantity, status, allocated_at, created_at, updated_at, sku_id FROM inventory_backorders
+WHERE product_id = $1
+    AND ($2::bigint IS NULL OR sku_id = $2::bigint)
+    AND ($3::varchar IS NULL OR status = $3::varchar)
+ORDER BY id
+LIMIT $5 OFFSET $4
+`
+
+type ListBackordersParams struct {
+	ProductID   int64   `db:"product_id" json:"product_id"`
+	SkuID       *int64  `db:"sku_id" json:"sku_id"`
+	Status      *string `db:"status" json:"status"`
+	OffsetCount int32   `db:"offset_count" json:"offset_count"`
+	LimitCount  int32   `db:"limit_count" json:"limit_count"`
+}
+
+func (q *Queries) ListBackorders(ctx context.Context, arg ListBackordersParams) ([]InventoryBackorder, error) {
+	rows, err := q.db.Query(ctx, listBackorders,
+		arg.ProductID,
+		arg.SkuID,
+		arg.Status,
+		arg.OffsetCount,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InventoryBackorder{}
+	for rows.Next() {
+		var i InventoryBackorder
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.OrderID,
+			&i.Quantity,
+			&i.Status,
+			&i.AllocatedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SkuID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingBackordersForUpdate = `-- name: ListPendingBackordersForUpdate :many
+SELECT id, product_id, order_id, quantity, status, allocated_at, created_at, updated_at, sku_id FROM inventory_backorders
+WHERE product_id = $1
+    AND sku_id IS NOT DISTINCT FROM $2
+    AND status = 'pending'
+ORDER BY id
+FOR UPDATE
+`
+
+type ListPendingBackordersForUpdateParams struct {
+	ProductID int64  `db:"product_id" json:"product_id"`
+	SkuID     *int64 `db:"sku_id" json:"sku_id"`
+}
+
+func (q *Queries) ListPendingBackordersForUpdate(ctx context.Context, arg ListPendingBackordersForUpdateParams) ([]InventoryBackorder, error) {
+	rows, err := q.db.Query(ctx, listPendingBackordersForUpdate, arg.ProductID, arg.SkuID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InventoryBackorder{}
+	for rows.Next() {
+		var i InventoryBackorder
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.OrderID,
+			&i.Quantity,
+			&i.Status,
+			&i.AllocatedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SkuID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markBackorderAllocated = `-- name: MarkBackorderAllocated :exec
+UPDATE inventory_backorders
+SET
+    status = 'allocated',
+    allocated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+`
+
+func (q *Queries) MarkBackorderAllocated(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, markBackorderAllocated, id)
+	return err
+}
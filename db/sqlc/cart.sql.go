@@ -10,23 +10,29 @@ import (
 )
 
 const addToCart = `-- name: AddToCart :one
-INSERT INTO carts (user_id, product_id, quantity, selected)
-VALUES ($1, $2, $3, TRUE)
-    ON CONFLICT (user_id, product_id)
+INSERT INTO carts (user_id, product_id, sku_id, quantity, selected)
+VALUES ($1, $2, $3, $4, TRUE)
+    ON CONFLICT (user_id, product_id, sku_id)
   DO UPDATE SET
     quantity = carts.quantity + EXCLUDED.quantity,
              updated_at = NOW()
-             RETURNING id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at
+             RETURNING id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at, sku_id
 `
 
 type AddToCartParams struct {
-	UserID    int64 `db:"user_id" json:"user_id"`
-	ProductID int64 `db:"product_id" json:"product_id"`
-	Quantity  int32 `db:"quantity" json:"quantity"`
+	UserID    int64  `db:"user_id" json:"user_id"`
+	ProductID int64  `db:"product_id" json:"product_id"`
+	SkuID     *int64 `db:"sku_id" json:"sku_id"`
+	Quantity  int32  `db:"quantity" json:"quantity"`
 }
 
 func (q *Queries) AddToCart(ctx context.Context, arg AddToCartParams) (Cart, error) {
-	row := q.db.QueryRow(ctx, addToCart, arg.UserID, arg.ProductID, arg.Quantity)
+	row := q.db.QueryRow(ctx, addToCart,
+		arg.UserID,
+		arg.ProductID,
+		arg.SkuID,
+		arg.Quantity,
+	)
 	var i Cart
 	err := row.Scan(
 		&i.ID,
@@ -37,6 +43,7 @@ func (q *Queries) AddToCart(ctx context.Context, arg AddToCartParams) (Cart, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SkuID,
 	)
 	return i, err
 }
@@ -81,7 +88,7 @@ func (q *Queries) DeleteCartItem(ctx context.Context, arg DeleteCartItemParams)
 }
 
 const getCartByUserID = `-- name: GetCartByUserID :many
-SELECT id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at FROM carts
+SELECT id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at, sku_id FROM carts
 WHERE user_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
 `
@@ -104,6 +111,7 @@ func (q *Queries) GetCartByUserID(ctx context.Context, userID int64) ([]Cart, er
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SkuID,
 		); err != nil {
 			return nil, err
 		}
@@ -116,7 +124,7 @@ func (q *Queries) GetCartByUserID(ctx context.Context, userID int64) ([]Cart, er
 }
 
 const getCartItem = `-- name: GetCartItem :one
-SELECT id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at FROM carts
+SELECT id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at, sku_id FROM carts
 WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
     LIMIT 1
 `
@@ -138,23 +146,28 @@ func (q *Queries) GetCartItem(ctx context.Context, arg GetCartItemParams) (Cart,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SkuID,
 	)
 	return i, err
 }
 
 const getCartItemByProduct = `-- name: GetCartItemByProduct :one
-SELECT id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at FROM carts
-WHERE user_id = $1 AND product_id = $2 AND deleted_at IS NULL
+SELECT id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at, sku_id FROM carts
+WHERE user_id = $1
+    AND product_id = $2
+    AND sku_id IS NOT DISTINCT FROM $3
+    AND deleted_at IS NULL
     LIMIT 1
 `
 
 type GetCartItemByProductParams struct {
-	UserID    int64 `db:"user_id" json:"user_id"`
-	ProductID int64 `db:"product_id" json:"product_id"`
+	UserID    int64  `db:"user_id" json:"user_id"`
+	ProductID int64  `db:"product_id" json:"product_id"`
+	SkuID     *int64 `db:"sku_id" json:"sku_id"`
 }
 
 func (q *Queries) GetCartItemByProduct(ctx context.Context, arg GetCartItemByProductParams) (Cart, error) {
-	row := q.db.QueryRow(ctx, getCartItemByProduct, arg.UserID, arg.ProductID)
+	row := q.db.QueryRow(ctx, getCartItemByProduct, arg.UserID, arg.ProductID, arg.SkuID)
 	var i Cart
 	err := row.Scan(
 		&i.ID,
@@ -165,12 +178,13 @@ func (q *Queries) GetCartItemByProduct(ctx context.Context, arg GetCartItemByPro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SkuID,
 	)
 	return i, err
 }
 
 const getSelectedCartItems = `-- name: GetSelectedCartItems :many
-SELECT id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at FROM carts
+SELECT id, user_id, product_id, quantity, selected, created_at, updated_at, deleted_at, sku_id FROM carts
 WHERE user_id = $1 AND selected = TRUE AND deleted_at IS NULL
 ORDER BY created_at DESC
 `
@@ -193,6 +207,7 @@ func (q *Queries) GetSelectedCartItems(ctx context.Context, userID int64) ([]Car
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SkuID,
 		); err != nil {
 			return nil, err
 		}
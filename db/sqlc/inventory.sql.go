@@ -8,33 +8,108 @@ package sqlc
 import (
 	"context"
 	"time"
+
+	"gomall/utils/types"
 )
 
+const activateOrderReservations = `-- name: ActivateOrderReservations :exec
+UPDATE inventory_reservations
+SET
+    status = 'active',
+    expires_at = $2,
+    updated_at = NOW()
+WHERE order_id = $1 AND status = 'held' AND deleted_at IS NULL
+`
+
+type ActivateOrderReservationsParams struct {
+	OrderID   int64     `db:"order_id" json:"order_id"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) ActivateOrderReservations(ctx context.Context, arg ActivateOrderReservationsParams) error {
+	_, err := q.db.Exec(ctx, activateOrderReservations, arg.OrderID, arg.ExpiresAt)
+	return err
+}
+
 const addAvailableStock = `-- name: AddAvailableStock :exec
 UPDATE inventory
 SET
     available_stock = available_stock + $1,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $2 AND deleted_at IS NULL
+WHERE id = $2 AND deleted_at IS NULL
 `
 
 type AddAvailableStockParams struct {
 	AvailableStock int32 `db:"available_stock" json:"available_stock"`
-	ProductID      int64 `db:"product_id" json:"product_id"`
+	ID             int64 `db:"id" json:"id"`
 }
 
 func (q *Queries) AddAvailableStock(ctx context.Context, arg AddAvailableStockParams) error {
-	_, err := q.db.Exec(ctx, addAvailableStock, arg.AvailableStock, arg.ProductID)
+	_, err := q.db.Exec(ctx, addAvailableStock, arg.AvailableStock, arg.ID)
 	return err
 }
 
+const addBackorderedStock = `-- name: AddBackorderedStock :execrows
+UPDATE inventory
+SET
+    backordered_stock = backordered_stock + $1,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = $2
+    AND version = $3
+    AND deleted_at IS NULL
+`
+
+type AddBackorderedStockParams struct {
+	Quantity int32 `db:"quantity" json:"quantity"`
+	ID       int64 `db:"id" json:"id"`
+	Version  int64 `db:"version" json:"version"`
+}
+
+func (q *Queries) AddBackorderedStock(ctx context.Context, arg AddBackorderedStockParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addBackorderedStock, arg.Quantity, arg.ID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const allocateBackorderedStock = `-- name: AllocateBackorderedStock :execrows
+UPDATE inventory
+SET
+    available_stock = available_stock - $1,
+    reserved_stock = reserved_stock + $1,
+    backordered_stock = backordered_stock - $1,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = $2
+    AND available_stock >= $1
+    AND backordered_stock >= $1
+    AND version = $3
+    AND deleted_at IS NULL
+`
+
+type AllocateBackorderedStockParams struct {
+	Quantity int32 `db:"quantity" json:"quantity"`
+	ID       int64 `db:"id" json:"id"`
+	Version  int64 `db:"version" json:"version"`
+}
+
+func (q *Queries) AllocateBackorderedStock(ctx context.Context, arg AllocateBackorderedStockParams) (int64, error) {
+	result, err := q.db.Exec(ctx, allocateBackorderedStock, arg.Quantity, arg.ID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const cancelReservation = `-- name: CancelReservation :exec
 UPDATE inventory_reservations
 SET
     status = 'cancelled',
     updated_at = NOW()
-WHERE order_id = $1 AND status = 'active' AND deleted_at IS NULL
+WHERE order_id = $1 AND status IN ('active', 'held') AND deleted_at IS NULL
 `
 
 func (q *Queries) CancelReservation(ctx context.Context, orderID int64) error {
@@ -47,7 +122,7 @@ UPDATE inventory_reservations
 SET
     status = 'confirmed',
     updated_at = NOW()
-WHERE order_id = $1 AND status = 'active' AND deleted_at IS NULL
+WHERE order_id = $1 AND status IN ('active', 'held') AND deleted_at IS NULL
 `
 
 func (q *Queries) ConfirmReservation(ctx context.Context, orderID int64) error {
@@ -79,6 +154,35 @@ func (q *Queries) CountInventoryLogsByProductID(ctx context.Context, productID i
 	return count, err
 }
 
+const countInventoryMovementReport = `-- name: CountInventoryMovementReport :one
+SELECT COUNT(*) FROM (
+    SELECT 1
+    FROM inventory_logs l
+    WHERE l.created_at >= $1 AND l.created_at < $2
+      AND ($3::bigint IS NULL OR l.product_id = $3::bigint)
+    GROUP BY l.product_id, date_trunc($4::text, l.created_at)
+) AS periods
+`
+
+type CountInventoryMovementReportParams struct {
+	FromTime  time.Time `db:"from_time" json:"from_time"`
+	ToTime    time.Time `db:"to_time" json:"to_time"`
+	ProductID *int64    `db:"product_id" json:"product_id"`
+	Period    string    `db:"period" json:"period"`
+}
+
+func (q *Queries) CountInventoryMovementReport(ctx context.Context, arg CountInventoryMovementReportParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countInventoryMovementReport,
+		arg.FromTime,
+		arg.ToTime,
+		arg.ProductID,
+		arg.Period,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countLowStockInventories = `-- name: CountLowStockInventories :one
 SELECT COUNT(*) FROM inventory
 WHERE available_stock <= low_stock_threshold AND deleted_at IS NULL
@@ -95,16 +199,18 @@ const createInventory = `-- name: CreateInventory :one
 
 INSERT INTO inventory (
     product_id,
+    sku_id,
     available_stock,
     reserved_stock,
     low_stock_threshold
 ) VALUES (
-    $1, $2, $3, $4
-) RETURNING id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at
+    $1, $2, $3, $4, $5
+) RETURNING id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at, stock_policy, backorder_limit, preorder_release_at, backordered_stock, sku_id
 `
 
 type CreateInventoryParams struct {
 	ProductID         int64  `db:"product_id" json:"product_id"`
+	SkuID             *int64 `db:"sku_id" json:"sku_id"`
 	AvailableStock    int32  `db:"available_stock" json:"available_stock"`
 	ReservedStock     int32  `db:"reserved_stock" json:"reserved_stock"`
 	LowStockThreshold *int32 `db:"low_stock_threshold" json:"low_stock_threshold"`
@@ -114,6 +220,7 @@ type CreateInventoryParams struct {
 func (q *Queries) CreateInventory(ctx context.Context, arg CreateInventoryParams) (Inventory, error) {
 	row := q.db.QueryRow(ctx, createInventory,
 		arg.ProductID,
+		arg.SkuID,
 		arg.AvailableStock,
 		arg.ReservedStock,
 		arg.LowStockThreshold,
@@ -130,6 +237,11 @@ func (q *Queries) CreateInventory(ctx context.Context, arg CreateInventoryParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.StockPolicy,
+		&i.BackorderLimit,
+		&i.PreorderReleaseAt,
+		&i.BackorderedStock,
+		&i.SkuID,
 	)
 	return i, err
 }
@@ -138,6 +250,7 @@ const createInventoryLog = `-- name: CreateInventoryLog :one
 
 INSERT INTO inventory_logs (
     product_id,
+    sku_id,
     order_id,
     change_type,
     quantity_change,
@@ -146,14 +259,17 @@ INSERT INTO inventory_logs (
     before_reserved,
     after_reserved,
     reason,
-    operator_id
+    operator_id,
+    reference_type,
+    reference_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING id, product_id, order_id, change_type, quantity_change, before_available, after_available, before_reserved, after_reserved, reason, operator_id, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING id, product_id, order_id, change_type, quantity_change, before_available, after_available, before_reserved, after_reserved, reason, operator_id, created_at, reference_type, reference_id, sku_id
 `
 
 type CreateInventoryLogParams struct {
 	ProductID       int64   `db:"product_id" json:"product_id"`
+	SkuID           *int64  `db:"sku_id" json:"sku_id"`
 	OrderID         *int64  `db:"order_id" json:"order_id"`
 	ChangeType      string  `db:"change_type" json:"change_type"`
 	QuantityChange  int32   `db:"quantity_change" json:"quantity_change"`
@@ -163,12 +279,15 @@ type CreateInventoryLogParams struct {
 	AfterReserved   int32   `db:"after_reserved" json:"after_reserved"`
 	Reason          *string `db:"reason" json:"reason"`
 	OperatorID      *int64  `db:"operator_id" json:"operator_id"`
+	ReferenceType   *string `db:"reference_type" json:"reference_type"`
+	ReferenceID     *int64  `db:"reference_id" json:"reference_id"`
 }
 
 // Inventory Logs Queries
 func (q *Queries) CreateInventoryLog(ctx context.Context, arg CreateInventoryLogParams) (InventoryLog, error) {
 	row := q.db.QueryRow(ctx, createInventoryLog,
 		arg.ProductID,
+		arg.SkuID,
 		arg.OrderID,
 		arg.ChangeType,
 		arg.QuantityChange,
@@ -178,6 +297,8 @@ func (q *Queries) CreateInventoryLog(ctx context.Context, arg CreateInventoryLog
 		arg.AfterReserved,
 		arg.Reason,
 		arg.OperatorID,
+		arg.ReferenceType,
+		arg.ReferenceID,
 	)
 	var i InventoryLog
 	err := row.Scan(
@@ -193,6 +314,9 @@ func (q *Queries) CreateInventoryLog(ctx context.Context, arg CreateInventoryLog
 		&i.Reason,
 		&i.OperatorID,
 		&i.CreatedAt,
+		&i.ReferenceType,
+		&i.ReferenceID,
+		&i.SkuID,
 	)
 	return i, err
 }
@@ -201,17 +325,19 @@ const createInventoryReservation = `-- name: CreateInventoryReservation :one
 
 INSERT INTO inventory_reservations (
     product_id,
+    sku_id,
     order_id,
     quantity,
     status,
     expires_at
 ) VALUES (
-    $1, $2, $3, $4, $5
-) RETURNING id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at, sku_id
 `
 
 type CreateInventoryReservationParams struct {
 	ProductID int64     `db:"product_id" json:"product_id"`
+	SkuID     *int64    `db:"sku_id" json:"sku_id"`
 	OrderID   int64     `db:"order_id" json:"order_id"`
 	Quantity  int32     `db:"quantity" json:"quantity"`
 	Status    *string   `db:"status" json:"status"`
@@ -222,6 +348,7 @@ type CreateInventoryReservationParams struct {
 func (q *Queries) CreateInventoryReservation(ctx context.Context, arg CreateInventoryReservationParams) (InventoryReservation, error) {
 	row := q.db.QueryRow(ctx, createInventoryReservation,
 		arg.ProductID,
+		arg.SkuID,
 		arg.OrderID,
 		arg.Quantity,
 		arg.Status,
@@ -238,6 +365,7 @@ func (q *Queries) CreateInventoryReservation(ctx context.Context, arg CreateInve
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SkuID,
 	)
 	return i, err
 }
@@ -248,7 +376,7 @@ SET
     reserved_stock = reserved_stock - $1,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $2
+WHERE id = $2
     AND reserved_stock >= $1
     AND version = $3
     AND deleted_at IS NULL
@@ -256,12 +384,12 @@ WHERE product_id = $2
 
 type DeductReservedStockParams struct {
 	ReservedStock int32 `db:"reserved_stock" json:"reserved_stock"`
-	ProductID     int64 `db:"product_id" json:"product_id"`
+	ID            int64 `db:"id" json:"id"`
 	Version       int64 `db:"version" json:"version"`
 }
 
 func (q *Queries) DeductReservedStock(ctx context.Context, arg DeductReservedStockParams) error {
-	_, err := q.db.Exec(ctx, deductReservedStock, arg.ReservedStock, arg.ProductID, arg.Version)
+	_, err := q.db.Exec(ctx, deductReservedStock, arg.ReservedStock, arg.ID, arg.Version)
 	return err
 }
 
@@ -289,8 +417,20 @@ func (q *Queries) DeleteReservation(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteSkuInventory = `-- name: DeleteSkuInventory :exec
+UPDATE inventory
+SET
+    deleted_at = NOW()
+WHERE sku_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteSkuInventory(ctx context.Context, skuID *int64) error {
+	_, err := q.db.Exec(ctx, deleteSkuInventory, skuID)
+	return err
+}
+
 const getActiveReservationsByProductID = `-- name: GetActiveReservationsByProductID :many
-SELECT id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at FROM inventory_reservations
+SELECT id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at, sku_id FROM inventory_reservations
 WHERE product_id = $1
     AND status = 'active'
     AND deleted_at IS NULL
@@ -316,6 +456,7 @@ func (q *Queries) GetActiveReservationsByProductID(ctx context.Context, productI
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SkuID,
 		); err != nil {
 			return nil, err
 		}
@@ -328,7 +469,7 @@ func (q *Queries) GetActiveReservationsByProductID(ctx context.Context, productI
 }
 
 const getExpiredReservations = `-- name: GetExpiredReservations :many
-SELECT id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at FROM inventory_reservations
+SELECT id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at, sku_id FROM inventory_reservations
 WHERE status = 'active'
     AND expires_at < NOW()
     AND deleted_at IS NULL
@@ -355,6 +496,127 @@ func (q *Queries) GetExpiredReservations(ctx context.Context, limit int32) ([]In
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SkuID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFirstInventoryLogAfter = `-- name: GetFirstInventoryLogAfter :one
+SELECT id, product_id, order_id, change_type, quantity_change, before_available, after_available, before_reserved, after_reserved, reason, operator_id, created_at, reference_type, reference_id, sku_id FROM inventory_logs
+WHERE product_id = $1
+    AND sku_id IS NOT DISTINCT FROM $2
+    AND created_at > $3
+ORDER BY created_at, id
+LIMIT 1
+`
+
+type GetFirstInventoryLogAfterParams struct {
+	ProductID int64     `db:"product_id" json:"product_id"`
+	SkuID     *int64    `db:"sku_id" json:"sku_id"`
+	AsOf      time.Time `db:"as_of" json:"as_of"`
+}
+
+func (q *Queries) GetFirstInventoryLogAfter(ctx context.Context, arg GetFirstInventoryLogAfterParams) (InventoryLog, error) {
+	row := q.db.QueryRow(ctx, getFirstInventoryLogAfter, arg.ProductID, arg.SkuID, arg.AsOf)
+	var i InventoryLog
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.OrderID,
+		&i.ChangeType,
+		&i.QuantityChange,
+		&i.BeforeAvailable,
+		&i.AfterAvailable,
+		&i.BeforeReserved,
+		&i.AfterReserved,
+		&i.Reason,
+		&i.OperatorID,
+		&i.CreatedAt,
+		&i.ReferenceType,
+		&i.ReferenceID,
+		&i.SkuID,
+	)
+	return i, err
+}
+
+const getInventoriesByProductIDs = `-- name: GetInventoriesByProductIDs :many
+SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at, stock_policy, backorder_limit, preorder_release_at, backordered_stock, sku_id FROM inventory
+WHERE product_id = ANY($1::bigint[]) AND sku_id IS NULL AND deleted_at IS NULL
+`
+
+func (q *Queries) GetInventoriesByProductIDs(ctx context.Context, productIds []int64) ([]Inventory, error) {
+	rows, err := q.db.Query(ctx, getInventoriesByProductIDs, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Inventory{}
+	for rows.Next() {
+		var i Inventory
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.AvailableStock,
+			&i.ReservedStock,
+			&i.TotalStock,
+			&i.LowStockThreshold,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.StockPolicy,
+			&i.BackorderLimit,
+			&i.PreorderReleaseAt,
+			&i.BackorderedStock,
+			&i.SkuID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInventoriesBySkuIDs = `-- name: GetInventoriesBySkuIDs :many
+SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at, stock_policy, backorder_limit, preorder_release_at, backordered_stock, sku_id FROM inventory
+WHERE sku_id = ANY($1::bigint[]) AND deleted_at IS NULL
+`
+
+func (q *Queries) GetInventoriesBySkuIDs(ctx context.Context, skuIds []int64) ([]Inventory, error) {
+	rows, err := q.db.Query(ctx, getInventoriesBySkuIDs, skuIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Inventory{}
+	for rows.Next() {
+		var i Inventory
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.AvailableStock,
+			&i.ReservedStock,
+			&i.TotalStock,
+			&i.LowStockThreshold,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.StockPolicy,
+			&i.BackorderLimit,
+			&i.PreorderReleaseAt,
+			&i.BackorderedStock,
+			&i.SkuID,
 		); err != nil {
 			return nil, err
 		}
@@ -367,7 +629,7 @@ func (q *Queries) GetExpiredReservations(ctx context.Context, limit int32) ([]In
 }
 
 const getInventoryByID = `-- name: GetInventoryByID :one
-SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at FROM inventory
+SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at, stock_policy, backorder_limit, preorder_release_at, backordered_stock, sku_id FROM inventory
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -385,13 +647,18 @@ func (q *Queries) GetInventoryByID(ctx context.Context, id int64) (Inventory, er
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.StockPolicy,
+		&i.BackorderLimit,
+		&i.PreorderReleaseAt,
+		&i.BackorderedStock,
+		&i.SkuID,
 	)
 	return i, err
 }
 
 const getInventoryByProductID = `-- name: GetInventoryByProductID :one
-SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at FROM inventory
-WHERE product_id = $1 AND deleted_at IS NULL
+SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at, stock_policy, backorder_limit, preorder_release_at, backordered_stock, sku_id FROM inventory
+WHERE product_id = $1 AND sku_id IS NULL AND deleted_at IS NULL
 `
 
 func (q *Queries) GetInventoryByProductID(ctx context.Context, productID int64) (Inventory, error) {
@@ -408,12 +675,45 @@ func (q *Queries) GetInventoryByProductID(ctx context.Context, productID int64)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.StockPolicy,
+		&i.BackorderLimit,
+		&i.PreorderReleaseAt,
+		&i.BackorderedStock,
+		&i.SkuID,
+	)
+	return i, err
+}
+
+const getInventoryBySkuID = `-- name: GetInventoryBySkuID :one
+SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at, stock_policy, backorder_limit, preorder_release_at, backordered_stock, sku_id FROM inventory
+WHERE sku_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetInventoryBySkuID(ctx context.Context, skuID *int64) (Inventory, error) {
+	row := q.db.QueryRow(ctx, getInventoryBySkuID, skuID)
+	var i Inventory
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.AvailableStock,
+		&i.ReservedStock,
+		&i.TotalStock,
+		&i.LowStockThreshold,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.StockPolicy,
+		&i.BackorderLimit,
+		&i.PreorderReleaseAt,
+		&i.BackorderedStock,
+		&i.SkuID,
 	)
 	return i, err
 }
 
 const getInventoryLogsByOrderID = `-- name: GetInventoryLogsByOrderID :many
-SELECT id, product_id, order_id, change_type, quantity_change, before_available, after_available, before_reserved, after_reserved, reason, operator_id, created_at FROM inventory_logs
+SELECT id, product_id, order_id, change_type, quantity_change, before_available, after_available, before_reserved, after_reserved, reason, operator_id, created_at, reference_type, reference_id, sku_id FROM inventory_logs
 WHERE order_id = $1::bigint
 ORDER BY created_at DESC
 `
@@ -440,6 +740,9 @@ func (q *Queries) GetInventoryLogsByOrderID(ctx context.Context, orderID int64)
 			&i.Reason,
 			&i.OperatorID,
 			&i.CreatedAt,
+			&i.ReferenceType,
+			&i.ReferenceID,
+			&i.SkuID,
 		); err != nil {
 			return nil, err
 		}
@@ -452,7 +755,7 @@ func (q *Queries) GetInventoryLogsByOrderID(ctx context.Context, orderID int64)
 }
 
 const getInventoryLogsByProductID = `-- name: GetInventoryLogsByProductID :many
-SELECT id, product_id, order_id, change_type, quantity_change, before_available, after_available, before_reserved, after_reserved, reason, operator_id, created_at FROM inventory_logs
+SELECT id, product_id, order_id, change_type, quantity_change, before_available, after_available, before_reserved, after_reserved, reason, operator_id, created_at, reference_type, reference_id, sku_id FROM inventory_logs
 WHERE product_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -486,6 +789,140 @@ func (q *Queries) GetInventoryLogsByProductID(ctx context.Context, arg GetInvent
 			&i.Reason,
 			&i.OperatorID,
 			&i.CreatedAt,
+			&i.ReferenceType,
+			&i.ReferenceID,
+			&i.SkuID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInventoryLogsByReference = `-- name: GetInventoryLogsByReference :many
+SELECT id, product_id, order_id, change_type, quantity_change, before_available, after_available, before_reserved, after_reserved, reason, operator_id, created_at, reference_type, reference_id, sku_id FROM inventory_logs
+WHERE reference_type = $1 AND reference_id = $2
+ORDER BY id
+`
+
+type GetInventoryLogsByReferenceParams struct {
+	ReferenceType *string `db:"reference_type" json:"reference_type"`
+	ReferenceID   *int64  `db:"reference_id" json:"reference_id"`
+}
+
+func (q *Queries) GetInventoryLogsByReference(ctx context.Context, arg GetInventoryLogsByReferenceParams) ([]InventoryLog, error) {
+	rows, err := q.db.Query(ctx, getInventoryLogsByReference, arg.ReferenceType, arg.ReferenceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InventoryLog{}
+	for rows.Next() {
+		var i InventoryLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.OrderID,
+			&i.ChangeType,
+			&i.QuantityChange,
+			&i.BeforeAvailable,
+			&i.AfterAvailable,
+			&i.BeforeReserved,
+			&i.AfterReserved,
+			&i.Reason,
+			&i.OperatorID,
+			&i.CreatedAt,
+			&i.ReferenceType,
+			&i.ReferenceID,
+			&i.SkuID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInventoryMovementReport = `-- name: GetInventoryMovementReport :many
+SELECT
+    l.product_id,
+    p.name AS product_name,
+    date_trunc($1::text, l.created_at)::timestamptz AS period_start,
+    COALESCE(SUM(l.quantity_change) FILTER (WHERE l.change_type = 'restock'), 0)::bigint AS restocked,
+    COALESCE(SUM(ABS(l.quantity_change)) FILTER (WHERE l.change_type = 'reserve'), 0)::bigint AS reserved,
+    COALESCE(SUM(ABS(l.quantity_change)) FILTER (WHERE l.change_type = 'release'), 0)::bigint AS released,
+    COALESCE(SUM(ABS(l.quantity_change)) FILTER (WHERE l.change_type = 'deduct'), 0)::bigint AS deducted,
+    COALESCE(SUM(l.quantity_change) FILTER (WHERE l.change_type = 'adjust'), 0)::bigint AS adjusted,
+    SUM(l.after_available - l.before_available)::bigint AS net_available_change,
+    SUM(l.after_reserved - l.before_reserved)::bigint AS net_reserved_change,
+    COUNT(*) AS movement_count
+FROM inventory_logs l
+JOIN products p ON p.id = l.product_id
+WHERE l.created_at >= $2 AND l.created_at < $3
+  AND ($4::bigint IS NULL OR l.product_id = $4::bigint)
+GROUP BY l.product_id, p.name, period_start
+ORDER BY period_start, l.product_id
+LIMIT $6 OFFSET $5
+`
+
+type GetInventoryMovementReportParams struct {
+	Period      string    `db:"period" json:"period"`
+	FromTime    time.Time `db:"from_time" json:"from_time"`
+	ToTime      time.Time `db:"to_time" json:"to_time"`
+	ProductID   *int64    `db:"product_id" json:"product_id"`
+	OffsetCount int32     `db:"offset_count" json:"offset_count"`
+	LimitCount  int32     `db:"limit_count" json:"limit_count"`
+}
+
+type GetInventoryMovementReportRow struct {
+	ProductID          int64     `db:"product_id" json:"product_id"`
+	ProductName        string    `db:"product_name" json:"product_name"`
+	PeriodStart        time.Time `db:"period_start" json:"period_start"`
+	Restocked          int64     `db:"restocked" json:"restocked"`
+	Reserved           int64     `db:"reserved" json:"reserved"`
+	Released           int64     `db:"released" json:"released"`
+	Deducted           int64     `db:"deducted" json:"deducted"`
+	Adjusted           int64     `db:"adjusted" json:"adjusted"`
+	NetAvailableChange int64     `db:"net_available_change" json:"net_available_change"`
+	NetReservedChange  int64     `db:"net_reserved_change" json:"net_reserved_change"`
+	MovementCount      int64     `db:"movement_count" json:"movement_count"`
+}
+
+func (q *Queries) GetInventoryMovementReport(ctx context.Context, arg GetInventoryMovementReportParams) ([]GetInventoryMovementReportRow, error) {
+	rows, err := q.db.Query(ctx, getInventoryMovementReport,
+		arg.Period,
+		arg.FromTime,
+		arg.ToTime,
+		arg.ProductID,
+		arg.OffsetCount,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetInventoryMovementReportRow{}
+	for rows.Next() {
+		var i GetInventoryMovementReportRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.PeriodStart,
+			&i.Restocked,
+			&i.Reserved,
+			&i.Released,
+			&i.Deducted,
+			&i.Adjusted,
+			&i.NetAvailableChange,
+			&i.NetReservedChange,
+			&i.MovementCount,
 		); err != nil {
 			return nil, err
 		}
@@ -498,7 +935,7 @@ func (q *Queries) GetInventoryLogsByProductID(ctx context.Context, arg GetInvent
 }
 
 const getInventoryReservationByID = `-- name: GetInventoryReservationByID :one
-SELECT id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at FROM inventory_reservations
+SELECT id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at, sku_id FROM inventory_reservations
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -515,12 +952,13 @@ func (q *Queries) GetInventoryReservationByID(ctx context.Context, id int64) (In
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SkuID,
 	)
 	return i, err
 }
 
 const getInventoryReservationByOrderID = `-- name: GetInventoryReservationByOrderID :many
-SELECT id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at FROM inventory_reservations
+SELECT id, product_id, order_id, quantity, status, expires_at, created_at, updated_at, deleted_at, sku_id FROM inventory_reservations
 WHERE order_id = $1 AND deleted_at IS NULL
 `
 
@@ -543,6 +981,7 @@ func (q *Queries) GetInventoryReservationByOrderID(ctx context.Context, orderID
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SkuID,
 		); err != nil {
 			return nil, err
 		}
@@ -554,8 +993,72 @@ func (q *Queries) GetInventoryReservationByOrderID(ctx context.Context, orderID
 	return items, nil
 }
 
+const getLastInventoryLogAtOrBefore = `-- name: GetLastInventoryLogAtOrBefore :one
+SELECT id, product_id, order_id, change_type, quantity_change, before_available, after_available, before_reserved, after_reserved, reason, operator_id, created_at, reference_type, reference_id, sku_id FROM inventory_logs
+WHERE product_id = $1
+    AND sku_id IS NOT DISTINCT FROM $2
+    AND created_at <= $3
+ORDER BY created_at DESC, id DESC
+LIMIT 1
+`
+
+type GetLastInventoryLogAtOrBeforeParams struct {
+	ProductID int64     `db:"product_id" json:"product_id"`
+	SkuID     *int64    `db:"sku_id" json:"sku_id"`
+	AsOf      time.Time `db:"as_of" json:"as_of"`
+}
+
+func (q *Queries) GetLastInventoryLogAtOrBefore(ctx context.Context, arg GetLastInventoryLogAtOrBeforeParams) (InventoryLog, error) {
+	row := q.db.QueryRow(ctx, getLastInventoryLogAtOrBefore, arg.ProductID, arg.SkuID, arg.AsOf)
+	var i InventoryLog
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.OrderID,
+		&i.ChangeType,
+		&i.QuantityChange,
+		&i.BeforeAvailable,
+		&i.AfterAvailable,
+		&i.BeforeReserved,
+		&i.AfterReserved,
+		&i.Reason,
+		&i.OperatorID,
+		&i.CreatedAt,
+		&i.ReferenceType,
+		&i.ReferenceID,
+		&i.SkuID,
+	)
+	return i, err
+}
+
+const getProductOnHandStock = `-- name: GetProductOnHandStock :one
+SELECT COALESCE(SUM(available_stock + reserved_stock), 0)::int AS on_hand
+FROM inventory
+WHERE product_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetProductOnHandStock(ctx context.Context, productID int64) (int32, error) {
+	row := q.db.QueryRow(ctx, getProductOnHandStock, productID)
+	var on_hand int32
+	err := row.Scan(&on_hand)
+	return on_hand, err
+}
+
+const holdOrderReservations = `-- name: HoldOrderReservations :exec
+UPDATE inventory_reservations
+SET
+    status = 'held',
+    updated_at = NOW()
+WHERE order_id = $1 AND status = 'active' AND deleted_at IS NULL
+`
+
+func (q *Queries) HoldOrderReservations(ctx context.Context, orderID int64) error {
+	_, err := q.db.Exec(ctx, holdOrderReservations, orderID)
+	return err
+}
+
 const listInventories = `-- name: ListInventories :many
-SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at FROM inventory
+SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at, stock_policy, backorder_limit, preorder_release_at, backordered_stock, sku_id FROM inventory
 WHERE deleted_at IS NULL
 ORDER BY id
 LIMIT $1 OFFSET $2
@@ -586,6 +1089,80 @@ func (q *Queries) ListInventories(ctx context.Context, arg ListInventoriesParams
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.StockPolicy,
+			&i.BackorderLimit,
+			&i.PreorderReleaseAt,
+			&i.BackorderedStock,
+			&i.SkuID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInventoriesForExport = `-- name: ListInventoriesForExport :many
+SELECT
+    i.id,
+    i.product_id,
+    i.sku_id,
+    s.sku_code,
+    p.name AS product_name,
+    i.available_stock,
+    i.reserved_stock,
+    i.total_stock,
+    i.low_stock_threshold,
+    i.updated_at
+FROM inventory i
+JOIN products p ON p.id = i.product_id
+LEFT JOIN product_skus s ON s.id = i.sku_id
+WHERE i.id > $1 AND i.deleted_at IS NULL
+ORDER BY i.id
+LIMIT $2
+`
+
+type ListInventoriesForExportParams struct {
+	AfterID   int64 `db:"after_id" json:"after_id"`
+	BatchSize int32 `db:"batch_size" json:"batch_size"`
+}
+
+type ListInventoriesForExportRow struct {
+	ID                int64     `db:"id" json:"id"`
+	ProductID         int64     `db:"product_id" json:"product_id"`
+	SkuID             *int64    `db:"sku_id" json:"sku_id"`
+	SkuCode           *string   `db:"sku_code" json:"sku_code"`
+	ProductName       string    `db:"product_name" json:"product_name"`
+	AvailableStock    int32     `db:"available_stock" json:"available_stock"`
+	ReservedStock     int32     `db:"reserved_stock" json:"reserved_stock"`
+	TotalStock        int32     `db:"total_stock" json:"total_stock"`
+	LowStockThreshold *int32    `db:"low_stock_threshold" json:"low_stock_threshold"`
+	UpdatedAt         time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *Queries) ListInventoriesForExport(ctx context.Context, arg ListInventoriesForExportParams) ([]ListInventoriesForExportRow, error) {
+	rows, err := q.db.Query(ctx, listInventoriesForExport, arg.AfterID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInventoriesForExportRow{}
+	for rows.Next() {
+		var i ListInventoriesForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.SkuID,
+			&i.SkuCode,
+			&i.ProductName,
+			&i.AvailableStock,
+			&i.ReservedStock,
+			&i.TotalStock,
+			&i.LowStockThreshold,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -598,7 +1175,7 @@ func (q *Queries) ListInventories(ctx context.Context, arg ListInventoriesParams
 }
 
 const listLowStockInventories = `-- name: ListLowStockInventories :many
-SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at FROM inventory
+SELECT id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at, stock_policy, backorder_limit, preorder_release_at, backordered_stock, sku_id FROM inventory
 WHERE available_stock <= low_stock_threshold AND deleted_at IS NULL
 ORDER BY available_stock ASC
 LIMIT $1 OFFSET $2
@@ -629,6 +1206,11 @@ func (q *Queries) ListLowStockInventories(ctx context.Context, arg ListLowStockI
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.StockPolicy,
+			&i.BackorderLimit,
+			&i.PreorderReleaseAt,
+			&i.BackorderedStock,
+			&i.SkuID,
 		); err != nil {
 			return nil, err
 		}
@@ -640,6 +1222,27 @@ func (q *Queries) ListLowStockInventories(ctx context.Context, arg ListLowStockI
 	return items, nil
 }
 
+const releaseBackorderedStock = `-- name: ReleaseBackorderedStock :exec
+UPDATE inventory
+SET
+    backordered_stock = backordered_stock - $1,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = $2
+    AND backordered_stock >= $1
+    AND deleted_at IS NULL
+`
+
+type ReleaseBackorderedStockParams struct {
+	Quantity int32 `db:"quantity" json:"quantity"`
+	ID       int64 `db:"id" json:"id"`
+}
+
+func (q *Queries) ReleaseBackorderedStock(ctx context.Context, arg ReleaseBackorderedStockParams) error {
+	_, err := q.db.Exec(ctx, releaseBackorderedStock, arg.Quantity, arg.ID)
+	return err
+}
+
 const releaseReservedStock = `-- name: ReleaseReservedStock :exec
 UPDATE inventory
 SET
@@ -647,7 +1250,7 @@ SET
     reserved_stock = reserved_stock - $1,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $2
+WHERE id = $2
     AND reserved_stock >= $1
     AND version = $3
     AND deleted_at IS NULL
@@ -655,12 +1258,12 @@ WHERE product_id = $2
 
 type ReleaseReservedStockParams struct {
 	AvailableStock int32 `db:"available_stock" json:"available_stock"`
-	ProductID      int64 `db:"product_id" json:"product_id"`
+	ID             int64 `db:"id" json:"id"`
 	Version        int64 `db:"version" json:"version"`
 }
 
 func (q *Queries) ReleaseReservedStock(ctx context.Context, arg ReleaseReservedStockParams) error {
-	_, err := q.db.Exec(ctx, releaseReservedStock, arg.AvailableStock, arg.ProductID, arg.Version)
+	_, err := q.db.Exec(ctx, releaseReservedStock, arg.AvailableStock, arg.ID, arg.Version)
 	return err
 }
 
@@ -671,7 +1274,7 @@ SET
     reserved_stock = reserved_stock + $1,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $2
+WHERE id = $2
     AND available_stock >= $1
     AND version = $3
     AND deleted_at IS NULL
@@ -679,12 +1282,12 @@ WHERE product_id = $2
 
 type ReserveStockParams struct {
 	AvailableStock int32 `db:"available_stock" json:"available_stock"`
-	ProductID      int64 `db:"product_id" json:"product_id"`
+	ID             int64 `db:"id" json:"id"`
 	Version        int64 `db:"version" json:"version"`
 }
 
 func (q *Queries) ReserveStock(ctx context.Context, arg ReserveStockParams) error {
-	_, err := q.db.Exec(ctx, reserveStock, arg.AvailableStock, arg.ProductID, arg.Version)
+	_, err := q.db.Exec(ctx, reserveStock, arg.AvailableStock, arg.ID, arg.Version)
 	return err
 }
 
@@ -695,13 +1298,13 @@ SET
     reserved_stock = $2,
     version = version + 1,
     updated_at = NOW()
-WHERE product_id = $3 AND version = $4 AND deleted_at IS NULL
+WHERE id = $3 AND version = $4 AND deleted_at IS NULL
 `
 
 type UpdateInventoryStockParams struct {
 	AvailableStock int32 `db:"available_stock" json:"available_stock"`
 	ReservedStock  int32 `db:"reserved_stock" json:"reserved_stock"`
-	ProductID      int64 `db:"product_id" json:"product_id"`
+	ID             int64 `db:"id" json:"id"`
 	Version        int64 `db:"version" json:"version"`
 }
 
@@ -709,7 +1312,7 @@ func (q *Queries) UpdateInventoryStock(ctx context.Context, arg UpdateInventoryS
 	_, err := q.db.Exec(ctx, updateInventoryStock,
 		arg.AvailableStock,
 		arg.ReservedStock,
-		arg.ProductID,
+		arg.ID,
 		arg.Version,
 	)
 	return err
@@ -720,16 +1323,16 @@ UPDATE inventory
 SET
     low_stock_threshold = $1,
     updated_at = NOW()
-WHERE product_id = $2 AND deleted_at IS NULL
+WHERE id = $2 AND deleted_at IS NULL
 `
 
 type UpdateLowStockThresholdParams struct {
 	LowStockThreshold *int32 `db:"low_stock_threshold" json:"low_stock_threshold"`
-	ProductID         int64  `db:"product_id" json:"product_id"`
+	ID                int64  `db:"id" json:"id"`
 }
 
 func (q *Queries) UpdateLowStockThreshold(ctx context.Context, arg UpdateLowStockThresholdParams) error {
-	_, err := q.db.Exec(ctx, updateLowStockThreshold, arg.LowStockThreshold, arg.ProductID)
+	_, err := q.db.Exec(ctx, updateLowStockThreshold, arg.LowStockThreshold, arg.ID)
 	return err
 }
 
@@ -750,3 +1353,49 @@ func (q *Queries) UpdateReservationStatus(ctx context.Context, arg UpdateReserva
 	_, err := q.db.Exec(ctx, updateReservationStatus, arg.Status, arg.ID)
 	return err
 }
+
+const updateStockPolicy = `-- name: UpdateStockPolicy :one
+UPDATE inventory
+SET
+    stock_policy = $1,
+    backorder_limit = $2,
+    preorder_release_at = $3,
+    updated_at = NOW()
+WHERE id = $4 AND deleted_at IS NULL
+RETURNING id, product_id, available_stock, reserved_stock, total_stock, low_stock_threshold, version, created_at, updated_at, deleted_at, stock_policy, backorder_limit, preorder_release_at, backordered_stock, sku_id
+`
+
+type UpdateStockPolicyParams struct {
+	StockPolicy       string         `db:"stock_policy" json:"stock_policy"`
+	BackorderLimit    *int32         `db:"backorder_limit" json:"backorder_limit"`
+	PreorderReleaseAt types.NullTime `db:"preorder_release_at" json:"preorder_release_at"`
+	ID                int64          `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateStockPolicy(ctx context.Context, arg UpdateStockPolicyParams) (Inventory, error) {
+	row := q.db.QueryRow(ctx, updateStockPolicy,
+		arg.StockPolicy,
+		arg.BackorderLimit,
+		arg.PreorderReleaseAt,
+		arg.ID,
+	)
+	var i Inventory
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.AvailableStock,
+		&i.ReservedStock,
+		&i.TotalStock,
+		&i.LowStockThreshold,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.StockPolicy,
+		&i.BackorderLimit,
+		&i.PreorderReleaseAt,
+		&i.BackorderedStock,
+		&i.SkuID,
+	)
+	return i, err
+}
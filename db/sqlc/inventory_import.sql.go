@@ -0,0 +1,143 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: inventory_import.sql
+
+package sqlc
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const createImportJob = `-- name: CreateImportJob :one
+
+INSERT INTO inventory_import_jobs (
+    filename,
+    total_rows,
+    operator_id
+) VALUES (
+    $1, $2, $3
+) RETURNING id, filename, status, total_rows, processed_rows, succeeded_rows, failed_rows, errors, error_message, operator_id, started_at, finished_at, created_at, updated_at
+`
+
+type CreateImportJobParams struct {
+	Filename   string `db:"filename" json:"filename"`
+	TotalRows  int32  `db:"total_rows" json:"total_rows"`
+	OperatorID *int64 `db:"operator_id" json:"operator_id"`
+}
+
+// Inventory Import Jobs Queries
+func (q *Queries) CreateImportJob(ctx context.Context, arg CreateImportJobParams) (InventoryImportJob, error) {
+	row := q.db.QueryRow(ctx, createImportJob, arg.Filename, arg.TotalRows, arg.OperatorID)
+	var i InventoryImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.Filename,
+		&i.Status,
+		&i.TotalRows,
+		&i.ProcessedRows,
+		&i.SucceededRows,
+		&i.FailedRows,
+		&i.Errors,
+		&i.ErrorMessage,
+		&i.OperatorID,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const finishImportJob = `-- name: FinishImportJob :exec
+UPDATE inventory_import_jobs
+SET
+    status = $1,
+    error_message = $2,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $3
+`
+
+type FinishImportJobParams struct {
+	Status       string  `db:"status" json:"status"`
+	ErrorMessage *string `db:"error_message" json:"error_message"`
+	ID           int64   `db:"id" json:"id"`
+}
+
+func (q *Queries) FinishImportJob(ctx context.Context, arg FinishImportJobParams) error {
+	_, err := q.db.Exec(ctx, finishImportJob, arg.Status, arg.ErrorMessage, arg.ID)
+	return err
+}
+
+const getImportJob = `-- name: GetImportJob :one
+SELECT id, filename, status, total_rows, processed_rows, succeeded_rows, failed_rows, errors, error_message, operator_id, started_at, finished_at, created_at, updated_at FROM inventory_import_jobs
+WHERE id = $1
+`
+
+func (q *Queries) GetImportJob(ctx context.Context, id int64) (InventoryImportJob, error) {
+	row := q.db.QueryRow(ctx, getImportJob, id)
+	var i InventoryImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.Filename,
+		&i.Status,
+		&i.TotalRows,
+		&i.ProcessedRows,
+		&i.SucceededRows,
+		&i.FailedRows,
+		&i.Errors,
+		&i.ErrorMessage,
+		&i.OperatorID,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const startImportJob = `-- name: StartImportJob :exec
+UPDATE inventory_import_jobs
+SET
+    status = 'running',
+    started_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+`
+
+func (q *Queries) StartImportJob(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, startImportJob, id)
+	return err
+}
+
+const updateImportJobProgress = `-- name: UpdateImportJobProgress :exec
+UPDATE inventory_import_jobs
+SET
+    processed_rows = $1,
+    succeeded_rows = $2,
+    failed_rows = $3,
+    errors = $4,
+    updated_at = NOW()
+WHERE id = $5
+`
+
+type UpdateImportJobProgressParams struct {
+	ProcessedRows int32           `db:"processed_rows" json:"processed_rows"`
+	SucceededRows int32           `db:"succeeded_rows" json:"succeeded_rows"`
+	FailedRows    int32           `db:"failed_rows" json:"failed_rows"`
+	Errors        json.RawMessage `db:"errors" json:"errors"`
+	ID            int64           `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateImportJobProgress(ctx context.Context, arg UpdateImportJobProgressParams) error {
+	_, err := q.db.Exec(ctx, updateImportJobProgress,
+		arg.ProcessedRows,
+		arg.SucceededRows,
+		arg.FailedRows,
+		arg.Errors,
+		arg.ID,
+	)
+	return err
+}
@@ -5,6 +5,7 @@
 package sqlc
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +21,7 @@ type Cart struct {
 	CreatedAt time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time      `db:"updated_at" json:"updated_at"`
 	DeletedAt types.NullTime `db:"deleted_at" json:"deleted_at"`
+	SkuID     *int64         `db:"sku_id" json:"sku_id"`
 }
 
 type Category struct {
@@ -47,6 +49,43 @@ type Inventory struct {
 	CreatedAt         time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt         time.Time      `db:"updated_at" json:"updated_at"`
 	DeletedAt         types.NullTime `db:"deleted_at" json:"deleted_at"`
+	StockPolicy       string         `db:"stock_policy" json:"stock_policy"`
+	// Maximum outstanding backordered units; NULL means unlimited
+	BackorderLimit    *int32         `db:"backorder_limit" json:"backorder_limit"`
+	PreorderReleaseAt types.NullTime `db:"preorder_release_at" json:"preorder_release_at"`
+	// Units on pending backorders waiting for stock
+	BackorderedStock int32 `db:"backordered_stock" json:"backordered_stock"`
+	// NULL for products without variants
+	SkuID *int64 `db:"sku_id" json:"sku_id"`
+}
+
+type InventoryBackorder struct {
+	ID          int64          `db:"id" json:"id"`
+	ProductID   int64          `db:"product_id" json:"product_id"`
+	OrderID     int64          `db:"order_id" json:"order_id"`
+	Quantity    int32          `db:"quantity" json:"quantity"`
+	Status      string         `db:"status" json:"status"`
+	AllocatedAt types.NullTime `db:"allocated_at" json:"allocated_at"`
+	CreatedAt   time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at" json:"updated_at"`
+	SkuID       *int64         `db:"sku_id" json:"sku_id"`
+}
+
+type InventoryImportJob struct {
+	ID            int64           `db:"id" json:"id"`
+	Filename      string          `db:"filename" json:"filename"`
+	Status        string          `db:"status" json:"status"`
+	TotalRows     int32           `db:"total_rows" json:"total_rows"`
+	ProcessedRows int32           `db:"processed_rows" json:"processed_rows"`
+	SucceededRows int32           `db:"succeeded_rows" json:"succeeded_rows"`
+	FailedRows    int32           `db:"failed_rows" json:"failed_rows"`
+	Errors        json.RawMessage `db:"errors" json:"errors"`
+	ErrorMessage  *string         `db:"error_message" json:"error_message"`
+	OperatorID    *int64          `db:"operator_id" json:"operator_id"`
+	StartedAt     types.NullTime  `db:"started_at" json:"started_at"`
+	FinishedAt    types.NullTime  `db:"finished_at" json:"finished_at"`
+	CreatedAt     time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at" json:"updated_at"`
 }
 
 type InventoryLog struct {
@@ -62,6 +101,9 @@ type InventoryLog struct {
 	Reason          *string   `db:"reason" json:"reason"`
 	OperatorID      *int64    `db:"operator_id" json:"operator_id"`
 	CreatedAt       time.Time `db:"created_at" json:"created_at"`
+	ReferenceType   *string   `db:"reference_type" json:"reference_type"`
+	ReferenceID     *int64    `db:"reference_id" json:"reference_id"`
+	SkuID           *int64    `db:"sku_id" json:"sku_id"`
 }
 
 type InventoryReservation struct {
@@ -74,6 +116,7 @@ type InventoryReservation struct {
 	CreatedAt time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time      `db:"updated_at" json:"updated_at"`
 	DeletedAt types.NullTime `db:"deleted_at" json:"deleted_at"`
+	SkuID     *int64         `db:"sku_id" json:"sku_id"`
 }
 
 type Order struct {
@@ -113,6 +156,7 @@ type OrderItem struct {
 	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt    time.Time      `db:"updated_at" json:"updated_at"`
 	DeletedAt    types.NullTime `db:"deleted_at" json:"deleted_at"`
+	SkuID        *int64         `db:"sku_id" json:"sku_id"`
 }
 
 type Product struct {
@@ -134,17 +178,207 @@ type Product struct {
 	CreatedAt         time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt         time.Time      `db:"updated_at" json:"updated_at"`
 	DeletedAt         types.NullTime `db:"deleted_at" json:"deleted_at"`
+	// Maintained by trigger: name (A), brand (B), description (C)
+	SearchVector string `db:"search_vector" json:"search_vector"`
+	RatingTotal  int64  `db:"rating_total" json:"rating_total"`
+	RatingCount  int32  `db:"rating_count" json:"rating_count"`
+}
+
+type ProductAnswer struct {
+	ID          int64     `db:"id" json:"id"`
+	QuestionID  int64     `db:"question_id" json:"question_id"`
+	UserID      int64     `db:"user_id" json:"user_id"`
+	Content     string    `db:"content" json:"content"`
+	IsStaff     bool      `db:"is_staff" json:"is_staff"`
+	IsBuyer     bool      `db:"is_buyer" json:"is_buyer"`
+	UpvoteCount int32     `db:"upvote_count" json:"upvote_count"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type ProductAnswerVote struct {
+	AnswerID  int64     `db:"answer_id" json:"answer_id"`
+	UserID    int64     `db:"user_id" json:"user_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type ProductAssociation struct {
+	ProductID        int64     `db:"product_id" json:"product_id"`
+	RelatedProductID int64     `db:"related_product_id" json:"related_product_id"`
+	Support          int32     `db:"support" json:"support"`
+	Confidence       float64   `db:"confidence" json:"confidence"`
+	ComputedAt       time.Time `db:"computed_at" json:"computed_at"`
 }
 
 type ProductImage struct {
-	ID        int64          `db:"id" json:"id"`
-	ProductID int64          `db:"product_id" json:"product_id"`
-	ImageUrl  string         `db:"image_url" json:"image_url"`
-	Sort      *int32         `db:"sort" json:"sort"`
-	IsMain    *bool          `db:"is_main" json:"is_main"`
-	CreatedAt time.Time      `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time      `db:"updated_at" json:"updated_at"`
-	DeletedAt types.NullTime `db:"deleted_at" json:"deleted_at"`
+	ID          int64          `db:"id" json:"id"`
+	ProductID   int64          `db:"product_id" json:"product_id"`
+	ImageUrl    string         `db:"image_url" json:"image_url"`
+	Sort        *int32         `db:"sort" json:"sort"`
+	IsMain      *bool          `db:"is_main" json:"is_main"`
+	CreatedAt   time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at" json:"updated_at"`
+	DeletedAt   types.NullTime `db:"deleted_at" json:"deleted_at"`
+	ParentID    *int64         `db:"parent_id" json:"parent_id"`
+	Rendition   string         `db:"rendition" json:"rendition"`
+	StorageKey  *string        `db:"storage_key" json:"storage_key"`
+	ContentType *string        `db:"content_type" json:"content_type"`
+	Width       *int32         `db:"width" json:"width"`
+	Height      *int32         `db:"height" json:"height"`
+	SizeBytes   *int64         `db:"size_bytes" json:"size_bytes"`
+}
+
+type ProductOption struct {
+	ID        int64     `db:"id" json:"id"`
+	ProductID int64     `db:"product_id" json:"product_id"`
+	Name      string    `db:"name" json:"name"`
+	Position  int32     `db:"position" json:"position"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type ProductOptionValue struct {
+	ID        int64     `db:"id" json:"id"`
+	OptionID  int64     `db:"option_id" json:"option_id"`
+	Value     string    `db:"value" json:"value"`
+	Position  int32     `db:"position" json:"position"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type ProductQuestion struct {
+	ID          int64     `db:"id" json:"id"`
+	ProductID   int64     `db:"product_id" json:"product_id"`
+	UserID      int64     `db:"user_id" json:"user_id"`
+	Content     string    `db:"content" json:"content"`
+	AnswerCount int32     `db:"answer_count" json:"answer_count"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type ProductReview struct {
+	ID           int64          `db:"id" json:"id"`
+	ProductID    int64          `db:"product_id" json:"product_id"`
+	UserID       int64          `db:"user_id" json:"user_id"`
+	OrderItemID  int64          `db:"order_item_id" json:"order_item_id"`
+	Rating       int16          `db:"rating" json:"rating"`
+	Content      string         `db:"content" json:"content"`
+	Images       []string       `db:"images" json:"images"`
+	HelpfulCount int32          `db:"helpful_count" json:"helpful_count"`
+	Reply        *string        `db:"reply" json:"reply"`
+	RepliedAt    types.NullTime `db:"replied_at" json:"replied_at"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at" json:"updated_at"`
+	Status       string         `db:"status" json:"status"`
+	// Screener rules the review broke when submitted
+	FlagReasons []string `db:"flag_reasons" json:"flag_reasons"`
+	// SHA-256 of the normalized content, for duplicate detection
+	ContentFingerprint string         `db:"content_fingerprint" json:"content_fingerprint"`
+	RejectReason       *string        `db:"reject_reason" json:"reject_reason"`
+	ModeratedBy        *int64         `db:"moderated_by" json:"moderated_by"`
+	ModeratedAt        types.NullTime `db:"moderated_at" json:"moderated_at"`
+}
+
+type ProductReviewModerationLog struct {
+	ID         int64     `db:"id" json:"id"`
+	ReviewID   int64     `db:"review_id" json:"review_id"`
+	Action     string    `db:"action" json:"action"`
+	FromStatus *string   `db:"from_status" json:"from_status"`
+	ToStatus   string    `db:"to_status" json:"to_status"`
+	Reasons    []string  `db:"reasons" json:"reasons"`
+	OperatorID *int64    `db:"operator_id" json:"operator_id"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+type ProductReviewVote struct {
+	ReviewID  int64     `db:"review_id" json:"review_id"`
+	UserID    int64     `db:"user_id" json:"user_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type ProductSku struct {
+	ID          int64   `db:"id" json:"id"`
+	ProductID   int64   `db:"product_id" json:"product_id"`
+	SkuCode     string  `db:"sku_code" json:"sku_code"`
+	Price       int64   `db:"price" json:"price"`
+	OriginPrice int64   `db:"origin_price" json:"origin_price"`
+	ImageUrl    *string `db:"image_url" json:"image_url"`
+	Barcode     *string `db:"barcode" json:"barcode"`
+	// Option name to value, e.g. {"size": "M", "colour": "Red"}
+	Options   json.RawMessage `db:"options" json:"options"`
+	IsActive  bool            `db:"is_active" json:"is_active"`
+	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at" json:"updated_at"`
+	DeletedAt types.NullTime  `db:"deleted_at" json:"deleted_at"`
+}
+
+type PurchaseOrder struct {
+	ID           int64          `db:"id" json:"id"`
+	PoNo         string         `db:"po_no" json:"po_no"`
+	SupplierID   int64          `db:"supplier_id" json:"supplier_id"`
+	Status       string         `db:"status" json:"status"`
+	ExpectedDate types.NullTime `db:"expected_date" json:"expected_date"`
+	TotalCost    int64          `db:"total_cost" json:"total_cost"`
+	Note         *string        `db:"note" json:"note"`
+	CreatedBy    *int64         `db:"created_by" json:"created_by"`
+	OrderedAt    types.NullTime `db:"ordered_at" json:"ordered_at"`
+	ReceivedAt   types.NullTime `db:"received_at" json:"received_at"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+type PurchaseOrderItem struct {
+	ID               int64          `db:"id" json:"id"`
+	PurchaseOrderID  int64          `db:"purchase_order_id" json:"purchase_order_id"`
+	ProductID        int64          `db:"product_id" json:"product_id"`
+	Quantity         int32          `db:"quantity" json:"quantity"`
+	ReceivedQuantity int32          `db:"received_quantity" json:"received_quantity"`
+	UnitCost         int64          `db:"unit_cost" json:"unit_cost"`
+	ExpectedDate     types.NullTime `db:"expected_date" json:"expected_date"`
+	CreatedAt        time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time      `db:"updated_at" json:"updated_at"`
+	SkuID            *int64         `db:"sku_id" json:"sku_id"`
+}
+
+type PurchaseReceipt struct {
+	ID                  int64  `db:"id" json:"id"`
+	PurchaseOrderID     int64  `db:"purchase_order_id" json:"purchase_order_id"`
+	PurchaseOrderItemID int64  `db:"purchase_order_item_id" json:"purchase_order_item_id"`
+	ProductID           int64  `db:"product_id" json:"product_id"`
+	Quantity            int32  `db:"quantity" json:"quantity"`
+	UnitCost            int64  `db:"unit_cost" json:"unit_cost"`
+	OnHandBefore        int32  `db:"on_hand_before" json:"on_hand_before"`
+	CostPriceBefore     *int64 `db:"cost_price_before" json:"cost_price_before"`
+	// Weighted average cost per unit after this receipt
+	CostPriceAfter int64     `db:"cost_price_after" json:"cost_price_after"`
+	ReceivedBy     *int64    `db:"received_by" json:"received_by"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+	SkuID          *int64    `db:"sku_id" json:"sku_id"`
+}
+
+type ReorderSetting struct {
+	CategoryID      int64     `db:"category_id" json:"category_id"`
+	LeadTimeDays    int32     `db:"lead_time_days" json:"lead_time_days"`
+	SafetyStockDays int32     `db:"safety_stock_days" json:"safety_stock_days"`
+	AutoApply       bool      `db:"auto_apply" json:"auto_apply"`
+	CreatedAt       time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type ReorderSuggestion struct {
+	ProductID  int64 `db:"product_id" json:"product_id"`
+	CategoryID int64 `db:"category_id" json:"category_id"`
+	WindowDays int32 `db:"window_days" json:"window_days"`
+	// Greater of ordered units (non-cancelled orders) and deducted units over the window
+	UnitsSold       int32   `db:"units_sold" json:"units_sold"`
+	DailyVelocity   float64 `db:"daily_velocity" json:"daily_velocity"`
+	LeadTimeDays    int32   `db:"lead_time_days" json:"lead_time_days"`
+	SafetyStockDays int32   `db:"safety_stock_days" json:"safety_stock_days"`
+	ReorderPoint    int32   `db:"reorder_point" json:"reorder_point"`
+	// Units needed to reach the order-up-to level from available stock
+	ReorderQuantity  int32     `db:"reorder_quantity" json:"reorder_quantity"`
+	CurrentThreshold *int32    `db:"current_threshold" json:"current_threshold"`
+	AvailableStock   int32     `db:"available_stock" json:"available_stock"`
+	ComputedAt       time.Time `db:"computed_at" json:"computed_at"`
+	SkuID            *int64    `db:"sku_id" json:"sku_id"`
 }
 
 type Session struct {
@@ -158,6 +392,59 @@ type Session struct {
 	CreatedAt    time.Time `db:"created_at" json:"created_at"`
 }
 
+type StockAlert struct {
+	ID             int64          `db:"id" json:"id"`
+	ProductID      int64          `db:"product_id" json:"product_id"`
+	AvailableStock int32          `db:"available_stock" json:"available_stock"`
+	Threshold      int32          `db:"threshold" json:"threshold"`
+	Status         string         `db:"status" json:"status"`
+	NotifiedAt     types.NullTime `db:"notified_at" json:"notified_at"`
+	ResolvedAt     types.NullTime `db:"resolved_at" json:"resolved_at"`
+	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at" json:"updated_at"`
+	SkuID          *int64         `db:"sku_id" json:"sku_id"`
+}
+
+type Stocktake struct {
+	ID         int64          `db:"id" json:"id"`
+	Status     string         `db:"status" json:"status"`
+	CategoryID *int64         `db:"category_id" json:"category_id"`
+	Note       *string        `db:"note" json:"note"`
+	CreatedBy  *int64         `db:"created_by" json:"created_by"`
+	ApprovedBy *int64         `db:"approved_by" json:"approved_by"`
+	ApprovedAt types.NullTime `db:"approved_at" json:"approved_at"`
+	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+type StocktakeItem struct {
+	ID          int64 `db:"id" json:"id"`
+	StocktakeID int64 `db:"stocktake_id" json:"stocktake_id"`
+	ProductID   int64 `db:"product_id" json:"product_id"`
+	// available_stock + reserved_stock when the session was opened
+	ExpectedQuantity int32          `db:"expected_quantity" json:"expected_quantity"`
+	CountedQuantity  *int32         `db:"counted_quantity" json:"counted_quantity"`
+	CountedBy        *int64         `db:"counted_by" json:"counted_by"`
+	CountedAt        types.NullTime `db:"counted_at" json:"counted_at"`
+	// Posted variance (counted - system total), set on approval
+	Variance  *int32    `db:"variance" json:"variance"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	SkuID     *int64    `db:"sku_id" json:"sku_id"`
+}
+
+type Supplier struct {
+	ID          int64     `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	ContactName *string   `db:"contact_name" json:"contact_name"`
+	Email       *string   `db:"email" json:"email"`
+	Phone       *string   `db:"phone" json:"phone"`
+	Address     *string   `db:"address" json:"address"`
+	IsActive    bool      `db:"is_active" json:"is_active"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
 type User struct {
 	ID                int64          `db:"id" json:"id"`
 	Username          string         `db:"username" json:"username"`
@@ -177,6 +464,7 @@ type User struct {
 	CreatedAt         time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt         time.Time      `db:"updated_at" json:"updated_at"`
 	DeletedAt         types.NullTime `db:"deleted_at" json:"deleted_at"`
+	Role              string         `db:"role" json:"role"`
 }
 
 type VerificationCode struct {
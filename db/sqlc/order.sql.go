@@ -125,19 +125,21 @@ const createOrderItem = `-- name: CreateOrderItem :one
 INSERT INTO order_items (
     order_id,
     product_id,
+    sku_id,
     product_name,
     product_image,
     quantity,
     unit_price,
     total_price
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, order_id, product_id, product_name, product_image, quantity, unit_price, total_price, created_at, updated_at, deleted_at
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, order_id, product_id, product_name, product_image, quantity, unit_price, total_price, created_at, updated_at, deleted_at, sku_id
 `
 
 type CreateOrderItemParams struct {
 	OrderID      int64   `db:"order_id" json:"order_id"`
 	ProductID    int64   `db:"product_id" json:"product_id"`
+	SkuID        *int64  `db:"sku_id" json:"sku_id"`
 	ProductName  string  `db:"product_name" json:"product_name"`
 	ProductImage *string `db:"product_image" json:"product_image"`
 	Quantity     int32   `db:"quantity" json:"quantity"`
@@ -150,6 +152,7 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 	row := q.db.QueryRow(ctx, createOrderItem,
 		arg.OrderID,
 		arg.ProductID,
+		arg.SkuID,
 		arg.ProductName,
 		arg.ProductImage,
 		arg.Quantity,
@@ -169,6 +172,7 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SkuID,
 	)
 	return i, err
 }
@@ -244,7 +248,7 @@ func (q *Queries) GetOrderByOrderNo(ctx context.Context, orderNo string) (Order,
 }
 
 const getOrderItems = `-- name: GetOrderItems :many
-SELECT id, order_id, product_id, product_name, product_image, quantity, unit_price, total_price, created_at, updated_at, deleted_at FROM order_items
+SELECT id, order_id, product_id, product_name, product_image, quantity, unit_price, total_price, created_at, updated_at, deleted_at, sku_id FROM order_items
 WHERE order_id = $1 AND deleted_at IS NULL
 ORDER BY id
 `
@@ -270,6 +274,7 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID int64) ([]OrderItem
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SkuID,
 		); err != nil {
 			return nil, err
 		}
@@ -282,7 +287,7 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID int64) ([]OrderItem
 }
 
 const getOrderItemsByIDs = `-- name: GetOrderItemsByIDs :many
-SELECT id, order_id, product_id, product_name, product_image, quantity, unit_price, total_price, created_at, updated_at, deleted_at FROM order_items
+SELECT id, order_id, product_id, product_name, product_image, quantity, unit_price, total_price, created_at, updated_at, deleted_at, sku_id FROM order_items
 WHERE order_id = ANY($1::bigint[]) AND deleted_at IS NULL
 ORDER BY order_id, id
 `
@@ -308,6 +313,7 @@ func (q *Queries) GetOrderItemsByIDs(ctx context.Context, dollar_1 []int64) ([]O
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SkuID,
 		); err != nil {
 			return nil, err
 		}
@@ -375,6 +381,28 @@ func (q *Queries) ListUserOrders(ctx context.Context, arg ListUserOrdersParams)
 	return items, nil
 }
 
+const promoteBackorderedOrder = `-- name: PromoteBackorderedOrder :execrows
+UPDATE orders o
+SET
+    status = 'pending',
+    updated_at = NOW()
+WHERE o.id = $1
+    AND o.status = 'backordered'
+    AND o.deleted_at IS NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM inventory_backorders b
+        WHERE b.order_id = o.id AND b.status = 'pending'
+    )
+`
+
+func (q *Queries) PromoteBackorderedOrder(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, promoteBackorderedOrder, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateOrderPaymentStatus = `-- name: UpdateOrderPaymentStatus :exec
 UPDATE orders
 SET
@@ -10,16 +10,24 @@ import (
 	"time"
 )
 
-const countProducts = `-- name: CountProducts :one
-SELECT COUNT(*) FROM products
-WHERE deleted_at IS NULL
+const addProductViews = `-- name: AddProductViews :exec
+UPDATE products AS p
+SET view_count = p.view_count + v.views
+FROM (
+    SELECT unnest($1::bigint[]) AS id, unnest($2::int[]) AS views
+) AS v
+WHERE p.id = v.id AND p.deleted_at IS NULL
 `
 
-func (q *Queries) CountProducts(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countProducts)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+type AddProductViewsParams struct {
+	ProductIds []int64 `db:"product_ids" json:"product_ids"`
+	Views      []int32 `db:"views" json:"views"`
+}
+
+// Applies batched view counts; product_ids and views are parallel arrays
+func (q *Queries) AddProductViews(ctx context.Context, arg AddProductViewsParams) error {
+	_, err := q.db.Exec(ctx, addProductViews, arg.ProductIds, arg.Views)
+	return err
 }
 
 const createProduct = `-- name: CreateProduct :one
@@ -29,7 +37,7 @@ INSERT INTO products (
 ) VALUES (
              $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
          )
-    RETURNING id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at
+    RETURNING id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at, search_vector, rating_total, rating_count
 `
 
 type CreateProductParams struct {
@@ -82,6 +90,9 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SearchVector,
+		&i.RatingTotal,
+		&i.RatingCount,
 	)
 	return i, err
 }
@@ -93,7 +104,7 @@ INSERT INTO product_images (
 ) VALUES (
              $1, $2, $3, $4
          )
-    RETURNING id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at
+    RETURNING id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at, parent_id, rendition, storage_key, content_type, width, height, size_bytes
 `
 
 type CreateProductImageParams struct {
@@ -121,6 +132,73 @@ func (q *Queries) CreateProductImage(ctx context.Context, arg CreateProductImage
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ParentID,
+		&i.Rendition,
+		&i.StorageKey,
+		&i.ContentType,
+		&i.Width,
+		&i.Height,
+		&i.SizeBytes,
+	)
+	return i, err
+}
+
+const createUploadedProductImage = `-- name: CreateUploadedProductImage :one
+INSERT INTO product_images (
+    product_id, parent_id, rendition, image_url, storage_key,
+    content_type, width, height, size_bytes, sort, is_main
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+         )
+    RETURNING id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at, parent_id, rendition, storage_key, content_type, width, height, size_bytes
+`
+
+type CreateUploadedProductImageParams struct {
+	ProductID   int64   `db:"product_id" json:"product_id"`
+	ParentID    *int64  `db:"parent_id" json:"parent_id"`
+	Rendition   string  `db:"rendition" json:"rendition"`
+	ImageUrl    string  `db:"image_url" json:"image_url"`
+	StorageKey  *string `db:"storage_key" json:"storage_key"`
+	ContentType *string `db:"content_type" json:"content_type"`
+	Width       *int32  `db:"width" json:"width"`
+	Height      *int32  `db:"height" json:"height"`
+	SizeBytes   *int64  `db:"size_bytes" json:"size_bytes"`
+	Sort        *int32  `db:"sort" json:"sort"`
+	IsMain      *bool   `db:"is_main" json:"is_main"`
+}
+
+// Records an uploaded original (parent_id NULL) or one of its thumbnail renditions
+func (q *Queries) CreateUploadedProductImage(ctx context.Context, arg CreateUploadedProductImageParams) (ProductImage, error) {
+	row := q.db.QueryRow(ctx, createUploadedProductImage,
+		arg.ProductID,
+		arg.ParentID,
+		arg.Rendition,
+		arg.ImageUrl,
+		arg.StorageKey,
+		arg.ContentType,
+		arg.Width,
+		arg.Height,
+		arg.SizeBytes,
+		arg.Sort,
+		arg.IsMain,
+	)
+	var i ProductImage
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ImageUrl,
+		&i.Sort,
+		&i.IsMain,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ParentID,
+		&i.Rendition,
+		&i.StorageKey,
+		&i.ContentType,
+		&i.Width,
+		&i.Height,
+		&i.SizeBytes,
 	)
 	return i, err
 }
@@ -156,10 +234,19 @@ func (q *Queries) DeleteProduct(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteProductAssociations = `-- name: DeleteProductAssociations :exec
+DELETE FROM product_associations
+`
+
+func (q *Queries) DeleteProductAssociations(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteProductAssociations)
+	return err
+}
+
 const deleteProductImage = `-- name: DeleteProductImage :exec
 UPDATE product_images
 SET deleted_at = NOW()
-WHERE id = $1
+WHERE id = $1 OR parent_id = $1
 `
 
 func (q *Queries) DeleteProductImage(ctx context.Context, id int64) error {
@@ -178,9 +265,60 @@ func (q *Queries) DeleteProductImages(ctx context.Context, productID int64) erro
 	return err
 }
 
+const getHotProductActivity = `-- name: GetHotProductActivity :many
+SELECT p.id AS product_id,
+       p.category_id,
+       s.score::float8 AS sales_score
+FROM products p
+JOIN (
+    SELECT oi.product_id,
+           SUM(oi.quantity * power(2, -EXTRACT(EPOCH FROM NOW() - COALESCE(o.paid_at, o.updated_at)) / $1::float8)) AS score
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.status IN ('paid', 'shipped', 'completed')
+      AND COALESCE(o.paid_at, o.updated_at) >= $2::timestamptz
+    GROUP BY oi.product_id
+) s ON s.product_id = p.id
+WHERE p.deleted_at IS NULL
+`
+
+type GetHotProductActivityParams struct {
+	HalfLifeSeconds float64   `db:"half_life_seconds" json:"half_life_seconds"`
+	Since           time.Time `db:"since" json:"since"`
+}
+
+type GetHotProductActivityRow struct {
+	ProductID  int64   `db:"product_id" json:"product_id"`
+	CategoryID int64   `db:"category_id" json:"category_id"`
+	SalesScore float64 `db:"sales_score" json:"sales_score"`
+}
+
+// Paid quantities per product since a cutoff, decayed to now with the given half-life;
+// used to rebuild the hot product rankings
+func (q *Queries) GetHotProductActivity(ctx context.Context, arg GetHotProductActivityParams) ([]GetHotProductActivityRow, error) {
+	rows, err := q.db.Query(ctx, getHotProductActivity, arg.HalfLifeSeconds, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetHotProductActivityRow{}
+	for rows.Next() {
+		var i GetHotProductActivityRow
+		if err := rows.Scan(&i.ProductID, &i.CategoryID, &i.SalesScore); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getImagesByProductIDs = `-- name: GetImagesByProductIDs :many
-SELECT id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at FROM product_images
+SELECT id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at, parent_id, rendition, storage_key, content_type, width, height, size_bytes FROM product_images
 WHERE product_id = ANY($1::bigint[])
+  AND parent_id IS NULL
   AND deleted_at IS NULL
 ORDER BY product_id ASC, sort ASC, id ASC
 `
@@ -203,6 +341,13 @@ func (q *Queries) GetImagesByProductIDs(ctx context.Context, dollar_1 []int64) (
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ParentID,
+			&i.Rendition,
+			&i.StorageKey,
+			&i.ContentType,
+			&i.Width,
+			&i.Height,
+			&i.SizeBytes,
 		); err != nil {
 			return nil, err
 		}
@@ -216,7 +361,7 @@ func (q *Queries) GetImagesByProductIDs(ctx context.Context, dollar_1 []int64) (
 
 const getLowStockProducts = `-- name: GetLowStockProducts :many
 
-SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at FROM products
+SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at, search_vector, rating_total, rating_count FROM products
 WHERE stock <= low_stock_threshold
   AND status = 'published'
   AND deleted_at IS NULL
@@ -258,6 +403,9 @@ func (q *Queries) GetLowStockProducts(ctx context.Context, arg GetLowStockProduc
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
+			&i.RatingTotal,
+			&i.RatingCount,
 		); err != nil {
 			return nil, err
 		}
@@ -270,7 +418,7 @@ func (q *Queries) GetLowStockProducts(ctx context.Context, arg GetLowStockProduc
 }
 
 const getProductByID = `-- name: GetProductByID :one
-SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at FROM products
+SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at, search_vector, rating_total, rating_count FROM products
 WHERE id = $1 AND deleted_at IS NULL
     LIMIT 1
 `
@@ -297,13 +445,99 @@ func (q *Queries) GetProductByID(ctx context.Context, id int64) (Product, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SearchVector,
+		&i.RatingTotal,
+		&i.RatingCount,
 	)
 	return i, err
 }
 
+const getProductCostForUpdate = `-- name: GetProductCostForUpdate :one
+SELECT cost_price FROM products
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE
+`
+
+func (q *Queries) GetProductCostForUpdate(ctx context.Context, id int64) (*int64, error) {
+	row := q.db.QueryRow(ctx, getProductCostForUpdate, id)
+	var cost_price *int64
+	err := row.Scan(&cost_price)
+	return cost_price, err
+}
+
+const getProductImage = `-- name: GetProductImage :one
+SELECT id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at, parent_id, rendition, storage_key, content_type, width, height, size_bytes FROM product_images
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetProductImage(ctx context.Context, id int64) (ProductImage, error) {
+	row := q.db.QueryRow(ctx, getProductImage, id)
+	var i ProductImage
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ImageUrl,
+		&i.Sort,
+		&i.IsMain,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ParentID,
+		&i.Rendition,
+		&i.StorageKey,
+		&i.ContentType,
+		&i.Width,
+		&i.Height,
+		&i.SizeBytes,
+	)
+	return i, err
+}
+
+const getProductImageRenditions = `-- name: GetProductImageRenditions :many
+SELECT id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at, parent_id, rendition, storage_key, content_type, width, height, size_bytes FROM product_images
+WHERE product_id = $1 AND parent_id IS NOT NULL AND deleted_at IS NULL
+ORDER BY parent_id ASC, width ASC
+`
+
+func (q *Queries) GetProductImageRenditions(ctx context.Context, productID int64) ([]ProductImage, error) {
+	rows, err := q.db.Query(ctx, getProductImageRenditions, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductImage{}
+	for rows.Next() {
+		var i ProductImage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.ImageUrl,
+			&i.Sort,
+			&i.IsMain,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ParentID,
+			&i.Rendition,
+			&i.StorageKey,
+			&i.ContentType,
+			&i.Width,
+			&i.Height,
+			&i.SizeBytes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProductImages = `-- name: GetProductImages :many
-SELECT id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at FROM product_images
-WHERE product_id = $1 AND deleted_at IS NULL
+SELECT id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at, parent_id, rendition, storage_key, content_type, width, height, size_bytes FROM product_images
+WHERE product_id = $1 AND parent_id IS NULL AND deleted_at IS NULL
 ORDER BY sort ASC, id ASC
 `
 
@@ -325,6 +559,13 @@ func (q *Queries) GetProductImages(ctx context.Context, productID int64) ([]Prod
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ParentID,
+			&i.Rendition,
+			&i.StorageKey,
+			&i.ContentType,
+			&i.Width,
+			&i.Height,
+			&i.SizeBytes,
 		); err != nil {
 			return nil, err
 		}
@@ -336,8 +577,36 @@ func (q *Queries) GetProductImages(ctx context.Context, productID int64) ([]Prod
 	return items, nil
 }
 
+const getProductLiveFields = `-- name: GetProductLiveFields :one
+SELECT stock, sales_count, view_count, rating_total, rating_count
+FROM products
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type GetProductLiveFieldsRow struct {
+	Stock       int32 `db:"stock" json:"stock"`
+	SalesCount  int32 `db:"sales_count" json:"sales_count"`
+	ViewCount   int32 `db:"view_count" json:"view_count"`
+	RatingTotal int64 `db:"rating_total" json:"rating_total"`
+	RatingCount int32 `db:"rating_count" json:"rating_count"`
+}
+
+// Detail fields moved by orders, reviews and view counts; read on every detail request instead of cached
+func (q *Queries) GetProductLiveFields(ctx context.Context, id int64) (GetProductLiveFieldsRow, error) {
+	row := q.db.QueryRow(ctx, getProductLiveFields, id)
+	var i GetProductLiveFieldsRow
+	err := row.Scan(
+		&i.Stock,
+		&i.SalesCount,
+		&i.ViewCount,
+		&i.RatingTotal,
+		&i.RatingCount,
+	)
+	return i, err
+}
+
 const getProductMainImage = `-- name: GetProductMainImage :one
-SELECT id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at FROM product_images
+SELECT id, product_id, image_url, sort, is_main, created_at, updated_at, deleted_at, parent_id, rendition, storage_key, content_type, width, height, size_bytes FROM product_images
 WHERE product_id = $1 AND is_main = TRUE AND deleted_at IS NULL
     LIMIT 1
 `
@@ -354,19 +623,24 @@ func (q *Queries) GetProductMainImage(ctx context.Context, productID int64) (Pro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ParentID,
+		&i.Rendition,
+		&i.StorageKey,
+		&i.ContentType,
+		&i.Width,
+		&i.Height,
+		&i.SizeBytes,
 	)
 	return i, err
 }
 
 const getProductsByIDs = `-- name: GetProductsByIDs :many
-
-SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at FROM products
+SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at, search_vector, rating_total, rating_count FROM products
 WHERE id = ANY($1::bigint[])
   AND deleted_at IS NULL
 ORDER BY sales_count DESC
 `
 
-// Batch Operations
 func (q *Queries) GetProductsByIDs(ctx context.Context, dollar_1 []int64) ([]Product, error) {
 	rows, err := q.db.Query(ctx, getProductsByIDs, dollar_1)
 	if err != nil {
@@ -395,6 +669,9 @@ func (q *Queries) GetProductsByIDs(ctx context.Context, dollar_1 []int64) ([]Pro
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
+			&i.RatingTotal,
+			&i.RatingCount,
 		); err != nil {
 			return nil, err
 		}
@@ -422,33 +699,173 @@ func (q *Queries) IncrementProductSales(ctx context.Context, arg IncrementProduc
 	return err
 }
 
-const incrementProductViews = `-- name: IncrementProductViews :exec
-UPDATE products
-SET view_count = view_count + 1
-WHERE id = $1 AND deleted_at IS NULL
+const insertProductAssociations = `-- name: InsertProductAssociations :execrows
+INSERT INTO product_associations (product_id, related_product_id, support, confidence, computed_at)
+SELECT ranked.product_id, ranked.related_product_id, ranked.support, ranked.confidence, NOW()
+FROM (
+    SELECT pairs.product_id,
+           pairs.related_product_id,
+           pairs.support,
+           pairs.support::float8 / totals.orders AS confidence,
+           ROW_NUMBER() OVER (
+               PARTITION BY pairs.product_id
+               ORDER BY pairs.support::float8 / totals.orders DESC, pairs.support DESC, pairs.related_product_id
+           ) AS position
+    FROM (
+        SELECT a.product_id, b.product_id AS related_product_id, COUNT(DISTINCT a.order_id)::int AS support
+        FROM order_items a
+        JOIN order_items b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+        JOIN orders o ON o.id = a.order_id
+        WHERE o.created_at >= $1::timestamptz
+          AND o.status NOT IN ('cancelled', 'refunded')
+          AND o.deleted_at IS NULL
+          AND a.deleted_at IS NULL
+          AND b.deleted_at IS NULL
+        GROUP BY a.product_id, b.product_id
+    ) pairs
+    JOIN (
+        SELECT oi.product_id, COUNT(DISTINCT oi.order_id) AS orders
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        WHERE o.created_at >= $1::timestamptz
+          AND o.status NOT IN ('cancelled', 'refunded')
+          AND o.deleted_at IS NULL
+          AND oi.deleted_at IS NULL
+        GROUP BY oi.product_id
+    ) totals ON totals.product_id = pairs.product_id
+    WHERE pairs.support >= $2::int
+      AND pairs.support::float8 / totals.orders >= $3::float8
+) ranked
+WHERE ranked.position <= $4::int
 `
 
-func (q *Queries) IncrementProductViews(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, incrementProductViews, id)
-	return err
+type InsertProductAssociationsParams struct {
+	Since         time.Time `db:"since" json:"since"`
+	MinSupport    int32     `db:"min_support" json:"min_support"`
+	MinConfidence float64   `db:"min_confidence" json:"min_confidence"`
+	MaxPerProduct int32     `db:"max_per_product" json:"max_per_product"`
 }
 
-const listFeaturedProducts = `-- name: ListFeaturedProducts :many
-SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at FROM products
-WHERE is_featured = TRUE
-  AND status = 'published'
-  AND deleted_at IS NULL
-ORDER BY sales_count DESC
-    LIMIT $1 OFFSET $2
+// Pairs of products bought in the same order since a cutoff, keeping for each product the
+// max_per_product strongest pairs that meet both thresholds
+func (q *Queries) InsertProductAssociations(ctx context.Context, arg InsertProductAssociationsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertProductAssociations,
+		arg.Since,
+		arg.MinSupport,
+		arg.MinConfidence,
+		arg.MaxPerProduct,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listAlsoBoughtProducts = `-- name: ListAlsoBoughtProducts :many
+SELECT p.id, p.name, p.description, p.brand, p.price, p.origin_price, p.cost_price, p.stock, p.low_stock_threshold, p.sales_count, p.view_count, p.category_id, p.status, p.is_featured, p.specifications, p.created_at, p.updated_at, p.deleted_at, p.search_vector, p.rating_total, p.rating_count, a.support, a.confidence
+FROM product_associations a
+JOIN products p ON p.id = a.related_product_id
+JOIN (
+    -- Available stock summed over the product-level row and the rows of active SKUs
+    SELECT i.product_id, SUM(i.available_stock) AS available_stock
+    FROM inventory i
+    LEFT JOIN product_skus s ON s.id = i.sku_id
+    WHERE i.deleted_at IS NULL
+      AND (i.sku_id IS NULL OR (s.is_active AND s.deleted_at IS NULL))
+    GROUP BY i.product_id
+) stock ON stock.product_id = p.id
+WHERE a.product_id = $1
+  AND p.status = 'published'
+  AND stock.available_stock > 0
+  AND p.deleted_at IS NULL
+ORDER BY a.confidence DESC, a.support DESC, p.id
+LIMIT $2
 `
 
-type ListFeaturedProductsParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+type ListAlsoBoughtProductsParams struct {
+	ProductID int64 `db:"product_id" json:"product_id"`
+	Limit     int32 `db:"limit" json:"limit"`
 }
 
-func (q *Queries) ListFeaturedProducts(ctx context.Context, arg ListFeaturedProductsParams) ([]Product, error) {
-	rows, err := q.db.Query(ctx, listFeaturedProducts, arg.Limit, arg.Offset)
+type ListAlsoBoughtProductsRow struct {
+	Product    Product `db:"product" json:"product"`
+	Support    int32   `db:"support" json:"support"`
+	Confidence float64 `db:"confidence" json:"confidence"`
+}
+
+// Products bought together with a product, strongest first, that can be bought right now
+func (q *Queries) ListAlsoBoughtProducts(ctx context.Context, arg ListAlsoBoughtProductsParams) ([]ListAlsoBoughtProductsRow, error) {
+	rows, err := q.db.Query(ctx, listAlsoBoughtProducts, arg.ProductID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAlsoBoughtProductsRow{}
+	for rows.Next() {
+		var i ListAlsoBoughtProductsRow
+		if err := rows.Scan(
+			&i.Product.ID,
+			&i.Product.Name,
+			&i.Product.Description,
+			&i.Product.Brand,
+			&i.Product.Price,
+			&i.Product.OriginPrice,
+			&i.Product.CostPrice,
+			&i.Product.Stock,
+			&i.Product.LowStockThreshold,
+			&i.Product.SalesCount,
+			&i.Product.ViewCount,
+			&i.Product.CategoryID,
+			&i.Product.Status,
+			&i.Product.IsFeatured,
+			&i.Product.Specifications,
+			&i.Product.CreatedAt,
+			&i.Product.UpdatedAt,
+			&i.Product.DeletedAt,
+			&i.Product.SearchVector,
+			&i.Product.RatingTotal,
+			&i.Product.RatingCount,
+			&i.Support,
+			&i.Confidence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryBestSellers = `-- name: ListCategoryBestSellers :many
+SELECT p.id, p.name, p.description, p.brand, p.price, p.origin_price, p.cost_price, p.stock, p.low_stock_threshold, p.sales_count, p.view_count, p.category_id, p.status, p.is_featured, p.specifications, p.created_at, p.updated_at, p.deleted_at, p.search_vector, p.rating_total, p.rating_count FROM products p
+JOIN (
+    SELECT i.product_id, SUM(i.available_stock) AS available_stock
+    FROM inventory i
+    LEFT JOIN product_skus s ON s.id = i.sku_id
+    WHERE i.deleted_at IS NULL
+      AND (i.sku_id IS NULL OR (s.is_active AND s.deleted_at IS NULL))
+    GROUP BY i.product_id
+) stock ON stock.product_id = p.id
+WHERE p.category_id = $1
+  AND p.id <> ALL($2::bigint[])
+  AND p.status = 'published'
+  AND stock.available_stock > 0
+  AND p.deleted_at IS NULL
+ORDER BY p.sales_count DESC, p.id
+LIMIT $3
+`
+
+type ListCategoryBestSellersParams struct {
+	CategoryID int64   `db:"category_id" json:"category_id"`
+	ExcludeIds []int64 `db:"exclude_ids" json:"exclude_ids"`
+	RowLimit   int32   `db:"row_limit" json:"row_limit"`
+}
+
+// Published, in-stock best sellers of a category, skipping the given products
+func (q *Queries) ListCategoryBestSellers(ctx context.Context, arg ListCategoryBestSellersParams) ([]Product, error) {
+	rows, err := q.db.Query(ctx, listCategoryBestSellers, arg.CategoryID, arg.ExcludeIds, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -475,6 +892,9 @@ func (q *Queries) ListFeaturedProducts(ctx context.Context, arg ListFeaturedProd
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
+			&i.RatingTotal,
+			&i.RatingCount,
 		); err != nil {
 			return nil, err
 		}
@@ -486,29 +906,22 @@ func (q *Queries) ListFeaturedProducts(ctx context.Context, arg ListFeaturedProd
 	return items, nil
 }
 
-const listProducts = `-- name: ListProducts :many
-SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at FROM products
-WHERE deleted_at IS NULL
-  AND ($3::bigint IS NULL OR category_id = $3)
-  AND ($4::text IS NULL OR status = $4)
-ORDER BY created_at DESC
+const listFeaturedProducts = `-- name: ListFeaturedProducts :many
+SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at, search_vector, rating_total, rating_count FROM products
+WHERE is_featured = TRUE
+  AND status = 'published'
+  AND deleted_at IS NULL
+ORDER BY sales_count DESC
     LIMIT $1 OFFSET $2
 `
 
-type ListProductsParams struct {
-	Limit      int32   `db:"limit" json:"limit"`
-	Offset     int32   `db:"offset" json:"offset"`
-	CategoryID *int64  `db:"category_id" json:"category_id"`
-	Status     *string `db:"status" json:"status"`
+type ListFeaturedProductsParams struct {
+	Limit  int32 `db:"limit" json:"limit"`
+	Offset int32 `db:"offset" json:"offset"`
 }
 
-func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error) {
-	rows, err := q.db.Query(ctx, listProducts,
-		arg.Limit,
-		arg.Offset,
-		arg.CategoryID,
-		arg.Status,
-	)
+func (q *Queries) ListFeaturedProducts(ctx context.Context, arg ListFeaturedProductsParams) ([]Product, error) {
+	rows, err := q.db.Query(ctx, listFeaturedProducts, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -535,6 +948,9 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
+			&i.RatingTotal,
+			&i.RatingCount,
 		); err != nil {
 			return nil, err
 		}
@@ -546,8 +962,33 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 	return items, nil
 }
 
+const listProductCategoryIDs = `-- name: ListProductCategoryIDs :many
+SELECT DISTINCT category_id FROM products
+WHERE deleted_at IS NULL
+`
+
+func (q *Queries) ListProductCategoryIDs(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listProductCategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var category_id int64
+		if err := rows.Scan(&category_id); err != nil {
+			return nil, err
+		}
+		items = append(items, category_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProductsByCategory = `-- name: ListProductsByCategory :many
-SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at FROM products
+SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at, search_vector, rating_total, rating_count FROM products
 WHERE category_id = $1
   AND status = 'published'
   AND deleted_at IS NULL
@@ -589,6 +1030,9 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, arg ListProductsBy
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
+			&i.RatingTotal,
+			&i.RatingCount,
 		); err != nil {
 			return nil, err
 		}
@@ -602,7 +1046,7 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, arg ListProductsBy
 
 const listProductsByPriceRange = `-- name: ListProductsByPriceRange :many
 
-SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at FROM products
+SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at, search_vector, rating_total, rating_count FROM products
 WHERE deleted_at IS NULL
   AND status = 'published'
   AND price BETWEEN $1 AND $2
@@ -651,6 +1095,9 @@ func (q *Queries) ListProductsByPriceRange(ctx context.Context, arg ListProducts
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
+			&i.RatingTotal,
+			&i.RatingCount,
 		); err != nil {
 			return nil, err
 		}
@@ -662,23 +1109,23 @@ func (q *Queries) ListProductsByPriceRange(ctx context.Context, arg ListProducts
 	return items, nil
 }
 
-const searchProducts = `-- name: SearchProducts :many
-SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at FROM products
+const listProductsForIndex = `-- name: ListProductsForIndex :many
+
+SELECT id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at, search_vector, rating_total, rating_count FROM products
 WHERE deleted_at IS NULL
-  AND status = 'published'
-  AND (name ILIKE '%' || $1 || '%' OR description ILIKE '%' || $1 || '%')
-ORDER BY sales_count DESC
-    LIMIT $2 OFFSET $3
+  AND id > $1
+ORDER BY id
+LIMIT $2
 `
 
-type SearchProductsParams struct {
-	Column1 *string `db:"column_1" json:"column_1"`
-	Limit   int32   `db:"limit" json:"limit"`
-	Offset  int32   `db:"offset" json:"offset"`
+type ListProductsForIndexParams struct {
+	AfterID   int64 `db:"after_id" json:"after_id"`
+	BatchSize int32 `db:"batch_size" json:"batch_size"`
 }
 
-func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]Product, error) {
-	rows, err := q.db.Query(ctx, searchProducts, arg.Column1, arg.Limit, arg.Offset)
+// Batch Operations
+func (q *Queries) ListProductsForIndex(ctx context.Context, arg ListProductsForIndexParams) ([]Product, error) {
+	rows, err := q.db.Query(ctx, listProductsForIndex, arg.AfterID, arg.BatchSize)
 	if err != nil {
 		return nil, err
 	}
@@ -705,6 +1152,9 @@ func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
+			&i.RatingTotal,
+			&i.RatingCount,
 		); err != nil {
 			return nil, err
 		}
@@ -761,6 +1211,24 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) er
 	return err
 }
 
+const updateProductCostPrice = `-- name: UpdateProductCostPrice :exec
+UPDATE products
+SET
+    cost_price = $1,
+    updated_at = NOW()
+WHERE id = $2
+`
+
+type UpdateProductCostPriceParams struct {
+	CostPrice *int64 `db:"cost_price" json:"cost_price"`
+	ID        int64  `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateProductCostPrice(ctx context.Context, arg UpdateProductCostPriceParams) error {
+	_, err := q.db.Exec(ctx, updateProductCostPrice, arg.CostPrice, arg.ID)
+	return err
+}
+
 const updateProductImage = `-- name: UpdateProductImage :exec
 UPDATE product_images
 SET
@@ -814,7 +1282,7 @@ SET
 WHERE id = $2
   AND updated_at = $3
   AND deleted_at IS NULL
-RETURNING id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at
+RETURNING id, name, description, brand, price, origin_price, cost_price, stock, low_stock_threshold, sales_count, view_count, category_id, status, is_featured, specifications, created_at, updated_at, deleted_at, search_vector, rating_total, rating_count
 `
 
 type UpdateProductStockWithVersionParams struct {
@@ -845,6 +1313,9 @@ func (q *Queries) UpdateProductStockWithVersion(ctx context.Context, arg UpdateP
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SearchVector,
+		&i.RatingTotal,
+		&i.RatingCount,
 	)
 	return i, err
 }
@@ -0,0 +1,493 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: product_sku.sql
+
+package sqlc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gomall/utils/types"
+)
+
+const countProductSkus = `-- name: CountProductSkus :one
+SELECT COUNT(*) FROM product_skus
+WHERE product_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountProductSkus(ctx context.Context, productID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductSkus, productID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createProductOption = `-- name: CreateProductOption :one
+
+INSERT INTO product_options (
+    product_id,
+    name,
+    position
+) VALUES (
+    $1, $2, $3
+) RETURNING id, product_id, name, position, created_at
+`
+
+type CreateProductOptionParams struct {
+	ProductID int64  `db:"product_id" json:"product_id"`
+	Name      string `db:"name" json:"name"`
+	Position  int32  `db:"position" json:"position"`
+}
+
+// Product Option Queries
+func (q *Queries) CreateProductOption(ctx context.Context, arg CreateProductOptionParams) (ProductOption, error) {
+	row := q.db.QueryRow(ctx, createProductOption, arg.ProductID, arg.Name, arg.Position)
+	var i ProductOption
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Name,
+		&i.Position,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createProductOptionValue = `-- name: CreateProductOptionValue :one
+INSERT INTO product_option_values (
+    option_id,
+    value,
+    position
+) VALUES (
+    $1, $2, $3
+) RETURNING id, option_id, value, position, created_at
+`
+
+type CreateProductOptionValueParams struct {
+	OptionID int64  `db:"option_id" json:"option_id"`
+	Value    string `db:"value" json:"value"`
+	Position int32  `db:"position" json:"position"`
+}
+
+func (q *Queries) CreateProductOptionValue(ctx context.Context, arg CreateProductOptionValueParams) (ProductOptionValue, error) {
+	row := q.db.QueryRow(ctx, createProductOptionValue, arg.OptionID, arg.Value, arg.Position)
+	var i ProductOptionValue
+	err := row.Scan(
+		&i.ID,
+		&i.OptionID,
+		&i.Value,
+		&i.Position,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createProductSku = `-- name: CreateProductSku :one
+
+INSERT INTO product_skus (
+    product_id,
+    sku_code,
+    price,
+    origin_price,
+    image_url,
+    barcode,
+    options
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, product_id, sku_code, price, origin_price, image_url, barcode, options, is_active, created_at, updated_at, deleted_at
+`
+
+type CreateProductSkuParams struct {
+	ProductID   int64           `db:"product_id" json:"product_id"`
+	SkuCode     string          `db:"sku_code" json:"sku_code"`
+	Price       int64           `db:"price" json:"price"`
+	OriginPrice int64           `db:"origin_price" json:"origin_price"`
+	ImageUrl    *string         `db:"image_url" json:"image_url"`
+	Barcode     *string         `db:"barcode" json:"barcode"`
+	Options     json.RawMessage `db:"options" json:"options"`
+}
+
+// Product SKU Queries
+func (q *Queries) CreateProductSku(ctx context.Context, arg CreateProductSkuParams) (ProductSku, error) {
+	row := q.db.QueryRow(ctx, createProductSku,
+		arg.ProductID,
+		arg.SkuCode,
+		arg.Price,
+		arg.OriginPrice,
+		arg.ImageUrl,
+		arg.Barcode,
+		arg.Options,
+	)
+	var i ProductSku
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.SkuCode,
+		&i.Price,
+		&i.OriginPrice,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.Options,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deleteProductOptions = `-- name: DeleteProductOptions :exec
+DELETE FROM product_options
+WHERE product_id = $1
+`
+
+func (q *Queries) DeleteProductOptions(ctx context.Context, productID int64) error {
+	_, err := q.db.Exec(ctx, deleteProductOptions, productID)
+	return err
+}
+
+const deleteProductSku = `-- name: DeleteProductSku :execrows
+UPDATE product_skus
+SET
+    deleted_at = NOW()
+WHERE id = $1 AND product_id = $2 AND deleted_at IS NULL
+`
+
+type DeleteProductSkuParams struct {
+	ID        int64 `db:"id" json:"id"`
+	ProductID int64 `db:"product_id" json:"product_id"`
+}
+
+func (q *Queries) DeleteProductSku(ctx context.Context, arg DeleteProductSkuParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProductSku, arg.ID, arg.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getConflictingProductSku = `-- name: GetConflictingProductSku :one
+SELECT id, product_id, sku_code, price, origin_price, image_url, barcode, options, is_active, created_at, updated_at, deleted_at FROM product_skus
+WHERE deleted_at IS NULL
+    AND (sku_code = $1 OR barcode = $2)
+LIMIT 1
+`
+
+type GetConflictingProductSkuParams struct {
+	SkuCode string  `db:"sku_code" json:"sku_code"`
+	Barcode *string `db:"barcode" json:"barcode"`
+}
+
+func (q *Queries) GetConflictingProductSku(ctx context.Context, arg GetConflictingProductSkuParams) (ProductSku, error) {
+	row := q.db.QueryRow(ctx, getConflictingProductSku, arg.SkuCode, arg.Barcode)
+	var i ProductSku
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.SkuCode,
+		&i.Price,
+		&i.OriginPrice,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.Options,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getProductSku = `-- name: GetProductSku :one
+SELECT id, product_id, sku_code, price, origin_price, image_url, barcode, options, is_active, created_at, updated_at, deleted_at FROM product_skus
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetProductSku(ctx context.Context, id int64) (ProductSku, error) {
+	row := q.db.QueryRow(ctx, getProductSku, id)
+	var i ProductSku
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.SkuCode,
+		&i.Price,
+		&i.OriginPrice,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.Options,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listActiveSkusByProductIDs = `-- name: ListActiveSkusByProductIDs :many
+SELECT id, product_id, sku_code, price, origin_price, image_url, barcode, options, is_active, created_at, updated_at, deleted_at FROM product_skus
+WHERE product_id = ANY($1::bigint[])
+    AND is_active = TRUE
+    AND deleted_at IS NULL
+ORDER BY product_id, id
+`
+
+func (q *Queries) ListActiveSkusByProductIDs(ctx context.Context, productIds []int64) ([]ProductSku, error) {
+	rows, err := q.db.Query(ctx, listActiveSkusByProductIDs, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductSku{}
+	for rows.Next() {
+		var i ProductSku
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.SkuCode,
+			&i.Price,
+			&i.OriginPrice,
+			&i.ImageUrl,
+			&i.Barcode,
+			&i.Options,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductOptionValues = `-- name: ListProductOptionValues :many
+SELECT
+    o.id AS option_id,
+    o.name AS option_name,
+    o.position AS option_position,
+    v.id AS value_id,
+    v.value,
+    v.position AS value_position
+FROM product_options o
+JOIN product_option_values v ON v.option_id = o.id
+WHERE o.product_id = $1
+ORDER BY o.position, o.id, v.position, v.id
+`
+
+type ListProductOptionValuesRow struct {
+	OptionID       int64  `db:"option_id" json:"option_id"`
+	OptionName     string `db:"option_name" json:"option_name"`
+	OptionPosition int32  `db:"option_position" json:"option_position"`
+	ValueID        int64  `db:"value_id" json:"value_id"`
+	Value          string `db:"value" json:"value"`
+	ValuePosition  int32  `db:"value_position" json:"value_position"`
+}
+
+func (q *Queries) ListProductOptionValues(ctx context.Context, productID int64) ([]ListProductOptionValuesRow, error) {
+	rows, err := q.db.Query(ctx, listProductOptionValues, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductOptionValuesRow{}
+	for rows.Next() {
+		var i ListProductOptionValuesRow
+		if err := rows.Scan(
+			&i.OptionID,
+			&i.OptionName,
+			&i.OptionPosition,
+			&i.ValueID,
+			&i.Value,
+			&i.ValuePosition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductSkuStock = `-- name: ListProductSkuStock :many
+SELECT s.id, COALESCE(i.available_stock, 0)::int AS available_stock
+FROM product_skus s
+LEFT JOIN inventory i ON i.sku_id = s.id AND i.deleted_at IS NULL
+WHERE s.product_id = $1 AND s.deleted_at IS NULL
+`
+
+type ListProductSkuStockRow struct {
+	ID             int64 `db:"id" json:"id"`
+	AvailableStock int32 `db:"available_stock" json:"available_stock"`
+}
+
+// Current available stock of a product's SKUs, read live for the cached product detail
+func (q *Queries) ListProductSkuStock(ctx context.Context, productID int64) ([]ListProductSkuStockRow, error) {
+	rows, err := q.db.Query(ctx, listProductSkuStock, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductSkuStockRow{}
+	for rows.Next() {
+		var i ListProductSkuStockRow
+		if err := rows.Scan(&i.ID, &i.AvailableStock); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductSkus = `-- name: ListProductSkus :many
+SELECT
+    s.id, s.product_id, s.sku_code, s.price, s.origin_price, s.image_url, s.barcode, s.options, s.is_active, s.created_at, s.updated_at, s.deleted_at,
+    COALESCE(i.available_stock, 0)::int AS available_stock
+FROM product_skus s
+LEFT JOIN inventory i ON i.sku_id = s.id AND i.deleted_at IS NULL
+WHERE s.product_id = $1 AND s.deleted_at IS NULL
+ORDER BY s.id
+`
+
+type ListProductSkusRow struct {
+	ID             int64           `db:"id" json:"id"`
+	ProductID      int64           `db:"product_id" json:"product_id"`
+	SkuCode        string          `db:"sku_code" json:"sku_code"`
+	Price          int64           `db:"price" json:"price"`
+	OriginPrice    int64           `db:"origin_price" json:"origin_price"`
+	ImageUrl       *string         `db:"image_url" json:"image_url"`
+	Barcode        *string         `db:"barcode" json:"barcode"`
+	Options        json.RawMessage `db:"options" json:"options"`
+	IsActive       bool            `db:"is_active" json:"is_active"`
+	CreatedAt      time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time       `db:"updated_at" json:"updated_at"`
+	DeletedAt      types.NullTime  `db:"deleted_at" json:"deleted_at"`
+	AvailableStock int32           `db:"available_stock" json:"available_stock"`
+}
+
+func (q *Queries) ListProductSkus(ctx context.Context, productID int64) ([]ListProductSkusRow, error) {
+	rows, err := q.db.Query(ctx, listProductSkus, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductSkusRow{}
+	for rows.Next() {
+		var i ListProductSkusRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.SkuCode,
+			&i.Price,
+			&i.OriginPrice,
+			&i.ImageUrl,
+			&i.Barcode,
+			&i.Options,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AvailableStock,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductSkusByCodes = `-- name: ListProductSkusByCodes :many
+SELECT id, product_id, sku_code FROM product_skus
+WHERE sku_code = ANY($1::text[]) AND deleted_at IS NULL
+`
+
+type ListProductSkusByCodesRow struct {
+	ID        int64  `db:"id" json:"id"`
+	ProductID int64  `db:"product_id" json:"product_id"`
+	SkuCode   string `db:"sku_code" json:"sku_code"`
+}
+
+func (q *Queries) ListProductSkusByCodes(ctx context.Context, skuCodes []string) ([]ListProductSkusByCodesRow, error) {
+	rows, err := q.db.Query(ctx, listProductSkusByCodes, skuCodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductSkusByCodesRow{}
+	for rows.Next() {
+		var i ListProductSkusByCodesRow
+		if err := rows.Scan(&i.ID, &i.ProductID, &i.SkuCode); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProductSku = `-- name: UpdateProductSku :one
+UPDATE product_skus
+SET
+    price = COALESCE($1, price),
+    origin_price = COALESCE($2, origin_price),
+    image_url = COALESCE($3, image_url),
+    barcode = COALESCE($4, barcode),
+    is_active = COALESCE($5, is_active),
+    updated_at = NOW()
+WHERE id = $6 AND product_id = $7 AND deleted_at IS NULL
+RETURNING id, product_id, sku_code, price, origin_price, image_url, barcode, options, is_active, created_at, updated_at, deleted_at
+`
+
+type UpdateProductSkuParams struct {
+	Price       *int64  `db:"price" json:"price"`
+	OriginPrice *int64  `db:"origin_price" json:"origin_price"`
+	ImageUrl    *string `db:"image_url" json:"image_url"`
+	Barcode     *string `db:"barcode" json:"barcode"`
+	IsActive    *bool   `db:"is_active" json:"is_active"`
+	ID          int64   `db:"id" json:"id"`
+	ProductID   int64   `db:"product_id" json:"product_id"`
+}
+
+func (q *Queries) UpdateProductSku(ctx context.Context, arg UpdateProductSkuParams) (ProductSku, error) {
+	row := q.db.QueryRow(ctx, updateProductSku,
+		arg.Price,
+		arg.OriginPrice,
+		arg.ImageUrl,
+		arg.Barcode,
+		arg.IsActive,
+		arg.ID,
+		arg.ProductID,
+	)
+	var i ProductSku
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.SkuCode,
+		&i.Price,
+		&i.OriginPrice,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.Options,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,213 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: product_suggest.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const listSpellingSuggestions = `-- name: ListSpellingSuggestions :many
+SELECT t.term::text AS term, similarity(t.term, $1::text)::REAL AS score
+FROM (
+    SELECT DISTINCT lower(w.word) AS term
+    FROM products p
+    CROSS JOIN LATERAL regexp_split_to_table(p.name, '\s+') AS w(word)
+    WHERE p.deleted_at IS NULL AND p.status = 'published'
+    UNION
+    SELECT lower(p.brand) FROM products p
+    WHERE p.deleted_at IS NULL AND p.status = 'published' AND p.brand IS NOT NULL
+    UNION
+    SELECT lower(c.name) FROM categories c
+    WHERE c.deleted_at IS NULL AND c.is_active = TRUE
+) t
+WHERE t.term % $1::text
+  AND t.term <> lower($1::text)
+ORDER BY score DESC, t.term
+LIMIT $2
+`
+
+type ListSpellingSuggestionsParams struct {
+	Keyword    string `db:"keyword" json:"keyword"`
+	MaxResults int32  `db:"max_results" json:"max_results"`
+}
+
+type ListSpellingSuggestionsRow struct {
+	Term  string  `db:"term" json:"term"`
+	Score float32 `db:"score" json:"score"`
+}
+
+// Candidates are whole brand and category names plus the individual words of product names
+func (q *Queries) ListSpellingSuggestions(ctx context.Context, arg ListSpellingSuggestionsParams) ([]ListSpellingSuggestionsRow, error) {
+	rows, err := q.db.Query(ctx, listSpellingSuggestions, arg.Keyword, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSpellingSuggestionsRow{}
+	for rows.Next() {
+		var i ListSpellingSuggestionsRow
+		if err := rows.Scan(&i.Term, &i.Score); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const suggestBrands = `-- name: SuggestBrands :many
+SELECT p.brand::text AS brand, COUNT(*) AS product_count
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND p.status = 'published'
+  AND p.brand IS NOT NULL
+  AND (p.brand ILIKE $1::text OR $2::text <% p.brand)
+GROUP BY p.brand
+ORDER BY bool_or(p.brand ILIKE $3::text) DESC,
+         MAX(word_similarity($2::text, p.brand)) DESC,
+         product_count DESC
+LIMIT $4
+`
+
+type SuggestBrandsParams struct {
+	Pattern       string `db:"pattern" json:"pattern"`
+	Keyword       string `db:"keyword" json:"keyword"`
+	PrefixPattern string `db:"prefix_pattern" json:"prefix_pattern"`
+	MaxResults    int32  `db:"max_results" json:"max_results"`
+}
+
+type SuggestBrandsRow struct {
+	Brand        string `db:"brand" json:"brand"`
+	ProductCount int64  `db:"product_count" json:"product_count"`
+}
+
+func (q *Queries) SuggestBrands(ctx context.Context, arg SuggestBrandsParams) ([]SuggestBrandsRow, error) {
+	rows, err := q.db.Query(ctx, suggestBrands,
+		arg.Pattern,
+		arg.Keyword,
+		arg.PrefixPattern,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SuggestBrandsRow{}
+	for rows.Next() {
+		var i SuggestBrandsRow
+		if err := rows.Scan(&i.Brand, &i.ProductCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const suggestCategories = `-- name: SuggestCategories :many
+SELECT c.id, c.name, c.slug
+FROM categories c
+WHERE c.deleted_at IS NULL
+  AND c.is_active = TRUE
+  AND (c.name ILIKE $1::text OR $2::text <% c.name)
+ORDER BY (c.name ILIKE $3::text) DESC,
+         word_similarity($2::text, c.name) DESC,
+         c.sort, c.id
+LIMIT $4
+`
+
+type SuggestCategoriesParams struct {
+	Pattern       string `db:"pattern" json:"pattern"`
+	Keyword       string `db:"keyword" json:"keyword"`
+	PrefixPattern string `db:"prefix_pattern" json:"prefix_pattern"`
+	MaxResults    int32  `db:"max_results" json:"max_results"`
+}
+
+type SuggestCategoriesRow struct {
+	ID   int64   `db:"id" json:"id"`
+	Name string  `db:"name" json:"name"`
+	Slug *string `db:"slug" json:"slug"`
+}
+
+func (q *Queries) SuggestCategories(ctx context.Context, arg SuggestCategoriesParams) ([]SuggestCategoriesRow, error) {
+	rows, err := q.db.Query(ctx, suggestCategories,
+		arg.Pattern,
+		arg.Keyword,
+		arg.PrefixPattern,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SuggestCategoriesRow{}
+	for rows.Next() {
+		var i SuggestCategoriesRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Slug); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const suggestProductNames = `-- name: SuggestProductNames :many
+
+SELECT p.id, p.name, word_similarity($1::text, p.name)::REAL AS score
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND p.status = 'published'
+  AND (p.name ILIKE $2::text OR $1::text <% p.name)
+ORDER BY (p.name ILIKE $3::text) DESC, score DESC, p.sales_count DESC, p.id
+LIMIT $4
+`
+
+type SuggestProductNamesParams struct {
+	Keyword       string `db:"keyword" json:"keyword"`
+	Pattern       string `db:"pattern" json:"pattern"`
+	PrefixPattern string `db:"prefix_pattern" json:"prefix_pattern"`
+	MaxResults    int32  `db:"max_results" json:"max_results"`
+}
+
+type SuggestProductNamesRow struct {
+	ID    int64   `db:"id" json:"id"`
+	Name  string  `db:"name" json:"name"`
+	Score float32 `db:"score" json:"score"`
+}
+
+// Typeahead and spelling suggestions (pg_trgm)
+// pattern is the escaped keyword wrapped as '%q%', prefix_pattern as 'q%'
+func (q *Queries) SuggestProductNames(ctx context.Context, arg SuggestProductNamesParams) ([]SuggestProductNamesRow, error) {
+	rows, err := q.db.Query(ctx, suggestProductNames,
+		arg.Keyword,
+		arg.Pattern,
+		arg.PrefixPattern,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SuggestProductNamesRow{}
+	for rows.Next() {
+		var i SuggestProductNamesRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Score); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
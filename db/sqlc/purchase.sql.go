@@ -0,0 +1,610 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: purchase.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"gomall/utils/types"
+)
+
+const countOutstandingPurchaseOrderItems = `-- name: CountOutstandingPurchaseOrderItems :one
+SELECT COUNT(*) FROM purchase_order_items
+WHERE purchase_order_id = $1 AND received_quantity < quantity
+`
+
+func (q *Queries) CountOutstandingPurchaseOrderItems(ctx context.Context, purchaseOrderID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countOutstandingPurchaseOrderItems, purchaseOrderID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPurchaseOrders = `-- name: CountPurchaseOrders :one
+SELECT COUNT(*) FROM purchase_orders
+WHERE ($1::varchar IS NULL OR status = $1::varchar)
+  AND ($2::bigint IS NULL OR supplier_id = $2::bigint)
+`
+
+type CountPurchaseOrdersParams struct {
+	Status     *string `db:"status" json:"status"`
+	SupplierID *int64  `db:"supplier_id" json:"supplier_id"`
+}
+
+func (q *Queries) CountPurchaseOrders(ctx context.Context, arg CountPurchaseOrdersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPurchaseOrders, arg.Status, arg.SupplierID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSuppliers = `-- name: CountSuppliers :one
+SELECT COUNT(*) FROM suppliers
+WHERE $1::boolean IS NULL OR is_active = $1::boolean
+`
+
+func (q *Queries) CountSuppliers(ctx context.Context, isActive *bool) (int64, error) {
+	row := q.db.QueryRow(ctx, countSuppliers, isActive)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createPurchaseOrder = `-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (
+    po_no,
+    supplier_id,
+    expected_date,
+    note,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, po_no, supplier_id, status, expected_date, total_cost, note, created_by, ordered_at, received_at, created_at, updated_at
+`
+
+type CreatePurchaseOrderParams struct {
+	PoNo         string         `db:"po_no" json:"po_no"`
+	SupplierID   int64          `db:"supplier_id" json:"supplier_id"`
+	ExpectedDate types.NullTime `db:"expected_date" json:"expected_date"`
+	Note         *string        `db:"note" json:"note"`
+	CreatedBy    *int64         `db:"created_by" json:"created_by"`
+}
+
+func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, createPurchaseOrder,
+		arg.PoNo,
+		arg.SupplierID,
+		arg.ExpectedDate,
+		arg.Note,
+		arg.CreatedBy,
+	)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.PoNo,
+		&i.SupplierID,
+		&i.Status,
+		&i.ExpectedDate,
+		&i.TotalCost,
+		&i.Note,
+		&i.CreatedBy,
+		&i.OrderedAt,
+		&i.ReceivedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createPurchaseOrderItem = `-- name: CreatePurchaseOrderItem :one
+INSERT INTO purchase_order_items (
+    purchase_order_id,
+    product_id,
+    sku_id,
+    quantity,
+    unit_cost,
+    expected_date
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, purchase_order_id, product_id, quantity, received_quantity, unit_cost, expected_date, created_at, updated_at, sku_id
+`
+
+type CreatePurchaseOrderItemParams struct {
+	PurchaseOrderID int64          `db:"purchase_order_id" json:"purchase_order_id"`
+	ProductID       int64          `db:"product_id" json:"product_id"`
+	SkuID           *int64         `db:"sku_id" json:"sku_id"`
+	Quantity        int32          `db:"quantity" json:"quantity"`
+	UnitCost        int64          `db:"unit_cost" json:"unit_cost"`
+	ExpectedDate    types.NullTime `db:"expected_date" json:"expected_date"`
+}
+
+func (q *Queries) CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error) {
+	row := q.db.QueryRow(ctx, createPurchaseOrderItem,
+		arg.PurchaseOrderID,
+		arg.ProductID,
+		arg.SkuID,
+		arg.Quantity,
+		arg.UnitCost,
+		arg.ExpectedDate,
+	)
+	var i PurchaseOrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.PurchaseOrderID,
+		&i.ProductID,
+		&i.Quantity,
+		&i.ReceivedQuantity,
+		&i.UnitCost,
+		&i.ExpectedDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SkuID,
+	)
+	return i, err
+}
+
+const createPurchaseReceipt = `-- name: CreatePurchaseReceipt :one
+INSERT INTO purchase_receipts (
+    purchase_order_id,
+    purchase_order_item_id,
+    product_id,
+    sku_id,
+    quantity,
+    unit_cost,
+    on_hand_before,
+    cost_price_before,
+    cost_price_after,
+    received_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, purchase_order_id, purchase_order_item_id, product_id, quantity, unit_cost, on_hand_before, cost_price_before, cost_price_after, received_by, created_at, sku_id
+`
+
+type CreatePurchaseReceiptParams struct {
+	PurchaseOrderID     int64  `db:"purchase_order_id" json:"purchase_order_id"`
+	PurchaseOrderItemID int64  `db:"purchase_order_item_id" json:"purchase_order_item_id"`
+	ProductID           int64  `db:"product_id" json:"product_id"`
+	SkuID               *int64 `db:"sku_id" json:"sku_id"`
+	Quantity            int32  `db:"quantity" json:"quantity"`
+	UnitCost            int64  `db:"unit_cost" json:"unit_cost"`
+	OnHandBefore        int32  `db:"on_hand_before" json:"on_hand_before"`
+	CostPriceBefore     *int64 `db:"cost_price_before" json:"cost_price_before"`
+	CostPriceAfter      int64  `db:"cost_price_after" json:"cost_price_after"`
+	ReceivedBy          *int64 `db:"received_by" json:"received_by"`
+}
+
+func (q *Queries) CreatePurchaseReceipt(ctx context.Context, arg CreatePurchaseReceiptParams) (PurchaseReceipt, error) {
+	row := q.db.QueryRow(ctx, createPurchaseReceipt,
+		arg.PurchaseOrderID,
+		arg.PurchaseOrderItemID,
+		arg.ProductID,
+		arg.SkuID,
+		arg.Quantity,
+		arg.UnitCost,
+		arg.OnHandBefore,
+		arg.CostPriceBefore,
+		arg.CostPriceAfter,
+		arg.ReceivedBy,
+	)
+	var i PurchaseReceipt
+	err := row.Scan(
+		&i.ID,
+		&i.PurchaseOrderID,
+		&i.PurchaseOrderItemID,
+		&i.ProductID,
+		&i.Quantity,
+		&i.UnitCost,
+		&i.OnHandBefore,
+		&i.CostPriceBefore,
+		&i.CostPriceAfter,
+		&i.ReceivedBy,
+		&i.CreatedAt,
+		&i.SkuID,
+	)
+	return i, err
+}
+
+const createSupplier = `-- name: CreateSupplier :one
+INSERT INTO suppliers (
+    name,
+    contact_name,
+    email,
+    phone,
+    address
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, name, contact_name, email, phone, address, is_active, created_at, updated_at
+`
+
+type CreateSupplierParams struct {
+	Name        string  `db:"name" json:"name"`
+	ContactName *string `db:"contact_name" json:"contact_name"`
+	Email       *string `db:"email" json:"email"`
+	Phone       *string `db:"phone" json:"phone"`
+	Address     *string `db:"address" json:"address"`
+}
+
+func (q *Queries) CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error) {
+	row := q.db.QueryRow(ctx, createSupplier,
+		arg.Name,
+		arg.ContactName,
+		arg.Email,
+		arg.Phone,
+		arg.Address,
+	)
+	var i Supplier
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ContactName,
+		&i.Email,
+		&i.Phone,
+		&i.Address,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPurchaseOrder = `-- name: GetPurchaseOrder :one
+SELECT id, po_no, supplier_id, status, expected_date, total_cost, note, created_by, ordered_at, received_at, created_at, updated_at FROM purchase_orders
+WHERE id = $1
+`
+
+func (q *Queries) GetPurchaseOrder(ctx context.Context, id int64) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, getPurchaseOrder, id)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.PoNo,
+		&i.SupplierID,
+		&i.Status,
+		&i.ExpectedDate,
+		&i.TotalCost,
+		&i.Note,
+		&i.CreatedBy,
+		&i.OrderedAt,
+		&i.ReceivedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPurchaseOrderForUpdate = `-- name: GetPurchaseOrderForUpdate :one
+SELECT id, po_no, supplier_id, status, expected_date, total_cost, note, created_by, ordered_at, received_at, created_at, updated_at FROM purchase_orders
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetPurchaseOrderForUpdate(ctx context.Context, id int64) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, getPurchaseOrderForUpdate, id)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.PoNo,
+		&i.SupplierID,
+		&i.Status,
+		&i.ExpectedDate,
+		&i.TotalCost,
+		&i.Note,
+		&i.CreatedBy,
+		&i.OrderedAt,
+		&i.ReceivedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSupplier = `-- name: GetSupplier :one
+SELECT id, name, contact_name, email, phone, address, is_active, created_at, updated_at FROM suppliers
+WHERE id = $1
+`
+
+func (q *Queries) GetSupplier(ctx context.Context, id int64) (Supplier, error) {
+	row := q.db.QueryRow(ctx, getSupplier, id)
+	var i Supplier
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ContactName,
+		&i.Email,
+		&i.Phone,
+		&i.Address,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listPurchaseOrderItems = `-- name: ListPurchaseOrderItems :many
+SELECT
+    poi.id, poi.purchase_order_id, poi.product_id, poi.quantity, poi.received_quantity, poi.unit_cost, poi.expected_date, poi.created_at, poi.updated_at, poi.sku_id,
+    p.name AS product_name
+FROM purchase_order_items poi
+JOIN products p ON p.id = poi.product_id
+WHERE poi.purchase_order_id = $1
+ORDER BY poi.id
+`
+
+type ListPurchaseOrderItemsRow struct {
+	ID               int64          `db:"id" json:"id"`
+	PurchaseOrderID  int64          `db:"purchase_order_id" json:"purchase_order_id"`
+	ProductID        int64          `db:"product_id" json:"product_id"`
+	Quantity         int32          `db:"quantity" json:"quantity"`
+	ReceivedQuantity int32          `db:"received_quantity" json:"received_quantity"`
+	UnitCost         int64          `db:"unit_cost" json:"unit_cost"`
+	ExpectedDate     types.NullTime `db:"expected_date" json:"expected_date"`
+	CreatedAt        time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time      `db:"updated_at" json:"updated_at"`
+	SkuID            *int64         `db:"sku_id" json:"sku_id"`
+	ProductName      string         `db:"product_name" json:"product_name"`
+}
+
+func (q *Queries) ListPurchaseOrderItems(ctx context.Context, purchaseOrderID int64) ([]ListPurchaseOrderItemsRow, error) {
+	rows, err := q.db.Query(ctx, listPurchaseOrderItems, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPurchaseOrderItemsRow{}
+	for rows.Next() {
+		var i ListPurchaseOrderItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PurchaseOrderID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.ReceivedQuantity,
+			&i.UnitCost,
+			&i.ExpectedDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SkuID,
+			&i.ProductName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurchaseOrders = `-- name: ListPurchaseOrders :many
+SELECT id, po_no, supplier_id, status, expected_date, total_cost, note, created_by, ordered_at, received_at, created_at, updated_at FROM purchase_orders
+WHERE ($1::varchar IS NULL OR status = $1::varchar)
+  AND ($2::bigint IS NULL OR supplier_id = $2::bigint)
+ORDER BY created_at DESC
+LIMIT $4 OFFSET $3
+`
+
+type ListPurchaseOrdersParams struct {
+	Status      *string `db:"status" json:"status"`
+	SupplierID  *int64  `db:"supplier_id" json:"supplier_id"`
+	OffsetCount int32   `db:"offset_count" json:"offset_count"`
+	LimitCount  int32   `db:"limit_count" json:"limit_count"`
+}
+
+func (q *Queries) ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]PurchaseOrder, error) {
+	rows, err := q.db.Query(ctx, listPurchaseOrders,
+		arg.Status,
+		arg.SupplierID,
+		arg.OffsetCount,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PurchaseOrder{}
+	for rows.Next() {
+		var i PurchaseOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.PoNo,
+			&i.SupplierID,
+			&i.Status,
+			&i.ExpectedDate,
+			&i.TotalCost,
+			&i.Note,
+			&i.CreatedBy,
+			&i.OrderedAt,
+			&i.ReceivedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurchaseReceipts = `-- name: ListPurchaseReceipts :many
+SELECT id, purchase_order_id, purchase_order_item_id, product_id, quantity, unit_cost, on_hand_before, cost_price_before, cost_price_after, received_by, created_at, sku_id FROM purchase_receipts
+WHERE purchase_order_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListPurchaseReceipts(ctx context.Context, purchaseOrderID int64) ([]PurchaseReceipt, error) {
+	rows, err := q.db.Query(ctx, listPurchaseReceipts, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PurchaseReceipt{}
+	for rows.Next() {
+		var i PurchaseReceipt
+		if err := rows.Scan(
+			&i.ID,
+			&i.PurchaseOrderID,
+			&i.PurchaseOrderItemID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.UnitCost,
+			&i.OnHandBefore,
+			&i.CostPriceBefore,
+			&i.CostPriceAfter,
+			&i.ReceivedBy,
+			&i.CreatedAt,
+			&i.SkuID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSuppliers = `-- name: ListSuppliers :many
+SELECT id, name, contact_name, email, phone, address, is_active, created_at, updated_at FROM suppliers
+WHERE $1::boolean IS NULL OR is_active = $1::boolean
+ORDER BY name
+LIMIT $3 OFFSET $2
+`
+
+type ListSuppliersParams struct {
+	IsActive    *bool `db:"is_active" json:"is_active"`
+	OffsetCount int32 `db:"offset_count" json:"offset_count"`
+	LimitCount  int32 `db:"limit_count" json:"limit_count"`
+}
+
+func (q *Queries) ListSuppliers(ctx context.Context, arg ListSuppliersParams) ([]Supplier, error) {
+	rows, err := q.db.Query(ctx, listSuppliers, arg.IsActive, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Supplier{}
+	for rows.Next() {
+		var i Supplier
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ContactName,
+			&i.Email,
+			&i.Phone,
+			&i.Address,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const receivePurchaseOrderItem = `-- name: ReceivePurchaseOrderItem :execrows
+UPDATE purchase_order_items
+SET
+    received_quantity = received_quantity + $1,
+    updated_at = NOW()
+WHERE id = $2 AND received_quantity + $1 <= quantity
+`
+
+type ReceivePurchaseOrderItemParams struct {
+	Quantity int32 `db:"quantity" json:"quantity"`
+	ID       int64 `db:"id" json:"id"`
+}
+
+func (q *Queries) ReceivePurchaseOrderItem(ctx context.Context, arg ReceivePurchaseOrderItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, receivePurchaseOrderItem, arg.Quantity, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const refreshPurchaseOrderTotal = `-- name: RefreshPurchaseOrderTotal :exec
+UPDATE purchase_orders po
+SET
+    total_cost = (
+        SELECT COALESCE(SUM(poi.quantity::bigint * poi.unit_cost), 0)::bigint
+        FROM purchase_order_items poi
+        WHERE poi.purchase_order_id = po.id
+    ),
+    updated_at = NOW()
+WHERE po.id = $1
+`
+
+func (q *Queries) RefreshPurchaseOrderTotal(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, refreshPurchaseOrderTotal, id)
+	return err
+}
+
+const updatePurchaseOrderStatus = `-- name: UpdatePurchaseOrderStatus :exec
+UPDATE purchase_orders
+SET
+    status = $1,
+    ordered_at = CASE WHEN $1 = 'ordered' THEN NOW() ELSE ordered_at END,
+    received_at = CASE WHEN $1 = 'received' THEN NOW() ELSE received_at END,
+    updated_at = NOW()
+WHERE id = $2
+`
+
+type UpdatePurchaseOrderStatusParams struct {
+	Status string `db:"status" json:"status"`
+	ID     int64  `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) error {
+	_, err := q.db.Exec(ctx, updatePurchaseOrderStatus, arg.Status, arg.ID)
+	return err
+}
+
+const updateSupplier = `-- name: UpdateSupplier :exec
+UPDATE suppliers
+SET
+    name = COALESCE($1, name),
+    contact_name = COALESCE($2, contact_name),
+    email = COALESCE($3, email),
+    phone = COALESCE($4, phone),
+    address = COALESCE($5, address),
+    is_active = COALESCE($6, is_active),
+    updated_at = NOW()
+WHERE id = $7
+`
+
+type UpdateSupplierParams struct {
+	Name        *string `db:"name" json:"name"`
+	ContactName *string `db:"contact_name" json:"contact_name"`
+	Email       *string `db:"email" json:"email"`
+	Phone       *string `db:"phone" json:"phone"`
+	Address     *string `db:"address" json:"address"`
+	IsActive    *bool   `db:"is_active" json:"is_active"`
+	ID          int64   `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) error {
+	_, err := q.db.Exec(ctx, updateSupplier,
+		arg.Name,
+		arg.ContactName,
+		arg.Email,
+		arg.Phone,
+		arg.Address,
+		arg.IsActive,
+		arg.ID,
+	)
+	return err
+}
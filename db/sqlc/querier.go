@@ -6,29 +6,68 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	ActivateOrderReservations(ctx context.Context, arg ActivateOrderReservationsParams) error
 	AddAvailableStock(ctx context.Context, arg AddAvailableStockParams) error
+	AddBackorderedStock(ctx context.Context, arg AddBackorderedStockParams) (int64, error)
+	AddProductAnswerUpvoteCount(ctx context.Context, arg AddProductAnswerUpvoteCountParams) (int32, error)
+	AddProductReviewHelpfulCount(ctx context.Context, arg AddProductReviewHelpfulCountParams) (int32, error)
+	// Applies batched view counts; product_ids and views are parallel arrays
+	AddProductViews(ctx context.Context, arg AddProductViewsParams) error
+	AddStocktakeItemsForCategory(ctx context.Context, arg AddStocktakeItemsForCategoryParams) (int64, error)
+	// Stocktake Items Queries
+	// One item per inventory row: the product-level row, or each SKU's row for products with variants
+	AddStocktakeItemsForProducts(ctx context.Context, arg AddStocktakeItemsForProductsParams) (int64, error)
 	AddToCart(ctx context.Context, arg AddToCartParams) (Cart, error)
+	AllocateBackorderedStock(ctx context.Context, arg AllocateBackorderedStockParams) (int64, error)
+	// A zero reorder point means no recent sales; it is skipped so low-stock alerts stay on
+	ApplyReorderPointsToCategory(ctx context.Context, categoryID int64) (int64, error)
+	ApproveStocktake(ctx context.Context, arg ApproveStocktakeParams) (int64, error)
 	BlockSession(ctx context.Context, id uuid.UUID) error
 	CancelOrder(ctx context.Context, id int64) error
+	CancelOrderBackorders(ctx context.Context, orderID int64) ([]InventoryBackorder, error)
 	CancelReservation(ctx context.Context, orderID int64) error
+	CancelStocktake(ctx context.Context, id int64) (int64, error)
 	CleanExpiredSessions(ctx context.Context) error
 	ClearCart(ctx context.Context, userID int64) error
 	ConfirmReservation(ctx context.Context, orderID int64) error
+	CountActiveStockAlerts(ctx context.Context) (int64, error)
+	CountBackorders(ctx context.Context, arg CountBackordersParams) (int64, error)
 	CountCartItems(ctx context.Context, userID int64) (int64, error)
 	CountCategoryChildren(ctx context.Context, parentID *int64) (int64, error)
+	CountDuplicateReviews(ctx context.Context, arg CountDuplicateReviewsParams) (int64, error)
 	CountInventories(ctx context.Context) (int64, error)
 	CountInventoryLogsByProductID(ctx context.Context, productID int64) (int64, error)
+	CountInventoryMovementReport(ctx context.Context, arg CountInventoryMovementReportParams) (int64, error)
 	CountLowStockInventories(ctx context.Context) (int64, error)
-	CountProducts(ctx context.Context) (int64, error)
+	CountOutOfStockAlerts(ctx context.Context) (int64, error)
+	CountOutstandingPurchaseOrderItems(ctx context.Context, purchaseOrderID int64) (int64, error)
+	CountProductAnswers(ctx context.Context, questionID int64) (int64, error)
+	CountProductIndex(ctx context.Context, arg CountProductIndexParams) (int64, error)
+	CountProductQuestions(ctx context.Context, productID int64) (int64, error)
+	CountProductReviews(ctx context.Context, productID int64) (int64, error)
+	CountProductSkus(ctx context.Context, productID int64) (int64, error)
 	CountProductsByCategory(ctx context.Context, categoryID int64) (int64, error)
+	CountPurchaseOrders(ctx context.Context, arg CountPurchaseOrdersParams) (int64, error)
+	CountRecentReviewsByUser(ctx context.Context, arg CountRecentReviewsByUserParams) (int64, error)
+	CountReorderSuggestions(ctx context.Context, arg CountReorderSuggestionsParams) (int64, error)
+	CountResolvedStockAlertsSince(ctx context.Context, since time.Time) (int64, error)
+	CountReviewsByStatus(ctx context.Context, status string) (int64, error)
+	CountStocktakes(ctx context.Context, status *string) (int64, error)
+	CountSuppliers(ctx context.Context, isActive *bool) (int64, error)
+	CountUncountedStocktakeItems(ctx context.Context, stocktakeID int64) (int64, error)
 	CountUserOrders(ctx context.Context, userID int64) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	// Backorder Queries
+	CreateBackorder(ctx context.Context, arg CreateBackorderParams) (InventoryBackorder, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	// Inventory Import Jobs Queries
+	CreateImportJob(ctx context.Context, arg CreateImportJobParams) (InventoryImportJob, error)
 	// Inventory Queries
 	CreateInventory(ctx context.Context, arg CreateInventoryParams) (Inventory, error)
 	// Inventory Logs Queries
@@ -40,9 +79,30 @@ type Querier interface {
 	// Order Items Queries
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreateProductAnswer(ctx context.Context, arg CreateProductAnswerParams) (ProductAnswer, error)
+	CreateProductAnswerVote(ctx context.Context, arg CreateProductAnswerVoteParams) (int64, error)
 	// Product Images
 	CreateProductImage(ctx context.Context, arg CreateProductImageParams) (ProductImage, error)
+	// Product Option Queries
+	CreateProductOption(ctx context.Context, arg CreateProductOptionParams) (ProductOption, error)
+	CreateProductOptionValue(ctx context.Context, arg CreateProductOptionValueParams) (ProductOptionValue, error)
+	CreateProductQuestion(ctx context.Context, arg CreateProductQuestionParams) (ProductQuestion, error)
+	CreateProductReview(ctx context.Context, arg CreateProductReviewParams) (ProductReview, error)
+	CreateProductReviewVote(ctx context.Context, arg CreateProductReviewVoteParams) (int64, error)
+	// Product SKU Queries
+	CreateProductSku(ctx context.Context, arg CreateProductSkuParams) (ProductSku, error)
+	CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error)
+	CreatePurchaseReceipt(ctx context.Context, arg CreatePurchaseReceiptParams) (PurchaseReceipt, error)
+	CreateReviewModerationLog(ctx context.Context, arg CreateReviewModerationLogParams) (ProductReviewModerationLog, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	// Stock Alerts Queries
+	CreateStockAlert(ctx context.Context, arg CreateStockAlertParams) (StockAlert, error)
+	// Stocktake Queries
+	CreateStocktake(ctx context.Context, arg CreateStocktakeParams) (Stocktake, error)
+	CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error)
+	// Records an uploaded original (parent_id NULL) or one of its thumbnail renditions
+	CreateUploadedProductImage(ctx context.Context, arg CreateUploadedProductImageParams) (ProductImage, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerificationCode(ctx context.Context, arg CreateVerificationCodeParams) (VerificationCode, error)
 	DecrementProductStock(ctx context.Context, arg DecrementProductStockParams) error
@@ -52,27 +112,48 @@ type Querier interface {
 	DeleteExpiredCodes(ctx context.Context) error
 	DeleteInventory(ctx context.Context, productID int64) error
 	DeleteProduct(ctx context.Context, id int64) error
+	DeleteProductAnswerVote(ctx context.Context, arg DeleteProductAnswerVoteParams) (int64, error)
+	DeleteProductAssociations(ctx context.Context) error
 	DeleteProductImage(ctx context.Context, id int64) error
 	DeleteProductImages(ctx context.Context, productID int64) error
+	DeleteProductOptions(ctx context.Context, productID int64) error
+	DeleteProductReviewVote(ctx context.Context, arg DeleteProductReviewVoteParams) (int64, error)
+	DeleteProductSku(ctx context.Context, arg DeleteProductSkuParams) (int64, error)
+	DeleteReorderSetting(ctx context.Context, categoryID int64) (int64, error)
 	DeleteReservation(ctx context.Context, id int64) error
 	DeleteSession(ctx context.Context, id uuid.UUID) error
+	DeleteSkuInventory(ctx context.Context, skuID *int64) error
 	DeleteUser(ctx context.Context, id int64) error
 	DeleteUserSessions(ctx context.Context, userID int64) error
+	FinishImportJob(ctx context.Context, arg FinishImportJobParams) error
 	GetActiveReservationsByProductID(ctx context.Context, productID int64) ([]InventoryReservation, error)
+	GetActiveStockAlert(ctx context.Context, arg GetActiveStockAlertParams) (GetActiveStockAlertRow, error)
 	GetCartByUserID(ctx context.Context, userID int64) ([]Cart, error)
 	GetCartItem(ctx context.Context, arg GetCartItemParams) (Cart, error)
 	GetCartItemByProduct(ctx context.Context, arg GetCartItemByProductParams) (Cart, error)
 	GetCategoryByID(ctx context.Context, id int64) (Category, error)
 	GetCategoryBySlug(ctx context.Context, slug *string) (Category, error)
 	GetCategoryChildren(ctx context.Context, parentID *int64) ([]Category, error)
+	GetConflictingProductSku(ctx context.Context, arg GetConflictingProductSkuParams) (ProductSku, error)
 	GetExpiredReservations(ctx context.Context, limit int32) ([]InventoryReservation, error)
+	GetFirstInventoryLogAfter(ctx context.Context, arg GetFirstInventoryLogAfterParams) (InventoryLog, error)
+	// Paid quantities per product since a cutoff, decayed to now with the given half-life;
+	// used to rebuild the hot product rankings
+	GetHotProductActivity(ctx context.Context, arg GetHotProductActivityParams) ([]GetHotProductActivityRow, error)
 	GetImagesByProductIDs(ctx context.Context, dollar_1 []int64) ([]ProductImage, error)
+	GetImportJob(ctx context.Context, id int64) (InventoryImportJob, error)
+	GetInventoriesByProductIDs(ctx context.Context, productIds []int64) ([]Inventory, error)
+	GetInventoriesBySkuIDs(ctx context.Context, skuIds []int64) ([]Inventory, error)
 	GetInventoryByID(ctx context.Context, id int64) (Inventory, error)
 	GetInventoryByProductID(ctx context.Context, productID int64) (Inventory, error)
+	GetInventoryBySkuID(ctx context.Context, skuID *int64) (Inventory, error)
 	GetInventoryLogsByOrderID(ctx context.Context, orderID int64) ([]InventoryLog, error)
 	GetInventoryLogsByProductID(ctx context.Context, arg GetInventoryLogsByProductIDParams) ([]InventoryLog, error)
+	GetInventoryLogsByReference(ctx context.Context, arg GetInventoryLogsByReferenceParams) ([]InventoryLog, error)
+	GetInventoryMovementReport(ctx context.Context, arg GetInventoryMovementReportParams) ([]GetInventoryMovementReportRow, error)
 	GetInventoryReservationByID(ctx context.Context, id int64) (InventoryReservation, error)
 	GetInventoryReservationByOrderID(ctx context.Context, orderID int64) ([]InventoryReservation, error)
+	GetLastInventoryLogAtOrBefore(ctx context.Context, arg GetLastInventoryLogAtOrBeforeParams) (InventoryLog, error)
 	GetLatestVerificationCode(ctx context.Context, arg GetLatestVerificationCodeParams) (VerificationCode, error)
 	// Stock Management
 	GetLowStockProducts(ctx context.Context, arg GetLowStockProductsParams) ([]Product, error)
@@ -80,55 +161,154 @@ type Querier interface {
 	GetOrderByOrderNo(ctx context.Context, orderNo string) (Order, error)
 	GetOrderItems(ctx context.Context, orderID int64) ([]OrderItem, error)
 	GetOrderItemsByIDs(ctx context.Context, dollar_1 []int64) ([]OrderItem, error)
+	GetProductAnswer(ctx context.Context, id int64) (ProductAnswer, error)
 	GetProductByID(ctx context.Context, id int64) (Product, error)
+	GetProductCostForUpdate(ctx context.Context, id int64) (*int64, error)
+	GetProductImage(ctx context.Context, id int64) (ProductImage, error)
+	GetProductImageRenditions(ctx context.Context, productID int64) ([]ProductImage, error)
 	GetProductImages(ctx context.Context, productID int64) ([]ProductImage, error)
+	// Detail fields moved by orders, reviews and view counts; read on every detail request instead of cached
+	GetProductLiveFields(ctx context.Context, id int64) (GetProductLiveFieldsRow, error)
 	GetProductMainImage(ctx context.Context, productID int64) (ProductImage, error)
-	// Batch Operations
+	GetProductOnHandStock(ctx context.Context, productID int64) (int32, error)
+	GetProductQuestion(ctx context.Context, id int64) (ProductQuestion, error)
+	// The question with its asker and product, for answer notifications
+	GetProductQuestionAsker(ctx context.Context, id int64) (GetProductQuestionAskerRow, error)
+	GetProductReview(ctx context.Context, id int64) (ProductReview, error)
+	GetProductReviewForUpdate(ctx context.Context, id int64) (ProductReview, error)
+	// One row per inventory row; sales of a SKU count toward that SKU's row only
+	GetProductSalesVelocity(ctx context.Context, since time.Time) ([]GetProductSalesVelocityRow, error)
+	GetProductSku(ctx context.Context, id int64) (ProductSku, error)
 	GetProductsByIDs(ctx context.Context, dollar_1 []int64) ([]Product, error)
+	GetPurchaseOrder(ctx context.Context, id int64) (PurchaseOrder, error)
+	GetPurchaseOrderForUpdate(ctx context.Context, id int64) (PurchaseOrder, error)
+	GetReviewableOrderItem(ctx context.Context, id int64) (GetReviewableOrderItemRow, error)
 	GetRootCategories(ctx context.Context) ([]Category, error)
 	GetSelectedCartItems(ctx context.Context, userID int64) ([]Cart, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStocktake(ctx context.Context, id int64) (Stocktake, error)
+	GetSupplier(ctx context.Context, id int64) (Supplier, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id int64) (User, error)
 	GetUserByPhone(ctx context.Context, phone *string) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
 	GetUserSessions(ctx context.Context, userID int64) ([]Session, error)
 	GetVerificationCode(ctx context.Context, arg GetVerificationCodeParams) (VerificationCode, error)
+	// Whether the user has a paid, shipped or completed order containing the product
+	HasPurchasedProduct(ctx context.Context, arg HasPurchasedProductParams) (bool, error)
+	HoldOrderReservations(ctx context.Context, orderID int64) error
 	IncrementProductSales(ctx context.Context, arg IncrementProductSalesParams) error
-	IncrementProductViews(ctx context.Context, id int64) error
+	IncrementQuestionAnswerCount(ctx context.Context, id int64) error
+	// Pairs of products bought in the same order since a cutoff, keeping for each product the
+	// max_per_product strongest pairs that meet both thresholds
+	InsertProductAssociations(ctx context.Context, arg InsertProductAssociationsParams) (int64, error)
+	ListActiveSkusByProductIDs(ctx context.Context, productIds []int64) ([]ProductSku, error)
+	ListActiveStockAlerts(ctx context.Context, arg ListActiveStockAlertsParams) ([]ListActiveStockAlertsRow, error)
+	// Products bought together with a product, strongest first, that can be bought right now
+	ListAlsoBoughtProducts(ctx context.Context, arg ListAlsoBoughtProductsParams) ([]ListAlsoBoughtProductsRow, error)
+	ListBackorders(ctx context.Context, arg ListBackordersParams) ([]InventoryBackorder, error)
 	ListCategories(ctx context.Context, dollar_1 bool) ([]Category, error)
+	// Published, in-stock best sellers of a category, skipping the given products
+	ListCategoryBestSellers(ctx context.Context, arg ListCategoryBestSellersParams) ([]Product, error)
 	ListFeaturedProducts(ctx context.Context, arg ListFeaturedProductsParams) ([]Product, error)
 	ListInventories(ctx context.Context, arg ListInventoriesParams) ([]Inventory, error)
+	ListInventoriesForExport(ctx context.Context, arg ListInventoriesForExportParams) ([]ListInventoriesForExportRow, error)
 	ListLowStockInventories(ctx context.Context, arg ListLowStockInventoriesParams) ([]Inventory, error)
-	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
+	ListPendingBackordersForUpdate(ctx context.Context, arg ListPendingBackordersForUpdateParams) ([]InventoryBackorder, error)
+	ListProductAnswers(ctx context.Context, arg ListProductAnswersParams) ([]ListProductAnswersRow, error)
+	ListProductCategoryIDs(ctx context.Context) ([]int64, error)
+	ListProductOptionValues(ctx context.Context, productID int64) ([]ListProductOptionValuesRow, error)
+	ListProductQuestions(ctx context.Context, arg ListProductQuestionsParams) ([]ListProductQuestionsRow, error)
+	ListProductReviews(ctx context.Context, arg ListProductReviewsParams) ([]ListProductReviewsRow, error)
+	// Current available stock of a product's SKUs, read live for the cached product detail
+	ListProductSkuStock(ctx context.Context, productID int64) ([]ListProductSkuStockRow, error)
+	ListProductSkus(ctx context.Context, productID int64) ([]ListProductSkusRow, error)
+	ListProductSkusByCodes(ctx context.Context, skuCodes []string) ([]ListProductSkusByCodesRow, error)
 	ListProductsByCategory(ctx context.Context, arg ListProductsByCategoryParams) ([]Product, error)
 	// Advanced Filtering
 	ListProductsByPriceRange(ctx context.Context, arg ListProductsByPriceRangeParams) ([]Product, error)
+	// Batch Operations
+	ListProductsForIndex(ctx context.Context, arg ListProductsForIndexParams) ([]Product, error)
+	ListPurchaseOrderItems(ctx context.Context, purchaseOrderID int64) ([]ListPurchaseOrderItemsRow, error)
+	ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]PurchaseOrder, error)
+	ListPurchaseReceipts(ctx context.Context, purchaseOrderID int64) ([]PurchaseReceipt, error)
+	ListReorderSettings(ctx context.Context) ([]ReorderSetting, error)
+	ListReorderSuggestions(ctx context.Context, arg ListReorderSuggestionsParams) ([]ListReorderSuggestionsRow, error)
+	ListReviewModerationLogs(ctx context.Context, reviewID int64) ([]ProductReviewModerationLog, error)
+	ListReviewsByStatus(ctx context.Context, arg ListReviewsByStatusParams) ([]ListReviewsByStatusRow, error)
+	// Candidates are whole brand and category names plus the individual words of product names
+	ListSpellingSuggestions(ctx context.Context, arg ListSpellingSuggestionsParams) ([]ListSpellingSuggestionsRow, error)
+	ListStocktakeItems(ctx context.Context, stocktakeID int64) ([]ListStocktakeItemsRow, error)
+	ListStocktakes(ctx context.Context, arg ListStocktakesParams) ([]Stocktake, error)
+	ListSuppliers(ctx context.Context, arg ListSuppliersParams) ([]Supplier, error)
+	// The most upvoted answers of each question, up to per_question each
+	ListTopAnswersByQuestionIDs(ctx context.Context, arg ListTopAnswersByQuestionIDsParams) ([]ListTopAnswersByQuestionIDsRow, error)
 	ListUserOrders(ctx context.Context, arg ListUserOrdersParams) ([]Order, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	MarkBackorderAllocated(ctx context.Context, id int64) error
 	MarkCodeAsUsed(ctx context.Context, id int64) error
+	MarkStockAlertNotified(ctx context.Context, id int64) error
+	PromoteBackorderedOrder(ctx context.Context, id int64) (int64, error)
+	ReceivePurchaseOrderItem(ctx context.Context, arg ReceivePurchaseOrderItemParams) (int64, error)
+	RecordStocktakeCount(ctx context.Context, arg RecordStocktakeCountParams) (int64, error)
+	// Recompute the product's aggregated rating from its approved reviews
+	RefreshProductRating(ctx context.Context, productID int64) error
+	RefreshPurchaseOrderTotal(ctx context.Context, id int64) error
+	ReleaseBackorderedStock(ctx context.Context, arg ReleaseBackorderedStockParams) error
 	ReleaseReservedStock(ctx context.Context, arg ReleaseReservedStockParams) error
+	ReplyProductReview(ctx context.Context, arg ReplyProductReviewParams) (ProductReview, error)
 	ReserveStock(ctx context.Context, arg ReserveStockParams) error
-	SearchProducts(ctx context.Context, arg SearchProductsParams) ([]Product, error)
+	ResolveStockAlerts(ctx context.Context, arg ResolveStockAlertsParams) error
+	ReviewExistsForOrderItem(ctx context.Context, orderItemID int64) (bool, error)
+	SearchIndexBrandFacets(ctx context.Context, arg SearchIndexBrandFacetsParams) ([]SearchIndexBrandFacetsRow, error)
+	// bucket is width_bucket over the ascending boundaries: 0 is below the first, len(boundaries) above the last
+	SearchIndexPriceFacets(ctx context.Context, arg SearchIndexPriceFacetsParams) ([]SearchIndexPriceFacetsRow, error)
+	// Each facet applies every active filter except its own, so sibling values keep their counts.
+	SearchIndexSpecFacets(ctx context.Context, arg SearchIndexSpecFacetsParams) ([]SearchIndexSpecFacetsRow, error)
+	// Postgres search index (see internal/search.PostgresIndex)
+	// query is pre-segmented by the application and parsed with websearch syntax; NULL lists without text matching.
+	// spec_filters maps each attribute to the candidate documents it may contain (OR within, AND across);
+	// spec_contains merges single-valued attributes so the GIN index can narrow the scan.
+	SearchProductIndex(ctx context.Context, arg SearchProductIndexParams) ([]SearchProductIndexRow, error)
+	SetStocktakeItemVariance(ctx context.Context, arg SetStocktakeItemVarianceParams) error
+	StartImportJob(ctx context.Context, id int64) error
+	SuggestBrands(ctx context.Context, arg SuggestBrandsParams) ([]SuggestBrandsRow, error)
+	SuggestCategories(ctx context.Context, arg SuggestCategoriesParams) ([]SuggestCategoriesRow, error)
+	// Typeahead and spelling suggestions (pg_trgm)
+	// pattern is the escaped keyword wrapped as '%q%', prefix_pattern as 'q%'
+	SuggestProductNames(ctx context.Context, arg SuggestProductNamesParams) ([]SuggestProductNamesRow, error)
 	UpdateAllCartSelected(ctx context.Context, arg UpdateAllCartSelectedParams) error
 	UpdateCartQuantity(ctx context.Context, arg UpdateCartQuantityParams) error
 	UpdateCartSelected(ctx context.Context, arg UpdateCartSelectedParams) error
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) error
+	UpdateImportJobProgress(ctx context.Context, arg UpdateImportJobProgressParams) error
 	UpdateInventoryStock(ctx context.Context, arg UpdateInventoryStockParams) error
 	UpdateLowStockThreshold(ctx context.Context, arg UpdateLowStockThresholdParams) error
 	UpdateOrderPaymentStatus(ctx context.Context, arg UpdateOrderPaymentStatusParams) error
 	UpdateOrderShipStatus(ctx context.Context, arg UpdateOrderShipStatusParams) error
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) error
+	UpdateProductCostPrice(ctx context.Context, arg UpdateProductCostPriceParams) error
 	UpdateProductImage(ctx context.Context, arg UpdateProductImageParams) error
+	UpdateProductReviewStatus(ctx context.Context, arg UpdateProductReviewStatusParams) (ProductReview, error)
+	UpdateProductSku(ctx context.Context, arg UpdateProductSkuParams) (ProductSku, error)
 	UpdateProductStock(ctx context.Context, arg UpdateProductStockParams) error
 	UpdateProductStockWithVersion(ctx context.Context, arg UpdateProductStockWithVersionParams) (Product, error)
 	UpdateProductsStatus(ctx context.Context, arg UpdateProductsStatusParams) error
+	UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) error
 	UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) error
+	UpdateStockAlertLevel(ctx context.Context, arg UpdateStockAlertLevelParams) error
+	UpdateStockPolicy(ctx context.Context, arg UpdateStockPolicyParams) (Inventory, error)
+	UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
 	UpdateUserLastLogin(ctx context.Context, arg UpdateUserLastLoginParams) error
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
 	UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) error
+	// Reorder Queries
+	UpsertReorderSetting(ctx context.Context, arg UpsertReorderSettingParams) (ReorderSetting, error)
+	// Saves a whole refresh in one statement; the array arguments are parallel, one element per
+	// inventory row, with a sku_id of 0 for the product-level row. current_threshold is read from that row.
+	UpsertReorderSuggestions(ctx context.Context, arg UpsertReorderSuggestionsParams) error
 	VerifyUserEmail(ctx context.Context, id int64) error
 	VerifyUserPhone(ctx context.Context, id int64) error
 }
@@ -0,0 +1,445 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: question.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+)
+
+const addProductAnswerUpvoteCount = `-- name: AddProductAnswerUpvoteCount :one
+UPDATE product_answers
+SET upvote_count = upvote_count + $1::int
+WHERE id = $2
+RETURNING upvote_count
+`
+
+type AddProductAnswerUpvoteCountParams struct {
+	Delta int32 `db:"delta" json:"delta"`
+	ID    int64 `db:"id" json:"id"`
+}
+
+func (q *Queries) AddProductAnswerUpvoteCount(ctx context.Context, arg AddProductAnswerUpvoteCountParams) (int32, error) {
+	row := q.db.QueryRow(ctx, addProductAnswerUpvoteCount, arg.Delta, arg.ID)
+	var upvote_count int32
+	err := row.Scan(&upvote_count)
+	return upvote_count, err
+}
+
+const countProductAnswers = `-- name: CountProductAnswers :one
+SELECT COUNT(*) FROM product_answers
+WHERE question_id = $1
+`
+
+func (q *Queries) CountProductAnswers(ctx context.Context, questionID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductAnswers, questionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countProductQuestions = `-- name: CountProductQuestions :one
+SELECT COUNT(*) FROM product_questions
+WHERE product_id = $1
+`
+
+func (q *Queries) CountProductQuestions(ctx context.Context, productID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductQuestions, productID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createProductAnswer = `-- name: CreateProductAnswer :one
+INSERT INTO product_answers (
+    question_id,
+    user_id,
+    content,
+    is_staff,
+    is_buyer
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, question_id, user_id, content, is_staff, is_buyer, upvote_count, created_at, updated_at
+`
+
+type CreateProductAnswerParams struct {
+	QuestionID int64  `db:"question_id" json:"question_id"`
+	UserID     int64  `db:"user_id" json:"user_id"`
+	Content    string `db:"content" json:"content"`
+	IsStaff    bool   `db:"is_staff" json:"is_staff"`
+	IsBuyer    bool   `db:"is_buyer" json:"is_buyer"`
+}
+
+func (q *Queries) CreateProductAnswer(ctx context.Context, arg CreateProductAnswerParams) (ProductAnswer, error) {
+	row := q.db.QueryRow(ctx, createProductAnswer,
+		arg.QuestionID,
+		arg.UserID,
+		arg.Content,
+		arg.IsStaff,
+		arg.IsBuyer,
+	)
+	var i ProductAnswer
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionID,
+		&i.UserID,
+		&i.Content,
+		&i.IsStaff,
+		&i.IsBuyer,
+		&i.UpvoteCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createProductAnswerVote = `-- name: CreateProductAnswerVote :execrows
+INSERT INTO product_answer_votes (answer_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type CreateProductAnswerVoteParams struct {
+	AnswerID int64 `db:"answer_id" json:"answer_id"`
+	UserID   int64 `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) CreateProductAnswerVote(ctx context.Context, arg CreateProductAnswerVoteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createProductAnswerVote, arg.AnswerID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createProductQuestion = `-- name: CreateProductQuestion :one
+INSERT INTO product_questions (
+    product_id,
+    user_id,
+    content
+) VALUES (
+    $1, $2, $3
+) RETURNING id, product_id, user_id, content, answer_count, created_at, updated_at
+`
+
+type CreateProductQuestionParams struct {
+	ProductID int64  `db:"product_id" json:"product_id"`
+	UserID    int64  `db:"user_id" json:"user_id"`
+	Content   string `db:"content" json:"content"`
+}
+
+func (q *Queries) CreateProductQuestion(ctx context.Context, arg CreateProductQuestionParams) (ProductQuestion, error) {
+	row := q.db.QueryRow(ctx, createProductQuestion, arg.ProductID, arg.UserID, arg.Content)
+	var i ProductQuestion
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Content,
+		&i.AnswerCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteProductAnswerVote = `-- name: DeleteProductAnswerVote :execrows
+DELETE FROM product_answer_votes
+WHERE answer_id = $1 AND user_id = $2
+`
+
+type DeleteProductAnswerVoteParams struct {
+	AnswerID int64 `db:"answer_id" json:"answer_id"`
+	UserID   int64 `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) DeleteProductAnswerVote(ctx context.Context, arg DeleteProductAnswerVoteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProductAnswerVote, arg.AnswerID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getProductAnswer = `-- name: GetProductAnswer :one
+SELECT id, question_id, user_id, content, is_staff, is_buyer, upvote_count, created_at, updated_at FROM product_answers
+WHERE id = $1
+`
+
+func (q *Queries) GetProductAnswer(ctx context.Context, id int64) (ProductAnswer, error) {
+	row := q.db.QueryRow(ctx, getProductAnswer, id)
+	var i ProductAnswer
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionID,
+		&i.UserID,
+		&i.Content,
+		&i.IsStaff,
+		&i.IsBuyer,
+		&i.UpvoteCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getProductQuestion = `-- name: GetProductQuestion :one
+SELECT id, product_id, user_id, content, answer_count, created_at, updated_at FROM product_questions
+WHERE id = $1
+`
+
+func (q *Queries) GetProductQuestion(ctx context.Context, id int64) (ProductQuestion, error) {
+	row := q.db.QueryRow(ctx, getProductQuestion, id)
+	var i ProductQuestion
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Content,
+		&i.AnswerCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getProductQuestionAsker = `-- name: GetProductQuestionAsker :one
+SELECT q.id, q.product_id, q.user_id, q.content, q.answer_count, q.created_at, q.updated_at, u.username, u.email, p.name AS product_name
+FROM product_questions q
+JOIN users u ON u.id = q.user_id
+JOIN products p ON p.id = q.product_id
+WHERE q.id = $1
+`
+
+type GetProductQuestionAskerRow struct {
+	ProductQuestion ProductQuestion `db:"product_question" json:"product_question"`
+	Username        string          `db:"username" json:"username"`
+	Email           string          `db:"email" json:"email"`
+	ProductName     string          `db:"product_name" json:"product_name"`
+}
+
+// The question with its asker and product, for answer notifications
+func (q *Queries) GetProductQuestionAsker(ctx context.Context, id int64) (GetProductQuestionAskerRow, error) {
+	row := q.db.QueryRow(ctx, getProductQuestionAsker, id)
+	var i GetProductQuestionAskerRow
+	err := row.Scan(
+		&i.ProductQuestion.ID,
+		&i.ProductQuestion.ProductID,
+		&i.ProductQuestion.UserID,
+		&i.ProductQuestion.Content,
+		&i.ProductQuestion.AnswerCount,
+		&i.ProductQuestion.CreatedAt,
+		&i.ProductQuestion.UpdatedAt,
+		&i.Username,
+		&i.Email,
+		&i.ProductName,
+	)
+	return i, err
+}
+
+const hasPurchasedProduct = `-- name: HasPurchasedProduct :one
+SELECT EXISTS (
+    SELECT 1
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.user_id = $1
+      AND oi.product_id = $2
+      AND o.status IN ('paid', 'shipped', 'completed')
+      AND oi.deleted_at IS NULL
+      AND o.deleted_at IS NULL
+)
+`
+
+type HasPurchasedProductParams struct {
+	UserID    int64 `db:"user_id" json:"user_id"`
+	ProductID int64 `db:"product_id" json:"product_id"`
+}
+
+// Whether the user has a paid, shipped or completed order containing the product
+func (q *Queries) HasPurchasedProduct(ctx context.Context, arg HasPurchasedProductParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasPurchasedProduct, arg.UserID, arg.ProductID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const incrementQuestionAnswerCount = `-- name: IncrementQuestionAnswerCount :exec
+UPDATE product_questions
+SET answer_count = answer_count + 1
+WHERE id = $1
+`
+
+func (q *Queries) IncrementQuestionAnswerCount(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, incrementQuestionAnswerCount, id)
+	return err
+}
+
+const listProductAnswers = `-- name: ListProductAnswers :many
+SELECT a.id, a.question_id, a.user_id, a.content, a.is_staff, a.is_buyer, a.upvote_count, a.created_at, a.updated_at, u.username, u.avatar
+FROM product_answers a
+JOIN users u ON u.id = a.user_id
+WHERE a.question_id = $1
+ORDER BY a.upvote_count DESC, a.id
+LIMIT $3 OFFSET $2
+`
+
+type ListProductAnswersParams struct {
+	QuestionID  int64 `db:"question_id" json:"question_id"`
+	OffsetCount int32 `db:"offset_count" json:"offset_count"`
+	LimitCount  int32 `db:"limit_count" json:"limit_count"`
+}
+
+type ListProductAnswersRow struct {
+	ProductAnswer ProductAnswer `db:"product_answer" json:"product_answer"`
+	Username      string        `db:"username" json:"username"`
+	Avatar        *string       `db:"avatar" json:"avatar"`
+}
+
+func (q *Queries) ListProductAnswers(ctx context.Context, arg ListProductAnswersParams) ([]ListProductAnswersRow, error) {
+	rows, err := q.db.Query(ctx, listProductAnswers, arg.QuestionID, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductAnswersRow{}
+	for rows.Next() {
+		var i ListProductAnswersRow
+		if err := rows.Scan(
+			&i.ProductAnswer.ID,
+			&i.ProductAnswer.QuestionID,
+			&i.ProductAnswer.UserID,
+			&i.ProductAnswer.Content,
+			&i.ProductAnswer.IsStaff,
+			&i.ProductAnswer.IsBuyer,
+			&i.ProductAnswer.UpvoteCount,
+			&i.ProductAnswer.CreatedAt,
+			&i.ProductAnswer.UpdatedAt,
+			&i.Username,
+			&i.Avatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductQuestions = `-- name: ListProductQuestions :many
+SELECT q.id, q.product_id, q.user_id, q.content, q.answer_count, q.created_at, q.updated_at, u.username, u.avatar
+FROM product_questions q
+JOIN users u ON u.id = q.user_id
+WHERE q.product_id = $1
+ORDER BY q.created_at DESC, q.id DESC
+LIMIT $3 OFFSET $2
+`
+
+type ListProductQuestionsParams struct {
+	ProductID   int64 `db:"product_id" json:"product_id"`
+	OffsetCount int32 `db:"offset_count" json:"offset_count"`
+	LimitCount  int32 `db:"limit_count" json:"limit_count"`
+}
+
+type ListProductQuestionsRow struct {
+	ProductQuestion ProductQuestion `db:"product_question" json:"product_question"`
+	Username        string          `db:"username" json:"username"`
+	Avatar          *string         `db:"avatar" json:"avatar"`
+}
+
+func (q *Queries) ListProductQuestions(ctx context.Context, arg ListProductQuestionsParams) ([]ListProductQuestionsRow, error) {
+	rows, err := q.db.Query(ctx, listProductQuestions, arg.ProductID, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductQuestionsRow{}
+	for rows.Next() {
+		var i ListProductQuestionsRow
+		if err := rows.Scan(
+			&i.ProductQuestion.ID,
+			&i.ProductQuestion.ProductID,
+			&i.ProductQuestion.UserID,
+			&i.ProductQuestion.Content,
+			&i.ProductQuestion.AnswerCount,
+			&i.ProductQuestion.CreatedAt,
+			&i.ProductQuestion.UpdatedAt,
+			&i.Username,
+			&i.Avatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTopAnswersByQuestionIDs = `-- name: ListTopAnswersByQuestionIDs :many
+SELECT a.id, a.question_id, a.user_id, a.content, a.is_staff, a.is_buyer, a.upvote_count, a.created_at, u.username, u.avatar
+FROM (
+    SELECT product_answers.id, product_answers.question_id, product_answers.user_id, product_answers.content, product_answers.is_staff, product_answers.is_buyer, product_answers.upvote_count, product_answers.created_at, product_answers.updated_at,
+           ROW_NUMBER() OVER (PARTITION BY question_id ORDER BY upvote_count DESC, id) AS position
+    FROM product_answers
+    WHERE question_id = ANY($1::bigint[])
+) a
+JOIN users u ON u.id = a.user_id
+WHERE a.position <= $2::int
+ORDER BY a.question_id, a.position
+`
+
+type ListTopAnswersByQuestionIDsParams struct {
+	QuestionIds []int64 `db:"question_ids" json:"question_ids"`
+	PerQuestion int32   `db:"per_question" json:"per_question"`
+}
+
+type ListTopAnswersByQuestionIDsRow struct {
+	ID          int64     `db:"id" json:"id"`
+	QuestionID  int64     `db:"question_id" json:"question_id"`
+	UserID      int64     `db:"user_id" json:"user_id"`
+	Content     string    `db:"content" json:"content"`
+	IsStaff     bool      `db:"is_staff" json:"is_staff"`
+	IsBuyer     bool      `db:"is_buyer" json:"is_buyer"`
+	UpvoteCount int32     `db:"upvote_count" json:"upvote_count"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	Username    string    `db:"username" json:"username"`
+	Avatar      *string   `db:"avatar" json:"avatar"`
+}
+
+// The most upvoted answers of each question, up to per_question each
+func (q *Queries) ListTopAnswersByQuestionIDs(ctx context.Context, arg ListTopAnswersByQuestionIDsParams) ([]ListTopAnswersByQuestionIDsRow, error) {
+	rows, err := q.db.Query(ctx, listTopAnswersByQuestionIDs, arg.QuestionIds, arg.PerQuestion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTopAnswersByQuestionIDsRow{}
+	for rows.Next() {
+		var i ListTopAnswersByQuestionIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.QuestionID,
+			&i.UserID,
+			&i.Content,
+			&i.IsStaff,
+			&i.IsBuyer,
+			&i.UpvoteCount,
+			&i.CreatedAt,
+			&i.Username,
+			&i.Avatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
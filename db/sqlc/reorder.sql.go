@@ -0,0 +1,384 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: reorder.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+)
+
+const applyReorderPointsToCategory = `-- name: ApplyReorderPointsToCategory :execrows
+UPDATE inventory i
+SET low_stock_threshold = s.reorder_point
+FROM reorder_suggestions s
+WHERE s.product_id = i.product_id
+  AND s.sku_id IS NOT DISTINCT FROM i.sku_id
+  AND s.category_id = $1
+  AND i.deleted_at IS NULL
+  AND s.reorder_point > 0
+  AND i.low_stock_threshold IS DISTINCT FROM s.reorder_point
+`
+
+// A zero reorder point means no recent sales; it is skipped so low-stock alerts stay on
+func (q *Queries) ApplyReorderPointsToCategory(ctx context.Context, categoryID int64) (int64, error) {
+	result, err := q.db.Exec(ctx, applyReorderPointsToCategory, categoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countReorderSuggestions = `-- name: CountReorderSuggestions :one
+SELECT COUNT(*) FROM reorder_suggestions s
+WHERE ($1::bigint IS NULL OR s.category_id = $1::bigint)
+  AND (NOT $2::boolean OR s.available_stock <= s.reorder_point)
+`
+
+type CountReorderSuggestionsParams struct {
+	CategoryID   *int64 `db:"category_id" json:"category_id"`
+	NeedsReorder bool   `db:"needs_reorder" json:"needs_reorder"`
+}
+
+func (q *Queries) CountReorderSuggestions(ctx context.Context, arg CountReorderSuggestionsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countReorderSuggestions, arg.CategoryID, arg.NeedsReorder)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteReorderSetting = `-- name: DeleteReorderSetting :execrows
+DELETE FROM reorder_settings
+WHERE category_id = $1
+`
+
+func (q *Queries) DeleteReorderSetting(ctx context.Context, categoryID int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteReorderSetting, categoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getProductSalesVelocity = `-- name: GetProductSalesVelocity :many
+SELECT
+    i.product_id,
+    i.sku_id,
+    p.category_id,
+    i.available_stock,
+    i.low_stock_threshold,
+    COALESCE(ordered.units, 0)::bigint AS ordered_units,
+    COALESCE(deducted.units, 0)::bigint AS deducted_units
+FROM inventory i
+JOIN products p ON p.id = i.product_id
+LEFT JOIN (
+    SELECT oi.product_id, oi.sku_id, SUM(oi.quantity) AS units
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.created_at >= $1::timestamptz
+      AND o.status NOT IN ('cancelled', 'refunded')
+      AND o.deleted_at IS NULL
+      AND oi.deleted_at IS NULL
+    GROUP BY oi.product_id, oi.sku_id
+) ordered ON ordered.product_id = i.product_id AND ordered.sku_id IS NOT DISTINCT FROM i.sku_id
+LEFT JOIN (
+    SELECT l.product_id, l.sku_id, SUM(ABS(l.quantity_change)) AS units
+    FROM inventory_logs l
+    WHERE l.change_type = 'deduct'
+      AND l.created_at >= $1::timestamptz
+    GROUP BY l.product_id, l.sku_id
+) deducted ON deducted.product_id = i.product_id AND deducted.sku_id IS NOT DISTINCT FROM i.sku_id
+WHERE i.deleted_at IS NULL
+  AND p.deleted_at IS NULL
+ORDER BY i.product_id, i.sku_id NULLS FIRST
+`
+
+type GetProductSalesVelocityRow struct {
+	ProductID         int64  `db:"product_id" json:"product_id"`
+	SkuID             *int64 `db:"sku_id" json:"sku_id"`
+	CategoryID        int64  `db:"category_id" json:"category_id"`
+	AvailableStock    int32  `db:"available_stock" json:"available_stock"`
+	LowStockThreshold *int32 `db:"low_stock_threshold" json:"low_stock_threshold"`
+	OrderedUnits      int64  `db:"ordered_units" json:"ordered_units"`
+	DeductedUnits     int64  `db:"deducted_units" json:"deducted_units"`
+}
+
+// One row per inventory row; sales of a SKU count toward that SKU's row only
+func (q *Queries) GetProductSalesVelocity(ctx context.Context, since time.Time) ([]GetProductSalesVelocityRow, error) {
+	rows, err := q.db.Query(ctx, getProductSalesVelocity, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductSalesVelocityRow{}
+	for rows.Next() {
+		var i GetProductSalesVelocityRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.SkuID,
+			&i.CategoryID,
+			&i.AvailableStock,
+			&i.LowStockThreshold,
+			&i.OrderedUnits,
+			&i.DeductedUnits,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReorderSettings = `-- name: ListReorderSettings :many
+SELECT category_id, lead_time_days, safety_stock_days, auto_apply, created_at, updated_at FROM reorder_settings
+ORDER BY category_id
+`
+
+func (q *Queries) ListReorderSettings(ctx context.Context) ([]ReorderSetting, error) {
+	rows, err := q.db.Query(ctx, listReorderSettings)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReorderSetting{}
+	for rows.Next() {
+		var i ReorderSetting
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.LeadTimeDays,
+			&i.SafetyStockDays,
+			&i.AutoApply,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReorderSuggestions = `-- name: ListReorderSuggestions :many
+SELECT
+    s.product_id, s.category_id, s.window_days, s.units_sold, s.daily_velocity, s.lead_time_days, s.safety_stock_days, s.reorder_point, s.reorder_quantity, s.current_threshold, s.available_stock, s.computed_at, s.sku_id,
+    p.name AS product_name,
+    k.sku_code
+FROM reorder_suggestions s
+JOIN products p ON p.id = s.product_id
+LEFT JOIN product_skus k ON k.id = s.sku_id
+WHERE ($1::bigint IS NULL OR s.category_id = $1::bigint)
+  AND (NOT $2::boolean OR s.available_stock <= s.reorder_point)
+ORDER BY s.reorder_quantity DESC, s.product_id, s.sku_id NULLS FIRST
+LIMIT $4 OFFSET $3
+`
+
+type ListReorderSuggestionsParams struct {
+	CategoryID   *int64 `db:"category_id" json:"category_id"`
+	NeedsReorder bool   `db:"needs_reorder" json:"needs_reorder"`
+	OffsetCount  int32  `db:"offset_count" json:"offset_count"`
+	LimitCount   int32  `db:"limit_count" json:"limit_count"`
+}
+
+type ListReorderSuggestionsRow struct {
+	ProductID        int64     `db:"product_id" json:"product_id"`
+	CategoryID       int64     `db:"category_id" json:"category_id"`
+	WindowDays       int32     `db:"window_days" json:"window_days"`
+	UnitsSold        int32     `db:"units_sold" json:"units_sold"`
+	DailyVelocity    float64   `db:"daily_velocity" json:"daily_velocity"`
+	LeadTimeDays     int32     `db:"lead_time_days" json:"lead_time_days"`
+	SafetyStockDays  int32     `db:"safety_stock_days" json:"safety_stock_days"`
+	ReorderPoint     int32     `db:"reorder_point" json:"reorder_point"`
+	ReorderQuantity  int32     `db:"reorder_quantity" json:"reorder_quantity"`
+	CurrentThreshold *int32    `db:"current_threshold" json:"current_threshold"`
+	AvailableStock   int32     `db:"available_stock" json:"available_stock"`
+	ComputedAt       time.Time `db:"computed_at" json:"computed_at"`
+	SkuID            *int64    `db:"sku_id" json:"sku_id"`
+	ProductName      string    `db:"product_name" json:"product_name"`
+	SkuCode          *string   `db:"sku_code" json:"sku_code"`
+}
+
+func (q *Queries) ListReorderSuggestions(ctx context.Context, arg ListReorderSuggestionsParams) ([]ListReorderSuggestionsRow, error) {
+	rows, err := q.db.Query(ctx, listReorderSuggestions,
+		arg.CategoryID,
+		arg.NeedsReorder,
+		arg.OffsetCount,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListReorderSuggestionsRow{}
+	for rows.Next() {
+		var i ListReorderSuggestionsRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.CategoryID,
+			&i.WindowDays,
+			&i.UnitsSold,
+			&i.DailyVelocity,
+			&i.LeadTimeDays,
+			&i.SafetyStockDays,
+			&i.ReorderPoint,
+			&i.ReorderQuantity,
+			&i.CurrentThreshold,
+			&i.AvailableStock,
+			&i.ComputedAt,
+			&i.SkuID,
+			&i.ProductName,
+			&i.SkuCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertReorderSetting = `-- name: UpsertReorderSetting :one
+
+INSERT INTO reorder_settings (
+    category_id,
+    lead_time_days,
+    safety_stock_days,
+    auto_apply
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (category_id) DO UPDATE
+SET
+    lead_time_days = EXCLUDED.lead_time_days,
+    safety_stock_days = EXCLUDED.safety_stock_days,
+    auto_apply = EXCLUDED.auto_apply
+RETURNING category_id, lead_time_days, safety_stock_days, auto_apply, created_at, updated_at
+`
+
+type UpsertReorderSettingParams struct {
+	CategoryID      int64 `db:"category_id" json:"category_id"`
+	LeadTimeDays    int32 `db:"lead_time_days" json:"lead_time_days"`
+	SafetyStockDays int32 `db:"safety_stock_days" json:"safety_stock_days"`
+	AutoApply       bool  `db:"auto_apply" json:"auto_apply"`
+}
+
+// Reorder Queries
+func (q *Queries) UpsertReorderSetting(ctx context.Context, arg UpsertReorderSettingParams) (ReorderSetting, error) {
+	row := q.db.QueryRow(ctx, upsertReorderSetting,
+		arg.CategoryID,
+		arg.LeadTimeDays,
+		arg.SafetyStockDays,
+		arg.AutoApply,
+	)
+	var i ReorderSetting
+	err := row.Scan(
+		&i.CategoryID,
+		&i.LeadTimeDays,
+		&i.SafetyStockDays,
+		&i.AutoApply,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertReorderSuggestions = `-- name: UpsertReorderSuggestions :exec
+INSERT INTO reorder_suggestions (
+    product_id,
+    sku_id,
+    category_id,
+    window_days,
+    units_sold,
+    daily_velocity,
+    lead_time_days,
+    safety_stock_days,
+    reorder_point,
+    reorder_quantity,
+    current_threshold,
+    available_stock,
+    computed_at
+)
+SELECT
+    v.product_id,
+    i.sku_id,
+    v.category_id,
+    $1::int,
+    v.units_sold,
+    v.daily_velocity,
+    v.lead_time_days,
+    v.safety_stock_days,
+    v.reorder_point,
+    v.reorder_quantity,
+    i.low_stock_threshold,
+    v.available_stock,
+    NOW()
+FROM (
+    SELECT
+        unnest($2::bigint[]) AS product_id,
+        NULLIF(unnest($3::bigint[]), 0) AS sku_id,
+        unnest($4::bigint[]) AS category_id,
+        unnest($5::int[]) AS units_sold,
+        unnest($6::double precision[]) AS daily_velocity,
+        unnest($7::int[]) AS lead_time_days,
+        unnest($8::int[]) AS safety_stock_days,
+        unnest($9::int[]) AS reorder_point,
+        unnest($10::int[]) AS reorder_quantity,
+        unnest($11::int[]) AS available_stock
+) AS v
+JOIN inventory i ON i.product_id = v.product_id AND i.sku_id IS NOT DISTINCT FROM v.sku_id AND i.deleted_at IS NULL
+ON CONFLICT (product_id, sku_id) DO UPDATE
+SET
+    category_id = EXCLUDED.category_id,
+    window_days = EXCLUDED.window_days,
+    units_sold = EXCLUDED.units_sold,
+    daily_velocity = EXCLUDED.daily_velocity,
+    lead_time_days = EXCLUDED.lead_time_days,
+    safety_stock_days = EXCLUDED.safety_stock_days,
+    reorder_point = EXCLUDED.reorder_point,
+    reorder_quantity = EXCLUDED.reorder_quantity,
+    current_threshold = EXCLUDED.current_threshold,
+    available_stock = EXCLUDED.available_stock,
+    computed_at = EXCLUDED.computed_at
+`
+
+type UpsertReorderSuggestionsParams struct {
+	WindowDays        int32     `db:"window_days" json:"window_days"`
+	ProductIds        []int64   `db:"product_ids" json:"product_ids"`
+	SkuIds            []int64   `db:"sku_ids" json:"sku_ids"`
+	CategoryIds       []int64   `db:"category_ids" json:"category_ids"`
+	UnitsSold         []int32   `db:"units_sold" json:"units_sold"`
+	DailyVelocities   []float64 `db:"daily_velocities" json:"daily_velocities"`
+	LeadTimeDays      []int32   `db:"lead_time_days" json:"lead_time_days"`
+	SafetyStockDays   []int32   `db:"safety_stock_days" json:"safety_stock_days"`
+	ReorderPoints     []int32   `db:"reorder_points" json:"reorder_points"`
+	ReorderQuantities []int32   `db:"reorder_quantities" json:"reorder_quantities"`
+	AvailableStocks   []int32   `db:"available_stocks" json:"available_stocks"`
+}
+
+// Saves a whole refresh in one statement; the array arguments are parallel, one element per
+// inventory row, with a sku_id of 0 for the product-level row. current_threshold is read from that row.
+func (q *Queries) UpsertReorderSuggestions(ctx context.Context, arg UpsertReorderSuggestionsParams) error {
+	_, err := q.db.Exec(ctx, upsertReorderSuggestions,
+		arg.WindowDays,
+		arg.ProductIds,
+		arg.SkuIds,
+		arg.CategoryIds,
+		arg.UnitsSold,
+		arg.DailyVelocities,
+		arg.LeadTimeDays,
+		arg.SafetyStockDays,
+		arg.ReorderPoints,
+		arg.ReorderQuantities,
+		arg.AvailableStocks,
+	)
+	return err
+}
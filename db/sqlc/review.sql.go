@@ -0,0 +1,619 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: review.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+)
+
+const addProductReviewHelpfulCount = `-- name: AddProductReviewHelpfulCount :one
+UPDATE product_reviews
+SET helpful_count = helpful_count + $1::int
+WHERE id = $2
+RETURNING helpful_count
+`
+
+type AddProductReviewHelpfulCountParams struct {
+	Delta int32 `db:"delta" json:"delta"`
+	ID    int64 `db:"id" json:"id"`
+}
+
+func (q *Queries) AddProductReviewHelpfulCount(ctx context.Context, arg AddProductReviewHelpfulCountParams) (int32, error) {
+	row := q.db.QueryRow(ctx, addProductReviewHelpfulCount, arg.Delta, arg.ID)
+	var helpful_count int32
+	err := row.Scan(&helpful_count)
+	return helpful_count, err
+}
+
+const countDuplicateReviews = `-- name: CountDuplicateReviews :one
+SELECT COUNT(*) FROM product_reviews
+WHERE content_fingerprint = $1 AND created_at >= $2
+`
+
+type CountDuplicateReviewsParams struct {
+	ContentFingerprint string    `db:"content_fingerprint" json:"content_fingerprint"`
+	Since              time.Time `db:"since" json:"since"`
+}
+
+func (q *Queries) CountDuplicateReviews(ctx context.Context, arg CountDuplicateReviewsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countDuplicateReviews, arg.ContentFingerprint, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countProductReviews = `-- name: CountProductReviews :one
+SELECT COUNT(*) FROM product_reviews
+WHERE product_id = $1 AND status = 'approved'
+`
+
+func (q *Queries) CountProductReviews(ctx context.Context, productID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductReviews, productID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countRecentReviewsByUser = `-- name: CountRecentReviewsByUser :one
+SELECT COUNT(*) FROM product_reviews
+WHERE user_id = $1 AND created_at >= $2
+`
+
+type CountRecentReviewsByUserParams struct {
+	UserID int64     `db:"user_id" json:"user_id"`
+	Since  time.Time `db:"since" json:"since"`
+}
+
+func (q *Queries) CountRecentReviewsByUser(ctx context.Context, arg CountRecentReviewsByUserParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countRecentReviewsByUser, arg.UserID, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countReviewsByStatus = `-- name: CountReviewsByStatus :one
+SELECT COUNT(*) FROM product_reviews
+WHERE status = $1
+`
+
+func (q *Queries) CountReviewsByStatus(ctx context.Context, status string) (int64, error) {
+	row := q.db.QueryRow(ctx, countReviewsByStatus, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createProductReview = `-- name: CreateProductReview :one
+INSERT INTO product_reviews (
+    product_id,
+    user_id,
+    order_item_id,
+    rating,
+    content,
+    images,
+    content_fingerprint,
+    status,
+    flag_reasons
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, product_id, user_id, order_item_id, rating, content, images, helpful_count, reply, replied_at, created_at, updated_at, status, flag_reasons, content_fingerprint, reject_reason, moderated_by, moderated_at
+`
+
+type CreateProductReviewParams struct {
+	ProductID          int64    `db:"product_id" json:"product_id"`
+	UserID             int64    `db:"user_id" json:"user_id"`
+	OrderItemID        int64    `db:"order_item_id" json:"order_item_id"`
+	Rating             int16    `db:"rating" json:"rating"`
+	Content            string   `db:"content" json:"content"`
+	Images             []string `db:"images" json:"images"`
+	ContentFingerprint string   `db:"content_fingerprint" json:"content_fingerprint"`
+	Status             string   `db:"status" json:"status"`
+	FlagReasons        []string `db:"flag_reasons" json:"flag_reasons"`
+}
+
+func (q *Queries) CreateProductReview(ctx context.Context, arg CreateProductReviewParams) (ProductReview, error) {
+	row := q.db.QueryRow(ctx, createProductReview,
+		arg.ProductID,
+		arg.UserID,
+		arg.OrderItemID,
+		arg.Rating,
+		arg.Content,
+		arg.Images,
+		arg.ContentFingerprint,
+		arg.Status,
+		arg.FlagReasons,
+	)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.OrderItemID,
+		&i.Rating,
+		&i.Content,
+		&i.Images,
+		&i.HelpfulCount,
+		&i.Reply,
+		&i.RepliedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.FlagReasons,
+		&i.ContentFingerprint,
+		&i.RejectReason,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+	)
+	return i, err
+}
+
+const createProductReviewVote = `-- name: CreateProductReviewVote :execrows
+INSERT INTO product_review_votes (review_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type CreateProductReviewVoteParams struct {
+	ReviewID int64 `db:"review_id" json:"review_id"`
+	UserID   int64 `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) CreateProductReviewVote(ctx context.Context, arg CreateProductReviewVoteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createProductReviewVote, arg.ReviewID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createReviewModerationLog = `-- name: CreateReviewModerationLog :one
+INSERT INTO product_review_moderation_logs (
+    review_id,
+    action,
+    from_status,
+    to_status,
+    reasons,
+    operator_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, review_id, action, from_status, to_status, reasons, operator_id, created_at
+`
+
+type CreateReviewModerationLogParams struct {
+	ReviewID   int64    `db:"review_id" json:"review_id"`
+	Action     string   `db:"action" json:"action"`
+	FromStatus *string  `db:"from_status" json:"from_status"`
+	ToStatus   string   `db:"to_status" json:"to_status"`
+	Reasons    []string `db:"reasons" json:"reasons"`
+	OperatorID *int64   `db:"operator_id" json:"operator_id"`
+}
+
+func (q *Queries) CreateReviewModerationLog(ctx context.Context, arg CreateReviewModerationLogParams) (ProductReviewModerationLog, error) {
+	row := q.db.QueryRow(ctx, createReviewModerationLog,
+		arg.ReviewID,
+		arg.Action,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reasons,
+		arg.OperatorID,
+	)
+	var i ProductReviewModerationLog
+	err := row.Scan(
+		&i.ID,
+		&i.ReviewID,
+		&i.Action,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Reasons,
+		&i.OperatorID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteProductReviewVote = `-- name: DeleteProductReviewVote :execrows
+DELETE FROM product_review_votes
+WHERE review_id = $1 AND user_id = $2
+`
+
+type DeleteProductReviewVoteParams struct {
+	ReviewID int64 `db:"review_id" json:"review_id"`
+	UserID   int64 `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) DeleteProductReviewVote(ctx context.Context, arg DeleteProductReviewVoteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProductReviewVote, arg.ReviewID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getProductReview = `-- name: GetProductReview :one
+SELECT id, product_id, user_id, order_item_id, rating, content, images, helpful_count, reply, replied_at, created_at, updated_at, status, flag_reasons, content_fingerprint, reject_reason, moderated_by, moderated_at FROM product_reviews
+WHERE id = $1
+`
+
+func (q *Queries) GetProductReview(ctx context.Context, id int64) (ProductReview, error) {
+	row := q.db.QueryRow(ctx, getProductReview, id)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.OrderItemID,
+		&i.Rating,
+		&i.Content,
+		&i.Images,
+		&i.HelpfulCount,
+		&i.Reply,
+		&i.RepliedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.FlagReasons,
+		&i.ContentFingerprint,
+		&i.RejectReason,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+	)
+	return i, err
+}
+
+const getProductReviewForUpdate = `-- name: GetProductReviewForUpdate :one
+SELECT id, product_id, user_id, order_item_id, rating, content, images, helpful_count, reply, replied_at, created_at, updated_at, status, flag_reasons, content_fingerprint, reject_reason, moderated_by, moderated_at FROM product_reviews
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetProductReviewForUpdate(ctx context.Context, id int64) (ProductReview, error) {
+	row := q.db.QueryRow(ctx, getProductReviewForUpdate, id)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.OrderItemID,
+		&i.Rating,
+		&i.Content,
+		&i.Images,
+		&i.HelpfulCount,
+		&i.Reply,
+		&i.RepliedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.FlagReasons,
+		&i.ContentFingerprint,
+		&i.RejectReason,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+	)
+	return i, err
+}
+
+const getReviewableOrderItem = `-- name: GetReviewableOrderItem :one
+SELECT oi.id, oi.product_id, o.user_id, o.status
+FROM order_items oi
+JOIN orders o ON o.id = oi.order_id
+WHERE oi.id = $1
+  AND oi.deleted_at IS NULL
+  AND o.deleted_at IS NULL
+FOR UPDATE OF oi
+`
+
+type GetReviewableOrderItemRow struct {
+	ID        int64  `db:"id" json:"id"`
+	ProductID int64  `db:"product_id" json:"product_id"`
+	UserID    int64  `db:"user_id" json:"user_id"`
+	Status    string `db:"status" json:"status"`
+}
+
+func (q *Queries) GetReviewableOrderItem(ctx context.Context, id int64) (GetReviewableOrderItemRow, error) {
+	row := q.db.QueryRow(ctx, getReviewableOrderItem, id)
+	var i GetReviewableOrderItemRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Status,
+	)
+	return i, err
+}
+
+const listProductReviews = `-- name: ListProductReviews :many
+SELECT r.id, r.product_id, r.user_id, r.order_item_id, r.rating, r.content, r.images, r.helpful_count, r.reply, r.replied_at, r.created_at, r.updated_at, r.status, r.flag_reasons, r.content_fingerprint, r.reject_reason, r.moderated_by, r.moderated_at, u.username, u.avatar
+FROM product_reviews r
+JOIN users u ON u.id = r.user_id
+WHERE r.product_id = $1
+  AND r.status = 'approved'
+ORDER BY
+    CASE WHEN $2::text = 'helpful' THEN r.helpful_count END DESC,
+    r.created_at DESC,
+    r.id DESC
+LIMIT $4 OFFSET $3
+`
+
+type ListProductReviewsParams struct {
+	ProductID   int64  `db:"product_id" json:"product_id"`
+	Sort        string `db:"sort" json:"sort"`
+	OffsetCount int32  `db:"offset_count" json:"offset_count"`
+	LimitCount  int32  `db:"limit_count" json:"limit_count"`
+}
+
+type ListProductReviewsRow struct {
+	ProductReview ProductReview `db:"product_review" json:"product_review"`
+	Username      string        `db:"username" json:"username"`
+	Avatar        *string       `db:"avatar" json:"avatar"`
+}
+
+func (q *Queries) ListProductReviews(ctx context.Context, arg ListProductReviewsParams) ([]ListProductReviewsRow, error) {
+	rows, err := q.db.Query(ctx, listProductReviews,
+		arg.ProductID,
+		arg.Sort,
+		arg.OffsetCount,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductReviewsRow{}
+	for rows.Next() {
+		var i ListProductReviewsRow
+		if err := rows.Scan(
+			&i.ProductReview.ID,
+			&i.ProductReview.ProductID,
+			&i.ProductReview.UserID,
+			&i.ProductReview.OrderItemID,
+			&i.ProductReview.Rating,
+			&i.ProductReview.Content,
+			&i.ProductReview.Images,
+			&i.ProductReview.HelpfulCount,
+			&i.ProductReview.Reply,
+			&i.ProductReview.RepliedAt,
+			&i.ProductReview.CreatedAt,
+			&i.ProductReview.UpdatedAt,
+			&i.ProductReview.Status,
+			&i.ProductReview.FlagReasons,
+			&i.ProductReview.ContentFingerprint,
+			&i.ProductReview.RejectReason,
+			&i.ProductReview.ModeratedBy,
+			&i.ProductReview.ModeratedAt,
+			&i.Username,
+			&i.Avatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewModerationLogs = `-- name: ListReviewModerationLogs :many
+SELECT id, review_id, action, from_status, to_status, reasons, operator_id, created_at FROM product_review_moderation_logs
+WHERE review_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListReviewModerationLogs(ctx context.Context, reviewID int64) ([]ProductReviewModerationLog, error) {
+	rows, err := q.db.Query(ctx, listReviewModerationLogs, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductReviewModerationLog{}
+	for rows.Next() {
+		var i ProductReviewModerationLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReviewID,
+			&i.Action,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reasons,
+			&i.OperatorID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewsByStatus = `-- name: ListReviewsByStatus :many
+SELECT r.id, r.product_id, r.user_id, r.order_item_id, r.rating, r.content, r.images, r.helpful_count, r.reply, r.replied_at, r.created_at, r.updated_at, r.status, r.flag_reasons, r.content_fingerprint, r.reject_reason, r.moderated_by, r.moderated_at, u.username, u.avatar
+FROM product_reviews r
+JOIN users u ON u.id = r.user_id
+WHERE r.status = $1
+ORDER BY r.created_at, r.id
+LIMIT $3 OFFSET $2
+`
+
+type ListReviewsByStatusParams struct {
+	Status      string `db:"status" json:"status"`
+	OffsetCount int32  `db:"offset_count" json:"offset_count"`
+	LimitCount  int32  `db:"limit_count" json:"limit_count"`
+}
+
+type ListReviewsByStatusRow struct {
+	ProductReview ProductReview `db:"product_review" json:"product_review"`
+	Username      string        `db:"username" json:"username"`
+	Avatar        *string       `db:"avatar" json:"avatar"`
+}
+
+func (q *Queries) ListReviewsByStatus(ctx context.Context, arg ListReviewsByStatusParams) ([]ListReviewsByStatusRow, error) {
+	rows, err := q.db.Query(ctx, listReviewsByStatus, arg.Status, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListReviewsByStatusRow{}
+	for rows.Next() {
+		var i ListReviewsByStatusRow
+		if err := rows.Scan(
+			&i.ProductReview.ID,
+			&i.ProductReview.ProductID,
+			&i.ProductReview.UserID,
+			&i.ProductReview.OrderItemID,
+			&i.ProductReview.Rating,
+			&i.ProductReview.Content,
+			&i.ProductReview.Images,
+			&i.ProductReview.HelpfulCount,
+			&i.ProductReview.Reply,
+			&i.ProductReview.RepliedAt,
+			&i.ProductReview.CreatedAt,
+			&i.ProductReview.UpdatedAt,
+			&i.ProductReview.Status,
+			&i.ProductReview.FlagReasons,
+			&i.ProductReview.ContentFingerprint,
+			&i.ProductReview.RejectReason,
+			&i.ProductReview.ModeratedBy,
+			&i.ProductReview.ModeratedAt,
+			&i.Username,
+			&i.Avatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const refreshProductRating = `-- name: RefreshProductRating :exec
+UPDATE products
+SET
+    rating_total = stats.total,
+    rating_count = stats.count
+FROM (
+    SELECT COALESCE(SUM(rating), 0)::bigint AS total, COUNT(*)::int AS count
+    FROM product_reviews
+    WHERE product_id = $1 AND status = 'approved'
+) AS stats
+WHERE products.id = $1
+`
+
+// Recompute the product's aggregated rating from its approved reviews
+func (q *Queries) RefreshProductRating(ctx context.Context, productID int64) error {
+	_, err := q.db.Exec(ctx, refreshProductRating, productID)
+	return err
+}
+
+const replyProductReview = `-- name: ReplyProductReview :one
+UPDATE product_reviews
+SET
+    reply = $2,
+    replied_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, product_id, user_id, order_item_id, rating, content, images, helpful_count, reply, replied_at, created_at, updated_at, status, flag_reasons, content_fingerprint, reject_reason, moderated_by, moderated_at
+`
+
+type ReplyProductReviewParams struct {
+	ID    int64   `db:"id" json:"id"`
+	Reply *string `db:"reply" json:"reply"`
+}
+
+func (q *Queries) ReplyProductReview(ctx context.Context, arg ReplyProductReviewParams) (ProductReview, error) {
+	row := q.db.QueryRow(ctx, replyProductReview, arg.ID, arg.Reply)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.OrderItemID,
+		&i.Rating,
+		&i.Content,
+		&i.Images,
+		&i.HelpfulCount,
+		&i.Reply,
+		&i.RepliedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.FlagReasons,
+		&i.ContentFingerprint,
+		&i.RejectReason,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+	)
+	return i, err
+}
+
+const reviewExistsForOrderItem = `-- name: ReviewExistsForOrderItem :one
+SELECT EXISTS (
+    SELECT 1 FROM product_reviews
+    WHERE order_item_id = $1
+)
+`
+
+func (q *Queries) ReviewExistsForOrderItem(ctx context.Context, orderItemID int64) (bool, error) {
+	row := q.db.QueryRow(ctx, reviewExistsForOrderItem, orderItemID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const updateProductReviewStatus = `-- name: UpdateProductReviewStatus :one
+UPDATE product_reviews
+SET
+    status = $1,
+    reject_reason = $2,
+    moderated_by = $3,
+    moderated_at = NOW(),
+    updated_at = NOW()
+WHERE id = $4
+RETURNING id, product_id, user_id, order_item_id, rating, content, images, helpful_count, reply, replied_at, created_at, updated_at, status, flag_reasons, content_fingerprint, reject_reason, moderated_by, moderated_at
+`
+
+type UpdateProductReviewStatusParams struct {
+	Status       string  `db:"status" json:"status"`
+	RejectReason *string `db:"reject_reason" json:"reject_reason"`
+	ModeratedBy  *int64  `db:"moderated_by" json:"moderated_by"`
+	ID           int64   `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateProductReviewStatus(ctx context.Context, arg UpdateProductReviewStatusParams) (ProductReview, error) {
+	row := q.db.QueryRow(ctx, updateProductReviewStatus,
+		arg.Status,
+		arg.RejectReason,
+		arg.ModeratedBy,
+		arg.ID,
+	)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.OrderItemID,
+		&i.Rating,
+		&i.Content,
+		&i.Images,
+		&i.HelpfulCount,
+		&i.Reply,
+		&i.RepliedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.FlagReasons,
+		&i.ContentFingerprint,
+		&i.RejectReason,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+	)
+	return i, err
+}
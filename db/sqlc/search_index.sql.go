@@ -0,0 +1,350 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: search_index.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const countProductIndex = `-- name: CountProductIndex :one
+SELECT COUNT(*) FROM products p
+WHERE p.deleted_at IS NULL
+  AND ($1::text IS NULL OR p.search_vector @@ websearch_to_tsquery($2::text::regconfig, $1::text))
+  AND ($3::bigint IS NULL OR p.category_id = $3)
+  AND ($4::text IS NULL OR p.status = $4)
+  AND ($5::text[] IS NULL OR p.brand = ANY($5::text[]))
+  AND ($6::bigint IS NULL OR p.price >= $6)
+  AND ($7::bigint IS NULL OR p.price < $7)
+  AND ($8::jsonb IS NULL OR p.specifications @> $8::jsonb)
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each($9::jsonb) AS f(key, docs)
+      WHERE NOT EXISTS (
+          SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+          WHERE p.specifications @> d.doc
+      )
+  )
+`
+
+type CountProductIndexParams struct {
+	Query        *string  `db:"query" json:"query"`
+	TsConfig     string   `db:"ts_config" json:"ts_config"`
+	CategoryID   *int64   `db:"category_id" json:"category_id"`
+	Status       *string  `db:"status" json:"status"`
+	Brands       []string `db:"brands" json:"brands"`
+	MinPrice     *int64   `db:"min_price" json:"min_price"`
+	MaxPrice     *int64   `db:"max_price" json:"max_price"`
+	SpecContains []byte   `db:"spec_contains" json:"spec_contains"`
+	SpecFilters  []byte   `db:"spec_filters" json:"spec_filters"`
+}
+
+func (q *Queries) CountProductIndex(ctx context.Context, arg CountProductIndexParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductIndex,
+		arg.Query,
+		arg.TsConfig,
+		arg.CategoryID,
+		arg.Status,
+		arg.Brands,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.SpecContains,
+		arg.SpecFilters,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const searchIndexBrandFacets = `-- name: SearchIndexBrandFacets :many
+SELECT p.brand::text AS brand, COUNT(*) AS product_count
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND ($1::text IS NULL OR p.search_vector @@ websearch_to_tsquery($2::text::regconfig, $1::text))
+  AND ($3::bigint IS NULL OR p.category_id = $3)
+  AND ($4::text IS NULL OR p.status = $4)
+  AND ($5::bigint IS NULL OR p.price >= $5)
+  AND ($6::bigint IS NULL OR p.price < $6)
+  AND ($7::jsonb IS NULL OR p.specifications @> $7::jsonb)
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each($8::jsonb) AS f(key, docs)
+      WHERE NOT EXISTS (
+          SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+          WHERE p.specifications @> d.doc
+      )
+  )
+  AND p.brand IS NOT NULL
+GROUP BY p.brand
+ORDER BY product_count DESC, p.brand
+`
+
+type SearchIndexBrandFacetsParams struct {
+	Query        *string `db:"query" json:"query"`
+	TsConfig     string  `db:"ts_config" json:"ts_config"`
+	CategoryID   *int64  `db:"category_id" json:"category_id"`
+	Status       *string `db:"status" json:"status"`
+	MinPrice     *int64  `db:"min_price" json:"min_price"`
+	MaxPrice     *int64  `db:"max_price" json:"max_price"`
+	SpecContains []byte  `db:"spec_contains" json:"spec_contains"`
+	SpecFilters  []byte  `db:"spec_filters" json:"spec_filters"`
+}
+
+type SearchIndexBrandFacetsRow struct {
+	Brand        string `db:"brand" json:"brand"`
+	ProductCount int64  `db:"product_count" json:"product_count"`
+}
+
+func (q *Queries) SearchIndexBrandFacets(ctx context.Context, arg SearchIndexBrandFacetsParams) ([]SearchIndexBrandFacetsRow, error) {
+	rows, err := q.db.Query(ctx, searchIndexBrandFacets,
+		arg.Query,
+		arg.TsConfig,
+		arg.CategoryID,
+		arg.Status,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.SpecContains,
+		arg.SpecFilters,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchIndexBrandFacetsRow{}
+	for rows.Next() {
+		var i SearchIndexBrandFacetsRow
+		if err := rows.Scan(&i.Brand, &i.ProductCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchIndexPriceFacets = `-- name: SearchIndexPriceFacets :many
+SELECT width_bucket(p.price, $1::bigint[])::int AS bucket, COUNT(*) AS product_count
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND ($2::text IS NULL OR p.search_vector @@ websearch_to_tsquery($3::text::regconfig, $2::text))
+  AND ($4::bigint IS NULL OR p.category_id = $4)
+  AND ($5::text IS NULL OR p.status = $5)
+  AND ($6::text[] IS NULL OR p.brand = ANY($6::text[]))
+  AND ($7::jsonb IS NULL OR p.specifications @> $7::jsonb)
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each($8::jsonb) AS f(key, docs)
+      WHERE NOT EXISTS (
+          SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+          WHERE p.specifications @> d.doc
+      )
+  )
+GROUP BY bucket
+ORDER BY bucket
+`
+
+type SearchIndexPriceFacetsParams struct {
+	Boundaries   []int64  `db:"boundaries" json:"boundaries"`
+	Query        *string  `db:"query" json:"query"`
+	TsConfig     string   `db:"ts_config" json:"ts_config"`
+	CategoryID   *int64   `db:"category_id" json:"category_id"`
+	Status       *string  `db:"status" json:"status"`
+	Brands       []string `db:"brands" json:"brands"`
+	SpecContains []byte   `db:"spec_contains" json:"spec_contains"`
+	SpecFilters  []byte   `db:"spec_filters" json:"spec_filters"`
+}
+
+type SearchIndexPriceFacetsRow struct {
+	Bucket       int32 `db:"bucket" json:"bucket"`
+	ProductCount int64 `db:"product_count" json:"product_count"`
+}
+
+// bucket is width_bucket over the ascending boundaries: 0 is below the first, len(boundaries) above the last
+func (q *Queries) SearchIndexPriceFacets(ctx context.Context, arg SearchIndexPriceFacetsParams) ([]SearchIndexPriceFacetsRow, error) {
+	rows, err := q.db.Query(ctx, searchIndexPriceFacets,
+		arg.Boundaries,
+		arg.Query,
+		arg.TsConfig,
+		arg.CategoryID,
+		arg.Status,
+		arg.Brands,
+		arg.SpecContains,
+		arg.SpecFilters,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchIndexPriceFacetsRow{}
+	for rows.Next() {
+		var i SearchIndexPriceFacetsRow
+		if err := rows.Scan(&i.Bucket, &i.ProductCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchIndexSpecFacets = `-- name: SearchIndexSpecFacets :many
+
+SELECT s.key::text AS name, s.value::text AS value, COUNT(*) AS product_count
+FROM products p
+CROSS JOIN LATERAL jsonb_each_text(
+    CASE WHEN jsonb_typeof(p.specifications) = 'object' THEN p.specifications ELSE '{}'::jsonb END
+) AS s(key, value)
+WHERE p.deleted_at IS NULL
+  AND ($1::text IS NULL OR p.search_vector @@ websearch_to_tsquery($2::text::regconfig, $1::text))
+  AND ($3::bigint IS NULL OR p.category_id = $3)
+  AND ($4::text IS NULL OR p.status = $4)
+  AND ($5::text[] IS NULL OR p.brand = ANY($5::text[]))
+  AND ($6::bigint IS NULL OR p.price >= $6)
+  AND ($7::bigint IS NULL OR p.price < $7)
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each($8::jsonb) AS f(key, docs)
+      WHERE f.key <> s.key
+        AND NOT EXISTS (
+            SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+            WHERE p.specifications @> d.doc
+        )
+  )
+GROUP BY s.key, s.value
+ORDER BY s.key, product_count DESC, s.value
+`
+
+type SearchIndexSpecFacetsParams struct {
+	Query       *string  `db:"query" json:"query"`
+	TsConfig    string   `db:"ts_config" json:"ts_config"`
+	CategoryID  *int64   `db:"category_id" json:"category_id"`
+	Status      *string  `db:"status" json:"status"`
+	Brands      []string `db:"brands" json:"brands"`
+	MinPrice    *int64   `db:"min_price" json:"min_price"`
+	MaxPrice    *int64   `db:"max_price" json:"max_price"`
+	SpecFilters []byte   `db:"spec_filters" json:"spec_filters"`
+}
+
+type SearchIndexSpecFacetsRow struct {
+	Name         string `db:"name" json:"name"`
+	Value        string `db:"value" json:"value"`
+	ProductCount int64  `db:"product_count" json:"product_count"`
+}
+
+// Each facet applies every active filter except its own, so sibling values keep their counts.
+func (q *Queries) SearchIndexSpecFacets(ctx context.Context, arg SearchIndexSpecFacetsParams) ([]SearchIndexSpecFacetsRow, error) {
+	rows, err := q.db.Query(ctx, searchIndexSpecFacets,
+		arg.Query,
+		arg.TsConfig,
+		arg.CategoryID,
+		arg.Status,
+		arg.Brands,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.SpecFilters,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchIndexSpecFacetsRow{}
+	for rows.Next() {
+		var i SearchIndexSpecFacetsRow
+		if err := rows.Scan(&i.Name, &i.Value, &i.ProductCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchProductIndex = `-- name: SearchProductIndex :many
+
+SELECT p.id, COALESCE(ts_rank(p.search_vector, websearch_to_tsquery($1::text::regconfig, $2::text)), 0)::REAL AS score
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND ($2::text IS NULL OR p.search_vector @@ websearch_to_tsquery($1::text::regconfig, $2::text))
+  AND ($3::bigint IS NULL OR p.category_id = $3)
+  AND ($4::text IS NULL OR p.status = $4)
+  AND ($5::text[] IS NULL OR p.brand = ANY($5::text[]))
+  AND ($6::bigint IS NULL OR p.price >= $6)
+  AND ($7::bigint IS NULL OR p.price < $7)
+  AND ($8::jsonb IS NULL OR p.specifications @> $8::jsonb)
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each($9::jsonb) AS f(key, docs)
+      WHERE NOT EXISTS (
+          SELECT 1 FROM jsonb_array_elements(f.docs) AS d(doc)
+          WHERE p.specifications @> d.doc
+      )
+  )
+ORDER BY
+    CASE WHEN $10::text = 'relevance' THEN ts_rank(p.search_vector, websearch_to_tsquery($1::text::regconfig, $2::text)) END DESC NULLS LAST,
+    CASE WHEN $10::text = 'price_asc' THEN p.price END ASC,
+    CASE WHEN $10::text = 'price_desc' THEN p.price END DESC,
+    CASE WHEN $10::text IN ('relevance', 'sales') THEN p.sales_count END DESC,
+    CASE WHEN $10::text = 'newest' THEN p.created_at END DESC,
+    p.id DESC
+LIMIT $12 OFFSET $11
+`
+
+type SearchProductIndexParams struct {
+	TsConfig     string   `db:"ts_config" json:"ts_config"`
+	Query        *string  `db:"query" json:"query"`
+	CategoryID   *int64   `db:"category_id" json:"category_id"`
+	Status       *string  `db:"status" json:"status"`
+	Brands       []string `db:"brands" json:"brands"`
+	MinPrice     *int64   `db:"min_price" json:"min_price"`
+	MaxPrice     *int64   `db:"max_price" json:"max_price"`
+	SpecContains []byte   `db:"spec_contains" json:"spec_contains"`
+	SpecFilters  []byte   `db:"spec_filters" json:"spec_filters"`
+	Sort         string   `db:"sort" json:"sort"`
+	PageOffset   int32    `db:"page_offset" json:"page_offset"`
+	PageLimit    int32    `db:"page_limit" json:"page_limit"`
+}
+
+type SearchProductIndexRow struct {
+	ID    int64   `db:"id" json:"id"`
+	Score float32 `db:"score" json:"score"`
+}
+
+// Postgres search index (see internal/search.PostgresIndex)
+// query is pre-segmented by the application and parsed with websearch syntax; NULL lists without text matching.
+// spec_filters maps each attribute to the candidate documents it may contain (OR within, AND across);
+// spec_contains merges single-valued attributes so the GIN index can narrow the scan.
+func (q *Queries) SearchProductIndex(ctx context.Context, arg SearchProductIndexParams) ([]SearchProductIndexRow, error) {
+	rows, err := q.db.Query(ctx, searchProductIndex,
+		arg.TsConfig,
+		arg.Query,
+		arg.CategoryID,
+		arg.Status,
+		arg.Brands,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.SpecContains,
+		arg.SpecFilters,
+		arg.Sort,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchProductIndexRow{}
+	for rows.Next() {
+		var i SearchProductIndexRow
+		if err := rows.Scan(&i.ID, &i.Score); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,267 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: stock_alert.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"gomall/utils/types"
+)
+
+const countActiveStockAlerts = `-- name: CountActiveStockAlerts :one
+SELECT COUNT(*) FROM stock_alerts
+WHERE status = 'active'
+`
+
+func (q *Queries) CountActiveStockAlerts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveStockAlerts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countOutOfStockAlerts = `-- name: CountOutOfStockAlerts :one
+SELECT COUNT(*) FROM stock_alerts
+WHERE status = 'active' AND available_stock = 0
+`
+
+func (q *Queries) CountOutOfStockAlerts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countOutOfStockAlerts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countResolvedStockAlertsSince = `-- name: CountResolvedStockAlertsSince :one
+SELECT COUNT(*) FROM stock_alerts
+WHERE status = 'resolved' AND resolved_at >= $1::timestamptz
+`
+
+func (q *Queries) CountResolvedStockAlertsSince(ctx context.Context, since time.Time) (int64, error) {
+	row := q.db.QueryRow(ctx, countResolvedStockAlertsSince, since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createStockAlert = `-- name: CreateStockAlert :one
+
+INSERT INTO stock_alerts (
+    product_id,
+    sku_id,
+    available_stock,
+    threshold
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (product_id, sku_id) WHERE status = 'active' DO NOTHING
+RETURNING id, product_id, available_stock, threshold, status, notified_at, resolved_at, created_at, updated_at, sku_id
+`
+
+type CreateStockAlertParams struct {
+	ProductID      int64  `db:"product_id" json:"product_id"`
+	SkuID          *int64 `db:"sku_id" json:"sku_id"`
+	AvailableStock int32  `db:"available_stock" json:"available_stock"`
+	Threshold      int32  `db:"threshold" json:"threshold"`
+}
+
+// Stock Alerts Queries
+func (q *Queries) CreateStockAlert(ctx context.Context, arg CreateStockAlertParams) (StockAlert, error) {
+	row := q.db.QueryRow(ctx, createStockAlert,
+		arg.ProductID,
+		arg.SkuID,
+		arg.AvailableStock,
+		arg.Threshold,
+	)
+	var i StockAlert
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.AvailableStock,
+		&i.Threshold,
+		&i.Status,
+		&i.NotifiedAt,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SkuID,
+	)
+	return i, err
+}
+
+const getActiveStockAlert = `-- name: GetActiveStockAlert :one
+SELECT sa.id, sa.product_id, sa.available_stock, sa.threshold, sa.status, sa.notified_at, sa.resolved_at, sa.created_at, sa.updated_at, sa.sku_id, p.name AS product_name, s.sku_code
+FROM stock_alerts sa
+JOIN products p ON p.id = sa.product_id
+LEFT JOIN product_skus s ON s.id = sa.sku_id
+WHERE sa.product_id = $1
+    AND sa.sku_id IS NOT DISTINCT FROM $2
+    AND sa.status = 'active'
+`
+
+type GetActiveStockAlertParams struct {
+	ProductID int64  `db:"product_id" json:"product_id"`
+	SkuID     *int64 `db:"sku_id" json:"sku_id"`
+}
+
+type GetActiveStockAlertRow struct {
+	ID             int64          `db:"id" json:"id"`
+	ProductID      int64          `db:"product_id" json:"product_id"`
+	AvailableStock int32          `db:"available_stock" json:"available_stock"`
+	Threshold      int32          `db:"threshold" json:"threshold"`
+	Status         string         `db:"status" json:"status"`
+	NotifiedAt     types.NullTime `db:"notified_at" json:"notified_at"`
+	ResolvedAt     types.NullTime `db:"resolved_at" json:"resolved_at"`
+	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at" json:"updated_at"`
+	SkuID          *int64         `db:"sku_id" json:"sku_id"`
+	ProductName    string         `db:"product_name" json:"product_name"`
+	SkuCode        *string        `db:"sku_code" json:"sku_code"`
+}
+
+func (q *Queries) GetActiveStockAlert(ctx context.Context, arg GetActiveStockAlertParams) (GetActiveStockAlertRow, error) {
+	row := q.db.QueryRow(ctx, getActiveStockAlert, arg.ProductID, arg.SkuID)
+	var i GetActiveStockAlertRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.AvailableStock,
+		&i.Threshold,
+		&i.Status,
+		&i.NotifiedAt,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SkuID,
+		&i.ProductName,
+		&i.SkuCode,
+	)
+	return i, err
+}
+
+const listActiveStockAlerts = `-- name: ListActiveStockAlerts :many
+SELECT
+    sa.id,
+    sa.product_id,
+    p.name AS product_name,
+    sa.sku_id,
+    s.sku_code,
+    sa.available_stock,
+    sa.threshold,
+    sa.notified_at,
+    sa.created_at
+FROM stock_alerts sa
+JOIN products p ON p.id = sa.product_id
+LEFT JOIN product_skus s ON s.id = sa.sku_id
+WHERE sa.status = 'active'
+ORDER BY sa.available_stock ASC, sa.created_at ASC
+LIMIT $1 OFFSET $2
+`
+
+type ListActiveStockAlertsParams struct {
+	Limit  int32 `db:"limit" json:"limit"`
+	Offset int32 `db:"offset" json:"offset"`
+}
+
+type ListActiveStockAlertsRow struct {
+	ID             int64          `db:"id" json:"id"`
+	ProductID      int64          `db:"product_id" json:"product_id"`
+	ProductName    string         `db:"product_name" json:"product_name"`
+	SkuID          *int64         `db:"sku_id" json:"sku_id"`
+	SkuCode        *string        `db:"sku_code" json:"sku_code"`
+	AvailableStock int32          `db:"available_stock" json:"available_stock"`
+	Threshold      int32          `db:"threshold" json:"threshold"`
+	NotifiedAt     types.NullTime `db:"notified_at" json:"notified_at"`
+	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
+}
+
+func (q *Queries) ListActiveStockAlerts(ctx context.Context, arg ListActiveStockAlertsParams) ([]ListActiveStockAlertsRow, error) {
+	rows, err := q.db.Query(ctx, listActiveStockAlerts, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListActiveStockAlertsRow{}
+	for rows.Next() {
+		var i ListActiveStockAlertsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.SkuID,
+			&i.SkuCode,
+			&i.AvailableStock,
+			&i.Threshold,
+			&i.NotifiedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markStockAlertNotified = `-- name: MarkStockAlertNotified :exec
+UPDATE stock_alerts
+SET
+    notified_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkStockAlertNotified(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, markStockAlertNotified, id)
+	return err
+}
+
+const resolveStockAlerts = `-- name: ResolveStockAlerts :exec
+UPDATE stock_alerts
+SET
+    status = 'resolved',
+    available_stock = $1,
+    resolved_at = NOW(),
+    updated_at = NOW()
+WHERE product_id = $2
+    AND sku_id IS NOT DISTINCT FROM $3
+    AND status = 'active'
+`
+
+type ResolveStockAlertsParams struct {
+	AvailableStock int32  `db:"available_stock" json:"available_stock"`
+	ProductID      int64  `db:"product_id" json:"product_id"`
+	SkuID          *int64 `db:"sku_id" json:"sku_id"`
+}
+
+func (q *Queries) ResolveStockAlerts(ctx context.Context, arg ResolveStockAlertsParams) error {
+	_, err := q.db.Exec(ctx, resolveStockAlerts, arg.AvailableStock, arg.ProductID, arg.SkuID)
+	return err
+}
+
+const updateStockAlertLevel = `-- name: UpdateStockAlertLevel :exec
+UPDATE stock_alerts
+SET
+    available_stock = $1,
+    updated_at = NOW()
+WHERE product_id = $2
+    AND sku_id IS NOT DISTINCT FROM $3
+    AND status = 'active'
+`
+
+type UpdateStockAlertLevelParams struct {
+	AvailableStock int32  `db:"available_stock" json:"available_stock"`
+	ProductID      int64  `db:"product_id" json:"product_id"`
+	SkuID          *int64 `db:"sku_id" json:"sku_id"`
+}
+
+func (q *Queries) UpdateStockAlertLevel(ctx context.Context, arg UpdateStockAlertLevelParams) error {
+	_, err := q.db.Exec(ctx, updateStockAlertLevel, arg.AvailableStock, arg.ProductID, arg.SkuID)
+	return err
+}
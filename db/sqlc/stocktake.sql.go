@@ -0,0 +1,343 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: stocktake.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"gomall/utils/types"
+)
+
+const addStocktakeItemsForCategory = `-- name: AddStocktakeItemsForCategory :execrows
+INSERT INTO stocktake_items (stocktake_id, product_id, sku_id, expected_quantity)
+SELECT $1, i.product_id, i.sku_id, i.available_stock + i.reserved_stock
+FROM inventory i
+JOIN products p ON p.id = i.product_id
+WHERE p.category_id = $2 AND p.deleted_at IS NULL AND i.deleted_at IS NULL
+ON CONFLICT (stocktake_id, product_id, sku_id) DO NOTHING
+`
+
+type AddStocktakeItemsForCategoryParams struct {
+	StocktakeID int64 `db:"stocktake_id" json:"stocktake_id"`
+	CategoryID  int64 `db:"category_id" json:"category_id"`
+}
+
+func (q *Queries) AddStocktakeItemsForCategory(ctx context.Context, arg AddStocktakeItemsForCategoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addStocktakeItemsForCategory, arg.StocktakeID, arg.CategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addStocktakeItemsForProducts = `-- name: AddStocktakeItemsForProducts :execrows
+
+INSERT INTO stocktake_items (stocktake_id, product_id, sku_id, expected_quantity)
+SELECT $1, i.product_id, i.sku_id, i.available_stock + i.reserved_stock
+FROM inventory i
+WHERE i.product_id = ANY($2::bigint[]) AND i.deleted_at IS NULL
+ON CONFLICT (stocktake_id, product_id, sku_id) DO NOTHING
+`
+
+type AddStocktakeItemsForProductsParams struct {
+	StocktakeID int64   `db:"stocktake_id" json:"stocktake_id"`
+	ProductIds  []int64 `db:"product_ids" json:"product_ids"`
+}
+
+// Stocktake Items Queries
+// One item per inventory row: the product-level row, or each SKU's row for products with variants
+func (q *Queries) AddStocktakeItemsForProducts(ctx context.Context, arg AddStocktakeItemsForProductsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addStocktakeItemsForProducts, arg.StocktakeID, arg.ProductIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const approveStocktake = `-- name: ApproveStocktake :execrows
+UPDATE stocktakes
+SET
+    status = 'approved',
+    approved_by = $1,
+    approved_at = NOW(),
+    updated_at = NOW()
+WHERE id = $2 AND status = 'open'
+`
+
+type ApproveStocktakeParams struct {
+	ApprovedBy *int64 `db:"approved_by" json:"approved_by"`
+	ID         int64  `db:"id" json:"id"`
+}
+
+func (q *Queries) ApproveStocktake(ctx context.Context, arg ApproveStocktakeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, approveStocktake, arg.ApprovedBy, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const cancelStocktake = `-- name: CancelStocktake :execrows
+UPDATE stocktakes
+SET
+    status = 'cancelled',
+    updated_at = NOW()
+WHERE id = $1 AND status = 'open'
+`
+
+func (q *Queries) CancelStocktake(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelStocktake, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countStocktakes = `-- name: CountStocktakes :one
+SELECT COUNT(*) FROM stocktakes
+WHERE $1::varchar IS NULL OR status = $1::varchar
+`
+
+func (q *Queries) CountStocktakes(ctx context.Context, status *string) (int64, error) {
+	row := q.db.QueryRow(ctx, countStocktakes, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUncountedStocktakeItems = `-- name: CountUncountedStocktakeItems :one
+SELECT COUNT(*) FROM stocktake_items
+WHERE stocktake_id = $1 AND counted_quantity IS NULL
+`
+
+func (q *Queries) CountUncountedStocktakeItems(ctx context.Context, stocktakeID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countUncountedStocktakeItems, stocktakeID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createStocktake = `-- name: CreateStocktake :one
+
+INSERT INTO stocktakes (
+    category_id,
+    note,
+    created_by
+) VALUES (
+    $1, $2, $3
+) RETURNING id, status, category_id, note, created_by, approved_by, approved_at, created_at, updated_at
+`
+
+type CreateStocktakeParams struct {
+	CategoryID *int64  `db:"category_id" json:"category_id"`
+	Note       *string `db:"note" json:"note"`
+	CreatedBy  *int64  `db:"created_by" json:"created_by"`
+}
+
+// Stocktake Queries
+func (q *Queries) CreateStocktake(ctx context.Context, arg CreateStocktakeParams) (Stocktake, error) {
+	row := q.db.QueryRow(ctx, createStocktake, arg.CategoryID, arg.Note, arg.CreatedBy)
+	var i Stocktake
+	err := row.Scan(
+		&i.ID,
+		&i.Status,
+		&i.CategoryID,
+		&i.Note,
+		&i.CreatedBy,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStocktake = `-- name: GetStocktake :one
+SELECT id, status, category_id, note, created_by, approved_by, approved_at, created_at, updated_at FROM stocktakes
+WHERE id = $1
+`
+
+func (q *Queries) GetStocktake(ctx context.Context, id int64) (Stocktake, error) {
+	row := q.db.QueryRow(ctx, getStocktake, id)
+	var i Stocktake
+	err := row.Scan(
+		&i.ID,
+		&i.Status,
+		&i.CategoryID,
+		&i.Note,
+		&i.CreatedBy,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listStocktakeItems = `-- name: ListStocktakeItems :many
+SELECT
+    si.id, si.stocktake_id, si.product_id, si.expected_quantity, si.counted_quantity, si.counted_by, si.counted_at, si.variance, si.created_at, si.updated_at, si.sku_id,
+    p.name AS product_name,
+    s.sku_code,
+    i.available_stock,
+    i.reserved_stock
+FROM stocktake_items si
+JOIN products p ON p.id = si.product_id
+LEFT JOIN product_skus s ON s.id = si.sku_id
+JOIN inventory i ON i.product_id = si.product_id AND i.sku_id IS NOT DISTINCT FROM si.sku_id AND i.deleted_at IS NULL
+WHERE si.stocktake_id = $1
+ORDER BY si.product_id, si.sku_id NULLS FIRST
+`
+
+type ListStocktakeItemsRow struct {
+	ID               int64          `db:"id" json:"id"`
+	StocktakeID      int64          `db:"stocktake_id" json:"stocktake_id"`
+	ProductID        int64          `db:"product_id" json:"product_id"`
+	ExpectedQuantity int32          `db:"expected_quantity" json:"expected_quantity"`
+	CountedQuantity  *int32         `db:"counted_quantity" json:"counted_quantity"`
+	CountedBy        *int64         `db:"counted_by" json:"counted_by"`
+	CountedAt        types.NullTime `db:"counted_at" json:"counted_at"`
+	Variance         *int32         `db:"variance" json:"variance"`
+	CreatedAt        time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time      `db:"updated_at" json:"updated_at"`
+	SkuID            *int64         `db:"sku_id" json:"sku_id"`
+	ProductName      string         `db:"product_name" json:"product_name"`
+	SkuCode          *string        `db:"sku_code" json:"sku_code"`
+	AvailableStock   int32          `db:"available_stock" json:"available_stock"`
+	ReservedStock    int32          `db:"reserved_stock" json:"reserved_stock"`
+}
+
+func (q *Queries) ListStocktakeItems(ctx context.Context, stocktakeID int64) ([]ListStocktakeItemsRow, error) {
+	rows, err := q.db.Query(ctx, listStocktakeItems, stocktakeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStocktakeItemsRow{}
+	for rows.Next() {
+		var i ListStocktakeItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StocktakeID,
+			&i.ProductID,
+			&i.ExpectedQuantity,
+			&i.CountedQuantity,
+			&i.CountedBy,
+			&i.CountedAt,
+			&i.Variance,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SkuID,
+			&i.ProductName,
+			&i.SkuCode,
+			&i.AvailableStock,
+			&i.ReservedStock,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStocktakes = `-- name: ListStocktakes :many
+SELECT id, status, category_id, note, created_by, approved_by, approved_at, created_at, updated_at FROM stocktakes
+WHERE $1::varchar IS NULL OR status = $1::varchar
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $2
+`
+
+type ListStocktakesParams struct {
+	Status      *string `db:"status" json:"status"`
+	OffsetCount int32   `db:"offset_count" json:"offset_count"`
+	LimitCount  int32   `db:"limit_count" json:"limit_count"`
+}
+
+func (q *Queries) ListStocktakes(ctx context.Context, arg ListStocktakesParams) ([]Stocktake, error) {
+	rows, err := q.db.Query(ctx, listStocktakes, arg.Status, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Stocktake{}
+	for rows.Next() {
+		var i Stocktake
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.CategoryID,
+			&i.Note,
+			&i.CreatedBy,
+			&i.ApprovedBy,
+			&i.ApprovedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordStocktakeCount = `-- name: RecordStocktakeCount :execrows
+UPDATE stocktake_items
+SET
+    counted_quantity = $1,
+    counted_by = $2,
+    counted_at = NOW(),
+    updated_at = NOW()
+WHERE stocktake_id = $3
+    AND product_id = $4
+    AND sku_id IS NOT DISTINCT FROM $5
+`
+
+type RecordStocktakeCountParams struct {
+	CountedQuantity *int32 `db:"counted_quantity" json:"counted_quantity"`
+	CountedBy       *int64 `db:"counted_by" json:"counted_by"`
+	StocktakeID     int64  `db:"stocktake_id" json:"stocktake_id"`
+	ProductID       int64  `db:"product_id" json:"product_id"`
+	SkuID           *int64 `db:"sku_id" json:"sku_id"`
+}
+
+func (q *Queries) RecordStocktakeCount(ctx context.Context, arg RecordStocktakeCountParams) (int64, error) {
+	result, err := q.db.Exec(ctx, recordStocktakeCount,
+		arg.CountedQuantity,
+		arg.CountedBy,
+		arg.StocktakeID,
+		arg.ProductID,
+		arg.SkuID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setStocktakeItemVariance = `-- name: SetStocktakeItemVariance :exec
+UPDATE stocktake_items
+SET
+    variance = $1,
+    updated_at = NOW()
+WHERE id = $2
+`
+
+type SetStocktakeItemVarianceParams struct {
+	Variance *int32 `db:"variance" json:"variance"`
+	ID       int64  `db:"id" json:"id"`
+}
+
+func (q *Queries) SetStocktakeItemVariance(ctx context.Context, arg SetStocktakeItemVarianceParams) error {
+	_, err := q.db.Exec(ctx, setStocktakeItemVariance, arg.Variance, arg.ID)
+	return err
+}
@@ -29,7 +29,7 @@ INSERT INTO users (
 ) VALUES (
              $1, $2, $3, $4, $5, $6, $7
          )
-    RETURNING id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at
+    RETURNING id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at, role
 `
 
 type CreateUserParams struct {
@@ -72,6 +72,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
 	)
 	return i, err
 }
@@ -88,7 +89,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at FROM users
+SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at, role FROM users
 WHERE email = $1 AND deleted_at IS NULL
     LIMIT 1
 `
@@ -115,12 +116,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at FROM users
+SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at, role FROM users
 WHERE id = $1 AND deleted_at IS NULL
     LIMIT 1
 `
@@ -147,12 +149,13 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByPhone = `-- name: GetUserByPhone :one
-SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at FROM users
+SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at, role FROM users
 WHERE phone = $1 AND deleted_at IS NULL
     LIMIT 1
 `
@@ -179,12 +182,13 @@ func (q *Queries) GetUserByPhone(ctx context.Context, phone *string) (User, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at FROM users
+SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at, role FROM users
 WHERE username = $1 AND deleted_at IS NULL
     LIMIT 1
 `
@@ -211,12 +215,13 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at FROM users
+SELECT id, username, email, phone, password, nickname, avatar, gender, birthday, status, is_email_verified, is_phone_verified, last_login_at, last_login_ip, password_changed_at, created_at, updated_at, deleted_at, role FROM users
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
     LIMIT $1 OFFSET $2
@@ -255,6 +260,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
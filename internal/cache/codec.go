@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec converts values to and from the bytes stored in the cache
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	// JSONCodec stores values as JSON, readable with redis-cli
+	JSONCodec Codec = jsonCodec{}
+	// MsgpackCodec stores values as MessagePack, smaller and faster to decode than JSON
+	MsgpackCodec Codec = msgpackCodec{}
+)
+
+// CodecByName returns the codec configured as "json" or "msgpack"; anything else falls back to JSON
+func CodecByName(name string) Codec {
+	if name == "msgpack" {
+		return MsgpackCodec
+	}
+	return JSONCodec
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// msgpackCodec honours json struct tags, so DTOs need no msgpack tags of their own
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type codecSample struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Tags      []string  `json:"tags"`
+	Note      *string   `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	in := codecSample{ID: 7, Name: "mug", Tags: []string{"kitchen"}, CreatedAt: time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)}
+
+	for _, codec := range []Codec{JSONCodec, MsgpackCodec} {
+		data, err := codec.Marshal(in)
+		require.NoError(t, err)
+		require.NotEqual(t, notFoundMarker, string(data[:1]))
+
+		var out codecSample
+		require.NoError(t, codec.Unmarshal(data, &out))
+		require.Equal(t, in.ID, out.ID)
+		require.Equal(t, in.Name, out.Name)
+		require.Equal(t, in.Tags, out.Tags)
+		require.Nil(t, out.Note)
+		require.True(t, in.CreatedAt.Equal(out.CreatedAt))
+	}
+}
+
+func TestMsgpackCodecUsesJSONTags(t *testing.T) {
+	data, err := MsgpackCodec.Marshal(codecSample{Name: "mug"})
+	require.NoError(t, err)
+	require.Contains(t, string(data), "created_at")
+	require.NotContains(t, string(data), "CreatedAt")
+}
+
+func TestCodecByName(t *testing.T) {
+	require.Equal(t, MsgpackCodec, CodecByName("msgpack"))
+	require.Equal(t, JSONCodec, CodecByName("json"))
+	require.Equal(t, JSONCodec, CodecByName(""))
+}
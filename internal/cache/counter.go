@@ -0,0 +1,12 @@
+package cache
+
+import "context"
+
+// CounterStore is implemented by caches that can drain a group of counters atomically.
+// Counters are added with IncrBy and listed in an index hash with HSet(index, member, counterKey);
+// incrementing before listing ensures a drain never misses a counted increment.
+type CounterStore interface {
+	// DrainCounters returns the value of every counter listed in the index hash by member,
+	// deleting the counters and the index in the same step
+	DrainCounters(ctx context.Context, indexKey string) (map[string]int64, error)
+}
@@ -1,6 +1,9 @@
 package cache
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Keys provides centralized cache key generation
 type Keys struct{}
@@ -12,16 +15,40 @@ func (k Keys) Product(id int64) string {
 	return fmt.Sprintf("product:%d", id)
 }
 
-func (k Keys) ProductList(page, pageSize int) string {
-	return fmt.Sprintf("product:list:%d:%d", page, pageSize)
+// ProductListVersion holds the generation stamp embedded in all product list keys;
+// changing it invalidates every cached list page at once
+func (k Keys) ProductListVersion() string {
+	return "product:list:version"
 }
 
-func (k Keys) ProductsByCategory(categoryID int64, page, pageSize int) string {
-	return fmt.Sprintf("product:category:%d:%d:%d", categoryID, page, pageSize)
+func (k Keys) ProductList(generation int64, queryHash string) string {
+	return fmt.Sprintf("product:list:%d:%s", generation, queryHash)
 }
 
-func (k Keys) HotProducts(limit int) string {
-	return fmt.Sprintf("product:hot:%d", limit)
+func (k Keys) ProductsByCategory(generation, categoryID int64, page, pageSize int) string {
+	return fmt.Sprintf("product:category:%d:%d:%d:%d", generation, categoryID, page, pageSize)
+}
+
+func (k Keys) FeaturedProducts(generation int64, page, pageSize int) string {
+	return fmt.Sprintf("product:featured:%d:%d:%d", generation, page, pageSize)
+}
+
+func (k Keys) HotProducts(generation int64, window time.Duration, categoryID int64, limit int) string {
+	return fmt.Sprintf("product:hot:%d:%s:%d:%d", generation, window, categoryID, limit)
+}
+
+// HotProductRanking is the time-decayed popularity ranking for one window; category 0 ranks every product
+func (k Keys) HotProductRanking(window time.Duration, categoryID int64) string {
+	return fmt.Sprintf("ranking:product:hot:%s:%d", window, categoryID)
+}
+
+// Search keys
+func (k Keys) SearchSuggest(prefix string, limit int) string {
+	return fmt.Sprintf("search:suggest:%d:%s", limit, prefix)
+}
+
+func (k Keys) SearchSuggestHits(prefix string) string {
+	return fmt.Sprintf("search:suggest:hits:%s", prefix)
 }
 
 // Inventory/Stock keys
@@ -41,6 +68,11 @@ func (k Keys) LowStockProducts(page, pageSize int) string {
 	return fmt.Sprintf("stock:low:%d:%d", page, pageSize)
 }
 
+// LowStockAlert de-duplicates a day's alert for one stock unit; skuID is 0 for the product-level row
+func (k Keys) LowStockAlert(productID, skuID int64, date string) string {
+	return fmt.Sprintf("alert:lowstock:%d:%d:%s", productID, skuID, date)
+}
+
 // User keys
 func (k Keys) User(id int64) string {
 	return fmt.Sprintf("user:%d", id)
@@ -54,13 +86,29 @@ func (k Keys) UserCart(userID int64) string {
 	return fmt.Sprintf("cart:user:%d", userID)
 }
 
+// UserRecentlyViewed lists the products a signed-in user opened, most recent first
+func (k Keys) UserRecentlyViewed(userID int64) string {
+	return fmt.Sprintf("recent:products:user:%d", userID)
+}
+
+// GuestRecentlyViewed is the same list for an anonymous visitor; it is merged into the user's on login
+func (k Keys) GuestRecentlyViewed(guestID string) string {
+	return fmt.Sprintf("recent:products:guest:%s", guestID)
+}
+
 // Category keys
 func (k Keys) Category(id int64) string {
 	return fmt.Sprintf("category:%d", id)
 }
 
-func (k Keys) CategoryList() string {
-	return "category:list"
+// CategoryListVersion holds the generation stamp embedded in all category list keys
+func (k Keys) CategoryListVersion() string {
+	return "category:list:version"
+}
+
+// CategoryList caches one view of the category list, e.g. "active", "tree" or "children:3"
+func (k Keys) CategoryList(generation int64, view string) string {
+	return fmt.Sprintf("category:list:%d:%s", generation, view)
 }
 
 // Order keys
@@ -99,6 +147,16 @@ func (k Keys) ProductViewCount(productID int64) string {
 	return fmt.Sprintf("counter:product:view:%d", productID)
 }
 
+// ProductViewPending indexes the view counters waiting to be flushed to the database
+func (k Keys) ProductViewPending() string {
+	return "counter:product:view:pending"
+}
+
+// ProductViewSeen marks that a visitor's view of a product was already counted
+func (k Keys) ProductViewSeen(productID int64, visitor string) string {
+	return fmt.Sprintf("counter:product:view:seen:%d:%s", productID, visitor)
+}
+
 func (k Keys) DailyOrderCount(date string) string {
 	return fmt.Sprintf("counter:order:daily:%s", date)
 }
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrLockHeld is returned by Acquire when another holder owns the lock
+	ErrLockHeld = errors.New("lock is held by another owner")
+	// ErrLockNotHeld is returned when a lock expired or was taken over before Release or Refresh
+	ErrLockNotHeld = errors.New("lock is no longer held")
+)
+
+// LockStore is implemented by caches that can store locks atomically.
+// Each call must check and change the lock in one step, so two owners can never both succeed.
+type LockStore interface {
+	// AcquireLock sets key to token for ttl if key is unset, returning the next fencing token
+	AcquireLock(ctx context.Context, key, token string, ttl time.Duration) (fence int64, ok bool, err error)
+	// ReleaseLock deletes key if it still holds token
+	ReleaseLock(ctx context.Context, key, token string) (bool, error)
+	// RefreshLock resets the ttl of key if it still holds token
+	RefreshLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+}
+
+// lockFenceKey holds the counter behind a lock's fencing tokens; it never expires, so tokens keep increasing
+func lockFenceKey(key string) string {
+	return key + ":fence"
+}
+
+// Locker hands out distributed locks stored in a cache
+type Locker struct {
+	store LockStore
+}
+
+// NewLocker creates a Locker on top of c, which must implement LockStore
+func NewLocker(c Cache) (*Locker, error) {
+	store, ok := c.(LockStore)
+	if !ok {
+		return nil, errors.New("cache does not support locks")
+	}
+	return &Locker{store: store}, nil
+}
+
+// Lock is a held lock. While held it is renewed every third of its ttl;
+// if a renewal finds the lock taken over, Lost is closed.
+type Lock struct {
+	store LockStore
+	key   string
+	token string
+	fence int64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	renewed  chan struct{} // closed when the renewal loop exits
+	lost     chan struct{}
+}
+
+// Acquire takes the lock under key for ttl, or returns ErrLockHeld without waiting.
+// Keys come from CacheKeys.Lock and friends.
+func (l *Locker) Acquire(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if ttl < time.Millisecond {
+		return nil, errors.New("lock ttl must be at least 1ms")
+	}
+
+	token := uuid.NewString()
+	fence, ok, err := l.store.AcquireLock(ctx, key, token, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if !ok {
+		return nil, ErrLockHeld
+	}
+
+	lock := &Lock{
+		store:   l.store,
+		key:     key,
+		token:   token,
+		fence:   fence,
+		stop:    make(chan struct{}),
+		renewed: make(chan struct{}),
+		lost:    make(chan struct{}),
+	}
+	go lock.renew(ttl)
+	return lock, nil
+}
+
+// WithLock runs fn while holding the lock under key, returning ErrLockHeld if it is taken.
+// fn's context is cancelled if the lock is lost, so work can stop before another owner starts.
+func (l *Locker) WithLock(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	lock, err := l.Acquire(ctx, key, ttl)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil && !errors.Is(err, ErrLockNotHeld) {
+			log.Printf("failed to release lock %s: %v", key, err)
+		}
+	}()
+
+	fnCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-lock.Lost():
+			cancel()
+		case <-fnCtx.Done():
+		}
+	}()
+
+	return fn(fnCtx)
+}
+
+// Fence returns the fencing token: it increases with every acquisition of the same key, so storage
+// that records the highest token seen can reject writes from an owner whose lock has since expired
+func (lock *Lock) Fence() int64 {
+	return lock.fence
+}
+
+// Lost is closed when renewal finds that the lock expired or was taken over
+func (lock *Lock) Lost() <-chan struct{} {
+	return lock.lost
+}
+
+// Refresh resets the lock's ttl; renewal does this automatically while the lock is held
+func (lock *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	ok, err := lock.store.RefreshLock(ctx, lock.key, lock.token, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to refresh lock %s: %w", lock.key, err)
+	}
+	if !ok {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Release stops renewal and frees the lock if this owner still holds it
+func (lock *Lock) Release(ctx context.Context) error {
+	lock.stopOnce.Do(func() { close(lock.stop) })
+	<-lock.renewed
+
+	ok, err := lock.store.ReleaseLock(ctx, lock.key, lock.token)
+	if err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", lock.key, err)
+	}
+	if !ok {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// renew refreshes the lock until it is released or lost. A refresh that fails for other reasons
+// is retried on the next tick; the lock counts as lost once its last successful ttl has run out.
+func (lock *Lock) renew(ttl time.Duration) {
+	defer close(lock.renewed)
+
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	heldUntil := time.Now().Add(ttl)
+	for {
+		select {
+		case <-lock.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), ttl/3)
+			refreshedAt := time.Now()
+			err := lock.Refresh(ctx, ttl)
+			cancel()
+
+			switch {
+			case err == nil:
+				heldUntil = refreshedAt.Add(ttl)
+			case errors.Is(err, ErrLockNotHeld) || time.Now().After(heldUntil):
+				close(lock.lost)
+				return
+			default:
+				log.Printf("%v", err)
+			}
+		}
+	}
+}
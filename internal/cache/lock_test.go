@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocker(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryCache(MemoryConfig{})
+	locker, err := NewLocker(store)
+	require.NoError(t, err)
+
+	key := CacheKeys.Lock("job")
+	lock, err := locker.Acquire(ctx, key, time.Minute)
+	require.NoError(t, err)
+
+	_, err = locker.Acquire(ctx, key, time.Minute)
+	require.ErrorIs(t, err, ErrLockHeld)
+
+	require.NoError(t, lock.Refresh(ctx, time.Minute))
+	require.NoError(t, lock.Release(ctx))
+	require.ErrorIs(t, lock.Release(ctx), ErrLockNotHeld)
+
+	next, err := locker.Acquire(ctx, key, time.Minute)
+	require.NoError(t, err)
+	require.Greater(t, next.Fence(), lock.Fence())
+
+	// A stale owner cannot release or extend the new owner's lock
+	require.ErrorIs(t, lock.Refresh(ctx, time.Minute), ErrLockNotHeld)
+	ok, err := store.ReleaseLock(ctx, key, lock.token)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.NoError(t, next.Release(ctx))
+}
+
+func TestLockRenewal(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryCache(MemoryConfig{})
+	locker, _ := NewLocker(store)
+
+	key := CacheKeys.Lock("renewed")
+	lock, err := locker.Acquire(ctx, key, 30*time.Millisecond)
+	require.NoError(t, err)
+
+	// Held well past its ttl thanks to renewal
+	time.Sleep(100 * time.Millisecond)
+	_, err = locker.Acquire(ctx, key, time.Minute)
+	require.ErrorIs(t, err, ErrLockHeld)
+
+	// Taken over behind its back: renewal notices
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = locker.Acquire(ctx, key, time.Minute)
+	require.NoError(t, err)
+	select {
+	case <-lock.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("lock loss not detected")
+	}
+}
+
+func TestWithLock(t *testing.T) {
+	ctx := context.Background()
+	locker, _ := NewLocker(NewMemoryCache(MemoryConfig{}))
+	key := CacheKeys.Lock("bulk")
+
+	errDone := errors.New("done")
+	err := locker.WithLock(ctx, key, time.Minute, func(ctx context.Context) error {
+		require.ErrorIs(t, locker.WithLock(ctx, key, time.Minute, func(context.Context) error { return nil }), ErrLockHeld)
+		return errDone
+	})
+	require.ErrorIs(t, err, errDone)
+
+	// Released afterwards
+	require.NoError(t, locker.WithLock(ctx, key, time.Minute, func(context.Context) error { return nil }))
+}
@@ -0,0 +1,814 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errWrongType mirrors Redis' WRONGTYPE error for operations on a key holding the other kind of value
+var errWrongType = errors.New("operation against a key holding the wrong kind of value")
+
+// MemoryConfig bounds an in-memory cache; zero means unbounded
+type MemoryConfig struct {
+	MaxEntries int
+	MaxBytes   int64 // approximate: keys, values and hash fields are counted, bookkeeping is not
+}
+
+// MemoryCache is an in-process Cache with LRU eviction. It follows Redis semantics closely
+// enough to stand in for it in tests: missing keys are errors, counters are decimal strings,
+// and TTL reports -2 for a missing key and -1 for a key without expiry.
+// Expired entries are dropped when touched or when they reach the end of the LRU list.
+type MemoryCache struct {
+	cfg MemoryConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front is most recently used
+	bytes   int64
+
+	subsMu sync.RWMutex
+	subs   map[string]map[*memorySubscriber]struct{}
+}
+
+type memoryEntry struct {
+	key       string
+	value     string
+	hash      map[string]string // non-nil for hash keys
+	expiresAt time.Time         // zero means no expiry
+	size      int64
+}
+
+type memorySubscriber struct {
+	ch   chan string
+	done <-chan struct{}
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache(cfg MemoryConfig) *MemoryCache {
+	return &MemoryCache{
+		cfg:     cfg,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		subs:    make(map[string]map[*memorySubscriber]struct{}),
+	}
+}
+
+// Len returns the number of stored keys, including expired ones not yet dropped
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// Get retrieves a value from cache
+func (m *MemoryCache) Get(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil {
+		return "", fmt.Errorf("key not found: %s", key)
+	}
+	if entry.hash != nil {
+		return "", errWrongType
+	}
+	return entry.value, nil
+}
+
+// Set stores a value in cache with expiration
+func (m *MemoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := encodeValue(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store(&memoryEntry{key: key, value: data, expiresAt: m.expiry(expiration)})
+	return nil
+}
+
+// SetNX stores a value with expiration only if key does not exist
+func (m *MemoryCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	data, err := encodeValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.lookup(key) != nil {
+		return false, nil
+	}
+	m.store(&memoryEntry{key: key, value: data, expiresAt: m.expiry(expiration)})
+	return true, nil
+}
+
+// Delete removes a key from cache
+func (m *MemoryCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		m.remove(elem)
+	}
+	return nil
+}
+
+// Exists checks if a key exists
+func (m *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lookup(key) != nil, nil
+}
+
+// MGet retrieves multiple values; missing keys and hashes yield empty strings
+func (m *MemoryCache) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]string, len(keys))
+	for i, key := range keys {
+		if entry := m.lookup(key); entry != nil && entry.hash == nil {
+			result[i] = entry.value
+		}
+	}
+	return result, nil
+}
+
+// MSet sets multiple key-value pairs
+func (m *MemoryCache) MSet(ctx context.Context, kvs map[string]interface{}, expiration time.Duration) error {
+	encoded := make(map[string]string, len(kvs))
+	for key, value := range kvs {
+		data, err := encodeValue(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal value for key %s: %w", key, err)
+		}
+		encoded[key] = data
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expiresAt := m.expiry(expiration)
+	for key, data := range encoded {
+		m.store(&memoryEntry{key: key, value: data, expiresAt: expiresAt})
+	}
+	return nil
+}
+
+// HGet retrieves a field from a hash
+func (m *MemoryCache) HGet(ctx context.Context, key, field string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry != nil && entry.hash == nil {
+		return "", errWrongType
+	}
+	if entry == nil {
+		return "", fmt.Errorf("field not found: %s", field)
+	}
+	value, ok := entry.hash[field]
+	if !ok {
+		return "", fmt.Errorf("field not found: %s", field)
+	}
+	return value, nil
+}
+
+// HSet sets a field in a hash, keeping the hash's expiry
+func (m *MemoryCache) HSet(ctx context.Context, key, field string, value interface{}) error {
+	data, err := encodeValue(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry != nil && entry.hash == nil {
+		return errWrongType
+	}
+	updated := &memoryEntry{key: key, hash: map[string]string{field: data}}
+	if entry != nil {
+		for f, v := range entry.hash {
+			if f != field {
+				updated.hash[f] = v
+			}
+		}
+		updated.expiresAt = entry.expiresAt
+	}
+	m.store(updated)
+	return nil
+}
+
+// HGetAll retrieves all fields from a hash
+func (m *MemoryCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry != nil && entry.hash == nil {
+		return nil, errWrongType
+	}
+	result := make(map[string]string)
+	if entry != nil {
+		for field, value := range entry.hash {
+			result[field] = value
+		}
+	}
+	return result, nil
+}
+
+// Incr increments a counter
+func (m *MemoryCache) Incr(ctx context.Context, key string) (int64, error) {
+	return m.IncrBy(ctx, key, 1)
+}
+
+// Decr decrements a counter
+func (m *MemoryCache) Decr(ctx context.Context, key string) (int64, error) {
+	return m.IncrBy(ctx, key, -1)
+}
+
+// IncrBy increments a counter by value; a missing counter starts at zero and keeps no expiry
+func (m *MemoryCache) IncrBy(ctx context.Context, key string, value int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var current int64
+	var expiresAt time.Time
+	if entry := m.lookup(key); entry != nil {
+		if entry.hash != nil {
+			return 0, errWrongType
+		}
+		n, err := strconv.ParseInt(entry.value, 10, 64)
+		if err != nil {
+			return 0, errors.New("value is not an integer or out of range")
+		}
+		current, expiresAt = n, entry.expiresAt
+	}
+
+	current += value
+	m.store(&memoryEntry{key: key, value: strconv.FormatInt(current, 10), expiresAt: expiresAt})
+	return current, nil
+}
+
+// DecrBy decrements a counter by value
+func (m *MemoryCache) DecrBy(ctx context.Context, key string, value int64) (int64, error) {
+	return m.IncrBy(ctx, key, -value)
+}
+
+// Expire sets expiration on a key; expiring a missing key is a no-op
+func (m *MemoryCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil {
+		return nil
+	}
+	if expiration <= 0 {
+		m.remove(m.entries[key])
+		return nil
+	}
+	entry.expiresAt = m.now().Add(expiration)
+	return nil
+}
+
+// TTL gets time to live for a key
+func (m *MemoryCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	switch {
+	case entry == nil:
+		return -2, nil
+	case entry.expiresAt.IsZero():
+		return -1, nil
+	default:
+		return entry.expiresAt.Sub(m.now()), nil
+	}
+}
+
+// Publish delivers message to every current subscriber of channel
+func (m *MemoryCache) Publish(ctx context.Context, channel, message string) error {
+	m.subsMu.RLock()
+	defer m.subsMu.RUnlock()
+
+	for sub := range m.subs[channel] {
+		select {
+		case sub.ch <- message:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Subscribe delivers messages published to channel until ctx is cancelled, then closes the returned channel
+func (m *MemoryCache) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	sub := &memorySubscriber{ch: make(chan string, 64), done: ctx.Done()}
+
+	m.subsMu.Lock()
+	if m.subs[channel] == nil {
+		m.subs[channel] = make(map[*memorySubscriber]struct{})
+	}
+	m.subs[channel][sub] = struct{}{}
+	m.subsMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		// Publishers give up on this subscriber once ctx is done, so the lock is released promptly
+		m.subsMu.Lock()
+		delete(m.subs[channel], sub)
+		m.subsMu.Unlock()
+		close(sub.ch)
+	}()
+	return sub.ch, nil
+}
+
+// AcquireLock sets key to token for ttl if key is unset, returning the next fencing token.
+// Eviction can drop a lock or its fence counter, so bound the cache generously when it holds locks.
+func (m *MemoryCache) AcquireLock(ctx context.Context, key, token string, ttl time.Duration) (int64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.lookup(key) != nil {
+		return 0, false, nil
+	}
+	m.store(&memoryEntry{key: key, value: token, expiresAt: m.expiry(ttl)})
+
+	var fence int64
+	if entry := m.lookup(lockFenceKey(key)); entry != nil {
+		fence, _ = strconv.ParseInt(entry.value, 10, 64)
+	}
+	fence++
+	m.store(&memoryEntry{key: lockFenceKey(key), value: strconv.FormatInt(fence, 10)})
+	return fence, true, nil
+}
+
+// ReleaseLock deletes key if it still holds token
+func (m *MemoryCache) ReleaseLock(ctx context.Context, key, token string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil || entry.hash != nil || entry.value != token {
+		return false, nil
+	}
+	m.remove(m.entries[key])
+	return true, nil
+}
+
+// RefreshLock resets the ttl of key if it still holds token
+func (m *MemoryCache) RefreshLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil || entry.hash != nil || entry.value != token {
+		return false, nil
+	}
+	entry.expiresAt = m.expiry(ttl)
+	return true, nil
+}
+
+// AllowSlidingWindow counts a request against a sliding window kept as a list of request times
+func (m *MemoryCache) AllowSlidingWindow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var log []int64
+	if entry := m.lookup(key); entry != nil {
+		if entry.hash != nil {
+			return RateLimitResult{}, errWrongType
+		}
+		for _, field := range strings.Fields(entry.value) {
+			t, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return RateLimitResult{}, errWrongType
+			}
+			log = append(log, t)
+		}
+	}
+
+	log, result := slidingWindow(log, m.now().UnixMilli(), limit, window)
+	fields := make([]string, len(log))
+	for i, t := range log {
+		fields[i] = strconv.FormatInt(t, 10)
+	}
+	m.store(&memoryEntry{key: key, value: strings.Join(fields, " "), expiresAt: m.expiry(window)})
+	return result, nil
+}
+
+// AllowTokenBucket counts a request against a token bucket kept as a hash of tokens and last update time
+func (m *MemoryCache) AllowTokenBucket(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now().UnixMilli()
+	tokens, updatedAt := float64(limit), now
+	if entry := m.lookup(key); entry != nil {
+		if entry.hash == nil {
+			return RateLimitResult{}, errWrongType
+		}
+		tokens, _ = strconv.ParseFloat(entry.hash["tokens"], 64)
+		updatedAt, _ = strconv.ParseInt(entry.hash["ts"], 10, 64)
+	}
+
+	tokens, result := tokenBucket(tokens, updatedAt, now, limit, window)
+	m.store(&memoryEntry{
+		key:       key,
+		hash:      map[string]string{"tokens": strconv.FormatFloat(tokens, 'f', -1, 64), "ts": strconv.FormatInt(now, 10)},
+		expiresAt: m.expiry(max(result.Reset, time.Millisecond)),
+	})
+	return result, nil
+}
+
+// DrainCounters returns and deletes the counters listed in the index hash, and the index itself
+func (m *MemoryCache) DrainCounters(ctx context.Context, indexKey string) (map[string]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := make(map[string]int64)
+	index := m.lookup(indexKey)
+	if index == nil {
+		return counts, nil
+	}
+	if index.hash == nil {
+		return nil, errWrongType
+	}
+	m.remove(m.entries[indexKey])
+
+	for member, key := range index.hash {
+		entry := m.lookup(key)
+		if entry == nil || entry.hash != nil {
+			continue
+		}
+		m.remove(m.entries[key])
+		if n, err := strconv.ParseInt(entry.value, 10, 64); err == nil {
+			counts[member] = n
+		}
+	}
+	return counts, nil
+}
+
+// AddToRankings adds weight to member in each of the rankings that exist; rankings are hashes of member to score
+func (m *MemoryCache) AddToRankings(ctx context.Context, keys []string, member string, weight float64, halfLife time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now().UnixMilli()
+	for _, key := range keys {
+		epoch, ok, err := m.rankingEpoch(key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		scores, err := m.rankingScores(key)
+		if err != nil {
+			return err
+		}
+		scores[member] += weight * decayGrowth(epoch, now, halfLife)
+		m.storeRanking(key, scores)
+	}
+	return nil
+}
+
+// TopOfRanking returns up to limit members with the highest scores, ties broken like Redis
+func (m *MemoryCache) TopOfRanking(ctx context.Context, key string, limit int, halfLife time.Duration) ([]RankedMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	epoch, ok, err := m.rankingEpoch(key)
+	if err != nil || !ok {
+		return []RankedMember{}, err
+	}
+	scores, err := m.rankingScores(key)
+	if err != nil {
+		return nil, err
+	}
+
+	decay := 1 / decayGrowth(epoch, m.now().UnixMilli(), halfLife)
+	members := make([]RankedMember, 0, len(scores))
+	for member, score := range scores {
+		members = append(members, RankedMember{Member: member, Score: score})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Score != members[j].Score {
+			return members[i].Score > members[j].Score
+		}
+		return members[i].Member > members[j].Member
+	})
+	if len(members) > limit {
+		members = members[:limit]
+	}
+	for i := range members {
+		members[i].Score *= decay
+	}
+	return members, nil
+}
+
+// RescaleRanking decays the stored scores to now, moves the epoch there and drops members below minScore
+func (m *MemoryCache) RescaleRanking(ctx context.Context, key string, halfLife time.Duration, minScore float64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	epoch, ok, err := m.rankingEpoch(key)
+	if err != nil || !ok {
+		return false, err
+	}
+	scores, err := m.rankingScores(key)
+	if err != nil {
+		return false, err
+	}
+
+	now := m.now().UnixMilli()
+	decay := 1 / decayGrowth(epoch, now, halfLife)
+	for member, score := range scores {
+		if score*decay < minScore {
+			delete(scores, member)
+		} else {
+			scores[member] = score * decay
+		}
+	}
+	m.storeRanking(key, scores)
+	m.store(&memoryEntry{key: rankingEpochKey(key), value: strconv.FormatInt(now, 10)})
+	return true, nil
+}
+
+// ReplaceRanking replaces the ranking with scores as of now
+func (m *MemoryCache) ReplaceRanking(ctx context.Context, key string, scores map[string]float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.storeRanking(key, maps.Clone(scores))
+	m.store(&memoryEntry{key: rankingEpochKey(key), value: strconv.FormatInt(m.now().UnixMilli(), 10)})
+	return nil
+}
+
+// rankingEpoch returns the epoch of the ranking under key, if it exists; the caller holds mu
+func (m *MemoryCache) rankingEpoch(key string) (int64, bool, error) {
+	entry := m.lookup(rankingEpochKey(key))
+	if entry == nil {
+		return 0, false, nil
+	}
+	epoch, err := strconv.ParseInt(entry.value, 10, 64)
+	if entry.hash != nil || err != nil {
+		return 0, false, errWrongType
+	}
+	return epoch, true, nil
+}
+
+// rankingScores returns a copy of the scores stored under key; the caller holds mu
+func (m *MemoryCache) rankingScores(key string) (map[string]float64, error) {
+	scores := make(map[string]float64)
+	entry := m.lookup(key)
+	if entry == nil {
+		return scores, nil
+	}
+	if entry.hash == nil {
+		return nil, errWrongType
+	}
+	for member, value := range entry.hash {
+		score, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errWrongType
+		}
+		scores[member] = score
+	}
+	return scores, nil
+}
+
+// storeRanking stores scores under key, deleting the key when there are none as Redis does; the caller holds mu
+func (m *MemoryCache) storeRanking(key string, scores map[string]float64) {
+	if len(scores) == 0 {
+		if elem, ok := m.entries[key]; ok {
+			m.remove(elem)
+		}
+		return
+	}
+	hash := make(map[string]string, len(scores))
+	for member, score := range scores {
+		hash[member] = strconv.FormatFloat(score, 'g', -1, 64)
+	}
+	m.store(&memoryEntry{key: key, hash: hash})
+}
+
+// PushRecent records member as of now in a recent list kept as a hash of member to Unix milliseconds
+func (m *MemoryCache) PushRecent(ctx context.Context, key, member string, size int, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	times, err := m.recentTimes(key)
+	if err != nil {
+		return err
+	}
+	times[member] = m.now().UnixMilli()
+	m.storeRecent(key, times, size, m.expiry(ttl))
+	return nil
+}
+
+// RecentMembers returns up to limit members, most recent first
+func (m *MemoryCache) RecentMembers(ctx context.Context, key string, limit int) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	times, err := m.recentTimes(key)
+	if err != nil {
+		return nil, err
+	}
+	members := sortRecent(times)
+	if len(members) > limit {
+		members = members[:max(limit, 0)]
+	}
+	return members, nil
+}
+
+// RemoveRecent drops members from the list, keeping its expiry
+func (m *MemoryCache) RemoveRecent(ctx context.Context, key string, members ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil {
+		return nil
+	}
+	times, err := m.recentTimes(key)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		delete(times, member)
+	}
+	m.storeRecent(key, times, len(times), entry.expiresAt)
+	return nil
+}
+
+// MergeRecent moves the members of src into dst, keeping the later time of each, and deletes src
+func (m *MemoryCache) MergeRecent(ctx context.Context, dst, src string, size int, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.lookup(src) == nil {
+		return nil
+	}
+	from, err := m.recentTimes(src)
+	if err != nil {
+		return err
+	}
+	into, err := m.recentTimes(dst)
+	if err != nil {
+		return err
+	}
+	for member, at := range from {
+		into[member] = max(into[member], at)
+	}
+	m.storeRecent(dst, into, size, m.expiry(ttl))
+	m.remove(m.entries[src])
+	return nil
+}
+
+// recentTimes returns a copy of the member times of the recent list under key; the caller holds mu
+func (m *MemoryCache) recentTimes(key string) (map[string]int64, error) {
+	times := make(map[string]int64)
+	entry := m.lookup(key)
+	if entry == nil {
+		return times, nil
+	}
+	if entry.hash == nil {
+		return nil, errWrongType
+	}
+	for member, value := range entry.hash {
+		at, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, errWrongType
+		}
+		times[member] = at
+	}
+	return times, nil
+}
+
+// storeRecent stores the size most recent members under key, deleting the key when none are left; the caller holds mu
+func (m *MemoryCache) storeRecent(key string, times map[string]int64, size int, expiresAt time.Time) {
+	members := sortRecent(times)
+	if len(members) > size {
+		members = members[:max(size, 0)]
+	}
+	if len(members) == 0 {
+		if elem, ok := m.entries[key]; ok {
+			m.remove(elem)
+		}
+		return
+	}
+	hash := make(map[string]string, len(members))
+	for _, member := range members {
+		hash[member] = strconv.FormatInt(times[member], 10)
+	}
+	m.store(&memoryEntry{key: key, hash: hash, expiresAt: expiresAt})
+}
+
+// sortRecent orders members most recent first, ties broken like Redis
+func sortRecent(times map[string]int64) []string {
+	members := make([]string, 0, len(times))
+	for member := range times {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if times[members[i]] != times[members[j]] {
+			return times[members[i]] > times[members[j]]
+		}
+		return members[i] > members[j]
+	})
+	return members
+}
+
+// lookup returns the live entry for key and marks it recently used; the caller holds mu
+func (m *MemoryCache) lookup(key string) *memoryEntry {
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*memoryEntry)
+	if m.expired(entry) {
+		m.remove(elem)
+		return nil
+	}
+	m.lru.MoveToFront(elem)
+	return entry
+}
+
+// store inserts or replaces an entry, then evicts from the LRU end until the bounds hold; the caller holds mu
+func (m *MemoryCache) store(entry *memoryEntry) {
+	entry.size = int64(len(entry.key) + len(entry.value))
+	for field, value := range entry.hash {
+		entry.size += int64(len(field) + len(value))
+	}
+
+	if elem, ok := m.entries[entry.key]; ok {
+		m.remove(elem)
+	}
+	if m.cfg.MaxBytes > 0 && entry.size > m.cfg.MaxBytes {
+		return // would evict everything and still not fit
+	}
+
+	m.entries[entry.key] = m.lru.PushFront(entry)
+	m.bytes += entry.size
+
+	for m.overCapacity() {
+		m.remove(m.lru.Back())
+	}
+}
+
+func (m *MemoryCache) overCapacity() bool {
+	return (m.cfg.MaxEntries > 0 && m.lru.Len() > m.cfg.MaxEntries) ||
+		(m.cfg.MaxBytes > 0 && m.bytes > m.cfg.MaxBytes)
+}
+
+func (m *MemoryCache) remove(elem *list.Element) {
+	entry := m.lru.Remove(elem).(*memoryEntry)
+	delete(m.entries, entry.key)
+	m.bytes -= entry.size
+}
+
+func (m *MemoryCache) expired(entry *memoryEntry) bool {
+	return !entry.expiresAt.IsZero() && !m.now().Before(entry.expiresAt)
+}
+
+func (m *MemoryCache) expiry(expiration time.Duration) time.Time {
+	if expiration <= 0 {
+		return time.Time{}
+	}
+	return m.now().Add(expiration)
+}
+
+// encodeValue converts a value to its stored form the way redisCache.Set does
+func encodeValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal value: %w", err)
+		}
+		return string(data), nil
+	}
+}
@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMemoryCache returns a cache whose clock only moves when advance is called
+func newTestMemoryCache(cfg MemoryConfig) (*MemoryCache, func(time.Duration)) {
+	m := NewMemoryCache(cfg)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestMemoryCacheSetNX(t *testing.T) {
+	ctx := context.Background()
+	m, advance := newTestMemoryCache(MemoryConfig{})
+
+	ok, err := m.SetNX(ctx, "k", "1", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = m.SetNX(ctx, "k", "2", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+	value, _ := m.Get(ctx, "k")
+	require.Equal(t, "1", value)
+
+	// The ttl is set with the value, so the key frees itself
+	advance(time.Minute)
+	ok, err = m.SetNX(ctx, "k", "3", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestMemoryCacheStrings(t *testing.T) {
+	ctx := context.Background()
+	m, advance := newTestMemoryCache(MemoryConfig{})
+
+	_, err := m.Get(ctx, "missing")
+	require.Error(t, err)
+
+	require.NoError(t, m.Set(ctx, "a", "1", time.Minute))
+	require.NoError(t, m.Set(ctx, "b", map[string]int{"n": 2}, 0))
+
+	value, err := m.Get(ctx, "b")
+	require.NoError(t, err)
+	require.Equal(t, `{"n":2}`, value)
+
+	values, err := m.MGet(ctx, "a", "missing", "b")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "", `{"n":2}`}, values)
+
+	ttl, err := m.TTL(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, ttl)
+	ttl, _ = m.TTL(ctx, "b")
+	require.Equal(t, time.Duration(-1), ttl)
+	ttl, _ = m.TTL(ctx, "missing")
+	require.Equal(t, time.Duration(-2), ttl)
+
+	advance(time.Minute)
+	ok, err := m.Exists(ctx, "a")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, m.Delete(ctx, "b"))
+	require.Equal(t, 0, m.Len())
+}
+
+func TestMemoryCacheCountersAndHashes(t *testing.T) {
+	ctx := context.Background()
+	m, advance := newTestMemoryCache(MemoryConfig{})
+
+	n, err := m.Incr(ctx, "views")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	require.NoError(t, m.Expire(ctx, "views", time.Minute))
+	n, err = m.IncrBy(ctx, "views", 9)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), n)
+	n, err = m.DecrBy(ctx, "views", 4)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), n)
+
+	// Counting keeps the expiry
+	ttl, _ := m.TTL(ctx, "views")
+	require.Equal(t, time.Minute, ttl)
+	advance(time.Minute)
+	n, _ = m.Decr(ctx, "views")
+	require.Equal(t, int64(-1), n)
+
+	require.NoError(t, m.Set(ctx, "name", "mug", 0))
+	_, err = m.Incr(ctx, "name")
+	require.Error(t, err)
+
+	require.NoError(t, m.HSet(ctx, "h", "a", "1"))
+	require.NoError(t, m.HSet(ctx, "h", "b", 2))
+	value, err := m.HGet(ctx, "h", "b")
+	require.NoError(t, err)
+	require.Equal(t, "2", value)
+	_, err = m.HGet(ctx, "h", "c")
+	require.Error(t, err)
+
+	all, err := m.HGetAll(ctx, "h")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "1", "b": "2"}, all)
+
+	_, err = m.Get(ctx, "h")
+	require.ErrorIs(t, err, errWrongType)
+	require.ErrorIs(t, m.HSet(ctx, "name", "a", "1"), errWrongType)
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	ctx := context.Background()
+
+	m := NewMemoryCache(MemoryConfig{MaxEntries: 2})
+	require.NoError(t, m.Set(ctx, "a", "1", 0))
+	require.NoError(t, m.Set(ctx, "b", "2", 0))
+	_, _ = m.Get(ctx, "a") // b is now least recently used
+	require.NoError(t, m.Set(ctx, "c", "3", 0))
+
+	values, _ := m.MGet(ctx, "a", "b", "c")
+	require.Equal(t, []string{"1", "", "3"}, values)
+
+	m = NewMemoryCache(MemoryConfig{MaxBytes: 10})
+	require.NoError(t, m.Set(ctx, "a", "1234", 0)) // 5 bytes
+	require.NoError(t, m.Set(ctx, "b", "1234", 0)) // 10 bytes
+	require.NoError(t, m.Set(ctx, "c", "12", 0))   // evicts a
+	require.Equal(t, 2, m.Len())
+	ok, _ := m.Exists(ctx, "a")
+	require.False(t, ok)
+
+	// Too large to ever fit
+	require.NoError(t, m.Set(ctx, "big", "0123456789", 0))
+	ok, _ = m.Exists(ctx, "big")
+	require.False(t, ok)
+}
+
+func TestMemoryCachePubSub(t *testing.T) {
+	m := NewMemoryCache(MemoryConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	messages, err := m.Subscribe(ctx, "events")
+	require.NoError(t, err)
+	require.NoError(t, m.Publish(context.Background(), "events", "hello"))
+	require.NoError(t, m.Publish(context.Background(), "other", "ignored"))
+	require.Equal(t, "hello", <-messages)
+
+	cancel()
+	_, open := <-messages
+	require.False(t, open)
+	require.NoError(t, m.Publish(context.Background(), "events", "after"))
+}
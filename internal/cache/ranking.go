@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// RankedMember is a member of a ranking with its score decayed to the time it was read
+type RankedMember struct {
+	Member string
+	Score  float64
+}
+
+// RankingStore is implemented by caches that keep time-decayed rankings.
+// Scores halve every halfLife. They are stored forward-decayed: an event at time t adds
+// weight*2^((t-epoch)/halfLife), so stored scores keep their order as time passes without being
+// touched, and only need rescaling to a newer epoch now and then to stay in floating point range.
+// The epoch is kept beside the ranking and only exists once the ranking has been filled by ReplaceRanking.
+type RankingStore interface {
+	// AddToRankings adds weight at the current time to member in each of the rankings that exist
+	AddToRankings(ctx context.Context, keys []string, member string, weight float64, halfLife time.Duration) error
+	// TopOfRanking returns up to limit members with the highest scores, highest first
+	TopOfRanking(ctx context.Context, key string, limit int, halfLife time.Duration) ([]RankedMember, error)
+	// RescaleRanking moves the epoch to now and drops members whose score fell below minScore,
+	// reporting false if the ranking does not exist
+	RescaleRanking(ctx context.Context, key string, halfLife time.Duration, minScore float64) (bool, error)
+	// ReplaceRanking replaces the ranking with scores decayed to the current time
+	ReplaceRanking(ctx context.Context, key string, scores map[string]float64) error
+}
+
+// rankingEpochKey holds the time, in Unix milliseconds, that a ranking's stored scores are relative to
+func rankingEpochKey(key string) string {
+	return key + ":epoch"
+}
+
+// Ranker keeps time-decayed rankings in a cache.
+// Live events are only added to rankings that exist, so a ranking lost with the cache stays
+// missing until it is rebuilt in full from the source of truth, rather than restarting empty.
+type Ranker struct {
+	store RankingStore
+}
+
+// NewRanker creates a Ranker on top of c, which must implement RankingStore
+func NewRanker(c Cache) (*Ranker, error) {
+	store, ok := c.(RankingStore)
+	if !ok {
+		return nil, errors.New("cache does not support rankings")
+	}
+	return &Ranker{store: store}, nil
+}
+
+// Add adds weight to member in each of the rankings under keys
+func (r *Ranker) Add(ctx context.Context, keys []string, member string, weight float64, halfLife time.Duration) error {
+	if err := validateHalfLife(halfLife); err != nil {
+		return err
+	}
+	if len(keys) == 0 || weight <= 0 {
+		return nil
+	}
+	return r.store.AddToRankings(ctx, keys, member, weight, halfLife)
+}
+
+// Top returns up to limit members of the ranking under key, highest score first
+func (r *Ranker) Top(ctx context.Context, key string, limit int, halfLife time.Duration) ([]RankedMember, error) {
+	if err := validateHalfLife(halfLife); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return []RankedMember{}, nil
+	}
+	return r.store.TopOfRanking(ctx, key, limit, halfLife)
+}
+
+// Rescale folds the decay since the last rescale into the stored scores and drops members
+// below minScore; it reports false if the ranking is missing and needs a Replace
+func (r *Ranker) Rescale(ctx context.Context, key string, halfLife time.Duration, minScore float64) (bool, error) {
+	if err := validateHalfLife(halfLife); err != nil {
+		return false, err
+	}
+	return r.store.RescaleRanking(ctx, key, halfLife, minScore)
+}
+
+// Replace fills the ranking under key with scores as of now
+func (r *Ranker) Replace(ctx context.Context, key string, scores map[string]float64) error {
+	return r.store.ReplaceRanking(ctx, key, scores)
+}
+
+func validateHalfLife(halfLife time.Duration) error {
+	if halfLife < time.Millisecond {
+		return errors.New("ranking half-life must be at least 1ms")
+	}
+	return nil
+}
+
+// decayGrowth is the factor by which an event at now outweighs one at epoch; times are in
+// milliseconds. Its inverse decays a score stored relative to epoch down to now.
+// The Redis scripts compute the same factor.
+func decayGrowth(epoch, now int64, halfLife time.Duration) float64 {
+	return math.Exp2(float64(now-epoch) / float64(halfLife.Milliseconds()))
+}
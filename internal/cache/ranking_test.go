@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRanker(t *testing.T) {
+	ctx := context.Background()
+	m, advance := newTestMemoryCache(MemoryConfig{})
+	ranker, err := NewRanker(m)
+	require.NoError(t, err)
+
+	const halfLife = time.Hour
+	key := CacheKeys.HotProductRanking(halfLife, 0)
+
+	// Live events are ignored until the ranking has been built
+	require.NoError(t, ranker.Add(ctx, []string{key}, "1", 5, halfLife))
+	top, err := ranker.Top(ctx, key, 10, halfLife)
+	require.NoError(t, err)
+	require.Empty(t, top)
+
+	require.NoError(t, ranker.Replace(ctx, key, map[string]float64{"1": 3}))
+	advance(halfLife)
+	require.NoError(t, ranker.Add(ctx, []string{key}, "2", 2, halfLife))
+
+	// One half-life later the older, heavier event has decayed below the new one
+	top, err = ranker.Top(ctx, key, 10, halfLife)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	require.Equal(t, "2", top[0].Member)
+	require.InDelta(t, 2, top[0].Score, 1e-9)
+	require.Equal(t, "1", top[1].Member)
+	require.InDelta(t, 1.5, top[1].Score, 1e-9)
+
+	top, err = ranker.Top(ctx, key, 1, halfLife)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+}
+
+func TestRankerRescale(t *testing.T) {
+	ctx := context.Background()
+	m, advance := newTestMemoryCache(MemoryConfig{})
+	ranker, err := NewRanker(m)
+	require.NoError(t, err)
+
+	const halfLife = time.Hour
+	key := CacheKeys.HotProductRanking(halfLife, 7)
+
+	ok, err := ranker.Rescale(ctx, key, halfLife, 0.5)
+	require.NoError(t, err)
+	require.False(t, ok, "a missing ranking needs a rebuild")
+
+	require.NoError(t, ranker.Replace(ctx, key, map[string]float64{"1": 8, "2": 1.5}))
+	advance(2 * halfLife)
+
+	// Rescaling keeps the decayed scores and drops members that fell below the minimum
+	ok, err = ranker.Rescale(ctx, key, halfLife, 0.5)
+	require.NoError(t, err)
+	require.True(t, ok)
+	top, err := ranker.Top(ctx, key, 10, halfLife)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	require.Equal(t, "1", top[0].Member)
+	require.InDelta(t, 2, top[0].Score, 1e-9)
+
+	// New events are weighted against the new epoch
+	require.NoError(t, ranker.Add(ctx, []string{key}, "2", 3, halfLife))
+	top, err = ranker.Top(ctx, key, 10, halfLife)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2", "1"}, []string{top[0].Member, top[1].Member})
+	require.InDelta(t, 3, top[0].Score, 1e-9)
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Rate limiting algorithms
+const (
+	// SlidingWindow admits at most Limit requests in any Window-long span
+	SlidingWindow = "sliding_window"
+	// TokenBucket admits bursts of up to Limit requests, refilling Limit tokens per Window
+	TokenBucket = "token_bucket"
+)
+
+// RateLimit describes one limit
+type RateLimit struct {
+	Algorithm string
+	Limit     int
+	Window    time.Duration
+}
+
+// RateLimitResult is the outcome of counting one request against a limit
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // until the full limit is available again
+	RetryAfter time.Duration // until the next request would be admitted; zero when allowed
+}
+
+// RateLimitStore is implemented by caches that can count requests atomically
+type RateLimitStore interface {
+	AllowSlidingWindow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+	AllowTokenBucket(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+}
+
+// RateLimiter counts requests against limits stored in a cache
+type RateLimiter struct {
+	store RateLimitStore
+}
+
+// NewRateLimiter creates a RateLimiter on top of c, which must implement RateLimitStore
+func NewRateLimiter(c Cache) (*RateLimiter, error) {
+	store, ok := c.(RateLimitStore)
+	if !ok {
+		return nil, errors.New("cache does not support rate limiting")
+	}
+	return &RateLimiter{store: store}, nil
+}
+
+// Validate reports whether a limit can be enforced
+func (l RateLimit) Validate() error {
+	if l.Algorithm != SlidingWindow && l.Algorithm != TokenBucket {
+		return fmt.Errorf("unknown rate limit algorithm %q", l.Algorithm)
+	}
+	if l.Limit <= 0 || l.Window < time.Millisecond {
+		return errors.New("rate limit needs a positive limit and a window of at least 1ms")
+	}
+	return nil
+}
+
+// Allow counts one request under key against limit; keys come from CacheKeys.RateLimit and APIRateLimit
+func (r *RateLimiter) Allow(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	if err := limit.Validate(); err != nil {
+		return RateLimitResult{}, err
+	}
+	if limit.Algorithm == TokenBucket {
+		return r.store.AllowTokenBucket(ctx, key, limit.Limit, limit.Window)
+	}
+	return r.store.AllowSlidingWindow(ctx, key, limit.Limit, limit.Window)
+}
+
+// slidingWindow admits a request at now if fewer than limit of the logged request times fall inside the window.
+// log is sorted and in milliseconds; the returned log keeps only the times still inside the window.
+// The Redis script implements the same rules.
+func slidingWindow(log []int64, now int64, limit int, window time.Duration) ([]int64, RateLimitResult) {
+	windowMs := window.Milliseconds()
+	start := 0
+	for start < len(log) && log[start] <= now-windowMs {
+		start++
+	}
+	log = log[start:]
+
+	result := RateLimitResult{Limit: limit}
+	if len(log) < limit {
+		log = append(log, now)
+		result.Allowed = true
+	}
+	result.Remaining = limit - len(log)
+	result.Reset = time.Duration(log[len(log)-1]+windowMs-now) * time.Millisecond
+	if !result.Allowed {
+		result.RetryAfter = time.Duration(log[0]+windowMs-now) * time.Millisecond
+	}
+	return log, result
+}
+
+// tokenBucket refills tokens for the time since updatedAt and takes one if available.
+// Times are in milliseconds; a bucket never seen before starts full. The Redis script implements the same rules.
+func tokenBucket(tokens float64, updatedAt, now int64, limit int, window time.Duration) (float64, RateLimitResult) {
+	perMs := float64(limit) / float64(window.Milliseconds())
+	tokens = math.Min(float64(limit), tokens+float64(now-updatedAt)*perMs)
+
+	result := RateLimitResult{Limit: limit}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration(math.Ceil((1-tokens)/perMs)) * time.Millisecond
+	}
+	result.Remaining = int(tokens)
+	result.Reset = time.Duration(math.Ceil((float64(limit)-tokens)/perMs)) * time.Millisecond
+	return tokens, result
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	ctx := context.Background()
+	store, advance := newTestMemoryCache(MemoryConfig{})
+	limiter, err := NewRateLimiter(store)
+	require.NoError(t, err)
+	limit := RateLimit{Algorithm: SlidingWindow, Limit: 3, Window: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		result, err := limiter.Allow(ctx, "k", limit)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, 2-i, result.Remaining)
+		advance(10 * time.Second)
+	}
+
+	result, err := limiter.Allow(ctx, "k", limit)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, 30*time.Second, result.RetryAfter) // the first request leaves the window
+
+	advance(30 * time.Second)
+	result, _ = limiter.Allow(ctx, "k", limit)
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+	require.Equal(t, time.Minute, result.Reset)
+}
+
+func TestTokenBucket(t *testing.T) {
+	ctx := context.Background()
+	store, advance := newTestMemoryCache(MemoryConfig{})
+	limiter, _ := NewRateLimiter(store)
+	limit := RateLimit{Algorithm: TokenBucket, Limit: 10, Window: 10 * time.Second} // one token per second
+
+	// A full bucket admits a burst
+	for i := 0; i < 10; i++ {
+		result, err := limiter.Allow(ctx, "k", limit)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+	}
+	result, _ := limiter.Allow(ctx, "k", limit)
+	require.False(t, result.Allowed)
+	require.Equal(t, time.Second, result.RetryAfter)
+	require.Equal(t, 10*time.Second, result.Reset)
+
+	advance(2500 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		result, _ = limiter.Allow(ctx, "k", limit)
+		require.True(t, result.Allowed)
+	}
+	result, _ = limiter.Allow(ctx, "k", limit)
+	require.False(t, result.Allowed)
+	require.Equal(t, 500*time.Millisecond, result.RetryAfter)
+}
+
+func TestRateLimitValidate(t *testing.T) {
+	require.NoError(t, RateLimit{Algorithm: SlidingWindow, Limit: 1, Window: time.Second}.Validate())
+	require.Error(t, RateLimit{Algorithm: "leaky", Limit: 1, Window: time.Second}.Validate())
+	require.Error(t, RateLimit{Algorithm: TokenBucket, Limit: 0, Window: time.Second}.Validate())
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RecentStore is implemented by caches that keep capped lists of distinct members, most recent first.
+// Each member is stored with the time it was last pushed, taken from the store's clock;
+// pushing it again moves it to the front.
+type RecentStore interface {
+	// PushRecent records member as of now, keeps the size most recent members and resets the ttl
+	PushRecent(ctx context.Context, key, member string, size int, ttl time.Duration) error
+	// RecentMembers returns up to limit members, most recent first
+	RecentMembers(ctx context.Context, key string, limit int) ([]string, error)
+	// RemoveRecent drops members from the list
+	RemoveRecent(ctx context.Context, key string, members ...string) error
+	// MergeRecent moves the members of src into dst, keeping the later time of members in both,
+	// then trims dst to size, resets its ttl and deletes src. Nothing happens if src does not exist.
+	MergeRecent(ctx context.Context, dst, src string, size int, ttl time.Duration) error
+}
+
+// RecentList keeps capped, expiring most-recent-first lists in a cache
+type RecentList struct {
+	store RecentStore
+	size  int
+	ttl   time.Duration
+}
+
+// NewRecentList creates a RecentList on top of c, which must implement RecentStore.
+// Lists keep at most size members and expire ttl after their last change.
+func NewRecentList(c Cache, size int, ttl time.Duration) (*RecentList, error) {
+	store, ok := c.(RecentStore)
+	if !ok {
+		return nil, errors.New("cache does not support recent lists")
+	}
+	if size <= 0 {
+		return nil, errors.New("recent list size must be positive")
+	}
+	return &RecentList{store: store, size: size, ttl: ttl}, nil
+}
+
+// Push moves member to the front of the list under key
+func (l *RecentList) Push(ctx context.Context, key, member string) error {
+	return l.store.PushRecent(ctx, key, member, l.size, l.ttl)
+}
+
+// Members returns the whole list under key, most recent first
+func (l *RecentList) Members(ctx context.Context, key string) ([]string, error) {
+	return l.store.RecentMembers(ctx, key, l.size)
+}
+
+// Remove drops members from the list under key
+func (l *RecentList) Remove(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	return l.store.RemoveRecent(ctx, key, members...)
+}
+
+// Merge folds the list under src into the one under dst and deletes src
+func (l *RecentList) Merge(ctx context.Context, dst, src string) error {
+	return l.store.MergeRecent(ctx, dst, src, l.size, l.ttl)
+}
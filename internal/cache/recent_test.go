@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentList(t *testing.T) {
+	ctx := context.Background()
+	m, advance := newTestMemoryCache(MemoryConfig{})
+	recents, err := NewRecentList(m, 3, time.Hour)
+	require.NoError(t, err)
+
+	key := CacheKeys.UserRecentlyViewed(1)
+	for _, member := range []string{"1", "2", "3", "1", "4"} {
+		advance(time.Second)
+		require.NoError(t, recents.Push(ctx, key, member))
+	}
+
+	// Repeats move to the front and the oldest member falls off
+	members, err := recents.Members(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, []string{"4", "1", "3"}, members)
+
+	require.NoError(t, recents.Remove(ctx, key, "1"))
+	members, err = recents.Members(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, []string{"4", "3"}, members)
+
+	advance(time.Hour)
+	members, err = recents.Members(ctx, key)
+	require.NoError(t, err)
+	require.Empty(t, members)
+}
+
+func TestRecentListMerge(t *testing.T) {
+	ctx := context.Background()
+	m, advance := newTestMemoryCache(MemoryConfig{})
+	recents, err := NewRecentList(m, 3, time.Hour)
+	require.NoError(t, err)
+
+	user := CacheKeys.UserRecentlyViewed(1)
+	guest := CacheKeys.GuestRecentlyViewed("visitor")
+	for _, push := range []struct{ key, member string }{
+		{user, "1"}, {guest, "2"}, {guest, "1"}, {user, "3"}, {guest, "4"},
+	} {
+		advance(time.Second)
+		require.NoError(t, recents.Push(ctx, push.key, push.member))
+	}
+
+	// Members in both keep the later view, and the guest list is gone afterwards
+	require.NoError(t, recents.Merge(ctx, user, guest))
+	members, err := recents.Members(ctx, user)
+	require.NoError(t, err)
+	require.Equal(t, []string{"4", "3", "1"}, members)
+
+	members, err = recents.Members(ctx, guest)
+	require.NoError(t, err)
+	require.Empty(t, members)
+
+	// Merging a missing list leaves the destination alone
+	require.NoError(t, recents.Merge(ctx, user, guest))
+	members, err = recents.Members(ctx, user)
+	require.NoError(t, err)
+	require.Len(t, members, 3)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -63,6 +65,15 @@ func (r *redisCache) Set(ctx context.Context, key string, value interface{}, exp
 	return r.client.Set(ctx, key, data, expiration).Err()
 }
 
+// SetNX stores a value with expiration only if key does not exist, in a single SET NX command
+func (r *redisCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	data, err := encodeValue(value)
+	if err != nil {
+		return false, err
+	}
+	return r.client.SetNX(ctx, key, data, expiration).Result()
+}
+
 // Delete removes a key from cache
 func (r *redisCache) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
@@ -173,4 +184,359 @@ func (r *redisCache) Expire(ctx context.Context, key string, expiration time.Dur
 // TTL gets time to live for a key
 func (r *redisCache) TTL(ctx context.Context, key string) (time.Duration, error) {
 	return r.client.TTL(ctx, key).Result()
-}
\ No newline at end of file
+}
+// Publish sends a message to a pub/sub channel
+func (r *redisCache) Publish(ctx context.Context, channel, message string) error {
+	return r.client.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe delivers messages published to channel until ctx is cancelled, then closes the returned channel.
+// The subscription reconnects on its own; messages published while it is down are lost.
+func (r *redisCache) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	sub := r.client.Subscribe(ctx, channel)
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		defer sub.Close()
+
+		messages := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case out <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// acquireLockScript sets the lock if it is free and hands out the next fencing token
+var acquireLockScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0
+`)
+
+// releaseLockScript deletes the lock only if it still holds the caller's token
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// refreshLockScript extends the lock only if it still holds the caller's token
+var refreshLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// AcquireLock sets key to token for ttl if key is unset, returning the next fencing token
+func (r *redisCache) AcquireLock(ctx context.Context, key, token string, ttl time.Duration) (int64, bool, error) {
+	fence, err := acquireLockScript.Run(ctx, r.client, []string{key, lockFenceKey(key)}, token, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return 0, false, err
+	}
+	return fence, fence > 0, nil
+}
+
+// ReleaseLock deletes key if it still holds token
+func (r *redisCache) ReleaseLock(ctx context.Context, key, token string) (bool, error) {
+	n, err := releaseLockScript.Run(ctx, r.client, []string{key}, token).Int64()
+	return n > 0, err
+}
+
+// RefreshLock resets the ttl of key if it still holds token
+func (r *redisCache) RefreshLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	n, err := refreshLockScript.Run(ctx, r.client, []string{key}, token, ttl.Milliseconds()).Int64()
+	return n > 0, err
+}
+
+// slidingWindowScript mirrors slidingWindow, logging request times in a sorted set.
+// Times come from the Redis clock so all instances agree on them.
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+redis.call("PEXPIRE", KEYS[1], window)
+
+local newest = redis.call("ZRANGE", KEYS[1], -1, -1, "WITHSCORES")
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+local reset = tonumber(newest[2]) + window - now
+local retry = 0
+if allowed == 0 then
+	retry = tonumber(oldest[2]) + window - now
+end
+return {allowed, limit - count, reset, retry}
+`)
+
+// tokenBucketScript mirrors tokenBucket, keeping the bucket in a hash
+var tokenBucketScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local per_ms = limit / window
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1]) or limit
+local updated_at = tonumber(bucket[2]) or now
+tokens = math.min(limit, tokens + (now - updated_at) * per_ms)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / per_ms)
+end
+local reset = math.ceil((limit - tokens) / per_ms)
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.max(reset, 1))
+return {allowed, math.floor(tokens), reset, retry}
+`)
+
+// AllowSlidingWindow counts a request against a sliding window
+func (r *redisCache) AllowSlidingWindow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	values, err := slidingWindowScript.Run(ctx, r.client, []string{key}, limit, window.Milliseconds(), uuid.NewString()).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	return rateLimitResult(limit, values), nil
+}
+
+// AllowTokenBucket counts a request against a token bucket
+func (r *redisCache) AllowTokenBucket(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	values, err := tokenBucketScript.Run(ctx, r.client, []string{key}, limit, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	return rateLimitResult(limit, values), nil
+}
+
+// rateLimitResult decodes the {allowed, remaining, reset ms, retry ms} reply of the rate limit scripts
+func rateLimitResult(limit int, values []int64) RateLimitResult {
+	return RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      limit,
+		Remaining:  int(values[1]),
+		Reset:      time.Duration(values[2]) * time.Millisecond,
+		RetryAfter: time.Duration(values[3]) * time.Millisecond,
+	}
+}
+
+// drainCountersScript reads and deletes every counter listed in the index hash, then the index
+var drainCountersScript = redis.NewScript(`
+local index = redis.call("HGETALL", KEYS[1])
+redis.call("DEL", KEYS[1])
+
+local counts = {}
+for i = 1, #index, 2 do
+	local value = redis.call("GET", index[i + 1])
+	if value then
+		redis.call("DEL", index[i + 1])
+		table.insert(counts, index[i])
+		table.insert(counts, value)
+	end
+end
+return counts
+`)
+
+// DrainCounters returns and deletes the counters listed in the index hash, and the index itself
+func (r *redisCache) DrainCounters(ctx context.Context, indexKey string) (map[string]int64, error) {
+	values, err := drainCountersScript.Run(ctx, r.client, []string{indexKey}).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		if n, err := strconv.ParseInt(values[i+1], 10, 64); err == nil {
+			counts[values[i]] = n
+		}
+	}
+	return counts, nil
+}
+
+// addToRankingsScript adds a forward-decayed weight to the member of every ranking whose epoch is set.
+// KEYS alternate between a ranking and its epoch.
+var addToRankingsScript = redis.NewScript(`
+local weight = tonumber(ARGV[2])
+local half_life = tonumber(ARGV[3])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+for i = 1, #KEYS, 2 do
+	local epoch = tonumber(redis.call("GET", KEYS[i + 1]))
+	if epoch then
+		redis.call("ZINCRBY", KEYS[i], weight * 2 ^ ((now - epoch) / half_life), ARGV[1])
+	end
+end
+return 0
+`)
+
+// topOfRankingScript returns the highest members with their scores decayed to now
+var topOfRankingScript = redis.NewScript(`
+local epoch = tonumber(redis.call("GET", KEYS[2]))
+if not epoch then
+	return {}
+end
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local decay = 2 ^ ((epoch - now) / tonumber(ARGV[2]))
+
+local top = redis.call("ZREVRANGE", KEYS[1], 0, tonumber(ARGV[1]) - 1, "WITHSCORES")
+for i = 2, #top, 2 do
+	top[i] = tostring(tonumber(top[i]) * decay)
+end
+return top
+`)
+
+// rescaleRankingScript decays every stored score to now, moves the epoch there and prunes low scores
+var rescaleRankingScript = redis.NewScript(`
+local epoch = tonumber(redis.call("GET", KEYS[2]))
+if not epoch then
+	return 0
+end
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local decay = 2 ^ ((epoch - now) / tonumber(ARGV[1]))
+
+redis.call("ZUNIONSTORE", KEYS[1], 1, KEYS[1], "WEIGHTS", tostring(decay))
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", "(" .. ARGV[2])
+redis.call("SET", KEYS[2], now)
+return 1
+`)
+
+// replaceRankingScript swaps in a new ranking; ARGV alternates between member and score
+var replaceRankingScript = redis.NewScript(`
+redis.call("DEL", KEYS[1])
+for i = 1, #ARGV, 2 do
+	redis.call("ZADD", KEYS[1], ARGV[i + 1], ARGV[i])
+end
+local time = redis.call("TIME")
+redis.call("SET", KEYS[2], tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000))
+return 0
+`)
+
+// AddToRankings adds weight to member in each of the rankings that exist
+func (r *redisCache) AddToRankings(ctx context.Context, keys []string, member string, weight float64, halfLife time.Duration) error {
+	scriptKeys := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		scriptKeys = append(scriptKeys, key, rankingEpochKey(key))
+	}
+	return addToRankingsScript.Run(ctx, r.client, scriptKeys, member, weight, halfLife.Milliseconds()).Err()
+}
+
+// TopOfRanking returns up to limit members with the highest scores
+func (r *redisCache) TopOfRanking(ctx context.Context, key string, limit int, halfLife time.Duration) ([]RankedMember, error) {
+	values, err := topOfRankingScript.Run(ctx, r.client, []string{key, rankingEpochKey(key)}, limit, halfLife.Milliseconds()).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]RankedMember, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		score, err := strconv.ParseFloat(values[i+1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid score for %s: %w", values[i], err)
+		}
+		members = append(members, RankedMember{Member: values[i], Score: score})
+	}
+	return members, nil
+}
+
+// RescaleRanking moves the ranking's epoch to now and prunes members below minScore
+func (r *redisCache) RescaleRanking(ctx context.Context, key string, halfLife time.Duration, minScore float64) (bool, error) {
+	n, err := rescaleRankingScript.Run(ctx, r.client, []string{key, rankingEpochKey(key)}, halfLife.Milliseconds(), minScore).Int64()
+	return n > 0, err
+}
+
+// ReplaceRanking replaces the ranking with scores as of now
+func (r *redisCache) ReplaceRanking(ctx context.Context, key string, scores map[string]float64) error {
+	args := make([]interface{}, 0, 2*len(scores))
+	for member, score := range scores {
+		args = append(args, member, score)
+	}
+	return replaceRankingScript.Run(ctx, r.client, []string{key, rankingEpochKey(key)}, args...).Err()
+}
+
+// PushRecent records member in a sorted set scored by time, trimmed to size
+func (r *redisCache) PushRecent(ctx context.Context, key, member string, size int, ttl time.Duration) error {
+	return pushRecentScript.Run(ctx, r.client, []string{key}, member, size, ttl.Milliseconds()).Err()
+}
+
+// RecentMembers returns up to limit members, most recent first
+func (r *redisCache) RecentMembers(ctx context.Context, key string, limit int) ([]string, error) {
+	return r.client.ZRevRange(ctx, key, 0, int64(limit-1)).Result()
+}
+
+// RemoveRecent drops members from the list
+func (r *redisCache) RemoveRecent(ctx context.Context, key string, members ...string) error {
+	args := make([]interface{}, len(members))
+	for i, member := range members {
+		args[i] = member
+	}
+	return r.client.ZRem(ctx, key, args...).Err()
+}
+
+// pushRecentScript stamps member with the Redis clock, so histories written by all instances
+// order the same way, then trims the list and resets its ttl
+var pushRecentScript = redis.NewScript(`
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+redis.call("ZADD", KEYS[1], now, ARGV[1])
+redis.call("ZREMRANGEBYRANK", KEYS[1], 0, -tonumber(ARGV[2]) - 1)
+if tonumber(ARGV[3]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+end
+return 1
+`)
+
+// mergeRecentScript unions src into dst keeping the later time of each member, trims dst and deletes src
+var mergeRecentScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[2]) == 0 then
+	return 0
+end
+redis.call("ZUNIONSTORE", KEYS[1], 2, KEYS[1], KEYS[2], "AGGREGATE", "MAX")
+redis.call("ZREMRANGEBYRANK", KEYS[1], 0, -tonumber(ARGV[1]) - 1)
+if tonumber(ARGV[2]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+redis.call("DEL", KEYS[2])
+return 1
+`)
+
+// MergeRecent moves the members of src into dst and deletes src
+func (r *redisCache) MergeRecent(ctx context.Context, dst, src string, size int, ttl time.Duration) error {
+	return mergeRecentScript.Run(ctx, r.client, []string{dst, src}, size, ttl.Milliseconds()).Err()
+}
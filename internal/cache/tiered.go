@@ -0,0 +1,390 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PubSub is implemented by caches that can broadcast messages between application instances
+type PubSub interface {
+	Publish(ctx context.Context, channel, message string) error
+	// Subscribe delivers messages published to channel until ctx is cancelled, then closes the returned channel
+	Subscribe(ctx context.Context, channel string) (<-chan string, error)
+}
+
+const defaultL1TTL = 30 * time.Second
+
+// TieredConfig configures a TieredCache
+type TieredConfig struct {
+	L1TTL   time.Duration // upper bound on how long a local copy is served and how stale it can get if an invalidation is lost; defaults to 30s
+	Channel string        // pub/sub channel carrying invalidations between instances
+}
+
+// TieredCache serves string values from an in-process L1 in front of a shared L2 such as Redis.
+// Writes go to L2, drop the local copy and tell other instances over pub/sub to drop theirs.
+// Hashes and counters are always read from and written to L2; counter writes drop the local copy
+// of that key but are not broadcast, so a counter read with Get elsewhere may lag by up to L1TTL.
+type TieredCache struct {
+	l1       *MemoryCache
+	l2       Cache
+	pubsub   PubSub
+	cfg      TieredConfig
+	instance string // tags our own invalidations so the subscriber can skip them
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewTieredCache puts l1 in front of l2 and starts listening for invalidations; l2 must implement PubSub
+func NewTieredCache(l1 *MemoryCache, l2 Cache, cfg TieredConfig) (*TieredCache, error) {
+	pubsub, ok := l2.(PubSub)
+	if !ok {
+		return nil, errors.New("l2 cache does not support pub/sub")
+	}
+
+	if cfg.L1TTL <= 0 {
+		cfg.L1TTL = defaultL1TTL
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages, err := pubsub.Subscribe(ctx, cfg.Channel)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	t := &TieredCache{
+		l1:       l1,
+		l2:       l2,
+		pubsub:   pubsub,
+		cfg:      cfg,
+		instance: uuid.NewString(),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go t.listen(messages)
+	return t, nil
+}
+
+// Close stops listening for invalidations
+func (t *TieredCache) Close() {
+	t.cancel()
+	<-t.done
+}
+
+// listen drops local copies of keys invalidated by other instances
+func (t *TieredCache) listen(messages <-chan string) {
+	defer close(t.done)
+	for message := range messages {
+		instance, key, ok := strings.Cut(message, " ")
+		if ok && instance != t.instance {
+			_ = t.l1.Delete(context.Background(), key)
+		}
+	}
+}
+
+// invalidate drops the local copy of key and broadcasts the invalidation.
+// A lost broadcast leaves other instances stale for at most L1TTL, so it is only logged.
+func (t *TieredCache) invalidate(ctx context.Context, key string) {
+	_ = t.l1.Delete(ctx, key)
+	if err := t.pubsub.Publish(ctx, t.cfg.Channel, t.instance+" "+key); err != nil {
+		log.Printf("failed to broadcast cache invalidation for %s: %v", key, err)
+	}
+}
+
+// Get retrieves a value from L1, falling back to L2
+func (t *TieredCache) Get(ctx context.Context, key string) (string, error) {
+	if value, err := t.l1.Get(ctx, key); err == nil {
+		return value, nil
+	}
+
+	value, err := t.l2.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	_ = t.l1.Set(ctx, key, value, t.cfg.L1TTL)
+	return value, nil
+}
+
+// Set stores a value in L2 and invalidates every L1 copy
+func (t *TieredCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if err := t.l2.Set(ctx, key, value, expiration); err != nil {
+		return err
+	}
+	t.invalidate(ctx, key)
+	return nil
+}
+
+// SetNX stores a value in L2 only if key does not exist there, then drops stale L1 copies
+func (t *TieredCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	ok, err := t.l2.SetNX(ctx, key, value, expiration)
+	if err != nil || !ok {
+		return ok, err
+	}
+	t.invalidate(ctx, key)
+	return true, nil
+}
+
+// Delete removes a key from L2 and every L1
+func (t *TieredCache) Delete(ctx context.Context, key string) error {
+	if err := t.l2.Delete(ctx, key); err != nil {
+		return err
+	}
+	t.invalidate(ctx, key)
+	return nil
+}
+
+// Exists checks if a key exists
+func (t *TieredCache) Exists(ctx context.Context, key string) (bool, error) {
+	if ok, _ := t.l1.Exists(ctx, key); ok {
+		return true, nil
+	}
+	return t.l2.Exists(ctx, key)
+}
+
+// MGet retrieves multiple values, fetching only the L1 misses from L2
+func (t *TieredCache) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	result := make([]string, len(keys))
+	var missing []string
+	var missingIdx []int
+	for i, key := range keys {
+		if value, err := t.l1.Get(ctx, key); err == nil {
+			result[i] = value
+		} else {
+			missing = append(missing, key)
+			missingIdx = append(missingIdx, i)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	values, err := t.l2.MGet(ctx, missing...)
+	if err != nil {
+		return nil, err
+	}
+	for j, value := range values {
+		result[missingIdx[j]] = value
+		if value != "" { // MGet cannot tell an empty value from a missing key
+			_ = t.l1.Set(ctx, missing[j], value, t.cfg.L1TTL)
+		}
+	}
+	return result, nil
+}
+
+// MSet sets multiple key-value pairs in L2 and invalidates every L1 copy
+func (t *TieredCache) MSet(ctx context.Context, kvs map[string]interface{}, expiration time.Duration) error {
+	if err := t.l2.MSet(ctx, kvs, expiration); err != nil {
+		return err
+	}
+	for key := range kvs {
+		t.invalidate(ctx, key)
+	}
+	return nil
+}
+
+// HGet retrieves a field from a hash in L2
+func (t *TieredCache) HGet(ctx context.Context, key, field string) (string, error) {
+	return t.l2.HGet(ctx, key, field)
+}
+
+// HSet sets a field in a hash in L2
+func (t *TieredCache) HSet(ctx context.Context, key, field string, value interface{}) error {
+	return t.l2.HSet(ctx, key, field, value)
+}
+
+// HGetAll retrieves all fields from a hash in L2
+func (t *TieredCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return t.l2.HGetAll(ctx, key)
+}
+
+// Incr increments a counter in L2
+func (t *TieredCache) Incr(ctx context.Context, key string) (int64, error) {
+	_ = t.l1.Delete(ctx, key)
+	return t.l2.Incr(ctx, key)
+}
+
+// Decr decrements a counter in L2
+func (t *TieredCache) Decr(ctx context.Context, key string) (int64, error) {
+	_ = t.l1.Delete(ctx, key)
+	return t.l2.Decr(ctx, key)
+}
+
+// IncrBy increments a counter in L2 by value
+func (t *TieredCache) IncrBy(ctx context.Context, key string, value int64) (int64, error) {
+	_ = t.l1.Delete(ctx, key)
+	return t.l2.IncrBy(ctx, key, value)
+}
+
+// DecrBy decrements a counter in L2 by value
+func (t *TieredCache) DecrBy(ctx context.Context, key string, value int64) (int64, error) {
+	_ = t.l1.Delete(ctx, key)
+	return t.l2.DecrBy(ctx, key, value)
+}
+
+// Expire sets expiration on a key in L2; local copies already expire within L1TTL
+func (t *TieredCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return t.l2.Expire(ctx, key, expiration)
+}
+
+// TTL gets time to live for a key in L2
+func (t *TieredCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return t.l2.TTL(ctx, key)
+}
+
+// AcquireLock takes a lock in L2, which must implement LockStore
+func (t *TieredCache) AcquireLock(ctx context.Context, key, token string, ttl time.Duration) (int64, bool, error) {
+	store, err := t.lockStore()
+	if err != nil {
+		return 0, false, err
+	}
+	return store.AcquireLock(ctx, key, token, ttl)
+}
+
+// ReleaseLock frees a lock in L2
+func (t *TieredCache) ReleaseLock(ctx context.Context, key, token string) (bool, error) {
+	store, err := t.lockStore()
+	if err != nil {
+		return false, err
+	}
+	return store.ReleaseLock(ctx, key, token)
+}
+
+// RefreshLock extends a lock in L2
+func (t *TieredCache) RefreshLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	store, err := t.lockStore()
+	if err != nil {
+		return false, err
+	}
+	return store.RefreshLock(ctx, key, token, ttl)
+}
+
+// lockStore returns L2 as a LockStore; locks never go through L1, which is per-instance
+func (t *TieredCache) lockStore() (LockStore, error) {
+	store, ok := t.l2.(LockStore)
+	if !ok {
+		return nil, errors.New("l2 cache does not support locks")
+	}
+	return store, nil
+}
+
+// AllowSlidingWindow counts a request in L2, which must implement RateLimitStore
+func (t *TieredCache) AllowSlidingWindow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	store, ok := t.l2.(RateLimitStore)
+	if !ok {
+		return RateLimitResult{}, errors.New("l2 cache does not support rate limiting")
+	}
+	return store.AllowSlidingWindow(ctx, key, limit, window)
+}
+
+// AllowTokenBucket counts a request in L2, which must implement RateLimitStore
+func (t *TieredCache) AllowTokenBucket(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	store, ok := t.l2.(RateLimitStore)
+	if !ok {
+		return RateLimitResult{}, errors.New("l2 cache does not support rate limiting")
+	}
+	return store.AllowTokenBucket(ctx, key, limit, window)
+}
+
+// DrainCounters drains counters in L2, which must implement CounterStore
+func (t *TieredCache) DrainCounters(ctx context.Context, indexKey string) (map[string]int64, error) {
+	store, ok := t.l2.(CounterStore)
+	if !ok {
+		return nil, errors.New("l2 cache does not support counters")
+	}
+	return store.DrainCounters(ctx, indexKey)
+}
+
+// rankingStore returns L2 as a RankingStore; rankings are shared state, so they bypass L1
+func (t *TieredCache) rankingStore() (RankingStore, error) {
+	store, ok := t.l2.(RankingStore)
+	if !ok {
+		return nil, errors.New("l2 cache does not support rankings")
+	}
+	return store, nil
+}
+
+// AddToRankings adds to rankings in L2, which must implement RankingStore
+func (t *TieredCache) AddToRankings(ctx context.Context, keys []string, member string, weight float64, halfLife time.Duration) error {
+	store, err := t.rankingStore()
+	if err != nil {
+		return err
+	}
+	return store.AddToRankings(ctx, keys, member, weight, halfLife)
+}
+
+// TopOfRanking reads a ranking from L2
+func (t *TieredCache) TopOfRanking(ctx context.Context, key string, limit int, halfLife time.Duration) ([]RankedMember, error) {
+	store, err := t.rankingStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.TopOfRanking(ctx, key, limit, halfLife)
+}
+
+// RescaleRanking rescales a ranking in L2
+func (t *TieredCache) RescaleRanking(ctx context.Context, key string, halfLife time.Duration, minScore float64) (bool, error) {
+	store, err := t.rankingStore()
+	if err != nil {
+		return false, err
+	}
+	return store.RescaleRanking(ctx, key, halfLife, minScore)
+}
+
+// ReplaceRanking replaces a ranking in L2
+func (t *TieredCache) ReplaceRanking(ctx context.Context, key string, scores map[string]float64) error {
+	store, err := t.rankingStore()
+	if err != nil {
+		return err
+	}
+	return store.ReplaceRanking(ctx, key, scores)
+}
+
+// recentStore returns L2 as a RecentStore; recent lists are shared state, so they bypass L1
+func (t *TieredCache) recentStore() (RecentStore, error) {
+	store, ok := t.l2.(RecentStore)
+	if !ok {
+		return nil, errors.New("l2 cache does not support recent lists")
+	}
+	return store, nil
+}
+
+// PushRecent records a member in a recent list in L2, which must implement RecentStore
+func (t *TieredCache) PushRecent(ctx context.Context, key, member string, size int, ttl time.Duration) error {
+	store, err := t.recentStore()
+	if err != nil {
+		return err
+	}
+	return store.PushRecent(ctx, key, member, size, ttl)
+}
+
+// RecentMembers reads a recent list from L2
+func (t *TieredCache) RecentMembers(ctx context.Context, key string, limit int) ([]string, error) {
+	store, err := t.recentStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.RecentMembers(ctx, key, limit)
+}
+
+// RemoveRecent drops members from a recent list in L2
+func (t *TieredCache) RemoveRecent(ctx context.Context, key string, members ...string) error {
+	store, err := t.recentStore()
+	if err != nil {
+		return err
+	}
+	return store.RemoveRecent(ctx, key, members...)
+}
+
+// MergeRecent merges recent lists in L2
+func (t *TieredCache) MergeRecent(ctx context.Context, dst, src string, size int, ttl time.Duration) error {
+	store, err := t.recentStore()
+	if err != nil {
+		return err
+	}
+	return store.MergeRecent(ctx, dst, src, size, ttl)
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTieredCache(t *testing.T, l2 *MemoryCache) *TieredCache {
+	t.Helper()
+
+	tiered, err := NewTieredCache(NewMemoryCache(MemoryConfig{}), l2, TieredConfig{L1TTL: time.Minute, Channel: "invalidate"})
+	require.NoError(t, err)
+	t.Cleanup(tiered.Close)
+	return tiered
+}
+
+func TestTieredCacheServesFromL1(t *testing.T) {
+	ctx := context.Background()
+	l2 := NewMemoryCache(MemoryConfig{})
+	tiered := newTestTieredCache(t, l2)
+
+	require.NoError(t, l2.Set(ctx, "k", "v1", 0))
+	value, err := tiered.Get(ctx, "k")
+	require.NoError(t, err)
+	require.Equal(t, "v1", value)
+
+	// Changed behind the tiered cache's back: the L1 copy is still served
+	require.NoError(t, l2.Set(ctx, "k", "v2", 0))
+	value, _ = tiered.Get(ctx, "k")
+	require.Equal(t, "v1", value)
+
+	values, err := tiered.MGet(ctx, "k", "missing")
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1", ""}, values)
+}
+
+func TestTieredCacheInvalidatesOtherInstances(t *testing.T) {
+	ctx := context.Background()
+	l2 := NewMemoryCache(MemoryConfig{})
+	a := newTestTieredCache(t, l2)
+	b := newTestTieredCache(t, l2)
+
+	require.NoError(t, a.Set(ctx, "k", "v1", 0))
+	value, _ := b.Get(ctx, "k")
+	require.Equal(t, "v1", value)
+
+	require.NoError(t, a.Set(ctx, "k", "v2", 0))
+	require.Eventually(t, func() bool {
+		value, _ := b.Get(ctx, "k")
+		return value == "v2"
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, a.Delete(ctx, "k"))
+	require.Eventually(t, func() bool {
+		_, err := b.Get(ctx, "k")
+		return err != nil
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestTieredCacheRequiresPubSub(t *testing.T) {
+	_, err := NewTieredCache(NewMemoryCache(MemoryConfig{}), nil, TieredConfig{})
+	require.Error(t, err)
+}
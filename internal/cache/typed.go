@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	// ErrMiss is returned by Typed.Get when nothing usable is cached under the key
+	ErrMiss = errors.New("cache miss")
+	// ErrNotFound marks a value that does not exist. Loaders return it (or wrap it) to have the
+	// absence cached, and Typed returns it when such an absence is read back.
+	ErrNotFound = errors.New("not found")
+)
+
+// notFoundMarker is stored in place of a value that does not exist.
+// 0xC1 is never used by MessagePack and cannot start JSON, so no codec output collides with it.
+const notFoundMarker = "\xc1"
+
+// TypedOptions configures a Typed cache
+type TypedOptions struct {
+	Codec       Codec         // defaults to JSONCodec
+	Jitter      float64       // each TTL is extended by a random share of up to this fraction, so entries set together do not expire together
+	NotFoundTTL time.Duration // how long an ErrNotFound from a loader is cached; zero disables caching absences
+}
+
+// Typed stores values of type T in a Cache, encoding them with a codec.
+// A Typed over a nil Cache caches nothing, so callers need no separate path for running without Redis.
+type Typed[T any] struct {
+	cache Cache
+	opts  TypedOptions
+	loads singleflight.Group
+}
+
+// NewTyped creates a Typed cache on top of c, which may be nil
+func NewTyped[T any](c Cache, opts TypedOptions) *Typed[T] {
+	if opts.Codec == nil {
+		opts.Codec = JSONCodec
+	}
+	return &Typed[T]{cache: c, opts: opts}
+}
+
+// Get returns the value cached under key, ErrNotFound for a cached absence, or ErrMiss.
+// Values that no longer decode (e.g. after a codec or type change) count as misses.
+func (t *Typed[T]) Get(ctx context.Context, key string) (T, error) {
+	var value T
+	if t.cache == nil {
+		return value, ErrMiss
+	}
+
+	cached, err := t.cache.Get(ctx, key)
+	if err != nil {
+		return value, ErrMiss
+	}
+	if cached == notFoundMarker {
+		return value, ErrNotFound
+	}
+	if err := t.opts.Codec.Unmarshal([]byte(cached), &value); err != nil {
+		return value, ErrMiss
+	}
+	return value, nil
+}
+
+// Set caches value under key for ttl plus jitter; a ttl of zero keeps it until deleted
+func (t *Typed[T]) Set(ctx context.Context, key string, value T, ttl time.Duration) error {
+	if t.cache == nil {
+		return nil
+	}
+
+	data, err := t.opts.Codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode cached value: %w", err)
+	}
+	return t.cache.Set(ctx, key, data, t.jitter(ttl))
+}
+
+// SetNotFound caches the absence of the value under key for NotFoundTTL
+func (t *Typed[T]) SetNotFound(ctx context.Context, key string) error {
+	if t.cache == nil || t.opts.NotFoundTTL <= 0 {
+		return nil
+	}
+	return t.cache.Set(ctx, key, notFoundMarker, t.jitter(t.opts.NotFoundTTL))
+}
+
+// Delete removes the value cached under key
+func (t *Typed[T]) Delete(ctx context.Context, key string) error {
+	if t.cache == nil {
+		return nil
+	}
+	return t.cache.Delete(ctx, key)
+}
+
+// GetOrLoad returns the value cached under key, or calls load and caches its result for ttl.
+// Concurrent misses on one key share a single load. A load failing with ErrNotFound is cached
+// for NotFoundTTL; other load errors are returned and not cached. A ttl of zero or less bypasses
+// the cache. Cache failures only cost a load, so they are not reported.
+func (t *Typed[T]) GetOrLoad(ctx context.Context, key string, ttl time.Duration, load func(context.Context) (T, error)) (T, error) {
+	if t.cache == nil || ttl <= 0 {
+		return load(ctx)
+	}
+
+	value, err := t.Get(ctx, key)
+	if !errors.Is(err, ErrMiss) {
+		return value, err
+	}
+
+	// The load is shared with other callers, so it must outlive this caller's cancellation
+	shared, err, _ := t.loads.Do(key, func() (any, error) {
+		loadCtx := context.WithoutCancel(ctx)
+		value, err := load(loadCtx)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				_ = t.SetNotFound(loadCtx, key)
+			}
+			return nil, err
+		}
+		_ = t.Set(loadCtx, key, value, ttl)
+		return value, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	value, _ = shared.(T)
+	return value, nil
+}
+
+// jitter extends ttl by a random share of up to Jitter of its length
+func (t *Typed[T]) jitter(ttl time.Duration) time.Duration {
+	if ttl <= 0 || t.opts.Jitter <= 0 {
+		return ttl
+	}
+	spread := int64(float64(ttl) * t.opts.Jitter)
+	if spread <= 0 {
+		return ttl
+	}
+	return ttl + time.Duration(rand.Int64N(spread+1))
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJitter(t *testing.T) {
+	typed := NewTyped[string](nil, TypedOptions{Jitter: 0.1})
+	for i := 0; i < 100; i++ {
+		ttl := typed.jitter(time.Minute)
+		require.GreaterOrEqual(t, ttl, time.Minute)
+		require.LessOrEqual(t, ttl, time.Minute+6*time.Second)
+	}
+	require.Equal(t, time.Duration(0), typed.jitter(0))
+}
+
+type typedSample struct {
+	Name string `json:"name"`
+}
+
+func TestTypedGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	typed := NewTyped[*typedSample](NewMemoryCache(MemoryConfig{}), TypedOptions{NotFoundTTL: time.Minute})
+
+	loads := 0
+	load := func(context.Context) (*typedSample, error) {
+		loads++
+		return &typedSample{Name: "mug"}, nil
+	}
+	for i := 0; i < 2; i++ {
+		value, err := typed.GetOrLoad(ctx, "k", time.Minute, load)
+		require.NoError(t, err)
+		require.Equal(t, "mug", value.Name)
+	}
+	require.Equal(t, 1, loads)
+
+	missing := func(context.Context) (*typedSample, error) {
+		loads++
+		return nil, fmt.Errorf("product 7: %w", ErrNotFound)
+	}
+	for i := 0; i < 2; i++ {
+		_, err := typed.GetOrLoad(ctx, "missing", time.Minute, missing)
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	require.Equal(t, 2, loads)
+
+	_, err := typed.Get(ctx, "other")
+	require.ErrorIs(t, err, ErrMiss)
+
+	// Other load errors are not cached
+	failing := func(context.Context) (*typedSample, error) {
+		loads++
+		return nil, errors.New("db down")
+	}
+	_, err = typed.GetOrLoad(ctx, "failing", time.Minute, failing)
+	require.Error(t, err)
+	_, _ = typed.GetOrLoad(ctx, "failing", time.Minute, failing)
+	require.Equal(t, 4, loads)
+}
+
+func TestTypedGetOrLoadSharesConcurrentLoads(t *testing.T) {
+	typed := NewTyped[int](NewMemoryCache(MemoryConfig{}), TypedOptions{})
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (int, error) {
+		loads.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	values := make([]int, 10)
+	for i := range values {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], _ = typed.GetOrLoad(context.Background(), "k", time.Minute, load)
+		}()
+	}
+	require.Eventually(t, func() bool { return loads.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond) // let the other callers join the load
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), loads.Load())
+	for _, value := range values {
+		require.Equal(t, 42, value)
+	}
+}
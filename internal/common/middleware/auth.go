@@ -63,3 +63,20 @@ func GetPayload(c *gin.Context) *token.Payload {
 	}
 	return payload
 }
+
+// BearerPayload returns the payload of a valid bearer token, if the request carries one.
+// Unlike AuthMiddleware it never rejects the request, so public routes can tell signed-in callers apart.
+func BearerPayload(c *gin.Context, tokenMaker token.Maker) *token.Payload {
+	if payload := GetPayload(c); payload != nil {
+		return payload
+	}
+	fields := strings.Fields(c.GetHeader(authorizationHeaderKey))
+	if tokenMaker == nil || len(fields) < 2 || strings.ToLower(fields[0]) != authorizationTypeBearer {
+		return nil
+	}
+	payload, err := tokenMaker.VerifyToken(fields[1])
+	if err != nil {
+		return nil
+	}
+	return payload
+}
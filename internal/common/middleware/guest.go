@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GuestCookie carries a random ID for an anonymous visitor; their browsing history is kept under it
+// until they log in. Unlike the client IP it is not shared by everyone behind the same NAT.
+const GuestCookie = "guest_id"
+
+const guestCookieMaxAge = 365 * 24 * 60 * 60 // 1 year
+
+// GuestID returns the visitor's guest ID, issuing a new cookie when the request has none or a malformed one
+func GuestID(c *gin.Context) string {
+	if id := RequestGuestID(c); id != "" {
+		return id
+	}
+	id := uuid.NewString()
+	setGuestCookie(c, id, guestCookieMaxAge)
+	return id
+}
+
+// RequestGuestID returns the guest ID the request carries, or "" if there is none; it never issues one
+func RequestGuestID(c *gin.Context) string {
+	value, err := c.Cookie(GuestCookie)
+	if err != nil {
+		return ""
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+// ClearGuestID expires the guest cookie, once its history has been merged into a user's
+func ClearGuestID(c *gin.Context) {
+	setGuestCookie(c, "", -1)
+}
+
+func setGuestCookie(c *gin.Context, value string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(GuestCookie, value, maxAge, "/", "", c.Request.TLS != nil, true)
+}
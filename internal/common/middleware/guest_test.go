@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/guest", func(c *gin.Context) { c.String(http.StatusOK, GuestID(c)) })
+	r.GET("/peek", func(c *gin.Context) { c.String(http.StatusOK, RequestGuestID(c)) })
+	r.POST("/login", func(c *gin.Context) { ClearGuestID(c) })
+
+	send := func(method, path string, cookie *http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	guestCookie := func(w *httptest.ResponseRecorder) *http.Cookie {
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == GuestCookie {
+				return cookie
+			}
+		}
+		return nil
+	}
+
+	// A new visitor is issued a random ID
+	w := send(http.MethodGet, "/guest", nil)
+	issued := guestCookie(w)
+	require.NotNil(t, issued)
+	require.True(t, issued.HttpOnly)
+	require.Equal(t, w.Body.String(), issued.Value)
+	_, err := uuid.Parse(issued.Value)
+	require.NoError(t, err)
+
+	// Visitors sharing an IP and user agent still get their own IDs
+	require.NotEqual(t, issued.Value, guestCookie(send(http.MethodGet, "/guest", nil)).Value)
+
+	// A returning visitor keeps theirs
+	w = send(http.MethodGet, "/guest", issued)
+	require.Equal(t, issued.Value, w.Body.String())
+	require.Nil(t, guestCookie(w))
+
+	// Malformed values are replaced rather than used as cache keys
+	w = send(http.MethodGet, "/guest", &http.Cookie{Name: GuestCookie, Value: "../../user:1"})
+	require.NotEqual(t, "../../user:1", w.Body.String())
+	require.NotNil(t, guestCookie(w))
+	require.Empty(t, send(http.MethodGet, "/peek", &http.Cookie{Name: GuestCookie, Value: "../../user:1"}).Body.String())
+
+	// Reading the ID never issues one, and clearing expires the cookie
+	w = send(http.MethodGet, "/peek", nil)
+	require.Empty(t, w.Body.String())
+	require.Nil(t, guestCookie(w))
+	require.Equal(t, issued.Value, send(http.MethodGet, "/peek", issued).Body.String())
+	require.Less(t, guestCookie(send(http.MethodPost, "/login", issued)).MaxAge, 0)
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gomall/internal/cache"
+	"gomall/internal/config"
+	"gomall/utils/response"
+	"gomall/utils/token"
+)
+
+// Identities a rate limit can be counted per
+const (
+	identityIP     = "ip"
+	identityUser   = "user"
+	identityAPIKey = "api_key"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+type rateLimitRule struct {
+	route    string
+	identity string
+	limit    cache.RateLimit
+}
+
+// RateLimitMiddleware enforces the configured per-route limits. Install it with Use before the
+// routes it covers are registered; rules match the method and registered path of each request.
+// Identities that cannot be determined (no valid token, no configured API key) fall back to the client IP.
+// Every limited response carries RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers
+// for the tightest rule; rejected requests get 429 with Retry-After.
+// If the cache fails, requests are let through rather than taking the API down with it.
+func RateLimitMiddleware(cfg config.RateLimitConfig, limiter *cache.RateLimiter, tokenMaker token.Maker) (gin.HandlerFunc, error) {
+	apiKeys := make(map[string]bool, len(cfg.APIKeys))
+	for _, key := range cfg.APIKeys {
+		if key != "" {
+			apiKeys[key] = true
+		}
+	}
+
+	rules := make(map[string][]rateLimitRule)
+	for _, rule := range cfg.Rules {
+		limit := cache.RateLimit{Algorithm: rule.Algorithm, Limit: rule.Limit, Window: rule.Window}
+		if err := limit.Validate(); err != nil {
+			return nil, fmt.Errorf("rate limit for %s: %w", rule.Route, err)
+		}
+		switch rule.Identity {
+		case identityIP, identityUser, identityAPIKey:
+		default:
+			return nil, fmt.Errorf("rate limit for %s: unknown identity %q", rule.Route, rule.Identity)
+		}
+		route := strings.Join(strings.Fields(rule.Route), " ")
+		rules[route] = append(rules[route], rateLimitRule{route: route, identity: rule.Identity, limit: limit})
+	}
+
+	return func(c *gin.Context) {
+		if !cfg.Enabled {
+			c.Next()
+			return
+		}
+		routeRules := rules[c.Request.Method+" "+c.FullPath()]
+		if len(routeRules) == 0 {
+			c.Next()
+			return
+		}
+
+		var tightest *cache.RateLimitResult
+		for i, rule := range routeRules {
+			result, err := limiter.Allow(c.Request.Context(), rateLimitKey(c, i, rule, tokenMaker, apiKeys), rule.limit)
+			if err != nil {
+				log.Printf("rate limit check failed for %s: %v", rule.route, err)
+				continue
+			}
+			if tightest == nil || !result.Allowed || (tightest.Allowed && result.Remaining < tightest.Remaining) {
+				tightest = &result
+			}
+			if !result.Allowed {
+				break
+			}
+		}
+		if tightest == nil {
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(tightest.Reset)))
+		if !tightest.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(tightest.RetryAfter)))
+			response.Error(c, http.StatusTooManyRequests, "too many requests, please try again later")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}, nil
+}
+
+// rateLimitKey identifies the caller for the i-th rule of a route. Only configured API keys get a
+// bucket of their own; otherwise a client could send a fresh random key with every request.
+func rateLimitKey(c *gin.Context, i int, rule rateLimitRule, tokenMaker token.Maker, apiKeys map[string]bool) string {
+	endpoint := fmt.Sprintf("%s:%d", rule.route, i)
+
+	switch rule.identity {
+	case identityUser:
+		if payload := BearerPayload(c, tokenMaker); payload != nil {
+			return cache.CacheKeys.APIRateLimit(payload.UserID, endpoint)
+		}
+	case identityAPIKey:
+		if apiKey := c.GetHeader(apiKeyHeader); apiKeys[apiKey] {
+			sum := sha256.Sum256([]byte(apiKey)) // keep raw keys out of Redis
+			return cache.CacheKeys.RateLimit(endpoint + ":key:" + hex.EncodeToString(sum[:16]))
+		}
+	}
+	return cache.CacheKeys.RateLimit(endpoint + ":ip:" + c.ClientIP())
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
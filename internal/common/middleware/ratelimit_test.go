@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"gomall/internal/cache"
+	"gomall/internal/config"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter, err := cache.NewRateLimiter(cache.NewMemoryCache(cache.MemoryConfig{}))
+	require.NoError(t, err)
+	rateLimit, err := RateLimitMiddleware(config.RateLimitConfig{
+		Enabled: true,
+		Rules: []config.RateLimitRule{
+			{Route: "POST /users/login", Identity: "ip", Algorithm: cache.SlidingWindow, Limit: 2, Window: time.Minute},
+		},
+	}, limiter, nil)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(rateLimit)
+	r.POST("/users/login", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.POST("/users/register", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(path, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("/users/login", "10.0.0.1")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+
+	require.Equal(t, http.StatusOK, send("/users/login", "10.0.0.1").Code)
+	w = send("/users/login", "10.0.0.1")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	// Other callers and unlisted routes are unaffected
+	require.Equal(t, http.StatusOK, send("/users/login", "10.0.0.2").Code)
+	w = send("/users/register", "10.0.0.1")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitMiddlewareRejectsBadConfig(t *testing.T) {
+	limiter, _ := cache.NewRateLimiter(cache.NewMemoryCache(cache.MemoryConfig{}))
+	_, err := RateLimitMiddleware(config.RateLimitConfig{
+		Rules: []config.RateLimitRule{{Route: "GET /x", Identity: "cookie", Algorithm: cache.TokenBucket, Limit: 1, Window: time.Second}},
+	}, limiter, nil)
+	require.Error(t, err)
+}
+
+func TestRateLimitMiddlewareIgnoresSpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(trustedProxies []string) *gin.Engine {
+		limiter, err := cache.NewRateLimiter(cache.NewMemoryCache(cache.MemoryConfig{}))
+		require.NoError(t, err)
+		rateLimit, err := RateLimitMiddleware(config.RateLimitConfig{
+			Enabled: true,
+			Rules: []config.RateLimitRule{
+				{Route: "POST /users/login", Identity: "ip", Algorithm: cache.SlidingWindow, Limit: 1, Window: time.Minute},
+			},
+		}, limiter, nil)
+		require.NoError(t, err)
+
+		r := gin.New()
+		require.NoError(t, r.SetTrustedProxies(trustedProxies))
+		r.Use(rateLimit)
+		r.POST("/users/login", func(c *gin.Context) { c.Status(http.StatusOK) })
+		return r
+	}
+	send := func(r *gin.Engine, remoteIP, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/users/login", nil)
+		req.RemoteAddr = remoteIP + ":1234"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// With no trusted proxies a caller cannot rotate X-Forwarded-For to get a fresh limit
+	r := newRouter(nil)
+	require.Equal(t, http.StatusOK, send(r, "10.0.0.1", "203.0.113.1"))
+	require.Equal(t, http.StatusTooManyRequests, send(r, "10.0.0.1", "203.0.113.2"))
+
+	// Behind a configured proxy the forwarded client address is the key
+	r = newRouter([]string{"10.0.0.0/8"})
+	require.Equal(t, http.StatusOK, send(r, "10.0.0.1", "203.0.113.1"))
+	require.Equal(t, http.StatusOK, send(r, "10.0.0.1", "203.0.113.2"))
+	require.Equal(t, http.StatusTooManyRequests, send(r, "10.0.0.2", "203.0.113.1"))
+}
+
+func TestRateLimitMiddlewareIgnoresUnknownAPIKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter, err := cache.NewRateLimiter(cache.NewMemoryCache(cache.MemoryConfig{}))
+	require.NoError(t, err)
+	rateLimit, err := RateLimitMiddleware(config.RateLimitConfig{
+		Enabled: true,
+		APIKeys: []string{"partner-a", "partner-b"},
+		Rules: []config.RateLimitRule{
+			{Route: "GET /products", Identity: "api_key", Algorithm: cache.SlidingWindow, Limit: 1, Window: time.Minute},
+		},
+	}, limiter, nil)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(rateLimit)
+	r.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(ip, apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set(apiKeyHeader, apiKey)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Rotating random keys does not buy a fresh limit: they all count against the IP
+	require.Equal(t, http.StatusOK, send("10.0.0.1", "random-1"))
+	require.Equal(t, http.StatusTooManyRequests, send("10.0.0.1", "random-2"))
+
+	// Configured keys are limited separately, wherever they come from
+	require.Equal(t, http.StatusOK, send("10.0.0.1", "partner-a"))
+	require.Equal(t, http.StatusTooManyRequests, send("10.0.0.2", "partner-a"))
+	require.Equal(t, http.StatusOK, send("10.0.0.2", "partner-b"))
+}
@@ -7,15 +7,23 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Server     ServerConfig     `mapstructure:"server"`
-	Database   DatabaseConfig   `mapstructure:"database"`
-	Redis      RedisConfig      `mapstructure:"redis"`
-	Cache      CacheConfig      `mapstructure:"cache"`
-	JWT        JWTConfig        `mapstructure:"jwt"`
-	Email      EmailConfig      `mapstructure:"email"`
-	Pagination PaginationConfig `mapstructure:"pagination"`
-	Inventory  InventoryConfig  `mapstructure:"inventory"`
-	Order      OrderConfig      `mapstructure:"order"`
+	Server          ServerConfig          `mapstructure:"server"`
+	Database        DatabaseConfig        `mapstructure:"database"`
+	Redis           RedisConfig           `mapstructure:"redis"`
+	Cache           CacheConfig           `mapstructure:"cache"`
+	JWT             JWTConfig             `mapstructure:"jwt"`
+	Email           EmailConfig           `mapstructure:"email"`
+	Pagination      PaginationConfig      `mapstructure:"pagination"`
+	Inventory       InventoryConfig       `mapstructure:"inventory"`
+	Order           OrderConfig           `mapstructure:"order"`
+	Alert           AlertConfig           `mapstructure:"alert"`
+	Search          SearchConfig          `mapstructure:"search"`
+	Review          ReviewConfig          `mapstructure:"review"`
+	Storage         StorageConfig         `mapstructure:"storage"`
+	Image           ImageConfig           `mapstructure:"image"`
+	RateLimit       RateLimitConfig       `mapstructure:"rate_limit"`
+	HotProducts     HotProductsConfig     `mapstructure:"hot_products"`
+	RelatedProducts RelatedProductsConfig `mapstructure:"related_products"`
 }
 
 // ServerConfig holds server configuration
@@ -25,6 +33,7 @@ type ServerConfig struct {
 	ReadTimeout    time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout   time.Duration `mapstructure:"write_timeout"`
 	MaxHeaderBytes int           `mapstructure:"max_header_bytes"`
+	TrustedProxies []string      `mapstructure:"trusted_proxies"`
 }
 
 // DatabaseConfig holds database configuration
@@ -65,11 +74,29 @@ type RedisConfig struct {
 
 // CacheConfig holds cache TTL configuration
 type CacheConfig struct {
-	ProductTTL     time.Duration `mapstructure:"product_ttl"`
-	ProductListTTL time.Duration `mapstructure:"product_list_ttl"`
-	StockTTL       time.Duration `mapstructure:"stock_ttl"`
-	UserSessionTTL time.Duration `mapstructure:"user_session_ttl"`
-	HotProductTTL  time.Duration `mapstructure:"hot_product_ttl"`
+	ProductTTL         time.Duration `mapstructure:"product_ttl"`
+	ProductListTTL     time.Duration `mapstructure:"product_list_ttl"`
+	StockTTL           time.Duration `mapstructure:"stock_ttl"`
+	UserSessionTTL     time.Duration `mapstructure:"user_session_ttl"`
+	HotProductTTL      time.Duration `mapstructure:"hot_product_ttl"`
+	CategoryTTL        time.Duration `mapstructure:"category_ttl"`
+	NotFoundTTL        time.Duration `mapstructure:"not_found_ttl"`        // how long a lookup of a missing ID is remembered
+	TTLJitter          float64       `mapstructure:"ttl_jitter"`           // fraction of each TTL added at random so entries don't expire together
+	Codec              string        `mapstructure:"codec"`                // json or msgpack
+	ViewDedupWindow    time.Duration `mapstructure:"view_dedup_window"`    // repeat views of a product by one visitor within this window count once
+	ViewFlushInterval  time.Duration `mapstructure:"view_flush_interval"`  // how often counted views are written to the database
+	RecentlyViewedSize int           `mapstructure:"recently_viewed_size"` // distinct products kept in each browsing history
+	RecentlyViewedTTL  time.Duration `mapstructure:"recently_viewed_ttl"`  // histories untouched for this long are dropped
+	L1                 L1CacheConfig `mapstructure:"l1"`
+}
+
+// L1CacheConfig holds the in-process cache kept in front of Redis
+type L1CacheConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	MaxEntries int           `mapstructure:"max_entries"`
+	MaxBytes   int64         `mapstructure:"max_bytes"`
+	TTL        time.Duration `mapstructure:"ttl"`     // bounds how stale a local copy can get if an invalidation is lost
+	Channel    string        `mapstructure:"channel"` // Redis pub/sub channel for invalidations
 }
 
 // JWTConfig holds JWT configuration
@@ -96,6 +123,16 @@ type PaginationConfig struct {
 type InventoryConfig struct {
 	ReservationTTL  time.Duration `mapstructure:"reservation_ttl"`
 	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	Reorder         ReorderConfig `mapstructure:"reorder"`
+}
+
+// ReorderConfig holds defaults for reorder-point suggestions
+type ReorderConfig struct {
+	WindowDays       int           `mapstructure:"window_days"`
+	LeadTimeDays     int           `mapstructure:"lead_time_days"`
+	SafetyStockDays  int           `mapstructure:"safety_stock_days"`
+	ReviewPeriodDays int           `mapstructure:"review_period_days"`
+	RefreshInterval  time.Duration `mapstructure:"refresh_interval"`
 }
 
 // OrderConfig holds order configuration
@@ -103,3 +140,124 @@ type OrderConfig struct {
 	PaymentTimeout      time.Duration `mapstructure:"payment_timeout"`
 	AutoCancelInterval time.Duration `mapstructure:"auto_cancel_interval"`
 }
+
+// AlertConfig holds operational alert configuration
+type AlertConfig struct {
+	LowStock LowStockAlertConfig `mapstructure:"low_stock"`
+}
+
+// LowStockAlertConfig holds low-stock notification configuration
+type LowStockAlertConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	Recipients     []string      `mapstructure:"recipients"`
+	WebhookURL     string        `mapstructure:"webhook_url"`
+	WebhookTimeout time.Duration `mapstructure:"webhook_timeout"`
+}
+
+// SearchConfig holds product full-text search configuration
+type SearchConfig struct {
+	Backend       string        `mapstructure:"backend"`
+	EmbeddedPath  string        `mapstructure:"embedded_path"`
+	TSConfig      string        `mapstructure:"ts_config"`
+	Segmenter     string        `mapstructure:"segmenter"`
+	SnippetLength int           `mapstructure:"snippet_length"`
+	PriceBuckets  []int64       `mapstructure:"price_buckets"`
+	Suggest       SuggestConfig `mapstructure:"suggest"`
+}
+
+// SuggestConfig holds typeahead caching configuration.
+// A prefix is cached once it has been requested PopularThreshold times within HitWindow.
+type SuggestConfig struct {
+	CacheTTL         time.Duration `mapstructure:"cache_ttl"`
+	PopularThreshold int64         `mapstructure:"popular_threshold"`
+	HitWindow        time.Duration `mapstructure:"hit_window"`
+}
+
+// ReviewConfig holds product review configuration
+type ReviewConfig struct {
+	Moderation ReviewModerationConfig `mapstructure:"moderation"`
+}
+
+// ReviewModerationConfig holds the rules of the automatic review screener.
+// A review breaking any rule waits in the pending queue for an admin decision; zero windows or limits disable a rule.
+type ReviewModerationConfig struct {
+	BannedWords     []string      `mapstructure:"banned_words"`
+	BlockLinks      bool          `mapstructure:"block_links"`
+	DuplicateWindow time.Duration `mapstructure:"duplicate_window"`
+	RateLimit       int64         `mapstructure:"rate_limit"`
+	RateWindow      time.Duration `mapstructure:"rate_window"`
+}
+
+// StorageConfig holds blob storage configuration for uploaded files
+type StorageConfig struct {
+	Backend string             `mapstructure:"backend"`
+	Local   LocalStorageConfig `mapstructure:"local"`
+	S3      S3StorageConfig    `mapstructure:"s3"`
+}
+
+// LocalStorageConfig stores blobs under Root and serves them at BaseURL
+type LocalStorageConfig struct {
+	Root    string `mapstructure:"root"`
+	BaseURL string `mapstructure:"base_url"`
+}
+
+// S3StorageConfig holds the settings of an S3-compatible object store.
+// BaseURL, when set, replaces the bucket URL in public links (e.g. a CDN in front of the bucket).
+type S3StorageConfig struct {
+	Endpoint  string `mapstructure:"endpoint"`
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+	UseSSL    bool   `mapstructure:"use_ssl"`
+	PathStyle bool   `mapstructure:"path_style"`
+	BaseURL   string `mapstructure:"base_url"`
+}
+
+// ImageConfig holds product image upload limits and thumbnail sizes
+type ImageConfig struct {
+	MaxUploadSize int64             `mapstructure:"max_upload_size"`
+	MaxPixels     int64             `mapstructure:"max_pixels"`
+	JPEGQuality   int               `mapstructure:"jpeg_quality"`
+	Thumbnails    []ThumbnailConfig `mapstructure:"thumbnails"`
+}
+
+// ThumbnailConfig is one thumbnail rendition, scaled to fit within Size x Size pixels
+type ThumbnailConfig struct {
+	Name string `mapstructure:"name"`
+	Size int    `mapstructure:"size"`
+}
+
+// RateLimitConfig holds per-route request limits
+type RateLimitConfig struct {
+	Enabled bool            `mapstructure:"enabled"`
+	APIKeys []string        `mapstructure:"api_keys"` // keys api_key rules count per; any other X-API-Key counts per IP
+	Rules   []RateLimitRule `mapstructure:"rules"`
+}
+
+// RateLimitRule limits one route per caller; several rules may cover the same route
+type RateLimitRule struct {
+	Route     string        `mapstructure:"route"`     // method and path as registered, e.g. "POST /api/v1/users/login"
+	Identity  string        `mapstructure:"identity"`  // ip, user or api_key
+	Algorithm string        `mapstructure:"algorithm"` // sliding_window or token_bucket
+	Limit     int           `mapstructure:"limit"`
+	Window    time.Duration `mapstructure:"window"`
+}
+
+// HotProductsConfig holds the time-decayed hot product rankings
+type HotProductsConfig struct {
+	Windows          []time.Duration `mapstructure:"windows"` // offered as ?window=, each the half-life of its own ranking; the first is the default
+	ViewWeight       float64         `mapstructure:"view_weight"`
+	SaleWeight       float64         `mapstructure:"sale_weight"`       // per unit paid for
+	MinScore         float64         `mapstructure:"min_score"`         // products whose score decays below this drop out
+	MaintainInterval time.Duration   `mapstructure:"maintain_interval"` // how often rankings are rescaled, and rebuilt from the database if missing
+}
+
+// RelatedProductsConfig holds the "customers also bought" associations
+type RelatedProductsConfig struct {
+	Lookback        time.Duration `mapstructure:"lookback"`       // orders placed within this span are counted
+	MinSupport      int32         `mapstructure:"min_support"`    // orders a pair must share to be related
+	MinConfidence   float64       `mapstructure:"min_confidence"` // share of a product's orders that must include the other
+	MaxPerProduct   int32         `mapstructure:"max_per_product"`
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
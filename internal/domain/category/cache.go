@@ -0,0 +1,56 @@
+package category
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"gomall/internal/cache"
+)
+
+// listGeneration returns the stamp embedded in every category list key.
+// Bumping it on writes orphans all cached views at once; they expire with their TTL.
+// A missing stamp (never set, or evicted) is replaced by the current time, which never
+// matches an older stamp, so evictions cannot resurrect stale views.
+func (s *service) listGeneration(ctx context.Context) (int64, bool) {
+	key := cache.CacheKeys.CategoryListVersion()
+	if cached, err := s.cache.Get(ctx, key); err == nil {
+		if generation, err := strconv.ParseInt(cached, 10, 64); err == nil {
+			return generation, true
+		}
+	}
+
+	generation := time.Now().UnixNano()
+	if err := s.cache.Set(ctx, key, strconv.FormatInt(generation, 10), 0); err != nil {
+		return 0, false
+	}
+	return generation, true
+}
+
+// readListThrough caches one view of the category list under the current list generation
+func readListThrough[T any](ctx context.Context, s *service, views *cache.Typed[T], view string, load func(context.Context) (T, error)) (T, error) {
+	if s.cache == nil {
+		return load(ctx)
+	}
+	generation, ok := s.listGeneration(ctx)
+	if !ok {
+		return load(ctx)
+	}
+	return views.GetOrLoad(ctx, cache.CacheKeys.CategoryList(generation, view), s.cacheCfg.CategoryTTL, load)
+}
+
+// invalidateCategory drops the cached category and every cached list view
+func (s *service) invalidateCategory(ctx context.Context, id int64) {
+	if s.cache == nil {
+		return
+	}
+
+	if err := s.categories.Delete(ctx, cache.CacheKeys.Category(id)); err != nil {
+		log.Printf("failed to invalidate cached category %d: %v", id, err)
+	}
+	generation := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := s.cache.Set(ctx, cache.CacheKeys.CategoryListVersion(), generation, 0); err != nil {
+		log.Printf("failed to invalidate cached category lists: %v", err)
+	}
+}
@@ -2,12 +2,16 @@ package category
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/jackc/pgx/v5"
+
 	"gomall/db/sqlc"
+	"gomall/internal/cache"
+	"gomall/internal/config"
 )
 
 // Service defines the business logic interface for category domain
@@ -24,13 +28,28 @@ type Service interface {
 }
 
 type service struct {
-	repo Repository
+	repo       Repository
+	cache      cache.Cache
+	cacheCfg   config.CacheConfig
+	categories *cache.Typed[*CategoryResponse]
+	lists      *cache.Typed[[]CategoryResponse]
+	trees      *cache.Typed[[]CategoryTreeNode]
 }
 
-// NewService creates a new Service instance
-func NewService(repo Repository) Service {
+// NewService creates a new Service instance; cacheClient may be nil to read straight from the database
+func NewService(repo Repository, cacheClient cache.Cache, cacheCfg config.CacheConfig) Service {
+	typedOpts := cache.TypedOptions{
+		Codec:       cache.CodecByName(cacheCfg.Codec),
+		Jitter:      cacheCfg.TTLJitter,
+		NotFoundTTL: cacheCfg.NotFoundTTL,
+	}
 	return &service{
-		repo: repo,
+		repo:       repo,
+		cache:      cacheClient,
+		cacheCfg:   cacheCfg,
+		categories: cache.NewTyped[*CategoryResponse](cacheClient, typedOpts),
+		lists:      cache.NewTyped[[]CategoryResponse](cacheClient, typedOpts),
+		trees:      cache.NewTyped[[]CategoryTreeNode](cacheClient, typedOpts),
 	}
 }
 
@@ -41,7 +60,7 @@ func (s *service) CreateCategory(ctx context.Context, req CreateCategoryRequest)
 	if req.ParentID != nil {
 		parent, err := s.repo.GetCategoryByID(ctx, *req.ParentID)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
+			if errors.Is(err, pgx.ErrNoRows) {
 				return nil, errors.New("parent category not found")
 			}
 			return nil, fmt.Errorf("failed to get parent category: %w", err)
@@ -69,22 +88,30 @@ func (s *service) CreateCategory(ctx context.Context, req CreateCategoryRequest)
 		return nil, fmt.Errorf("failed to create category: %w", err)
 	}
 
+	s.invalidateCategory(ctx, category.ID)
+
 	response := toCategoryResponse(category)
 	return &response, nil
 }
 
-// GetCategory retrieves a category by ID
+// GetCategory retrieves a category by ID, reading through the cache
 func (s *service) GetCategory(ctx context.Context, id int64) (*CategoryResponse, error) {
-	category, err := s.repo.GetCategoryByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("category not found")
+	response, err := s.categories.GetOrLoad(ctx, cache.CacheKeys.Category(id), s.cacheCfg.CategoryTTL, func(ctx context.Context) (*CategoryResponse, error) {
+		category, err := s.repo.GetCategoryByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, cache.ErrNotFound
+			}
+			return nil, fmt.Errorf("failed to get category: %w", err)
 		}
-		return nil, fmt.Errorf("failed to get category: %w", err)
-	}
 
-	response := toCategoryResponse(category)
-	return &response, nil
+		response := toCategoryResponse(category)
+		return &response, nil
+	})
+	if errors.Is(err, cache.ErrNotFound) {
+		return nil, errors.New("category not found")
+	}
+	return response, err
 }
 
 // UpdateCategory updates a category
@@ -92,7 +119,7 @@ func (s *service) UpdateCategory(ctx context.Context, id int64, req UpdateCatego
 	// Check if category exists
 	_, err := s.repo.GetCategoryByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return errors.New("category not found")
 		}
 		return fmt.Errorf("failed to get category: %w", err)
@@ -105,7 +132,7 @@ func (s *service) UpdateCategory(ctx context.Context, id int64, req UpdateCatego
 		}
 		_, err := s.repo.GetCategoryByID(ctx, *req.ParentID)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
+			if errors.Is(err, pgx.ErrNoRows) {
 				return errors.New("parent category not found")
 			}
 			return fmt.Errorf("failed to get parent category: %w", err)
@@ -126,6 +153,7 @@ func (s *service) UpdateCategory(ctx context.Context, id int64, req UpdateCatego
 		return fmt.Errorf("failed to update category: %w", err)
 	}
 
+	s.invalidateCategory(ctx, id)
 	return nil
 }
 
@@ -155,11 +183,22 @@ func (s *service) DeleteCategory(ctx context.Context, id int64) error {
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
 
+	s.invalidateCategory(ctx, id)
 	return nil
 }
 
-// ListCategories lists all categories with optional filter
+// ListCategories lists all categories with optional filter, reading through the cache
 func (s *service) ListCategories(ctx context.Context, isActive *bool) ([]CategoryResponse, error) {
+	view := "all"
+	if isActive != nil {
+		view = "active:" + strconv.FormatBool(*isActive)
+	}
+	return readListThrough(ctx, s, s.lists, view, func(ctx context.Context) ([]CategoryResponse, error) {
+		return s.loadCategories(ctx, isActive)
+	})
+}
+
+func (s *service) loadCategories(ctx context.Context, isActive *bool) ([]CategoryResponse, error) {
 	categories, err := s.repo.ListCategories(ctx, isActive)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list categories: %w", err)
@@ -173,8 +212,14 @@ func (s *service) ListCategories(ctx context.Context, isActive *bool) ([]Categor
 	return responses, nil
 }
 
-// GetCategoryTree builds a hierarchical tree of all active categories
+// GetCategoryTree builds a hierarchical tree of all active categories, reading through the cache
 func (s *service) GetCategoryTree(ctx context.Context) ([]CategoryTreeNode, error) {
+	return readListThrough(ctx, s, s.trees, "tree", func(ctx context.Context) ([]CategoryTreeNode, error) {
+		return s.loadCategoryTree(ctx)
+	})
+}
+
+func (s *service) loadCategoryTree(ctx context.Context) ([]CategoryTreeNode, error) {
 	// Get all active categories
 	isActive := true
 	categories, err := s.repo.ListCategories(ctx, &isActive)
@@ -208,8 +253,15 @@ func (s *service) GetCategoryTree(ctx context.Context) ([]CategoryTreeNode, erro
 	return roots, nil
 }
 
-// GetChildren gets all children of a category
+// GetChildren gets all children of a category, reading through the cache
 func (s *service) GetChildren(ctx context.Context, parentID int64) ([]CategoryResponse, error) {
+	view := "children:" + strconv.FormatInt(parentID, 10)
+	return readListThrough(ctx, s, s.lists, view, func(ctx context.Context) ([]CategoryResponse, error) {
+		return s.loadChildren(ctx, parentID)
+	})
+}
+
+func (s *service) loadChildren(ctx context.Context, parentID int64) ([]CategoryResponse, error) {
 	categories, err := s.repo.GetCategoryChildren(ctx, parentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get children: %w", err)
@@ -223,8 +275,14 @@ func (s *service) GetChildren(ctx context.Context, parentID int64) ([]CategoryRe
 	return responses, nil
 }
 
-// GetRoots gets all root categories
+// GetRoots gets all root categories, reading through the cache
 func (s *service) GetRoots(ctx context.Context) ([]CategoryResponse, error) {
+	return readListThrough(ctx, s, s.lists, "roots", func(ctx context.Context) ([]CategoryResponse, error) {
+		return s.loadRoots(ctx)
+	})
+}
+
+func (s *service) loadRoots(ctx context.Context) ([]CategoryResponse, error) {
 	categories, err := s.repo.GetRootCategories(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get root categories: %w", err)
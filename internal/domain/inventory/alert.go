@@ -0,0 +1,202 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+	"gomall/internal/cache"
+	"gomall/internal/config"
+	"gomall/utils"
+	"gomall/utils/mail"
+	"gomall/utils/webhook"
+)
+
+const lowStockWebhookEvent = "inventory.low_stock"
+
+// StockChange describes how a single inventory operation moved available stock
+type StockChange struct {
+	ProductID       int64
+	SkuID           *int64
+	BeforeAvailable int32
+	AfterAvailable  int32
+	Threshold       int32
+}
+
+func newStockChange(inv sqlc.Inventory, afterAvailable int32) StockChange {
+	return StockChange{
+		ProductID:       inv.ProductID,
+		SkuID:           inv.SkuID,
+		BeforeAvailable: inv.AvailableStock,
+		AfterAvailable:  afterAvailable,
+		Threshold:       utils.PtrValue(inv.LowStockThreshold),
+	}
+}
+
+// AlertNotifier reacts to stock changes by raising and clearing low-stock alerts
+type AlertNotifier interface {
+	StockChanged(change StockChange)
+}
+
+type lowStockNotifier struct {
+	cfg     config.LowStockAlertConfig
+	repo    Repository
+	cache   cache.Cache
+	mailer  mail.Sender
+	webhook webhook.Sender
+}
+
+// NewLowStockNotifier creates an AlertNotifier that records alerts in the database,
+// de-duplicates notifications per product or SKU per day in Redis and delivers them by
+// email and, if configured, an outbound webhook
+func NewLowStockNotifier(cfg config.LowStockAlertConfig, repo Repository, cacheClient cache.Cache, mailer mail.Sender) AlertNotifier {
+	n := &lowStockNotifier{
+		cfg:    cfg,
+		repo:   repo,
+		cache:  cacheClient,
+		mailer: mailer,
+	}
+	if cfg.WebhookURL != "" {
+		n.webhook = webhook.NewHTTPSender(cfg.WebhookURL, cfg.WebhookTimeout)
+	}
+	return n
+}
+
+// StockChanged evaluates the change in the background so stock operations never wait on delivery
+func (n *lowStockNotifier) StockChanged(change StockChange) {
+	if !n.cfg.Enabled || change.BeforeAvailable == change.AfterAvailable {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := n.handle(ctx, change); err != nil {
+			log.Printf("low stock alert for %s failed: %v", NewStockKey(change.ProductID, change.SkuID), err)
+		}
+	}()
+}
+
+func (n *lowStockNotifier) handle(ctx context.Context, change StockChange) error {
+	wasLow := change.BeforeAvailable <= change.Threshold
+	isLow := change.AfterAvailable <= change.Threshold
+
+	switch {
+	case isLow && !wasLow:
+		return n.raise(ctx, change)
+	case !isLow && wasLow:
+		// Restocked above the threshold: clear the alert of this product or SKU only
+		return n.repo.ResolveStockAlerts(ctx, sqlc.ResolveStockAlertsParams{
+			AvailableStock: change.AfterAvailable,
+			ProductID:      change.ProductID,
+			SkuID:          change.SkuID,
+		})
+	case isLow:
+		// Still low: keep the digest figure current and retry a delivery that failed
+		if err := n.repo.UpdateStockAlertLevel(ctx, sqlc.UpdateStockAlertLevelParams{
+			AvailableStock: change.AfterAvailable,
+			ProductID:      change.ProductID,
+			SkuID:          change.SkuID,
+		}); err != nil {
+			return err
+		}
+		return n.notify(ctx, change)
+	}
+	return nil
+}
+
+func (n *lowStockNotifier) raise(ctx context.Context, change StockChange) error {
+	_, err := n.repo.CreateStockAlert(ctx, sqlc.CreateStockAlertParams{
+		ProductID:      change.ProductID,
+		SkuID:          change.SkuID,
+		AvailableStock: change.AfterAvailable,
+		Threshold:      change.Threshold,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// An alert is already active for this product or SKU
+		err = n.repo.UpdateStockAlertLevel(ctx, sqlc.UpdateStockAlertLevelParams{
+			AvailableStock: change.AfterAvailable,
+			ProductID:      change.ProductID,
+			SkuID:          change.SkuID,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record stock alert: %w", err)
+	}
+
+	return n.notify(ctx, change)
+}
+
+// notify delivers the active alert of the changed product or SKU if that has not happened yet. Only
+// one alert per stock unit is delivered a day: the day's key is claimed and given its ttl in one step,
+// and given back if delivery fails, so the next movement of a unit that is still low retries.
+func (n *lowStockNotifier) notify(ctx context.Context, change StockChange) error {
+	alert, err := n.repo.GetActiveStockAlert(ctx, sqlc.GetActiveStockAlertParams{
+		ProductID: change.ProductID,
+		SkuID:     change.SkuID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Low since before alerts were raised, e.g. after a threshold change
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get stock alert: %w", err)
+	}
+	if alert.NotifiedAt.Valid {
+		return nil
+	}
+
+	unit := NewStockKey(change.ProductID, change.SkuID)
+	key := cache.CacheKeys.LowStockAlert(unit.ProductID, unit.SkuID, time.Now().Format("2006-01-02"))
+	claimed, err := n.cache.SetNX(ctx, key, "1", 24*time.Hour)
+	if err != nil {
+		return fmt.Errorf("failed to check alert de-duplication: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	if err := n.deliver(ctx, alert); err != nil {
+		if delErr := n.cache.Delete(ctx, key); delErr != nil {
+			log.Printf("low stock alert for %s: failed to release de-duplication key: %v", unit, delErr)
+		}
+		return err
+	}
+
+	if err := n.repo.MarkStockAlertNotified(ctx, alert.ID); err != nil {
+		return fmt.Errorf("failed to mark stock alert notified: %w", err)
+	}
+	return nil
+}
+
+// deliver sends an alert by email and webhook. If either fails the whole alert is retried later,
+// so a channel that succeeded may see it twice.
+func (n *lowStockNotifier) deliver(ctx context.Context, alert sqlc.GetActiveStockAlertRow) error {
+	var errs []error
+
+	if len(n.cfg.Recipients) > 0 {
+		name := alert.ProductName
+		if alert.SkuCode != nil {
+			name = fmt.Sprintf("%s (%s)", alert.ProductName, *alert.SkuCode)
+		}
+		subject := fmt.Sprintf("GoMall - Low Stock: %s", name)
+		body := mail.LowStockAlertTemplate(name, alert.ProductID, alert.AvailableStock, alert.Threshold)
+		if err := n.mailer.SendEmail(subject, body, n.cfg.Recipients, nil, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send alert email: %w", err))
+		}
+	}
+
+	if n.webhook != nil {
+		if err := n.webhook.Send(ctx, lowStockWebhookEvent, toActiveStockAlertResponse(alert)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send alert webhook: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
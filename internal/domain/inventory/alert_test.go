@@ -0,0 +1,128 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+	"gomall/internal/cache"
+	"gomall/internal/config"
+	"gomall/utils"
+	"gomall/utils/types"
+)
+
+// fakeMailer records alert emails and fails while err is set
+type fakeMailer struct {
+	sent []string
+	err  error
+}
+
+func (m *fakeMailer) SendEmail(subject string, body string, to []string, cc []string, bcc []string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, subject)
+	return nil
+}
+
+func newTestNotifier(t *testing.T) (*lowStockNotifier, *mockdb.MockStore, *fakeMailer) {
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	mailer := &fakeMailer{}
+	n := NewLowStockNotifier(config.LowStockAlertConfig{
+		Enabled:    true,
+		Recipients: []string{"ops@example.com"},
+	}, store, cache.NewMemoryCache(cache.MemoryConfig{}), mailer)
+	return n.(*lowStockNotifier), store, mailer
+}
+
+func TestLowStockAlertCrossingIsDeliveredOncePerDay(t *testing.T) {
+	ctx := context.Background()
+	n, store, mailer := newTestNotifier(t)
+	alert := sqlc.GetActiveStockAlertRow{ID: 1, ProductID: 5, ProductName: "Mug", AvailableStock: 3, Threshold: 5}
+
+	// First crossing: the alert is recorded, delivered and marked
+	store.EXPECT().CreateStockAlert(gomock.Any(), sqlc.CreateStockAlertParams{ProductID: 5, AvailableStock: 3, Threshold: 5}).Return(sqlc.StockAlert{ID: 1}, nil)
+	store.EXPECT().GetActiveStockAlert(gomock.Any(), sqlc.GetActiveStockAlertParams{ProductID: 5}).Return(alert, nil)
+	store.EXPECT().MarkStockAlertNotified(gomock.Any(), int64(1)).Return(nil)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 5, BeforeAvailable: 8, AfterAvailable: 3, Threshold: 5}))
+	require.Len(t, mailer.sent, 1)
+
+	// Recovery resolves the alert
+	store.EXPECT().ResolveStockAlerts(gomock.Any(), sqlc.ResolveStockAlertsParams{AvailableStock: 9, ProductID: 5}).Return(nil)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 5, BeforeAvailable: 3, AfterAvailable: 9, Threshold: 5}))
+
+	// Crossing again the same day records a new alert but does not deliver it
+	store.EXPECT().CreateStockAlert(gomock.Any(), gomock.Any()).Return(sqlc.StockAlert{ID: 2}, nil)
+	store.EXPECT().GetActiveStockAlert(gomock.Any(), sqlc.GetActiveStockAlertParams{ProductID: 5}).Return(sqlc.GetActiveStockAlertRow{ID: 2, ProductID: 5}, nil)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 5, BeforeAvailable: 9, AfterAvailable: 4, Threshold: 5}))
+	require.Len(t, mailer.sent, 1)
+}
+
+func TestLowStockAlertRetriesFailedDelivery(t *testing.T) {
+	ctx := context.Background()
+	n, store, mailer := newTestNotifier(t)
+	alert := sqlc.GetActiveStockAlertRow{ID: 1, ProductID: 5, ProductName: "Mug", AvailableStock: 3, Threshold: 5}
+
+	mailer.err = errors.New("smtp unavailable")
+	store.EXPECT().CreateStockAlert(gomock.Any(), gomock.Any()).Return(sqlc.StockAlert{ID: 1}, nil)
+	store.EXPECT().GetActiveStockAlert(gomock.Any(), sqlc.GetActiveStockAlertParams{ProductID: 5}).Return(alert, nil)
+	require.Error(t, n.handle(ctx, StockChange{ProductID: 5, BeforeAvailable: 8, AfterAvailable: 3, Threshold: 5}))
+
+	// The product stays low: the next movement delivers the alert the failure left pending
+	mailer.err = nil
+	store.EXPECT().UpdateStockAlertLevel(gomock.Any(), sqlc.UpdateStockAlertLevelParams{AvailableStock: 2, ProductID: 5}).Return(nil)
+	store.EXPECT().GetActiveStockAlert(gomock.Any(), sqlc.GetActiveStockAlertParams{ProductID: 5}).Return(alert, nil)
+	store.EXPECT().MarkStockAlertNotified(gomock.Any(), int64(1)).Return(nil)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 5, BeforeAvailable: 3, AfterAvailable: 2, Threshold: 5}))
+	require.Len(t, mailer.sent, 1)
+
+	// Once delivered, further movements only keep the level current
+	alert.NotifiedAt = types.NewNullTimeValue(time.Now())
+	store.EXPECT().UpdateStockAlertLevel(gomock.Any(), gomock.Any()).Return(nil)
+	store.EXPECT().GetActiveStockAlert(gomock.Any(), sqlc.GetActiveStockAlertParams{ProductID: 5}).Return(alert, nil)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 5, BeforeAvailable: 2, AfterAvailable: 1, Threshold: 5}))
+
+	// Low without an active alert, e.g. after a threshold change, is left alone
+	store.EXPECT().UpdateStockAlertLevel(gomock.Any(), gomock.Any()).Return(nil)
+	store.EXPECT().GetActiveStockAlert(gomock.Any(), sqlc.GetActiveStockAlertParams{ProductID: 6}).Return(sqlc.GetActiveStockAlertRow{}, pgx.ErrNoRows)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 6, BeforeAvailable: 2, AfterAvailable: 1, Threshold: 5}))
+	require.Len(t, mailer.sent, 1)
+}
+
+func TestLowStockAlertsAreKeptPerSku(t *testing.T) {
+	ctx := context.Background()
+	n, store, mailer := newTestNotifier(t)
+	red, blue := utils.Ptr(int64(7)), utils.Ptr(int64(8))
+	code := func(c string) *string { return &c }
+
+	// Both SKUs of one product run out the same day: each gets its own alert and delivery
+	store.EXPECT().CreateStockAlert(gomock.Any(), sqlc.CreateStockAlertParams{ProductID: 5, SkuID: red, Threshold: 2}).Return(sqlc.StockAlert{ID: 1}, nil)
+	store.EXPECT().GetActiveStockAlert(gomock.Any(), sqlc.GetActiveStockAlertParams{ProductID: 5, SkuID: red}).
+		Return(sqlc.GetActiveStockAlertRow{ID: 1, ProductID: 5, SkuID: red, ProductName: "Mug", SkuCode: code("MUG-RED")}, nil)
+	store.EXPECT().MarkStockAlertNotified(gomock.Any(), int64(1)).Return(nil)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 5, SkuID: red, BeforeAvailable: 4, AfterAvailable: 0, Threshold: 2}))
+
+	store.EXPECT().CreateStockAlert(gomock.Any(), sqlc.CreateStockAlertParams{ProductID: 5, SkuID: blue, Threshold: 2}).Return(sqlc.StockAlert{ID: 2}, nil)
+	store.EXPECT().GetActiveStockAlert(gomock.Any(), sqlc.GetActiveStockAlertParams{ProductID: 5, SkuID: blue}).
+		Return(sqlc.GetActiveStockAlertRow{ID: 2, ProductID: 5, SkuID: blue, ProductName: "Mug", SkuCode: code("MUG-BLUE")}, nil)
+	store.EXPECT().MarkStockAlertNotified(gomock.Any(), int64(2)).Return(nil)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 5, SkuID: blue, BeforeAvailable: 3, AfterAvailable: 0, Threshold: 2}))
+
+	require.Equal(t, []string{"GoMall - Low Stock: Mug (MUG-RED)", "GoMall - Low Stock: Mug (MUG-BLUE)"}, mailer.sent)
+
+	// One SKU moving only updates and resolves its own alert
+	store.EXPECT().UpdateStockAlertLevel(gomock.Any(), sqlc.UpdateStockAlertLevelParams{AvailableStock: 1, ProductID: 5, SkuID: blue}).Return(nil)
+	store.EXPECT().GetActiveStockAlert(gomock.Any(), sqlc.GetActiveStockAlertParams{ProductID: 5, SkuID: blue}).
+		Return(sqlc.GetActiveStockAlertRow{ID: 2, NotifiedAt: types.NewNullTimeValue(time.Now())}, nil)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 5, SkuID: blue, BeforeAvailable: 0, AfterAvailable: 1, Threshold: 2}))
+
+	store.EXPECT().ResolveStockAlerts(gomock.Any(), sqlc.ResolveStockAlertsParams{AvailableStock: 10, ProductID: 5, SkuID: red}).Return(nil)
+	require.NoError(t, n.handle(ctx, StockChange{ProductID: 5, SkuID: red, BeforeAvailable: 0, AfterAvailable: 10, Threshold: 2}))
+}
@@ -0,0 +1,396 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	dberrors "gomall/db"
+	"gomall/db/sqlc"
+	"gomall/utils"
+	"gomall/utils/types"
+)
+
+// Stock policies
+const (
+	StockPolicyDeny      = "deny"
+	StockPolicyBackorder = "backorder"
+	StockPolicyPreorder  = "preorder"
+)
+
+const (
+	// BackorderReferenceType links allocation logs to inventory_backorders
+	BackorderReferenceType = "backorder"
+
+	// backorderPaymentWindow is how long a backordered order stays payable once its stock arrives
+	backorderPaymentWindow = 24 * time.Hour
+)
+
+// preorderPending reports whether a pre-order product has not been released yet
+func preorderPending(inv sqlc.Inventory, now time.Time) bool {
+	return inv.StockPolicy == StockPolicyPreorder && inv.PreorderReleaseAt.Valid && now.Before(inv.PreorderReleaseAt.Time)
+}
+
+// shouldBackorder decides whether a request goes on the backorder queue instead of reserving stock.
+// Once a queue exists new requests join it, so restocks are allocated first come, first served.
+func shouldBackorder(inv sqlc.Inventory, quantity int32, now time.Time) bool {
+	if inv.StockPolicy == StockPolicyDeny {
+		return false
+	}
+	return preorderPending(inv, now) || inv.BackorderedStock > 0 || inv.AvailableStock < quantity
+}
+
+// withinBackorderLimit reports whether quantity more units may be backordered
+func withinBackorderLimit(inv sqlc.Inventory, quantity int32) bool {
+	return inv.BackorderLimit == nil || inv.BackorderedStock+quantity <= *inv.BackorderLimit
+}
+
+// UpdateStockPolicy sets how a product, or one of its SKUs, behaves once available stock runs out
+func (s *service) UpdateStockPolicy(ctx context.Context, productID int64, req UpdateStockPolicyRequest) (*InventoryResponse, error) {
+	current, err := getStockUnit(ctx, s.repo, productID, req.SkuID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("inventory not found")
+		}
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
+	}
+
+	params := sqlc.UpdateStockPolicyParams{
+		StockPolicy: req.Policy,
+		ID:          current.ID,
+	}
+
+	switch req.Policy {
+	case StockPolicyBackorder:
+		params.BackorderLimit = req.BackorderLimit
+	case StockPolicyPreorder:
+		if req.PreorderReleaseAt == nil {
+			return nil, errors.New("preorder_release_at is required for preorder policy")
+		}
+		params.BackorderLimit = req.BackorderLimit
+		params.PreorderReleaseAt = types.NewNullTimeValue(*req.PreorderReleaseAt)
+	}
+
+	inventory, err := s.repo.UpdateStockPolicy(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("inventory not found")
+		}
+		return nil, fmt.Errorf("failed to update stock policy: %w", err)
+	}
+
+	// A release date moved into the past frees pre-orders for allocation
+	if inventory.BackorderedStock > 0 {
+		s.tryAllocateBackorders(ctx, productID, req.SkuID)
+	}
+
+	resp := toInventoryResponse(inventory)
+	return &resp, nil
+}
+
+// ReserveStockWithBackorder reserves stock like ReserveStock, but when the product's policy allows it
+// a request that cannot be filled is accepted as a backorder for the whole quantity instead
+func (s *service) ReserveStockWithBackorder(ctx context.Context, req ReserveStockRequest, expiresInMinutes int) (*ReserveStockResult, error) {
+	result := &ReserveStockResult{ProductID: req.ProductID, SkuID: req.SkuID}
+
+	var change *StockChange
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		inventory, err := getStockUnit(ctx, q, req.ProductID, req.SkuID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errors.New("inventory not found")
+			}
+			return fmt.Errorf("failed to get inventory: %w", err)
+		}
+
+		if !shouldBackorder(inventory, req.Quantity, time.Now()) {
+			if inventory.AvailableStock < req.Quantity {
+				return dberrors.ErrInsufficientStock
+			}
+			if err := reserveStockTx(ctx, q, inventory, req, expiresInMinutes); err != nil {
+				return err
+			}
+			stockChange := newStockChange(inventory, inventory.AvailableStock-req.Quantity)
+			change = &stockChange
+			result.ReservedQuantity = req.Quantity
+			return nil
+		}
+
+		if !withinBackorderLimit(inventory, req.Quantity) {
+			return dberrors.ErrInsufficientStock
+		}
+
+		backorder, err := q.CreateBackorder(ctx, sqlc.CreateBackorderParams{
+			ProductID: req.ProductID,
+			SkuID:     req.SkuID,
+			OrderID:   req.OrderID,
+			Quantity:  req.Quantity,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create backorder: %w", err)
+		}
+
+		rows, err := q.AddBackorderedStock(ctx, sqlc.AddBackorderedStockParams{
+			Quantity: req.Quantity,
+			ID:       inventory.ID,
+			Version:  inventory.Version,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to backorder stock: %w", err)
+		}
+		if rows == 0 {
+			return errors.New("failed to backorder stock (possible concurrent update)")
+		}
+
+		result.BackorderedQuantity = req.Quantity
+		result.BackorderID = &backorder.ID
+		result.PreorderReleaseAt = inventory.PreorderReleaseAt.Ptr()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if change != nil {
+		s.notifyStockChange(*change)
+	}
+	return result, nil
+}
+
+// HoldOrderReservations stops an order's reservations from expiring while it waits for backorders
+func (s *service) HoldOrderReservations(ctx context.Context, orderID int64) error {
+	if err := s.repo.HoldOrderReservations(ctx, orderID); err != nil {
+		return fmt.Errorf("failed to hold reservations: %w", err)
+	}
+	return nil
+}
+
+// CancelOrderBackorders cancels an order's pending backorders and returns the affected stock units.
+// Those lines never reserved stock, so callers must not release stock for them.
+func (s *service) CancelOrderBackorders(ctx context.Context, orderID int64) ([]StockKey, error) {
+	var keys []StockKey
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		backorders, err := q.CancelOrderBackorders(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to cancel backorders: %w", err)
+		}
+
+		for _, backorder := range backorders {
+			inventory, err := getStockUnit(ctx, q, backorder.ProductID, backorder.SkuID)
+			if err != nil {
+				return fmt.Errorf("failed to get inventory: %w", err)
+			}
+
+			err = q.ReleaseBackorderedStock(ctx, sqlc.ReleaseBackorderedStockParams{
+				Quantity: backorder.Quantity,
+				ID:       inventory.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to release backordered stock: %w", err)
+			}
+			keys = append(keys, NewStockKey(backorder.ProductID, backorder.SkuID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// AllocateBackorders reserves available stock for pending backorders in FIFO order.
+// Allocation stops at the first backorder that does not fit so later orders never jump the queue.
+// Orders whose backorders are all allocated move from backordered to pending and become payable.
+func (s *service) AllocateBackorders(ctx context.Context, productID int64, skuID *int64) (*AllocateBackordersResponse, error) {
+	result := &AllocateBackordersResponse{
+		ProductID:      productID,
+		SkuID:          skuID,
+		PromotedOrders: []int64{},
+	}
+
+	now := time.Now()
+	var change *StockChange
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		inventory, err := getStockUnit(ctx, q, productID, skuID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errors.New("inventory not found")
+			}
+			return fmt.Errorf("failed to get inventory: %w", err)
+		}
+
+		if inventory.BackorderedStock == 0 || preorderPending(inventory, now) {
+			return nil
+		}
+
+		pending, err := q.ListPendingBackordersForUpdate(ctx, sqlc.ListPendingBackordersForUpdateParams{
+			ProductID: productID,
+			SkuID:     skuID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list backorders: %w", err)
+		}
+
+		available := inventory.AvailableStock
+		reserved := inventory.ReservedStock
+		version := inventory.Version
+		payableUntil := now.Add(backorderPaymentWindow)
+		var orderIDs []int64
+
+		for _, backorder := range pending {
+			if backorder.Quantity > available {
+				break
+			}
+
+			rows, err := q.AllocateBackorderedStock(ctx, sqlc.AllocateBackorderedStockParams{
+				Quantity: backorder.Quantity,
+				ID:       inventory.ID,
+				Version:  version,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to allocate backorder: %w", err)
+			}
+			if rows == 0 {
+				return errors.New("failed to allocate backorder (possible concurrent update)")
+			}
+			version++
+
+			if err := q.MarkBackorderAllocated(ctx, backorder.ID); err != nil {
+				return fmt.Errorf("failed to mark backorder allocated: %w", err)
+			}
+
+			// Held until every backorder on the order is allocated
+			_, err = q.CreateInventoryReservation(ctx, sqlc.CreateInventoryReservationParams{
+				ProductID: productID,
+				SkuID:     skuID,
+				OrderID:   backorder.OrderID,
+				Quantity:  backorder.Quantity,
+				Status:    utils.Ptr("held"),
+				ExpiresAt: payableUntil,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create reservation: %w", err)
+			}
+
+			_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
+				ProductID:       productID,
+				SkuID:           skuID,
+				OrderID:         &backorder.OrderID,
+				ChangeType:      "reserve",
+				QuantityChange:  backorder.Quantity,
+				BeforeAvailable: available,
+				AfterAvailable:  available - backorder.Quantity,
+				BeforeReserved:  reserved,
+				AfterReserved:   reserved + backorder.Quantity,
+				Reason:          utils.Ptr("Stock allocated to backorder"),
+				ReferenceType:   utils.Ptr(BackorderReferenceType),
+				ReferenceID:     &backorder.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create inventory log: %w", err)
+			}
+
+			available -= backorder.Quantity
+			reserved += backorder.Quantity
+			result.Allocated++
+			result.AllocatedQuantity += backorder.Quantity
+			orderIDs = append(orderIDs, backorder.OrderID)
+		}
+
+		for _, orderID := range orderIDs {
+			promoted, err := q.PromoteBackorderedOrder(ctx, orderID)
+			if err != nil {
+				return fmt.Errorf("failed to promote order: %w", err)
+			}
+			if promoted == 0 {
+				continue
+			}
+
+			err = q.ActivateOrderReservations(ctx, sqlc.ActivateOrderReservationsParams{
+				OrderID:   orderID,
+				ExpiresAt: payableUntil,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to activate reservations: %w", err)
+			}
+			result.PromotedOrders = append(result.PromotedOrders, orderID)
+		}
+
+		if result.Allocated > 0 {
+			stockChange := newStockChange(inventory, available)
+			change = &stockChange
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if change != nil {
+		s.notifyStockChange(*change)
+	}
+	return result, nil
+}
+
+// tryAllocateBackorders runs allocation after stock was added. Failures are logged and left
+// for the next stock movement or a manual allocation.
+func (s *service) tryAllocateBackorders(ctx context.Context, productID int64, skuID *int64) {
+	if _, err := s.AllocateBackorders(ctx, productID, skuID); err != nil {
+		log.Printf("failed to allocate backorders for product %d: %v", productID, err)
+	}
+}
+
+// ListBackorders lists the backorder queue of a product
+func (s *service) ListBackorders(ctx context.Context, productID int64, req ListBackordersRequest) (*PaginatedBackordersResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 20
+	}
+
+	var status *string
+	if req.Status != "" {
+		status = &req.Status
+	}
+
+	backorders, err := s.repo.ListBackorders(ctx, sqlc.ListBackordersParams{
+		ProductID:   productID,
+		SkuID:       req.SkuID,
+		Status:      status,
+		LimitCount:  req.PageSize,
+		OffsetCount: (req.Page - 1) * req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backorders: %w", err)
+	}
+
+	total, err := s.repo.CountBackorders(ctx, sqlc.CountBackordersParams{
+		ProductID: productID,
+		SkuID:     req.SkuID,
+		Status:    status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count backorders: %w", err)
+	}
+
+	responses := make([]BackorderResponse, len(backorders))
+	for i, backorder := range backorders {
+		responses[i] = toBackorderResponse(backorder)
+	}
+
+	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+
+	return &PaginatedBackordersResponse{
+		Backorders: responses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
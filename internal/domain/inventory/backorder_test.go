@@ -0,0 +1,47 @@
+package inventory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+	"gomall/utils/types"
+)
+
+func TestShouldBackorder(t *testing.T) {
+	now := time.Now()
+
+	deny := sqlc.Inventory{StockPolicy: StockPolicyDeny, AvailableStock: 0}
+	require.False(t, shouldBackorder(deny, 5, now))
+
+	backorder := sqlc.Inventory{StockPolicy: StockPolicyBackorder, AvailableStock: 10}
+	require.False(t, shouldBackorder(backorder, 5, now))
+	require.True(t, shouldBackorder(backorder, 11, now))
+
+	// New requests join an existing queue even when stock is available
+	backorder.BackorderedStock = 3
+	require.True(t, shouldBackorder(backorder, 1, now))
+
+	preorder := sqlc.Inventory{
+		StockPolicy:       StockPolicyPreorder,
+		AvailableStock:    100,
+		PreorderReleaseAt: types.NewNullTimeValue(now.Add(24 * time.Hour)),
+	}
+	require.True(t, shouldBackorder(preorder, 1, now))
+	require.True(t, preorderPending(preorder, now))
+
+	// After the release date a pre-order product sells from stock like any other
+	require.False(t, shouldBackorder(preorder, 1, now.Add(48*time.Hour)))
+}
+
+func TestWithinBackorderLimit(t *testing.T) {
+	unlimited := sqlc.Inventory{StockPolicy: StockPolicyBackorder, BackorderedStock: 1000}
+	require.True(t, withinBackorderLimit(unlimited, 1000))
+
+	limited := sqlc.Inventory{StockPolicy: StockPolicyBackorder, BackorderedStock: 8, BackorderLimit: utils.Ptr(int32(10))}
+	require.True(t, withinBackorderLimit(limited, 2))
+	require.False(t, withinBackorderLimit(limited, 3))
+}
@@ -1,18 +1,21 @@
 package inventory
 
 import (
+	"encoding/json"
+	"time"
+
 	"gomall/db/sqlc"
 	"gomall/utils"
-	"time"
 )
 
 // Request DTOs
 
 type CreateInventoryRequest struct {
-	ProductID         int64 `json:"product_id" binding:"required"`
-	AvailableStock    int32 `json:"available_stock" binding:"required,min=0"`
-	ReservedStock     int32 `json:"reserved_stock,omitempty" binding:"min=0"`
-	LowStockThreshold int32 `json:"low_stock_threshold,omitempty" binding:"min=0"`
+	ProductID         int64  `json:"product_id" binding:"required"`
+	SkuID             *int64 `json:"sku_id,omitempty"`
+	AvailableStock    int32  `json:"available_stock" binding:"required,min=0"`
+	ReservedStock     int32  `json:"reserved_stock,omitempty" binding:"min=0"`
+	LowStockThreshold int32  `json:"low_stock_threshold,omitempty" binding:"min=0"`
 }
 
 type UpdateInventoryStockRequest struct {
@@ -21,43 +24,71 @@ type UpdateInventoryStockRequest struct {
 }
 
 type StockCheckItem struct {
-	ProductID int64 `json:"product_id" binding:"required"`
-	Quantity  int32 `json:"quantity" binding:"required,min=1"`
+	ProductID int64  `json:"product_id" binding:"required"`
+	SkuID     *int64 `json:"sku_id,omitempty"`
+	Quantity  int32  `json:"quantity" binding:"required,min=1"`
 }
 
 
 type ReserveStockRequest struct {
-	ProductID int64 `json:"product_id" binding:"required"`
-	Quantity  int32 `json:"quantity" binding:"required,min=1"`
-	OrderID   int64 `json:"order_id" binding:"required"`
+	ProductID int64  `json:"product_id" binding:"required"`
+	SkuID     *int64 `json:"sku_id,omitempty"`
+	Quantity  int32  `json:"quantity" binding:"required,min=1"`
+	OrderID   int64  `json:"order_id" binding:"required"`
+}
+
+type UpdateStockPolicyRequest struct {
+	// Set to change the policy of one SKU instead of the product
+	SkuID  *int64 `json:"sku_id,omitempty"`
+	Policy string `json:"policy" binding:"required,oneof=deny backorder preorder"`
+	// Maximum outstanding backordered units; omit for no limit
+	BackorderLimit *int32 `json:"backorder_limit,omitempty" binding:"omitempty,min=0"`
+	// Required for preorder: orders are held as backorders until this time
+	PreorderReleaseAt *time.Time `json:"preorder_release_at,omitempty"`
+}
+
+type ListBackordersRequest struct {
+	SkuID    *int64 `form:"sku_id"`
+	Status   string `form:"status" binding:"omitempty,oneof=pending allocated cancelled"`
+	Page     int32  `form:"page"`
+	PageSize int32  `form:"page_size"`
 }
 
 type ReleaseStockRequest struct {
-	ProductID int64 `json:"product_id" binding:"required"`
-	Quantity  int32 `json:"quantity" binding:"required,min=1"`
-	OrderID   int64 `json:"order_id" binding:"required"`
+	ProductID int64  `json:"product_id" binding:"required"`
+	SkuID     *int64 `json:"sku_id,omitempty"`
+	Quantity  int32  `json:"quantity" binding:"required,min=1"`
+	OrderID   int64  `json:"order_id" binding:"required"`
 }
 
 type DeductStockRequest struct {
-	ProductID int64 `json:"product_id" binding:"required"`
-	Quantity  int32 `json:"quantity" binding:"required,min=1"`
-	OrderID   int64 `json:"order_id" binding:"required"`
+	ProductID int64  `json:"product_id" binding:"required"`
+	SkuID     *int64 `json:"sku_id,omitempty"`
+	Quantity  int32  `json:"quantity" binding:"required,min=1"`
+	OrderID   int64  `json:"order_id" binding:"required"`
 }
 
 type RestockRequest struct {
 	ProductID int64  `json:"product_id" binding:"required"`
+	SkuID     *int64 `json:"sku_id,omitempty"`
 	Quantity  int32  `json:"quantity" binding:"required,min=1"`
 	Reason    string `json:"reason,omitempty" binding:"max=500"`
+
+	// Set by other domains to link the restock log to a business document (e.g. a purchase order)
+	ReferenceType string `json:"-"`
+	ReferenceID   *int64 `json:"-"`
 }
 
 type AdjustStockRequest struct {
 	ProductID int64  `json:"product_id" binding:"required"`
+	SkuID     *int64 `json:"sku_id,omitempty"`
 	Quantity  int32  `json:"quantity" binding:"required"`
 	Reason    string `json:"reason" binding:"required,max=500"`
 }
 
 type UpdateLowStockThresholdRequest struct {
-	Threshold int32 `json:"threshold" binding:"required,min=0"`
+	SkuID     *int64 `json:"sku_id,omitempty"`
+	Threshold int32  `json:"threshold" binding:"required,min=0"`
 }
 
 type ListInventoriesRequest struct {
@@ -71,24 +102,73 @@ type ListInventoryLogsRequest struct {
 	PageSize  int32 `form:"page_size" binding:"min=1,max=100"`
 }
 
+type CreateStocktakeRequest struct {
+	ProductIDs []int64 `json:"product_ids,omitempty" binding:"omitempty,max=1000,dive,min=1"`
+	CategoryID *int64  `json:"category_id,omitempty" binding:"omitempty,min=1"`
+	Note       string  `json:"note,omitempty" binding:"max=500"`
+}
+
+type StocktakeCount struct {
+	ProductID       int64  `json:"product_id" binding:"required"`
+	SkuID           *int64 `json:"sku_id,omitempty" binding:"omitempty,min=1"` // required for products with variants
+	CountedQuantity *int32 `json:"counted_quantity" binding:"required,min=0"`
+}
+
+type RecordStocktakeCountsRequest struct {
+	Counts []StocktakeCount `json:"counts" binding:"required,min=1,max=1000,dive"`
+}
+
+type MovementReportRequest struct {
+	From      time.Time
+	To        time.Time
+	Period    string
+	ProductID *int64
+	Page      int32
+	PageSize  int32
+}
+
+type ListReorderSuggestionsRequest struct {
+	CategoryID   *int64 `form:"category_id"`
+	NeedsReorder bool   `form:"needs_reorder"`
+	Page         int32  `form:"page"`
+	PageSize     int32  `form:"page_size"`
+}
+
+type ApplyReorderPointsRequest struct {
+	CategoryID int64 `json:"category_id" binding:"required"`
+}
+
+type UpsertReorderSettingRequest struct {
+	LeadTimeDays    int32 `json:"lead_time_days" binding:"required,min=1,max=365"`
+	SafetyStockDays int32 `json:"safety_stock_days" binding:"min=0,max=365"`
+	// Copy reorder points into low_stock_threshold whenever suggestions are refreshed
+	AutoApply bool `json:"auto_apply"`
+}
+
 // Response DTOs
 
 type InventoryResponse struct {
-	ID                int64     `json:"id"`
-	ProductID         int64     `json:"product_id"`
-	AvailableStock    int32     `json:"available_stock"`
-	ReservedStock     int32     `json:"reserved_stock"`
-	TotalStock        int32     `json:"total_stock"`
-	LowStockThreshold int32     `json:"low_stock_threshold"`
-	IsLowStock        bool      `json:"is_low_stock"`
-	Version           int64     `json:"version"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	ID                int64      `json:"id"`
+	ProductID         int64      `json:"product_id"`
+	SkuID             *int64     `json:"sku_id,omitempty"`
+	AvailableStock    int32      `json:"available_stock"`
+	ReservedStock     int32      `json:"reserved_stock"`
+	TotalStock        int32      `json:"total_stock"`
+	LowStockThreshold int32      `json:"low_stock_threshold"`
+	IsLowStock        bool       `json:"is_low_stock"`
+	StockPolicy       string     `json:"stock_policy"`
+	BackorderLimit    *int32     `json:"backorder_limit,omitempty"`
+	PreorderReleaseAt *time.Time `json:"preorder_release_at,omitempty"`
+	BackorderedStock  int32      `json:"backordered_stock"`
+	Version           int64      `json:"version"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
 }
 
 type InventoryLogResponse struct {
 	ID              int64     `json:"id"`
 	ProductID       int64     `json:"product_id"`
+	SkuID           *int64    `json:"sku_id,omitempty"`
 	OrderID         *int64    `json:"order_id,omitempty"`
 	ChangeType      string    `json:"change_type"`
 	QuantityChange  int32     `json:"quantity_change"`
@@ -98,12 +178,15 @@ type InventoryLogResponse struct {
 	AfterReserved   int32     `json:"after_reserved"`
 	Reason          string    `json:"reason,omitempty"`
 	OperatorID      *int64    `json:"operator_id,omitempty"`
+	ReferenceType   string    `json:"reference_type,omitempty"`
+	ReferenceID     *int64    `json:"reference_id,omitempty"`
 	CreatedAt       time.Time `json:"created_at"`
 }
 
 type InventoryReservationResponse struct {
 	ID        int64     `json:"id"`
 	ProductID int64     `json:"product_id"`
+	SkuID     *int64    `json:"sku_id,omitempty"`
 	OrderID   int64     `json:"order_id"`
 	Quantity  int32     `json:"quantity"`
 	Status    string    `json:"status"`
@@ -128,12 +211,236 @@ type PaginatedInventoryLogsResponse struct {
 	TotalPages int32                  `json:"total_pages"`
 }
 
+type StockAlertResponse struct {
+	ID             int64      `json:"id"`
+	ProductID      int64      `json:"product_id"`
+	ProductName    string     `json:"product_name"`
+	SkuID          *int64     `json:"sku_id,omitempty"`
+	SkuCode        *string    `json:"sku_code,omitempty"`
+	AvailableStock int32      `json:"available_stock"`
+	Threshold      int32      `json:"threshold"`
+	NotifiedAt     *time.Time `json:"notified_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type LowStockDigestResponse struct {
+	GeneratedAt     time.Time            `json:"generated_at"`
+	ActiveCount     int64                `json:"active_count"`
+	OutOfStockCount int64                `json:"out_of_stock_count"`
+	ResolvedLast24h int64                `json:"resolved_last_24h"`
+	Alerts          []StockAlertResponse `json:"alerts"`
+	Page            int32                `json:"page"`
+	PageSize        int32                `json:"page_size"`
+	TotalPages      int32                `json:"total_pages"`
+}
+
+type ImportRowError struct {
+	Line      int    `json:"line"`
+	ProductID int64  `json:"product_id,omitempty"`
+	SkuID     *int64 `json:"sku_id,omitempty"`
+	Message   string `json:"message"`
+}
+
+type ImportValidationResponse struct {
+	TotalRows int              `json:"total_rows"`
+	ValidRows int              `json:"valid_rows"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+type ImportJobResponse struct {
+	ID            int64            `json:"id"`
+	Filename      string           `json:"filename"`
+	Status        string           `json:"status"`
+	TotalRows     int32            `json:"total_rows"`
+	ProcessedRows int32            `json:"processed_rows"`
+	SucceededRows int32            `json:"succeeded_rows"`
+	FailedRows    int32            `json:"failed_rows"`
+	Errors        []ImportRowError `json:"errors"`
+	ErrorMessage  string           `json:"error_message,omitempty"`
+	OperatorID    *int64           `json:"operator_id,omitempty"`
+	StartedAt     *time.Time       `json:"started_at,omitempty"`
+	FinishedAt    *time.Time       `json:"finished_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+type ImportInventoryResponse struct {
+	DryRun     bool                     `json:"dry_run"`
+	Validation ImportValidationResponse `json:"validation"`
+	Job        *ImportJobResponse       `json:"job,omitempty"`
+}
+
+type StocktakeResponse struct {
+	ID         int64      `json:"id"`
+	Status     string     `json:"status"`
+	CategoryID *int64     `json:"category_id,omitempty"`
+	Note       string     `json:"note,omitempty"`
+	CreatedBy  *int64     `json:"created_by,omitempty"`
+	ApprovedBy *int64     `json:"approved_by,omitempty"`
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type StocktakeItemResponse struct {
+	ProductID        int64      `json:"product_id"`
+	ProductName      string     `json:"product_name"`
+	SkuID            *int64     `json:"sku_id,omitempty"`
+	SkuCode          *string    `json:"sku_code,omitempty"`
+	ExpectedQuantity int32      `json:"expected_quantity"`
+	AvailableStock   int32      `json:"available_stock"`
+	ReservedStock    int32      `json:"reserved_stock"`
+	SystemQuantity   int32      `json:"system_quantity"`
+	CountedQuantity  *int32     `json:"counted_quantity,omitempty"`
+	Variance         *int32     `json:"variance,omitempty"`
+	CountedBy        *int64     `json:"counted_by,omitempty"`
+	CountedAt        *time.Time `json:"counted_at,omitempty"`
+}
+
+type StocktakeDetailResponse struct {
+	Stocktake     StocktakeResponse       `json:"stocktake"`
+	Items         []StocktakeItemResponse `json:"items"`
+	TotalItems    int                     `json:"total_items"`
+	CountedItems  int                     `json:"counted_items"`
+	VarianceItems int                     `json:"variance_items"`
+	NetVariance   int32                   `json:"net_variance"`
+}
+
+type PaginatedStocktakesResponse struct {
+	Stocktakes []StocktakeResponse `json:"stocktakes"`
+	Total      int64               `json:"total"`
+	Page       int32               `json:"page"`
+	PageSize   int32               `json:"page_size"`
+	TotalPages int32               `json:"total_pages"`
+}
+
+type StockAsOfResponse struct {
+	ProductID      int64     `json:"product_id"`
+	SkuID          *int64    `json:"sku_id,omitempty"`
+	AsOf           time.Time `json:"as_of"`
+	Exists         bool      `json:"exists"`
+	AvailableStock int32     `json:"available_stock"`
+	ReservedStock  int32     `json:"reserved_stock"`
+	TotalStock     int32     `json:"total_stock"`
+	Source         string    `json:"source"`
+	LogID          *int64    `json:"log_id,omitempty"`
+}
+
+type MovementReportRow struct {
+	PeriodStart        time.Time `json:"period_start"`
+	ProductID          int64     `json:"product_id"`
+	ProductName        string    `json:"product_name"`
+	Restocked          int64     `json:"restocked"`
+	Reserved           int64     `json:"reserved"`
+	Released           int64     `json:"released"`
+	Deducted           int64     `json:"deducted"`
+	Adjusted           int64     `json:"adjusted"`
+	NetAvailableChange int64     `json:"net_available_change"`
+	NetReservedChange  int64     `json:"net_reserved_change"`
+	MovementCount      int64     `json:"movement_count"`
+}
+
+type MovementReportResponse struct {
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Period     string              `json:"period"`
+	Rows       []MovementReportRow `json:"rows"`
+	Total      int64               `json:"total"`
+	Page       int32               `json:"page"`
+	PageSize   int32               `json:"page_size"`
+	TotalPages int32               `json:"total_pages"`
+}
+
+type ReorderSuggestionResponse struct {
+	ProductID        int64     `json:"product_id"`
+	ProductName      string    `json:"product_name"`
+	SkuID            *int64    `json:"sku_id,omitempty"`
+	SkuCode          *string   `json:"sku_code,omitempty"`
+	CategoryID       int64     `json:"category_id"`
+	WindowDays       int32     `json:"window_days"`
+	UnitsSold        int32     `json:"units_sold"`
+	DailyVelocity    float64   `json:"daily_velocity"`
+	LeadTimeDays     int32     `json:"lead_time_days"`
+	SafetyStockDays  int32     `json:"safety_stock_days"`
+	ReorderPoint     int32     `json:"reorder_point"`
+	ReorderQuantity  int32     `json:"reorder_quantity"`
+	CurrentThreshold *int32    `json:"current_threshold,omitempty"`
+	AvailableStock   int32     `json:"available_stock"`
+	NeedsReorder     bool      `json:"needs_reorder"`
+	ComputedAt       time.Time `json:"computed_at"`
+}
+
+type PaginatedReorderSuggestionsResponse struct {
+	Suggestions []ReorderSuggestionResponse `json:"suggestions"`
+	Total       int64                       `json:"total"`
+	Page        int32                       `json:"page"`
+	PageSize    int32                       `json:"page_size"`
+	TotalPages  int32                       `json:"total_pages"`
+}
+
+type ReorderRefreshResponse struct {
+	Products              int     `json:"products"`
+	AutoAppliedCategories []int64 `json:"auto_applied_categories"`
+	ThresholdsUpdated     int64   `json:"thresholds_updated"`
+}
+
+type ApplyReorderPointsResponse struct {
+	CategoryID        int64 `json:"category_id"`
+	ThresholdsUpdated int64 `json:"thresholds_updated"`
+}
+
+type ReorderSettingResponse struct {
+	CategoryID      int64     `json:"category_id"`
+	LeadTimeDays    int32     `json:"lead_time_days"`
+	SafetyStockDays int32     `json:"safety_stock_days"`
+	AutoApply       bool      `json:"auto_apply"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
 type StockCheckResponse struct {
-	ProductID      int64 `json:"product_id"`
-	AvailableStock int32 `json:"available_stock"`
-	ReservedStock  int32 `json:"reserved_stock"`
-	IsAvailable    bool  `json:"is_available"`
-	RequestedQty   int32 `json:"requested_qty"`
+	ProductID      int64  `json:"product_id"`
+	SkuID          *int64 `json:"sku_id,omitempty"`
+	AvailableStock int32  `json:"available_stock"`
+	ReservedStock  int32  `json:"reserved_stock"`
+	IsAvailable    bool   `json:"is_available"`
+	RequestedQty   int32  `json:"requested_qty"`
+	// The request would be accepted as a backorder or pre-order
+	BackorderAllowed bool `json:"backorder_allowed"`
+}
+
+type ReserveStockResult struct {
+	ProductID           int64      `json:"product_id"`
+	SkuID               *int64     `json:"sku_id,omitempty"`
+	ReservedQuantity    int32      `json:"reserved_quantity"`
+	BackorderedQuantity int32      `json:"backordered_quantity"`
+	BackorderID         *int64     `json:"backorder_id,omitempty"`
+	PreorderReleaseAt   *time.Time `json:"preorder_release_at,omitempty"`
+}
+
+type BackorderResponse struct {
+	ID          int64      `json:"id"`
+	ProductID   int64      `json:"product_id"`
+	SkuID       *int64     `json:"sku_id,omitempty"`
+	OrderID     int64      `json:"order_id"`
+	Quantity    int32      `json:"quantity"`
+	Status      string     `json:"status"`
+	AllocatedAt *time.Time `json:"allocated_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type PaginatedBackordersResponse struct {
+	Backorders []BackorderResponse `json:"backorders"`
+	Total      int64               `json:"total"`
+	Page       int32               `json:"page"`
+	PageSize   int32               `json:"page_size"`
+	TotalPages int32               `json:"total_pages"`
+}
+
+type AllocateBackordersResponse struct {
+	ProductID         int64   `json:"product_id"`
+	SkuID             *int64  `json:"sku_id,omitempty"`
+	Allocated         int     `json:"allocated"`
+	AllocatedQuantity int32   `json:"allocated_quantity"`
+	PromotedOrders    []int64 `json:"promoted_orders"`
 }
 
 // Conversion functions
@@ -146,11 +453,16 @@ func toInventoryResponse(inv sqlc.Inventory) InventoryResponse {
 	return InventoryResponse{
 		ID:                inv.ID,
 		ProductID:         inv.ProductID,
+		SkuID:             inv.SkuID,
 		AvailableStock:    inv.AvailableStock,
 		ReservedStock:     inv.ReservedStock,
 		TotalStock:        totalStock,
 		LowStockThreshold: threshold,
 		IsLowStock:        isLowStock,
+		StockPolicy:       inv.StockPolicy,
+		BackorderLimit:    inv.BackorderLimit,
+		PreorderReleaseAt: inv.PreorderReleaseAt.Ptr(),
+		BackorderedStock:  inv.BackorderedStock,
 		Version:           inv.Version,
 		CreatedAt:         inv.CreatedAt,
 		UpdatedAt:         inv.UpdatedAt,
@@ -161,6 +473,7 @@ func toInventoryLogResponse(log sqlc.InventoryLog) InventoryLogResponse {
 	return InventoryLogResponse{
 		ID:              log.ID,
 		ProductID:       log.ProductID,
+		SkuID:           log.SkuID,
 		OrderID:         log.OrderID,
 		ChangeType:      log.ChangeType,
 		QuantityChange:  log.QuantityChange,
@@ -170,6 +483,8 @@ func toInventoryLogResponse(log sqlc.InventoryLog) InventoryLogResponse {
 		AfterReserved:   log.AfterReserved,
 		Reason:          utils.PtrValue(log.Reason),
 		OperatorID:      log.OperatorID,
+		ReferenceType:   utils.PtrValue(log.ReferenceType),
+		ReferenceID:     log.ReferenceID,
 		CreatedAt:       log.CreatedAt,
 	}
 }
@@ -178,6 +493,7 @@ func toInventoryReservationResponse(res sqlc.InventoryReservation) InventoryRese
 	return InventoryReservationResponse{
 		ID:        res.ID,
 		ProductID: res.ProductID,
+		SkuID:     res.SkuID,
 		OrderID:   res.OrderID,
 		Quantity:  res.Quantity,
 		Status:    utils.PtrValue(res.Status),
@@ -186,3 +502,178 @@ func toInventoryReservationResponse(res sqlc.InventoryReservation) InventoryRese
 		UpdatedAt: res.UpdatedAt,
 	}
 }
+
+func toStockAlertResponse(row sqlc.ListActiveStockAlertsRow) StockAlertResponse {
+	var notifiedAt *time.Time
+	if row.NotifiedAt.Valid {
+		notifiedAt = utils.Ptr(row.NotifiedAt.Time)
+	}
+
+	return StockAlertResponse{
+		ID:             row.ID,
+		ProductID:      row.ProductID,
+		ProductName:    row.ProductName,
+		SkuID:          row.SkuID,
+		SkuCode:        row.SkuCode,
+		AvailableStock: row.AvailableStock,
+		Threshold:      row.Threshold,
+		NotifiedAt:     notifiedAt,
+		CreatedAt:      row.CreatedAt,
+	}
+}
+
+func toActiveStockAlertResponse(row sqlc.GetActiveStockAlertRow) StockAlertResponse {
+	return toStockAlertResponse(sqlc.ListActiveStockAlertsRow{
+		ID:             row.ID,
+		ProductID:      row.ProductID,
+		ProductName:    row.ProductName,
+		SkuID:          row.SkuID,
+		SkuCode:        row.SkuCode,
+		AvailableStock: row.AvailableStock,
+		Threshold:      row.Threshold,
+		NotifiedAt:     row.NotifiedAt,
+		CreatedAt:      row.CreatedAt,
+	})
+}
+
+func toImportJobResponse(job sqlc.InventoryImportJob) ImportJobResponse {
+	rowErrors := []ImportRowError{}
+	if len(job.Errors) > 0 {
+		// Stored by the import worker; an unreadable payload just yields no row errors
+		_ = json.Unmarshal(job.Errors, &rowErrors)
+	}
+
+	var startedAt, finishedAt *time.Time
+	if job.StartedAt.Valid {
+		startedAt = utils.Ptr(job.StartedAt.Time)
+	}
+	if job.FinishedAt.Valid {
+		finishedAt = utils.Ptr(job.FinishedAt.Time)
+	}
+
+	return ImportJobResponse{
+		ID:            job.ID,
+		Filename:      job.Filename,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		SucceededRows: job.SucceededRows,
+		FailedRows:    job.FailedRows,
+		Errors:        rowErrors,
+		ErrorMessage:  utils.PtrValue(job.ErrorMessage),
+		OperatorID:    job.OperatorID,
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
+		CreatedAt:     job.CreatedAt,
+	}
+}
+
+func toStocktakeResponse(st sqlc.Stocktake) StocktakeResponse {
+	var approvedAt *time.Time
+	if st.ApprovedAt.Valid {
+		approvedAt = utils.Ptr(st.ApprovedAt.Time)
+	}
+
+	return StocktakeResponse{
+		ID:         st.ID,
+		Status:     st.Status,
+		CategoryID: st.CategoryID,
+		Note:       utils.PtrValue(st.Note),
+		CreatedBy:  st.CreatedBy,
+		ApprovedBy: st.ApprovedBy,
+		ApprovedAt: approvedAt,
+		CreatedAt:  st.CreatedAt,
+		UpdatedAt:  st.UpdatedAt,
+	}
+}
+
+// toStocktakeItemResponse reports the posted variance for approved sessions and the
+// live variance against current stock while the session is still open
+func toStocktakeItemResponse(item sqlc.ListStocktakeItemsRow) StocktakeItemResponse {
+	systemQuantity := item.AvailableStock + item.ReservedStock
+
+	variance := item.Variance
+	if variance == nil && item.CountedQuantity != nil {
+		variance = utils.Ptr(*item.CountedQuantity - systemQuantity)
+	}
+
+	var countedAt *time.Time
+	if item.CountedAt.Valid {
+		countedAt = utils.Ptr(item.CountedAt.Time)
+	}
+
+	return StocktakeItemResponse{
+		ProductID:        item.ProductID,
+		ProductName:      item.ProductName,
+		SkuID:            item.SkuID,
+		SkuCode:          item.SkuCode,
+		ExpectedQuantity: item.ExpectedQuantity,
+		AvailableStock:   item.AvailableStock,
+		ReservedStock:    item.ReservedStock,
+		SystemQuantity:   systemQuantity,
+		CountedQuantity:  item.CountedQuantity,
+		Variance:         variance,
+		CountedBy:        item.CountedBy,
+		CountedAt:        countedAt,
+	}
+}
+
+func toMovementReportRow(row sqlc.GetInventoryMovementReportRow) MovementReportRow {
+	return MovementReportRow{
+		PeriodStart:        row.PeriodStart,
+		ProductID:          row.ProductID,
+		ProductName:        row.ProductName,
+		Restocked:          row.Restocked,
+		Reserved:           row.Reserved,
+		Released:           row.Released,
+		Deducted:           row.Deducted,
+		Adjusted:           row.Adjusted,
+		NetAvailableChange: row.NetAvailableChange,
+		NetReservedChange:  row.NetReservedChange,
+		MovementCount:      row.MovementCount,
+	}
+}
+
+func toReorderSuggestionResponse(row sqlc.ListReorderSuggestionsRow) ReorderSuggestionResponse {
+	return ReorderSuggestionResponse{
+		ProductID:        row.ProductID,
+		ProductName:      row.ProductName,
+		SkuID:            row.SkuID,
+		SkuCode:          row.SkuCode,
+		CategoryID:       row.CategoryID,
+		WindowDays:       row.WindowDays,
+		UnitsSold:        row.UnitsSold,
+		DailyVelocity:    row.DailyVelocity,
+		LeadTimeDays:     row.LeadTimeDays,
+		SafetyStockDays:  row.SafetyStockDays,
+		ReorderPoint:     row.ReorderPoint,
+		ReorderQuantity:  row.ReorderQuantity,
+		CurrentThreshold: row.CurrentThreshold,
+		AvailableStock:   row.AvailableStock,
+		NeedsReorder:     row.AvailableStock <= row.ReorderPoint,
+		ComputedAt:       row.ComputedAt,
+	}
+}
+
+func toReorderSettingResponse(setting sqlc.ReorderSetting) ReorderSettingResponse {
+	return ReorderSettingResponse{
+		CategoryID:      setting.CategoryID,
+		LeadTimeDays:    setting.LeadTimeDays,
+		SafetyStockDays: setting.SafetyStockDays,
+		AutoApply:       setting.AutoApply,
+		UpdatedAt:       setting.UpdatedAt,
+	}
+}
+
+func toBackorderResponse(backorder sqlc.InventoryBackorder) BackorderResponse {
+	return BackorderResponse{
+		ID:          backorder.ID,
+		ProductID:   backorder.ProductID,
+		SkuID:       backorder.SkuID,
+		OrderID:     backorder.OrderID,
+		Quantity:    backorder.Quantity,
+		Status:      backorder.Status,
+		AllocatedAt: backorder.AllocatedAt.Ptr(),
+		CreatedAt:   backorder.CreatedAt,
+	}
+}
@@ -1,22 +1,31 @@
 package inventory
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"gomall/internal/common/middleware"
 	"gomall/utils/response"
+	"gomall/utils/token"
+	"log"
 	"net/http"
 	"strconv"
+	"time"
 )
 
+// maxImportFileSize limits the size of an uploaded inventory CSV
+const maxImportFileSize = 10 << 20
+
 // Handler handles inventory-related HTTP requests
 type Handler struct {
-	service Service
+	service    Service
+	tokenMaker token.Maker
 }
 
 // NewHandler creates a new Handler instance
-func NewHandler(service Service) *Handler {
+func NewHandler(service Service, tokenMaker token.Maker) *Handler {
 	return &Handler{
-		service: service,
+		service:    service,
+		tokenMaker: tokenMaker,
 	}
 }
 
@@ -33,10 +42,41 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		inventory.GET("", h.ListInventories)                            // GET /inventory
 		inventory.GET("/product/:product_id", h.GetInventoryByProduct)  // GET /inventory/product/:product_id
 		inventory.GET("/low-stock", h.ListLowStock)                     // GET /inventory/low-stock
+		inventory.GET("/alerts/digest", h.GetLowStockDigest)            // GET /inventory/alerts/digest
 		inventory.POST("/restock", h.Restock)                           // POST /inventory/restock
 		inventory.POST("/adjust", h.AdjustStock)                        // POST /inventory/adjust
 		inventory.PUT("/:product_id/threshold", h.UpdateThreshold)      // PUT /inventory/:product_id/threshold
 		inventory.GET("/logs/:product_id", h.GetInventoryLogs)          // GET /inventory/logs/:product_id
+		inventory.POST("/import", h.ImportInventory)                    // POST /inventory/import
+		inventory.GET("/import/:job_id", h.GetImportJob)                // GET /inventory/import/:job_id
+		inventory.GET("/export", h.ExportInventory)                     // GET /inventory/export
+		inventory.GET("/:product_id/as-of", h.GetStockAsOf)             // GET /inventory/:product_id/as-of
+		inventory.GET("/reports/movements", h.GetMovementReport)        // GET /inventory/reports/movements
+
+		// Backorder and pre-order
+		inventory.PUT("/:product_id/stock-policy", h.UpdateStockPolicy)                  // PUT /inventory/:product_id/stock-policy
+		inventory.GET("/:product_id/backorders", h.ListBackorders)                       // GET /inventory/:product_id/backorders
+		inventory.POST("/:product_id/backorders/allocate", h.AllocateBackorders)         // POST /inventory/:product_id/backorders/allocate
+
+		// Reorder-point suggestions
+		inventory.GET("/reorder-suggestions", h.ListReorderSuggestions)                  // GET /inventory/reorder-suggestions
+		inventory.POST("/reorder-suggestions/refresh", h.RefreshReorderSuggestions)      // POST /inventory/reorder-suggestions/refresh
+		inventory.POST("/reorder-suggestions/apply", h.ApplyReorderPoints)               // POST /inventory/reorder-suggestions/apply
+		inventory.GET("/reorder-settings", h.ListReorderSettings)                        // GET /inventory/reorder-settings
+		inventory.PUT("/reorder-settings/:category_id", h.UpsertReorderSetting)          // PUT /inventory/reorder-settings/:category_id
+		inventory.DELETE("/reorder-settings/:category_id", h.DeleteReorderSetting)       // DELETE /inventory/reorder-settings/:category_id
+
+		// Stocktake (cycle count) sessions; each step records the signed-in operator
+		stocktakes := inventory.Group("/stocktakes")
+		stocktakes.Use(middleware.AuthMiddleware(h.tokenMaker))
+		{
+			stocktakes.POST("", h.CreateStocktake)                 // POST /inventory/stocktakes
+			stocktakes.GET("", h.ListStocktakes)                   // GET /inventory/stocktakes
+			stocktakes.GET("/:id", h.GetStocktake)                 // GET /inventory/stocktakes/:id
+			stocktakes.PUT("/:id/counts", h.RecordStocktakeCounts) // PUT /inventory/stocktakes/:id/counts
+			stocktakes.POST("/:id/approve", h.ApproveStocktake)    // POST /inventory/stocktakes/:id/approve
+			stocktakes.POST("/:id/cancel", h.CancelStocktake)      // POST /inventory/stocktakes/:id/cancel
+		}
 
 		// Reservation management (internal use)
 		inventory.POST("/reserve", h.ReserveStock)                      // POST /inventory/reserve
@@ -84,7 +124,8 @@ func (h *Handler) CreateInventory(c *gin.Context) {
 // @Tags         Inventory
 // @Accept       json
 // @Produce      json
-// @Param        product_id path      int  true  "Product ID"
+// @Param        product_id path      int  true   "Product ID"
+// @Param        sku_id     query     int  false  "SKU ID for products with variants"
 // @Success      200        {object}  response.Response{data=InventoryResponse}
 // @Failure      404        {object}  response.Response
 // @Failure      500        {object}  response.Response
@@ -96,7 +137,13 @@ func (h *Handler) GetInventoryByProduct(c *gin.Context) {
 		return
 	}
 
-	inventory, err := h.service.GetInventoryByProductID(c.Request.Context(), productID)
+	skuID, err := parseSkuID(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid sku id")
+		return
+	}
+
+	inventory, err := h.service.GetInventoryByProductID(c.Request.Context(), productID, skuID)
 	if err != nil {
 		if err.Error() == "inventory not found" {
 			response.Error(c, http.StatusNotFound, err.Error())
@@ -163,6 +210,798 @@ func (h *Handler) ListLowStock(c *gin.Context) {
 	response.Success(c, inventories)
 }
 
+// GetLowStockDigest godoc
+// @Summary      Low Stock Alert Digest
+// @Description  Summary of active low-stock alerts for operations staff
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        page      query     int  false  "Page number (default: 1)"
+// @Param        page_size query     int  false  "Page size (default: 20)"
+// @Success      200       {object}  response.Response{data=LowStockDigestResponse}
+// @Failure      500       {object}  response.Response
+// @Router       /inventory/alerts/digest [get]
+func (h *Handler) GetLowStockDigest(c *gin.Context) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	pageSize, _ := strconv.ParseInt(c.DefaultQuery("page_size", "20"), 10, 32)
+
+	digest, err := h.service.GetLowStockDigest(c.Request.Context(), int32(page), int32(pageSize))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, digest)
+}
+
+// ImportInventory godoc
+// @Summary      Import Inventory CSV
+// @Description  Bulk update stock from a CSV with product_id, quantity and mode (set or add) columns. SKU stock is addressed with a sku_id or sku (SKU code) column, in which case product_id may be left empty. The file is validated first; with dry_run=true only the validation report is returned, otherwise a background job applies the rows in batches.
+// @Tags         Inventory
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     Bearer
+// @Param        file     formData  file  true   "CSV file"
+// @Param        dry_run  query     bool  false  "Validate only (default: false)"
+// @Success      200      {object}  response.Response{data=ImportInventoryResponse}  "Dry run result"
+// @Success      202      {object}  response.Response{data=ImportInventoryResponse}  "Import job started"
+// @Failure      400      {object}  response.Response
+// @Failure      422      {object}  response.Response{data=ImportInventoryResponse}  "Validation failed"
+// @Failure      500      {object}  response.Response
+// @Router       /inventory/import [post]
+func (h *Handler) ImportInventory(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		response.Error(c, http.StatusBadRequest, fmt.Sprintf("import file exceeds %d bytes", maxImportFileSize))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	defer file.Close()
+
+	operatorID := operatorIDFromContext(c)
+
+	result, err := h.service.ImportInventory(c.Request.Context(), fileHeader.Filename, file, dryRun, operatorID)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch {
+	case len(result.Validation.Errors) > 0 && !dryRun:
+		response.ErrorWithData(c, http.StatusUnprocessableEntity, "import validation failed", result)
+	case result.Job != nil:
+		c.JSON(http.StatusAccepted, gin.H{
+			"code":    0,
+			"message": "success",
+			"data":    result,
+		})
+	default:
+		response.Success(c, result)
+	}
+}
+
+// GetImportJob godoc
+// @Summary      Get Inventory Import Job
+// @Description  Poll the progress of an inventory CSV import job
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        job_id   path      int  true  "Import job ID"
+// @Success      200      {object}  response.Response{data=ImportJobResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /inventory/import/{job_id} [get]
+func (h *Handler) GetImportJob(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("job_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid job id")
+		return
+	}
+
+	job, err := h.service.GetImportJob(c.Request.Context(), jobID)
+	if err != nil {
+		if err.Error() == "import job not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, job)
+}
+
+// ExportInventory godoc
+// @Summary      Export Inventory CSV
+// @Description  Stream current stock levels for all products and SKUs as CSV
+// @Tags         Inventory
+// @Produce      text/csv
+// @Security     Bearer
+// @Success      200  {file}    file
+// @Failure      500  {object}  response.Response
+// @Router       /inventory/export [get]
+func (h *Handler) ExportInventory(c *gin.Context) {
+	filename := fmt.Sprintf("inventory-%s.csv", time.Now().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	if err := h.service.ExportInventory(c.Request.Context(), c.Writer); err != nil {
+		// Headers are already on the wire once rows have been streamed
+		log.Printf("inventory export failed: %v", err)
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Type")
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+}
+
+// GetStockAsOf godoc
+// @Summary      Get Stock As Of
+// @Description  Reconstruct available and reserved stock for a product at a past timestamp from the inventory log
+// @Tags         Inventory
+// @Produce      json
+// @Security     Bearer
+// @Param        product_id  path      int     true  "Product ID"
+// @Param        ts          query     string  true  "Timestamp (RFC3339 or YYYY-MM-DD)"
+// @Param        sku_id      query     int     false "SKU ID for products with variants"
+// @Success      200         {object}  response.Response{data=StockAsOfResponse}
+// @Failure      400         {object}  response.Response
+// @Failure      404         {object}  response.Response
+// @Failure      500         {object}  response.Response
+// @Router       /inventory/{product_id}/as-of [get]
+func (h *Handler) GetStockAsOf(c *gin.Context) {
+	productID, err := strconv.ParseInt(c.Param("product_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	asOf, err := parseReportTime(c.Query("ts"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid ts: expected RFC3339 or YYYY-MM-DD")
+		return
+	}
+
+	skuID, err := parseSkuID(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid sku id")
+		return
+	}
+
+	stock, err := h.service.GetStockAsOf(c.Request.Context(), productID, skuID, asOf)
+	if err != nil {
+		if err.Error() == "inventory not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, stock)
+}
+
+// GetMovementReport godoc
+// @Summary      Get Inventory Movement Report
+// @Description  Aggregate restock/reserve/release/deduct/adjust totals per product per period; format=csv streams the full report
+// @Tags         Inventory
+// @Produce      json
+// @Produce      text/csv
+// @Security     Bearer
+// @Param        from        query     string  true   "Start of range, inclusive (RFC3339 or YYYY-MM-DD)"
+// @Param        to          query     string  true   "End of range, exclusive (RFC3339 or YYYY-MM-DD)"
+// @Param        period      query     string  false  "Bucket size: day, week or month (default month)"
+// @Param        product_id  query     int     false  "Limit to one product"
+// @Param        format      query     string  false  "json (default) or csv"
+// @Param        page        query     int     false  "Page number"
+// @Param        page_size   query     int     false  "Page size"
+// @Success      200         {object}  response.Response{data=MovementReportResponse}
+// @Failure      400         {object}  response.Response
+// @Failure      500         {object}  response.Response
+// @Router       /inventory/reports/movements [get]
+func (h *Handler) GetMovementReport(c *gin.Context) {
+	from, err := parseReportTime(c.Query("from"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid from: expected RFC3339 or YYYY-MM-DD")
+		return
+	}
+	to, err := parseReportTime(c.Query("to"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid to: expected RFC3339 or YYYY-MM-DD")
+		return
+	}
+
+	req := MovementReportRequest{
+		From:   from,
+		To:     to,
+		Period: c.Query("period"),
+	}
+	if value := c.Query("product_id"); value != "" {
+		productID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid product id")
+			return
+		}
+		req.ProductID = &productID
+	}
+
+	if c.Query("format") == "csv" {
+		h.exportMovementReport(c, req)
+		return
+	}
+
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	pageSize, _ := strconv.ParseInt(c.DefaultQuery("page_size", "20"), 10, 32)
+	req.Page = int32(page)
+	req.PageSize = int32(pageSize)
+
+	report, err := h.service.GetMovementReport(c.Request.Context(), req)
+	if err != nil {
+		movementReportError(c, err)
+		return
+	}
+
+	response.Success(c, report)
+}
+
+func (h *Handler) exportMovementReport(c *gin.Context, req MovementReportRequest) {
+	filename := fmt.Sprintf("inventory-movements-%s.csv", time.Now().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	if err := h.service.ExportMovementReport(c.Request.Context(), req, c.Writer); err != nil {
+		log.Printf("inventory movement export failed: %v", err)
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Type")
+			movementReportError(c, err)
+		}
+	}
+}
+
+func movementReportError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "period must be day, week or month", "from must be before to":
+		response.Error(c, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// parseReportTime accepts an RFC3339 timestamp or a plain date (midnight UTC)
+func parseReportTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// parseSkuID reads the optional sku_id query parameter; nil selects the product-level stock
+func parseSkuID(c *gin.Context) (*int64, error) {
+	value := c.Query("sku_id")
+	if value == "" {
+		return nil, nil
+	}
+	skuID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &skuID, nil
+}
+
+// UpdateStockPolicy godoc
+// @Summary      Update Stock Policy
+// @Description  Choose what happens when a product runs out: deny, backorder up to a limit, or pre-order until a release date
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        product_id  path      int                       true  "Product ID"
+// @Param        request     body      UpdateStockPolicyRequest  true  "Stock policy"
+// @Success      200         {object}  response.Response{data=InventoryResponse}
+// @Failure      400         {object}  response.Response
+// @Failure      404         {object}  response.Response
+// @Failure      500         {object}  response.Response
+// @Router       /inventory/{product_id}/stock-policy [put]
+func (h *Handler) UpdateStockPolicy(c *gin.Context) {
+	productID, err := strconv.ParseInt(c.Param("product_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req UpdateStockPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	inventory, err := h.service.UpdateStockPolicy(c.Request.Context(), productID, req)
+	if err != nil {
+		switch err.Error() {
+		case "inventory not found":
+			response.Error(c, http.StatusNotFound, err.Error())
+		case "preorder_release_at is required for preorder policy":
+			response.Error(c, http.StatusBadRequest, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, inventory)
+}
+
+// ListBackorders godoc
+// @Summary      List Backorders
+// @Description  List the backorder queue of a product in allocation order
+// @Tags         Inventory
+// @Produce      json
+// @Security     Bearer
+// @Param        product_id  path      int     true   "Product ID"
+// @Param        status      query     string  false  "pending, allocated or cancelled"
+// @Param        page        query     int     false  "Page number"
+// @Param        page_size   query     int     false  "Page size"
+// @Success      200         {object}  response.Response{data=PaginatedBackordersResponse}
+// @Failure      400         {object}  response.Response
+// @Failure      500         {object}  response.Response
+// @Router       /inventory/{product_id}/backorders [get]
+func (h *Handler) ListBackorders(c *gin.Context) {
+	productID, err := strconv.ParseInt(c.Param("product_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req ListBackordersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	backorders, err := h.service.ListBackorders(c.Request.Context(), productID, req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, backorders)
+}
+
+// AllocateBackorders godoc
+// @Summary      Allocate Backorders
+// @Description  Allocate available stock to pending backorders in FIFO order; runs automatically after restocks
+// @Tags         Inventory
+// @Produce      json
+// @Security     Bearer
+// @Param        product_id  path      int  true   "Product ID"
+// @Param        sku_id      query     int  false  "SKU ID for products with variants"
+// @Success      200         {object}  response.Response{data=AllocateBackordersResponse}
+// @Failure      400         {object}  response.Response
+// @Failure      404         {object}  response.Response
+// @Failure      500         {object}  response.Response
+// @Router       /inventory/{product_id}/backorders/allocate [post]
+func (h *Handler) AllocateBackorders(c *gin.Context) {
+	productID, err := strconv.ParseInt(c.Param("product_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	skuID, err := parseSkuID(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid sku id")
+		return
+	}
+
+	result, err := h.service.AllocateBackorders(c.Request.Context(), productID, skuID)
+	if err != nil {
+		if err.Error() == "inventory not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListReorderSuggestions godoc
+// @Summary      List Reorder Suggestions
+// @Description  List the latest reorder points and quantities computed from rolling sales velocity
+// @Tags         Inventory
+// @Produce      json
+// @Security     Bearer
+// @Param        category_id    query     int   false  "Filter by category"
+// @Param        needs_reorder  query     bool  false  "Only products at or below their reorder point"
+// @Param        page           query     int   false  "Page number"
+// @Param        page_size      query     int   false  "Page size"
+// @Success      200            {object}  response.Response{data=PaginatedReorderSuggestionsResponse}
+// @Failure      400            {object}  response.Response
+// @Failure      500            {object}  response.Response
+// @Router       /inventory/reorder-suggestions [get]
+func (h *Handler) ListReorderSuggestions(c *gin.Context) {
+	var req ListReorderSuggestionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	suggestions, err := h.service.ListReorderSuggestions(c.Request.Context(), req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, suggestions)
+}
+
+// RefreshReorderSuggestions godoc
+// @Summary      Refresh Reorder Suggestions
+// @Description  Recompute sales velocity and reorder suggestions now; auto-apply categories get their thresholds updated
+// @Tags         Inventory
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  response.Response{data=ReorderRefreshResponse}
+// @Failure      500  {object}  response.Response
+// @Router       /inventory/reorder-suggestions/refresh [post]
+func (h *Handler) RefreshReorderSuggestions(c *gin.Context) {
+	result, err := h.service.RefreshReorderSuggestions(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ApplyReorderPoints godoc
+// @Summary      Apply Reorder Points
+// @Description  Set low_stock_threshold to the suggested reorder point for every product in a category
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      ApplyReorderPointsRequest  true  "Category to apply"
+// @Success      200      {object}  response.Response{data=ApplyReorderPointsResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /inventory/reorder-suggestions/apply [post]
+func (h *Handler) ApplyReorderPoints(c *gin.Context) {
+	var req ApplyReorderPointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	result, err := h.service.ApplyReorderPoints(c.Request.Context(), req.CategoryID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListReorderSettings godoc
+// @Summary      List Reorder Settings
+// @Description  List per-category lead time, safety stock and auto-apply overrides
+// @Tags         Inventory
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  response.Response{data=[]ReorderSettingResponse}
+// @Failure      500  {object}  response.Response
+// @Router       /inventory/reorder-settings [get]
+func (h *Handler) ListReorderSettings(c *gin.Context) {
+	settings, err := h.service.ListReorderSettings(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, settings)
+}
+
+// UpsertReorderSetting godoc
+// @Summary      Update Reorder Setting
+// @Description  Create or replace the reorder override for a category
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        category_id  path      int                          true  "Category ID"
+// @Param        request      body      UpsertReorderSettingRequest  true  "Reorder setting"
+// @Success      200          {object}  response.Response{data=ReorderSettingResponse}
+// @Failure      400          {object}  response.Response
+// @Failure      500          {object}  response.Response
+// @Router       /inventory/reorder-settings/{category_id} [put]
+func (h *Handler) UpsertReorderSetting(c *gin.Context) {
+	categoryID, err := strconv.ParseInt(c.Param("category_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid category id")
+		return
+	}
+
+	var req UpsertReorderSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	setting, err := h.service.UpsertReorderSetting(c.Request.Context(), categoryID, req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, setting)
+}
+
+// DeleteReorderSetting godoc
+// @Summary      Delete Reorder Setting
+// @Description  Remove a category override so the configured defaults apply again
+// @Tags         Inventory
+// @Produce      json
+// @Security     Bearer
+// @Param        category_id  path      int  true  "Category ID"
+// @Success      200          {object}  response.Response
+// @Failure      400          {object}  response.Response
+// @Failure      404          {object}  response.Response
+// @Failure      500          {object}  response.Response
+// @Router       /inventory/reorder-settings/{category_id} [delete]
+func (h *Handler) DeleteReorderSetting(c *gin.Context) {
+	categoryID, err := strconv.ParseInt(c.Param("category_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid category id")
+		return
+	}
+
+	if err := h.service.DeleteReorderSetting(c.Request.Context(), categoryID); err != nil {
+		if err.Error() == "reorder setting not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// CreateStocktake godoc
+// @Summary      Create Stocktake
+// @Description  Open a physical count session for a list of products or for every product in a category
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      CreateStocktakeRequest  true  "Stocktake scope"
+// @Success      201      {object}  response.Response{data=StocktakeDetailResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /inventory/stocktakes [post]
+func (h *Handler) CreateStocktake(c *gin.Context) {
+	var req CreateStocktakeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	stocktake, err := h.service.CreateStocktake(c.Request.Context(), req, operatorIDFromContext(c))
+	if err != nil {
+		if err.Error() == "either product_ids or category_id is required" || err.Error() == "no inventory found for stocktake" {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    0,
+		"message": "success",
+		"data":    stocktake,
+	})
+}
+
+// ListStocktakes godoc
+// @Summary      List Stocktakes
+// @Description  List stocktake sessions, newest first
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        status    query     string  false  "Filter by status (open, approved, cancelled)"
+// @Param        page      query     int     false  "Page number (default: 1)"
+// @Param        page_size query     int     false  "Page size (default: 20)"
+// @Success      200       {object}  response.Response{data=PaginatedStocktakesResponse}
+// @Failure      401       {object}  response.Response
+// @Failure      500       {object}  response.Response
+// @Router       /inventory/stocktakes [get]
+func (h *Handler) ListStocktakes(c *gin.Context) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	pageSize, _ := strconv.ParseInt(c.DefaultQuery("page_size", "20"), 10, 32)
+
+	stocktakes, err := h.service.ListStocktakes(c.Request.Context(), c.Query("status"), int32(page), int32(pageSize))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, stocktakes)
+}
+
+// GetStocktake godoc
+// @Summary      Get Stocktake
+// @Description  Get a stocktake session with counted quantities and variances against available + reserved stock
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Stocktake ID"
+// @Success      200  {object}  response.Response{data=StocktakeDetailResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /inventory/stocktakes/{id} [get]
+func (h *Handler) GetStocktake(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid stocktake id")
+		return
+	}
+
+	stocktake, err := h.service.GetStocktake(c.Request.Context(), id)
+	if err != nil {
+		stocktakeError(c, err)
+		return
+	}
+
+	response.Success(c, stocktake)
+}
+
+// RecordStocktakeCounts godoc
+// @Summary      Record Stocktake Counts
+// @Description  Enter counted quantities for products in an open stocktake; re-submitting a product overwrites its count
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                           true  "Stocktake ID"
+// @Param        request  body      RecordStocktakeCountsRequest  true  "Counted quantities"
+// @Success      200      {object}  response.Response{data=StocktakeDetailResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /inventory/stocktakes/{id}/counts [put]
+func (h *Handler) RecordStocktakeCounts(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid stocktake id")
+		return
+	}
+
+	var req RecordStocktakeCountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	stocktake, err := h.service.RecordStocktakeCounts(c.Request.Context(), id, req, operatorIDFromContext(c))
+	if err != nil {
+		stocktakeError(c, err)
+		return
+	}
+
+	response.Success(c, stocktake)
+}
+
+// ApproveStocktake godoc
+// @Summary      Approve Stocktake
+// @Description  Approve a fully counted stocktake and post non-zero variances as adjust logs linked to the session
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Stocktake ID"
+// @Success      200  {object}  response.Response{data=StocktakeDetailResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /inventory/stocktakes/{id}/approve [post]
+func (h *Handler) ApproveStocktake(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid stocktake id")
+		return
+	}
+
+	stocktake, err := h.service.ApproveStocktake(c.Request.Context(), id, operatorIDFromContext(c))
+	if err != nil {
+		stocktakeError(c, err)
+		return
+	}
+
+	response.Success(c, stocktake)
+}
+
+// CancelStocktake godoc
+// @Summary      Cancel Stocktake
+// @Description  Cancel an open stocktake without changing stock
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Stocktake ID"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /inventory/stocktakes/{id}/cancel [post]
+func (h *Handler) CancelStocktake(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid stocktake id")
+		return
+	}
+
+	if err := h.service.CancelStocktake(c.Request.Context(), id); err != nil {
+		stocktakeError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "stocktake cancelled successfully"})
+}
+
+// stocktakeError maps stocktake workflow errors to HTTP status codes
+func stocktakeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "stocktake not found":
+		response.Error(c, http.StatusNotFound, err.Error())
+	case "stocktake is not open":
+		response.Error(c, http.StatusConflict, err.Error())
+	case "product is not part of this stocktake", "stocktake has uncounted items", "counted quantity is below reserved stock":
+		response.Error(c, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// operatorIDFromContext returns the authenticated user's ID, if any
+func operatorIDFromContext(c *gin.Context) *int64 {
+	payload := middleware.GetPayload(c)
+	if payload == nil {
+		return nil
+	}
+	return &payload.UserID
+}
+
 // CheckStock godoc
 // @Summary      Check Stock Availability
 // @Description  Check if stock is available for a product
@@ -171,6 +1010,7 @@ func (h *Handler) ListLowStock(c *gin.Context) {
 // @Produce      json
 // @Param        product_id path      int  true  "Product ID"
 // @Param        quantity   query     int  true  "Requested quantity"
+// @Param        sku_id     query     int  false "SKU ID for products with variants"
 // @Success      200        {object}  response.Response{data=StockCheckResponse}
 // @Failure      400        {object}  response.Response
 // @Failure      500        {object}  response.Response
@@ -188,7 +1028,13 @@ func (h *Handler) CheckStock(c *gin.Context) {
 		return
 	}
 
-	check, err := h.service.CheckStockAvailability(c.Request.Context(), productID, int32(quantity))
+	skuID, err := parseSkuID(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid sku id")
+		return
+	}
+
+	check, err := h.service.CheckStockAvailability(c.Request.Context(), productID, skuID, int32(quantity))
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
@@ -204,7 +1050,7 @@ func (h *Handler) CheckStock(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request  body      []StockCheckItem  true  "Products to check"
-// @Success      200      {object}  response.Response{data=map[int64]StockCheckResponse}
+// @Success      200      {object}  response.Response{data=[]StockCheckResponse}
 // @Failure      400      {object}  response.Response
 // @Failure      500      {object}  response.Response
 // @Router       /inventory/check/batch [post]
@@ -332,12 +1178,7 @@ func (h *Handler) Restock(c *gin.Context) {
 		return
 	}
 
-	// Get operator ID from middleware if available
-	payload := middleware.GetPayload(c)
-	var operatorID *int64
-	if payload != nil {
-		operatorID = &payload.UserID
-	}
+	operatorID := operatorIDFromContext(c)
 
 	err := h.service.RestockInventory(c.Request.Context(), req, operatorID)
 	if err != nil {
@@ -367,12 +1208,7 @@ func (h *Handler) AdjustStock(c *gin.Context) {
 		return
 	}
 
-	// Get operator ID from middleware if available
-	payload := middleware.GetPayload(c)
-	var operatorID *int64
-	if payload != nil {
-		operatorID = &payload.UserID
-	}
+	operatorID := operatorIDFromContext(c)
 
 	err := h.service.AdjustStock(c.Request.Context(), req, operatorID)
 	if err != nil {
@@ -0,0 +1,590 @@
+package inventory
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+)
+
+const (
+	ImportModeSet = "set"
+	ImportModeAdd = "add"
+
+	// ImportReferenceType tags inventory logs written by a CSV import job
+	ImportReferenceType = "import_job"
+
+	maxImportRows      = 10000
+	maxImportRowErrors = 500
+	importBatchSize    = 100
+	exportBatchSize    = 500
+)
+
+var (
+	errImportNegativeStock     = errors.New("import would result in negative stock")
+	errImportInventoryNotFound = errors.New("inventory not found")
+)
+
+var exportHeader = []string{
+	"product_id",
+	"sku_id",
+	"sku",
+	"product_name",
+	"available_stock",
+	"reserved_stock",
+	"total_stock",
+	"low_stock_threshold",
+	"updated_at",
+}
+
+// importRow is a single parsed line of an inventory import file. SKU rows may leave
+// ProductID zero until validateImport resolves it from the SKU.
+type importRow struct {
+	Line      int
+	ProductID int64
+	SkuID     *int64
+	SkuCode   string
+	Quantity  int32
+	Mode      string
+}
+
+// parseImportCSV reads an import file with a header row containing a quantity column,
+// an optional mode column (defaults to "set") and the stock unit to update: product_id
+// for product-level stock, or sku_id / sku (the SKU code) for a SKU. Row-level problems
+// are returned as ImportRowErrors; only an unreadable file or header returns an error.
+func parseImportCSV(r io.Reader) ([]importRow, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, errors.New("import file is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read import header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	productCol, hasProduct := columns["product_id"]
+	skuIDCol, hasSkuID := columns["sku_id"]
+	skuCodeCol, hasSkuCode := columns["sku"]
+	if !hasProduct && !hasSkuID && !hasSkuCode {
+		return nil, nil, errors.New("import header must contain a product_id, sku_id or sku column")
+	}
+	quantityCol, ok := columns["quantity"]
+	if !ok {
+		return nil, nil, errors.New("import header must contain a quantity column")
+	}
+	modeCol, hasMode := columns["mode"]
+
+	var rows []importRow
+	var rowErrors []ImportRowError
+	seen := make(map[int64]int)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read import line %d: %w", line, err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(rows)+len(rowErrors) >= maxImportRows {
+			return nil, nil, fmt.Errorf("import file exceeds %d rows", maxImportRows)
+		}
+
+		field := func(col int, ok bool) string {
+			if ok && col < len(record) {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+
+		var skuID *int64
+		if value := field(skuIDCol, hasSkuID); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || id <= 0 {
+				rowErrors = append(rowErrors, ImportRowError{Line: line, Message: "invalid sku_id"})
+				continue
+			}
+			skuID = &id
+		}
+		skuCode := field(skuCodeCol, hasSkuCode)
+
+		// product_id may be left out of SKU rows; it is filled in from the SKU
+		var productID int64
+		if value := field(productCol, hasProduct); value != "" || (skuID == nil && skuCode == "") {
+			productID, err = strconv.ParseInt(value, 10, 64)
+			if err != nil || productID <= 0 {
+				rowErrors = append(rowErrors, ImportRowError{Line: line, SkuID: skuID, Message: "invalid product_id"})
+				continue
+			}
+		}
+
+		quantity, err := strconv.ParseInt(field(quantityCol, true), 10, 32)
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, ProductID: productID, SkuID: skuID, Message: "invalid quantity"})
+			continue
+		}
+
+		mode := ImportModeSet
+		if field(modeCol, hasMode) != "" {
+			mode = strings.ToLower(field(modeCol, hasMode))
+		}
+
+		switch {
+		case mode != ImportModeSet && mode != ImportModeAdd:
+			rowErrors = append(rowErrors, ImportRowError{Line: line, ProductID: productID, SkuID: skuID, Message: "mode must be set or add"})
+			continue
+		case mode == ImportModeSet && quantity < 0:
+			rowErrors = append(rowErrors, ImportRowError{Line: line, ProductID: productID, SkuID: skuID, Message: "quantity must not be negative in set mode"})
+			continue
+		case mode == ImportModeAdd && quantity == 0:
+			rowErrors = append(rowErrors, ImportRowError{Line: line, ProductID: productID, SkuID: skuID, Message: "quantity must not be zero in add mode"})
+			continue
+		}
+
+		// SKU rows are checked for duplicates once their SKU codes are resolved
+		if skuID == nil && skuCode == "" {
+			if firstLine, dup := seen[productID]; dup {
+				rowErrors = append(rowErrors, ImportRowError{
+					Line:      line,
+					ProductID: productID,
+					Message:   fmt.Sprintf("duplicate product_id (first seen on line %d)", firstLine),
+				})
+				continue
+			}
+			seen[productID] = line
+		}
+
+		rows = append(rows, importRow{
+			Line:      line,
+			ProductID: productID,
+			SkuID:     skuID,
+			SkuCode:   skuCode,
+			Quantity:  int32(quantity),
+			Mode:      mode,
+		})
+	}
+
+	return rows, rowErrors, nil
+}
+
+// applyImportRow returns the available stock after applying a row to the current inventory
+func applyImportRow(row importRow, currentAvailable int32) (int32, error) {
+	newAvailable := row.Quantity
+	if row.Mode == ImportModeAdd {
+		newAvailable = currentAvailable + row.Quantity
+	}
+	if newAvailable < 0 {
+		return 0, errImportNegativeStock
+	}
+	return newAvailable, nil
+}
+
+// ImportInventory validates an inventory CSV and, unless this is a dry run or the file
+// has errors, starts a background job that applies it in batches
+func (s *service) ImportInventory(ctx context.Context, filename string, file io.Reader, dryRun bool, operatorID *int64) (*ImportInventoryResponse, error) {
+	rows, validation, err := s.validateImport(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportInventoryResponse{
+		DryRun:     dryRun,
+		Validation: *validation,
+	}
+	if dryRun || len(validation.Errors) > 0 || len(rows) == 0 {
+		return result, nil
+	}
+
+	job, err := s.repo.CreateImportJob(ctx, sqlc.CreateImportJobParams{
+		Filename:   filename,
+		TotalRows:  int32(len(rows)),
+		OperatorID: operatorID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	go s.runImportJob(job.ID, filename, rows, operatorID)
+
+	jobResp := toImportJobResponse(job)
+	result.Job = &jobResp
+	return result, nil
+}
+
+// GetImportJob returns the progress of an inventory import job
+func (s *service) GetImportJob(ctx context.Context, jobID int64) (*ImportJobResponse, error) {
+	job, err := s.repo.GetImportJob(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("import job not found")
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	resp := toImportJobResponse(job)
+	return &resp, nil
+}
+
+// ExportInventory streams current stock levels as CSV, flushing after every batch
+func (s *service) ExportInventory(ctx context.Context, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportHeader); err != nil {
+		return fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	var afterID int64
+	for {
+		rows, err := s.repo.ListInventoriesForExport(ctx, sqlc.ListInventoriesForExportParams{
+			AfterID:   afterID,
+			BatchSize: exportBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list inventories for export: %w", err)
+		}
+
+		for _, row := range rows {
+			var skuID string
+			if row.SkuID != nil {
+				skuID = strconv.FormatInt(*row.SkuID, 10)
+			}
+			record := []string{
+				strconv.FormatInt(row.ProductID, 10),
+				skuID,
+				utils.PtrValue(row.SkuCode),
+				row.ProductName,
+				strconv.FormatInt(int64(row.AvailableStock), 10),
+				strconv.FormatInt(int64(row.ReservedStock), 10),
+				strconv.FormatInt(int64(row.TotalStock), 10),
+				strconv.FormatInt(int64(utils.PtrValue(row.LowStockThreshold)), 10),
+				row.UpdatedAt.Format(time.RFC3339),
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write export row: %w", err)
+			}
+			afterID = row.ID
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to flush export: %w", err)
+		}
+
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+// validateImport parses the file and checks every row against current stock without changing anything.
+// SKU rows come back with SkuID and ProductID resolved from the SKU.
+func (s *service) validateImport(ctx context.Context, file io.Reader) ([]importRow, *ImportValidationResponse, error) {
+	rows, rowErrors, err := parseImportCSV(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalRows := len(rows) + len(rowErrors)
+
+	rows, rowErrors, err = s.resolveImportSkus(ctx, rows, rowErrors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var productIDs, skuIDs []int64
+	for _, row := range rows {
+		if row.SkuID != nil {
+			skuIDs = append(skuIDs, *row.SkuID)
+		} else {
+			productIDs = append(productIDs, row.ProductID)
+		}
+	}
+
+	inventories := make(map[StockKey]sqlc.Inventory, len(rows))
+	for start := 0; start < len(productIDs); start += importBatchSize {
+		found, err := s.repo.GetInventoriesByProductIDs(ctx, productIDs[start:min(start+importBatchSize, len(productIDs))])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get inventories: %w", err)
+		}
+		for _, inv := range found {
+			inventories[NewStockKey(inv.ProductID, nil)] = inv
+		}
+	}
+	skuInventories := make(map[int64]sqlc.Inventory, len(skuIDs))
+	for start := 0; start < len(skuIDs); start += importBatchSize {
+		found, err := s.repo.GetInventoriesBySkuIDs(ctx, skuIDs[start:min(start+importBatchSize, len(skuIDs))])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get inventories: %w", err)
+		}
+		for _, inv := range found {
+			skuInventories[*inv.SkuID] = inv
+		}
+	}
+
+	valid := rows[:0]
+	seen := make(map[StockKey]int, len(rows))
+	for _, row := range rows {
+		if row.SkuID != nil {
+			inv, ok := skuInventories[*row.SkuID]
+			switch {
+			case !ok:
+				rowErrors = append(rowErrors, ImportRowError{Line: row.Line, ProductID: row.ProductID, SkuID: row.SkuID, Message: errImportInventoryNotFound.Error()})
+				continue
+			case row.ProductID != 0 && row.ProductID != inv.ProductID:
+				rowErrors = append(rowErrors, ImportRowError{Line: row.Line, ProductID: row.ProductID, SkuID: row.SkuID, Message: "sku does not belong to product_id"})
+				continue
+			}
+			row.ProductID = inv.ProductID
+			inventories[NewStockKey(inv.ProductID, inv.SkuID)] = inv
+
+			key := NewStockKey(row.ProductID, row.SkuID)
+			if firstLine, dup := seen[key]; dup {
+				rowErrors = append(rowErrors, ImportRowError{
+					Line:      row.Line,
+					ProductID: row.ProductID,
+					SkuID:     row.SkuID,
+					Message:   fmt.Sprintf("duplicate sku (first seen on line %d)", firstLine),
+				})
+				continue
+			}
+			seen[key] = row.Line
+		}
+
+		inv, ok := inventories[NewStockKey(row.ProductID, row.SkuID)]
+		if !ok {
+			rowErrors = append(rowErrors, ImportRowError{Line: row.Line, ProductID: row.ProductID, Message: errImportInventoryNotFound.Error()})
+			continue
+		}
+		if _, err := applyImportRow(row, inv.AvailableStock); err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: row.Line, ProductID: row.ProductID, SkuID: row.SkuID, Message: err.Error()})
+			continue
+		}
+		valid = append(valid, row)
+	}
+
+	if rowErrors == nil {
+		rowErrors = []ImportRowError{}
+	}
+
+	return valid, &ImportValidationResponse{
+		TotalRows: totalRows,
+		ValidRows: len(valid),
+		Errors:    rowErrors,
+	}, nil
+}
+
+// resolveImportSkus looks up the SKU codes given in the sku column and sets SkuID on those rows.
+// Unknown codes, and codes that disagree with the row's sku_id, become row errors.
+func (s *service) resolveImportSkus(ctx context.Context, rows []importRow, rowErrors []ImportRowError) ([]importRow, []ImportRowError, error) {
+	var codes []string
+	for _, row := range rows {
+		if row.SkuCode != "" {
+			codes = append(codes, row.SkuCode)
+		}
+	}
+	if len(codes) == 0 {
+		return rows, rowErrors, nil
+	}
+
+	skus := make(map[string]int64, len(codes))
+	for start := 0; start < len(codes); start += importBatchSize {
+		found, err := s.repo.ListProductSkusByCodes(ctx, codes[start:min(start+importBatchSize, len(codes))])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get skus: %w", err)
+		}
+		for _, sku := range found {
+			skus[sku.SkuCode] = sku.ID
+		}
+	}
+
+	resolved := rows[:0]
+	for _, row := range rows {
+		if row.SkuCode != "" {
+			skuID, ok := skus[row.SkuCode]
+			switch {
+			case !ok:
+				rowErrors = append(rowErrors, ImportRowError{Line: row.Line, ProductID: row.ProductID, Message: "sku not found"})
+				continue
+			case row.SkuID != nil && *row.SkuID != skuID:
+				rowErrors = append(rowErrors, ImportRowError{Line: row.Line, ProductID: row.ProductID, SkuID: row.SkuID, Message: "sku does not match sku_id"})
+				continue
+			}
+			row.SkuID = utils.Ptr(skuID)
+		}
+		resolved = append(resolved, row)
+	}
+	return resolved, rowErrors, nil
+}
+
+// runImportJob applies the rows in batches, one transaction per batch, recording progress after each
+func (s *service) runImportJob(jobID int64, filename string, rows []importRow, operatorID *int64) {
+	ctx := context.Background()
+
+	if err := s.repo.StartImportJob(ctx, jobID); err != nil {
+		log.Printf("import job %d: failed to start: %v", jobID, err)
+		s.finishImportJob(ctx, jobID, "failed", err.Error())
+		return
+	}
+
+	reason := fmt.Sprintf("CSV import #%d (%s)", jobID, filename)
+	var processed, succeeded, failed int32
+	rowErrors := []ImportRowError{}
+
+	for start := 0; start < len(rows); start += importBatchSize {
+		batch := rows[start:min(start+importBatchSize, len(rows))]
+
+		var changes []StockChange
+		var batchErrors []ImportRowError
+		err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+			changes, batchErrors = nil, nil
+			for _, row := range batch {
+				change, err := s.applyImportRowTx(ctx, q, jobID, row, reason, operatorID)
+				if errors.Is(err, errImportNegativeStock) || errors.Is(err, errImportInventoryNotFound) {
+					// Stock moved since validation; skip the row and keep the rest of the batch
+					batchErrors = append(batchErrors, ImportRowError{Line: row.Line, ProductID: row.ProductID, SkuID: row.SkuID, Message: err.Error()})
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				if change != nil {
+					changes = append(changes, *change)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			// The whole batch was rolled back
+			changes, batchErrors = nil, nil
+			for _, row := range batch {
+				batchErrors = append(batchErrors, ImportRowError{Line: row.Line, ProductID: row.ProductID, SkuID: row.SkuID, Message: err.Error()})
+			}
+		}
+
+		processed += int32(len(batch))
+		failed += int32(len(batchErrors))
+		succeeded += int32(len(batch) - len(batchErrors))
+		for _, rowErr := range batchErrors {
+			if len(rowErrors) < maxImportRowErrors {
+				rowErrors = append(rowErrors, rowErr)
+			}
+		}
+
+		for _, change := range changes {
+			s.notifyStockChange(change)
+			if change.AfterAvailable > change.BeforeAvailable {
+				s.tryAllocateBackorders(ctx, change.ProductID, change.SkuID)
+			}
+		}
+
+		errorsJSON, _ := json.Marshal(rowErrors)
+		if err := s.repo.UpdateImportJobProgress(ctx, sqlc.UpdateImportJobProgressParams{
+			ProcessedRows: processed,
+			SucceededRows: succeeded,
+			FailedRows:    failed,
+			Errors:        errorsJSON,
+			ID:            jobID,
+		}); err != nil {
+			log.Printf("import job %d: failed to update progress: %v", jobID, err)
+		}
+	}
+
+	if succeeded == 0 && failed > 0 {
+		s.finishImportJob(ctx, jobID, "failed", "no rows could be applied")
+		return
+	}
+	s.finishImportJob(ctx, jobID, "completed", "")
+}
+
+// applyImportRowTx applies a single row inside the batch transaction. A nil change means
+// the stock already matched and nothing was written.
+func (s *service) applyImportRowTx(ctx context.Context, q sqlc.Querier, jobID int64, row importRow, reason string, operatorID *int64) (*StockChange, error) {
+	inventory, err := getStockUnit(ctx, q, row.ProductID, row.SkuID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errImportInventoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
+	}
+
+	newAvailableStock, err := applyImportRow(row, inventory.AvailableStock)
+	if err != nil {
+		return nil, err
+	}
+	if newAvailableStock == inventory.AvailableStock {
+		return nil, nil
+	}
+
+	err = q.UpdateInventoryStock(ctx, sqlc.UpdateInventoryStockParams{
+		AvailableStock: newAvailableStock,
+		ReservedStock:  inventory.ReservedStock,
+		ID:             inventory.ID,
+		Version:        inventory.Version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update stock: %w", err)
+	}
+
+	quantityChange := newAvailableStock - inventory.AvailableStock
+	changeType := "adjust"
+	if row.Mode == ImportModeAdd && quantityChange > 0 {
+		changeType = "restock"
+	}
+
+	_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
+		ProductID:       row.ProductID,
+		SkuID:           inventory.SkuID,
+		OrderID:         nil,
+		ChangeType:      changeType,
+		QuantityChange:  quantityChange,
+		BeforeAvailable: inventory.AvailableStock,
+		AfterAvailable:  newAvailableStock,
+		BeforeReserved:  inventory.ReservedStock,
+		AfterReserved:   inventory.ReservedStock,
+		Reason:          utils.Ptr(reason),
+		OperatorID:      operatorID,
+		ReferenceType:   utils.Ptr(ImportReferenceType),
+		ReferenceID:     utils.Ptr(jobID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inventory log: %w", err)
+	}
+
+	change := newStockChange(inventory, newAvailableStock)
+	return &change, nil
+}
+
+func (s *service) finishImportJob(ctx context.Context, jobID int64, status, message string) {
+	var errorMessage *string
+	if message != "" {
+		errorMessage = utils.Ptr(message)
+	}
+	if err := s.repo.FinishImportJob(ctx, sqlc.FinishImportJobParams{
+		Status:       status,
+		ErrorMessage: errorMessage,
+		ID:           jobID,
+	}); err != nil {
+		log.Printf("import job %d: failed to finish: %v", jobID, err)
+	}
+}
@@ -0,0 +1,194 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+	"gomall/internal/config"
+	"gomall/utils"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	input := "\ufeffProduct_ID, Quantity, Mode\n" +
+		"1,10,set\n" +
+		"2,-3,add\n" +
+		"3,5,\n" +
+		"\n" +
+		"abc,1,set\n" +
+		"4,x,set\n" +
+		"5,-1,set\n" +
+		"6,0,add\n" +
+		"7,1,replace\n" +
+		"1,4,add\n"
+
+	rows, rowErrors, err := parseImportCSV(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Equal(t, []importRow{
+		{Line: 2, ProductID: 1, Quantity: 10, Mode: ImportModeSet},
+		{Line: 3, ProductID: 2, Quantity: -3, Mode: ImportModeAdd},
+		{Line: 4, ProductID: 3, Quantity: 5, Mode: ImportModeSet},
+	}, rows)
+
+	require.Len(t, rowErrors, 6)
+	require.Equal(t, ImportRowError{Line: 6, Message: "invalid product_id"}, rowErrors[0])
+	require.Equal(t, "invalid quantity", rowErrors[1].Message)
+	require.Equal(t, "quantity must not be negative in set mode", rowErrors[2].Message)
+	require.Equal(t, "quantity must not be zero in add mode", rowErrors[3].Message)
+	require.Equal(t, "mode must be set or add", rowErrors[4].Message)
+	require.Equal(t, ImportRowError{Line: 11, ProductID: 1, Message: "duplicate product_id (first seen on line 2)"}, rowErrors[5])
+}
+
+func TestParseImportCSV_SkuColumns(t *testing.T) {
+	input := "product_id,sku_id,sku,quantity\n" +
+		",7,,10\n" +
+		"5,,MUG-RED,3\n" +
+		"5,,,4\n" +
+		",x,,1\n" +
+		",,,1\n"
+
+	rows, rowErrors, err := parseImportCSV(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Equal(t, []importRow{
+		{Line: 2, SkuID: utils.Ptr(int64(7)), Quantity: 10, Mode: ImportModeSet},
+		{Line: 3, ProductID: 5, SkuCode: "MUG-RED", Quantity: 3, Mode: ImportModeSet},
+		{Line: 4, ProductID: 5, Quantity: 4, Mode: ImportModeSet},
+	}, rows)
+	require.Equal(t, []ImportRowError{
+		{Line: 5, Message: "invalid sku_id"},
+		{Line: 6, Message: "invalid product_id"},
+	}, rowErrors)
+}
+
+func TestParseImportCSV_MissingColumn(t *testing.T) {
+	_, _, err := parseImportCSV(strings.NewReader("product_id,mode\n1,set\n"))
+	require.EqualError(t, err, "import header must contain a quantity column")
+
+	_, _, err = parseImportCSV(strings.NewReader("quantity\n1\n"))
+	require.EqualError(t, err, "import header must contain a product_id, sku_id or sku column")
+
+	_, _, err = parseImportCSV(strings.NewReader(""))
+	require.EqualError(t, err, "import file is empty")
+}
+
+func TestApplyImportRow(t *testing.T) {
+	available, err := applyImportRow(importRow{Quantity: 7, Mode: ImportModeSet}, 20)
+	require.NoError(t, err)
+	require.Equal(t, int32(7), available)
+
+	available, err = applyImportRow(importRow{Quantity: -5, Mode: ImportModeAdd}, 20)
+	require.NoError(t, err)
+	require.Equal(t, int32(15), available)
+
+	_, err = applyImportRow(importRow{Quantity: -25, Mode: ImportModeAdd}, 20)
+	require.ErrorIs(t, err, errImportNegativeStock)
+}
+
+func TestValidateImportResolvesSkus(t *testing.T) {
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	s := NewService(store, nil, config.ReorderConfig{}).(*service)
+
+	input := "product_id,sku_id,sku,quantity\n" +
+		",,MUG-RED,10\n" + // resolved by code
+		",7,,4\n" + // same SKU by id
+		"6,8,,1\n" + // SKU of another product
+		",,MUG-XL,1\n" + // unknown code
+		"9,,,2\n" // product-level stock
+
+	store.EXPECT().ListProductSkusByCodes(gomock.Any(), []string{"MUG-RED", "MUG-XL"}).Return([]sqlc.ListProductSkusByCodesRow{
+		{ID: 7, ProductID: 5, SkuCode: "MUG-RED"},
+	}, nil)
+	store.EXPECT().GetInventoriesByProductIDs(gomock.Any(), []int64{9}).Return([]sqlc.Inventory{{ID: 1, ProductID: 9}}, nil)
+	store.EXPECT().GetInventoriesBySkuIDs(gomock.Any(), []int64{7, 7, 8}).Return([]sqlc.Inventory{
+		{ID: 2, ProductID: 5, SkuID: utils.Ptr(int64(7))},
+		{ID: 3, ProductID: 5, SkuID: utils.Ptr(int64(8))},
+	}, nil)
+
+	rows, validation, err := s.validateImport(context.Background(), strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Equal(t, []importRow{
+		{Line: 2, ProductID: 5, SkuID: utils.Ptr(int64(7)), SkuCode: "MUG-RED", Quantity: 10, Mode: ImportModeSet},
+		{Line: 6, ProductID: 9, Quantity: 2, Mode: ImportModeSet},
+	}, rows)
+	require.Equal(t, 5, validation.TotalRows)
+	require.Equal(t, []ImportRowError{
+		{Line: 5, Message: "sku not found"},
+		{Line: 3, ProductID: 5, SkuID: utils.Ptr(int64(7)), Message: "duplicate sku (first seen on line 2)"},
+		{Line: 4, ProductID: 6, SkuID: utils.Ptr(int64(8)), Message: "sku does not belong to product_id"},
+	}, validation.Errors)
+}
+
+func TestApplyImportRowTxUpdatesSkuInventory(t *testing.T) {
+	tx := mockdb.NewMockStore(gomock.NewController(t))
+	s := NewService(tx, nil, config.ReorderConfig{}).(*service)
+
+	skuID := utils.Ptr(int64(7))
+	tx.EXPECT().GetInventoryBySkuID(gomock.Any(), skuID).Return(sqlc.Inventory{ID: 2, ProductID: 5, SkuID: skuID, AvailableStock: 3, Version: 4}, nil)
+	tx.EXPECT().UpdateInventoryStock(gomock.Any(), sqlc.UpdateInventoryStockParams{AvailableStock: 10, ID: 2, Version: 4}).Return(nil)
+	tx.EXPECT().CreateInventoryLog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg sqlc.CreateInventoryLogParams) (sqlc.InventoryLog, error) {
+		require.Equal(t, skuID, arg.SkuID)
+		require.Equal(t, int32(7), arg.QuantityChange)
+		return sqlc.InventoryLog{}, nil
+	})
+
+	change, err := s.applyImportRowTx(context.Background(), tx, 1, importRow{ProductID: 5, SkuID: skuID, Quantity: 10, Mode: ImportModeSet}, "import", nil)
+	require.NoError(t, err)
+	require.Equal(t, skuID, change.SkuID)
+}
+
+func TestExportInventoryIncludesSkuRows(t *testing.T) {
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	s := NewService(store, nil, config.ReorderConfig{})
+
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	store.EXPECT().ListInventoriesForExport(gomock.Any(), sqlc.ListInventoriesForExportParams{BatchSize: exportBatchSize}).Return([]sqlc.ListInventoriesForExportRow{
+		{ID: 1, ProductID: 9, ProductName: "Pen", AvailableStock: 2, TotalStock: 2, UpdatedAt: updatedAt},
+		{ID: 2, ProductID: 5, SkuID: utils.Ptr(int64(7)), SkuCode: utils.Ptr("MUG-RED"), ProductName: "Mug", AvailableStock: 3, ReservedStock: 1, TotalStock: 4, UpdatedAt: updatedAt},
+	}, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, s.ExportInventory(context.Background(), &buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		exportHeader,
+		{"9", "", "", "Pen", "2", "0", "2", "0", "2026-01-02T03:04:05Z"},
+		{"5", "7", "MUG-RED", "Mug", "3", "1", "4", "0", "2026-01-02T03:04:05Z"},
+	}, records)
+}
+
+func TestRunImportJobAllocatesBackordersForAddedStock(t *testing.T) {
+	s, store, tx, _ := newTestService(t)
+
+	store.EXPECT().StartImportJob(gomock.Any(), int64(1)).Return(nil)
+	gomock.InOrder(
+		tx.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(sqlc.Inventory{ID: 20, ProductID: 5, AvailableStock: 2}, nil),
+		tx.EXPECT().UpdateInventoryStock(gomock.Any(), gomock.Any()).Return(nil),
+		tx.EXPECT().CreateInventoryLog(gomock.Any(), gomock.Any()).Return(sqlc.InventoryLog{}, nil),
+		tx.EXPECT().GetInventoryByProductID(gomock.Any(), int64(6)).Return(sqlc.Inventory{ID: 21, ProductID: 6, AvailableStock: 9}, nil),
+		tx.EXPECT().UpdateInventoryStock(gomock.Any(), gomock.Any()).Return(nil),
+		tx.EXPECT().CreateInventoryLog(gomock.Any(), gomock.Any()).Return(sqlc.InventoryLog{}, nil),
+
+		// Only the product whose stock went up is checked for backorders
+		tx.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(sqlc.Inventory{ID: 20, ProductID: 5, AvailableStock: 6}, nil),
+	)
+	store.EXPECT().UpdateImportJobProgress(gomock.Any(), gomock.Any()).Return(nil)
+	store.EXPECT().FinishImportJob(gomock.Any(), gomock.Any()).Return(nil)
+
+	s.runImportJob(1, "stock.csv", []importRow{
+		{Line: 2, ProductID: 5, Quantity: 4, Mode: ImportModeAdd},
+		{Line: 3, ProductID: 6, Quantity: 1, Mode: ImportModeSet},
+	}, nil)
+}
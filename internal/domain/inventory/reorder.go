@@ -0,0 +1,251 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gomall/db/sqlc"
+)
+
+// Fallbacks used when the reorder section of the config is left empty
+const (
+	defaultReorderWindowDays       = 30
+	defaultReorderLeadTimeDays     = 7
+	defaultReorderReviewPeriodDays = 14
+)
+
+// reorderPolicy is the lead time and safety stock applied to one product
+type reorderPolicy struct {
+	windowDays       int32
+	leadTimeDays     int32
+	safetyStockDays  int32
+	reviewPeriodDays int32
+}
+
+// reorderSuggestion is the outcome of computeReorderSuggestion
+type reorderSuggestion struct {
+	dailyVelocity   float64
+	reorderPoint    int32
+	reorderQuantity int32
+}
+
+// computeReorderSuggestion derives a reorder point and quantity from units sold over the window.
+// The reorder point covers demand during the lead time plus safety stock; the quantity tops
+// available stock up to cover one more review period on top of that.
+func computeReorderSuggestion(unitsSold int64, available int32, policy reorderPolicy) reorderSuggestion {
+	if policy.windowDays <= 0 || unitsSold <= 0 {
+		return reorderSuggestion{}
+	}
+
+	velocity := float64(unitsSold) / float64(policy.windowDays)
+	coverDays := float64(policy.leadTimeDays + policy.safetyStockDays)
+	reorderPoint := int32(math.Ceil(velocity * coverDays))
+	orderUpTo := int32(math.Ceil(velocity * (coverDays + float64(policy.reviewPeriodDays))))
+
+	quantity := orderUpTo - available
+	if quantity < 0 {
+		quantity = 0
+	}
+
+	return reorderSuggestion{
+		dailyVelocity:   velocity,
+		reorderPoint:    reorderPoint,
+		reorderQuantity: quantity,
+	}
+}
+
+// defaultReorderPolicy builds the policy from config, filling in fallbacks
+func (s *service) defaultReorderPolicy() reorderPolicy {
+	policy := reorderPolicy{
+		windowDays:       int32(s.reorder.WindowDays),
+		leadTimeDays:     int32(s.reorder.LeadTimeDays),
+		safetyStockDays:  int32(s.reorder.SafetyStockDays),
+		reviewPeriodDays: int32(s.reorder.ReviewPeriodDays),
+	}
+	if policy.windowDays <= 0 {
+		policy.windowDays = defaultReorderWindowDays
+	}
+	if policy.leadTimeDays <= 0 {
+		policy.leadTimeDays = defaultReorderLeadTimeDays
+	}
+	if policy.safetyStockDays < 0 {
+		policy.safetyStockDays = 0
+	}
+	if policy.reviewPeriodDays <= 0 {
+		policy.reviewPeriodDays = defaultReorderReviewPeriodDays
+	}
+	return policy
+}
+
+// RefreshReorderSuggestions recomputes sales velocity and reorder suggestions for every product,
+// per SKU for products with variants.
+// Categories with auto_apply enabled get their low_stock_threshold updated to the new non-zero reorder points.
+func (s *service) RefreshReorderSuggestions(ctx context.Context) (*ReorderRefreshResponse, error) {
+	defaults := s.defaultReorderPolicy()
+
+	settings, err := s.repo.ListReorderSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reorder settings: %w", err)
+	}
+	settingsByCategory := make(map[int64]sqlc.ReorderSetting, len(settings))
+	for _, setting := range settings {
+		settingsByCategory[setting.CategoryID] = setting
+	}
+
+	since := time.Now().AddDate(0, 0, -int(defaults.windowDays))
+	rows, err := s.repo.GetProductSalesVelocity(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales velocity: %w", err)
+	}
+
+	suggestions := sqlc.UpsertReorderSuggestionsParams{WindowDays: defaults.windowDays}
+	for _, row := range rows {
+		policy := defaults
+		if setting, ok := settingsByCategory[row.CategoryID]; ok {
+			policy.leadTimeDays = setting.LeadTimeDays
+			policy.safetyStockDays = setting.SafetyStockDays
+		}
+
+		// Orders capture demand that has not shipped yet; deduct logs capture sales made
+		// outside the order flow. Taking the larger avoids under-counting either way.
+		unitsSold := max(row.OrderedUnits, row.DeductedUnits)
+		suggestion := computeReorderSuggestion(unitsSold, row.AvailableStock, policy)
+
+		suggestions.ProductIds = append(suggestions.ProductIds, row.ProductID)
+		suggestions.SkuIds = append(suggestions.SkuIds, NewStockKey(row.ProductID, row.SkuID).SkuID)
+		suggestions.CategoryIds = append(suggestions.CategoryIds, row.CategoryID)
+		suggestions.UnitsSold = append(suggestions.UnitsSold, int32(min(unitsSold, math.MaxInt32)))
+		suggestions.DailyVelocities = append(suggestions.DailyVelocities, suggestion.dailyVelocity)
+		suggestions.LeadTimeDays = append(suggestions.LeadTimeDays, policy.leadTimeDays)
+		suggestions.SafetyStockDays = append(suggestions.SafetyStockDays, policy.safetyStockDays)
+		suggestions.ReorderPoints = append(suggestions.ReorderPoints, suggestion.reorderPoint)
+		suggestions.ReorderQuantities = append(suggestions.ReorderQuantities, suggestion.reorderQuantity)
+		suggestions.AvailableStocks = append(suggestions.AvailableStocks, row.AvailableStock)
+	}
+
+	if len(rows) > 0 {
+		if err := s.repo.UpsertReorderSuggestions(ctx, suggestions); err != nil {
+			return nil, fmt.Errorf("failed to save reorder suggestions: %w", err)
+		}
+	}
+
+	result := &ReorderRefreshResponse{
+		Products:              len(rows),
+		AutoAppliedCategories: []int64{},
+	}
+	for _, setting := range settings {
+		if !setting.AutoApply {
+			continue
+		}
+		updated, err := s.repo.ApplyReorderPointsToCategory(ctx, setting.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply reorder points: %w", err)
+		}
+		result.AutoAppliedCategories = append(result.AutoAppliedCategories, setting.CategoryID)
+		result.ThresholdsUpdated += updated
+	}
+
+	return result, nil
+}
+
+// ListReorderSuggestions lists the latest computed suggestions
+func (s *service) ListReorderSuggestions(ctx context.Context, req ListReorderSuggestionsRequest) (*PaginatedReorderSuggestionsResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 20
+	}
+
+	rows, err := s.repo.ListReorderSuggestions(ctx, sqlc.ListReorderSuggestionsParams{
+		CategoryID:   req.CategoryID,
+		NeedsReorder: req.NeedsReorder,
+		LimitCount:   req.PageSize,
+		OffsetCount:  (req.Page - 1) * req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reorder suggestions: %w", err)
+	}
+
+	total, err := s.repo.CountReorderSuggestions(ctx, sqlc.CountReorderSuggestionsParams{
+		CategoryID:   req.CategoryID,
+		NeedsReorder: req.NeedsReorder,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reorder suggestions: %w", err)
+	}
+
+	suggestions := make([]ReorderSuggestionResponse, len(rows))
+	for i, row := range rows {
+		suggestions[i] = toReorderSuggestionResponse(row)
+	}
+
+	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+
+	return &PaginatedReorderSuggestionsResponse{
+		Suggestions: suggestions,
+		Total:       total,
+		Page:        req.Page,
+		PageSize:    req.PageSize,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+// ApplyReorderPoints copies the suggested reorder points of a category into low_stock_threshold.
+// Products without recent sales have a zero reorder point and keep their current threshold.
+func (s *service) ApplyReorderPoints(ctx context.Context, categoryID int64) (*ApplyReorderPointsResponse, error) {
+	updated, err := s.repo.ApplyReorderPointsToCategory(ctx, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply reorder points: %w", err)
+	}
+
+	return &ApplyReorderPointsResponse{
+		CategoryID:        categoryID,
+		ThresholdsUpdated: updated,
+	}, nil
+}
+
+// ListReorderSettings lists per-category reorder overrides
+func (s *service) ListReorderSettings(ctx context.Context) ([]ReorderSettingResponse, error) {
+	settings, err := s.repo.ListReorderSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reorder settings: %w", err)
+	}
+
+	result := make([]ReorderSettingResponse, len(settings))
+	for i, setting := range settings {
+		result[i] = toReorderSettingResponse(setting)
+	}
+	return result, nil
+}
+
+// UpsertReorderSetting creates or replaces the reorder override for a category
+func (s *service) UpsertReorderSetting(ctx context.Context, categoryID int64, req UpsertReorderSettingRequest) (*ReorderSettingResponse, error) {
+	setting, err := s.repo.UpsertReorderSetting(ctx, sqlc.UpsertReorderSettingParams{
+		CategoryID:      categoryID,
+		LeadTimeDays:    req.LeadTimeDays,
+		SafetyStockDays: req.SafetyStockDays,
+		AutoApply:       req.AutoApply,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save reorder setting: %w", err)
+	}
+
+	resp := toReorderSettingResponse(setting)
+	return &resp, nil
+}
+
+// DeleteReorderSetting removes a category override so the defaults apply again
+func (s *service) DeleteReorderSetting(ctx context.Context, categoryID int64) error {
+	rows, err := s.repo.DeleteReorderSetting(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to delete reorder setting: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("reorder setting not found")
+	}
+	return nil
+}
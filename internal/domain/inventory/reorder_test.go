@@ -0,0 +1,69 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+	"gomall/internal/config"
+)
+
+func TestComputeReorderSuggestion(t *testing.T) {
+	policy := reorderPolicy{windowDays: 30, leadTimeDays: 7, safetyStockDays: 3, reviewPeriodDays: 14}
+
+	// No sales: nothing to suggest
+	require.Equal(t, reorderSuggestion{}, computeReorderSuggestion(0, 50, policy))
+
+	// 60 units over 30 days = 2/day; point = 2*(7+3) = 20; order up to 2*(7+3+14) = 48
+	s := computeReorderSuggestion(60, 15, policy)
+	require.InDelta(t, 2.0, s.dailyVelocity, 1e-9)
+	require.Equal(t, int32(20), s.reorderPoint)
+	require.Equal(t, int32(33), s.reorderQuantity)
+
+	// Fractional demand rounds up: 10/30 per day * 10 days = 3.33 -> 4
+	s = computeReorderSuggestion(10, 0, policy)
+	require.Equal(t, int32(4), s.reorderPoint)
+	require.Equal(t, int32(8), s.reorderQuantity)
+
+	// Stock above the order-up-to level needs no reorder
+	s = computeReorderSuggestion(60, 100, policy)
+	require.Equal(t, int32(0), s.reorderQuantity)
+}
+
+func TestRefreshReorderSuggestionsSavesInOneStatement(t *testing.T) {
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	s := NewService(store, nil, config.ReorderConfig{WindowDays: 30, LeadTimeDays: 7, SafetyStockDays: 3, ReviewPeriodDays: 14})
+
+	store.EXPECT().ListReorderSettings(gomock.Any()).Return([]sqlc.ReorderSetting{
+		{CategoryID: 2, LeadTimeDays: 2, SafetyStockDays: 0, AutoApply: true},
+	}, nil)
+	store.EXPECT().GetProductSalesVelocity(gomock.Any(), gomock.Any()).Return([]sqlc.GetProductSalesVelocityRow{
+		{ProductID: 5, CategoryID: 1, AvailableStock: 15, OrderedUnits: 60, DeductedUnits: 20},
+		{ProductID: 6, CategoryID: 2, AvailableStock: 4, OrderedUnits: 0, DeductedUnits: 0},
+	}, nil)
+	store.EXPECT().UpsertReorderSuggestions(gomock.Any(), sqlc.UpsertReorderSuggestionsParams{
+		WindowDays:        30,
+		ProductIds:        []int64{5, 6},
+		SkuIds:            []int64{0, 0},
+		CategoryIds:       []int64{1, 2},
+		UnitsSold:         []int32{60, 0},
+		DailyVelocities:   []float64{2, 0},
+		LeadTimeDays:      []int32{7, 2},
+		SafetyStockDays:   []int32{3, 0},
+		ReorderPoints:     []int32{20, 0},
+		ReorderQuantities: []int32{33, 0},
+		AvailableStocks:   []int32{15, 4},
+	}).Return(nil)
+	// Product 6 has a zero reorder point, which the update skips
+	store.EXPECT().ApplyReorderPointsToCategory(gomock.Any(), int64(2)).Return(int64(0), nil)
+
+	resp, err := s.RefreshReorderSuggestions(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.Products)
+	require.Equal(t, []int64{2}, resp.AutoAppliedCategories)
+	require.Equal(t, int64(0), resp.ThresholdsUpdated)
+}
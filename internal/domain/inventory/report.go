@@ -0,0 +1,225 @@
+package inventory
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+)
+
+// Where a point-in-time stock figure came from
+const (
+	AsOfSourceLog             = "log"              // after-values of the last movement at or before the timestamp
+	AsOfSourceBeforeFirstLog  = "before_first_log" // before-values of the first movement after the timestamp
+	AsOfSourceCurrent         = "current"          // no movements at all, so current stock applies
+	AsOfSourceNotYetTracked   = "not_yet_tracked"  // the timestamp predates the inventory record
+	movementReportExportBatch = 1000
+)
+
+var movementReportPeriods = map[string]bool{"day": true, "week": true, "month": true}
+
+var movementReportHeader = []string{
+	"period_start",
+	"product_id",
+	"product_name",
+	"restocked",
+	"reserved",
+	"released",
+	"deducted",
+	"adjusted",
+	"net_available_change",
+	"net_reserved_change",
+	"movement_count",
+}
+
+// GetStockAsOf reconstructs available and reserved stock of a product or SKU at a point in time from inventory_logs
+func (s *service) GetStockAsOf(ctx context.Context, productID int64, skuID *int64, asOf time.Time) (*StockAsOfResponse, error) {
+	inventory, err := getStockUnit(ctx, s.repo, productID, skuID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("inventory not found")
+		}
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
+	}
+
+	resp := &StockAsOfResponse{
+		ProductID: productID,
+		SkuID:     skuID,
+		AsOf:      asOf,
+	}
+
+	last, err := s.repo.GetLastInventoryLogAtOrBefore(ctx, sqlc.GetLastInventoryLogAtOrBeforeParams{
+		ProductID: productID,
+		SkuID:     skuID,
+		AsOf:      asOf,
+	})
+	if err == nil {
+		resp.setStock(last.AfterAvailable, last.AfterReserved, AsOfSourceLog, last.ID)
+		return resp, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get inventory log: %w", err)
+	}
+
+	if asOf.Before(inventory.CreatedAt) {
+		resp.Source = AsOfSourceNotYetTracked
+		return resp, nil
+	}
+
+	// No movement yet at asOf: the stock is whatever the first later movement started from
+	first, err := s.repo.GetFirstInventoryLogAfter(ctx, sqlc.GetFirstInventoryLogAfterParams{
+		ProductID: productID,
+		SkuID:     skuID,
+		AsOf:      asOf,
+	})
+	if err == nil {
+		resp.setStock(first.BeforeAvailable, first.BeforeReserved, AsOfSourceBeforeFirstLog, first.ID)
+		return resp, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get inventory log: %w", err)
+	}
+
+	resp.Exists = true
+	resp.AvailableStock = inventory.AvailableStock
+	resp.ReservedStock = inventory.ReservedStock
+	resp.TotalStock = inventory.AvailableStock + inventory.ReservedStock
+	resp.Source = AsOfSourceCurrent
+	return resp, nil
+}
+
+func (r *StockAsOfResponse) setStock(available, reserved int32, source string, logID int64) {
+	r.Exists = true
+	r.AvailableStock = available
+	r.ReservedStock = reserved
+	r.TotalStock = available + reserved
+	r.Source = source
+	r.LogID = &logID
+}
+
+// GetMovementReport aggregates inventory movements per product per period
+func (s *service) GetMovementReport(ctx context.Context, req MovementReportRequest) (*MovementReportResponse, error) {
+	if err := validateMovementReportRequest(&req); err != nil {
+		return nil, err
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 20
+	}
+
+	rows, err := s.repo.GetInventoryMovementReport(ctx, sqlc.GetInventoryMovementReportParams{
+		Period:      req.Period,
+		FromTime:    req.From,
+		ToTime:      req.To,
+		ProductID:   req.ProductID,
+		LimitCount:  req.PageSize,
+		OffsetCount: (req.Page - 1) * req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movement report: %w", err)
+	}
+
+	total, err := s.repo.CountInventoryMovementReport(ctx, sqlc.CountInventoryMovementReportParams{
+		FromTime:  req.From,
+		ToTime:    req.To,
+		ProductID: req.ProductID,
+		Period:    req.Period,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count movement report: %w", err)
+	}
+
+	reportRows := make([]MovementReportRow, len(rows))
+	for i, row := range rows {
+		reportRows[i] = toMovementReportRow(row)
+	}
+
+	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+
+	return &MovementReportResponse{
+		From:       req.From,
+		To:         req.To,
+		Period:     req.Period,
+		Rows:       reportRows,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// ExportMovementReport streams the whole movement report as CSV; paging fields are ignored
+func (s *service) ExportMovementReport(ctx context.Context, req MovementReportRequest, w io.Writer) error {
+	if err := validateMovementReportRequest(&req); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(movementReportHeader); err != nil {
+		return fmt.Errorf("failed to write report header: %w", err)
+	}
+
+	for offset := int32(0); ; offset += movementReportExportBatch {
+		rows, err := s.repo.GetInventoryMovementReport(ctx, sqlc.GetInventoryMovementReportParams{
+			Period:      req.Period,
+			FromTime:    req.From,
+			ToTime:      req.To,
+			ProductID:   req.ProductID,
+			LimitCount:  movementReportExportBatch,
+			OffsetCount: offset,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get movement report: %w", err)
+		}
+
+		for _, row := range rows {
+			record := []string{
+				row.PeriodStart.Format(time.RFC3339),
+				strconv.FormatInt(row.ProductID, 10),
+				row.ProductName,
+				strconv.FormatInt(row.Restocked, 10),
+				strconv.FormatInt(row.Reserved, 10),
+				strconv.FormatInt(row.Released, 10),
+				strconv.FormatInt(row.Deducted, 10),
+				strconv.FormatInt(row.Adjusted, 10),
+				strconv.FormatInt(row.NetAvailableChange, 10),
+				strconv.FormatInt(row.NetReservedChange, 10),
+				strconv.FormatInt(row.MovementCount, 10),
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write report row: %w", err)
+			}
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to flush report: %w", err)
+		}
+
+		if len(rows) < movementReportExportBatch {
+			return nil
+		}
+	}
+}
+
+func validateMovementReportRequest(req *MovementReportRequest) error {
+	if req.Period == "" {
+		req.Period = "month"
+	}
+	if !movementReportPeriods[req.Period] {
+		return errors.New("period must be day, week or month")
+	}
+	if !req.From.Before(req.To) {
+		return errors.New("from must be before to")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+	"gomall/internal/config"
+	"gomall/utils"
+)
+
+func TestGetStockAsOf(t *testing.T) {
+	ctx := context.Background()
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	s := NewService(store, nil, config.ReorderConfig{})
+
+	createdAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	asOf := createdAt.Add(48 * time.Hour)
+	inventory := sqlc.Inventory{ID: 20, ProductID: 5, AvailableStock: 9, ReservedStock: 1, CreatedAt: createdAt}
+	lastParams := sqlc.GetLastInventoryLogAtOrBeforeParams{ProductID: 5, AsOf: asOf}
+	firstParams := sqlc.GetFirstInventoryLogAfterParams{ProductID: 5, AsOf: asOf}
+
+	// The last movement at or before the timestamp gives its after-values
+	store.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(inventory, nil)
+	store.EXPECT().GetLastInventoryLogAtOrBefore(gomock.Any(), lastParams).Return(sqlc.InventoryLog{ID: 70, AfterAvailable: 4, AfterReserved: 2}, nil)
+	resp, err := s.GetStockAsOf(ctx, 5, nil, asOf)
+	require.NoError(t, err)
+	require.Equal(t, &StockAsOfResponse{ProductID: 5, AsOf: asOf, Exists: true, AvailableStock: 4, ReservedStock: 2, TotalStock: 6, Source: AsOfSourceLog, LogID: utils.Ptr(int64(70))}, resp)
+
+	// Without an earlier movement, the first later one gives its before-values
+	store.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(inventory, nil)
+	store.EXPECT().GetLastInventoryLogAtOrBefore(gomock.Any(), lastParams).Return(sqlc.InventoryLog{}, pgx.ErrNoRows)
+	store.EXPECT().GetFirstInventoryLogAfter(gomock.Any(), firstParams).Return(sqlc.InventoryLog{ID: 71, BeforeAvailable: 7, BeforeReserved: 0}, nil)
+	resp, err = s.GetStockAsOf(ctx, 5, nil, asOf)
+	require.NoError(t, err)
+	require.Equal(t, AsOfSourceBeforeFirstLog, resp.Source)
+	require.Equal(t, int32(7), resp.AvailableStock)
+
+	// No movements at all: current stock applies
+	store.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(inventory, nil)
+	store.EXPECT().GetLastInventoryLogAtOrBefore(gomock.Any(), lastParams).Return(sqlc.InventoryLog{}, pgx.ErrNoRows)
+	store.EXPECT().GetFirstInventoryLogAfter(gomock.Any(), firstParams).Return(sqlc.InventoryLog{}, pgx.ErrNoRows)
+	resp, err = s.GetStockAsOf(ctx, 5, nil, asOf)
+	require.NoError(t, err)
+	require.Equal(t, AsOfSourceCurrent, resp.Source)
+	require.Equal(t, int32(10), resp.TotalStock)
+
+	// Before the inventory record existed there is nothing to report
+	before := createdAt.Add(-time.Hour)
+	store.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(inventory, nil)
+	store.EXPECT().GetLastInventoryLogAtOrBefore(gomock.Any(), sqlc.GetLastInventoryLogAtOrBeforeParams{ProductID: 5, AsOf: before}).Return(sqlc.InventoryLog{}, pgx.ErrNoRows)
+	resp, err = s.GetStockAsOf(ctx, 5, nil, before)
+	require.NoError(t, err)
+	require.False(t, resp.Exists)
+	require.Equal(t, AsOfSourceNotYetTracked, resp.Source)
+}
+
+func TestGetMovementReport(t *testing.T) {
+	ctx := context.Background()
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	s := NewService(store, nil, config.ReorderConfig{})
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 3, 0)
+
+	_, err := s.GetMovementReport(ctx, MovementReportRequest{From: from, To: to, Period: "year"})
+	require.EqualError(t, err, "period must be day, week or month")
+	_, err = s.GetMovementReport(ctx, MovementReportRequest{From: to, To: from})
+	require.EqualError(t, err, "from must be before to")
+
+	// Period defaults to month and paging to the first page of 20
+	store.EXPECT().GetInventoryMovementReport(gomock.Any(), sqlc.GetInventoryMovementReportParams{
+		Period: "month", FromTime: from, ToTime: to, LimitCount: 20,
+	}).Return([]sqlc.GetInventoryMovementReportRow{{ProductID: 5, PeriodStart: from, Restocked: 10, Deducted: 4, NetAvailableChange: 6}}, nil)
+	store.EXPECT().CountInventoryMovementReport(gomock.Any(), sqlc.CountInventoryMovementReportParams{
+		FromTime: from, ToTime: to, Period: "month",
+	}).Return(int64(41), nil)
+
+	resp, err := s.GetMovementReport(ctx, MovementReportRequest{From: from, To: to})
+	require.NoError(t, err)
+	require.Equal(t, "month", resp.Period)
+	require.Equal(t, int32(3), resp.TotalPages)
+	require.Len(t, resp.Rows, 1)
+	require.Equal(t, int64(6), resp.Rows[0].NetAvailableChange)
+}
+
+func TestExportMovementReportPagesThroughAllRows(t *testing.T) {
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	s := NewService(store, nil, config.ReorderConfig{})
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	full := make([]sqlc.GetInventoryMovementReportRow, movementReportExportBatch)
+	for i := range full {
+		full[i] = sqlc.GetInventoryMovementReportRow{ProductID: int64(i + 1), PeriodStart: from}
+	}
+
+	gomock.InOrder(
+		store.EXPECT().GetInventoryMovementReport(gomock.Any(), sqlc.GetInventoryMovementReportParams{
+			Period: "day", FromTime: from, ToTime: to, LimitCount: movementReportExportBatch,
+		}).Return(full, nil),
+		store.EXPECT().GetInventoryMovementReport(gomock.Any(), sqlc.GetInventoryMovementReportParams{
+			Period: "day", FromTime: from, ToTime: to, LimitCount: movementReportExportBatch, OffsetCount: movementReportExportBatch,
+		}).Return([]sqlc.GetInventoryMovementReportRow{{ProductID: 9999, PeriodStart: from}}, nil),
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, s.ExportMovementReport(context.Background(), MovementReportRequest{From: from, To: to, Period: "day"}, &buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, movementReportExportBatch+2)
+	require.Equal(t, movementReportHeader, records[0])
+	require.Equal(t, "9999", records[len(records)-1][1])
+}
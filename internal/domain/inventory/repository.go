@@ -2,6 +2,7 @@ package inventory
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -13,6 +14,7 @@ type Repository interface {
 	// Inventory operations
 	CreateInventory(ctx context.Context, arg sqlc.CreateInventoryParams) (sqlc.Inventory, error)
 	GetInventoryByProductID(ctx context.Context, productID int64) (sqlc.Inventory, error)
+	GetInventoryBySkuID(ctx context.Context, skuID *int64) (sqlc.Inventory, error)
 	GetInventoryByID(ctx context.Context, id int64) (sqlc.Inventory, error)
 	ListInventories(ctx context.Context, arg sqlc.ListInventoriesParams) ([]sqlc.Inventory, error)
 	ListLowStockInventories(ctx context.Context, arg sqlc.ListLowStockInventoriesParams) ([]sqlc.Inventory, error)
@@ -25,12 +27,21 @@ type Repository interface {
 	AddAvailableStock(ctx context.Context, arg sqlc.AddAvailableStockParams) error
 	UpdateLowStockThreshold(ctx context.Context, arg sqlc.UpdateLowStockThresholdParams) error
 	DeleteInventory(ctx context.Context, productID int64) error
+	GetInventoriesByProductIDs(ctx context.Context, productIds []int64) ([]sqlc.Inventory, error)
+	GetInventoriesBySkuIDs(ctx context.Context, skuIds []int64) ([]sqlc.Inventory, error)
+	ListProductSkusByCodes(ctx context.Context, skuCodes []string) ([]sqlc.ListProductSkusByCodesRow, error)
+	ListInventoriesForExport(ctx context.Context, arg sqlc.ListInventoriesForExportParams) ([]sqlc.ListInventoriesForExportRow, error)
 
 	// Inventory log operations
 	CreateInventoryLog(ctx context.Context, arg sqlc.CreateInventoryLogParams) (sqlc.InventoryLog, error)
 	GetInventoryLogsByProductID(ctx context.Context, arg sqlc.GetInventoryLogsByProductIDParams) ([]sqlc.InventoryLog, error)
 	GetInventoryLogsByOrderID(ctx context.Context, orderID int64) ([]sqlc.InventoryLog, error)
 	CountInventoryLogsByProductID(ctx context.Context, productID int64) (int64, error)
+	GetInventoryLogsByReference(ctx context.Context, arg sqlc.GetInventoryLogsByReferenceParams) ([]sqlc.InventoryLog, error)
+	GetLastInventoryLogAtOrBefore(ctx context.Context, arg sqlc.GetLastInventoryLogAtOrBeforeParams) (sqlc.InventoryLog, error)
+	GetFirstInventoryLogAfter(ctx context.Context, arg sqlc.GetFirstInventoryLogAfterParams) (sqlc.InventoryLog, error)
+	GetInventoryMovementReport(ctx context.Context, arg sqlc.GetInventoryMovementReportParams) ([]sqlc.GetInventoryMovementReportRow, error)
+	CountInventoryMovementReport(ctx context.Context, arg sqlc.CountInventoryMovementReportParams) (int64, error)
 
 	// Inventory reservation operations
 	CreateInventoryReservation(ctx context.Context, arg sqlc.CreateInventoryReservationParams) (sqlc.InventoryReservation, error)
@@ -43,6 +54,54 @@ type Repository interface {
 	GetExpiredReservations(ctx context.Context, limit int32) ([]sqlc.InventoryReservation, error)
 	DeleteReservation(ctx context.Context, id int64) error
 
+	// Stock alert operations
+	CreateStockAlert(ctx context.Context, arg sqlc.CreateStockAlertParams) (sqlc.StockAlert, error)
+	GetActiveStockAlert(ctx context.Context, arg sqlc.GetActiveStockAlertParams) (sqlc.GetActiveStockAlertRow, error)
+	UpdateStockAlertLevel(ctx context.Context, arg sqlc.UpdateStockAlertLevelParams) error
+	MarkStockAlertNotified(ctx context.Context, id int64) error
+	ResolveStockAlerts(ctx context.Context, arg sqlc.ResolveStockAlertsParams) error
+	ListActiveStockAlerts(ctx context.Context, arg sqlc.ListActiveStockAlertsParams) ([]sqlc.ListActiveStockAlertsRow, error)
+	CountActiveStockAlerts(ctx context.Context) (int64, error)
+	CountOutOfStockAlerts(ctx context.Context) (int64, error)
+	CountResolvedStockAlertsSince(ctx context.Context, since time.Time) (int64, error)
+
+	// Import job operations
+	CreateImportJob(ctx context.Context, arg sqlc.CreateImportJobParams) (sqlc.InventoryImportJob, error)
+	GetImportJob(ctx context.Context, id int64) (sqlc.InventoryImportJob, error)
+	StartImportJob(ctx context.Context, id int64) error
+	UpdateImportJobProgress(ctx context.Context, arg sqlc.UpdateImportJobProgressParams) error
+	FinishImportJob(ctx context.Context, arg sqlc.FinishImportJobParams) error
+
+	// Stocktake operations
+	CreateStocktake(ctx context.Context, arg sqlc.CreateStocktakeParams) (sqlc.Stocktake, error)
+	GetStocktake(ctx context.Context, id int64) (sqlc.Stocktake, error)
+	ListStocktakes(ctx context.Context, arg sqlc.ListStocktakesParams) ([]sqlc.Stocktake, error)
+	CountStocktakes(ctx context.Context, status *string) (int64, error)
+	ApproveStocktake(ctx context.Context, arg sqlc.ApproveStocktakeParams) (int64, error)
+	CancelStocktake(ctx context.Context, id int64) (int64, error)
+	AddStocktakeItemsForProducts(ctx context.Context, arg sqlc.AddStocktakeItemsForProductsParams) (int64, error)
+	AddStocktakeItemsForCategory(ctx context.Context, arg sqlc.AddStocktakeItemsForCategoryParams) (int64, error)
+	RecordStocktakeCount(ctx context.Context, arg sqlc.RecordStocktakeCountParams) (int64, error)
+	ListStocktakeItems(ctx context.Context, stocktakeID int64) ([]sqlc.ListStocktakeItemsRow, error)
+	CountUncountedStocktakeItems(ctx context.Context, stocktakeID int64) (int64, error)
+	SetStocktakeItemVariance(ctx context.Context, arg sqlc.SetStocktakeItemVarianceParams) error
+
+	// Backorder operations
+	UpdateStockPolicy(ctx context.Context, arg sqlc.UpdateStockPolicyParams) (sqlc.Inventory, error)
+	HoldOrderReservations(ctx context.Context, orderID int64) error
+	ListBackorders(ctx context.Context, arg sqlc.ListBackordersParams) ([]sqlc.InventoryBackorder, error)
+	CountBackorders(ctx context.Context, arg sqlc.CountBackordersParams) (int64, error)
+
+	// Reorder operations
+	UpsertReorderSetting(ctx context.Context, arg sqlc.UpsertReorderSettingParams) (sqlc.ReorderSetting, error)
+	ListReorderSettings(ctx context.Context) ([]sqlc.ReorderSetting, error)
+	DeleteReorderSetting(ctx context.Context, categoryID int64) (int64, error)
+	GetProductSalesVelocity(ctx context.Context, since time.Time) ([]sqlc.GetProductSalesVelocityRow, error)
+	UpsertReorderSuggestions(ctx context.Context, arg sqlc.UpsertReorderSuggestionsParams) error
+	ListReorderSuggestions(ctx context.Context, arg sqlc.ListReorderSuggestionsParams) ([]sqlc.ListReorderSuggestionsRow, error)
+	CountReorderSuggestions(ctx context.Context, arg sqlc.CountReorderSuggestionsParams) (int64, error)
+	ApplyReorderPointsToCategory(ctx context.Context, categoryID int64) (int64, error)
+
 	// Transaction support
 	ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error
 }
@@ -68,6 +127,10 @@ func (r *repository) GetInventoryByProductID(ctx context.Context, productID int6
 	return r.store.GetInventoryByProductID(ctx, productID)
 }
 
+func (r *repository) GetInventoryBySkuID(ctx context.Context, skuID *int64) (sqlc.Inventory, error) {
+	return r.store.GetInventoryBySkuID(ctx, skuID)
+}
+
 func (r *repository) GetInventoryByID(ctx context.Context, id int64) (sqlc.Inventory, error) {
 	return r.store.GetInventoryByID(ctx, id)
 }
@@ -116,6 +179,22 @@ func (r *repository) DeleteInventory(ctx context.Context, productID int64) error
 	return r.store.DeleteInventory(ctx, productID)
 }
 
+func (r *repository) GetInventoriesByProductIDs(ctx context.Context, productIds []int64) ([]sqlc.Inventory, error) {
+	return r.store.GetInventoriesByProductIDs(ctx, productIds)
+}
+
+func (r *repository) GetInventoriesBySkuIDs(ctx context.Context, skuIds []int64) ([]sqlc.Inventory, error) {
+	return r.store.GetInventoriesBySkuIDs(ctx, skuIds)
+}
+
+func (r *repository) ListProductSkusByCodes(ctx context.Context, skuCodes []string) ([]sqlc.ListProductSkusByCodesRow, error) {
+	return r.store.ListProductSkusByCodes(ctx, skuCodes)
+}
+
+func (r *repository) ListInventoriesForExport(ctx context.Context, arg sqlc.ListInventoriesForExportParams) ([]sqlc.ListInventoriesForExportRow, error) {
+	return r.store.ListInventoriesForExport(ctx, arg)
+}
+
 // Inventory log operations
 
 func (r *repository) CreateInventoryLog(ctx context.Context, arg sqlc.CreateInventoryLogParams) (sqlc.InventoryLog, error) {
@@ -134,6 +213,26 @@ func (r *repository) CountInventoryLogsByProductID(ctx context.Context, productI
 	return r.store.CountInventoryLogsByProductID(ctx, productID)
 }
 
+func (r *repository) GetInventoryLogsByReference(ctx context.Context, arg sqlc.GetInventoryLogsByReferenceParams) ([]sqlc.InventoryLog, error) {
+	return r.store.GetInventoryLogsByReference(ctx, arg)
+}
+
+func (r *repository) GetLastInventoryLogAtOrBefore(ctx context.Context, arg sqlc.GetLastInventoryLogAtOrBeforeParams) (sqlc.InventoryLog, error) {
+	return r.store.GetLastInventoryLogAtOrBefore(ctx, arg)
+}
+
+func (r *repository) GetFirstInventoryLogAfter(ctx context.Context, arg sqlc.GetFirstInventoryLogAfterParams) (sqlc.InventoryLog, error) {
+	return r.store.GetFirstInventoryLogAfter(ctx, arg)
+}
+
+func (r *repository) GetInventoryMovementReport(ctx context.Context, arg sqlc.GetInventoryMovementReportParams) ([]sqlc.GetInventoryMovementReportRow, error) {
+	return r.store.GetInventoryMovementReport(ctx, arg)
+}
+
+func (r *repository) CountInventoryMovementReport(ctx context.Context, arg sqlc.CountInventoryMovementReportParams) (int64, error) {
+	return r.store.CountInventoryMovementReport(ctx, arg)
+}
+
 // Inventory reservation operations
 
 func (r *repository) CreateInventoryReservation(ctx context.Context, arg sqlc.CreateInventoryReservationParams) (sqlc.InventoryReservation, error) {
@@ -172,6 +271,168 @@ func (r *repository) DeleteReservation(ctx context.Context, id int64) error {
 	return r.store.DeleteReservation(ctx, id)
 }
 
+// Stock alert operations
+
+func (r *repository) CreateStockAlert(ctx context.Context, arg sqlc.CreateStockAlertParams) (sqlc.StockAlert, error) {
+	return r.store.CreateStockAlert(ctx, arg)
+}
+
+func (r *repository) GetActiveStockAlert(ctx context.Context, arg sqlc.GetActiveStockAlertParams) (sqlc.GetActiveStockAlertRow, error) {
+	return r.store.GetActiveStockAlert(ctx, arg)
+}
+
+func (r *repository) UpdateStockAlertLevel(ctx context.Context, arg sqlc.UpdateStockAlertLevelParams) error {
+	return r.store.UpdateStockAlertLevel(ctx, arg)
+}
+
+func (r *repository) MarkStockAlertNotified(ctx context.Context, id int64) error {
+	return r.store.MarkStockAlertNotified(ctx, id)
+}
+
+func (r *repository) ResolveStockAlerts(ctx context.Context, arg sqlc.ResolveStockAlertsParams) error {
+	return r.store.ResolveStockAlerts(ctx, arg)
+}
+
+func (r *repository) ListActiveStockAlerts(ctx context.Context, arg sqlc.ListActiveStockAlertsParams) ([]sqlc.ListActiveStockAlertsRow, error) {
+	return r.store.ListActiveStockAlerts(ctx, arg)
+}
+
+func (r *repository) CountActiveStockAlerts(ctx context.Context) (int64, error) {
+	return r.store.CountActiveStockAlerts(ctx)
+}
+
+func (r *repository) CountOutOfStockAlerts(ctx context.Context) (int64, error) {
+	return r.store.CountOutOfStockAlerts(ctx)
+}
+
+func (r *repository) CountResolvedStockAlertsSince(ctx context.Context, since time.Time) (int64, error) {
+	return r.store.CountResolvedStockAlertsSince(ctx, since)
+}
+
+// Import job operations
+
+func (r *repository) CreateImportJob(ctx context.Context, arg sqlc.CreateImportJobParams) (sqlc.InventoryImportJob, error) {
+	return r.store.CreateImportJob(ctx, arg)
+}
+
+func (r *repository) GetImportJob(ctx context.Context, id int64) (sqlc.InventoryImportJob, error) {
+	return r.store.GetImportJob(ctx, id)
+}
+
+func (r *repository) StartImportJob(ctx context.Context, id int64) error {
+	return r.store.StartImportJob(ctx, id)
+}
+
+func (r *repository) UpdateImportJobProgress(ctx context.Context, arg sqlc.UpdateImportJobProgressParams) error {
+	return r.store.UpdateImportJobProgress(ctx, arg)
+}
+
+func (r *repository) FinishImportJob(ctx context.Context, arg sqlc.FinishImportJobParams) error {
+	return r.store.FinishImportJob(ctx, arg)
+}
+
+// Stocktake operations
+
+func (r *repository) CreateStocktake(ctx context.Context, arg sqlc.CreateStocktakeParams) (sqlc.Stocktake, error) {
+	return r.store.CreateStocktake(ctx, arg)
+}
+
+func (r *repository) GetStocktake(ctx context.Context, id int64) (sqlc.Stocktake, error) {
+	return r.store.GetStocktake(ctx, id)
+}
+
+func (r *repository) ListStocktakes(ctx context.Context, arg sqlc.ListStocktakesParams) ([]sqlc.Stocktake, error) {
+	return r.store.ListStocktakes(ctx, arg)
+}
+
+func (r *repository) CountStocktakes(ctx context.Context, status *string) (int64, error) {
+	return r.store.CountStocktakes(ctx, status)
+}
+
+func (r *repository) ApproveStocktake(ctx context.Context, arg sqlc.ApproveStocktakeParams) (int64, error) {
+	return r.store.ApproveStocktake(ctx, arg)
+}
+
+func (r *repository) CancelStocktake(ctx context.Context, id int64) (int64, error) {
+	return r.store.CancelStocktake(ctx, id)
+}
+
+func (r *repository) AddStocktakeItemsForProducts(ctx context.Context, arg sqlc.AddStocktakeItemsForProductsParams) (int64, error) {
+	return r.store.AddStocktakeItemsForProducts(ctx, arg)
+}
+
+func (r *repository) AddStocktakeItemsForCategory(ctx context.Context, arg sqlc.AddStocktakeItemsForCategoryParams) (int64, error) {
+	return r.store.AddStocktakeItemsForCategory(ctx, arg)
+}
+
+func (r *repository) RecordStocktakeCount(ctx context.Context, arg sqlc.RecordStocktakeCountParams) (int64, error) {
+	return r.store.RecordStocktakeCount(ctx, arg)
+}
+
+func (r *repository) ListStocktakeItems(ctx context.Context, stocktakeID int64) ([]sqlc.ListStocktakeItemsRow, error) {
+	return r.store.ListStocktakeItems(ctx, stocktakeID)
+}
+
+func (r *repository) CountUncountedStocktakeItems(ctx context.Context, stocktakeID int64) (int64, error) {
+	return r.store.CountUncountedStocktakeItems(ctx, stocktakeID)
+}
+
+func (r *repository) SetStocktakeItemVariance(ctx context.Context, arg sqlc.SetStocktakeItemVarianceParams) error {
+	return r.store.SetStocktakeItemVariance(ctx, arg)
+}
+
+// Backorder operations
+
+func (r *repository) UpdateStockPolicy(ctx context.Context, arg sqlc.UpdateStockPolicyParams) (sqlc.Inventory, error) {
+	return r.store.UpdateStockPolicy(ctx, arg)
+}
+
+func (r *repository) HoldOrderReservations(ctx context.Context, orderID int64) error {
+	return r.store.HoldOrderReservations(ctx, orderID)
+}
+
+func (r *repository) ListBackorders(ctx context.Context, arg sqlc.ListBackordersParams) ([]sqlc.InventoryBackorder, error) {
+	return r.store.ListBackorders(ctx, arg)
+}
+
+func (r *repository) CountBackorders(ctx context.Context, arg sqlc.CountBackordersParams) (int64, error) {
+	return r.store.CountBackorders(ctx, arg)
+}
+
+// Reorder operations
+
+func (r *repository) UpsertReorderSetting(ctx context.Context, arg sqlc.UpsertReorderSettingParams) (sqlc.ReorderSetting, error) {
+	return r.store.UpsertReorderSetting(ctx, arg)
+}
+
+func (r *repository) ListReorderSettings(ctx context.Context) ([]sqlc.ReorderSetting, error) {
+	return r.store.ListReorderSettings(ctx)
+}
+
+func (r *repository) DeleteReorderSetting(ctx context.Context, categoryID int64) (int64, error) {
+	return r.store.DeleteReorderSetting(ctx, categoryID)
+}
+
+func (r *repository) GetProductSalesVelocity(ctx context.Context, since time.Time) ([]sqlc.GetProductSalesVelocityRow, error) {
+	return r.store.GetProductSalesVelocity(ctx, since)
+}
+
+func (r *repository) UpsertReorderSuggestions(ctx context.Context, arg sqlc.UpsertReorderSuggestionsParams) error {
+	return r.store.UpsertReorderSuggestions(ctx, arg)
+}
+
+func (r *repository) ListReorderSuggestions(ctx context.Context, arg sqlc.ListReorderSuggestionsParams) ([]sqlc.ListReorderSuggestionsRow, error) {
+	return r.store.ListReorderSuggestions(ctx, arg)
+}
+
+func (r *repository) CountReorderSuggestions(ctx context.Context, arg sqlc.CountReorderSuggestionsParams) (int64, error) {
+	return r.store.CountReorderSuggestions(ctx, arg)
+}
+
+func (r *repository) ApplyReorderPointsToCategory(ctx context.Context, categoryID int64) (int64, error) {
+	return r.store.ApplyReorderPointsToCategory(ctx, categoryID)
+}
+
 // Transaction support
 
 func (r *repository) ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error {
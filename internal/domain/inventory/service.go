@@ -5,10 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+
 	"gomall/db/sqlc"
 	dberrors "gomall/db"
+	"gomall/internal/config"
 	"gomall/utils"
 )
 
@@ -16,7 +20,7 @@ import (
 type Service interface {
 	// Inventory CRUD operations
 	CreateInventory(ctx context.Context, req CreateInventoryRequest) (*InventoryResponse, error)
-	GetInventoryByProductID(ctx context.Context, productID int64) (*InventoryResponse, error)
+	GetInventoryByProductID(ctx context.Context, productID int64, skuID *int64) (*InventoryResponse, error)
 	ListInventories(ctx context.Context, req ListInventoriesRequest) (*PaginatedInventoriesResponse, error)
 	ListLowStockInventories(ctx context.Context, page, pageSize int32) (*PaginatedInventoriesResponse, error)
 	UpdateLowStockThreshold(ctx context.Context, productID int64, req UpdateLowStockThresholdRequest) error
@@ -26,11 +30,13 @@ type Service interface {
 	ReleaseStock(ctx context.Context, req ReleaseStockRequest) error
 	DeductStock(ctx context.Context, req DeductStockRequest) error
 	RestockInventory(ctx context.Context, req RestockRequest, operatorID *int64) error
+	RestockInventoryTx(ctx context.Context, q sqlc.Querier, req RestockRequest, operatorID *int64) (StockChange, error)
+	StockRestocked(ctx context.Context, change StockChange)
 	AdjustStock(ctx context.Context, req AdjustStockRequest, operatorID *int64) error
 
 	// Stock check operations
-	CheckStockAvailability(ctx context.Context, productID int64, quantity int32) (*StockCheckResponse, error)
-	BatchCheckStockAvailability(ctx context.Context, items []StockCheckItem) (map[int64]*StockCheckResponse, error)
+	CheckStockAvailability(ctx context.Context, productID int64, skuID *int64, quantity int32) (*StockCheckResponse, error)
+	BatchCheckStockAvailability(ctx context.Context, items []StockCheckItem) ([]StockCheckResponse, error)
 
 	// Reservation operations
 	ConfirmReservation(ctx context.Context, orderID int64) error
@@ -39,23 +45,64 @@ type Service interface {
 
 	// Inventory log operations
 	GetInventoryLogs(ctx context.Context, req ListInventoryLogsRequest) (*PaginatedInventoryLogsResponse, error)
+
+	// Low stock alert operations
+	GetLowStockDigest(ctx context.Context, page, pageSize int32) (*LowStockDigestResponse, error)
+
+	// Bulk import/export operations
+	ImportInventory(ctx context.Context, filename string, file io.Reader, dryRun bool, operatorID *int64) (*ImportInventoryResponse, error)
+	GetImportJob(ctx context.Context, jobID int64) (*ImportJobResponse, error)
+	ExportInventory(ctx context.Context, w io.Writer) error
+
+	// Reporting operations
+	GetStockAsOf(ctx context.Context, productID int64, skuID *int64, asOf time.Time) (*StockAsOfResponse, error)
+	GetMovementReport(ctx context.Context, req MovementReportRequest) (*MovementReportResponse, error)
+	ExportMovementReport(ctx context.Context, req MovementReportRequest, w io.Writer) error
+
+	// Stocktake operations
+	CreateStocktake(ctx context.Context, req CreateStocktakeRequest, operatorID *int64) (*StocktakeDetailResponse, error)
+	GetStocktake(ctx context.Context, id int64) (*StocktakeDetailResponse, error)
+	ListStocktakes(ctx context.Context, status string, page, pageSize int32) (*PaginatedStocktakesResponse, error)
+	RecordStocktakeCounts(ctx context.Context, id int64, req RecordStocktakeCountsRequest, operatorID *int64) (*StocktakeDetailResponse, error)
+	ApproveStocktake(ctx context.Context, id int64, operatorID *int64) (*StocktakeDetailResponse, error)
+	CancelStocktake(ctx context.Context, id int64) error
+
+	// Backorder and pre-order operations
+	UpdateStockPolicy(ctx context.Context, productID int64, req UpdateStockPolicyRequest) (*InventoryResponse, error)
+	ReserveStockWithBackorder(ctx context.Context, req ReserveStockRequest, expiresInMinutes int) (*ReserveStockResult, error)
+	HoldOrderReservations(ctx context.Context, orderID int64) error
+	CancelOrderBackorders(ctx context.Context, orderID int64) ([]StockKey, error)
+	AllocateBackorders(ctx context.Context, productID int64, skuID *int64) (*AllocateBackordersResponse, error)
+	ListBackorders(ctx context.Context, productID int64, req ListBackordersRequest) (*PaginatedBackordersResponse, error)
+
+	// Reorder-point operations
+	RefreshReorderSuggestions(ctx context.Context) (*ReorderRefreshResponse, error)
+	ListReorderSuggestions(ctx context.Context, req ListReorderSuggestionsRequest) (*PaginatedReorderSuggestionsResponse, error)
+	ApplyReorderPoints(ctx context.Context, categoryID int64) (*ApplyReorderPointsResponse, error)
+	ListReorderSettings(ctx context.Context) ([]ReorderSettingResponse, error)
+	UpsertReorderSetting(ctx context.Context, categoryID int64, req UpsertReorderSettingRequest) (*ReorderSettingResponse, error)
+	DeleteReorderSetting(ctx context.Context, categoryID int64) error
 }
 
 type service struct {
-	repo Repository
+	repo     Repository
+	notifier AlertNotifier
+	reorder  config.ReorderConfig
 }
 
 // NewService creates a new Service instance
-func NewService(repo Repository) Service {
+func NewService(repo Repository, notifier AlertNotifier, reorder config.ReorderConfig) Service {
 	return &service{
-		repo: repo,
+		repo:     repo,
+		notifier: notifier,
+		reorder:  reorder,
 	}
 }
 
-// CreateInventory creates a new inventory record for a product
+// CreateInventory creates a new inventory record for a product or one of its SKUs
 func (s *service) CreateInventory(ctx context.Context, req CreateInventoryRequest) (*InventoryResponse, error) {
 	// Check if inventory already exists
-	_, err := s.repo.GetInventoryByProductID(ctx, req.ProductID)
+	_, err := getStockUnit(ctx, s.repo, req.ProductID, req.SkuID)
 	if err == nil {
 		return nil, errors.New("inventory already exists for this product")
 	}
@@ -66,6 +113,7 @@ func (s *service) CreateInventory(ctx context.Context, req CreateInventoryReques
 	// Create inventory
 	inventory, err := s.repo.CreateInventory(ctx, sqlc.CreateInventoryParams{
 		ProductID:         req.ProductID,
+		SkuID:             req.SkuID,
 		AvailableStock:    req.AvailableStock,
 		ReservedStock:     req.ReservedStock,
 		LowStockThreshold: utils.Ptr(req.LowStockThreshold),
@@ -78,9 +126,9 @@ func (s *service) CreateInventory(ctx context.Context, req CreateInventoryReques
 	return &response, nil
 }
 
-// GetInventoryByProductID retrieves inventory by product ID
-func (s *service) GetInventoryByProductID(ctx context.Context, productID int64) (*InventoryResponse, error) {
-	inventory, err := s.repo.GetInventoryByProductID(ctx, productID)
+// GetInventoryByProductID retrieves inventory by product ID, or of one SKU when skuID is set
+func (s *service) GetInventoryByProductID(ctx context.Context, productID int64, skuID *int64) (*InventoryResponse, error) {
+	inventory, err := getStockUnit(ctx, s.repo, productID, skuID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("inventory not found")
@@ -175,9 +223,10 @@ func (s *service) ListLowStockInventories(ctx context.Context, page, pageSize in
 
 // ReserveStock reserves stock for an order with optimistic locking (防止超卖)
 func (s *service) ReserveStock(ctx context.Context, req ReserveStockRequest, expiresInMinutes int) error {
-	return s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+	var change StockChange
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
 		// 1. Get current inventory
-		inventory, err := q.GetInventoryByProductID(ctx, req.ProductID)
+		inventory, err := getStockUnit(ctx, q, req.ProductID, req.SkuID)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				return errors.New("inventory not found")
@@ -190,55 +239,76 @@ func (s *service) ReserveStock(ctx context.Context, req ReserveStockRequest, exp
 			return dberrors.ErrInsufficientStock
 		}
 
-		// 3. Reserve stock with optimistic locking
-		err = q.ReserveStock(ctx, sqlc.ReserveStockParams{
-			AvailableStock: req.Quantity,
-			ProductID:      req.ProductID,
-			Version:        inventory.Version,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to reserve stock (possible concurrent update): %w", err)
+		// 3. Reserve stock, record the reservation and log it
+		if err := reserveStockTx(ctx, q, inventory, req, expiresInMinutes); err != nil {
+			return err
 		}
 
-		// 4. Create reservation record
-		expiresAt := time.Now().Add(time.Duration(expiresInMinutes) * time.Minute)
-		_, err = q.CreateInventoryReservation(ctx, sqlc.CreateInventoryReservationParams{
-			ProductID: req.ProductID,
-			OrderID:   req.OrderID,
-			Quantity:  req.Quantity,
-			Status:    utils.Ptr("active"),
-			ExpiresAt: expiresAt,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create reservation: %w", err)
-		}
+		change = newStockChange(inventory, inventory.AvailableStock-req.Quantity)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-		// 5. Log the operation
-		_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
-			ProductID:       req.ProductID,
-			OrderID:         &req.OrderID,
-			ChangeType:      "reserve",
-			QuantityChange:  req.Quantity,
-			BeforeAvailable: inventory.AvailableStock,
-			AfterAvailable:  inventory.AvailableStock - req.Quantity,
-			BeforeReserved:  inventory.ReservedStock,
-			AfterReserved:   inventory.ReservedStock + req.Quantity,
-			Reason:          utils.Ptr("Stock reserved for order"),
-			OperatorID:      nil,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create inventory log: %w", err)
-		}
+	s.notifyStockChange(change)
+	return nil
+}
 
-		return nil
+// reserveStockTx moves quantity from available to reserved, records an active reservation
+// and logs the movement; the caller has already checked available stock
+func reserveStockTx(ctx context.Context, q sqlc.Querier, inventory sqlc.Inventory, req ReserveStockRequest, expiresInMinutes int) error {
+	// Reserve stock with optimistic locking
+	err := q.ReserveStock(ctx, sqlc.ReserveStockParams{
+		AvailableStock: req.Quantity,
+		ID:             inventory.ID,
+		Version:        inventory.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reserve stock (possible concurrent update): %w", err)
+	}
+
+	// Create reservation record
+	expiresAt := time.Now().Add(time.Duration(expiresInMinutes) * time.Minute)
+	_, err = q.CreateInventoryReservation(ctx, sqlc.CreateInventoryReservationParams{
+		ProductID: req.ProductID,
+		SkuID:     req.SkuID,
+		OrderID:   req.OrderID,
+		Quantity:  req.Quantity,
+		Status:    utils.Ptr("active"),
+		ExpiresAt: expiresAt,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	// Log the operation
+	_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
+		ProductID:       req.ProductID,
+		SkuID:           req.SkuID,
+		OrderID:         &req.OrderID,
+		ChangeType:      "reserve",
+		QuantityChange:  req.Quantity,
+		BeforeAvailable: inventory.AvailableStock,
+		AfterAvailable:  inventory.AvailableStock - req.Quantity,
+		BeforeReserved:  inventory.ReservedStock,
+		AfterReserved:   inventory.ReservedStock + req.Quantity,
+		Reason:          utils.Ptr("Stock reserved for order"),
+		OperatorID:      nil,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create inventory log: %w", err)
+	}
+
+	return nil
 }
 
 // ReleaseStock releases reserved stock (e.g., when order is cancelled)
 func (s *service) ReleaseStock(ctx context.Context, req ReleaseStockRequest) error {
-	return s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+	var change StockChange
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
 		// 1. Get current inventory
-		inventory, err := q.GetInventoryByProductID(ctx, req.ProductID)
+		inventory, err := getStockUnit(ctx, q, req.ProductID, req.SkuID)
 		if err != nil {
 			return fmt.Errorf("failed to get inventory: %w", err)
 		}
@@ -246,7 +316,7 @@ func (s *service) ReleaseStock(ctx context.Context, req ReleaseStockRequest) err
 		// 2. Release reserved stock with optimistic locking
 		err = q.ReleaseReservedStock(ctx, sqlc.ReleaseReservedStockParams{
 			AvailableStock: req.Quantity,
-			ProductID:      req.ProductID,
+			ID:             inventory.ID,
 			Version:        inventory.Version,
 		})
 		if err != nil {
@@ -262,6 +332,7 @@ func (s *service) ReleaseStock(ctx context.Context, req ReleaseStockRequest) err
 		// 4. Log the operation
 		_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
 			ProductID:       req.ProductID,
+			SkuID:           req.SkuID,
 			OrderID:         &req.OrderID,
 			ChangeType:      "release",
 			QuantityChange:  -req.Quantity,
@@ -276,15 +347,23 @@ func (s *service) ReleaseStock(ctx context.Context, req ReleaseStockRequest) err
 			return fmt.Errorf("failed to create inventory log: %w", err)
 		}
 
+		change = newStockChange(inventory, inventory.AvailableStock+req.Quantity)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyStockChange(change)
+	s.tryAllocateBackorders(ctx, req.ProductID, req.SkuID)
+	return nil
 }
 
 // DeductStock deducts reserved stock (e.g., when order is confirmed/paid)
 func (s *service) DeductStock(ctx context.Context, req DeductStockRequest) error {
 	return s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
 		// 1. Get current inventory
-		inventory, err := q.GetInventoryByProductID(ctx, req.ProductID)
+		inventory, err := getStockUnit(ctx, q, req.ProductID, req.SkuID)
 		if err != nil {
 			return fmt.Errorf("failed to get inventory: %w", err)
 		}
@@ -292,7 +371,7 @@ func (s *service) DeductStock(ctx context.Context, req DeductStockRequest) error
 		// 2. Deduct reserved stock with optimistic locking
 		err = q.DeductReservedStock(ctx, sqlc.DeductReservedStockParams{
 			ReservedStock: req.Quantity,
-			ProductID:     req.ProductID,
+			ID:            inventory.ID,
 			Version:       inventory.Version,
 		})
 		if err != nil {
@@ -308,6 +387,7 @@ func (s *service) DeductStock(ctx context.Context, req DeductStockRequest) error
 		// 4. Log the operation
 		_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
 			ProductID:       req.ProductID,
+			SkuID:           req.SkuID,
 			OrderID:         &req.OrderID,
 			ChangeType:      "deduct",
 			QuantityChange:  -req.Quantity,
@@ -328,48 +408,80 @@ func (s *service) DeductStock(ctx context.Context, req DeductStockRequest) error
 
 // RestockInventory adds stock to inventory
 func (s *service) RestockInventory(ctx context.Context, req RestockRequest, operatorID *int64) error {
-	return s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
-		// 1. Get current inventory
-		inventory, err := q.GetInventoryByProductID(ctx, req.ProductID)
-		if err != nil {
-			return fmt.Errorf("failed to get inventory: %w", err)
-		}
+	var change StockChange
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		var err error
+		change, err = s.RestockInventoryTx(ctx, q, req, operatorID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
-		// 2. Add stock
-		err = q.AddAvailableStock(ctx, sqlc.AddAvailableStockParams{
-			AvailableStock: req.Quantity,
-			ProductID:      req.ProductID,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to add stock: %w", err)
-		}
+	s.StockRestocked(ctx, change)
+	return nil
+}
 
-		// 3. Log the operation
-		_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
-			ProductID:       req.ProductID,
-			OrderID:         nil,
-			ChangeType:      "restock",
-			QuantityChange:  req.Quantity,
-			BeforeAvailable: inventory.AvailableStock,
-			AfterAvailable:  inventory.AvailableStock + req.Quantity,
-			BeforeReserved:  inventory.ReservedStock,
-			AfterReserved:   inventory.ReservedStock,
-			Reason:          utils.Ptr(req.Reason),
-			OperatorID:      operatorID,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create inventory log: %w", err)
-		}
+// RestockInventoryTx adds stock inside the caller's transaction, so a restock can commit or roll
+// back together with the caller's own writes. Once the transaction commits, pass the returned
+// change to StockRestocked.
+func (s *service) RestockInventoryTx(ctx context.Context, q sqlc.Querier, req RestockRequest, operatorID *int64) (StockChange, error) {
+	var referenceType *string
+	if req.ReferenceType != "" {
+		referenceType = utils.Ptr(req.ReferenceType)
+	}
 
-		return nil
+	// 1. Get current inventory
+	inventory, err := getStockUnit(ctx, q, req.ProductID, req.SkuID)
+	if err != nil {
+		return StockChange{}, fmt.Errorf("failed to get inventory: %w", err)
+	}
+
+	// 2. Add stock
+	err = q.AddAvailableStock(ctx, sqlc.AddAvailableStockParams{
+		AvailableStock: req.Quantity,
+		ID:             inventory.ID,
 	})
+	if err != nil {
+		return StockChange{}, fmt.Errorf("failed to add stock: %w", err)
+	}
+
+	// 3. Log the operation
+	_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
+		ProductID:       req.ProductID,
+		SkuID:           req.SkuID,
+		OrderID:         nil,
+		ChangeType:      "restock",
+		QuantityChange:  req.Quantity,
+		BeforeAvailable: inventory.AvailableStock,
+		AfterAvailable:  inventory.AvailableStock + req.Quantity,
+		BeforeReserved:  inventory.ReservedStock,
+		AfterReserved:   inventory.ReservedStock,
+		Reason:          utils.Ptr(req.Reason),
+		OperatorID:      operatorID,
+		ReferenceType:   referenceType,
+		ReferenceID:     req.ReferenceID,
+	})
+	if err != nil {
+		return StockChange{}, fmt.Errorf("failed to create inventory log: %w", err)
+	}
+
+	return newStockChange(inventory, inventory.AvailableStock+req.Quantity), nil
+}
+
+// StockRestocked runs the follow-up of a committed restock: low-stock alerts are raised or
+// cleared and backorders waiting on the stock unit are allocated
+func (s *service) StockRestocked(ctx context.Context, change StockChange) {
+	s.notifyStockChange(change)
+	s.tryAllocateBackorders(ctx, change.ProductID, change.SkuID)
 }
 
 // AdjustStock adjusts inventory (can be positive or negative)
 func (s *service) AdjustStock(ctx context.Context, req AdjustStockRequest, operatorID *int64) error {
-	return s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+	var change StockChange
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
 		// 1. Get current inventory
-		inventory, err := q.GetInventoryByProductID(ctx, req.ProductID)
+		inventory, err := getStockUnit(ctx, q, req.ProductID, req.SkuID)
 		if err != nil {
 			return fmt.Errorf("failed to get inventory: %w", err)
 		}
@@ -384,7 +496,7 @@ func (s *service) AdjustStock(ctx context.Context, req AdjustStockRequest, opera
 		err = q.UpdateInventoryStock(ctx, sqlc.UpdateInventoryStockParams{
 			AvailableStock: newAvailableStock,
 			ReservedStock:  inventory.ReservedStock,
-			ProductID:      req.ProductID,
+			ID:             inventory.ID,
 			Version:        inventory.Version,
 		})
 		if err != nil {
@@ -394,6 +506,7 @@ func (s *service) AdjustStock(ctx context.Context, req AdjustStockRequest, opera
 		// 4. Log the operation
 		_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
 			ProductID:       req.ProductID,
+			SkuID:           req.SkuID,
 			OrderID:         nil,
 			ChangeType:      "adjust",
 			QuantityChange:  req.Quantity,
@@ -408,13 +521,23 @@ func (s *service) AdjustStock(ctx context.Context, req AdjustStockRequest, opera
 			return fmt.Errorf("failed to create inventory log: %w", err)
 		}
 
+		change = newStockChange(inventory, newAvailableStock)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyStockChange(change)
+	if req.Quantity > 0 {
+		s.tryAllocateBackorders(ctx, req.ProductID, req.SkuID)
+	}
+	return nil
 }
 
-// CheckStockAvailability checks if stock is available
-func (s *service) CheckStockAvailability(ctx context.Context, productID int64, quantity int32) (*StockCheckResponse, error) {
-	inventory, err := s.repo.GetInventoryByProductID(ctx, productID)
+// CheckStockAvailability checks if stock is available for a product or one of its SKUs
+func (s *service) CheckStockAvailability(ctx context.Context, productID int64, skuID *int64, quantity int32) (*StockCheckResponse, error) {
+	inventory, err := getStockUnit(ctx, s.repo, productID, skuID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("inventory not found")
@@ -423,24 +546,26 @@ func (s *service) CheckStockAvailability(ctx context.Context, productID int64, q
 	}
 
 	return &StockCheckResponse{
-		ProductID:      productID,
-		AvailableStock: inventory.AvailableStock,
-		ReservedStock:  inventory.ReservedStock,
-		IsAvailable:    inventory.AvailableStock >= quantity,
-		RequestedQty:   quantity,
+		ProductID:        productID,
+		SkuID:            skuID,
+		AvailableStock:   inventory.AvailableStock,
+		ReservedStock:    inventory.ReservedStock,
+		IsAvailable:      inventory.AvailableStock >= quantity,
+		RequestedQty:     quantity,
+		BackorderAllowed: shouldBackorder(inventory, quantity, time.Now()) && withinBackorderLimit(inventory, quantity),
 	}, nil
 }
 
-// BatchCheckStockAvailability checks stock availability for multiple items
-func (s *service) BatchCheckStockAvailability(ctx context.Context, items []StockCheckItem) (map[int64]*StockCheckResponse, error) {
-	result := make(map[int64]*StockCheckResponse)
+// BatchCheckStockAvailability checks stock availability for multiple items, in request order
+func (s *service) BatchCheckStockAvailability(ctx context.Context, items []StockCheckItem) ([]StockCheckResponse, error) {
+	result := make([]StockCheckResponse, 0, len(items))
 
 	for _, item := range items {
-		check, err := s.CheckStockAvailability(ctx, item.ProductID, item.Quantity)
+		check, err := s.CheckStockAvailability(ctx, item.ProductID, item.SkuID, item.Quantity)
 		if err != nil {
 			return nil, err
 		}
-		result[item.ProductID] = check
+		result = append(result, *check)
 	}
 
 	return result, nil
@@ -468,6 +593,7 @@ func (s *service) CleanupExpiredReservations(ctx context.Context) error {
 		// Release stock for each expired reservation
 		err = s.ReleaseStock(ctx, ReleaseStockRequest{
 			ProductID: reservation.ProductID,
+			SkuID:     reservation.SkuID,
 			Quantity:  reservation.Quantity,
 			OrderID:   reservation.OrderID,
 		})
@@ -521,10 +647,80 @@ func (s *service) GetInventoryLogs(ctx context.Context, req ListInventoryLogsReq
 	}, nil
 }
 
-// UpdateLowStockThreshold updates the low stock threshold
+// UpdateLowStockThreshold updates the low stock threshold of a product or one of its SKUs
 func (s *service) UpdateLowStockThreshold(ctx context.Context, productID int64, req UpdateLowStockThresholdRequest) error {
+	inventory, err := getStockUnit(ctx, s.repo, productID, req.SkuID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("inventory not found")
+		}
+		return fmt.Errorf("failed to get inventory: %w", err)
+	}
+
 	return s.repo.UpdateLowStockThreshold(ctx, sqlc.UpdateLowStockThresholdParams{
 		LowStockThreshold: utils.Ptr(req.Threshold),
-		ProductID:         productID,
+		ID:                inventory.ID,
 	})
 }
+
+// GetLowStockDigest summarises active low-stock alerts for operations staff
+func (s *service) GetLowStockDigest(ctx context.Context, page, pageSize int32) (*LowStockDigestResponse, error) {
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = 20
+	}
+
+	offset := (page - 1) * pageSize
+
+	alerts, err := s.repo.ListActiveStockAlerts(ctx, sqlc.ListActiveStockAlertsParams{
+		Limit:  pageSize,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock alerts: %w", err)
+	}
+
+	activeCount, err := s.repo.CountActiveStockAlerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count stock alerts: %w", err)
+	}
+
+	outOfStockCount, err := s.repo.CountOutOfStockAlerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count out of stock alerts: %w", err)
+	}
+
+	now := time.Now()
+	resolvedCount, err := s.repo.CountResolvedStockAlertsSince(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count resolved stock alerts: %w", err)
+	}
+
+	responses := make([]StockAlertResponse, len(alerts))
+	for i, alert := range alerts {
+		responses[i] = toStockAlertResponse(alert)
+	}
+
+	totalPages := int32((activeCount + int64(pageSize) - 1) / int64(pageSize))
+
+	return &LowStockDigestResponse{
+		GeneratedAt:     now,
+		ActiveCount:     activeCount,
+		OutOfStockCount: outOfStockCount,
+		ResolvedLast24h: resolvedCount,
+		Alerts:          responses,
+		Page:            page,
+		PageSize:        pageSize,
+		TotalPages:      totalPages,
+	}, nil
+}
+
+// notifyStockChange hands a committed stock change to the low stock notifier
+func (s *service) notifyStockChange(change StockChange) {
+	if s.notifier == nil {
+		return
+	}
+	s.notifier.StockChanged(change)
+}
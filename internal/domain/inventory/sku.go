@@ -0,0 +1,56 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+)
+
+// StockKey identifies one stock unit: a product without variants (SkuID 0) or a single SKU
+type StockKey struct {
+	ProductID int64
+	SkuID     int64
+}
+
+// NewStockKey builds the key for a product and optional SKU
+func NewStockKey(productID int64, skuID *int64) StockKey {
+	key := StockKey{ProductID: productID}
+	if skuID != nil {
+		key.SkuID = *skuID
+	}
+	return key
+}
+
+// String names the unit in log messages
+func (k StockKey) String() string {
+	if k.SkuID == 0 {
+		return fmt.Sprintf("product %d", k.ProductID)
+	}
+	return fmt.Sprintf("product %d sku %d", k.ProductID, k.SkuID)
+}
+
+// stockUnitGetter is satisfied by both Repository and sqlc.Querier so lookups work in and out of transactions
+type stockUnitGetter interface {
+	GetInventoryByProductID(ctx context.Context, productID int64) (sqlc.Inventory, error)
+	GetInventoryBySkuID(ctx context.Context, skuID *int64) (sqlc.Inventory, error)
+}
+
+// getStockUnit loads the inventory row of a product, or of one of its SKUs when skuID is set.
+// A SKU belonging to another product is reported as pgx.ErrNoRows.
+func getStockUnit(ctx context.Context, q stockUnitGetter, productID int64, skuID *int64) (sqlc.Inventory, error) {
+	if skuID == nil {
+		return q.GetInventoryByProductID(ctx, productID)
+	}
+
+	inventory, err := q.GetInventoryBySkuID(ctx, skuID)
+	if err != nil {
+		return sqlc.Inventory{}, err
+	}
+	if inventory.ProductID != productID {
+		return sqlc.Inventory{}, pgx.ErrNoRows
+	}
+	return inventory, nil
+}
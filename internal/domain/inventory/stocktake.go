@@ -0,0 +1,316 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+)
+
+// StocktakeReferenceType tags inventory logs posted when a stocktake is approved
+const StocktakeReferenceType = "stocktake"
+
+// CreateStocktake opens a count session for a list of products or for every product in a category.
+// Products with variants get one item per SKU.
+func (s *service) CreateStocktake(ctx context.Context, req CreateStocktakeRequest, operatorID *int64) (*StocktakeDetailResponse, error) {
+	if (len(req.ProductIDs) == 0) == (req.CategoryID == nil) {
+		return nil, errors.New("either product_ids or category_id is required")
+	}
+
+	var note *string
+	if req.Note != "" {
+		note = utils.Ptr(req.Note)
+	}
+
+	var stocktakeID int64
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		stocktake, err := q.CreateStocktake(ctx, sqlc.CreateStocktakeParams{
+			CategoryID: req.CategoryID,
+			Note:       note,
+			CreatedBy:  operatorID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create stocktake: %w", err)
+		}
+
+		// Snapshot the system quantity of every product in scope
+		var added int64
+		if req.CategoryID != nil {
+			added, err = q.AddStocktakeItemsForCategory(ctx, sqlc.AddStocktakeItemsForCategoryParams{
+				StocktakeID: stocktake.ID,
+				CategoryID:  *req.CategoryID,
+			})
+		} else {
+			added, err = q.AddStocktakeItemsForProducts(ctx, sqlc.AddStocktakeItemsForProductsParams{
+				StocktakeID: stocktake.ID,
+				ProductIds:  req.ProductIDs,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add stocktake items: %w", err)
+		}
+		if added == 0 {
+			return errors.New("no inventory found for stocktake")
+		}
+
+		stocktakeID = stocktake.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetStocktake(ctx, stocktakeID)
+}
+
+// GetStocktake returns a session with its items and variances
+func (s *service) GetStocktake(ctx context.Context, id int64) (*StocktakeDetailResponse, error) {
+	stocktake, err := s.repo.GetStocktake(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("stocktake not found")
+		}
+		return nil, fmt.Errorf("failed to get stocktake: %w", err)
+	}
+
+	items, err := s.repo.ListStocktakeItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stocktake items: %w", err)
+	}
+
+	resp := &StocktakeDetailResponse{
+		Stocktake:  toStocktakeResponse(stocktake),
+		Items:      make([]StocktakeItemResponse, 0, len(items)),
+		TotalItems: len(items),
+	}
+	for _, item := range items {
+		itemResp := toStocktakeItemResponse(item)
+		if itemResp.CountedQuantity != nil {
+			resp.CountedItems++
+		}
+		if itemResp.Variance != nil && *itemResp.Variance != 0 {
+			resp.VarianceItems++
+			resp.NetVariance += *itemResp.Variance
+		}
+		resp.Items = append(resp.Items, itemResp)
+	}
+
+	return resp, nil
+}
+
+// ListStocktakes lists count sessions, optionally filtered by status
+func (s *service) ListStocktakes(ctx context.Context, status string, page, pageSize int32) (*PaginatedStocktakesResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+
+	var statusFilter *string
+	if status != "" {
+		statusFilter = utils.Ptr(status)
+	}
+
+	stocktakes, err := s.repo.ListStocktakes(ctx, sqlc.ListStocktakesParams{
+		Status:      statusFilter,
+		LimitCount:  pageSize,
+		OffsetCount: (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stocktakes: %w", err)
+	}
+
+	total, err := s.repo.CountStocktakes(ctx, statusFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count stocktakes: %w", err)
+	}
+
+	responses := make([]StocktakeResponse, len(stocktakes))
+	for i, st := range stocktakes {
+		responses[i] = toStocktakeResponse(st)
+	}
+
+	totalPages := int32((total + int64(pageSize) - 1) / int64(pageSize))
+
+	return &PaginatedStocktakesResponse{
+		Stocktakes: responses,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// RecordStocktakeCounts stores counted quantities; re-submitting a product or SKU overwrites its count
+func (s *service) RecordStocktakeCounts(ctx context.Context, id int64, req RecordStocktakeCountsRequest, operatorID *int64) (*StocktakeDetailResponse, error) {
+	if err := s.ensureStocktakeOpen(ctx, id); err != nil {
+		return nil, err
+	}
+
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		for _, count := range req.Counts {
+			rows, err := q.RecordStocktakeCount(ctx, sqlc.RecordStocktakeCountParams{
+				CountedQuantity: count.CountedQuantity,
+				CountedBy:       operatorID,
+				StocktakeID:     id,
+				ProductID:       count.ProductID,
+				SkuID:           count.SkuID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record stocktake count: %w", err)
+			}
+			if rows == 0 {
+				return errors.New("product is not part of this stocktake")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetStocktake(ctx, id)
+}
+
+// ApproveStocktake posts every non-zero variance as an adjust log linked to the session.
+// Variances are measured against current available + reserved stock and applied to
+// available stock, since reserved stock is held by orders.
+func (s *service) ApproveStocktake(ctx context.Context, id int64, operatorID *int64) (*StocktakeDetailResponse, error) {
+	if err := s.ensureStocktakeOpen(ctx, id); err != nil {
+		return nil, err
+	}
+
+	reason := fmt.Sprintf("Stocktake #%d", id)
+	var changes []StockChange
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		changes = nil
+
+		// Claim the session first so concurrent approvals cannot post twice
+		rows, err := q.ApproveStocktake(ctx, sqlc.ApproveStocktakeParams{
+			ApprovedBy: operatorID,
+			ID:         id,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to approve stocktake: %w", err)
+		}
+		if rows == 0 {
+			return errors.New("stocktake is not open")
+		}
+
+		uncounted, err := q.CountUncountedStocktakeItems(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to count uncounted items: %w", err)
+		}
+		if uncounted > 0 {
+			return errors.New("stocktake has uncounted items")
+		}
+
+		items, err := q.ListStocktakeItems(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to list stocktake items: %w", err)
+		}
+
+		for _, item := range items {
+			inventory, err := getStockUnit(ctx, q, item.ProductID, item.SkuID)
+			if err != nil {
+				return fmt.Errorf("failed to get inventory: %w", err)
+			}
+
+			variance := *item.CountedQuantity - (inventory.AvailableStock + inventory.ReservedStock)
+			if err := q.SetStocktakeItemVariance(ctx, sqlc.SetStocktakeItemVarianceParams{
+				Variance: utils.Ptr(variance),
+				ID:       item.ID,
+			}); err != nil {
+				return fmt.Errorf("failed to record stocktake variance: %w", err)
+			}
+			if variance == 0 {
+				continue
+			}
+
+			newAvailableStock := inventory.AvailableStock + variance
+			if newAvailableStock < 0 {
+				return errors.New("counted quantity is below reserved stock")
+			}
+
+			err = q.UpdateInventoryStock(ctx, sqlc.UpdateInventoryStockParams{
+				AvailableStock: newAvailableStock,
+				ReservedStock:  inventory.ReservedStock,
+				ID:             inventory.ID,
+				Version:        inventory.Version,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to adjust stock: %w", err)
+			}
+
+			_, err = q.CreateInventoryLog(ctx, sqlc.CreateInventoryLogParams{
+				ProductID:       item.ProductID,
+				SkuID:           item.SkuID,
+				OrderID:         nil,
+				ChangeType:      "adjust",
+				QuantityChange:  variance,
+				BeforeAvailable: inventory.AvailableStock,
+				AfterAvailable:  newAvailableStock,
+				BeforeReserved:  inventory.ReservedStock,
+				AfterReserved:   inventory.ReservedStock,
+				Reason:          utils.Ptr(reason),
+				OperatorID:      operatorID,
+				ReferenceType:   utils.Ptr(StocktakeReferenceType),
+				ReferenceID:     utils.Ptr(id),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create inventory log: %w", err)
+			}
+
+			changes = append(changes, newStockChange(inventory, newAvailableStock))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Counted stock above the system figure can fill waiting backorders
+	for _, change := range changes {
+		s.notifyStockChange(change)
+		if change.AfterAvailable > change.BeforeAvailable {
+			s.tryAllocateBackorders(ctx, change.ProductID, change.SkuID)
+		}
+	}
+
+	return s.GetStocktake(ctx, id)
+}
+
+// CancelStocktake discards an open session without touching stock
+func (s *service) CancelStocktake(ctx context.Context, id int64) error {
+	if err := s.ensureStocktakeOpen(ctx, id); err != nil {
+		return err
+	}
+
+	rows, err := s.repo.CancelStocktake(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to cancel stocktake: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("stocktake is not open")
+	}
+	return nil
+}
+
+func (s *service) ensureStocktakeOpen(ctx context.Context, id int64) error {
+	stocktake, err := s.repo.GetStocktake(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("stocktake not found")
+		}
+		return fmt.Errorf("failed to get stocktake: %w", err)
+	}
+	if stocktake.Status != "open" {
+		return errors.New("stocktake is not open")
+	}
+	return nil
+}
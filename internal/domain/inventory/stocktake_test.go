@@ -0,0 +1,194 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+	"gomall/internal/config"
+	"gomall/utils"
+)
+
+// recordingNotifier collects the stock changes a service reports after commit
+type recordingNotifier struct {
+	changes []StockChange
+}
+
+func (n *recordingNotifier) StockChanged(change StockChange) {
+	n.changes = append(n.changes, change)
+}
+
+// newTestService returns a service whose transactions run on tx
+func newTestService(t *testing.T) (*service, *mockdb.MockStore, *mockdb.MockStore, *recordingNotifier) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	tx := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ExecTx(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(ctx context.Context, fn func(sqlc.Querier) error) error {
+		return fn(tx)
+	})
+
+	notifier := &recordingNotifier{}
+	s := NewService(store, notifier, config.ReorderConfig{}).(*service)
+	return s, store, tx, notifier
+}
+
+func TestApproveStocktakePostsVariances(t *testing.T) {
+	s, store, tx, notifier := newTestService(t)
+	operatorID := utils.Ptr(int64(3))
+
+	items := []sqlc.ListStocktakeItemsRow{
+		{ID: 10, StocktakeID: 1, ProductID: 5, ExpectedQuantity: 12, CountedQuantity: utils.Ptr(int32(9))},
+		{ID: 11, StocktakeID: 1, ProductID: 6, ExpectedQuantity: 4, CountedQuantity: utils.Ptr(int32(4))},
+	}
+
+	store.EXPECT().GetStocktake(gomock.Any(), int64(1)).Return(sqlc.Stocktake{ID: 1, Status: "open"}, nil)
+	gomock.InOrder(
+		tx.EXPECT().ApproveStocktake(gomock.Any(), sqlc.ApproveStocktakeParams{ApprovedBy: operatorID, ID: 1}).Return(int64(1), nil),
+		tx.EXPECT().CountUncountedStocktakeItems(gomock.Any(), int64(1)).Return(int64(0), nil),
+		tx.EXPECT().ListStocktakeItems(gomock.Any(), int64(1)).Return(items, nil),
+
+		// Stock moved since the snapshot: 8 available + 2 reserved against 9 counted is -1
+		tx.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(sqlc.Inventory{ID: 20, ProductID: 5, AvailableStock: 8, ReservedStock: 2, Version: 7}, nil),
+		tx.EXPECT().SetStocktakeItemVariance(gomock.Any(), sqlc.SetStocktakeItemVarianceParams{Variance: utils.Ptr(int32(-1)), ID: 10}).Return(nil),
+		tx.EXPECT().UpdateInventoryStock(gomock.Any(), sqlc.UpdateInventoryStockParams{AvailableStock: 7, ReservedStock: 2, ID: 20, Version: 7}).Return(nil),
+		tx.EXPECT().CreateInventoryLog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg sqlc.CreateInventoryLogParams) (sqlc.InventoryLog, error) {
+			require.Equal(t, "adjust", arg.ChangeType)
+			require.Equal(t, int32(-1), arg.QuantityChange)
+			require.Equal(t, operatorID, arg.OperatorID)
+			require.Equal(t, utils.Ptr(StocktakeReferenceType), arg.ReferenceType)
+			require.Equal(t, utils.Ptr(int64(1)), arg.ReferenceID)
+			return sqlc.InventoryLog{}, nil
+		}),
+
+		// A matching count records a zero variance and leaves stock alone
+		tx.EXPECT().GetInventoryByProductID(gomock.Any(), int64(6)).Return(sqlc.Inventory{ID: 21, ProductID: 6, AvailableStock: 4}, nil),
+		tx.EXPECT().SetStocktakeItemVariance(gomock.Any(), sqlc.SetStocktakeItemVarianceParams{Variance: utils.Ptr(int32(0)), ID: 11}).Return(nil),
+	)
+
+	items[0].Variance = utils.Ptr(int32(-1))
+	items[1].Variance = utils.Ptr(int32(0))
+	store.EXPECT().GetStocktake(gomock.Any(), int64(1)).Return(sqlc.Stocktake{ID: 1, Status: "approved"}, nil)
+	store.EXPECT().ListStocktakeItems(gomock.Any(), int64(1)).Return(items, nil)
+
+	resp, err := s.ApproveStocktake(context.Background(), 1, operatorID)
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.VarianceItems)
+	require.Equal(t, int32(-1), resp.NetVariance)
+	require.Equal(t, []StockChange{{ProductID: 5, BeforeAvailable: 8, AfterAvailable: 7}}, notifier.changes)
+}
+
+func TestApproveStocktakePostsSkuVariancesToSkuInventory(t *testing.T) {
+	s, store, tx, notifier := newTestService(t)
+	skuID := utils.Ptr(int64(8))
+
+	items := []sqlc.ListStocktakeItemsRow{
+		{ID: 10, StocktakeID: 1, ProductID: 5, SkuID: skuID, ExpectedQuantity: 3, CountedQuantity: utils.Ptr(int32(2))},
+	}
+
+	store.EXPECT().GetStocktake(gomock.Any(), int64(1)).Return(sqlc.Stocktake{ID: 1, Status: "open"}, nil)
+	gomock.InOrder(
+		tx.EXPECT().ApproveStocktake(gomock.Any(), gomock.Any()).Return(int64(1), nil),
+		tx.EXPECT().CountUncountedStocktakeItems(gomock.Any(), int64(1)).Return(int64(0), nil),
+		tx.EXPECT().ListStocktakeItems(gomock.Any(), int64(1)).Return(items, nil),
+		tx.EXPECT().GetInventoryBySkuID(gomock.Any(), skuID).Return(sqlc.Inventory{ID: 30, ProductID: 5, SkuID: skuID, AvailableStock: 3, Version: 1}, nil),
+		tx.EXPECT().SetStocktakeItemVariance(gomock.Any(), sqlc.SetStocktakeItemVarianceParams{Variance: utils.Ptr(int32(-1)), ID: 10}).Return(nil),
+		tx.EXPECT().UpdateInventoryStock(gomock.Any(), sqlc.UpdateInventoryStockParams{AvailableStock: 2, ID: 30, Version: 1}).Return(nil),
+		tx.EXPECT().CreateInventoryLog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg sqlc.CreateInventoryLogParams) (sqlc.InventoryLog, error) {
+			require.Equal(t, skuID, arg.SkuID)
+			return sqlc.InventoryLog{}, nil
+		}),
+	)
+	store.EXPECT().GetStocktake(gomock.Any(), int64(1)).Return(sqlc.Stocktake{ID: 1, Status: "approved"}, nil)
+	store.EXPECT().ListStocktakeItems(gomock.Any(), int64(1)).Return(items, nil)
+
+	_, err := s.ApproveStocktake(context.Background(), 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, []StockChange{{ProductID: 5, SkuID: skuID, BeforeAvailable: 3, AfterAvailable: 2}}, notifier.changes)
+}
+
+func TestApproveStocktakeRejectsUncountedItems(t *testing.T) {
+	s, store, tx, notifier := newTestService(t)
+
+	store.EXPECT().GetStocktake(gomock.Any(), int64(1)).Return(sqlc.Stocktake{ID: 1, Status: "open"}, nil)
+	tx.EXPECT().ApproveStocktake(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	tx.EXPECT().CountUncountedStocktakeItems(gomock.Any(), int64(1)).Return(int64(2), nil)
+
+	_, err := s.ApproveStocktake(context.Background(), 1, nil)
+	require.EqualError(t, err, "stocktake has uncounted items")
+	require.Empty(t, notifier.changes)
+}
+
+func TestApproveStocktakeRejectsCountBelowReserved(t *testing.T) {
+	s, store, tx, notifier := newTestService(t)
+
+	store.EXPECT().GetStocktake(gomock.Any(), int64(1)).Return(sqlc.Stocktake{ID: 1, Status: "open"}, nil)
+	tx.EXPECT().ApproveStocktake(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	tx.EXPECT().CountUncountedStocktakeItems(gomock.Any(), int64(1)).Return(int64(0), nil)
+	tx.EXPECT().ListStocktakeItems(gomock.Any(), int64(1)).Return([]sqlc.ListStocktakeItemsRow{
+		{ID: 10, ProductID: 5, CountedQuantity: utils.Ptr(int32(1))},
+	}, nil)
+	tx.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(sqlc.Inventory{ID: 20, ProductID: 5, AvailableStock: 0, ReservedStock: 3}, nil)
+	tx.EXPECT().SetStocktakeItemVariance(gomock.Any(), gomock.Any()).Return(nil)
+
+	_, err := s.ApproveStocktake(context.Background(), 1, nil)
+	require.EqualError(t, err, "counted quantity is below reserved stock")
+	require.Empty(t, notifier.changes)
+}
+
+func TestRecordStocktakeCounts(t *testing.T) {
+	s, store, tx, _ := newTestService(t)
+
+	// Counts are only accepted while the session is open
+	store.EXPECT().GetStocktake(gomock.Any(), int64(2)).Return(sqlc.Stocktake{ID: 2, Status: "approved"}, nil)
+	_, err := s.RecordStocktakeCounts(context.Background(), 2, RecordStocktakeCountsRequest{}, nil)
+	require.EqualError(t, err, "stocktake is not open")
+
+	store.EXPECT().GetStocktake(gomock.Any(), int64(1)).Return(sqlc.Stocktake{ID: 1, Status: "open"}, nil)
+	tx.EXPECT().RecordStocktakeCount(gomock.Any(), sqlc.RecordStocktakeCountParams{CountedQuantity: utils.Ptr(int32(4)), StocktakeID: 1, ProductID: 5}).Return(int64(1), nil)
+	tx.EXPECT().RecordStocktakeCount(gomock.Any(), sqlc.RecordStocktakeCountParams{CountedQuantity: utils.Ptr(int32(2)), StocktakeID: 1, ProductID: 99}).Return(int64(0), nil)
+
+	_, err = s.RecordStocktakeCounts(context.Background(), 1, RecordStocktakeCountsRequest{
+		Counts: []StocktakeCount{{ProductID: 5, CountedQuantity: utils.Ptr(int32(4))}, {ProductID: 99, CountedQuantity: utils.Ptr(int32(2))}},
+	}, nil)
+	require.EqualError(t, err, "product is not part of this stocktake")
+}
+
+func TestApproveStocktakeAllocatesBackordersFromFoundStock(t *testing.T) {
+	s, store, tx, notifier := newTestService(t)
+
+	items := []sqlc.ListStocktakeItemsRow{{ID: 10, StocktakeID: 1, ProductID: 5, CountedQuantity: utils.Ptr(int32(3))}}
+	store.EXPECT().GetStocktake(gomock.Any(), int64(1)).Return(sqlc.Stocktake{ID: 1, Status: "open"}, nil)
+	gomock.InOrder(
+		tx.EXPECT().ApproveStocktake(gomock.Any(), gomock.Any()).Return(int64(1), nil),
+		tx.EXPECT().CountUncountedStocktakeItems(gomock.Any(), int64(1)).Return(int64(0), nil),
+		tx.EXPECT().ListStocktakeItems(gomock.Any(), int64(1)).Return(items, nil),
+		tx.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(sqlc.Inventory{ID: 20, ProductID: 5, BackorderedStock: 2, Version: 1}, nil),
+		tx.EXPECT().SetStocktakeItemVariance(gomock.Any(), gomock.Any()).Return(nil),
+		tx.EXPECT().UpdateInventoryStock(gomock.Any(), sqlc.UpdateInventoryStockParams{AvailableStock: 3, ID: 20, Version: 1}).Return(nil),
+		tx.EXPECT().CreateInventoryLog(gomock.Any(), gomock.Any()).Return(sqlc.InventoryLog{}, nil),
+
+		// The three units found fill the waiting backorder of two
+		tx.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(sqlc.Inventory{ID: 20, ProductID: 5, AvailableStock: 3, BackorderedStock: 2, Version: 2}, nil),
+		tx.EXPECT().ListPendingBackordersForUpdate(gomock.Any(), sqlc.ListPendingBackordersForUpdateParams{ProductID: 5}).
+			Return([]sqlc.InventoryBackorder{{ID: 40, ProductID: 5, OrderID: 90, Quantity: 2}}, nil),
+		tx.EXPECT().AllocateBackorderedStock(gomock.Any(), sqlc.AllocateBackorderedStockParams{Quantity: 2, ID: 20, Version: 2}).Return(int64(1), nil),
+		tx.EXPECT().MarkBackorderAllocated(gomock.Any(), int64(40)).Return(nil),
+		tx.EXPECT().CreateInventoryReservation(gomock.Any(), gomock.Any()).Return(sqlc.InventoryReservation{}, nil),
+		tx.EXPECT().CreateInventoryLog(gomock.Any(), gomock.Any()).Return(sqlc.InventoryLog{}, nil),
+		tx.EXPECT().PromoteBackorderedOrder(gomock.Any(), int64(90)).Return(int64(1), nil),
+		tx.EXPECT().ActivateOrderReservations(gomock.Any(), gomock.Any()).Return(nil),
+	)
+	store.EXPECT().GetStocktake(gomock.Any(), int64(1)).Return(sqlc.Stocktake{ID: 1, Status: "approved"}, nil)
+	store.EXPECT().ListStocktakeItems(gomock.Any(), int64(1)).Return(items, nil)
+
+	_, err := s.ApproveStocktake(context.Background(), 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, []StockChange{
+		{ProductID: 5, BeforeAvailable: 0, AfterAvailable: 3},
+		{ProductID: 5, BeforeAvailable: 3, AfterAvailable: 1},
+	}, notifier.changes)
+}
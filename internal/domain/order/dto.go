@@ -9,8 +9,9 @@ import (
 // Request DTOs
 
 type OrderItemRequest struct {
-	ProductID int64 `json:"product_id" binding:"required"`
-	Quantity  int32 `json:"quantity" binding:"required,min=1"`
+	ProductID int64  `json:"product_id" binding:"required"`
+	SkuID     *int64 `json:"sku_id,omitempty"` // required for products with variants
+	Quantity  int32  `json:"quantity" binding:"required,min=1"`
 }
 
 type CreateOrderRequest struct {
@@ -38,6 +39,7 @@ type ListOrdersRequest struct {
 type OrderItemResponse struct {
 	ID           int64  `json:"id"`
 	ProductID    int64  `json:"product_id"`
+	SkuID        *int64 `json:"sku_id,omitempty"`
 	ProductName  string `json:"product_name"`
 	ProductImage string `json:"product_image,omitempty"`
 	Quantity     int32  `json:"quantity"`
@@ -86,6 +88,7 @@ func toOrderResponse(order sqlc.Order, items []sqlc.OrderItem) OrderResponse {
 		itemResponses[i] = OrderItemResponse{
 			ID:           item.ID,
 			ProductID:    item.ProductID,
+			SkuID:        item.SkuID,
 			ProductName:  item.ProductName,
 			ProductImage: utils.PtrValue(item.ProductImage),
 			Quantity:     item.Quantity,
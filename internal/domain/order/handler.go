@@ -244,6 +244,10 @@ func (h *Handler) UpdateOrderStatus(c *gin.Context) {
 			response.Error(c, http.StatusForbidden, err.Error())
 			return
 		}
+		if err.Error() == "backordered order status cannot be changed directly" {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
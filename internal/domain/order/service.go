@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -36,6 +37,10 @@ type Service interface {
 
 }
 
+// StatusBackordered marks an order accepted without stock; it becomes pending once
+// every backordered line has been allocated
+const StatusBackordered = "backordered"
+
 type service struct {
 	repo Repository
 	inventoryService inventory.Service
@@ -101,11 +106,26 @@ func (s *service) createOrderWithRetry(ctx context.Context, userID int64, req Cr
 		}
 	}
 
+	// Products with variants are priced and stocked per SKU
+	skusByProduct, err := s.productService.GetActiveSkusByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product skus: %w", err)
+	}
+
+	skus := make([]*product.SkuResponse, len(req.Items))
+	for i, item := range req.Items {
+		skus[i], err = resolveOrderItemSku(item, skusByProduct[item.ProductID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	//validate all products exist
 	stockChecks := make([]inventory.StockCheckItem, len(req.Items))
 	for i, item := range req.Items {
 		stockChecks[i] = inventory.StockCheckItem{
 			ProductID: item.ProductID,
+			SkuID:     item.SkuID,
 			Quantity:  item.Quantity,
 		}
 	}
@@ -116,11 +136,11 @@ func (s *service) createOrderWithRetry(ctx context.Context, userID int64, req Cr
 	}
 	
 
-	//validate all products are sufficient
-	for productID, check := range checkResults {
-		if !check.IsAvailable {
+	//validate all products are sufficient (or may be backordered)
+	for _, check := range checkResults {
+		if !check.IsAvailable && !check.BackorderAllowed {
 			return nil, fmt.Errorf("product %d insufficient stock, available: %d, requested: %d", 
-				productID, check.AvailableStock, check.RequestedQty)
+				check.ProductID, check.AvailableStock, check.RequestedQty)
 		}
 	}
 
@@ -132,9 +152,8 @@ func (s *service) createOrderWithRetry(ctx context.Context, userID int64, req Cr
 		// 2. Calculate total amount
 		
 		var totalAmount int64 = 0
-		for _, item := range req.Items {
-			product:=products[item.ProductID]
-			totalAmount+=int64(item.Quantity)*product.Price
+		for i, item := range req.Items {
+			totalAmount+=int64(item.Quantity)*orderItemUnitPrice(products[item.ProductID], skus[i])
 		}
 
 		// 3. Calculate pay amount
@@ -163,19 +182,22 @@ func (s *service) createOrderWithRetry(ctx context.Context, userID int64, req Cr
 
 		// 5. Create order items
 		items := make([]sqlc.OrderItem, 0, len(req.Items))
-		for _, itemReq := range req.Items {
+		for i, itemReq := range req.Items {
 			product:=products[itemReq.ProductID]
-			unitPrice:=product.Price
+			unitPrice:=orderItemUnitPrice(product, skus[i])
 			totalPrice := int64(itemReq.Quantity) * unitPrice
 
 			var productImage *string
-			if product.MainImage != "" {
+			if skus[i] != nil && skus[i].ImageURL != "" {
+				productImage = &skus[i].ImageURL
+			} else if product.MainImage != "" {
 				productImage = &product.MainImage
 			}
 
 			item, err := q.CreateOrderItem(ctx, sqlc.CreateOrderItemParams{
 				OrderID:      order.ID,
 				ProductID:    itemReq.ProductID,
+				SkuID:        itemReq.SkuID,
 				ProductName:  fmt.Sprintf("Product %d", itemReq.ProductID), // placeholder
 				ProductImage: productImage,                                          // placeholder
 				Quantity:     itemReq.Quantity,
@@ -188,11 +210,12 @@ func (s *service) createOrderWithRetry(ctx context.Context, userID int64, req Cr
 			items = append(items, item)
 		}
 
-		//7. Reserve stock 
-
+		//7. Reserve stock (lines without stock may be backordered)
+		backordered := false
 		for _,item:=range req.Items{
-			err=s.inventoryService.ReserveStock(ctx, inventory.ReserveStockRequest{
+			reservation,err:=s.inventoryService.ReserveStockWithBackorder(ctx, inventory.ReserveStockRequest{
 				ProductID: item.ProductID,
+				SkuID: item.SkuID,
 				Quantity: item.Quantity,
 				OrderID: order.ID,
 			},30)
@@ -200,6 +223,25 @@ func (s *service) createOrderWithRetry(ctx context.Context, userID int64, req Cr
 			if err!=nil{
 				return fmt.Errorf("failed ti reserve stock for product %d: %w",item.ProductID,err)
 			}
+			if reservation.BackorderedQuantity > 0 {
+				backordered = true
+			}
+		}
+
+		// An order waiting for stock is not payable yet; keep its reservations from expiring
+		if backordered {
+			err = q.UpdateOrderStatus(ctx, sqlc.UpdateOrderStatusParams{
+				Status: StatusBackordered,
+				ID:     order.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to mark order backordered: %w", err)
+			}
+			order.Status = StatusBackordered
+
+			if err := s.inventoryService.HoldOrderReservations(ctx, order.ID); err != nil {
+				return err
+			}
 		}
 
 		// 8. Convert to response
@@ -343,6 +385,12 @@ func (s *service) UpdateOrderStatus(ctx context.Context, userID int64, orderID i
 		return errors.New("unauthorized access to order")
 	}
 
+	// A backordered order becomes pending when its backorders are allocated, or is cancelled
+	// through CancelOrder so the backorders are cancelled with it
+	if order.Status == StatusBackordered {
+		return errors.New("backordered order status cannot be changed directly")
+	}
+
 	// Update status
 	err = s.repo.UpdateOrderStatus(ctx, sqlc.UpdateOrderStatusParams{
 		Status: req.Status,
@@ -384,11 +432,27 @@ func (s *service) CancelOrder(ctx context.Context, userID int64, orderID int64)
 			return fmt.Errorf("failed to get order items: %w",err)
 		}
 
-		//Release reserved stock(only if order is pending/unpaid)
-		if order.Status=="pending" &&order.PaymentStatus=="unpaid"{
+		// Backordered lines never reserved stock, so they are cancelled instead of released
+		backordered := make(map[inventory.StockKey]bool)
+		if order.Status == StatusBackordered {
+			keys, err := s.inventoryService.CancelOrderBackorders(ctx, orderID)
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				backordered[key] = true
+			}
+		}
+
+		//Release reserved stock(only if order is pending/backordered and unpaid)
+		if (order.Status=="pending" || order.Status==StatusBackordered) &&order.PaymentStatus=="unpaid"{
 			for _,item:=range items{
+				if backordered[inventory.NewStockKey(item.ProductID, item.SkuID)] {
+					continue
+				}
 				err=s.inventoryService.ReleaseStock(ctx, inventory.ReleaseStockRequest{
 					ProductID: item.ProductID,
+					SkuID: item.SkuID,
 					Quantity: item.Quantity,
 					OrderID: orderID,
 				})
@@ -458,7 +522,8 @@ func (s *service) UpdateShipStatus(ctx context.Context, orderID int64, status st
 
 //PayOrder handles order payment (deduct reserved stock)
 func (s *service) PayOrder(ctx context.Context, userID int64, orderID int64) error{
-	return s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+	var paidItems []sqlc.OrderItem
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
 		// 1.Verify order ownership
 		order,err:=q.GetOrderByID(ctx,orderID)
 		if err!=nil{
@@ -487,6 +552,7 @@ func (s *service) PayOrder(ctx context.Context, userID int64, orderID int64) err
 		for _,item:=range items{
 			err=s.inventoryService.DeductStock(ctx,inventory.DeductStockRequest{
 				ProductID: item.ProductID,
+				SkuID: item.SkuID,
 				Quantity: item.Quantity,
 				OrderID: item.OrderID,
 			})
@@ -504,8 +570,20 @@ func (s *service) PayOrder(ctx context.Context, userID int64, orderID int64) err
 			return fmt.Errorf("failed to update payment status: %w",err)
 		}
 
+		paidItems = items
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// Sales feed the hot product rankings; a failure there must not fail the payment
+	for _, item := range paidItems {
+		if err := s.productService.RecordHotEvent(ctx, item.ProductID, product.HotEventSale, item.Quantity); err != nil {
+			log.Printf("failed to record sale of product %d in hot rankings: %v", item.ProductID, err)
+		}
+	}
+	return nil
 }
 
 // ShipOrder marks order as shipped
@@ -570,3 +648,29 @@ func generateOrderNo() string {
 	now := time.Now()
 	return fmt.Sprintf("ORD%s%03d", now.Format("20060102150405"), now.Nanosecond()%1000)
 }
+
+// resolveOrderItemSku picks the SKU an order line refers to. Products with active SKUs must be
+// ordered by SKU; products without variants must not name one.
+func resolveOrderItemSku(item OrderItemRequest, skus []product.SkuResponse) (*product.SkuResponse, error) {
+	if item.SkuID == nil {
+		if len(skus) > 0 {
+			return nil, fmt.Errorf("product %d requires a sku_id", item.ProductID)
+		}
+		return nil, nil
+	}
+
+	for i := range skus {
+		if skus[i].ID == *item.SkuID {
+			return &skus[i], nil
+		}
+	}
+	return nil, fmt.Errorf("sku %d not found for product %d", *item.SkuID, item.ProductID)
+}
+
+// orderItemUnitPrice charges the SKU price when the line names a variant
+func orderItemUnitPrice(p *product.ProductResponse, sku *product.SkuResponse) int64 {
+	if sku != nil {
+		return sku.Price
+	}
+	return p.Price
+}
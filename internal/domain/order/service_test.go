@@ -0,0 +1,28 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+)
+
+func TestUpdateOrderStatusRejectsBackorderedOrders(t *testing.T) {
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	s := NewService(store, nil, nil)
+
+	// Paying or shipping a backordered order would skip allocation; no status update is written
+	store.EXPECT().GetOrderByID(gomock.Any(), int64(8)).Return(sqlc.Order{ID: 8, UserID: 1, Status: StatusBackordered}, nil).Times(2)
+	err := s.UpdateOrderStatus(context.Background(), 1, 8, UpdateOrderStatusRequest{Status: "paid"})
+	require.EqualError(t, err, "backordered order status cannot be changed directly")
+	err = s.UpdateOrderStatus(context.Background(), 1, 8, UpdateOrderStatusRequest{Status: "cancelled"})
+	require.EqualError(t, err, "backordered order status cannot be changed directly")
+
+	store.EXPECT().GetOrderByID(gomock.Any(), int64(9)).Return(sqlc.Order{ID: 9, UserID: 1, Status: "pending"}, nil)
+	store.EXPECT().UpdateOrderStatus(gomock.Any(), sqlc.UpdateOrderStatusParams{Status: "paid", ID: 9}).Return(nil)
+	require.NoError(t, s.UpdateOrderStatus(context.Background(), 1, 9, UpdateOrderStatusRequest{Status: "paid"}))
+}
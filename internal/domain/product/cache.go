@@ -0,0 +1,115 @@
+package product
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/internal/cache"
+)
+
+// listGeneration returns the stamp embedded in every product list key.
+// Bumping it on writes orphans all cached pages at once; they expire with their TTL.
+// A missing stamp (never set, or evicted) is replaced by the current time, which never
+// matches an older stamp, so evictions cannot resurrect stale pages.
+func (s *service) listGeneration(ctx context.Context) (int64, bool) {
+	key := cache.CacheKeys.ProductListVersion()
+	if cached, err := s.cache.Get(ctx, key); err == nil {
+		if generation, err := strconv.ParseInt(cached, 10, 64); err == nil {
+			return generation, true
+		}
+	}
+
+	generation := time.Now().UnixNano()
+	if err := s.cache.Set(ctx, key, strconv.FormatInt(generation, 10), 0); err != nil {
+		return 0, false
+	}
+	return generation, true
+}
+
+// readPageThrough caches a product list page under a key derived from the current list generation
+func (s *service) readPageThrough(ctx context.Context, listKey func(generation int64) string, load func(context.Context) (*PaginatedProductsResponse, error)) (*PaginatedProductsResponse, error) {
+	if s.cache == nil {
+		return load(ctx)
+	}
+	generation, ok := s.listGeneration(ctx)
+	if !ok {
+		return load(ctx)
+	}
+	return s.pages.GetOrLoad(ctx, listKey(generation), s.cacheCfg.ProductListTTL, load)
+}
+
+// applyLiveFields overwrites the detail fields that inventory, orders, reviews and the view counter
+// change without invalidating the cache: stock, SKU stock, sales and views, and the rating
+func (s *service) applyLiveFields(ctx context.Context, detail *ProductDetailResponse) error {
+	live, err := s.repo.GetProductLiveFields(ctx, detail.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("product not found")
+		}
+		return fmt.Errorf("failed to get product: %w", err)
+	}
+	detail.Stock = live.Stock
+	detail.SalesCount = live.SalesCount
+	detail.ViewCount = live.ViewCount
+	detail.RatingAvg = averageRating(live.RatingTotal, live.RatingCount)
+	detail.RatingCount = live.RatingCount
+
+	if len(detail.Skus) == 0 {
+		return nil
+	}
+	rows, err := s.repo.ListProductSkuStock(ctx, detail.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get product sku stock: %w", err)
+	}
+	stock := make(map[int64]int32, len(rows))
+	for _, row := range rows {
+		stock[row.ID] = row.AvailableStock
+	}
+	skus := make([]SkuResponse, len(detail.Skus))
+	for i, sku := range detail.Skus {
+		sku.Stock = stock[sku.ID]
+		skus[i] = sku
+	}
+	detail.Skus = skus
+	return nil
+}
+
+// invalidateProduct drops the cached detail of a changed product and every cached product list
+func (s *service) invalidateProduct(ctx context.Context, productID int64) {
+	if s.cache == nil {
+		return
+	}
+
+	if err := s.details.Delete(ctx, cache.CacheKeys.Product(productID)); err != nil {
+		log.Printf("failed to invalidate cached product %d: %v", productID, err)
+	}
+	s.invalidateProductLists(ctx)
+}
+
+// invalidateProductLists starts a new list generation
+func (s *service) invalidateProductLists(ctx context.Context) {
+	if s.cache == nil {
+		return
+	}
+
+	generation := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := s.cache.Set(ctx, cache.CacheKeys.ProductListVersion(), generation, 0); err != nil {
+		log.Printf("failed to invalidate cached product lists: %v", err)
+	}
+}
+
+// listQueryHash identifies a list request in cache keys
+func listQueryHash(req ListProductsRequest) string {
+	data, _ := json.Marshal(req) // map keys are sorted, so equal requests hash equally
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
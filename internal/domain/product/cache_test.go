@@ -0,0 +1,130 @@
+package product
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+	"gomall/internal/cache"
+	"gomall/internal/config"
+)
+
+func newCachedTestService(t *testing.T) (*service, *mockdb.MockStore) {
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	c := cache.NewMemoryCache(cache.MemoryConfig{})
+	return &service{
+		repo:     store,
+		cache:    c,
+		cacheCfg: config.CacheConfig{ProductTTL: time.Hour},
+		details:  cache.NewTyped[*ProductDetailResponse](c, cache.TypedOptions{}),
+	}, store
+}
+
+// expectProductLoad expects one uncached load of a product with a single SKU
+func expectProductLoad(store *mockdb.MockStore, product sqlc.Product, skuStock int32) {
+	store.EXPECT().GetProductByID(gomock.Any(), product.ID).Return(product, nil)
+	store.EXPECT().GetProductImages(gomock.Any(), product.ID).Return(nil, nil)
+	store.EXPECT().GetProductImageRenditions(gomock.Any(), product.ID).Return(nil, nil)
+	store.EXPECT().ListProductOptionValues(gomock.Any(), product.ID).Return(nil, nil)
+	store.EXPECT().ListProductSkus(gomock.Any(), product.ID).Return([]sqlc.ListProductSkusRow{
+		{ID: 7, ProductID: product.ID, SkuCode: "MUG-RED", Options: []byte(`{}`), AvailableStock: skuStock},
+	}, nil)
+}
+
+func TestGetProductReadsVolatileFieldsLive(t *testing.T) {
+	ctx := context.Background()
+	s, store := newCachedTestService(t)
+
+	expectProductLoad(store, sqlc.Product{ID: 5, Name: "Mug", Stock: 10, SalesCount: 1, RatingTotal: 4, RatingCount: 1}, 10)
+	store.EXPECT().GetProductLiveFields(gomock.Any(), int64(5)).Return(sqlc.GetProductLiveFieldsRow{Stock: 10, SalesCount: 1, RatingTotal: 4, RatingCount: 1}, nil)
+	store.EXPECT().ListProductSkuStock(gomock.Any(), int64(5)).Return([]sqlc.ListProductSkuStockRow{{ID: 7, AvailableStock: 10}}, nil)
+
+	detail, err := s.GetProduct(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, int32(10), detail.Skus[0].Stock)
+	require.Equal(t, 4.0, detail.RatingAvg)
+
+	// Stock was reserved, an order was paid and a review was approved; none of these touch
+	// the product service, and the second read is served from the cache
+	store.EXPECT().GetProductLiveFields(gomock.Any(), int64(5)).Return(sqlc.GetProductLiveFieldsRow{Stock: 8, SalesCount: 3, RatingTotal: 6, RatingCount: 2}, nil)
+	store.EXPECT().ListProductSkuStock(gomock.Any(), int64(5)).Return([]sqlc.ListProductSkuStockRow{{ID: 7, AvailableStock: 6}}, nil)
+
+	detail, err = s.GetProduct(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, "Mug", detail.Name)
+	require.Equal(t, int32(8), detail.Stock)
+	require.Equal(t, int32(3), detail.SalesCount)
+	require.Equal(t, 3.0, detail.RatingAvg)
+	require.Equal(t, int32(2), detail.RatingCount)
+	require.Equal(t, int32(6), detail.Skus[0].Stock)
+
+	// Live values are not written back into the cached detail
+	cached, err := s.details.Get(ctx, cache.CacheKeys.Product(5))
+	require.NoError(t, err)
+	require.Equal(t, int32(10), cached.Skus[0].Stock)
+}
+
+func TestGetProductInvalidatedByProductUpdate(t *testing.T) {
+	ctx := context.Background()
+	s, store := newCachedTestService(t)
+
+	expectProductLoad(store, sqlc.Product{ID: 5, Name: "Mug"}, 0)
+	store.EXPECT().GetProductLiveFields(gomock.Any(), int64(5)).Return(sqlc.GetProductLiveFieldsRow{}, nil).Times(2)
+	store.EXPECT().ListProductSkuStock(gomock.Any(), int64(5)).Return(nil, nil).Times(2)
+
+	_, err := s.GetProduct(ctx, 5)
+	require.NoError(t, err)
+
+	// Writes through the product service drop the cached detail, so the next read reloads it
+	s.invalidateProduct(ctx, 5)
+	expectProductLoad(store, sqlc.Product{ID: 5, Name: "Large mug"}, 0)
+
+	detail, err := s.GetProduct(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, "Large mug", detail.Name)
+}
+
+func TestGetProductDeletedWhileCached(t *testing.T) {
+	ctx := context.Background()
+	s, store := newCachedTestService(t)
+
+	expectProductLoad(store, sqlc.Product{ID: 5, Name: "Mug"}, 0)
+	store.EXPECT().GetProductLiveFields(gomock.Any(), int64(5)).Return(sqlc.GetProductLiveFieldsRow{}, nil)
+	store.EXPECT().ListProductSkuStock(gomock.Any(), int64(5)).Return(nil, nil)
+	_, err := s.GetProduct(ctx, 5)
+	require.NoError(t, err)
+
+	store.EXPECT().GetProductLiveFields(gomock.Any(), int64(5)).Return(sqlc.GetProductLiveFieldsRow{}, pgx.ErrNoRows)
+	_, err = s.GetProduct(ctx, 5)
+	require.EqualError(t, err, "product not found")
+}
+
+func TestRecordViewReadsCategoryFromCachedDetail(t *testing.T) {
+	ctx := context.Background()
+	s, store := newCachedTestService(t)
+	s.cacheCfg.ViewDedupWindow = time.Minute
+	s.hot = config.HotProductsConfig{Windows: []time.Duration{time.Hour}, ViewWeight: 1}
+	var err error
+	s.ranker, err = cache.NewRanker(s.cache)
+	require.NoError(t, err)
+	require.NoError(t, s.ranker.Replace(ctx, cache.CacheKeys.HotProductRanking(time.Hour, 3), map[string]float64{}))
+
+	expectProductLoad(store, sqlc.Product{ID: 5, CategoryID: 3, Name: "Mug"}, 10)
+	store.EXPECT().GetProductLiveFields(gomock.Any(), int64(5)).Return(sqlc.GetProductLiveFieldsRow{}, nil)
+	store.EXPECT().ListProductSkuStock(gomock.Any(), int64(5)).Return(nil, nil)
+	_, err = s.GetProduct(ctx, 5)
+	require.NoError(t, err)
+
+	// The page view that follows finds the category in the cached detail without reading the product again
+	require.NoError(t, s.RecordView(ctx, 5, "alice"))
+	top, err := s.ranker.Top(ctx, cache.CacheKeys.HotProductRanking(time.Hour, 3), 10, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	require.Equal(t, "5", top[0].Member)
+}
@@ -1,8 +1,10 @@
 package product
 
 import (
+	"encoding/json"
 	"gomall/db/sqlc"
 	"gomall/utils"
+	"math"
 	"time"
 )
 
@@ -13,12 +15,29 @@ type ImageRequest struct {
 	IsMain   bool   `json:"is_main"`
 }
 
+// UploadImageRequest holds the form fields sent with an uploaded image file
+type UploadImageRequest struct {
+	Sort   int32 `form:"sort"`
+	IsMain bool  `form:"is_main"`
+}
+
 type ImageResponse struct {
 	ID       int64     `json:"id"`
 	ImageURL string    `json:"image_url"`
 	Sort     int32     `json:"sort"`
 	IsMain   bool      `json:"is_main"`
+	Width    int32     `json:"width,omitempty"`
+	Height   int32     `json:"height,omitempty"`
 	CreateAt time.Time `json:"created_at"`
+	// Renditions are the thumbnails of an uploaded image, smallest first
+	Renditions []ImageRenditionResponse `json:"renditions,omitempty"`
+}
+
+type ImageRenditionResponse struct {
+	Name     string `json:"name"`
+	ImageURL string `json:"image_url"`
+	Width    int32  `json:"width"`
+	Height   int32  `json:"height"`
 }
 
 // Product Request DTOs
@@ -56,18 +75,44 @@ type UpdateStockRequest struct {
 }
 
 type ListProductsRequest struct {
-	CategoryID *int64  `form:"category_id"`
-	Status     *string `form:"status" binding:"omitempty,oneof=draft published off_shelf"`
-	Page       int32   `form:"page" binding:"min=1"`
-	PageSize   int32   `form:"page_size" binding:"min=1,max=100"`
+	CategoryID *int64   `form:"category_id"`
+	Status     *string  `form:"status" binding:"omitempty,oneof=draft published off_shelf"`
+	Brands     []string `form:"brand"`
+	Price      string   `form:"price"` // price bucket key such as "5000-10000", "-5000" or "500000-"
+	Sort       string   `form:"sort" binding:"omitempty,oneof=relevance newest price_asc price_desc sales"`
+	Page       int32    `form:"page" binding:"min=1"`
+	PageSize   int32    `form:"page_size" binding:"min=1,max=100"`
+
+	// Specs holds spec.<attribute>=<value>[,<value>] query parameters
+	Specs map[string][]string `form:"-"`
 }
 
 type SearchProductsRequest struct {
 	Keyword  string `form:"keyword" binding:"required,min=1"`
+	Sort     string `form:"sort" binding:"omitempty,oneof=relevance newest price_asc price_desc sales"`
 	Page     int32  `form:"page" binding:"min=1"`
 	PageSize int32  `form:"page_size" binding:"min=1,max=100"`
 }
 
+type SuggestRequest struct {
+	Q     string `form:"q" binding:"required,min=1,max=100"`
+	Limit int32  `form:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+type HotProductsRequest struct {
+	Window     string `form:"window"`
+	CategoryID int64  `form:"category_id" binding:"min=0"`
+	Limit      int32  `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type RelatedProductsRequest struct {
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type RecentlyViewedRequest struct {
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
 type PriceRangeRequest struct {
 	MinPrice int64 `form:"min_price" binding:"min=0"`
 	MaxPrice int64 `form:"max_price" binding:"min=0"`
@@ -75,6 +120,36 @@ type PriceRangeRequest struct {
 	PageSize int32 `form:"page_size" binding:"min=1,max=100"`
 }
 
+// Variant Request DTOs
+
+type ProductOptionRequest struct {
+	Name   string   `json:"name" binding:"required,min=1,max=50"`
+	Values []string `json:"values" binding:"required,min=1,dive,min=1,max=100"`
+}
+
+type SetProductOptionsRequest struct {
+	Options []ProductOptionRequest `json:"options" binding:"required,dive"`
+}
+
+type CreateSkuRequest struct {
+	SkuCode           string            `json:"sku_code" binding:"required,min=1,max=64"`
+	Price             int64             `json:"price" binding:"required,min=0"`
+	OriginPrice       int64             `json:"origin_price" binding:"min=0"`
+	ImageURL          string            `json:"image_url" binding:"omitempty,url,max=500"`
+	Barcode           string            `json:"barcode" binding:"max=64"`
+	Options           map[string]string `json:"options" binding:"required"`
+	Stock             int32             `json:"stock" binding:"min=0"`
+	LowStockThreshold int32             `json:"low_stock_threshold" binding:"min=0"`
+}
+
+type UpdateSkuRequest struct {
+	Price       *int64  `json:"price,omitempty" binding:"omitempty,min=0"`
+	OriginPrice *int64  `json:"origin_price,omitempty" binding:"omitempty,min=0"`
+	ImageURL    *string `json:"image_url,omitempty" binding:"omitempty,url,max=500"`
+	Barcode     *string `json:"barcode,omitempty" binding:"omitempty,min=1,max=64"`
+	IsActive    *bool   `json:"is_active,omitempty"`
+}
+
 // Product Response DTOs
 
 type ProductResponse struct {
@@ -93,8 +168,23 @@ type ProductResponse struct {
 	IsFeatured        bool      `json:"is_featured"`
 	Specifications    string    `json:"specifications,omitempty"`
 	MainImage         string    `json:"main_image,omitempty"`
+	RatingAvg         float64   `json:"rating_avg"`
+	RatingCount       int32     `json:"rating_count"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
+
+	// Search results only
+	Rank      float32          `json:"rank,omitempty"`
+	Highlight *SearchHighlight `json:"highlight,omitempty"`
+
+	// Hot products only: the decayed popularity score the list is ordered by
+	HotScore float64 `json:"hot_score,omitempty"`
+}
+
+// SearchHighlight holds HTML-escaped snippets with matched terms wrapped in <em> tags
+type SearchHighlight struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 type ProductDetailResponse struct {
@@ -113,9 +203,67 @@ type ProductDetailResponse struct {
 	Status            string          `json:"status"`
 	IsFeatured        bool            `json:"is_featured"`
 	Specifications    string          `json:"specifications,omitempty"`
+	RatingAvg         float64         `json:"rating_avg"`
+	RatingCount       int32           `json:"rating_count"`
 	Images            []ImageResponse `json:"images"`
-	CreatedAt         time.Time       `json:"created_at"`
-	UpdatedAt         time.Time       `json:"updated_at"`
+	// Variant matrix: the option dimensions and every SKU with its option values
+	Options   []ProductOptionResponse `json:"options"`
+	Skus      []SkuResponse           `json:"skus"`
+	CreatedAt time.Time               `json:"created_at"`
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+type ProductOptionResponse struct {
+	ID     int64    `json:"id"`
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type SkuResponse struct {
+	ID          int64             `json:"id"`
+	ProductID   int64             `json:"product_id"`
+	SkuCode     string            `json:"sku_code"`
+	Price       int64             `json:"price"`
+	OriginPrice int64             `json:"origin_price"`
+	ImageURL    string            `json:"image_url,omitempty"`
+	Barcode     string            `json:"barcode,omitempty"`
+	Options     map[string]string `json:"options"`
+	Stock       int32             `json:"stock"`
+	IsActive    bool              `json:"is_active"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// HotProductsResponse lists the most popular products of a window, most popular first
+type HotProductsResponse struct {
+	Window     string            `json:"window"`
+	CategoryID int64             `json:"category_id,omitempty"`
+	Products   []ProductResponse `json:"products"`
+}
+
+// Reasons a product is related to another
+const (
+	RelatedAlsoBought         = "also_bought"
+	RelatedCategoryBestSeller = "category_best_seller"
+)
+
+// RelatedProductResponse is a product recommended alongside another. Support and confidence
+// are set for also_bought: the orders containing both, and their share of the orders containing the viewed product.
+type RelatedProductResponse struct {
+	ProductResponse
+	Reason     string  `json:"reason"`
+	Support    int32   `json:"support,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// RelatedProductsResponse lists products customers also bought, topped up with category best sellers
+type RelatedProductsResponse struct {
+	ProductID int64                    `json:"product_id"`
+	Products  []RelatedProductResponse `json:"products"`
+}
+
+type RecentlyViewedResponse struct {
+	Products []ProductResponse `json:"products"`
 }
 
 type PaginatedProductsResponse struct {
@@ -124,6 +272,59 @@ type PaginatedProductsResponse struct {
 	Page       int32             `json:"page"`
 	PageSize   int32             `json:"page_size"`
 	TotalPages int32             `json:"total_pages"`
+	Facets     *ProductFacets    `json:"facets,omitempty"`
+
+	// Suggestions holds "did you mean" terms when a search finds nothing
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// SuggestResponse holds typeahead matches for a search-box prefix
+type SuggestResponse struct {
+	Products   []ProductSuggestion  `json:"products"`
+	Brands     []BrandSuggestion    `json:"brands"`
+	Categories []CategorySuggestion `json:"categories"`
+}
+
+type ProductSuggestion struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type BrandSuggestion struct {
+	Brand        string `json:"brand"`
+	ProductCount int64  `json:"product_count"`
+}
+
+type CategorySuggestion struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug,omitempty"`
+}
+
+// ProductFacets holds the "filter by" counts for a product listing
+type ProductFacets struct {
+	Specs  []SpecFacet  `json:"specs"`
+	Brands []FacetValue `json:"brands"`
+	Prices []PriceFacet `json:"prices"`
+}
+
+type SpecFacet struct {
+	Name   string       `json:"name"`
+	Values []FacetValue `json:"values"`
+}
+
+type FacetValue struct {
+	Value    string `json:"value"`
+	Count    int64  `json:"count"`
+	Selected bool   `json:"selected"`
+}
+
+type PriceFacet struct {
+	Key      string `json:"key"`
+	MinPrice *int64 `json:"min_price,omitempty"`
+	MaxPrice *int64 `json:"max_price,omitempty"`
+	Count    int64  `json:"count"`
+	Selected bool   `json:"selected"`
 }
 
 // Conversion functions
@@ -150,6 +351,8 @@ func toProductResponse(product sqlc.Product, mainImageURL string) ProductRespons
 		IsFeatured:        product.IsFeatured,
 		Specifications:    specs,
 		MainImage:         mainImageURL,
+		RatingAvg:         averageRating(product.RatingTotal, product.RatingCount),
+		RatingCount:       product.RatingCount,
 		CreatedAt:         product.CreatedAt,
 		UpdatedAt:         product.UpdatedAt,
 	}
@@ -163,13 +366,7 @@ func toProductDetailResponse(product sqlc.Product, images []sqlc.ProductImage) P
 
 	imageResponses := make([]ImageResponse, 0, len(images))
 	for _, img := range images {
-		imageResponses = append(imageResponses, ImageResponse{
-			ID:       img.ID,
-			ImageURL: img.ImageUrl,
-			Sort:     utils.PtrValue(img.Sort),
-			IsMain:   utils.PtrValue(img.IsMain),
-			CreateAt: img.CreatedAt,
-		})
+		imageResponses = append(imageResponses, toImageResponse(img))
 	}
 
 	return ProductDetailResponse{
@@ -188,8 +385,90 @@ func toProductDetailResponse(product sqlc.Product, images []sqlc.ProductImage) P
 		Status:            product.Status,
 		IsFeatured:        product.IsFeatured,
 		Specifications:    specs,
+		RatingAvg:         averageRating(product.RatingTotal, product.RatingCount),
+		RatingCount:       product.RatingCount,
 		Images:            imageResponses,
+		Options:           []ProductOptionResponse{},
+		Skus:              []SkuResponse{},
 		CreatedAt:         product.CreatedAt,
 		UpdatedAt:         product.UpdatedAt,
 	}
 }
+
+// averageRating is the mean star rating rounded to two decimals, 0 when unrated
+func averageRating(total int64, count int32) float64 {
+	if count == 0 {
+		return 0
+	}
+	return math.Round(float64(total)/float64(count)*100) / 100
+}
+
+func toProductOptionResponses(rows []sqlc.ListProductOptionValuesRow) []ProductOptionResponse {
+	options := make([]ProductOptionResponse, 0)
+	for _, row := range rows {
+		if n := len(options); n == 0 || options[n-1].ID != row.OptionID {
+			options = append(options, ProductOptionResponse{
+				ID:     row.OptionID,
+				Name:   row.OptionName,
+				Values: []string{},
+			})
+		}
+		last := &options[len(options)-1]
+		last.Values = append(last.Values, row.Value)
+	}
+	return options
+}
+
+func toSkuResponse(sku sqlc.ProductSku, stock int32) SkuResponse {
+	options := map[string]string{}
+	_ = json.Unmarshal(sku.Options, &options)
+
+	return SkuResponse{
+		ID:          sku.ID,
+		ProductID:   sku.ProductID,
+		SkuCode:     sku.SkuCode,
+		Price:       sku.Price,
+		OriginPrice: sku.OriginPrice,
+		ImageURL:    utils.PtrValue(sku.ImageUrl),
+		Barcode:     utils.PtrValue(sku.Barcode),
+		Options:     options,
+		Stock:       stock,
+		IsActive:    sku.IsActive,
+		CreatedAt:   sku.CreatedAt,
+		UpdatedAt:   sku.UpdatedAt,
+	}
+}
+
+func toImageResponse(img sqlc.ProductImage) ImageResponse {
+	return ImageResponse{
+		ID:       img.ID,
+		ImageURL: img.ImageUrl,
+		Sort:     utils.PtrValue(img.Sort),
+		IsMain:   utils.PtrValue(img.IsMain),
+		Width:    utils.PtrValue(img.Width),
+		Height:   utils.PtrValue(img.Height),
+		CreateAt: img.CreatedAt,
+	}
+}
+
+func toImageRenditionResponse(img sqlc.ProductImage) ImageRenditionResponse {
+	return ImageRenditionResponse{
+		Name:     img.Rendition,
+		ImageURL: img.ImageUrl,
+		Width:    utils.PtrValue(img.Width),
+		Height:   utils.PtrValue(img.Height),
+	}
+}
+
+// attachRenditions adds each thumbnail rendition to the image it was rendered from
+func attachRenditions(images []ImageResponse, renditions []sqlc.ProductImage) {
+	positions := make(map[int64]int, len(images))
+	for i, img := range images {
+		positions[img.ID] = i
+	}
+	for _, rendition := range renditions {
+		if i, ok := positions[utils.PtrValue(rendition.ParentID)]; ok {
+			images[i].Renditions = append(images[i].Renditions, toImageRenditionResponse(rendition))
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package product
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gomall/internal/search"
+)
+
+const (
+	// maxSpecFilters caps the number of spec attributes a listing can filter on at once
+	maxSpecFilters = 10
+	// maxFacetValues caps the values returned per spec attribute, most common first
+	maxFacetValues = 20
+)
+
+// defaultPriceBuckets are the price facet boundaries (in cents) used when none are configured
+var defaultPriceBuckets = []int64{5000, 10000, 50000, 100000, 500000}
+
+// productFilter is a ListProductsRequest resolved into query parameters
+type productFilter struct {
+	categoryID *int64
+	status     *string
+	brands     []string
+	price      string
+	minPrice   *int64
+	maxPrice   *int64
+	specs      map[string][]string
+}
+
+// newProductFilter validates the filters of a listing request
+func newProductFilter(req ListProductsRequest) (productFilter, error) {
+	f := productFilter{
+		categoryID: req.CategoryID,
+		status:     req.Status,
+		brands:     splitFilterValues(req.Brands),
+		price:      req.Price,
+		specs:      make(map[string][]string, len(req.Specs)),
+	}
+
+	if req.Price != "" {
+		minPrice, maxPrice, err := parsePriceRange(req.Price)
+		if err != nil {
+			return productFilter{}, err
+		}
+		f.minPrice, f.maxPrice = minPrice, maxPrice
+	}
+
+	for name, values := range req.Specs {
+		if values = splitFilterValues(values); len(values) > 0 {
+			f.specs[name] = values
+		}
+	}
+	if len(f.specs) > maxSpecFilters {
+		return productFilter{}, errors.New("too many spec filters")
+	}
+
+	return f, nil
+}
+
+// splitFilterValues flattens repeated and comma-separated query values, dropping blanks and duplicates
+func splitFilterValues(raw []string) []string {
+	var values []string
+	for _, item := range raw {
+		for _, value := range strings.Split(item, ",") {
+			value = strings.TrimSpace(value)
+			if value != "" && !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// parsePriceRange parses a price bucket key "min-max" where either bound may be omitted.
+// The lower bound is inclusive and the upper bound exclusive.
+func parsePriceRange(key string) (*int64, *int64, error) {
+	lower, upper, ok := strings.Cut(key, "-")
+	if !ok || (lower == "" && upper == "") {
+		return nil, nil, errors.New("invalid price range")
+	}
+
+	parse := func(s string) (*int64, error) {
+		if s == "" {
+			return nil, nil
+		}
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || v < 0 {
+			return nil, errors.New("invalid price range")
+		}
+		return &v, nil
+	}
+
+	minPrice, err := parse(lower)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxPrice, err := parse(upper)
+	if err != nil {
+		return nil, nil, err
+	}
+	if minPrice != nil && maxPrice != nil && *minPrice >= *maxPrice {
+		return nil, nil, errors.New("invalid price range")
+	}
+
+	return minPrice, maxPrice, nil
+}
+
+// priceBucket describes bucket i of width_bucket over boundaries
+func priceBucket(boundaries []int64, bucket int) PriceFacet {
+	var facet PriceFacet
+	if bucket > 0 && bucket <= len(boundaries) {
+		facet.MinPrice = &boundaries[bucket-1]
+	}
+	if bucket >= 0 && bucket < len(boundaries) {
+		facet.MaxPrice = &boundaries[bucket]
+	}
+
+	var lower, upper string
+	if facet.MinPrice != nil {
+		lower = strconv.FormatInt(*facet.MinPrice, 10)
+	}
+	if facet.MaxPrice != nil {
+		upper = strconv.FormatInt(*facet.MaxPrice, 10)
+	}
+	facet.Key = lower + "-" + upper
+	return facet
+}
+
+// searchQuery turns the filter into an index query
+func (f productFilter) searchQuery() search.Query {
+	return search.Query{
+		CategoryID: f.categoryID,
+		Status:     f.status,
+		Brands:     f.brands,
+		MinPrice:   f.minPrice,
+		MaxPrice:   f.maxPrice,
+		Specs:      f.specs,
+	}
+}
+
+// toProductFacets labels index facet counts and marks the values selected by the filter
+func toProductFacets(facets *search.Facets, f productFilter, priceBuckets []int64) *ProductFacets {
+	result := &ProductFacets{
+		Specs:  []SpecFacet{},
+		Brands: []FacetValue{},
+		Prices: []PriceFacet{},
+	}
+	if facets == nil {
+		return result
+	}
+
+	for _, spec := range facets.Specs {
+		facet := SpecFacet{Name: spec.Name}
+		for _, value := range spec.Values[:min(len(spec.Values), maxFacetValues)] {
+			facet.Values = append(facet.Values, FacetValue{
+				Value:    value.Value,
+				Count:    value.Count,
+				Selected: slices.Contains(f.specs[spec.Name], value.Value),
+			})
+		}
+		result.Specs = append(result.Specs, facet)
+	}
+
+	for _, brand := range facets.Brands {
+		result.Brands = append(result.Brands, FacetValue{
+			Value:    brand.Value,
+			Count:    brand.Count,
+			Selected: slices.Contains(f.brands, brand.Value),
+		})
+	}
+
+	for _, bucket := range facets.Prices {
+		facet := priceBucket(priceBuckets, bucket.Bucket)
+		facet.Count = bucket.Count
+		facet.Selected = facet.Key == f.price
+		result.Prices = append(result.Prices, facet)
+	}
+
+	return result
+}
@@ -0,0 +1,44 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriceRange(t *testing.T) {
+	minPrice, maxPrice, err := parsePriceRange("5000-10000")
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), *minPrice)
+	require.Equal(t, int64(10000), *maxPrice)
+
+	minPrice, maxPrice, err = parsePriceRange("500000-")
+	require.NoError(t, err)
+	require.Equal(t, int64(500000), *minPrice)
+	require.Nil(t, maxPrice)
+
+	for _, key := range []string{"-", "100", "abc-200", "200-100", "-5-10"} {
+		_, _, err = parsePriceRange(key)
+		require.Error(t, err, key)
+	}
+}
+
+func TestPriceBucketRoundTrip(t *testing.T) {
+	boundaries := []int64{5000, 10000}
+
+	require.Equal(t, "-5000", priceBucket(boundaries, 0).Key)
+	require.Equal(t, "5000-10000", priceBucket(boundaries, 1).Key)
+	require.Equal(t, "10000-", priceBucket(boundaries, 2).Key)
+
+	for bucket := 0; bucket <= len(boundaries); bucket++ {
+		facet := priceBucket(boundaries, bucket)
+		minPrice, maxPrice, err := parsePriceRange(facet.Key)
+		require.NoError(t, err)
+		require.Equal(t, facet.MinPrice, minPrice)
+		require.Equal(t, facet.MaxPrice, maxPrice)
+	}
+}
+
+func TestSplitFilterValues(t *testing.T) {
+	require.Equal(t, []string{"apple", "xiaomi", "huawei"}, splitFilterValues([]string{"apple, xiaomi", "huawei,apple", " "}))
+}
@@ -1,21 +1,43 @@
 package product
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
+	"gomall/internal/common/middleware"
+	"gomall/utils"
 	"gomall/utils/response"
+	"gomall/utils/token"
+	"io"
+	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
+// defaultMaxUploadSize caps image uploads when no limit is configured
+const defaultMaxUploadSize = 10 << 20
+
+// multipartOverhead allows for the form fields and part headers around an uploaded file
+const multipartOverhead = 1 << 20
+
 // Handler handles product-related HTTP requests
 type Handler struct {
-	service Service
+	service       Service
+	maxUploadSize int64
+	tokenMaker    token.Maker
 }
 
-// NewHandler creates a new Handler instance
-func NewHandler(service Service) *Handler {
+// NewHandler creates a new Handler instance; maxUploadSize limits uploaded image files in bytes.
+// tokenMaker identifies signed-in users, whose product views go to their browsing history.
+func NewHandler(service Service, maxUploadSize int64, tokenMaker token.Maker) *Handler {
+	if maxUploadSize <= 0 {
+		maxUploadSize = defaultMaxUploadSize
+	}
 	return &Handler{
-		service: service,
+		service:       service,
+		maxUploadSize: maxUploadSize,
+		tokenMaker:    tokenMaker,
 	}
 }
 
@@ -27,9 +49,12 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		products.GET("", h.ListProducts)            // GET /products
 		products.GET("/:id", h.GetProduct)           // GET /products/:id
 		products.GET("/search", h.SearchProducts)    // GET /products/search
+		products.GET("/suggest", h.Suggest)          // GET /products/suggest
 		products.GET("/featured", h.GetFeatured)     // GET /products/featured
+		products.GET("/hot", h.GetHot)               // GET /products/hot
 		products.GET("/category/:category_id", h.GetByCategory) // GET /products/category/:category_id
 		products.GET("/price-range", h.GetByPriceRange)         // GET /products/price-range
+		products.GET("/:id/related", h.GetRelated)              // GET /products/:id/related
 
 		// Protected endpoints (require auth)
 		products.POST("", h.CreateProduct)                    // POST /products
@@ -38,8 +63,21 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		products.PUT("/:id/stock", h.UpdateStock)             // PUT /products/:id/stock
 		products.GET("/low-stock", h.GetLowStock)             // GET /products/low-stock
 		products.POST("/:id/images", h.AddImages)             // POST /products/:id/images
+		products.POST("/:id/images/upload", h.UploadImage)    // POST /products/:id/images/upload
 		products.PUT("/:id/images/:image_id/main", h.SetMainImage) // PUT /products/:id/images/:image_id/main
 		products.DELETE("/images/:image_id", h.DeleteImage)   // DELETE /products/images/:image_id
+
+		// Variants (options and SKUs)
+		products.PUT("/:id/options", h.SetOptions)          // PUT /products/:id/options
+		products.POST("/:id/skus", h.CreateSku)             // POST /products/:id/skus
+		products.PUT("/:id/skus/:sku_id", h.UpdateSku)      // PUT /products/:id/skus/:sku_id
+		products.DELETE("/:id/skus/:sku_id", h.DeleteSku)   // DELETE /products/:id/skus/:sku_id
+	}
+
+	me := router.Group("/users/me")
+	me.Use(middleware.AuthMiddleware(h.tokenMaker))
+	{
+		me.GET("/recently-viewed", h.GetRecentlyViewed) // GET /users/me/recently-viewed
 	}
 }
 
@@ -76,7 +114,7 @@ func (h *Handler) CreateProduct(c *gin.Context) {
 
 // GetProduct godoc
 // @Summary      Get Product
-// @Description  Get product details by ID
+// @Description  Get product details by ID. Anonymous callers are issued a guest_id cookie that keys their browsing history until they log in.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
@@ -102,6 +140,22 @@ func (h *Handler) GetProduct(c *gin.Context) {
 		return
 	}
 
+	if err := h.service.RecordView(c.Request.Context(), id, visitorID(c)); err != nil {
+		log.Printf("failed to record view of product %d: %v", id, err)
+	}
+
+	// Anonymous views go to the history of a cookie-issued guest ID, merged into the user's on login
+	var userID int64
+	var guestID string
+	if payload := middleware.BearerPayload(c, h.tokenMaker); payload != nil {
+		userID = payload.UserID
+	} else {
+		guestID = middleware.GuestID(c)
+	}
+	if err := h.service.RecordRecentView(c.Request.Context(), id, userID, guestID); err != nil {
+		log.Printf("failed to record recently viewed product %d: %v", id, err)
+	}
+
 	response.Success(c, product)
 }
 
@@ -173,12 +227,15 @@ func (h *Handler) DeleteProduct(c *gin.Context) {
 
 // ListProducts godoc
 // @Summary      List Products
-// @Description  List products with filtering and pagination
+// @Description  List products with filtering, pagination and facet counts. Filter on specifications with spec.<attribute>=<value>[,<value>] (e.g. spec.color=red,blue); values of one attribute are OR-ed, attributes are AND-ed.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Param        category_id  query     int     false  "Category ID"
 // @Param        status       query     string  false  "Status (draft/published/off_shelf)"
+// @Param        brand        query     string  false  "Brands, comma separated or repeated"
+// @Param        price        query     string  false  "Price bucket key from facets.prices (e.g. 5000-10000, -5000, 500000-)"
+// @Param        sort         query     string  false  "Sort order (default: newest)" Enums(relevance, newest, price_asc, price_desc, sales)
 // @Param        page         query     int     false  "Page number (default: 1)"
 // @Param        page_size    query     int     false  "Page size (default: 20)"
 // @Success      200          {object}  response.Response{data=PaginatedProductsResponse}
@@ -191,10 +248,16 @@ func (h *Handler) ListProducts(c *gin.Context) {
 		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
 		return
 	}
+	req.Specs = parseSpecFilters(c.Request.URL.Query())
 
 	products, err := h.service.ListProducts(c.Request.Context(), req)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		switch err.Error() {
+		case "invalid price range", "too many spec filters":
+			response.Error(c, http.StatusBadRequest, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
@@ -203,16 +266,18 @@ func (h *Handler) ListProducts(c *gin.Context) {
 
 // SearchProducts godoc
 // @Summary      Search Products
-// @Description  Search products by keyword
+// @Description  Full-text search over name, brand and description, ranked by relevance. Supports web search syntax ("quoted phrase", or, -exclude) and CJK keywords. Results carry a rank and <em>-highlighted snippets; when nothing matches, suggestions lists "did you mean" terms.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Param        keyword   query     string  true   "Search keyword"
+// @Param        sort      query     string  false  "Sort order (default: relevance)" Enums(relevance, newest, price_asc, price_desc, sales)
 // @Param        page      query     int     false  "Page number (default: 1)"
 // @Param        page_size query     int     false  "Page size (default: 20)"
 // @Success      200       {object}  response.Response{data=PaginatedProductsResponse}
 // @Failure      400       {object}  response.Response
 // @Failure      500       {object}  response.Response
+// @Failure      429       {object}  response.Response  "Rate limit exceeded"
 // @Router       /products/search [get]
 func (h *Handler) SearchProducts(c *gin.Context) {
 	var req SearchProductsRequest
@@ -230,9 +295,37 @@ func (h *Handler) SearchProducts(c *gin.Context) {
 	response.Success(c, products)
 }
 
+// Suggest godoc
+// @Summary      Search Suggestions
+// @Description  Typeahead for the search box: product names, brands and categories matching a prefix, including near misses (pg_trgm similarity). Popular prefixes are cached.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        q      query     string  true   "Search-box prefix"
+// @Param        limit  query     int     false  "Max suggestions per group (default: 8, max: 20)"
+// @Success      200    {object}  response.Response{data=SuggestResponse}
+// @Failure      400    {object}  response.Response
+// @Failure      500    {object}  response.Response
+// @Router       /products/suggest [get]
+func (h *Handler) Suggest(c *gin.Context) {
+	var req SuggestRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	suggestions, err := h.service.Suggest(c.Request.Context(), req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, suggestions)
+}
+
 // GetFeatured godoc
 // @Summary      Get Featured Products
-// @Description  Get featured/hot products
+// @Description  Get featured products
 // @Tags         Products
 // @Accept       json
 // @Produce      json
@@ -254,6 +347,113 @@ func (h *Handler) GetFeatured(c *gin.Context) {
 	response.Success(c, products)
 }
 
+// GetHot godoc
+// @Summary      Get Hot Products
+// @Description  Most popular published products, ranked by views, add-to-carts and sales with time decay: an event counts half as much after each window has passed
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        window       query     string  false  "Ranking window, one of the configured windows such as 1h, 24h or 168h (default: the first)"
+// @Param        category_id  query     int     false  "Only rank products of this category"
+// @Param        limit        query     int     false  "Number of products (default: 10, max: 50)"
+// @Success      200          {object}  response.Response{data=HotProductsResponse}
+// @Failure      400          {object}  response.Response
+// @Failure      500          {object}  response.Response
+// @Router       /products/hot [get]
+func (h *Handler) GetHot(c *gin.Context) {
+	var req HotProductsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	products, err := h.service.GetHotProducts(c.Request.Context(), req)
+	if err != nil {
+		if err.Error() == "unsupported window" {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, products)
+}
+
+// GetRelated godoc
+// @Summary      Get Related Products
+// @Description  Products customers also bought with this one, from orders that contained both, topped up with best sellers of its category. Unpublished and out-of-stock products are left out.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int  true   "Product ID"
+// @Param        limit  query     int  false  "Number of products (default: 10, max: 50)"
+// @Success      200    {object}  response.Response{data=RelatedProductsResponse}
+// @Failure      400    {object}  response.Response
+// @Failure      404    {object}  response.Response
+// @Failure      500    {object}  response.Response
+// @Router       /products/{id}/related [get]
+func (h *Handler) GetRelated(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req RelatedProductsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	products, err := h.service.GetRelatedProducts(c.Request.Context(), id, req)
+	if err != nil {
+		if err.Error() == "product not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, products)
+}
+
+// GetRecentlyViewed godoc
+// @Summary      Get Recently Viewed Products
+// @Description  The distinct products the current user opened last, most recent first. Products browsed before logging in are included; deleted and unpublished products are left out.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        limit  query     int  false  "Number of products (default: 20, max: 50)"
+// @Success      200    {object}  response.Response{data=RecentlyViewedResponse}
+// @Failure      400    {object}  response.Response
+// @Failure      401    {object}  response.Response
+// @Failure      500    {object}  response.Response
+// @Router       /users/me/recently-viewed [get]
+func (h *Handler) GetRecentlyViewed(c *gin.Context) {
+	payload := middleware.GetPayload(c)
+	if payload == nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req RecentlyViewedRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	products, err := h.service.GetRecentlyViewed(c.Request.Context(), payload.UserID, req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, products)
+}
+
 // GetByCategory godoc
 // @Summary      Get Products by Category
 // @Description  Get products filtered by category
@@ -408,6 +608,82 @@ func (h *Handler) AddImages(c *gin.Context) {
 	response.Success(c, nil)
 }
 
+// UploadImage godoc
+// @Summary      Upload Product Image
+// @Description  Upload an image file (JPEG, PNG, GIF or WebP). The type is detected from the content, metadata such as EXIF is stripped and thumbnails are generated in the configured sizes.
+// @Tags         Products
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id       path      int     true   "Product ID"
+// @Param        file     formData  file    true   "Image file"
+// @Param        sort     formData  int     false  "Sort order"
+// @Param        is_main  formData  bool    false  "Make this the main image"
+// @Success      201      {object}  response.Response{data=ImageResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      413      {object}  response.Response
+// @Failure      415      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /products/{id}/images/upload [post]
+func (h *Handler) UploadImage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+multipartOverhead)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "image too large")
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "image file is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > h.maxUploadSize {
+		response.Error(c, http.StatusRequestEntityTooLarge, "image too large")
+		return
+	}
+
+	var req UploadImageRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to read image file")
+		return
+	}
+
+	image, err := h.service.UploadProductImage(c.Request.Context(), id, data, req)
+	if err != nil {
+		switch err.Error() {
+		case "product not found":
+			response.Error(c, http.StatusNotFound, err.Error())
+		case "unsupported image type":
+			response.Error(c, http.StatusUnsupportedMediaType, err.Error())
+		case "invalid image", "image dimensions too large":
+			response.Error(c, http.StatusBadRequest, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    0,
+		"message": "success",
+		"data":    image,
+	})
+}
+
 // SetMainImage godoc
 // @Summary      Set Main Product Image
 // @Description  Set the main image for a product
@@ -468,3 +744,193 @@ func (h *Handler) DeleteImage(c *gin.Context) {
 
 	response.Success(c, nil)
 }
+
+// SetOptions godoc
+// @Summary      Set Product Options
+// @Description  Replace the variant options (e.g. size, colour) of a product; locked once SKUs exist
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                       true  "Product ID"
+// @Param        request  body      SetProductOptionsRequest  true  "Options and their values"
+// @Success      200      {object}  response.Response{data=[]ProductOptionResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /products/{id}/options [put]
+func (h *Handler) SetOptions(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req SetProductOptionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	options, err := h.service.SetProductOptions(c.Request.Context(), id, req)
+	if err != nil {
+		variantError(c, err)
+		return
+	}
+
+	response.Success(c, options)
+}
+
+// CreateSku godoc
+// @Summary      Create SKU
+// @Description  Add a variant with its own code, price, image, barcode and initial stock
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int               true  "Product ID"
+// @Param        request  body      CreateSkuRequest  true  "SKU information"
+// @Success      201      {object}  response.Response{data=SkuResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /products/{id}/skus [post]
+func (h *Handler) CreateSku(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req CreateSkuRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	sku, err := h.service.CreateSku(c.Request.Context(), id, req)
+	if err != nil {
+		variantError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    0,
+		"message": "success",
+		"data":    sku,
+	})
+}
+
+// UpdateSku godoc
+// @Summary      Update SKU
+// @Description  Update the price, image, barcode or availability of a variant
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int               true  "Product ID"
+// @Param        sku_id   path      int               true  "SKU ID"
+// @Param        request  body      UpdateSkuRequest  true  "SKU fields to change"
+// @Success      200      {object}  response.Response{data=SkuResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /products/{id}/skus/{sku_id} [put]
+func (h *Handler) UpdateSku(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	skuID, err := strconv.ParseInt(c.Param("sku_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid sku id")
+		return
+	}
+
+	var req UpdateSkuRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	sku, err := h.service.UpdateSku(c.Request.Context(), id, skuID, req)
+	if err != nil {
+		variantError(c, err)
+		return
+	}
+
+	response.Success(c, sku)
+}
+
+// DeleteSku godoc
+// @Summary      Delete SKU
+// @Description  Retire a variant and its inventory record
+// @Tags         Products
+// @Produce      json
+// @Param        id      path      int  true  "Product ID"
+// @Param        sku_id  path      int  true  "SKU ID"
+// @Success      200     {object}  response.Response
+// @Failure      400     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Failure      500     {object}  response.Response
+// @Router       /products/{id}/skus/{sku_id} [delete]
+func (h *Handler) DeleteSku(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	skuID, err := strconv.ParseInt(c.Param("sku_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid sku id")
+		return
+	}
+
+	if err := h.service.DeleteSku(c.Request.Context(), id, skuID); err != nil {
+		variantError(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// variantError maps option and SKU errors to HTTP status codes
+func variantError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "product not found", "sku not found":
+		response.Error(c, http.StatusNotFound, err.Error())
+	case "options cannot be changed while the product has SKUs",
+		"a sku with these options already exists",
+		"sku code already exists",
+		"barcode already exists":
+		response.Error(c, http.StatusConflict, err.Error())
+	case "option names must be unique",
+		"option values must be unique within an option",
+		"define product options before adding SKUs",
+		"sku options must set exactly one value for every product option",
+		"sku options contain an unknown option or value":
+		response.Error(c, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// parseSpecFilters collects spec.<attribute> query parameters
+func parseSpecFilters(query url.Values) map[string][]string {
+	specs := make(map[string][]string)
+	for key, values := range query {
+		if name, ok := strings.CutPrefix(key, "spec."); ok && name != "" {
+			specs[name] = append(specs[name], values...)
+		}
+	}
+	return specs
+}
+
+// visitorID identifies an anonymous visitor by client IP and user agent, without storing either.
+// It only dedupes view counts; browsing history is keyed by the guest cookie instead.
+func visitorID(c *gin.Context) string {
+	return utils.VisitorID(c.ClientIP(), c.Request.UserAgent())
+}
@@ -0,0 +1,200 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"gomall/db/sqlc"
+	"gomall/internal/cache"
+)
+
+// Events that make a product hot
+const (
+	HotEventView = "view"
+	HotEventSale = "sale"
+)
+
+const defaultHotLimit = 10
+
+// hotRebuildHalfLives bounds how far back a rebuild reads; older events have decayed below 1/1024
+const hotRebuildHalfLives = 10
+
+// RecordHotEvent adds quantity units of an event for a product to every hot ranking: the overall
+// ranking and the product's category ranking of each configured window.
+// The category comes from the cached detail, so a counted page view does not read the product again.
+func (s *service) RecordHotEvent(ctx context.Context, productID int64, event string, quantity int32) error {
+	if s.ranker == nil || quantity <= 0 {
+		return nil
+	}
+
+	var weight float64
+	switch event {
+	case HotEventView:
+		weight = s.hot.ViewWeight
+	case HotEventSale:
+		weight = s.hot.SaleWeight
+	default:
+		return fmt.Errorf("unknown hot product event %q", event)
+	}
+
+	product, err := s.cachedProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	member := strconv.FormatInt(productID, 10)
+	for _, window := range s.hot.Windows {
+		keys := []string{
+			cache.CacheKeys.HotProductRanking(window, 0),
+			cache.CacheKeys.HotProductRanking(window, product.CategoryID),
+		}
+		if err := s.ranker.Add(ctx, keys, member, weight*float64(quantity), window); err != nil {
+			return fmt.Errorf("failed to record hot product event: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetHotProducts lists the most popular published products of a window, optionally within a category.
+// Lists are cached for HotProductTTL and dropped with the other product lists when a product changes.
+func (s *service) GetHotProducts(ctx context.Context, req HotProductsRequest) (*HotProductsResponse, error) {
+	if s.ranker == nil {
+		return nil, errors.New("hot products are unavailable")
+	}
+
+	window := s.hot.Windows[0]
+	if req.Window != "" {
+		parsed, err := time.ParseDuration(req.Window)
+		if err != nil || !slices.Contains(s.hot.Windows, parsed) {
+			return nil, errors.New("unsupported window")
+		}
+		window = parsed
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultHotLimit
+	}
+
+	load := func(ctx context.Context) (*HotProductsResponse, error) {
+		return s.loadHotProducts(ctx, window, req.CategoryID, limit)
+	}
+	generation, ok := s.listGeneration(ctx)
+	if !ok {
+		return load(ctx)
+	}
+	return s.hotLists.GetOrLoad(ctx, cache.CacheKeys.HotProducts(generation, window, req.CategoryID, limit), s.cacheCfg.HotProductTTL, load)
+}
+
+func (s *service) loadHotProducts(ctx context.Context, window time.Duration, categoryID int64, limit int) (*HotProductsResponse, error) {
+	// Read past the limit to make up for products unpublished or deleted since they were ranked
+	top, err := s.ranker.Top(ctx, cache.CacheKeys.HotProductRanking(window, categoryID), 2*limit, window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hot product ranking: %w", err)
+	}
+
+	productIDs := make([]int64, 0, len(top))
+	scores := make(map[int64]float64, len(top))
+	for _, member := range top {
+		productID, err := strconv.ParseInt(member.Member, 10, 64)
+		if err != nil {
+			continue
+		}
+		productIDs = append(productIDs, productID)
+		scores[productID] = member.Score
+	}
+
+	products, err := s.loadProductsInOrder(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	hot := make([]ProductResponse, 0, limit)
+	for _, p := range products {
+		if p.Status != "published" || len(hot) == limit {
+			continue
+		}
+		p.HotScore = scores[p.ID]
+		hot = append(hot, p)
+	}
+
+	return &HotProductsResponse{
+		Window:     window.String(),
+		CategoryID: categoryID,
+		Products:   hot,
+	}, nil
+}
+
+// MaintainHotRankings folds the decay since the last run into every hot ranking and drops products
+// that have cooled off. A window whose rankings are missing, because the cache was lost or a category
+// is new, is rebuilt from paid orders and carts; views are not stored with a time and cannot be replayed.
+// It returns how many windows were rebuilt.
+func (s *service) MaintainHotRankings(ctx context.Context) (int, error) {
+	if s.ranker == nil {
+		return 0, nil
+	}
+
+	categoryIDs, err := s.repo.ListProductCategoryIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list product categories: %w", err)
+	}
+
+	rebuilt := 0
+	for _, window := range s.hot.Windows {
+		complete := true
+		for _, categoryID := range append([]int64{0}, categoryIDs...) {
+			exists, err := s.ranker.Rescale(ctx, cache.CacheKeys.HotProductRanking(window, categoryID), window, s.hot.MinScore)
+			if err != nil {
+				return rebuilt, fmt.Errorf("failed to rescale hot product ranking: %w", err)
+			}
+			complete = complete && exists
+		}
+		if complete {
+			continue
+		}
+
+		if err := s.rebuildHotRankings(ctx, window, categoryIDs); err != nil {
+			return rebuilt, err
+		}
+		rebuilt++
+	}
+	return rebuilt, nil
+}
+
+// rebuildHotRankings replaces the rankings of a window with scores computed from the database
+func (s *service) rebuildHotRankings(ctx context.Context, window time.Duration, categoryIDs []int64) error {
+	rows, err := s.repo.GetHotProductActivity(ctx, sqlc.GetHotProductActivityParams{
+		HalfLifeSeconds: window.Seconds(),
+		Since:           time.Now().Add(-hotRebuildHalfLives * window),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get hot product activity: %w", err)
+	}
+
+	rankings := make(map[int64]map[string]float64, len(categoryIDs)+1)
+	rankings[0] = make(map[string]float64)
+	for _, categoryID := range categoryIDs {
+		rankings[categoryID] = make(map[string]float64)
+	}
+	for _, row := range rows {
+		score := row.SalesScore * s.hot.SaleWeight
+		if score < s.hot.MinScore || score <= 0 {
+			continue
+		}
+		member := strconv.FormatInt(row.ProductID, 10)
+		if rankings[row.CategoryID] == nil {
+			rankings[row.CategoryID] = make(map[string]float64)
+		}
+		rankings[row.CategoryID][member] = score
+		rankings[0][member] = score
+	}
+
+	for categoryID, scores := range rankings {
+		if err := s.ranker.Replace(ctx, cache.CacheKeys.HotProductRanking(window, categoryID), scores); err != nil {
+			return fmt.Errorf("failed to rebuild hot product ranking: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+)
+
+// UploadProductImage stores an uploaded image and its thumbnails, then records every rendition.
+// Each upload gets its own key prefix, so stored objects are never overwritten.
+func (s *service) UploadProductImage(ctx context.Context, productID int64, data []byte, req UploadImageRequest) (*ImageResponse, error) {
+	if s.blob == nil {
+		return nil, errors.New("image storage is not configured")
+	}
+
+	if _, err := s.repo.GetProductByID(ctx, productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("product not found")
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	renditions, err := s.imaging.Process(data)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("products/%d/%s/", productID, uuid.NewString())
+	keys := make([]string, 0, len(renditions))
+	for _, rendition := range renditions {
+		key := prefix + rendition.Name + rendition.Ext
+		if err := s.blob.Put(ctx, key, bytes.NewReader(rendition.Data), int64(len(rendition.Data)), rendition.ContentType); err != nil {
+			s.deleteBlobs(keys)
+			return nil, fmt.Errorf("failed to store image: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	var original sqlc.ProductImage
+	var thumbnails []sqlc.ProductImage
+	err = s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		if req.IsMain {
+			if err := unsetMainImages(ctx, q, productID); err != nil {
+				return err
+			}
+		}
+
+		for i, rendition := range renditions {
+			params := sqlc.CreateUploadedProductImageParams{
+				ProductID:   productID,
+				Rendition:   rendition.Name,
+				ImageUrl:    s.blob.URL(keys[i]),
+				StorageKey:  &keys[i],
+				ContentType: &renditions[i].ContentType,
+				Width:       utils.Ptr(int32(rendition.Width)),
+				Height:      utils.Ptr(int32(rendition.Height)),
+				SizeBytes:   utils.Ptr(int64(len(rendition.Data))),
+				Sort:        &req.Sort,
+				IsMain:      utils.Ptr(req.IsMain && i == 0),
+			}
+			if i > 0 {
+				params.ParentID = &original.ID
+			}
+
+			image, err := q.CreateUploadedProductImage(ctx, params)
+			if err != nil {
+				return fmt.Errorf("failed to create product image: %w", err)
+			}
+			if i == 0 {
+				original = image
+			} else {
+				thumbnails = append(thumbnails, image)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.deleteBlobs(keys)
+		return nil, err
+	}
+
+	s.invalidateProduct(ctx, productID)
+
+	response := []ImageResponse{toImageResponse(original)}
+	attachRenditions(response, thumbnails)
+	return &response[0], nil
+}
+
+// unsetMainImages clears the main flag on all of a product's images
+func unsetMainImages(ctx context.Context, q sqlc.Querier, productID int64) error {
+	images, err := q.GetProductImages(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to get product images: %w", err)
+	}
+
+	for _, img := range images {
+		if utils.PtrValue(img.IsMain) {
+			err := q.UpdateProductImage(ctx, sqlc.UpdateProductImageParams{
+				ID:     img.ID,
+				IsMain: utils.Ptr(false),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to unset main image: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteBlobs removes objects stored for an upload that failed; failures only leave orphans, so they are logged
+func (s *service) deleteBlobs(keys []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, key := range keys {
+		if err := s.blob.Delete(ctx, key); err != nil {
+			log.Printf("failed to clean up uploaded image: %v", err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"gomall/internal/cache"
+)
+
+const defaultRecentlyViewedLimit = 20
+
+// RecordRecentView puts a product at the front of a browsing history: the user's when userID is set,
+// otherwise the guest's, which MergeGuestHistory folds into the user's on login.
+func (s *service) RecordRecentView(ctx context.Context, productID, userID int64, guestID string) error {
+	if s.recents == nil {
+		return nil
+	}
+
+	var key string
+	switch {
+	case userID > 0:
+		key = cache.CacheKeys.UserRecentlyViewed(userID)
+	case guestID != "":
+		key = cache.CacheKeys.GuestRecentlyViewed(guestID)
+	default:
+		return nil
+	}
+	if err := s.recents.Push(ctx, key, strconv.FormatInt(productID, 10)); err != nil {
+		return fmt.Errorf("failed to record recently viewed product: %w", err)
+	}
+	return nil
+}
+
+// GetRecentlyViewed lists the distinct products a user opened last, most recent first.
+// Deleted products are dropped from the history; unpublished ones are skipped but kept,
+// so they reappear if published again.
+func (s *service) GetRecentlyViewed(ctx context.Context, userID int64, req RecentlyViewedRequest) (*RecentlyViewedResponse, error) {
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultRecentlyViewedLimit
+	}
+	if s.recents == nil {
+		return &RecentlyViewedResponse{Products: []ProductResponse{}}, nil
+	}
+
+	key := cache.CacheKeys.UserRecentlyViewed(userID)
+	members, err := s.recents.Members(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recently viewed products: %w", err)
+	}
+
+	productIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		productID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		productIDs = append(productIDs, productID)
+	}
+
+	products, err := s.loadProductsInOrder(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[int64]bool, len(products))
+	recent := make([]ProductResponse, 0, limit)
+	for _, p := range products {
+		found[p.ID] = true
+		if p.Status != "published" || len(recent) == limit {
+			continue
+		}
+		recent = append(recent, p)
+	}
+
+	var gone []string
+	for _, id := range productIDs {
+		if !found[id] {
+			gone = append(gone, strconv.FormatInt(id, 10))
+		}
+	}
+	if err := s.recents.Remove(ctx, key, gone...); err != nil {
+		return nil, fmt.Errorf("failed to prune recently viewed products: %w", err)
+	}
+
+	return &RecentlyViewedResponse{Products: recent}, nil
+}
+
+// MergeGuestHistory moves what a guest browsed before logging in into the user's history.
+// Products in both keep the later view.
+func (s *service) MergeGuestHistory(ctx context.Context, guestID string, userID int64) error {
+	if s.recents == nil || guestID == "" {
+		return nil
+	}
+	err := s.recents.Merge(ctx, cache.CacheKeys.UserRecentlyViewed(userID), cache.CacheKeys.GuestRecentlyViewed(guestID))
+	if err != nil {
+		return fmt.Errorf("failed to merge guest browsing history: %w", err)
+	}
+	return nil
+}
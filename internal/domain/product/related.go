@@ -0,0 +1,124 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+)
+
+const defaultRelatedLimit = 10
+
+// GetRelatedProducts lists the products most often bought in the same order as a product, topped up
+// with best sellers of its category when there are too few. Associations come from the table
+// RefreshRelatedProducts fills; unpublished and out-of-stock products are skipped when reading.
+func (s *service) GetRelatedProducts(ctx context.Context, productID int64, req RelatedProductsRequest) (*RelatedProductsResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultRelatedLimit
+	}
+
+	product, err := s.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	alsoBought, err := s.repo.ListAlsoBoughtProducts(ctx, sqlc.ListAlsoBoughtProductsParams{
+		ProductID: productID,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get related products: %w", err)
+	}
+
+	products := make([]sqlc.Product, 0, limit)
+	related := make([]RelatedProductResponse, 0, limit)
+	excludeIDs := []int64{productID}
+	for _, row := range alsoBought {
+		products = append(products, row.Product)
+		related = append(related, RelatedProductResponse{
+			Reason:     RelatedAlsoBought,
+			Support:    row.Support,
+			Confidence: row.Confidence,
+		})
+		excludeIDs = append(excludeIDs, row.Product.ID)
+	}
+
+	if missing := limit - int32(len(alsoBought)); missing > 0 {
+		bestSellers, err := s.repo.ListCategoryBestSellers(ctx, sqlc.ListCategoryBestSellersParams{
+			CategoryID: product.CategoryID,
+			ExcludeIds: excludeIDs,
+			RowLimit:   missing,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category best sellers: %w", err)
+		}
+		for _, p := range bestSellers {
+			products = append(products, p)
+			related = append(related, RelatedProductResponse{Reason: RelatedCategoryBestSeller})
+		}
+	}
+
+	// Batch load main images
+	mainImages := make(map[int64]string)
+	if len(products) > 0 {
+		productIDs := make([]int64, len(products))
+		for i, p := range products {
+			productIDs[i] = p.ID
+		}
+		images, err := s.repo.GetImagesByProductIDs(ctx, productIDs)
+		if err == nil {
+			for _, img := range images {
+				if utils.PtrValue(img.IsMain) {
+					mainImages[img.ProductID] = img.ImageUrl
+				}
+			}
+		}
+	}
+	for i, p := range products {
+		related[i].ProductResponse = toProductResponse(p, mainImages[p.ID])
+	}
+
+	return &RelatedProductsResponse{
+		ProductID: productID,
+		Products:  related,
+	}, nil
+}
+
+// RefreshRelatedProducts recomputes every product association from the orders placed within the
+// lookback, keeping pairs that meet the support and confidence thresholds. The table is replaced in
+// one transaction, so readers see either the old or the new associations. It returns how many were stored.
+func (s *service) RefreshRelatedProducts(ctx context.Context) (int64, error) {
+	lookback := s.related.Lookback
+	if lookback <= 0 {
+		lookback = 90 * 24 * time.Hour
+	}
+	params := sqlc.InsertProductAssociationsParams{
+		Since:         time.Now().Add(-lookback),
+		MinSupport:    max(s.related.MinSupport, 1),
+		MinConfidence: s.related.MinConfidence,
+		MaxPerProduct: s.related.MaxPerProduct,
+	}
+	if params.MaxPerProduct <= 0 {
+		params.MaxPerProduct = 50
+	}
+
+	var stored int64
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		if err := q.DeleteProductAssociations(ctx); err != nil {
+			return fmt.Errorf("failed to clear product associations: %w", err)
+		}
+		n, err := q.InsertProductAssociations(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to compute product associations: %w", err)
+		}
+		stored = n
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return stored, nil
+}
@@ -0,0 +1,104 @@
+package product
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+
+	"gomall/db/sqlc"
+	"gomall/internal/cache"
+	"gomall/internal/config"
+)
+
+// relatedRepo serves canned associations and best sellers, recording the best seller query
+type relatedRepo struct {
+	Repository
+	alsoBought  []sqlc.ListAlsoBoughtProductsRow
+	bestSellers []sqlc.Product
+	bestQuery   *sqlc.ListCategoryBestSellersParams
+}
+
+func (r *relatedRepo) ListAlsoBoughtProducts(ctx context.Context, arg sqlc.ListAlsoBoughtProductsParams) ([]sqlc.ListAlsoBoughtProductsRow, error) {
+	return r.alsoBought[:min(len(r.alsoBought), int(arg.Limit))], nil
+}
+
+func (r *relatedRepo) ListCategoryBestSellers(ctx context.Context, arg sqlc.ListCategoryBestSellersParams) ([]sqlc.Product, error) {
+	r.bestQuery = &arg
+	return r.bestSellers[:min(len(r.bestSellers), int(arg.RowLimit))], nil
+}
+
+func (r *relatedRepo) GetProductByID(ctx context.Context, id int64) (sqlc.Product, error) {
+	return sqlc.Product{}, pgx.ErrNoRows
+}
+
+func (r *relatedRepo) GetProductLiveFields(ctx context.Context, id int64) (sqlc.GetProductLiveFieldsRow, error) {
+	return sqlc.GetProductLiveFieldsRow{}, nil
+}
+
+func (r *relatedRepo) GetImagesByProductIDs(ctx context.Context, productIDs []int64) ([]sqlc.ProductImage, error) {
+	return nil, nil
+}
+
+func newRelatedTestService(t *testing.T, repo Repository) *service {
+	memory := cache.NewMemoryCache(cache.MemoryConfig{})
+	s := &service{
+		repo:     repo,
+		cache:    memory,
+		cacheCfg: config.CacheConfig{ProductTTL: time.Minute},
+		details:  cache.NewTyped[*ProductDetailResponse](memory, cache.TypedOptions{}),
+	}
+	// The viewed product's detail comes from the cache, so the repository only serves its live fields
+	require.NoError(t, s.details.Set(context.Background(), cache.CacheKeys.Product(10), &ProductDetailResponse{ID: 10, CategoryID: 3}, time.Minute))
+	return s
+}
+
+func TestGetRelatedProductsFallsBackToBestSellers(t *testing.T) {
+	repo := &relatedRepo{
+		alsoBought: []sqlc.ListAlsoBoughtProductsRow{
+			{Product: sqlc.Product{ID: 11, CategoryID: 5}, Support: 4, Confidence: 0.5},
+		},
+		bestSellers: []sqlc.Product{{ID: 20, CategoryID: 3}, {ID: 21, CategoryID: 3}, {ID: 22, CategoryID: 3}},
+	}
+	s := newRelatedTestService(t, repo)
+
+	related, err := s.GetRelatedProducts(context.Background(), 10, RelatedProductsRequest{Limit: 3})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), related.ProductID)
+	require.Len(t, related.Products, 3)
+
+	require.Equal(t, int64(11), related.Products[0].ID)
+	require.Equal(t, RelatedAlsoBought, related.Products[0].Reason)
+	require.Equal(t, int32(4), related.Products[0].Support)
+	require.Equal(t, 0.5, related.Products[0].Confidence)
+	for _, p := range related.Products[1:] {
+		require.Equal(t, RelatedCategoryBestSeller, p.Reason)
+		require.Zero(t, p.Support)
+	}
+
+	// Best sellers come from the viewed product's category and skip what is already listed
+	require.NotNil(t, repo.bestQuery)
+	require.Equal(t, int64(3), repo.bestQuery.CategoryID)
+	require.Equal(t, []int64{10, 11}, repo.bestQuery.ExcludeIds)
+	require.Equal(t, int32(2), repo.bestQuery.RowLimit)
+}
+
+func TestGetRelatedProductsSkipsFallbackWhenFull(t *testing.T) {
+	repo := &relatedRepo{
+		alsoBought: []sqlc.ListAlsoBoughtProductsRow{
+			{Product: sqlc.Product{ID: 11}, Support: 4, Confidence: 0.5},
+			{Product: sqlc.Product{ID: 12}, Support: 2, Confidence: 0.25},
+		},
+	}
+	s := newRelatedTestService(t, repo)
+
+	related, err := s.GetRelatedProducts(context.Background(), 10, RelatedProductsRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, related.Products, 2)
+	require.Nil(t, repo.bestQuery)
+
+	_, err = s.GetRelatedProducts(context.Background(), 99, RelatedProductsRequest{})
+	require.EqualError(t, err, "product not found")
+}
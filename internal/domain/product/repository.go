@@ -17,13 +17,17 @@ type Repository interface {
 	UpdateProduct(ctx context.Context, arg sqlc.UpdateProductParams) error
 	DeleteProduct(ctx context.Context, id int64) error
 
-	// ListProducts Product listing and search
-	ListProducts(ctx context.Context, arg sqlc.ListProductsParams) ([]sqlc.Product, error)
+	// ListProductsByCategory Product listing
 	ListProductsByCategory(ctx context.Context, arg sqlc.ListProductsByCategoryParams) ([]sqlc.Product, error)
 	ListFeaturedProducts(ctx context.Context, arg sqlc.ListFeaturedProductsParams) ([]sqlc.Product, error)
 	ListProductsByPriceRange(ctx context.Context, arg sqlc.ListProductsByPriceRangeParams) ([]sqlc.Product, error)
-	SearchProducts(ctx context.Context, arg sqlc.SearchProductsParams) ([]sqlc.Product, error)
-	CountProducts(ctx context.Context) (int64, error)
+	ListProductsForIndex(ctx context.Context, arg sqlc.ListProductsForIndexParams) ([]sqlc.Product, error)
+
+	// SuggestProductNames Typeahead and spelling suggestions
+	SuggestProductNames(ctx context.Context, arg sqlc.SuggestProductNamesParams) ([]sqlc.SuggestProductNamesRow, error)
+	SuggestBrands(ctx context.Context, arg sqlc.SuggestBrandsParams) ([]sqlc.SuggestBrandsRow, error)
+	SuggestCategories(ctx context.Context, arg sqlc.SuggestCategoriesParams) ([]sqlc.SuggestCategoriesRow, error)
+	ListSpellingSuggestions(ctx context.Context, arg sqlc.ListSpellingSuggestionsParams) ([]sqlc.ListSpellingSuggestionsRow, error)
 
 	// UpdateProductStock Stock management operations
 	UpdateProductStock(ctx context.Context, arg sqlc.UpdateProductStockParams) error
@@ -31,22 +35,39 @@ type Repository interface {
 	UpdateProductStockWithVersion(ctx context.Context, arg sqlc.UpdateProductStockWithVersionParams) (sqlc.Product, error)
 	GetLowStockProducts(ctx context.Context, arg sqlc.GetLowStockProductsParams) ([]sqlc.Product, error)
 
-	// IncrementProductViews Statistics operations
-	IncrementProductViews(ctx context.Context, id int64) error
+	// AddProductViews Statistics operations
+	AddProductViews(ctx context.Context, arg sqlc.AddProductViewsParams) error
 	IncrementProductSales(ctx context.Context, arg sqlc.IncrementProductSalesParams) error
+	GetHotProductActivity(ctx context.Context, arg sqlc.GetHotProductActivityParams) ([]sqlc.GetHotProductActivityRow, error)
+	ListProductCategoryIDs(ctx context.Context) ([]int64, error)
+	ListAlsoBoughtProducts(ctx context.Context, arg sqlc.ListAlsoBoughtProductsParams) ([]sqlc.ListAlsoBoughtProductsRow, error)
+	ListCategoryBestSellers(ctx context.Context, arg sqlc.ListCategoryBestSellersParams) ([]sqlc.Product, error)
 
 	// UpdateProductsStatus Batch operations
 	UpdateProductsStatus(ctx context.Context, arg sqlc.UpdateProductsStatusParams) error
 
 	// CreateProductImage Product image operations
 	CreateProductImage(ctx context.Context, arg sqlc.CreateProductImageParams) (sqlc.ProductImage, error)
+	GetProductImage(ctx context.Context, id int64) (sqlc.ProductImage, error)
 	GetProductImages(ctx context.Context, productID int64) ([]sqlc.ProductImage, error)
+	GetProductImageRenditions(ctx context.Context, productID int64) ([]sqlc.ProductImage, error)
 	GetProductMainImage(ctx context.Context, productID int64) (sqlc.ProductImage, error)
 	GetImagesByProductIDs(ctx context.Context, productIDs []int64) ([]sqlc.ProductImage, error)
 	UpdateProductImage(ctx context.Context, arg sqlc.UpdateProductImageParams) error
 	DeleteProductImage(ctx context.Context, id int64) error
 	DeleteProductImages(ctx context.Context, productID int64) error
 
+	// ListProductOptionValues Variant operations
+	ListProductOptionValues(ctx context.Context, productID int64) ([]sqlc.ListProductOptionValuesRow, error)
+	GetProductSku(ctx context.Context, id int64) (sqlc.ProductSku, error)
+	GetConflictingProductSku(ctx context.Context, arg sqlc.GetConflictingProductSkuParams) (sqlc.ProductSku, error)
+	ListProductSkus(ctx context.Context, productID int64) ([]sqlc.ListProductSkusRow, error)
+	ListProductSkuStock(ctx context.Context, productID int64) ([]sqlc.ListProductSkuStockRow, error)
+	GetProductLiveFields(ctx context.Context, id int64) (sqlc.GetProductLiveFieldsRow, error)
+	ListActiveSkusByProductIDs(ctx context.Context, productIDs []int64) ([]sqlc.ProductSku, error)
+	CountProductSkus(ctx context.Context, productID int64) (int64, error)
+	UpdateProductSku(ctx context.Context, arg sqlc.UpdateProductSkuParams) (sqlc.ProductSku, error)
+
 	// ExecTx Transaction support
 	ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error
 }
@@ -85,11 +106,7 @@ func (r *repository) DeleteProduct(ctx context.Context, id int64) error {
 	return r.store.DeleteProduct(ctx, id)
 }
 
-// Product listing and search
-
-func (r *repository) ListProducts(ctx context.Context, arg sqlc.ListProductsParams) ([]sqlc.Product, error) {
-	return r.store.ListProducts(ctx, arg)
-}
+// Product listing
 
 func (r *repository) ListProductsByCategory(ctx context.Context, arg sqlc.ListProductsByCategoryParams) ([]sqlc.Product, error) {
 	return r.store.ListProductsByCategory(ctx, arg)
@@ -103,12 +120,24 @@ func (r *repository) ListProductsByPriceRange(ctx context.Context, arg sqlc.List
 	return r.store.ListProductsByPriceRange(ctx, arg)
 }
 
-func (r *repository) SearchProducts(ctx context.Context, arg sqlc.SearchProductsParams) ([]sqlc.Product, error) {
-	return r.store.SearchProducts(ctx, arg)
+func (r *repository) ListProductsForIndex(ctx context.Context, arg sqlc.ListProductsForIndexParams) ([]sqlc.Product, error) {
+	return r.store.ListProductsForIndex(ctx, arg)
+}
+
+func (r *repository) SuggestProductNames(ctx context.Context, arg sqlc.SuggestProductNamesParams) ([]sqlc.SuggestProductNamesRow, error) {
+	return r.store.SuggestProductNames(ctx, arg)
 }
 
-func (r *repository) CountProducts(ctx context.Context) (int64, error) {
-	return r.store.CountProducts(ctx)
+func (r *repository) SuggestBrands(ctx context.Context, arg sqlc.SuggestBrandsParams) ([]sqlc.SuggestBrandsRow, error) {
+	return r.store.SuggestBrands(ctx, arg)
+}
+
+func (r *repository) SuggestCategories(ctx context.Context, arg sqlc.SuggestCategoriesParams) ([]sqlc.SuggestCategoriesRow, error) {
+	return r.store.SuggestCategories(ctx, arg)
+}
+
+func (r *repository) ListSpellingSuggestions(ctx context.Context, arg sqlc.ListSpellingSuggestionsParams) ([]sqlc.ListSpellingSuggestionsRow, error) {
+	return r.store.ListSpellingSuggestions(ctx, arg)
 }
 
 // Stock management operations
@@ -131,14 +160,30 @@ func (r *repository) GetLowStockProducts(ctx context.Context, arg sqlc.GetLowSto
 
 // Statistics operations
 
-func (r *repository) IncrementProductViews(ctx context.Context, id int64) error {
-	return r.store.IncrementProductViews(ctx, id)
+func (r *repository) AddProductViews(ctx context.Context, arg sqlc.AddProductViewsParams) error {
+	return r.store.AddProductViews(ctx, arg)
 }
 
 func (r *repository) IncrementProductSales(ctx context.Context, arg sqlc.IncrementProductSalesParams) error {
 	return r.store.IncrementProductSales(ctx, arg)
 }
 
+func (r *repository) GetHotProductActivity(ctx context.Context, arg sqlc.GetHotProductActivityParams) ([]sqlc.GetHotProductActivityRow, error) {
+	return r.store.GetHotProductActivity(ctx, arg)
+}
+
+func (r *repository) ListProductCategoryIDs(ctx context.Context) ([]int64, error) {
+	return r.store.ListProductCategoryIDs(ctx)
+}
+
+func (r *repository) ListAlsoBoughtProducts(ctx context.Context, arg sqlc.ListAlsoBoughtProductsParams) ([]sqlc.ListAlsoBoughtProductsRow, error) {
+	return r.store.ListAlsoBoughtProducts(ctx, arg)
+}
+
+func (r *repository) ListCategoryBestSellers(ctx context.Context, arg sqlc.ListCategoryBestSellersParams) ([]sqlc.Product, error) {
+	return r.store.ListCategoryBestSellers(ctx, arg)
+}
+
 // Batch operations
 
 func (r *repository) UpdateProductsStatus(ctx context.Context, arg sqlc.UpdateProductsStatusParams) error {
@@ -151,6 +196,10 @@ func (r *repository) CreateProductImage(ctx context.Context, arg sqlc.CreateProd
 	return r.store.CreateProductImage(ctx, arg)
 }
 
+func (r *repository) GetProductImage(ctx context.Context, id int64) (sqlc.ProductImage, error) {
+	return r.store.GetProductImage(ctx, id)
+}
+
 func (r *repository) GetProductImages(ctx context.Context, productID int64) ([]sqlc.ProductImage, error) {
 	return r.store.GetProductImages(ctx, productID)
 }
@@ -159,6 +208,10 @@ func (r *repository) GetProductMainImage(ctx context.Context, productID int64) (
 	return r.store.GetProductMainImage(ctx, productID)
 }
 
+func (r *repository) GetProductImageRenditions(ctx context.Context, productID int64) ([]sqlc.ProductImage, error) {
+	return r.store.GetProductImageRenditions(ctx, productID)
+}
+
 func (r *repository) GetImagesByProductIDs(ctx context.Context, productIDs []int64) ([]sqlc.ProductImage, error) {
 	return r.store.GetImagesByProductIDs(ctx, productIDs)
 }
@@ -175,6 +228,44 @@ func (r *repository) DeleteProductImages(ctx context.Context, productID int64) e
 	return r.store.DeleteProductImages(ctx, productID)
 }
 
+// Variant operations
+
+func (r *repository) ListProductOptionValues(ctx context.Context, productID int64) ([]sqlc.ListProductOptionValuesRow, error) {
+	return r.store.ListProductOptionValues(ctx, productID)
+}
+
+func (r *repository) GetProductSku(ctx context.Context, id int64) (sqlc.ProductSku, error) {
+	return r.store.GetProductSku(ctx, id)
+}
+
+func (r *repository) GetConflictingProductSku(ctx context.Context, arg sqlc.GetConflictingProductSkuParams) (sqlc.ProductSku, error) {
+	return r.store.GetConflictingProductSku(ctx, arg)
+}
+
+func (r *repository) ListProductSkus(ctx context.Context, productID int64) ([]sqlc.ListProductSkusRow, error) {
+	return r.store.ListProductSkus(ctx, productID)
+}
+
+func (r *repository) ListProductSkuStock(ctx context.Context, productID int64) ([]sqlc.ListProductSkuStockRow, error) {
+	return r.store.ListProductSkuStock(ctx, productID)
+}
+
+func (r *repository) GetProductLiveFields(ctx context.Context, id int64) (sqlc.GetProductLiveFieldsRow, error) {
+	return r.store.GetProductLiveFields(ctx, id)
+}
+
+func (r *repository) ListActiveSkusByProductIDs(ctx context.Context, productIDs []int64) ([]sqlc.ProductSku, error) {
+	return r.store.ListActiveSkusByProductIDs(ctx, productIDs)
+}
+
+func (r *repository) CountProductSkus(ctx context.Context, productID int64) (int64, error) {
+	return r.store.CountProductSkus(ctx, productID)
+}
+
+func (r *repository) UpdateProductSku(ctx context.Context, arg sqlc.UpdateProductSkuParams) (sqlc.ProductSku, error) {
+	return r.store.UpdateProductSku(ctx, arg)
+}
+
 // Transaction support
 
 func (r *repository) ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error {
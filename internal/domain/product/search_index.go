@@ -0,0 +1,132 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"gomall/db/sqlc"
+	"gomall/internal/search"
+	"gomall/utils"
+)
+
+// reindexBatchSize is the number of products read per page during a full reindex
+const reindexBatchSize = 500
+
+// toSearchDocument builds the searchable view of a product
+func toSearchDocument(product sqlc.Product) search.Document {
+	return search.Document{
+		ID:          product.ID,
+		Name:        product.Name,
+		Brand:       utils.PtrValue(product.Brand),
+		Description: utils.PtrValue(product.Description),
+		CategoryID:  product.CategoryID,
+		Status:      product.Status,
+		Price:       product.Price,
+		SalesCount:  product.SalesCount,
+		Specs:       search.SpecsFromJSON(product.Specifications),
+		CreatedAt:   product.CreatedAt,
+	}
+}
+
+// indexProduct feeds a changed product to the search index.
+// The product write has already committed, so a failure is logged and repaired by the next reindex.
+func (s *service) indexProduct(ctx context.Context, product sqlc.Product) {
+	if err := s.index.Index(ctx, toSearchDocument(product)); err != nil {
+		log.Printf("failed to index product %d: %v", product.ID, err)
+	}
+}
+
+// unindexProduct removes a deleted product from the search index
+func (s *service) unindexProduct(ctx context.Context, productID int64) {
+	if err := s.index.Delete(ctx, productID); err != nil {
+		log.Printf("failed to remove product %d from search index: %v", productID, err)
+	}
+}
+
+// ReindexSearch rebuilds the search index from every product that is not deleted
+func (s *service) ReindexSearch(ctx context.Context) (int, error) {
+	var docs []search.Document
+	var afterID int64
+
+	for {
+		products, err := s.repo.ListProductsForIndex(ctx, sqlc.ListProductsForIndexParams{
+			AfterID:   afterID,
+			BatchSize: reindexBatchSize,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list products for reindex: %w", err)
+		}
+
+		for _, product := range products {
+			docs = append(docs, toSearchDocument(product))
+		}
+		if len(products) < reindexBatchSize {
+			break
+		}
+		afterID = products[len(products)-1].ID
+	}
+
+	if err := s.index.Rebuild(ctx, docs); err != nil {
+		return 0, fmt.Errorf("failed to rebuild search index: %w", err)
+	}
+	return len(docs), nil
+}
+
+// loadSearchHits loads the products behind search hits in hit order, with main images and rank.
+// Hits for products deleted since they were indexed are skipped.
+func (s *service) loadSearchHits(ctx context.Context, hits []search.Hit) ([]ProductResponse, error) {
+	productIDs := make([]int64, len(hits))
+	ranks := make(map[int64]float32, len(hits))
+	for i, hit := range hits {
+		productIDs[i] = hit.ID
+		ranks[hit.ID] = hit.Score
+	}
+
+	productResponses, err := s.loadProductsInOrder(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range productResponses {
+		productResponses[i].Rank = ranks[productResponses[i].ID]
+	}
+	return productResponses, nil
+}
+
+// loadProductsInOrder loads products with their main images in the order of productIDs,
+// skipping deleted ones
+func (s *service) loadProductsInOrder(ctx context.Context, productIDs []int64) ([]ProductResponse, error) {
+	if len(productIDs) == 0 {
+		return []ProductResponse{}, nil
+	}
+
+	products, err := s.repo.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	byID := make(map[int64]sqlc.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+
+	// Batch load main images
+	mainImages := make(map[int64]string)
+	images, err := s.repo.GetImagesByProductIDs(ctx, productIDs)
+	if err == nil {
+		for _, img := range images {
+			if utils.PtrValue(img.IsMain) {
+				mainImages[img.ProductID] = img.ImageUrl
+			}
+		}
+	}
+
+	productResponses := make([]ProductResponse, 0, len(productIDs))
+	for _, id := range productIDs {
+		p, ok := byID[id]
+		if !ok {
+			continue
+		}
+		productResponses = append(productResponses, toProductResponse(p, mainImages[p.ID]))
+	}
+	return productResponses, nil
+}
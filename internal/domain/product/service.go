@@ -2,11 +2,19 @@ package product
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
 
 	"gomall/db/sqlc"
+	"gomall/internal/cache"
+	"gomall/internal/config"
+	"gomall/internal/imaging"
+	"gomall/internal/search"
+	"gomall/internal/storage"
 	"gomall/utils"
 )
 
@@ -21,6 +29,8 @@ type Service interface {
 
 	// SearchProducts Search and filtering
 	SearchProducts(ctx context.Context, req SearchProductsRequest) (*PaginatedProductsResponse, error)
+	Suggest(ctx context.Context, req SuggestRequest) (*SuggestResponse, error)
+	ReindexSearch(ctx context.Context) (int, error)
 	GetFeaturedProducts(ctx context.Context, page, pageSize int32) (*PaginatedProductsResponse, error)
 	GetProductsByIDs(ctx context.Context,productIDs []int64) (map[int64]*ProductResponse,error)
 	GetProductsByCategory(ctx context.Context, categoryID int64, page, pageSize int32) (*PaginatedProductsResponse, error)
@@ -35,25 +45,99 @@ type Service interface {
 	AddProductImages(ctx context.Context, productID int64, images []ImageRequest) error
 	SetMainImage(ctx context.Context, productID, imageID int64) error
 	DeleteProductImage(ctx context.Context, imageID int64) error
-
-	// IncrementViews Statistics
-	IncrementViews(ctx context.Context, productID int64) error
+	UploadProductImage(ctx context.Context, productID int64, data []byte, req UploadImageRequest) (*ImageResponse, error)
+
+	// View statistics
+	RecordView(ctx context.Context, productID int64, visitor string) error
+	FlushViews(ctx context.Context) (int, error)
+
+	// Hot products
+	RecordHotEvent(ctx context.Context, productID int64, event string, quantity int32) error
+	GetHotProducts(ctx context.Context, req HotProductsRequest) (*HotProductsResponse, error)
+	MaintainHotRankings(ctx context.Context) (int, error)
+
+	// Related products ("customers also bought")
+	GetRelatedProducts(ctx context.Context, productID int64, req RelatedProductsRequest) (*RelatedProductsResponse, error)
+	RefreshRelatedProducts(ctx context.Context) (int64, error)
+
+	// Recently viewed products
+	RecordRecentView(ctx context.Context, productID, userID int64, guestID string) error
+	GetRecentlyViewed(ctx context.Context, userID int64, req RecentlyViewedRequest) (*RecentlyViewedResponse, error)
+	MergeGuestHistory(ctx context.Context, guestID string, userID int64) error
+
+	// SetProductOptions Variant management
+	SetProductOptions(ctx context.Context, productID int64, req SetProductOptionsRequest) ([]ProductOptionResponse, error)
+	CreateSku(ctx context.Context, productID int64, req CreateSkuRequest) (*SkuResponse, error)
+	UpdateSku(ctx context.Context, productID, skuID int64, req UpdateSkuRequest) (*SkuResponse, error)
+	DeleteSku(ctx context.Context, productID, skuID int64) error
+	GetActiveSkusByProductIDs(ctx context.Context, productIDs []int64) (map[int64][]SkuResponse, error)
 }
 
 type service struct {
-	repo Repository
+	repo         Repository
+	cache        cache.Cache
+	cacheCfg     config.CacheConfig
+	details      *cache.Typed[*ProductDetailResponse]
+	pages        *cache.Typed[*PaginatedProductsResponse]
+	suggestions  *cache.Typed[*SuggestResponse]
+	hotLists     *cache.Typed[*HotProductsResponse]
+	ranker       *cache.Ranker
+	recents      *cache.RecentList
+	hot          config.HotProductsConfig
+	related      config.RelatedProductsConfig
+	index        search.Index
+	search       config.SearchConfig
+	priceBuckets []int64
+	blob         storage.Blob
+	imaging      *imaging.Processor
 }
 
 // NewService creates a new Service instance
-func NewService(repo Repository) Service {
+// blob may be nil when image uploads are not needed, e.g. in offline tools.
+// Hot product rankings and recently viewed histories need a cache that supports them and are off otherwise.
+func NewService(repo Repository, cacheClient cache.Cache, cacheCfg config.CacheConfig, index search.Index, searchCfg config.SearchConfig, blob storage.Blob, imageCfg config.ImageConfig, hotCfg config.HotProductsConfig, relatedCfg config.RelatedProductsConfig) Service {
+	priceBuckets := slices.Sorted(slices.Values(searchCfg.PriceBuckets))
+	if len(priceBuckets) == 0 {
+		priceBuckets = defaultPriceBuckets
+	}
+	typedOpts := cache.TypedOptions{
+		Codec:       cache.CodecByName(cacheCfg.Codec),
+		Jitter:      cacheCfg.TTLJitter,
+		NotFoundTTL: cacheCfg.NotFoundTTL,
+	}
+	if len(hotCfg.Windows) == 0 {
+		hotCfg.Windows = []time.Duration{24 * time.Hour}
+	}
+	ranker, _ := cache.NewRanker(cacheClient)
+	recentSize := cacheCfg.RecentlyViewedSize
+	if recentSize <= 0 {
+		recentSize = 50
+	}
+	recents, _ := cache.NewRecentList(cacheClient, recentSize, cacheCfg.RecentlyViewedTTL)
 	return &service{
-		repo: repo,
+		repo:         repo,
+		cache:        cacheClient,
+		cacheCfg:     cacheCfg,
+		details:      cache.NewTyped[*ProductDetailResponse](cacheClient, typedOpts),
+		pages:        cache.NewTyped[*PaginatedProductsResponse](cacheClient, typedOpts),
+		suggestions:  cache.NewTyped[*SuggestResponse](cacheClient, typedOpts),
+		hotLists:     cache.NewTyped[*HotProductsResponse](cacheClient, typedOpts),
+		ranker:       ranker,
+		recents:      recents,
+		hot:          hotCfg,
+		related:      relatedCfg,
+		index:        index,
+		search:       searchCfg,
+		priceBuckets: slices.Compact(priceBuckets),
+		blob:         blob,
+		imaging:      imaging.NewProcessor(imageCfg),
 	}
 }
 
 // CreateProduct creates a new product with images (atomic transaction)
 func (s *service) CreateProduct(ctx context.Context, req CreateProductRequest) (*ProductDetailResponse, error) {
 	var result ProductDetailResponse
+	var created sqlc.Product
 
 	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
 		// 1. Create product
@@ -102,6 +186,7 @@ func (s *service) CreateProduct(ctx context.Context, req CreateProductRequest) (
 		}
 
 		result = toProductDetailResponse(product, images)
+		created = product
 		return nil
 	})
 
@@ -109,16 +194,50 @@ func (s *service) CreateProduct(ctx context.Context, req CreateProductRequest) (
 		return nil, err
 	}
 
+	s.indexProduct(ctx, created)
+	s.invalidateProduct(ctx, created.ID)
+
 	return &result, nil
 }
 
-// GetProduct retrieves a product by ID with all its images
+// GetProduct retrieves a product by ID with all its images, reading through the cache.
+// Stock, sales, views and ratings change outside the product service, so they are read live.
 func (s *service) GetProduct(ctx context.Context, productID int64) (*ProductDetailResponse, error) {
+	cached, err := s.cachedProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The loaded detail may be shared with concurrent callers, so live fields go on a copy
+	response := *cached
+	if err := s.applyLiveFields(ctx, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// cachedProduct returns a product's detail from the cache, without the live fields.
+// The detail may be shared with concurrent callers and must not be modified.
+func (s *service) cachedProduct(ctx context.Context, productID int64) (*ProductDetailResponse, error) {
+	cached, err := s.details.GetOrLoad(ctx, cache.CacheKeys.Product(productID), s.cacheCfg.ProductTTL, func(ctx context.Context) (*ProductDetailResponse, error) {
+		return s.loadProduct(ctx, productID)
+	})
+	if err != nil {
+		if errors.Is(err, cache.ErrNotFound) {
+			return nil, errors.New("product not found")
+		}
+		return nil, err
+	}
+	return cached, nil
+}
+
+// loadProduct reads a product's detail from the database
+func (s *service) loadProduct(ctx context.Context, productID int64) (*ProductDetailResponse, error) {
 	// Get product
 	product, err := s.repo.GetProductByID(ctx, productID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("product not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cache.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
@@ -128,13 +247,25 @@ func (s *service) GetProduct(ctx context.Context, productID int64) (*ProductDeta
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product images: %w", err)
 	}
+	renditions, err := s.repo.GetProductImageRenditions(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product image renditions: %w", err)
+	}
 
-	// Increment view count asynchronously (don't block on error)
-	go func() {
-		_ = s.repo.IncrementProductViews(context.Background(), productID)
-	}()
+	// Get variant matrix
+	options, err := s.getProductOptions(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	skus, err := s.getProductSkus(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
 
 	response := toProductDetailResponse(product, images)
+	attachRenditions(response.Images, renditions)
+	response.Options = options
+	response.Skus = skus
 	return &response, nil
 }
 
@@ -143,7 +274,7 @@ func (s *service) UpdateProduct(ctx context.Context, productID int64, req Update
 	// Check if product exists
 	_, err := s.repo.GetProductByID(ctx, productID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errors.New("product not found")
 		}
 		return nil, fmt.Errorf("failed to get product: %w", err)
@@ -172,6 +303,9 @@ func (s *service) UpdateProduct(ctx context.Context, productID int64, req Update
 		return nil, fmt.Errorf("failed to get updated product: %w", err)
 	}
 
+	s.indexProduct(ctx, product)
+	s.invalidateProduct(ctx, productID)
+
 	// Get main image URL
 	mainImageURL := ""
 	mainImage, err := s.repo.GetProductMainImage(ctx, productID)
@@ -185,11 +319,26 @@ func (s *service) UpdateProduct(ctx context.Context, productID int64, req Update
 
 // DeleteProduct soft deletes a product
 func (s *service) DeleteProduct(ctx context.Context, productID int64) error {
-	return s.repo.DeleteProduct(ctx, productID)
+	if err := s.repo.DeleteProduct(ctx, productID); err != nil {
+		return err
+	}
+
+	s.unindexProduct(ctx, productID)
+	s.invalidateProduct(ctx, productID)
+	return nil
 }
 
-// ListProducts lists products with filtering and pagination
+// ListProducts lists products with filtering, sorting, pagination and facet counts, reading through the cache
 func (s *service) ListProducts(ctx context.Context, req ListProductsRequest) (*PaginatedProductsResponse, error) {
+	listKey := func(generation int64) string {
+		return cache.CacheKeys.ProductList(generation, listQueryHash(req))
+	}
+	return s.readPageThrough(ctx, listKey, func(ctx context.Context) (*PaginatedProductsResponse, error) {
+		return s.loadProductList(ctx, req)
+	})
+}
+
+func (s *service) loadProductList(ctx context.Context, req ListProductsRequest) (*PaginatedProductsResponse, error) {
 	// Default pagination
 	if req.Page == 0 {
 		req.Page = 1
@@ -198,62 +347,41 @@ func (s *service) ListProducts(ctx context.Context, req ListProductsRequest) (*P
 		req.PageSize = 20
 	}
 
-	offset := (req.Page - 1) * req.PageSize
-
-	// Get products
-	products, err := s.repo.ListProducts(ctx, sqlc.ListProductsParams{
-		CategoryID: req.CategoryID,
-		Status:     req.Status,
-		Limit:      req.PageSize,
-		Offset:     offset,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list products: %w", err)
-	}
-
-	// Get total count
-	total, err := s.repo.CountProducts(ctx)
+	filter, err := newProductFilter(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count products: %w", err)
+		return nil, err
 	}
 
-	// Get product IDs for batch loading images
-	productIDs := make([]int64, len(products))
-	for i, p := range products {
-		productIDs[i] = p.ID
-	}
+	query := filter.searchQuery()
+	query.Sort = search.Sort(req.Sort)
+	query.Offset = (req.Page - 1) * req.PageSize
+	query.Limit = req.PageSize
+	query.Facets = true
+	query.PriceBuckets = s.priceBuckets
 
-	// Batch load main images
-	mainImages := make(map[int64]string)
-	if len(productIDs) > 0 {
-		images, err := s.repo.GetImagesByProductIDs(ctx, productIDs)
-		if err == nil {
-			for _, img := range images {
-				if utils.PtrValue(img.IsMain) {
-					mainImages[img.ProductID] = img.ImageUrl
-				}
-			}
-		}
+	result, err := s.index.Search(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
 
-	// Convert to response
-	productResponses := make([]ProductResponse, len(products))
-	for i, p := range products {
-		productResponses[i] = toProductResponse(p, mainImages[p.ID])
+	productResponses, err := s.loadSearchHits(ctx, result.Hits)
+	if err != nil {
+		return nil, err
 	}
 
-	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+	totalPages := int32((result.Total + int64(req.PageSize) - 1) / int64(req.PageSize))
 
 	return &PaginatedProductsResponse{
 		Products:   productResponses,
-		Total:      total,
+		Total:      result.Total,
 		Page:       req.Page,
 		PageSize:   req.PageSize,
 		TotalPages: totalPages,
+		Facets:     toProductFacets(result.Facets, filter, s.priceBuckets),
 	}, nil
 }
 
-// SearchProducts searches products by keyword
+// SearchProducts runs a full-text search over published products with highlighted snippets
 func (s *service) SearchProducts(ctx context.Context, req SearchProductsRequest) (*PaginatedProductsResponse, error) {
 	if req.Page == 0 {
 		req.Page = 1
@@ -262,54 +390,60 @@ func (s *service) SearchProducts(ctx context.Context, req SearchProductsRequest)
 		req.PageSize = 20
 	}
 
-	offset := (req.Page - 1) * req.PageSize
-
-	keyword := "%" + req.Keyword + "%"
-	products, err := s.repo.SearchProducts(ctx, sqlc.SearchProductsParams{
-		Column1: &keyword,
-		Limit:   req.PageSize,
-		Offset:  offset,
+	result, err := s.index.Search(ctx, search.Query{
+		Text:   req.Keyword,
+		Status: utils.Ptr("published"),
+		Sort:   search.Sort(req.Sort),
+		Offset: (req.Page - 1) * req.PageSize,
+		Limit:  req.PageSize,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to search products: %w", err)
 	}
 
-	// Get main images
-	productIDs := make([]int64, len(products))
-	for i, p := range products {
-		productIDs[i] = p.ID
+	var suggestions []string
+	if result.Total == 0 {
+		suggestions, err = s.getSpellingSuggestions(ctx, req.Keyword)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	mainImages := make(map[int64]string)
-	if len(productIDs) > 0 {
-		images, err := s.repo.GetImagesByProductIDs(ctx, productIDs)
-		if err == nil {
-			for _, img := range images {
-				if utils.PtrValue(img.IsMain) {
-					mainImages[img.ProductID] = img.ImageUrl
-				}
-			}
+	productResponses, err := s.loadSearchHits(ctx, result.Hits)
+	if err != nil {
+		return nil, err
+	}
+	for i := range productResponses {
+		product := &productResponses[i]
+		product.Highlight = &SearchHighlight{
+			Name:        search.Highlight(product.Name, result.Terms, 0),
+			Description: search.Highlight(product.Description, result.Terms, s.search.SnippetLength),
 		}
 	}
 
-	productResponses := make([]ProductResponse, len(products))
-	for i, p := range products {
-		productResponses[i] = toProductResponse(p, mainImages[p.ID])
-	}
+	totalPages := int32((result.Total + int64(req.PageSize) - 1) / int64(req.PageSize))
 
-	// Note: For simplicity, we're not counting total search results
-	// In production, you might want to add a CountSearchProducts query
 	return &PaginatedProductsResponse{
-		Products:   productResponses,
-		Total:      int64(len(products)),
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		TotalPages: 1,
+		Products:    productResponses,
+		Total:       result.Total,
+		Page:        req.Page,
+		PageSize:    req.PageSize,
+		TotalPages:  totalPages,
+		Suggestions: suggestions,
 	}, nil
 }
 
-// GetFeaturedProducts gets featured products
+// GetFeaturedProducts gets featured products, reading through the cache
 func (s *service) GetFeaturedProducts(ctx context.Context, page, pageSize int32) (*PaginatedProductsResponse, error) {
+	listKey := func(generation int64) string {
+		return cache.CacheKeys.FeaturedProducts(generation, int(page), int(pageSize))
+	}
+	return s.readPageThrough(ctx, listKey, func(ctx context.Context) (*PaginatedProductsResponse, error) {
+		return s.loadFeaturedProducts(ctx, page, pageSize)
+	})
+}
+
+func (s *service) loadFeaturedProducts(ctx context.Context, page, pageSize int32) (*PaginatedProductsResponse, error) {
 	if page == 0 {
 		page = 1
 	}
@@ -359,8 +493,17 @@ func (s *service) GetFeaturedProducts(ctx context.Context, page, pageSize int32)
 	}, nil
 }
 
-// GetProductsByCategory gets products by category
+// GetProductsByCategory gets products by category, reading through the cache
 func (s *service) GetProductsByCategory(ctx context.Context, categoryID int64, page, pageSize int32) (*PaginatedProductsResponse, error) {
+	listKey := func(generation int64) string {
+		return cache.CacheKeys.ProductsByCategory(generation, categoryID, int(page), int(pageSize))
+	}
+	return s.readPageThrough(ctx, listKey, func(ctx context.Context) (*PaginatedProductsResponse, error) {
+		return s.loadProductsByCategory(ctx, categoryID, page, pageSize)
+	})
+}
+
+func (s *service) loadProductsByCategory(ctx context.Context, categoryID int64, page, pageSize int32) (*PaginatedProductsResponse, error) {
 	if page == 0 {
 		page = 1
 	}
@@ -422,7 +565,7 @@ func (s *service) GetProductsByIDs(ctx context.Context, productIDs []int64) (map
 	for _, id := range productIDs {
 		product, err := s.repo.GetProductByID(ctx, id)
 		if err != nil {
-			if errors.Is(err,sql.ErrNoRows){
+			if errors.Is(err,pgx.ErrNoRows){
 				continue
 			}
 			return nil,fmt.Errorf("failed to get product %d: %w",id,err)
@@ -501,10 +644,16 @@ func (s *service) GetProductsByPriceRange(ctx context.Context, req PriceRangeReq
 
 // UpdateStock updates product stock
 func (s *service) UpdateStock(ctx context.Context, productID int64, delta int32) error {
-	return s.repo.UpdateProductStock(ctx, sqlc.UpdateProductStockParams{
+	err := s.repo.UpdateProductStock(ctx, sqlc.UpdateProductStockParams{
 		Stock: delta,
 		ID:    productID,
 	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateProduct(ctx, productID)
+	return nil
 }
 
 // CheckStock checks if product has sufficient stock
@@ -581,35 +730,22 @@ func (s *service) AddProductImages(ctx context.Context, productID int64, images
 			return fmt.Errorf("failed to create product image: %w", err)
 		}
 	}
+
+	s.invalidateProduct(ctx, productID)
 	return nil
 }
 
 // SetMainImage sets the main image for a product (atomic transaction)
 func (s *service) SetMainImage(ctx context.Context, productID, imageID int64) error {
-	return s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
-		// 1. Get all images for this product
-		images, err := q.GetProductImages(ctx, productID)
-		if err != nil {
-			return fmt.Errorf("failed to get product images: %w", err)
-		}
-
-		// 2. Unset all main flags
-		for _, img := range images {
-			if utils.PtrValue(img.IsMain) {
-				falseVal := false
-				err := q.UpdateProductImage(ctx, sqlc.UpdateProductImageParams{
-					ID:     img.ID,
-					IsMain: &falseVal,
-				})
-				if err != nil {
-					return fmt.Errorf("failed to unset main image: %w", err)
-				}
-			}
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		// 1. Unset all main flags
+		if err := unsetMainImages(ctx, q, productID); err != nil {
+			return err
 		}
 
-		// 3. Set new main image
+		// 2. Set new main image
 		trueVal := true
-		err = q.UpdateProductImage(ctx, sqlc.UpdateProductImageParams{
+		err := q.UpdateProductImage(ctx, sqlc.UpdateProductImageParams{
 			ID:     imageID,
 			IsMain: &trueVal,
 		})
@@ -619,16 +755,30 @@ func (s *service) SetMainImage(ctx context.Context, productID, imageID int64) er
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateProduct(ctx, productID)
+	return nil
 }
 
 // DeleteProductImage deletes a product image
 func (s *service) DeleteProductImage(ctx context.Context, imageID int64) error {
-	return s.repo.DeleteProductImage(ctx, imageID)
-}
+	image, err := s.repo.GetProductImage(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get product image: %w", err)
+	}
+
+	if err := s.repo.DeleteProductImage(ctx, imageID); err != nil {
+		return err
+	}
 
-// IncrementViews increments product view count
-func (s *service) IncrementViews(ctx context.Context, productID int64) error {
-	return s.repo.IncrementProductViews(ctx, productID)
+	s.invalidateProduct(ctx, image.ProductID)
+	return nil
 }
 
 // Helper functions
@@ -0,0 +1,328 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+)
+
+// validateOptionDefinitions rejects duplicate option names and duplicate values within an option
+func validateOptionDefinitions(options []ProductOptionRequest) error {
+	names := make(map[string]bool, len(options))
+	for _, option := range options {
+		if names[option.Name] {
+			return errors.New("option names must be unique")
+		}
+		names[option.Name] = true
+
+		values := make(map[string]bool, len(option.Values))
+		for _, value := range option.Values {
+			if values[value] {
+				return errors.New("option values must be unique within an option")
+			}
+			values[value] = true
+		}
+	}
+	return nil
+}
+
+// validateSkuOptions checks that a SKU picks exactly one defined value for every product option
+func validateSkuOptions(options []ProductOptionResponse, selected map[string]string) error {
+	if len(options) == 0 {
+		return errors.New("define product options before adding SKUs")
+	}
+	if len(selected) != len(options) {
+		return errors.New("sku options must set exactly one value for every product option")
+	}
+
+	for _, option := range options {
+		value, ok := selected[option.Name]
+		if !ok {
+			return errors.New("sku options must set exactly one value for every product option")
+		}
+
+		found := false
+		for _, v := range option.Values {
+			if v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New("sku options contain an unknown option or value")
+		}
+	}
+	return nil
+}
+
+// getProductOptions loads the option dimensions of a product in display order
+func (s *service) getProductOptions(ctx context.Context, productID int64) ([]ProductOptionResponse, error) {
+	rows, err := s.repo.ListProductOptionValues(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product options: %w", err)
+	}
+	return toProductOptionResponses(rows), nil
+}
+
+// getProductSkus loads every SKU of a product with its available stock
+func (s *service) getProductSkus(ctx context.Context, productID int64) ([]SkuResponse, error) {
+	rows, err := s.repo.ListProductSkus(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product skus: %w", err)
+	}
+
+	skus := make([]SkuResponse, len(rows))
+	for i, row := range rows {
+		skus[i] = toSkuResponse(sqlc.ProductSku{
+			ID:          row.ID,
+			ProductID:   row.ProductID,
+			SkuCode:     row.SkuCode,
+			Price:       row.Price,
+			OriginPrice: row.OriginPrice,
+			ImageUrl:    row.ImageUrl,
+			Barcode:     row.Barcode,
+			Options:     row.Options,
+			IsActive:    row.IsActive,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		}, row.AvailableStock)
+	}
+	return skus, nil
+}
+
+// SetProductOptions replaces the option dimensions of a product.
+// Options are locked once SKUs exist because every SKU is keyed by them.
+func (s *service) SetProductOptions(ctx context.Context, productID int64, req SetProductOptionsRequest) ([]ProductOptionResponse, error) {
+	if _, err := s.repo.GetProductByID(ctx, productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("product not found")
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	if err := validateOptionDefinitions(req.Options); err != nil {
+		return nil, err
+	}
+
+	skuCount, err := s.repo.CountProductSkus(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count product skus: %w", err)
+	}
+	if skuCount > 0 {
+		return nil, errors.New("options cannot be changed while the product has SKUs")
+	}
+
+	err = s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		if err := q.DeleteProductOptions(ctx, productID); err != nil {
+			return fmt.Errorf("failed to delete product options: %w", err)
+		}
+
+		for i, optionReq := range req.Options {
+			option, err := q.CreateProductOption(ctx, sqlc.CreateProductOptionParams{
+				ProductID: productID,
+				Name:      optionReq.Name,
+				Position:  int32(i),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create product option: %w", err)
+			}
+
+			for j, value := range optionReq.Values {
+				_, err := q.CreateProductOptionValue(ctx, sqlc.CreateProductOptionValueParams{
+					OptionID: option.ID,
+					Value:    value,
+					Position: int32(j),
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create option value: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateProduct(ctx, productID)
+	return s.getProductOptions(ctx, productID)
+}
+
+// CreateSku adds a variant to a product and opens its inventory record with the initial stock
+func (s *service) CreateSku(ctx context.Context, productID int64, req CreateSkuRequest) (*SkuResponse, error) {
+	if _, err := s.repo.GetProductByID(ctx, productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("product not found")
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	options, err := s.getProductOptions(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSkuOptions(options, req.Options); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.getProductSkus(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sku := range existing {
+		if maps.Equal(sku.Options, req.Options) {
+			return nil, errors.New("a sku with these options already exists")
+		}
+	}
+
+	barcode := stringToNullString(req.Barcode)
+	if err := s.checkSkuConflict(ctx, 0, req.SkuCode, barcode); err != nil {
+		return nil, err
+	}
+
+	encodedOptions, err := json.Marshal(req.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sku options: %w", err)
+	}
+
+	var result SkuResponse
+	err = s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		sku, err := q.CreateProductSku(ctx, sqlc.CreateProductSkuParams{
+			ProductID:   productID,
+			SkuCode:     req.SkuCode,
+			Price:       req.Price,
+			OriginPrice: req.OriginPrice,
+			ImageUrl:    stringToNullString(req.ImageURL),
+			Barcode:     barcode,
+			Options:     encodedOptions,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create sku: %w", err)
+		}
+
+		_, err = q.CreateInventory(ctx, sqlc.CreateInventoryParams{
+			ProductID:         productID,
+			SkuID:             &sku.ID,
+			AvailableStock:    req.Stock,
+			LowStockThreshold: utils.Ptr(req.LowStockThreshold),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create sku inventory: %w", err)
+		}
+
+		result = toSkuResponse(sku, req.Stock)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateProduct(ctx, productID)
+	return &result, nil
+}
+
+// checkSkuConflict reports a SKU code or barcode already used by another SKU
+func (s *service) checkSkuConflict(ctx context.Context, skuID int64, skuCode string, barcode *string) error {
+	conflict, err := s.repo.GetConflictingProductSku(ctx, sqlc.GetConflictingProductSkuParams{
+		SkuCode: skuCode,
+		Barcode: barcode,
+	})
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && conflict.ID == skuID) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check sku conflicts: %w", err)
+	}
+	if conflict.SkuCode == skuCode {
+		return errors.New("sku code already exists")
+	}
+	return errors.New("barcode already exists")
+}
+
+// UpdateSku changes the price, image, barcode or availability of a variant
+func (s *service) UpdateSku(ctx context.Context, productID, skuID int64, req UpdateSkuRequest) (*SkuResponse, error) {
+	if req.Barcode != nil {
+		if err := s.checkSkuConflict(ctx, skuID, "", req.Barcode); err != nil {
+			return nil, err
+		}
+	}
+
+	sku, err := s.repo.UpdateProductSku(ctx, sqlc.UpdateProductSkuParams{
+		Price:       req.Price,
+		OriginPrice: req.OriginPrice,
+		ImageUrl:    req.ImageURL,
+		Barcode:     req.Barcode,
+		IsActive:    req.IsActive,
+		ID:          skuID,
+		ProductID:   productID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("sku not found")
+		}
+		return nil, fmt.Errorf("failed to update sku: %w", err)
+	}
+
+	s.invalidateProduct(ctx, productID)
+
+	skus, err := s.getProductSkus(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, current := range skus {
+		if current.ID == sku.ID {
+			return &current, nil
+		}
+	}
+
+	result := toSkuResponse(sku, 0)
+	return &result, nil
+}
+
+// DeleteSku retires a variant together with its inventory record
+func (s *service) DeleteSku(ctx context.Context, productID, skuID int64) error {
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		rows, err := q.DeleteProductSku(ctx, sqlc.DeleteProductSkuParams{
+			ID:        skuID,
+			ProductID: productID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete sku: %w", err)
+		}
+		if rows == 0 {
+			return errors.New("sku not found")
+		}
+
+		if err := q.DeleteSkuInventory(ctx, &skuID); err != nil {
+			return fmt.Errorf("failed to delete sku inventory: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateProduct(ctx, productID)
+	return nil
+}
+
+// GetActiveSkusByProductIDs returns the orderable SKUs of each product; products without variants are absent
+func (s *service) GetActiveSkusByProductIDs(ctx context.Context, productIDs []int64) (map[int64][]SkuResponse, error) {
+	skus, err := s.repo.ListActiveSkusByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product skus: %w", err)
+	}
+
+	result := make(map[int64][]SkuResponse)
+	for _, sku := range skus {
+		result[sku.ProductID] = append(result[sku.ProductID], toSkuResponse(sku, 0))
+	}
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSkuOptions(t *testing.T) {
+	options := []ProductOptionResponse{
+		{Name: "size", Values: []string{"S", "M", "L"}},
+		{Name: "colour", Values: []string{"Red", "Blue"}},
+	}
+
+	require.NoError(t, validateSkuOptions(options, map[string]string{"size": "M", "colour": "Red"}))
+
+	// Every option needs exactly one value
+	require.Error(t, validateSkuOptions(options, map[string]string{"size": "M"}))
+	require.Error(t, validateSkuOptions(options, map[string]string{"size": "M", "material": "Wool"}))
+
+	// Values must come from the option definition
+	require.Error(t, validateSkuOptions(options, map[string]string{"size": "XL", "colour": "Red"}))
+
+	require.Error(t, validateSkuOptions(nil, map[string]string{}))
+}
+
+func TestValidateOptionDefinitions(t *testing.T) {
+	require.NoError(t, validateOptionDefinitions([]ProductOptionRequest{
+		{Name: "size", Values: []string{"S", "M"}},
+		{Name: "colour", Values: []string{"Red"}},
+	}))
+
+	require.Error(t, validateOptionDefinitions([]ProductOptionRequest{
+		{Name: "size", Values: []string{"S"}},
+		{Name: "size", Values: []string{"M"}},
+	}))
+
+	require.Error(t, validateOptionDefinitions([]ProductOptionRequest{
+		{Name: "size", Values: []string{"S", "S"}},
+	}))
+}
@@ -0,0 +1,154 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gomall/db/sqlc"
+	"gomall/internal/cache"
+	"gomall/utils"
+)
+
+const (
+	defaultSuggestLimit   = 8
+	maxSpellingSuggestion = 5
+)
+
+// normalizeSuggestQuery lowercases a search-box prefix and collapses whitespace so equivalent prefixes share a cache entry
+func normalizeSuggestQuery(q string) string {
+	return strings.Join(strings.Fields(strings.ToLower(q)), " ")
+}
+
+// escapeLikePattern escapes LIKE wildcards so user input matches literally
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// Suggest returns product names, brands and categories matching a search-box prefix.
+// Prefixes requested often enough are served from the cache.
+func (s *service) Suggest(ctx context.Context, req SuggestRequest) (*SuggestResponse, error) {
+	if req.Limit == 0 {
+		req.Limit = defaultSuggestLimit
+	}
+
+	keyword := normalizeSuggestQuery(req.Q)
+	if keyword == "" {
+		return &SuggestResponse{
+			Products:   []ProductSuggestion{},
+			Brands:     []BrandSuggestion{},
+			Categories: []CategorySuggestion{},
+		}, nil
+	}
+
+	cacheKey := cache.CacheKeys.SearchSuggest(keyword, int(req.Limit))
+	if cached, err := s.suggestions.Get(ctx, cacheKey); err == nil {
+		return cached, nil
+	}
+
+	result, err := s.loadSuggestions(ctx, keyword, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cachePopularSuggestions(ctx, keyword, cacheKey, result)
+
+	return result, nil
+}
+
+func (s *service) loadSuggestions(ctx context.Context, keyword string, limit int32) (*SuggestResponse, error) {
+	escaped := escapeLikePattern(keyword)
+	pattern := "%" + escaped + "%"
+	prefixPattern := escaped + "%"
+
+	products, err := s.repo.SuggestProductNames(ctx, sqlc.SuggestProductNamesParams{
+		Keyword:       keyword,
+		Pattern:       pattern,
+		PrefixPattern: prefixPattern,
+		MaxResults:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest products: %w", err)
+	}
+
+	brands, err := s.repo.SuggestBrands(ctx, sqlc.SuggestBrandsParams{
+		Keyword:       keyword,
+		Pattern:       pattern,
+		PrefixPattern: prefixPattern,
+		MaxResults:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest brands: %w", err)
+	}
+
+	categories, err := s.repo.SuggestCategories(ctx, sqlc.SuggestCategoriesParams{
+		Keyword:       keyword,
+		Pattern:       pattern,
+		PrefixPattern: prefixPattern,
+		MaxResults:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest categories: %w", err)
+	}
+
+	result := &SuggestResponse{
+		Products:   make([]ProductSuggestion, len(products)),
+		Brands:     make([]BrandSuggestion, len(brands)),
+		Categories: make([]CategorySuggestion, len(categories)),
+	}
+	for i, p := range products {
+		result.Products[i] = ProductSuggestion{ID: p.ID, Name: p.Name}
+	}
+	for i, b := range brands {
+		result.Brands[i] = BrandSuggestion{Brand: b.Brand, ProductCount: b.ProductCount}
+	}
+	for i, c := range categories {
+		result.Categories[i] = CategorySuggestion{ID: c.ID, Name: c.Name, Slug: utils.PtrValue(c.Slug)}
+	}
+
+	return result, nil
+}
+
+// cachePopularSuggestions counts requests for a prefix and caches its suggestions once it becomes popular.
+// Cache failures only cost a database round trip, so they are not reported.
+func (s *service) cachePopularSuggestions(ctx context.Context, keyword, cacheKey string, result *SuggestResponse) {
+	if s.cache == nil || s.search.Suggest.CacheTTL <= 0 {
+		return
+	}
+
+	hitsKey := cache.CacheKeys.SearchSuggestHits(keyword)
+	hits, err := s.cache.Incr(ctx, hitsKey)
+	if err != nil {
+		return
+	}
+	if hits == 1 && s.search.Suggest.HitWindow > 0 {
+		_ = s.cache.Expire(ctx, hitsKey, s.search.Suggest.HitWindow)
+	}
+	if hits < s.search.Suggest.PopularThreshold {
+		return
+	}
+
+	_ = s.suggestions.Set(ctx, cacheKey, result, s.search.Suggest.CacheTTL)
+}
+
+// getSpellingSuggestions returns "did you mean" terms close to a keyword that found nothing
+func (s *service) getSpellingSuggestions(ctx context.Context, keyword string) ([]string, error) {
+	keyword = normalizeSuggestQuery(keyword)
+	if keyword == "" {
+		return nil, nil
+	}
+
+	rows, err := s.repo.ListSpellingSuggestions(ctx, sqlc.ListSpellingSuggestionsParams{
+		Keyword:    keyword,
+		MaxResults: maxSpellingSuggestion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spelling suggestions: %w", err)
+	}
+
+	suggestions := make([]string, len(rows))
+	for i, row := range rows {
+		suggestions[i] = row.Term
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,16 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSuggestQuery(t *testing.T) {
+	require.Equal(t, "iphone 15", normalizeSuggestQuery("  iPhone   15 "))
+	require.Equal(t, "", normalizeSuggestQuery("   "))
+}
+
+func TestEscapeLikePattern(t *testing.T) {
+	require.Equal(t, `100\% cotton\_t\\shirt`, escapeLikePattern(`100% cotton_t\shirt`))
+}
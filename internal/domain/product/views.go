@@ -0,0 +1,89 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+
+	"gomall/db/sqlc"
+	"gomall/internal/cache"
+)
+
+// RecordView counts a view of a product, once per visitor within ViewDedupWindow.
+// Views accumulate in the cache and reach products.view_count when FlushViews runs;
+// counted views also feed the hot product rankings.
+func (s *service) RecordView(ctx context.Context, productID int64, visitor string) error {
+	if s.cache == nil {
+		return s.repo.AddProductViews(ctx, sqlc.AddProductViewsParams{ProductIds: []int64{productID}, Views: []int32{1}})
+	}
+
+	if visitor != "" && s.cacheCfg.ViewDedupWindow > 0 {
+		seenKey := cache.CacheKeys.ProductViewSeen(productID, visitor)
+		first, err := s.cache.SetNX(ctx, seenKey, "1", s.cacheCfg.ViewDedupWindow)
+		if err != nil {
+			return fmt.Errorf("failed to record product view: %w", err)
+		}
+		if !first {
+			return nil
+		}
+	}
+
+	if err := s.addPendingViews(ctx, productID, 1); err != nil {
+		return err
+	}
+	return s.RecordHotEvent(ctx, productID, HotEventView, 1)
+}
+
+// addPendingViews adds to a product's view counter and lists it for the next flush.
+// Listing after counting means a concurrent flush can never drain the list but miss the count.
+func (s *service) addPendingViews(ctx context.Context, productID, views int64) error {
+	countKey := cache.CacheKeys.ProductViewCount(productID)
+	if _, err := s.cache.IncrBy(ctx, countKey, views); err != nil {
+		return fmt.Errorf("failed to record product view: %w", err)
+	}
+	if err := s.cache.HSet(ctx, cache.CacheKeys.ProductViewPending(), strconv.FormatInt(productID, 10), countKey); err != nil {
+		return fmt.Errorf("failed to record product view: %w", err)
+	}
+	return nil
+}
+
+// FlushViews writes the views counted since the last flush to the database in a single UPDATE,
+// returning how many products were updated. Draining is atomic, so instances may flush concurrently.
+func (s *service) FlushViews(ctx context.Context) (int, error) {
+	store, ok := s.cache.(cache.CounterStore)
+	if !ok {
+		return 0, nil // views went straight to the database
+	}
+
+	counts, err := store.DrainCounters(ctx, cache.CacheKeys.ProductViewPending())
+	if err != nil {
+		return 0, fmt.Errorf("failed to drain view counters: %w", err)
+	}
+
+	var params sqlc.AddProductViewsParams
+	for member, views := range counts {
+		productID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil || views <= 0 {
+			continue
+		}
+		params.ProductIds = append(params.ProductIds, productID)
+		params.Views = append(params.Views, int32(min(views, math.MaxInt32)))
+	}
+	if len(params.ProductIds) == 0 {
+		return 0, nil
+	}
+
+	if err := s.repo.AddProductViews(ctx, params); err != nil {
+		// Put the drained views back so the next flush retries them
+		restoreCtx := context.WithoutCancel(ctx)
+		for i, productID := range params.ProductIds {
+			if err := s.addPendingViews(restoreCtx, productID, int64(params.Views[i])); err != nil {
+				log.Printf("failed to restore %d views of product %d: %v", params.Views[i], productID, err)
+			}
+		}
+		return 0, fmt.Errorf("failed to save product views: %w", err)
+	}
+	return len(params.ProductIds), nil
+}
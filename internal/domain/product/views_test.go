@@ -0,0 +1,67 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gomall/db/sqlc"
+	"gomall/internal/cache"
+	"gomall/internal/config"
+)
+
+// viewRepo records batched view updates; other Repository methods are not used by the view counter
+type viewRepo struct {
+	Repository
+	updates []sqlc.AddProductViewsParams
+	err     error
+}
+
+func (r *viewRepo) AddProductViews(ctx context.Context, arg sqlc.AddProductViewsParams) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.updates = append(r.updates, arg)
+	return nil
+}
+
+func TestViewCounting(t *testing.T) {
+	ctx := context.Background()
+	repo := &viewRepo{}
+	s := &service{
+		repo:     repo,
+		cache:    cache.NewMemoryCache(cache.MemoryConfig{}),
+		cacheCfg: config.CacheConfig{ViewDedupWindow: time.Minute},
+	}
+
+	require.NoError(t, s.RecordView(ctx, 1, "alice"))
+	require.NoError(t, s.RecordView(ctx, 1, "alice")) // repeat view, not counted
+	require.NoError(t, s.RecordView(ctx, 1, "bob"))
+	require.NoError(t, s.RecordView(ctx, 2, "alice"))
+
+	// A failed write keeps the views for the next flush
+	repo.err = errors.New("db down")
+	_, err := s.FlushViews(ctx)
+	require.Error(t, err)
+	require.NoError(t, s.RecordView(ctx, 2, "carol"))
+
+	repo.err = nil
+	updated, err := s.FlushViews(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, updated)
+	require.Len(t, repo.updates, 1)
+
+	views := map[int64]int32{}
+	for i, id := range repo.updates[0].ProductIds {
+		views[id] = repo.updates[0].Views[i]
+	}
+	require.Equal(t, map[int64]int32{1: 2, 2: 2}, views)
+
+	// Nothing left to flush
+	updated, err = s.FlushViews(ctx)
+	require.NoError(t, err)
+	require.Zero(t, updated)
+}
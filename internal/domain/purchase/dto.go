@@ -0,0 +1,219 @@
+package purchase
+
+import (
+	"time"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+	"gomall/utils/types"
+)
+
+// dateLayout is the wire format for expected delivery dates
+const dateLayout = "2006-01-02"
+
+// Request DTOs
+
+type CreateSupplierRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=200"`
+	ContactName string `json:"contact_name,omitempty" binding:"max=100"`
+	Email       string `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	Phone       string `json:"phone,omitempty" binding:"max=50"`
+	Address     string `json:"address,omitempty" binding:"max=500"`
+}
+
+type UpdateSupplierRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,min=1,max=200"`
+	ContactName *string `json:"contact_name,omitempty" binding:"omitempty,max=100"`
+	Email       *string `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	Phone       *string `json:"phone,omitempty" binding:"omitempty,max=50"`
+	Address     *string `json:"address,omitempty" binding:"omitempty,max=500"`
+	IsActive    *bool   `json:"is_active,omitempty"`
+}
+
+type PurchaseOrderItemRequest struct {
+	ProductID int64 `json:"product_id" binding:"required"`
+	// Required for products with variants, which are stocked per SKU
+	SkuID        *int64 `json:"sku_id,omitempty"`
+	Quantity     int32  `json:"quantity" binding:"required,min=1"`
+	UnitCost     int64  `json:"unit_cost" binding:"min=0"`
+	ExpectedDate string `json:"expected_date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	SupplierID   int64                      `json:"supplier_id" binding:"required"`
+	ExpectedDate string                     `json:"expected_date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Note         string                     `json:"note,omitempty" binding:"max=500"`
+	Items        []PurchaseOrderItemRequest `json:"items" binding:"required,min=1,max=500,dive"`
+}
+
+type ListPurchaseOrdersRequest struct {
+	Status     string `form:"status"`
+	SupplierID *int64 `form:"supplier_id"`
+	Page       int32  `form:"page"`
+	PageSize   int32  `form:"page_size"`
+}
+
+type ReceiveItemRequest struct {
+	ProductID int64  `json:"product_id" binding:"required"`
+	SkuID     *int64 `json:"sku_id,omitempty"`
+	Quantity  int32  `json:"quantity" binding:"required,min=1"`
+	// Actual landed cost per unit; defaults to the unit cost on the purchase order
+	UnitCost *int64 `json:"unit_cost,omitempty" binding:"omitempty,min=0"`
+}
+
+type ReceivePurchaseOrderRequest struct {
+	Items []ReceiveItemRequest `json:"items" binding:"required,min=1,max=500,dive"`
+}
+
+// Response DTOs
+
+type SupplierResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	ContactName string    `json:"contact_name,omitempty"`
+	Email       string    `json:"email,omitempty"`
+	Phone       string    `json:"phone,omitempty"`
+	Address     string    `json:"address,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type PaginatedSuppliersResponse struct {
+	Suppliers  []SupplierResponse `json:"suppliers"`
+	Total      int64              `json:"total"`
+	Page       int32              `json:"page"`
+	PageSize   int32              `json:"page_size"`
+	TotalPages int32              `json:"total_pages"`
+}
+
+type PurchaseOrderItemResponse struct {
+	ID               int64   `json:"id"`
+	ProductID        int64   `json:"product_id"`
+	SkuID            *int64  `json:"sku_id,omitempty"`
+	ProductName      string  `json:"product_name"`
+	Quantity         int32   `json:"quantity"`
+	ReceivedQuantity int32   `json:"received_quantity"`
+	UnitCost         int64   `json:"unit_cost"`
+	ExpectedDate     *string `json:"expected_date,omitempty"`
+}
+
+type PurchaseReceiptResponse struct {
+	ID                  int64     `json:"id"`
+	PurchaseOrderItemID int64     `json:"purchase_order_item_id"`
+	ProductID           int64     `json:"product_id"`
+	SkuID               *int64    `json:"sku_id,omitempty"`
+	Quantity            int32     `json:"quantity"`
+	UnitCost            int64     `json:"unit_cost"`
+	OnHandBefore        int32     `json:"on_hand_before"`
+	CostPriceBefore     *int64    `json:"cost_price_before,omitempty"`
+	CostPriceAfter      int64     `json:"cost_price_after"`
+	ReceivedBy          *int64    `json:"received_by,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type PurchaseOrderResponse struct {
+	ID           int64                       `json:"id"`
+	PoNo         string                      `json:"po_no"`
+	SupplierID   int64                       `json:"supplier_id"`
+	Status       string                      `json:"status"`
+	ExpectedDate *string                     `json:"expected_date,omitempty"`
+	TotalCost    int64                       `json:"total_cost"`
+	Note         string                      `json:"note,omitempty"`
+	CreatedBy    *int64                      `json:"created_by,omitempty"`
+	OrderedAt    *time.Time                  `json:"ordered_at,omitempty"`
+	ReceivedAt   *time.Time                  `json:"received_at,omitempty"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+	Items        []PurchaseOrderItemResponse `json:"items,omitempty"`
+	Receipts     []PurchaseReceiptResponse   `json:"receipts,omitempty"`
+}
+
+type PaginatedPurchaseOrdersResponse struct {
+	PurchaseOrders []PurchaseOrderResponse `json:"purchase_orders"`
+	Total          int64                   `json:"total"`
+	Page           int32                   `json:"page"`
+	PageSize       int32                   `json:"page_size"`
+	TotalPages     int32                   `json:"total_pages"`
+}
+
+// Conversion functions
+
+func toSupplierResponse(supplier sqlc.Supplier) SupplierResponse {
+	return SupplierResponse{
+		ID:          supplier.ID,
+		Name:        supplier.Name,
+		ContactName: utils.PtrValue(supplier.ContactName),
+		Email:       utils.PtrValue(supplier.Email),
+		Phone:       utils.PtrValue(supplier.Phone),
+		Address:     utils.PtrValue(supplier.Address),
+		IsActive:    supplier.IsActive,
+		CreatedAt:   supplier.CreatedAt,
+		UpdatedAt:   supplier.UpdatedAt,
+	}
+}
+
+func toPurchaseOrderResponse(po sqlc.PurchaseOrder) PurchaseOrderResponse {
+	return PurchaseOrderResponse{
+		ID:           po.ID,
+		PoNo:         po.PoNo,
+		SupplierID:   po.SupplierID,
+		Status:       po.Status,
+		ExpectedDate: formatDate(po.ExpectedDate),
+		TotalCost:    po.TotalCost,
+		Note:         utils.PtrValue(po.Note),
+		CreatedBy:    po.CreatedBy,
+		OrderedAt:    po.OrderedAt.Ptr(),
+		ReceivedAt:   po.ReceivedAt.Ptr(),
+		CreatedAt:    po.CreatedAt,
+		UpdatedAt:    po.UpdatedAt,
+	}
+}
+
+func toPurchaseOrderItemResponse(item sqlc.ListPurchaseOrderItemsRow) PurchaseOrderItemResponse {
+	return PurchaseOrderItemResponse{
+		ID:               item.ID,
+		ProductID:        item.ProductID,
+		SkuID:            item.SkuID,
+		ProductName:      item.ProductName,
+		Quantity:         item.Quantity,
+		ReceivedQuantity: item.ReceivedQuantity,
+		UnitCost:         item.UnitCost,
+		ExpectedDate:     formatDate(item.ExpectedDate),
+	}
+}
+
+func toPurchaseReceiptResponse(receipt sqlc.PurchaseReceipt) PurchaseReceiptResponse {
+	return PurchaseReceiptResponse{
+		ID:                  receipt.ID,
+		PurchaseOrderItemID: receipt.PurchaseOrderItemID,
+		ProductID:           receipt.ProductID,
+		SkuID:               receipt.SkuID,
+		Quantity:            receipt.Quantity,
+		UnitCost:            receipt.UnitCost,
+		OnHandBefore:        receipt.OnHandBefore,
+		CostPriceBefore:     receipt.CostPriceBefore,
+		CostPriceAfter:      receipt.CostPriceAfter,
+		ReceivedBy:          receipt.ReceivedBy,
+		CreatedAt:           receipt.CreatedAt,
+	}
+}
+
+func formatDate(t types.NullTime) *string {
+	if !t.Valid {
+		return nil
+	}
+	return utils.Ptr(t.Time.Format(dateLayout))
+}
+
+// parseDate converts an already validated YYYY-MM-DD string; empty means no date
+func parseDate(value string) types.NullTime {
+	if value == "" {
+		return types.NullTime{}
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return types.NullTime{}
+	}
+	return types.NewNullTimeValue(t)
+}
@@ -0,0 +1,407 @@
+package purchase
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gomall/internal/common/middleware"
+	"gomall/utils/response"
+)
+
+// Handler handles purchasing-related HTTP requests
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new Handler instance
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// RegisterRoutes registers all supplier and purchase order routes
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	// Protected endpoints (require auth - admin only in production)
+	suppliers := router.Group("/suppliers")
+	{
+		suppliers.POST("", h.CreateSupplier)    // POST /suppliers
+		suppliers.GET("", h.ListSuppliers)      // GET /suppliers
+		suppliers.GET("/:id", h.GetSupplier)    // GET /suppliers/:id
+		suppliers.PUT("/:id", h.UpdateSupplier) // PUT /suppliers/:id
+	}
+
+	purchaseOrders := router.Group("/purchase-orders")
+	{
+		purchaseOrders.POST("", h.CreatePurchaseOrder)              // POST /purchase-orders
+		purchaseOrders.GET("", h.ListPurchaseOrders)                // GET /purchase-orders
+		purchaseOrders.GET("/:id", h.GetPurchaseOrder)              // GET /purchase-orders/:id
+		purchaseOrders.POST("/:id/submit", h.SubmitPurchaseOrder)   // POST /purchase-orders/:id/submit
+		purchaseOrders.POST("/:id/receive", h.ReceivePurchaseOrder) // POST /purchase-orders/:id/receive
+		purchaseOrders.POST("/:id/cancel", h.CancelPurchaseOrder)   // POST /purchase-orders/:id/cancel
+	}
+}
+
+// CreateSupplier godoc
+// @Summary      Create Supplier
+// @Description  Create a new supplier
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      CreateSupplierRequest  true  "Supplier information"
+// @Success      201      {object}  response.Response{data=SupplierResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /suppliers [post]
+func (h *Handler) CreateSupplier(c *gin.Context) {
+	var req CreateSupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	supplier, err := h.service.CreateSupplier(c.Request.Context(), req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    0,
+		"message": "success",
+		"data":    supplier,
+	})
+}
+
+// ListSuppliers godoc
+// @Summary      List Suppliers
+// @Description  List suppliers with pagination
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        is_active query     bool  false  "Filter by active flag"
+// @Param        page      query     int   false  "Page number (default: 1)"
+// @Param        page_size query     int   false  "Page size (default: 20)"
+// @Success      200       {object}  response.Response{data=PaginatedSuppliersResponse}
+// @Failure      500       {object}  response.Response
+// @Router       /suppliers [get]
+func (h *Handler) ListSuppliers(c *gin.Context) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	pageSize, _ := strconv.ParseInt(c.DefaultQuery("page_size", "20"), 10, 32)
+
+	var isActive *bool
+	if value := c.Query("is_active"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid is_active")
+			return
+		}
+		isActive = &parsed
+	}
+
+	suppliers, err := h.service.ListSuppliers(c.Request.Context(), isActive, int32(page), int32(pageSize))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, suppliers)
+}
+
+// GetSupplier godoc
+// @Summary      Get Supplier
+// @Description  Get supplier details by ID
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Supplier ID"
+// @Success      200  {object}  response.Response{data=SupplierResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /suppliers/{id} [get]
+func (h *Handler) GetSupplier(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid supplier id")
+		return
+	}
+
+	supplier, err := h.service.GetSupplier(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "supplier not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, supplier)
+}
+
+// UpdateSupplier godoc
+// @Summary      Update Supplier
+// @Description  Update supplier details or deactivate a supplier
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                    true  "Supplier ID"
+// @Param        request  body      UpdateSupplierRequest  true  "Supplier fields to update"
+// @Success      200      {object}  response.Response
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /suppliers/{id} [put]
+func (h *Handler) UpdateSupplier(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid supplier id")
+		return
+	}
+
+	var req UpdateSupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.service.UpdateSupplier(c.Request.Context(), id, req); err != nil {
+		if err.Error() == "supplier not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{"message": "supplier updated successfully"})
+}
+
+// CreatePurchaseOrder godoc
+// @Summary      Create Purchase Order
+// @Description  Create a draft purchase order with line items
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      CreatePurchaseOrderRequest  true  "Purchase order information"
+// @Success      201      {object}  response.Response{data=PurchaseOrderResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /purchase-orders [post]
+func (h *Handler) CreatePurchaseOrder(c *gin.Context) {
+	var req CreatePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	po, err := h.service.CreatePurchaseOrder(c.Request.Context(), req, operatorID(c))
+	if err != nil {
+		switch err.Error() {
+		case "supplier not found", "product not found", "sku not found":
+			response.Error(c, http.StatusNotFound, err.Error())
+		case "supplier is inactive", "duplicate product in purchase order", "sku_id is required for products with variants":
+			response.Error(c, http.StatusBadRequest, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    0,
+		"message": "success",
+		"data":    po,
+	})
+}
+
+// ListPurchaseOrders godoc
+// @Summary      List Purchase Orders
+// @Description  List purchase orders, newest first
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        status      query     string  false  "Filter by status (draft, ordered, partially_received, received, cancelled)"
+// @Param        supplier_id query     int     false  "Filter by supplier"
+// @Param        page        query     int     false  "Page number (default: 1)"
+// @Param        page_size   query     int     false  "Page size (default: 20)"
+// @Success      200         {object}  response.Response{data=PaginatedPurchaseOrdersResponse}
+// @Failure      400         {object}  response.Response
+// @Failure      500         {object}  response.Response
+// @Router       /purchase-orders [get]
+func (h *Handler) ListPurchaseOrders(c *gin.Context) {
+	var req ListPurchaseOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	pos, err := h.service.ListPurchaseOrders(c.Request.Context(), req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, pos)
+}
+
+// GetPurchaseOrder godoc
+// @Summary      Get Purchase Order
+// @Description  Get a purchase order with its line items and receipts
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Purchase order ID"
+// @Success      200  {object}  response.Response{data=PurchaseOrderResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /purchase-orders/{id} [get]
+func (h *Handler) GetPurchaseOrder(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	po, err := h.service.GetPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "purchase order not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, po)
+}
+
+// SubmitPurchaseOrder godoc
+// @Summary      Submit Purchase Order
+// @Description  Mark a draft purchase order as ordered
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Purchase order ID"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /purchase-orders/{id}/submit [post]
+func (h *Handler) SubmitPurchaseOrder(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	if err := h.service.SubmitPurchaseOrder(c.Request.Context(), id); err != nil {
+		purchaseOrderError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "purchase order submitted successfully"})
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary      Receive Purchase Order
+// @Description  Receive goods against a purchase order. Restocks inventory with the purchase order as reference and updates the product cost price using weighted average cost.
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                          true  "Purchase order ID"
+// @Param        request  body      ReceivePurchaseOrderRequest  true  "Received quantities"
+// @Success      200      {object}  response.Response{data=PurchaseOrderResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /purchase-orders/{id}/receive [post]
+func (h *Handler) ReceivePurchaseOrder(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	var req ReceivePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	po, err := h.service.ReceivePurchaseOrder(c.Request.Context(), id, req, operatorID(c))
+	if err != nil {
+		purchaseOrderError(c, err)
+		return
+	}
+
+	response.Success(c, po)
+}
+
+// CancelPurchaseOrder godoc
+// @Summary      Cancel Purchase Order
+// @Description  Cancel a draft or ordered purchase order that has not received goods
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Purchase order ID"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /purchase-orders/{id}/cancel [post]
+func (h *Handler) CancelPurchaseOrder(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	if err := h.service.CancelPurchaseOrder(c.Request.Context(), id); err != nil {
+		purchaseOrderError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "purchase order cancelled successfully"})
+}
+
+// purchaseOrderError maps purchase order workflow errors to HTTP status codes
+func purchaseOrderError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "purchase order not found":
+		response.Error(c, http.StatusNotFound, err.Error())
+	case "invalid purchase order status transition", "purchase order cannot be received":
+		response.Error(c, http.StatusConflict, err.Error())
+	case "product is not on this purchase order", "duplicate product in receipt", "received quantity exceeds ordered quantity":
+		response.Error(c, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// operatorID returns the authenticated user's ID, if any
+func operatorID(c *gin.Context) *int64 {
+	payload := middleware.GetPayload(c)
+	if payload == nil {
+		return nil
+	}
+	return &payload.UserID
+}
@@ -0,0 +1,90 @@
+package purchase
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"gomall/db/sqlc"
+)
+
+// Repository defines the interface for purchasing data access
+type Repository interface {
+	// Supplier operations
+	CreateSupplier(ctx context.Context, arg sqlc.CreateSupplierParams) (sqlc.Supplier, error)
+	GetSupplier(ctx context.Context, id int64) (sqlc.Supplier, error)
+	ListSuppliers(ctx context.Context, arg sqlc.ListSuppliersParams) ([]sqlc.Supplier, error)
+	CountSuppliers(ctx context.Context, isActive *bool) (int64, error)
+	UpdateSupplier(ctx context.Context, arg sqlc.UpdateSupplierParams) error
+
+	// Purchase order operations
+	GetPurchaseOrder(ctx context.Context, id int64) (sqlc.PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, arg sqlc.ListPurchaseOrdersParams) ([]sqlc.PurchaseOrder, error)
+	CountPurchaseOrders(ctx context.Context, arg sqlc.CountPurchaseOrdersParams) (int64, error)
+	ListPurchaseOrderItems(ctx context.Context, purchaseOrderID int64) ([]sqlc.ListPurchaseOrderItemsRow, error)
+	ListPurchaseReceipts(ctx context.Context, purchaseOrderID int64) ([]sqlc.PurchaseReceipt, error)
+
+	// Transaction support
+	ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error
+}
+
+type repository struct {
+	store sqlc.Store
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(pool *pgxpool.Pool) Repository {
+	return &repository{
+		store: sqlc.NewStore(pool),
+	}
+}
+
+// Supplier operations
+
+func (r *repository) CreateSupplier(ctx context.Context, arg sqlc.CreateSupplierParams) (sqlc.Supplier, error) {
+	return r.store.CreateSupplier(ctx, arg)
+}
+
+func (r *repository) GetSupplier(ctx context.Context, id int64) (sqlc.Supplier, error) {
+	return r.store.GetSupplier(ctx, id)
+}
+
+func (r *repository) ListSuppliers(ctx context.Context, arg sqlc.ListSuppliersParams) ([]sqlc.Supplier, error) {
+	return r.store.ListSuppliers(ctx, arg)
+}
+
+func (r *repository) CountSuppliers(ctx context.Context, isActive *bool) (int64, error) {
+	return r.store.CountSuppliers(ctx, isActive)
+}
+
+func (r *repository) UpdateSupplier(ctx context.Context, arg sqlc.UpdateSupplierParams) error {
+	return r.store.UpdateSupplier(ctx, arg)
+}
+
+// Purchase order operations
+
+func (r *repository) GetPurchaseOrder(ctx context.Context, id int64) (sqlc.PurchaseOrder, error) {
+	return r.store.GetPurchaseOrder(ctx, id)
+}
+
+func (r *repository) ListPurchaseOrders(ctx context.Context, arg sqlc.ListPurchaseOrdersParams) ([]sqlc.PurchaseOrder, error) {
+	return r.store.ListPurchaseOrders(ctx, arg)
+}
+
+func (r *repository) CountPurchaseOrders(ctx context.Context, arg sqlc.CountPurchaseOrdersParams) (int64, error) {
+	return r.store.CountPurchaseOrders(ctx, arg)
+}
+
+func (r *repository) ListPurchaseOrderItems(ctx context.Context, purchaseOrderID int64) ([]sqlc.ListPurchaseOrderItemsRow, error) {
+	return r.store.ListPurchaseOrderItems(ctx, purchaseOrderID)
+}
+
+func (r *repository) ListPurchaseReceipts(ctx context.Context, purchaseOrderID int64) ([]sqlc.PurchaseReceipt, error) {
+	return r.store.ListPurchaseReceipts(ctx, purchaseOrderID)
+}
+
+// Transaction support
+
+func (r *repository) ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return r.store.ExecTx(ctx, fn)
+}
@@ -0,0 +1,536 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+	"gomall/internal/domain/inventory"
+	"gomall/utils"
+)
+
+// Purchase order statuses
+const (
+	StatusDraft             = "draft"
+	StatusOrdered           = "ordered"
+	StatusPartiallyReceived = "partially_received"
+	StatusReceived          = "received"
+	StatusCancelled         = "cancelled"
+)
+
+// ReferenceType tags inventory logs written when goods are received against a purchase order
+const ReferenceType = "purchase_order"
+
+// Service defines the business logic interface for purchasing domain
+type Service interface {
+	// Supplier operations
+	CreateSupplier(ctx context.Context, req CreateSupplierRequest) (*SupplierResponse, error)
+	GetSupplier(ctx context.Context, id int64) (*SupplierResponse, error)
+	ListSuppliers(ctx context.Context, isActive *bool, page, pageSize int32) (*PaginatedSuppliersResponse, error)
+	UpdateSupplier(ctx context.Context, id int64, req UpdateSupplierRequest) error
+
+	// Purchase order operations
+	CreatePurchaseOrder(ctx context.Context, req CreatePurchaseOrderRequest, operatorID *int64) (*PurchaseOrderResponse, error)
+	GetPurchaseOrder(ctx context.Context, id int64) (*PurchaseOrderResponse, error)
+	ListPurchaseOrders(ctx context.Context, req ListPurchaseOrdersRequest) (*PaginatedPurchaseOrdersResponse, error)
+	SubmitPurchaseOrder(ctx context.Context, id int64) error
+	CancelPurchaseOrder(ctx context.Context, id int64) error
+	ReceivePurchaseOrder(ctx context.Context, id int64, req ReceivePurchaseOrderRequest, operatorID *int64) (*PurchaseOrderResponse, error)
+}
+
+type service struct {
+	repo             Repository
+	inventoryService inventory.Service
+}
+
+// NewService creates a new Service instance
+func NewService(repo Repository, inventoryService inventory.Service) Service {
+	return &service{
+		repo:             repo,
+		inventoryService: inventoryService,
+	}
+}
+
+// CreateSupplier creates a new supplier
+func (s *service) CreateSupplier(ctx context.Context, req CreateSupplierRequest) (*SupplierResponse, error) {
+	supplier, err := s.repo.CreateSupplier(ctx, sqlc.CreateSupplierParams{
+		Name:        req.Name,
+		ContactName: optionalString(req.ContactName),
+		Email:       optionalString(req.Email),
+		Phone:       optionalString(req.Phone),
+		Address:     optionalString(req.Address),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	response := toSupplierResponse(supplier)
+	return &response, nil
+}
+
+// GetSupplier retrieves a supplier by ID
+func (s *service) GetSupplier(ctx context.Context, id int64) (*SupplierResponse, error) {
+	supplier, err := s.repo.GetSupplier(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("supplier not found")
+		}
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+
+	response := toSupplierResponse(supplier)
+	return &response, nil
+}
+
+// ListSuppliers lists suppliers with pagination
+func (s *service) ListSuppliers(ctx context.Context, isActive *bool, page, pageSize int32) (*PaginatedSuppliersResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+
+	suppliers, err := s.repo.ListSuppliers(ctx, sqlc.ListSuppliersParams{
+		IsActive:    isActive,
+		LimitCount:  pageSize,
+		OffsetCount: (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suppliers: %w", err)
+	}
+
+	total, err := s.repo.CountSuppliers(ctx, isActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count suppliers: %w", err)
+	}
+
+	responses := make([]SupplierResponse, len(suppliers))
+	for i, supplier := range suppliers {
+		responses[i] = toSupplierResponse(supplier)
+	}
+
+	totalPages := int32((total + int64(pageSize) - 1) / int64(pageSize))
+
+	return &PaginatedSuppliersResponse{
+		Suppliers:  responses,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// UpdateSupplier updates supplier details
+func (s *service) UpdateSupplier(ctx context.Context, id int64, req UpdateSupplierRequest) error {
+	if _, err := s.GetSupplier(ctx, id); err != nil {
+		return err
+	}
+
+	err := s.repo.UpdateSupplier(ctx, sqlc.UpdateSupplierParams{
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Email:       req.Email,
+		Phone:       req.Phone,
+		Address:     req.Address,
+		IsActive:    req.IsActive,
+		ID:          id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update supplier: %w", err)
+	}
+	return nil
+}
+
+// CreatePurchaseOrder creates a draft purchase order with its line items
+func (s *service) CreatePurchaseOrder(ctx context.Context, req CreatePurchaseOrderRequest, operatorID *int64) (*PurchaseOrderResponse, error) {
+	supplier, err := s.repo.GetSupplier(ctx, req.SupplierID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("supplier not found")
+		}
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+	if !supplier.IsActive {
+		return nil, errors.New("supplier is inactive")
+	}
+
+	productIDs := make([]int64, 0, len(req.Items))
+	seenProducts := make(map[int64]bool, len(req.Items))
+	seen := make(map[inventory.StockKey]bool, len(req.Items))
+	for _, item := range req.Items {
+		key := inventory.NewStockKey(item.ProductID, item.SkuID)
+		if seen[key] {
+			return nil, errors.New("duplicate product in purchase order")
+		}
+		seen[key] = true
+		if !seenProducts[item.ProductID] {
+			seenProducts[item.ProductID] = true
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+
+	var poID int64
+	err = s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		products, err := q.GetProductsByIDs(ctx, productIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get products: %w", err)
+		}
+		if len(products) != len(productIDs) {
+			return errors.New("product not found")
+		}
+		for _, item := range req.Items {
+			if err := checkItemSku(ctx, q, item.ProductID, item.SkuID); err != nil {
+				return err
+			}
+		}
+
+		po, err := q.CreatePurchaseOrder(ctx, sqlc.CreatePurchaseOrderParams{
+			PoNo:         generatePONo(),
+			SupplierID:   req.SupplierID,
+			ExpectedDate: parseDate(req.ExpectedDate),
+			Note:         optionalString(req.Note),
+			CreatedBy:    operatorID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create purchase order: %w", err)
+		}
+
+		for _, item := range req.Items {
+			_, err := q.CreatePurchaseOrderItem(ctx, sqlc.CreatePurchaseOrderItemParams{
+				PurchaseOrderID: po.ID,
+				ProductID:       item.ProductID,
+				SkuID:           item.SkuID,
+				Quantity:        item.Quantity,
+				UnitCost:        item.UnitCost,
+				ExpectedDate:    parseDate(item.ExpectedDate),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create purchase order item: %w", err)
+			}
+		}
+
+		if err := q.RefreshPurchaseOrderTotal(ctx, po.ID); err != nil {
+			return fmt.Errorf("failed to update purchase order total: %w", err)
+		}
+
+		poID = po.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseOrder(ctx, poID)
+}
+
+// GetPurchaseOrder retrieves a purchase order with its items and receipts
+func (s *service) GetPurchaseOrder(ctx context.Context, id int64) (*PurchaseOrderResponse, error) {
+	po, err := s.repo.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("purchase order not found")
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+
+	items, err := s.repo.ListPurchaseOrderItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order items: %w", err)
+	}
+
+	receipts, err := s.repo.ListPurchaseReceipts(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase receipts: %w", err)
+	}
+
+	response := toPurchaseOrderResponse(po)
+	response.Items = make([]PurchaseOrderItemResponse, len(items))
+	for i, item := range items {
+		response.Items[i] = toPurchaseOrderItemResponse(item)
+	}
+	response.Receipts = make([]PurchaseReceiptResponse, len(receipts))
+	for i, receipt := range receipts {
+		response.Receipts[i] = toPurchaseReceiptResponse(receipt)
+	}
+
+	return &response, nil
+}
+
+// ListPurchaseOrders lists purchase orders, optionally filtered by status and supplier
+func (s *service) ListPurchaseOrders(ctx context.Context, req ListPurchaseOrdersRequest) (*PaginatedPurchaseOrdersResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 20
+	}
+
+	status := optionalString(req.Status)
+
+	pos, err := s.repo.ListPurchaseOrders(ctx, sqlc.ListPurchaseOrdersParams{
+		Status:      status,
+		SupplierID:  req.SupplierID,
+		LimitCount:  req.PageSize,
+		OffsetCount: (req.Page - 1) * req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purchase orders: %w", err)
+	}
+
+	total, err := s.repo.CountPurchaseOrders(ctx, sqlc.CountPurchaseOrdersParams{
+		Status:     status,
+		SupplierID: req.SupplierID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count purchase orders: %w", err)
+	}
+
+	responses := make([]PurchaseOrderResponse, len(pos))
+	for i, po := range pos {
+		responses[i] = toPurchaseOrderResponse(po)
+	}
+
+	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+
+	return &PaginatedPurchaseOrdersResponse{
+		PurchaseOrders: responses,
+		Total:          total,
+		Page:           req.Page,
+		PageSize:       req.PageSize,
+		TotalPages:     totalPages,
+	}, nil
+}
+
+// SubmitPurchaseOrder sends a draft purchase order to the supplier
+func (s *service) SubmitPurchaseOrder(ctx context.Context, id int64) error {
+	return s.transition(ctx, id, StatusOrdered, StatusDraft)
+}
+
+// CancelPurchaseOrder cancels a purchase order that has not received any goods
+func (s *service) CancelPurchaseOrder(ctx context.Context, id int64) error {
+	return s.transition(ctx, id, StatusCancelled, StatusDraft, StatusOrdered)
+}
+
+func (s *service) transition(ctx context.Context, id int64, to string, from ...string) error {
+	return s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		po, err := q.GetPurchaseOrderForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errors.New("purchase order not found")
+			}
+			return fmt.Errorf("failed to get purchase order: %w", err)
+		}
+
+		allowed := false
+		for _, status := range from {
+			if po.Status == status {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return errors.New("invalid purchase order status transition")
+		}
+
+		if err := q.UpdatePurchaseOrderStatus(ctx, sqlc.UpdatePurchaseOrderStatusParams{
+			Status: to,
+			ID:     id,
+		}); err != nil {
+			return fmt.Errorf("failed to update purchase order status: %w", err)
+		}
+		return nil
+	})
+}
+
+// ReceivePurchaseOrder books goods received against a purchase order. Each line updates
+// the product's weighted average cost, is recorded as a purchase receipt and restocks
+// inventory with the purchase order as the log reference. The restock runs in the same
+// transaction, so stock and the purchase order are always updated together.
+func (s *service) ReceivePurchaseOrder(ctx context.Context, id int64, req ReceivePurchaseOrderRequest, operatorID *int64) (*PurchaseOrderResponse, error) {
+	changes := make([]inventory.StockChange, 0, len(req.Items))
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		// 1. Lock the purchase order so concurrent receipts are serialized
+		po, err := q.GetPurchaseOrderForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errors.New("purchase order not found")
+			}
+			return fmt.Errorf("failed to get purchase order: %w", err)
+		}
+		if po.Status != StatusOrdered && po.Status != StatusPartiallyReceived {
+			return errors.New("purchase order cannot be received")
+		}
+
+		items, err := q.ListPurchaseOrderItems(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get purchase order items: %w", err)
+		}
+		itemsByKey := make(map[inventory.StockKey]sqlc.ListPurchaseOrderItemsRow, len(items))
+		for _, item := range items {
+			itemsByKey[inventory.NewStockKey(item.ProductID, item.SkuID)] = item
+		}
+
+		seen := make(map[inventory.StockKey]bool, len(req.Items))
+		for _, line := range req.Items {
+			key := inventory.NewStockKey(line.ProductID, line.SkuID)
+			item, ok := itemsByKey[key]
+			if !ok {
+				return errors.New("product is not on this purchase order")
+			}
+			if seen[key] {
+				return errors.New("duplicate product in receipt")
+			}
+			seen[key] = true
+
+			// 2. Book the received quantity on the line
+			rows, err := q.ReceivePurchaseOrderItem(ctx, sqlc.ReceivePurchaseOrderItemParams{
+				Quantity: line.Quantity,
+				ID:       item.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to receive purchase order item: %w", err)
+			}
+			if rows == 0 {
+				return errors.New("received quantity exceeds ordered quantity")
+			}
+
+			// 3. Roll the receipt into the weighted average cost. Cost is kept per product,
+			// so it is weighed against stock on hand across all of the product's SKUs.
+			costBefore, err := q.GetProductCostForUpdate(ctx, line.ProductID)
+			if err != nil {
+				return fmt.Errorf("failed to get product cost: %w", err)
+			}
+			onHand, err := q.GetProductOnHandStock(ctx, line.ProductID)
+			if err != nil {
+				return fmt.Errorf("failed to get inventory: %w", err)
+			}
+
+			unitCost := item.UnitCost
+			if line.UnitCost != nil {
+				unitCost = *line.UnitCost
+			}
+			costAfter := weightedAverageCost(onHand, costBefore, line.Quantity, unitCost)
+
+			if err := q.UpdateProductCostPrice(ctx, sqlc.UpdateProductCostPriceParams{
+				CostPrice: utils.Ptr(costAfter),
+				ID:        line.ProductID,
+			}); err != nil {
+				return fmt.Errorf("failed to update product cost: %w", err)
+			}
+
+			_, err = q.CreatePurchaseReceipt(ctx, sqlc.CreatePurchaseReceiptParams{
+				PurchaseOrderID:     id,
+				PurchaseOrderItemID: item.ID,
+				ProductID:           line.ProductID,
+				SkuID:               item.SkuID,
+				Quantity:            line.Quantity,
+				UnitCost:            unitCost,
+				OnHandBefore:        onHand,
+				CostPriceBefore:     costBefore,
+				CostPriceAfter:      costAfter,
+				ReceivedBy:          operatorID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create purchase receipt: %w", err)
+			}
+
+			// 4. Restock the product or SKU the line was ordered for
+			change, err := s.inventoryService.RestockInventoryTx(ctx, q, inventory.RestockRequest{
+				ProductID:     line.ProductID,
+				SkuID:         item.SkuID,
+				Quantity:      line.Quantity,
+				Reason:        fmt.Sprintf("Received against purchase order %s", po.PoNo),
+				ReferenceType: ReferenceType,
+				ReferenceID:   utils.Ptr(id),
+			}, operatorID)
+			if err != nil {
+				return fmt.Errorf("failed to restock product %d: %w", line.ProductID, err)
+			}
+			changes = append(changes, change)
+		}
+
+		// 5. Advance the purchase order status
+		outstanding, err := q.CountOutstandingPurchaseOrderItems(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to count outstanding items: %w", err)
+		}
+		status := StatusPartiallyReceived
+		if outstanding == 0 {
+			status = StatusReceived
+		}
+		if err := q.UpdatePurchaseOrderStatus(ctx, sqlc.UpdatePurchaseOrderStatusParams{
+			Status: status,
+			ID:     id,
+		}); err != nil {
+			return fmt.Errorf("failed to update purchase order status: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		s.inventoryService.StockRestocked(ctx, change)
+	}
+
+	return s.GetPurchaseOrder(ctx, id)
+}
+
+// checkItemSku makes sure a purchase order line names a stock unit that can be restocked:
+// a SKU of the product, or the product itself when it has no variants
+func checkItemSku(ctx context.Context, q sqlc.Querier, productID int64, skuID *int64) error {
+	if skuID == nil {
+		skuCount, err := q.CountProductSkus(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("failed to count product skus: %w", err)
+		}
+		if skuCount > 0 {
+			return errors.New("sku_id is required for products with variants")
+		}
+		return nil
+	}
+
+	sku, err := q.GetProductSku(ctx, *skuID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("sku not found")
+		}
+		return fmt.Errorf("failed to get sku: %w", err)
+	}
+	if sku.ProductID != productID {
+		return errors.New("sku not found")
+	}
+	return nil
+}
+
+// weightedAverageCost blends the current cost of stock on hand with a new receipt,
+// rounding to the nearest minor currency unit
+func weightedAverageCost(onHand int32, currentCost *int64, quantity int32, unitCost int64) int64 {
+	if currentCost == nil || onHand <= 0 {
+		return unitCost
+	}
+
+	totalValue := int64(onHand)*(*currentCost) + int64(quantity)*unitCost
+	totalUnits := int64(onHand) + int64(quantity)
+	return (totalValue + totalUnits/2) / totalUnits
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return utils.Ptr(value)
+}
+
+func generatePONo() string {
+	// Format: PO + YYYYMMDDHHMMSS + random suffix
+	now := time.Now()
+	return fmt.Sprintf("PO%s%03d", now.Format("20060102150405"), now.Nanosecond()%1000)
+}
@@ -0,0 +1,158 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+	"gomall/internal/config"
+	"gomall/internal/domain/inventory"
+	"gomall/utils"
+)
+
+func TestWeightedAverageCost(t *testing.T) {
+	// No cost history: the receipt sets the cost
+	require.Equal(t, int64(500), weightedAverageCost(10, nil, 5, 500))
+
+	// Nothing on hand: the old cost no longer matters
+	require.Equal(t, int64(500), weightedAverageCost(0, utils.Ptr(int64(300)), 5, 500))
+
+	// (10*300 + 30*500) / 40 = 450
+	require.Equal(t, int64(450), weightedAverageCost(10, utils.Ptr(int64(300)), 30, 500))
+
+	// (2*100 + 1*101) / 3 = 100.33, rounded to 100; (1*100 + 1*101) / 2 = 100.5, rounded to 101
+	require.Equal(t, int64(100), weightedAverageCost(2, utils.Ptr(int64(100)), 1, 101))
+	require.Equal(t, int64(101), weightedAverageCost(1, utils.Ptr(int64(100)), 1, 101))
+}
+
+// newReceiveTestService wires the purchase and inventory services to one store, as in production.
+// Transactions run on tx, and a transaction opened while another is running fails the test:
+// against Postgres it would wait on the row locks held by the outer one.
+func newReceiveTestService(t *testing.T) (Service, *mockdb.MockStore, *mockdb.MockStore) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	tx := mockdb.NewMockStore(ctrl)
+
+	inTx := false
+	store.EXPECT().ExecTx(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(ctx context.Context, fn func(sqlc.Querier) error) error {
+		require.False(t, inTx, "nested transaction")
+		inTx = true
+		defer func() { inTx = false }()
+		return fn(tx)
+	})
+
+	inventoryService := inventory.NewService(store, nil, config.ReorderConfig{})
+	return NewService(store, inventoryService), store, tx
+}
+
+func TestReceivePurchaseOrderRestocksSkuInTransaction(t *testing.T) {
+	s, store, tx := newReceiveTestService(t)
+
+	skuID := utils.Ptr(int64(7))
+	item := sqlc.ListPurchaseOrderItemsRow{ID: 30, PurchaseOrderID: 1, ProductID: 5, SkuID: skuID, Quantity: 10, UnitCost: 500}
+	skuInventory := sqlc.Inventory{ID: 40, ProductID: 5, SkuID: skuID, AvailableStock: 2}
+
+	gomock.InOrder(
+		tx.EXPECT().GetPurchaseOrderForUpdate(gomock.Any(), int64(1)).Return(sqlc.PurchaseOrder{ID: 1, PoNo: "PO1", Status: StatusOrdered}, nil),
+		tx.EXPECT().ListPurchaseOrderItems(gomock.Any(), int64(1)).Return([]sqlc.ListPurchaseOrderItemsRow{item}, nil),
+		tx.EXPECT().ReceivePurchaseOrderItem(gomock.Any(), sqlc.ReceivePurchaseOrderItemParams{Quantity: 4, ID: 30}).Return(int64(1), nil),
+		tx.EXPECT().GetProductCostForUpdate(gomock.Any(), int64(5)).Return(utils.Ptr(int64(300)), nil),
+		tx.EXPECT().GetProductOnHandStock(gomock.Any(), int64(5)).Return(int32(4), nil),
+		// (4*300 + 4*500) / 8 = 400
+		tx.EXPECT().UpdateProductCostPrice(gomock.Any(), sqlc.UpdateProductCostPriceParams{CostPrice: utils.Ptr(int64(400)), ID: 5}).Return(nil),
+		tx.EXPECT().CreatePurchaseReceipt(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg sqlc.CreatePurchaseReceiptParams) (sqlc.PurchaseReceipt, error) {
+			require.Equal(t, skuID, arg.SkuID)
+			require.Equal(t, int32(4), arg.OnHandBefore)
+			return sqlc.PurchaseReceipt{}, nil
+		}),
+		tx.EXPECT().GetInventoryBySkuID(gomock.Any(), skuID).Return(skuInventory, nil),
+		tx.EXPECT().AddAvailableStock(gomock.Any(), sqlc.AddAvailableStockParams{AvailableStock: 4, ID: 40}).Return(nil),
+		tx.EXPECT().CreateInventoryLog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg sqlc.CreateInventoryLogParams) (sqlc.InventoryLog, error) {
+			require.Equal(t, "restock", arg.ChangeType)
+			require.Equal(t, skuID, arg.SkuID)
+			require.Equal(t, utils.Ptr(ReferenceType), arg.ReferenceType)
+			require.Equal(t, utils.Ptr(int64(1)), arg.ReferenceID)
+			return sqlc.InventoryLog{}, nil
+		}),
+		tx.EXPECT().CountOutstandingPurchaseOrderItems(gomock.Any(), int64(1)).Return(int64(1), nil),
+		tx.EXPECT().UpdatePurchaseOrderStatus(gomock.Any(), sqlc.UpdatePurchaseOrderStatusParams{Status: StatusPartiallyReceived, ID: 1}).Return(nil),
+
+		// After commit, backorders waiting on the SKU are allocated in a transaction of their own
+		tx.EXPECT().GetInventoryBySkuID(gomock.Any(), skuID).Return(sqlc.Inventory{ID: 40, ProductID: 5, SkuID: skuID, AvailableStock: 6}, nil),
+	)
+
+	store.EXPECT().GetPurchaseOrder(gomock.Any(), int64(1)).Return(sqlc.PurchaseOrder{ID: 1, PoNo: "PO1", Status: StatusPartiallyReceived}, nil)
+	store.EXPECT().ListPurchaseOrderItems(gomock.Any(), int64(1)).Return([]sqlc.ListPurchaseOrderItemsRow{item}, nil)
+	store.EXPECT().ListPurchaseReceipts(gomock.Any(), int64(1)).Return(nil, nil)
+
+	po, err := s.ReceivePurchaseOrder(context.Background(), 1, ReceivePurchaseOrderRequest{
+		Items: []ReceiveItemRequest{{ProductID: 5, SkuID: skuID, Quantity: 4}},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, StatusPartiallyReceived, po.Status)
+	require.Equal(t, skuID, po.Items[0].SkuID)
+}
+
+func TestReceivePurchaseOrderRollsBackWhenRestockFails(t *testing.T) {
+	s, _, tx := newReceiveTestService(t)
+
+	item := sqlc.ListPurchaseOrderItemsRow{ID: 30, PurchaseOrderID: 1, ProductID: 5, Quantity: 10, UnitCost: 500}
+	tx.EXPECT().GetPurchaseOrderForUpdate(gomock.Any(), int64(1)).Return(sqlc.PurchaseOrder{ID: 1, Status: StatusOrdered}, nil)
+	tx.EXPECT().ListPurchaseOrderItems(gomock.Any(), int64(1)).Return([]sqlc.ListPurchaseOrderItemsRow{item}, nil)
+	tx.EXPECT().ReceivePurchaseOrderItem(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	tx.EXPECT().GetProductCostForUpdate(gomock.Any(), int64(5)).Return(nil, nil)
+	tx.EXPECT().GetProductOnHandStock(gomock.Any(), int64(5)).Return(int32(0), nil)
+	tx.EXPECT().UpdateProductCostPrice(gomock.Any(), gomock.Any()).Return(nil)
+	tx.EXPECT().CreatePurchaseReceipt(gomock.Any(), gomock.Any()).Return(sqlc.PurchaseReceipt{}, nil)
+	tx.EXPECT().GetInventoryByProductID(gomock.Any(), int64(5)).Return(sqlc.Inventory{}, pgx.ErrNoRows)
+
+	// The error surfaces from the receipt's transaction, so its writes roll back with it and
+	// neither the status update nor the post-commit allocation runs
+	_, err := s.ReceivePurchaseOrder(context.Background(), 1, ReceivePurchaseOrderRequest{
+		Items: []ReceiveItemRequest{{ProductID: 5, Quantity: 4}},
+	}, nil)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, pgx.ErrNoRows))
+}
+
+func TestReceivePurchaseOrderMatchesLinesBySku(t *testing.T) {
+	s, _, tx := newReceiveTestService(t)
+
+	item := sqlc.ListPurchaseOrderItemsRow{ID: 30, PurchaseOrderID: 1, ProductID: 5, SkuID: utils.Ptr(int64(7)), Quantity: 10}
+	tx.EXPECT().GetPurchaseOrderForUpdate(gomock.Any(), int64(1)).Return(sqlc.PurchaseOrder{ID: 1, Status: StatusOrdered}, nil)
+	tx.EXPECT().ListPurchaseOrderItems(gomock.Any(), int64(1)).Return([]sqlc.ListPurchaseOrderItemsRow{item}, nil)
+
+	// A line for another SKU of the same product is not on the purchase order
+	_, err := s.ReceivePurchaseOrder(context.Background(), 1, ReceivePurchaseOrderRequest{
+		Items: []ReceiveItemRequest{{ProductID: 5, SkuID: utils.Ptr(int64(8)), Quantity: 1}},
+	}, nil)
+	require.EqualError(t, err, "product is not on this purchase order")
+}
+
+func TestCreatePurchaseOrderRequiresSkuForVariants(t *testing.T) {
+	s, store, tx := newReceiveTestService(t)
+
+	store.EXPECT().GetSupplier(gomock.Any(), int64(2)).Return(sqlc.Supplier{ID: 2, IsActive: true}, nil).Times(2)
+	tx.EXPECT().GetProductsByIDs(gomock.Any(), []int64{5}).Return([]sqlc.Product{{ID: 5}}, nil).Times(2)
+	tx.EXPECT().CountProductSkus(gomock.Any(), int64(5)).Return(int64(3), nil)
+	tx.EXPECT().GetProductSku(gomock.Any(), int64(9)).Return(sqlc.ProductSku{ID: 9, ProductID: 6}, nil)
+
+	_, err := s.CreatePurchaseOrder(context.Background(), CreatePurchaseOrderRequest{
+		SupplierID: 2,
+		Items:      []PurchaseOrderItemRequest{{ProductID: 5, Quantity: 1}},
+	}, nil)
+	require.EqualError(t, err, "sku_id is required for products with variants")
+
+	// A SKU of another product is rejected too
+	_, err = s.CreatePurchaseOrder(context.Background(), CreatePurchaseOrderRequest{
+		SupplierID: 2,
+		Items:      []PurchaseOrderItemRequest{{ProductID: 5, SkuID: utils.Ptr(int64(9)), Quantity: 1}},
+	}, nil)
+	require.EqualError(t, err, "sku not found")
+}
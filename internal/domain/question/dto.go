@@ -0,0 +1,103 @@
+package question
+
+import (
+	"time"
+
+	"gomall/db/sqlc"
+)
+
+// Request DTOs
+
+type AskQuestionRequest struct {
+	Content string `json:"content" binding:"required,min=1,max=500"`
+}
+
+type AnswerQuestionRequest struct {
+	Content string `json:"content" binding:"required,min=1,max=2000"`
+}
+
+type ListQuestionsRequest struct {
+	Page     int32 `form:"page" binding:"omitempty,min=1"`
+	PageSize int32 `form:"page_size" binding:"omitempty,min=1,max=50"`
+	// Answers is the number of top answers embedded in each question
+	Answers int32 `form:"answers" binding:"omitempty,min=1,max=10"`
+}
+
+type ListAnswersRequest struct {
+	Page     int32 `form:"page" binding:"omitempty,min=1"`
+	PageSize int32 `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// Response DTOs
+
+type QuestionResponse struct {
+	ID          int64            `json:"id"`
+	ProductID   int64            `json:"product_id"`
+	UserID      int64            `json:"user_id"`
+	Username    string           `json:"username,omitempty"`
+	Avatar      string           `json:"avatar,omitempty"`
+	Content     string           `json:"content"`
+	AnswerCount int32            `json:"answer_count"`
+	Answers     []AnswerResponse `json:"answers"` // most upvoted first
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type AnswerResponse struct {
+	ID          int64     `json:"id"`
+	QuestionID  int64     `json:"question_id"`
+	UserID      int64     `json:"user_id"`
+	Username    string    `json:"username,omitempty"`
+	Avatar      string    `json:"avatar,omitempty"`
+	Content     string    `json:"content"`
+	IsStaff     bool      `json:"is_staff"`
+	IsBuyer     bool      `json:"is_buyer"`
+	UpvoteCount int32     `json:"upvote_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PaginatedQuestionsResponse struct {
+	Questions  []QuestionResponse `json:"questions"`
+	Total      int64              `json:"total"`
+	Page       int32              `json:"page"`
+	PageSize   int32              `json:"page_size"`
+	TotalPages int32              `json:"total_pages"`
+}
+
+type PaginatedAnswersResponse struct {
+	Answers    []AnswerResponse `json:"answers"`
+	Total      int64            `json:"total"`
+	Page       int32            `json:"page"`
+	PageSize   int32            `json:"page_size"`
+	TotalPages int32            `json:"total_pages"`
+}
+
+type UpvoteResponse struct {
+	UpvoteCount int32 `json:"upvote_count"`
+}
+
+// Conversion functions
+
+func toQuestionResponse(question sqlc.ProductQuestion) QuestionResponse {
+	return QuestionResponse{
+		ID:          question.ID,
+		ProductID:   question.ProductID,
+		UserID:      question.UserID,
+		Content:     question.Content,
+		AnswerCount: question.AnswerCount,
+		Answers:     []AnswerResponse{},
+		CreatedAt:   question.CreatedAt,
+	}
+}
+
+func toAnswerResponse(answer sqlc.ProductAnswer) AnswerResponse {
+	return AnswerResponse{
+		ID:          answer.ID,
+		QuestionID:  answer.QuestionID,
+		UserID:      answer.UserID,
+		Content:     answer.Content,
+		IsStaff:     answer.IsStaff,
+		IsBuyer:     answer.IsBuyer,
+		UpvoteCount: answer.UpvoteCount,
+		CreatedAt:   answer.CreatedAt,
+	}
+}
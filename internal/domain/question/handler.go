@@ -0,0 +1,291 @@
+package question
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gomall/internal/common/middleware"
+	"gomall/utils/response"
+	"gomall/utils/token"
+)
+
+// Handler handles product Q&A HTTP requests
+type Handler struct {
+	service    Service
+	tokenMaker token.Maker
+}
+
+// NewHandler creates a new Handler instance
+func NewHandler(service Service, tokenMaker token.Maker) *Handler {
+	return &Handler{
+		service:    service,
+		tokenMaker: tokenMaker,
+	}
+}
+
+// RegisterRoutes registers all product Q&A routes
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	// Public endpoints (no auth required)
+	router.GET("/products/:id/questions", h.ListQuestions) // GET /products/:id/questions
+	router.GET("/questions/:id/answers", h.ListAnswers)    // GET /questions/:id/answers
+
+	// Protected endpoints (require auth)
+	auth := router.Group("")
+	auth.Use(middleware.AuthMiddleware(h.tokenMaker))
+	{
+		auth.POST("/products/:id/questions", h.AskQuestion)   // POST /products/:id/questions
+		auth.POST("/questions/:id/answers", h.AnswerQuestion) // POST /questions/:id/answers
+		auth.POST("/answers/:id/upvote", h.UpvoteAnswer)      // POST /answers/:id/upvote
+		auth.DELETE("/answers/:id/upvote", h.RemoveUpvote)    // DELETE /answers/:id/upvote
+	}
+}
+
+// AskQuestion godoc
+// @Summary      Ask Question
+// @Description  Ask a question about a product
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                 true  "Product ID"
+// @Param        request  body      AskQuestionRequest  true  "Question"
+// @Success      201      {object}  response.Response{data=QuestionResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /products/{id}/questions [post]
+func (h *Handler) AskQuestion(c *gin.Context) {
+	payload := middleware.GetPayload(c)
+	if payload == nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req AskQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	question, err := h.service.AskQuestion(c.Request.Context(), payload.UserID, productID, req)
+	if err != nil {
+		if err.Error() == "product not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    0,
+		"message": "success",
+		"data":    question,
+	})
+}
+
+// ListQuestions godoc
+// @Summary      List Questions
+// @Description  List a product's questions, newest first, each with its most upvoted answers
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true   "Product ID"
+// @Param        page       query     int  false  "Page number (default: 1)"
+// @Param        page_size  query     int  false  "Page size (default: 10)"
+// @Param        answers    query     int  false  "Answers embedded per question (default: 3)"
+// @Success      200        {object}  response.Response{data=PaginatedQuestionsResponse}
+// @Failure      400        {object}  response.Response
+// @Failure      500        {object}  response.Response
+// @Router       /products/{id}/questions [get]
+func (h *Handler) ListQuestions(c *gin.Context) {
+	productID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req ListQuestionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	questions, err := h.service.ListQuestions(c.Request.Context(), productID, req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, questions)
+}
+
+// AnswerQuestion godoc
+// @Summary      Answer Question
+// @Description  Answer a product question. Only buyers of the product and staff can answer; the asker is notified by email.
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                    true  "Question ID"
+// @Param        request  body      AnswerQuestionRequest  true  "Answer"
+// @Success      201      {object}  response.Response{data=AnswerResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /questions/{id}/answers [post]
+func (h *Handler) AnswerQuestion(c *gin.Context) {
+	payload := middleware.GetPayload(c)
+	if payload == nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	questionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid question id")
+		return
+	}
+
+	var req AnswerQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	answer, err := h.service.AnswerQuestion(c.Request.Context(), payload.UserID, payload.Role, questionID, req)
+	if err != nil {
+		switch err.Error() {
+		case "question not found":
+			response.Error(c, http.StatusNotFound, err.Error())
+		case "only buyers or staff can answer":
+			response.Error(c, http.StatusForbidden, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    0,
+		"message": "success",
+		"data":    answer,
+	})
+}
+
+// ListAnswers godoc
+// @Summary      List Answers
+// @Description  List a question's answers, most upvoted first
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true   "Question ID"
+// @Param        page       query     int  false  "Page number (default: 1)"
+// @Param        page_size  query     int  false  "Page size (default: 20)"
+// @Success      200        {object}  response.Response{data=PaginatedAnswersResponse}
+// @Failure      400        {object}  response.Response
+// @Failure      404        {object}  response.Response
+// @Failure      500        {object}  response.Response
+// @Router       /questions/{id}/answers [get]
+func (h *Handler) ListAnswers(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid question id")
+		return
+	}
+
+	var req ListAnswersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	answers, err := h.service.ListAnswers(c.Request.Context(), questionID, req)
+	if err != nil {
+		if err.Error() == "question not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, answers)
+}
+
+// UpvoteAnswer godoc
+// @Summary      Upvote Answer
+// @Description  Upvote an answer. Upvoting again has no effect.
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Answer ID"
+// @Success      200  {object}  response.Response{data=UpvoteResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /answers/{id}/upvote [post]
+func (h *Handler) UpvoteAnswer(c *gin.Context) {
+	h.changeUpvote(c, h.service.UpvoteAnswer)
+}
+
+// RemoveUpvote godoc
+// @Summary      Remove Upvote
+// @Description  Withdraw an upvote from an answer
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Answer ID"
+// @Success      200  {object}  response.Response{data=UpvoteResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /answers/{id}/upvote [delete]
+func (h *Handler) RemoveUpvote(c *gin.Context) {
+	h.changeUpvote(c, h.service.RemoveUpvote)
+}
+
+func (h *Handler) changeUpvote(c *gin.Context, vote func(ctx context.Context, userID, answerID int64) (*UpvoteResponse, error)) {
+	payload := middleware.GetPayload(c)
+	if payload == nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	answerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid answer id")
+		return
+	}
+
+	result, err := vote(c.Request.Context(), payload.UserID, answerID)
+	if err != nil {
+		switch err.Error() {
+		case "answer not found":
+			response.Error(c, http.StatusNotFound, err.Error())
+		case "cannot vote on own answer":
+			response.Error(c, http.StatusBadRequest, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,94 @@
+package question
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"gomall/db/sqlc"
+)
+
+// Repository defines the data access interface for the product Q&A domain
+type Repository interface {
+	GetProductByID(ctx context.Context, id int64) (sqlc.Product, error)
+	HasPurchasedProduct(ctx context.Context, arg sqlc.HasPurchasedProductParams) (bool, error)
+
+	// Question operations
+	CreateProductQuestion(ctx context.Context, arg sqlc.CreateProductQuestionParams) (sqlc.ProductQuestion, error)
+	GetProductQuestion(ctx context.Context, id int64) (sqlc.ProductQuestion, error)
+	GetProductQuestionAsker(ctx context.Context, id int64) (sqlc.GetProductQuestionAskerRow, error)
+	ListProductQuestions(ctx context.Context, arg sqlc.ListProductQuestionsParams) ([]sqlc.ListProductQuestionsRow, error)
+	CountProductQuestions(ctx context.Context, productID int64) (int64, error)
+
+	// Answer operations
+	GetProductAnswer(ctx context.Context, id int64) (sqlc.ProductAnswer, error)
+	ListProductAnswers(ctx context.Context, arg sqlc.ListProductAnswersParams) ([]sqlc.ListProductAnswersRow, error)
+	CountProductAnswers(ctx context.Context, questionID int64) (int64, error)
+	ListTopAnswersByQuestionIDs(ctx context.Context, arg sqlc.ListTopAnswersByQuestionIDsParams) ([]sqlc.ListTopAnswersByQuestionIDsRow, error)
+
+	// Transaction support
+	ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error
+}
+
+type repository struct {
+	store sqlc.Store
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(pool *pgxpool.Pool) Repository {
+	return &repository{
+		store: sqlc.NewStore(pool),
+	}
+}
+
+func (r *repository) GetProductByID(ctx context.Context, id int64) (sqlc.Product, error) {
+	return r.store.GetProductByID(ctx, id)
+}
+
+func (r *repository) HasPurchasedProduct(ctx context.Context, arg sqlc.HasPurchasedProductParams) (bool, error) {
+	return r.store.HasPurchasedProduct(ctx, arg)
+}
+
+// Question operations
+
+func (r *repository) CreateProductQuestion(ctx context.Context, arg sqlc.CreateProductQuestionParams) (sqlc.ProductQuestion, error) {
+	return r.store.CreateProductQuestion(ctx, arg)
+}
+
+func (r *repository) GetProductQuestion(ctx context.Context, id int64) (sqlc.ProductQuestion, error) {
+	return r.store.GetProductQuestion(ctx, id)
+}
+
+func (r *repository) GetProductQuestionAsker(ctx context.Context, id int64) (sqlc.GetProductQuestionAskerRow, error) {
+	return r.store.GetProductQuestionAsker(ctx, id)
+}
+
+func (r *repository) ListProductQuestions(ctx context.Context, arg sqlc.ListProductQuestionsParams) ([]sqlc.ListProductQuestionsRow, error) {
+	return r.store.ListProductQuestions(ctx, arg)
+}
+
+func (r *repository) CountProductQuestions(ctx context.Context, productID int64) (int64, error) {
+	return r.store.CountProductQuestions(ctx, productID)
+}
+
+// Answer operations
+
+func (r *repository) GetProductAnswer(ctx context.Context, id int64) (sqlc.ProductAnswer, error) {
+	return r.store.GetProductAnswer(ctx, id)
+}
+
+func (r *repository) ListProductAnswers(ctx context.Context, arg sqlc.ListProductAnswersParams) ([]sqlc.ListProductAnswersRow, error) {
+	return r.store.ListProductAnswers(ctx, arg)
+}
+
+func (r *repository) CountProductAnswers(ctx context.Context, questionID int64) (int64, error) {
+	return r.store.CountProductAnswers(ctx, questionID)
+}
+
+func (r *repository) ListTopAnswersByQuestionIDs(ctx context.Context, arg sqlc.ListTopAnswersByQuestionIDsParams) ([]sqlc.ListTopAnswersByQuestionIDsRow, error) {
+	return r.store.ListTopAnswersByQuestionIDs(ctx, arg)
+}
+
+func (r *repository) ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return r.store.ExecTx(ctx, fn)
+}
@@ -0,0 +1,319 @@
+package question
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+	"gomall/utils/mail"
+	"gomall/utils/token"
+)
+
+// defaultAnswersPerQuestion is the number of top answers embedded in each listed question
+const defaultAnswersPerQuestion = 3
+
+// staffRoles are the token roles allowed to answer any product's questions
+var staffRoles = []string{token.RoleAdmin, token.RoleStaff}
+
+// Service defines the business logic interface for the product Q&A domain
+type Service interface {
+	AskQuestion(ctx context.Context, userID, productID int64, req AskQuestionRequest) (*QuestionResponse, error)
+	ListQuestions(ctx context.Context, productID int64, req ListQuestionsRequest) (*PaginatedQuestionsResponse, error)
+	AnswerQuestion(ctx context.Context, userID int64, role string, questionID int64, req AnswerQuestionRequest) (*AnswerResponse, error)
+	ListAnswers(ctx context.Context, questionID int64, req ListAnswersRequest) (*PaginatedAnswersResponse, error)
+	UpvoteAnswer(ctx context.Context, userID, answerID int64) (*UpvoteResponse, error)
+	RemoveUpvote(ctx context.Context, userID, answerID int64) (*UpvoteResponse, error)
+}
+
+type service struct {
+	repo   Repository
+	mailer mail.Sender
+}
+
+// NewService creates a new Service instance; mailer may be nil to skip answer notifications
+func NewService(repo Repository, mailer mail.Sender) Service {
+	return &service{
+		repo:   repo,
+		mailer: mailer,
+	}
+}
+
+// AskQuestion posts a question on a product
+func (s *service) AskQuestion(ctx context.Context, userID, productID int64, req AskQuestionRequest) (*QuestionResponse, error) {
+	if _, err := s.repo.GetProductByID(ctx, productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("product not found")
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	question, err := s.repo.CreateProductQuestion(ctx, sqlc.CreateProductQuestionParams{
+		ProductID: productID,
+		UserID:    userID,
+		Content:   req.Content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create question: %w", err)
+	}
+
+	response := toQuestionResponse(question)
+	return &response, nil
+}
+
+// ListQuestions lists a product's questions, newest first, each with its most upvoted answers
+func (s *service) ListQuestions(ctx context.Context, productID int64, req ListQuestionsRequest) (*PaginatedQuestionsResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 10
+	}
+	if req.Answers < 1 {
+		req.Answers = defaultAnswersPerQuestion
+	}
+
+	rows, err := s.repo.ListProductQuestions(ctx, sqlc.ListProductQuestionsParams{
+		ProductID:   productID,
+		LimitCount:  req.PageSize,
+		OffsetCount: (req.Page - 1) * req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+
+	total, err := s.repo.CountProductQuestions(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count questions: %w", err)
+	}
+
+	questions := make([]QuestionResponse, len(rows))
+	questionIDs := make([]int64, len(rows))
+	positions := make(map[int64]int, len(rows))
+	for i, row := range rows {
+		questions[i] = toQuestionResponse(row.ProductQuestion)
+		questions[i].Username = row.Username
+		questions[i].Avatar = utils.PtrValue(row.Avatar)
+		questionIDs[i] = row.ProductQuestion.ID
+		positions[row.ProductQuestion.ID] = i
+	}
+
+	// Batch load the top answers of every question on the page
+	if len(questionIDs) > 0 {
+		answers, err := s.repo.ListTopAnswersByQuestionIDs(ctx, sqlc.ListTopAnswersByQuestionIDsParams{
+			QuestionIds: questionIDs,
+			PerQuestion: req.Answers,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list answers: %w", err)
+		}
+		for _, answer := range answers {
+			question := &questions[positions[answer.QuestionID]]
+			question.Answers = append(question.Answers, AnswerResponse{
+				ID:          answer.ID,
+				QuestionID:  answer.QuestionID,
+				UserID:      answer.UserID,
+				Username:    answer.Username,
+				Avatar:      utils.PtrValue(answer.Avatar),
+				Content:     answer.Content,
+				IsStaff:     answer.IsStaff,
+				IsBuyer:     answer.IsBuyer,
+				UpvoteCount: answer.UpvoteCount,
+				CreatedAt:   answer.CreatedAt,
+			})
+		}
+	}
+
+	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+
+	return &PaginatedQuestionsResponse{
+		Questions:  questions,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// AnswerQuestion answers a question as a buyer of the product or as staff, then emails the asker
+func (s *service) AnswerQuestion(ctx context.Context, userID int64, role string, questionID int64, req AnswerQuestionRequest) (*AnswerResponse, error) {
+	question, err := s.repo.GetProductQuestion(ctx, questionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("question not found")
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	isStaff := slices.Contains(staffRoles, role)
+	isBuyer, err := s.repo.HasPurchasedProduct(ctx, sqlc.HasPurchasedProductParams{
+		UserID:    userID,
+		ProductID: question.ProductID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check purchase history: %w", err)
+	}
+	if !isStaff && !isBuyer {
+		return nil, errors.New("only buyers or staff can answer")
+	}
+
+	var answer sqlc.ProductAnswer
+	err = s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		answer, err = q.CreateProductAnswer(ctx, sqlc.CreateProductAnswerParams{
+			QuestionID: questionID,
+			UserID:     userID,
+			Content:    req.Content,
+			IsStaff:    isStaff,
+			IsBuyer:    isBuyer,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create answer: %w", err)
+		}
+
+		if err := q.IncrementQuestionAnswerCount(ctx, questionID); err != nil {
+			return fmt.Errorf("failed to update answer count: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if question.UserID != userID {
+		s.notifyAsker(questionID, answer.Content)
+	}
+
+	response := toAnswerResponse(answer)
+	return &response, nil
+}
+
+// notifyAsker emails the asker about a new answer in the background.
+// The answer is already saved, so a failure is only logged.
+func (s *service) notifyAsker(questionID int64, answer string) {
+	if s.mailer == nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		asker, err := s.repo.GetProductQuestionAsker(ctx, questionID)
+		if err != nil {
+			log.Printf("answer notification for question %d failed: %v", questionID, err)
+			return
+		}
+
+		subject := fmt.Sprintf("GoMall - New answer: %s", asker.ProductName)
+		body := mail.QuestionAnsweredTemplate(asker.Username, asker.ProductName, asker.ProductQuestion.Content, answer)
+		if err := s.mailer.SendEmail(subject, body, []string{asker.Email}, nil, nil); err != nil {
+			log.Printf("answer notification for question %d failed: %v", questionID, err)
+		}
+	}()
+}
+
+// ListAnswers lists a question's answers, most upvoted first
+func (s *service) ListAnswers(ctx context.Context, questionID int64, req ListAnswersRequest) (*PaginatedAnswersResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 20
+	}
+
+	if _, err := s.repo.GetProductQuestion(ctx, questionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("question not found")
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	rows, err := s.repo.ListProductAnswers(ctx, sqlc.ListProductAnswersParams{
+		QuestionID:  questionID,
+		LimitCount:  req.PageSize,
+		OffsetCount: (req.Page - 1) * req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list answers: %w", err)
+	}
+
+	total, err := s.repo.CountProductAnswers(ctx, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count answers: %w", err)
+	}
+
+	answers := make([]AnswerResponse, len(rows))
+	for i, row := range rows {
+		answers[i] = toAnswerResponse(row.ProductAnswer)
+		answers[i].Username = row.Username
+		answers[i].Avatar = utils.PtrValue(row.Avatar)
+	}
+
+	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+
+	return &PaginatedAnswersResponse{
+		Answers:    answers,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// UpvoteAnswer upvotes an answer; upvoting twice has no further effect
+func (s *service) UpvoteAnswer(ctx context.Context, userID, answerID int64) (*UpvoteResponse, error) {
+	return s.changeUpvote(ctx, userID, answerID, true)
+}
+
+// RemoveUpvote withdraws an upvote
+func (s *service) RemoveUpvote(ctx context.Context, userID, answerID int64) (*UpvoteResponse, error) {
+	return s.changeUpvote(ctx, userID, answerID, false)
+}
+
+func (s *service) changeUpvote(ctx context.Context, userID, answerID int64, upvote bool) (*UpvoteResponse, error) {
+	answer, err := s.repo.GetProductAnswer(ctx, answerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("answer not found")
+		}
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+	if answer.UserID == userID {
+		return nil, errors.New("cannot vote on own answer")
+	}
+
+	count := answer.UpvoteCount
+	err = s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		var changed int64
+		var delta int32 = 1
+		if upvote {
+			changed, err = q.CreateProductAnswerVote(ctx, sqlc.CreateProductAnswerVoteParams{AnswerID: answerID, UserID: userID})
+		} else {
+			changed, err = q.DeleteProductAnswerVote(ctx, sqlc.DeleteProductAnswerVoteParams{AnswerID: answerID, UserID: userID})
+			delta = -1
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record vote: %w", err)
+		}
+		if changed == 0 {
+			return nil
+		}
+
+		count, err = q.AddProductAnswerUpvoteCount(ctx, sqlc.AddProductAnswerUpvoteCountParams{ID: answerID, Delta: delta})
+		if err != nil {
+			return fmt.Errorf("failed to update upvote count: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpvoteResponse{UpvoteCount: count}, nil
+}
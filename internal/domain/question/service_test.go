@@ -0,0 +1,81 @@
+package question
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+	"gomall/utils/token"
+)
+
+// newTestService returns a service without a mailer whose transactions run on tx
+func newTestService(t *testing.T) (Service, *mockdb.MockStore, *mockdb.MockStore) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	tx := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ExecTx(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(ctx context.Context, fn func(sqlc.Querier) error) error {
+		return fn(tx)
+	})
+	return NewService(store, nil), store, tx
+}
+
+func TestAskQuestion(t *testing.T) {
+	s, store, _ := newTestService(t)
+
+	store.EXPECT().GetProductByID(gomock.Any(), int64(99)).Return(sqlc.Product{}, pgx.ErrNoRows)
+	_, err := s.AskQuestion(context.Background(), 1, 99, AskQuestionRequest{Content: "Is it waterproof?"})
+	require.EqualError(t, err, "product not found")
+
+	store.EXPECT().GetProductByID(gomock.Any(), int64(5)).Return(sqlc.Product{ID: 5}, nil)
+	store.EXPECT().CreateProductQuestion(gomock.Any(), sqlc.CreateProductQuestionParams{ProductID: 5, UserID: 1, Content: "Is it waterproof?"}).
+		Return(sqlc.ProductQuestion{ID: 3, ProductID: 5, UserID: 1, Content: "Is it waterproof?"}, nil)
+	question, err := s.AskQuestion(context.Background(), 1, 5, AskQuestionRequest{Content: "Is it waterproof?"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), question.ID)
+}
+
+func TestAnswerQuestionRequiresBuyerOrStaff(t *testing.T) {
+	s, store, _ := newTestService(t)
+
+	store.EXPECT().GetProductQuestion(gomock.Any(), int64(3)).Return(sqlc.ProductQuestion{ID: 3, ProductID: 5, UserID: 1}, nil)
+	store.EXPECT().HasPurchasedProduct(gomock.Any(), sqlc.HasPurchasedProductParams{UserID: 2, ProductID: 5}).Return(false, nil)
+
+	_, err := s.AnswerQuestion(context.Background(), 2, token.RoleUser, 3, AnswerQuestionRequest{Content: "Yes"})
+	require.EqualError(t, err, "only buyers or staff can answer")
+}
+
+func TestAnswerQuestionFlagsBuyersAndStaff(t *testing.T) {
+	s, store, tx := newTestService(t)
+
+	expectAnswer := func(userID int64, isStaff, isBuyer bool) {
+		store.EXPECT().GetProductQuestion(gomock.Any(), int64(3)).Return(sqlc.ProductQuestion{ID: 3, ProductID: 5, UserID: 1}, nil)
+		store.EXPECT().HasPurchasedProduct(gomock.Any(), sqlc.HasPurchasedProductParams{UserID: userID, ProductID: 5}).Return(isBuyer, nil)
+		tx.EXPECT().CreateProductAnswer(gomock.Any(), sqlc.CreateProductAnswerParams{
+			QuestionID: 3, UserID: userID, Content: "Yes", IsStaff: isStaff, IsBuyer: isBuyer,
+		}).Return(sqlc.ProductAnswer{ID: 7, QuestionID: 3, UserID: userID, Content: "Yes", IsStaff: isStaff, IsBuyer: isBuyer}, nil)
+		tx.EXPECT().IncrementQuestionAnswerCount(gomock.Any(), int64(3)).Return(nil)
+	}
+
+	// A buyer with a plain user token
+	expectAnswer(2, false, true)
+	answer, err := s.AnswerQuestion(context.Background(), 2, token.RoleUser, 3, AnswerQuestionRequest{Content: "Yes"})
+	require.NoError(t, err)
+	require.True(t, answer.IsBuyer)
+	require.False(t, answer.IsStaff)
+
+	// Staff and admin tokens answer any product's questions
+	expectAnswer(8, true, false)
+	answer, err = s.AnswerQuestion(context.Background(), 8, token.RoleStaff, 3, AnswerQuestionRequest{Content: "Yes"})
+	require.NoError(t, err)
+	require.True(t, answer.IsStaff)
+
+	expectAnswer(9, true, false)
+	answer, err = s.AnswerQuestion(context.Background(), 9, token.RoleAdmin, 3, AnswerQuestionRequest{Content: "Yes"})
+	require.NoError(t, err)
+	require.True(t, answer.IsStaff)
+}
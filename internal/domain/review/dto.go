@@ -0,0 +1,138 @@
+package review
+
+import (
+	"time"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+)
+
+// Request DTOs
+
+type CreateReviewRequest struct {
+	OrderItemID int64    `json:"order_item_id" binding:"required"`
+	Rating      int16    `json:"rating" binding:"required,min=1,max=5"`
+	Content     string   `json:"content" binding:"required,min=1,max=2000"`
+	Images      []string `json:"images,omitempty" binding:"max=9,dive,url,max=500"`
+}
+
+type ListReviewsRequest struct {
+	Sort     string `form:"sort" binding:"omitempty,oneof=newest helpful"`
+	Page     int32  `form:"page" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type ReplyReviewRequest struct {
+	Reply string `json:"reply" binding:"required,min=1,max=2000"`
+}
+
+type ListModerationQueueRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	Page     int32  `form:"page" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type RejectReviewRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=500"`
+}
+
+// Response DTOs
+
+type ReviewResponse struct {
+	ID           int64      `json:"id"`
+	ProductID    int64      `json:"product_id"`
+	UserID       int64      `json:"user_id"`
+	Username     string     `json:"username,omitempty"`
+	Avatar       string     `json:"avatar,omitempty"`
+	Rating       int16      `json:"rating"`
+	Content      string     `json:"content"`
+	Status       string     `json:"status"`
+	Images       []string   `json:"images"`
+	HelpfulCount int32      `json:"helpful_count"`
+	Reply        string     `json:"reply,omitempty"`
+	RepliedAt    *time.Time `json:"replied_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type PaginatedReviewsResponse struct {
+	Reviews    []ReviewResponse `json:"reviews"`
+	Total      int64            `json:"total"`
+	Page       int32            `json:"page"`
+	PageSize   int32            `json:"page_size"`
+	TotalPages int32            `json:"total_pages"`
+}
+
+// ModerationReviewResponse is a review with its moderation details, for admins
+type ModerationReviewResponse struct {
+	ReviewResponse
+	OrderItemID  int64      `json:"order_item_id"`
+	FlagReasons  []string   `json:"flag_reasons"`
+	RejectReason string     `json:"reject_reason,omitempty"`
+	ModeratedBy  *int64     `json:"moderated_by,omitempty"`
+	ModeratedAt  *time.Time `json:"moderated_at,omitempty"`
+}
+
+type PaginatedModerationReviewsResponse struct {
+	Reviews    []ModerationReviewResponse `json:"reviews"`
+	Total      int64                      `json:"total"`
+	Page       int32                      `json:"page"`
+	PageSize   int32                      `json:"page_size"`
+	TotalPages int32                      `json:"total_pages"`
+}
+
+type ModerationLogResponse struct {
+	ID         int64     `json:"id"`
+	ReviewID   int64     `json:"review_id"`
+	Action     string    `json:"action"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Reasons    []string  `json:"reasons"`
+	OperatorID *int64    `json:"operator_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type HelpfulResponse struct {
+	HelpfulCount int32 `json:"helpful_count"`
+}
+
+// Conversion functions
+
+func toReviewResponse(review sqlc.ProductReview) ReviewResponse {
+	return ReviewResponse{
+		ID:           review.ID,
+		ProductID:    review.ProductID,
+		UserID:       review.UserID,
+		Rating:       review.Rating,
+		Content:      review.Content,
+		Status:       review.Status,
+		Images:       review.Images,
+		HelpfulCount: review.HelpfulCount,
+		Reply:        utils.PtrValue(review.Reply),
+		RepliedAt:    review.RepliedAt.Ptr(),
+		CreatedAt:    review.CreatedAt,
+	}
+}
+
+func toModerationReviewResponse(review sqlc.ProductReview) ModerationReviewResponse {
+	return ModerationReviewResponse{
+		ReviewResponse: toReviewResponse(review),
+		OrderItemID:    review.OrderItemID,
+		FlagReasons:    review.FlagReasons,
+		RejectReason:   utils.PtrValue(review.RejectReason),
+		ModeratedBy:    review.ModeratedBy,
+		ModeratedAt:    review.ModeratedAt.Ptr(),
+	}
+}
+
+func toModerationLogResponse(log sqlc.ProductReviewModerationLog) ModerationLogResponse {
+	return ModerationLogResponse{
+		ID:         log.ID,
+		ReviewID:   log.ReviewID,
+		Action:     log.Action,
+		FromStatus: utils.PtrValue(log.FromStatus),
+		ToStatus:   log.ToStatus,
+		Reasons:    log.Reasons,
+		OperatorID: log.OperatorID,
+		CreatedAt:  log.CreatedAt,
+	}
+}
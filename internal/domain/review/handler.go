@@ -0,0 +1,426 @@
+package review
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gomall/internal/common/middleware"
+	"gomall/utils/response"
+	"gomall/utils/token"
+)
+
+// staffRoles are the token roles allowed to reply to and moderate reviews
+var staffRoles = []string{token.RoleAdmin, token.RoleStaff}
+
+// Handler handles review-related HTTP requests
+type Handler struct {
+	service    Service
+	tokenMaker token.Maker
+}
+
+// NewHandler creates a new Handler instance
+func NewHandler(service Service, tokenMaker token.Maker) *Handler {
+	return &Handler{
+		service:    service,
+		tokenMaker: tokenMaker,
+	}
+}
+
+// RegisterRoutes registers all review routes
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	// Public endpoints (no auth required)
+	router.GET("/products/:id/reviews", h.ListReviews) // GET /products/:id/reviews
+
+	// Protected endpoints (require auth)
+	auth := router.Group("")
+	auth.Use(middleware.AuthMiddleware(h.tokenMaker))
+	{
+		auth.POST("/products/:id/reviews", h.CreateReview)   // POST /products/:id/reviews
+		auth.POST("/reviews/:id/helpful", h.VoteHelpful)     // POST /reviews/:id/helpful
+		auth.DELETE("/reviews/:id/helpful", h.UnvoteHelpful) // DELETE /reviews/:id/helpful
+	}
+
+	// Merchant and moderation endpoints (require a staff or admin token)
+	staff := router.Group("")
+	staff.Use(middleware.AuthMiddleware(h.tokenMaker), requireStaff)
+	{
+		staff.PUT("/reviews/:id/reply", h.ReplyReview)                  // PUT /reviews/:id/reply
+		staff.GET("/reviews/moderation", h.ListModerationQueue)         // GET /reviews/moderation
+		staff.POST("/reviews/:id/approve", h.ApproveReview)             // POST /reviews/:id/approve
+		staff.POST("/reviews/:id/reject", h.RejectReview)               // POST /reviews/:id/reject
+		staff.GET("/reviews/:id/moderation-logs", h.ListModerationLogs) // GET /reviews/:id/moderation-logs
+	}
+}
+
+// CreateReview godoc
+// @Summary      Create Review
+// @Description  Review a product bought in one of your completed orders. Each order item can be reviewed once. Reviews flagged by the spam screener are held as pending until an admin approves them.
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                  true  "Product ID"
+// @Param        request  body      CreateReviewRequest  true  "Review"
+// @Success      201      {object}  response.Response{data=ReviewResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /products/{id}/reviews [post]
+func (h *Handler) CreateReview(c *gin.Context) {
+	payload := middleware.GetPayload(c)
+	if payload == nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	review, err := h.service.CreateReview(c.Request.Context(), payload.UserID, productID, req)
+	if err != nil {
+		switch err.Error() {
+		case "order item not found":
+			response.Error(c, http.StatusNotFound, err.Error())
+		case "order item does not match product", "order is not completed":
+			response.Error(c, http.StatusBadRequest, err.Error())
+		case "order item already reviewed":
+			response.Error(c, http.StatusConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    0,
+		"message": "success",
+		"data":    review,
+	})
+}
+
+// ListReviews godoc
+// @Summary      List Reviews
+// @Description  List a product's reviews with pagination
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int     true   "Product ID"
+// @Param        sort       query     string  false  "Sort order (default: newest)" Enums(newest, helpful)
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 20)"
+// @Success      200        {object}  response.Response{data=PaginatedReviewsResponse}
+// @Failure      400        {object}  response.Response
+// @Failure      500        {object}  response.Response
+// @Router       /products/{id}/reviews [get]
+func (h *Handler) ListReviews(c *gin.Context) {
+	productID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req ListReviewsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	reviews, err := h.service.ListReviews(c.Request.Context(), productID, req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, reviews)
+}
+
+// VoteHelpful godoc
+// @Summary      Vote Review Helpful
+// @Description  Mark a review as helpful. Voting again has no effect.
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Review ID"
+// @Success      200  {object}  response.Response{data=HelpfulResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /reviews/{id}/helpful [post]
+func (h *Handler) VoteHelpful(c *gin.Context) {
+	h.changeHelpfulVote(c, h.service.VoteHelpful)
+}
+
+// UnvoteHelpful godoc
+// @Summary      Withdraw Helpful Vote
+// @Description  Withdraw a helpful vote from a review
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Review ID"
+// @Success      200  {object}  response.Response{data=HelpfulResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /reviews/{id}/helpful [delete]
+func (h *Handler) UnvoteHelpful(c *gin.Context) {
+	h.changeHelpfulVote(c, h.service.UnvoteHelpful)
+}
+
+func (h *Handler) changeHelpfulVote(c *gin.Context, vote func(ctx context.Context, userID, reviewID int64) (*HelpfulResponse, error)) {
+	payload := middleware.GetPayload(c)
+	if payload == nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	reviewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid review id")
+		return
+	}
+
+	result, err := vote(c.Request.Context(), payload.UserID, reviewID)
+	if err != nil {
+		switch err.Error() {
+		case "review not found":
+			response.Error(c, http.StatusNotFound, err.Error())
+		case "cannot vote on own review":
+			response.Error(c, http.StatusBadRequest, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ReplyReview godoc
+// @Summary      Reply to Review
+// @Description  Set or replace the merchant reply on a review. Requires a staff or admin token.
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                 true  "Review ID"
+// @Param        request  body      ReplyReviewRequest  true  "Reply"
+// @Success      200      {object}  response.Response{data=ReviewResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /reviews/{id}/reply [put]
+func (h *Handler) ReplyReview(c *gin.Context) {
+	reviewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid review id")
+		return
+	}
+
+	var req ReplyReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	review, err := h.service.ReplyReview(c.Request.Context(), reviewID, req)
+	if err != nil {
+		if err.Error() == "review not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, review)
+}
+
+// ListModerationQueue godoc
+// @Summary      List Review Moderation Queue
+// @Description  List reviews by moderation status, oldest first. Defaults to the pending queue.
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        status     query     string  false  "Moderation status (default: pending)" Enums(pending, approved, rejected)
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 20)"
+// @Success      200        {object}  response.Response{data=PaginatedModerationReviewsResponse}
+// @Failure      400        {object}  response.Response
+// @Failure      401        {object}  response.Response
+// @Failure      403        {object}  response.Response
+// @Failure      500        {object}  response.Response
+// @Router       /reviews/moderation [get]
+func (h *Handler) ListModerationQueue(c *gin.Context) {
+	var req ListModerationQueueRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	reviews, err := h.service.ListModerationQueue(c.Request.Context(), req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, reviews)
+}
+
+// ApproveReview godoc
+// @Summary      Approve Review
+// @Description  Publish a pending or rejected review; it then counts toward the product rating
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Review ID"
+// @Success      200  {object}  response.Response{data=ModerationReviewResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /reviews/{id}/approve [post]
+func (h *Handler) ApproveReview(c *gin.Context) {
+	reviewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid review id")
+		return
+	}
+
+	review, err := h.service.ApproveReview(c.Request.Context(), reviewID, operatorID(c))
+	if err != nil {
+		respondModerationError(c, err)
+		return
+	}
+
+	response.Success(c, review)
+}
+
+// RejectReview godoc
+// @Summary      Reject Review
+// @Description  Hide a pending or approved review with a reason
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                  true  "Review ID"
+// @Param        request  body      RejectReviewRequest  true  "Rejection reason"
+// @Success      200      {object}  response.Response{data=ModerationReviewResponse}
+// @Failure      400      {object}  response.Response
+// @Failure      401      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Failure      500      {object}  response.Response
+// @Router       /reviews/{id}/reject [post]
+func (h *Handler) RejectReview(c *gin.Context) {
+	reviewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid review id")
+		return
+	}
+
+	var req RejectReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	review, err := h.service.RejectReview(c.Request.Context(), reviewID, req, operatorID(c))
+	if err != nil {
+		respondModerationError(c, err)
+		return
+	}
+
+	response.Success(c, review)
+}
+
+// ListModerationLogs godoc
+// @Summary      List Review Moderation Logs
+// @Description  Audit trail of screener and admin decisions on a review, oldest first
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Review ID"
+// @Success      200  {object}  response.Response{data=[]ModerationLogResponse}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /reviews/{id}/moderation-logs [get]
+func (h *Handler) ListModerationLogs(c *gin.Context) {
+	reviewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid review id")
+		return
+	}
+
+	logs, err := h.service.ListModerationLogs(c.Request.Context(), reviewID)
+	if err != nil {
+		if err.Error() == "review not found" {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, logs)
+}
+
+func respondModerationError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "review not found":
+		response.Error(c, http.StatusNotFound, err.Error())
+	case "review is already approved", "review is already rejected":
+		response.Error(c, http.StatusConflict, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// requireStaff rejects requests whose token does not carry a staff role; it runs after AuthMiddleware
+func requireStaff(c *gin.Context) {
+	payload := middleware.GetPayload(c)
+	if payload == nil || !slices.Contains(staffRoles, payload.Role) {
+		response.Error(c, http.StatusForbidden, "forbidden")
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// operatorID returns the authenticated user's ID, if any
+func operatorID(c *gin.Context) *int64 {
+	payload := middleware.GetPayload(c)
+	if payload == nil {
+		return nil
+	}
+	return &payload.UserID
+}
@@ -0,0 +1,57 @@
+package review
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"gomall/utils/token"
+)
+
+func TestStaffRoutesRequireStaffToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokenMaker, err := token.NewJWTMaker("01234567890123456789012345678901")
+	require.NoError(t, err)
+
+	r := gin.New()
+	// A nil service is never reached: every request below is rejected or fails validation first
+	NewHandler(nil, tokenMaker).RegisterRoutes(r.Group(""))
+
+	bearer := func(role string) string {
+		accessToken, _, err := tokenMaker.CreateToken(1, "user", role, time.Minute)
+		require.NoError(t, err)
+		return "Bearer " + accessToken
+	}
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPut, "/reviews/1/reply"},
+		{http.MethodGet, "/reviews/moderation?status=unknown"},
+		{http.MethodPost, "/reviews/x/approve"},
+		{http.MethodPost, "/reviews/1/reject"},
+		{http.MethodGet, "/reviews/x/moderation-logs"},
+	}
+
+	for _, route := range routes {
+		send := func(authorization string) int {
+			req := httptest.NewRequest(route.method, route.path, nil)
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		require.Equal(t, http.StatusUnauthorized, send(""), route.path)
+		require.Equal(t, http.StatusForbidden, send(bearer(token.RoleUser)), route.path)
+		require.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, send(bearer(token.RoleStaff)), route.path)
+	}
+}
@@ -0,0 +1,151 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+	"gomall/utils"
+)
+
+// ListModerationQueue lists reviews in a moderation status, oldest first; the default is the pending queue
+func (s *service) ListModerationQueue(ctx context.Context, req ListModerationQueueRequest) (*PaginatedModerationReviewsResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 20
+	}
+	if req.Status == "" {
+		req.Status = StatusPending
+	}
+
+	rows, err := s.repo.ListReviewsByStatus(ctx, sqlc.ListReviewsByStatusParams{
+		Status:      req.Status,
+		LimitCount:  req.PageSize,
+		OffsetCount: (req.Page - 1) * req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	total, err := s.repo.CountReviewsByStatus(ctx, req.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	reviews := make([]ModerationReviewResponse, len(rows))
+	for i, row := range rows {
+		reviews[i] = toModerationReviewResponse(row.ProductReview)
+		reviews[i].Username = row.Username
+		reviews[i].Avatar = utils.PtrValue(row.Avatar)
+	}
+
+	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+
+	return &PaginatedModerationReviewsResponse{
+		Reviews:    reviews,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// ApproveReview publishes a pending or previously rejected review
+func (s *service) ApproveReview(ctx context.Context, reviewID int64, operatorID *int64) (*ModerationReviewResponse, error) {
+	return s.moderate(ctx, reviewID, StatusApproved, ActionApproved, nil, operatorID)
+}
+
+// RejectReview hides a pending or approved review with a reason shown to the author
+func (s *service) RejectReview(ctx context.Context, reviewID int64, req RejectReviewRequest, operatorID *int64) (*ModerationReviewResponse, error) {
+	return s.moderate(ctx, reviewID, StatusRejected, ActionRejected, &req.Reason, operatorID)
+}
+
+// moderate applies an admin decision, logs it and refreshes the product rating
+func (s *service) moderate(ctx context.Context, reviewID int64, status, action string, reason *string, operatorID *int64) (*ModerationReviewResponse, error) {
+	var review sqlc.ProductReview
+
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		current, err := q.GetProductReviewForUpdate(ctx, reviewID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errors.New("review not found")
+			}
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+		if current.Status == status {
+			return fmt.Errorf("review is already %s", status)
+		}
+
+		review, err = q.UpdateProductReviewStatus(ctx, sqlc.UpdateProductReviewStatusParams{
+			ID:           reviewID,
+			Status:       status,
+			RejectReason: reason,
+			ModeratedBy:  operatorID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update review status: %w", err)
+		}
+
+		var reasons []string
+		if reason != nil {
+			reasons = []string{*reason}
+		}
+		if err := logModeration(ctx, q, review, action, &current.Status, reasons, operatorID); err != nil {
+			return err
+		}
+
+		// Either the old or the new status is approved, so the rating changes
+		if err := q.RefreshProductRating(ctx, review.ProductID); err != nil {
+			return fmt.Errorf("failed to refresh product rating: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toModerationReviewResponse(review)
+	return &response, nil
+}
+
+// ListModerationLogs returns the screener and admin decisions on a review, oldest first
+func (s *service) ListModerationLogs(ctx context.Context, reviewID int64) ([]ModerationLogResponse, error) {
+	if _, err := s.repo.GetProductReview(ctx, reviewID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("review not found")
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	logs, err := s.repo.ListReviewModerationLogs(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation logs: %w", err)
+	}
+
+	responses := make([]ModerationLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = toModerationLogResponse(log)
+	}
+	return responses, nil
+}
+
+// logModeration records a moderation decision for audit
+func logModeration(ctx context.Context, q sqlc.Querier, review sqlc.ProductReview, action string, fromStatus *string, reasons []string, operatorID *int64) error {
+	_, err := q.CreateReviewModerationLog(ctx, sqlc.CreateReviewModerationLogParams{
+		ReviewID:   review.ID,
+		Action:     action,
+		FromStatus: fromStatus,
+		ToStatus:   review.Status,
+		Reasons:    append([]string{}, reasons...),
+		OperatorID: operatorID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to log moderation decision: %w", err)
+	}
+	return nil
+}
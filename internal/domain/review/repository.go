@@ -0,0 +1,68 @@
+package review
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"gomall/db/sqlc"
+)
+
+// Repository defines the data access interface for the review domain
+type Repository interface {
+	GetProductReview(ctx context.Context, id int64) (sqlc.ProductReview, error)
+	ListProductReviews(ctx context.Context, arg sqlc.ListProductReviewsParams) ([]sqlc.ListProductReviewsRow, error)
+	CountProductReviews(ctx context.Context, productID int64) (int64, error)
+	ReplyProductReview(ctx context.Context, arg sqlc.ReplyProductReviewParams) (sqlc.ProductReview, error)
+
+	// Moderation
+	ListReviewsByStatus(ctx context.Context, arg sqlc.ListReviewsByStatusParams) ([]sqlc.ListReviewsByStatusRow, error)
+	CountReviewsByStatus(ctx context.Context, status string) (int64, error)
+	ListReviewModerationLogs(ctx context.Context, reviewID int64) ([]sqlc.ProductReviewModerationLog, error)
+
+	// Transaction support
+	ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error
+}
+
+type repository struct {
+	store sqlc.Store
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(pool *pgxpool.Pool) Repository {
+	return &repository{
+		store: sqlc.NewStore(pool),
+	}
+}
+
+func (r *repository) GetProductReview(ctx context.Context, id int64) (sqlc.ProductReview, error) {
+	return r.store.GetProductReview(ctx, id)
+}
+
+func (r *repository) ListProductReviews(ctx context.Context, arg sqlc.ListProductReviewsParams) ([]sqlc.ListProductReviewsRow, error) {
+	return r.store.ListProductReviews(ctx, arg)
+}
+
+func (r *repository) CountProductReviews(ctx context.Context, productID int64) (int64, error) {
+	return r.store.CountProductReviews(ctx, productID)
+}
+
+func (r *repository) ReplyProductReview(ctx context.Context, arg sqlc.ReplyProductReviewParams) (sqlc.ProductReview, error) {
+	return r.store.ReplyProductReview(ctx, arg)
+}
+
+func (r *repository) ListReviewsByStatus(ctx context.Context, arg sqlc.ListReviewsByStatusParams) ([]sqlc.ListReviewsByStatusRow, error) {
+	return r.store.ListReviewsByStatus(ctx, arg)
+}
+
+func (r *repository) CountReviewsByStatus(ctx context.Context, status string) (int64, error) {
+	return r.store.CountReviewsByStatus(ctx, status)
+}
+
+func (r *repository) ListReviewModerationLogs(ctx context.Context, reviewID int64) ([]sqlc.ProductReviewModerationLog, error) {
+	return r.store.ListReviewModerationLogs(ctx, reviewID)
+}
+
+func (r *repository) ExecTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return r.store.ExecTx(ctx, fn)
+}
@@ -0,0 +1,84 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"gomall/internal/config"
+)
+
+// Screener flag reasons
+const (
+	FlagBannedWord = "banned_word"
+	FlagLink       = "link"
+	FlagDuplicate  = "duplicate"
+	FlagRateLimit  = "rate_limit"
+)
+
+// linkPattern matches URLs, www. hosts and bare domains with a common top-level domain
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.|\b[a-z0-9][a-z0-9-]*\.(com|net|org|cn|io|co|me|cc|xyz|top|shop|site|info|biz|link|ly)\b)`)
+
+// Screener flags reviews that need manual moderation using local rules
+type Screener struct {
+	bannedWords []string
+	blockLinks  bool
+	rateLimit   int64
+}
+
+// NewScreener creates a Screener from the moderation config
+func NewScreener(cfg config.ReviewModerationConfig) *Screener {
+	words := make([]string, 0, len(cfg.BannedWords))
+	for _, word := range cfg.BannedWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return &Screener{
+		bannedWords: words,
+		blockLinks:  cfg.BlockLinks,
+		rateLimit:   cfg.RateLimit,
+	}
+}
+
+// Screen returns the rules a review breaks, none when it can be published right away.
+// recent is the number of reviews the author posted within the rate window,
+// duplicates the number of reviews with the same fingerprint within the duplicate window.
+func (s *Screener) Screen(content string, recent, duplicates int64) []string {
+	var reasons []string
+
+	normalized := normalizeContent(content)
+	// Also match banned words spelled with spaces in between, such as "f r e e money"
+	compact := strings.Join(strings.Fields(normalized), "")
+	for _, word := range s.bannedWords {
+		if strings.Contains(normalized, word) || strings.Contains(compact, strings.Join(strings.Fields(word), "")) {
+			reasons = append(reasons, FlagBannedWord)
+			break
+		}
+	}
+
+	if s.blockLinks && linkPattern.MatchString(content) {
+		reasons = append(reasons, FlagLink)
+	}
+	if duplicates > 0 {
+		reasons = append(reasons, FlagDuplicate)
+	}
+	if s.rateLimit > 0 && recent >= s.rateLimit {
+		reasons = append(reasons, FlagRateLimit)
+	}
+
+	return reasons
+}
+
+// normalizeContent lowercases text and collapses whitespace
+func normalizeContent(content string) string {
+	return strings.Join(strings.Fields(strings.ToLower(content)), " ")
+}
+
+// contentFingerprint identifies reviews with the same text regardless of case and spacing
+func contentFingerprint(content string) string {
+	sum := sha256.Sum256([]byte(normalizeContent(content)))
+	return hex.EncodeToString(sum[:])
+}
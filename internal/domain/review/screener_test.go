@@ -0,0 +1,29 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gomall/internal/config"
+)
+
+func TestScreener(t *testing.T) {
+	s := NewScreener(config.ReviewModerationConfig{
+		BannedWords: []string{"加微信", "Free Money"},
+		BlockLinks:  true,
+		RateLimit:   3,
+	})
+
+	require.Empty(t, s.Screen("Great fit, fast delivery. 5.0 would buy again", 0, 0))
+	require.Equal(t, []string{FlagBannedWord}, s.Screen("好评，加微信返现", 0, 0))
+	require.Equal(t, []string{FlagBannedWord}, s.Screen("F R E E   money inside", 0, 0))
+	require.Equal(t, []string{FlagLink}, s.Screen("see https://example.org for more", 0, 0))
+	require.Equal(t, []string{FlagLink}, s.Screen("cheaper at shop-deals.com", 0, 0))
+	require.Equal(t, []string{FlagDuplicate, FlagRateLimit}, s.Screen("good", 3, 1))
+}
+
+func TestContentFingerprint(t *testing.T) {
+	require.Equal(t, contentFingerprint("Nice  Shirt\n"), contentFingerprint("nice shirt"))
+	require.NotEqual(t, contentFingerprint("nice shirt"), contentFingerprint("nice shirts"))
+}
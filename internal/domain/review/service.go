@@ -0,0 +1,291 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"gomall/db/sqlc"
+	"gomall/internal/config"
+	"gomall/utils"
+)
+
+// Review list orders
+const (
+	SortNewest  = "newest"
+	SortHelpful = "helpful"
+)
+
+// Review moderation statuses; only approved reviews are listed and count toward the product rating
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// Moderation log actions
+const (
+	ActionAutoApproved = "auto_approved"
+	ActionFlagged      = "flagged"
+	ActionApproved     = "approved"
+	ActionRejected     = "rejected"
+)
+
+// Service defines the business logic interface for the review domain
+type Service interface {
+	CreateReview(ctx context.Context, userID, productID int64, req CreateReviewRequest) (*ReviewResponse, error)
+	ListReviews(ctx context.Context, productID int64, req ListReviewsRequest) (*PaginatedReviewsResponse, error)
+	VoteHelpful(ctx context.Context, userID, reviewID int64) (*HelpfulResponse, error)
+	UnvoteHelpful(ctx context.Context, userID, reviewID int64) (*HelpfulResponse, error)
+	ReplyReview(ctx context.Context, reviewID int64, req ReplyReviewRequest) (*ReviewResponse, error)
+
+	// Moderation
+	ListModerationQueue(ctx context.Context, req ListModerationQueueRequest) (*PaginatedModerationReviewsResponse, error)
+	ApproveReview(ctx context.Context, reviewID int64, operatorID *int64) (*ModerationReviewResponse, error)
+	RejectReview(ctx context.Context, reviewID int64, req RejectReviewRequest, operatorID *int64) (*ModerationReviewResponse, error)
+	ListModerationLogs(ctx context.Context, reviewID int64) ([]ModerationLogResponse, error)
+}
+
+type service struct {
+	repo       Repository
+	screener   *Screener
+	moderation config.ReviewModerationConfig
+}
+
+// NewService creates a new Service instance
+func NewService(repo Repository, cfg config.ReviewConfig) Service {
+	return &service{
+		repo:       repo,
+		screener:   NewScreener(cfg.Moderation),
+		moderation: cfg.Moderation,
+	}
+}
+
+// CreateReview reviews a product bought in one of the user's completed orders.
+// Each order item can be reviewed once. The screener publishes clean reviews right away and holds
+// flagged ones for moderation; the product's aggregated rating is refreshed in the same transaction.
+func (s *service) CreateReview(ctx context.Context, userID, productID int64, req CreateReviewRequest) (*ReviewResponse, error) {
+	var review sqlc.ProductReview
+
+	err := s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		// Lock the order item so concurrent reviews of it are serialized
+		item, err := q.GetReviewableOrderItem(ctx, req.OrderItemID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errors.New("order item not found")
+			}
+			return fmt.Errorf("failed to get order item: %w", err)
+		}
+		if item.UserID != userID {
+			return errors.New("order item not found")
+		}
+		if item.ProductID != productID {
+			return errors.New("order item does not match product")
+		}
+		if item.Status != "completed" {
+			return errors.New("order is not completed")
+		}
+
+		exists, err := q.ReviewExistsForOrderItem(ctx, item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check existing review: %w", err)
+		}
+		if exists {
+			return errors.New("order item already reviewed")
+		}
+
+		fingerprint := contentFingerprint(req.Content)
+		reasons, err := s.screen(ctx, q, userID, req.Content, fingerprint)
+		if err != nil {
+			return err
+		}
+		status, action := StatusApproved, ActionAutoApproved
+		if len(reasons) > 0 {
+			status, action = StatusPending, ActionFlagged
+		}
+
+		images := req.Images
+		if images == nil {
+			images = []string{}
+		}
+		review, err = q.CreateProductReview(ctx, sqlc.CreateProductReviewParams{
+			ProductID:          productID,
+			UserID:             userID,
+			OrderItemID:        item.ID,
+			Rating:             req.Rating,
+			Content:            req.Content,
+			Images:             images,
+			ContentFingerprint: fingerprint,
+			Status:             status,
+			FlagReasons:        append([]string{}, reasons...),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create review: %w", err)
+		}
+
+		if err := logModeration(ctx, q, review, action, nil, reasons, nil); err != nil {
+			return err
+		}
+
+		if status == StatusApproved {
+			if err := q.RefreshProductRating(ctx, productID); err != nil {
+				return fmt.Errorf("failed to refresh product rating: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toReviewResponse(review)
+	return &response, nil
+}
+
+// screen runs the screener over a new review, counting the author's recent reviews and earlier copies of its text
+func (s *service) screen(ctx context.Context, q sqlc.Querier, userID int64, content, fingerprint string) ([]string, error) {
+	now := time.Now()
+
+	var recent, duplicates int64
+	var err error
+	if s.moderation.RateLimit > 0 && s.moderation.RateWindow > 0 {
+		recent, err = q.CountRecentReviewsByUser(ctx, sqlc.CountRecentReviewsByUserParams{
+			UserID: userID,
+			Since:  now.Add(-s.moderation.RateWindow),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count recent reviews: %w", err)
+		}
+	}
+	if s.moderation.DuplicateWindow > 0 {
+		duplicates, err = q.CountDuplicateReviews(ctx, sqlc.CountDuplicateReviewsParams{
+			ContentFingerprint: fingerprint,
+			Since:              now.Add(-s.moderation.DuplicateWindow),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count duplicate reviews: %w", err)
+		}
+	}
+
+	return s.screener.Screen(content, recent, duplicates), nil
+}
+
+// ListReviews lists a product's reviews, newest or most helpful first
+func (s *service) ListReviews(ctx context.Context, productID int64, req ListReviewsRequest) (*PaginatedReviewsResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 20
+	}
+	if req.Sort == "" {
+		req.Sort = SortNewest
+	}
+
+	rows, err := s.repo.ListProductReviews(ctx, sqlc.ListProductReviewsParams{
+		ProductID:   productID,
+		Sort:        req.Sort,
+		LimitCount:  req.PageSize,
+		OffsetCount: (req.Page - 1) * req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	total, err := s.repo.CountProductReviews(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	reviews := make([]ReviewResponse, len(rows))
+	for i, row := range rows {
+		reviews[i] = toReviewResponse(row.ProductReview)
+		reviews[i].Username = row.Username
+		reviews[i].Avatar = utils.PtrValue(row.Avatar)
+	}
+
+	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+
+	return &PaginatedReviewsResponse{
+		Reviews:    reviews,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// VoteHelpful marks a review as helpful; voting twice has no further effect
+func (s *service) VoteHelpful(ctx context.Context, userID, reviewID int64) (*HelpfulResponse, error) {
+	return s.changeHelpfulVote(ctx, userID, reviewID, true)
+}
+
+// UnvoteHelpful withdraws a helpful vote
+func (s *service) UnvoteHelpful(ctx context.Context, userID, reviewID int64) (*HelpfulResponse, error) {
+	return s.changeHelpfulVote(ctx, userID, reviewID, false)
+}
+
+func (s *service) changeHelpfulVote(ctx context.Context, userID, reviewID int64, helpful bool) (*HelpfulResponse, error) {
+	review, err := s.repo.GetProductReview(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("review not found")
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	if review.Status != StatusApproved {
+		return nil, errors.New("review not found")
+	}
+	if review.UserID == userID {
+		return nil, errors.New("cannot vote on own review")
+	}
+
+	count := review.HelpfulCount
+	err = s.repo.ExecTx(ctx, func(q sqlc.Querier) error {
+		var changed int64
+		var delta int32 = 1
+		if helpful {
+			changed, err = q.CreateProductReviewVote(ctx, sqlc.CreateProductReviewVoteParams{ReviewID: reviewID, UserID: userID})
+		} else {
+			changed, err = q.DeleteProductReviewVote(ctx, sqlc.DeleteProductReviewVoteParams{ReviewID: reviewID, UserID: userID})
+			delta = -1
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record vote: %w", err)
+		}
+		if changed == 0 {
+			return nil
+		}
+
+		count, err = q.AddProductReviewHelpfulCount(ctx, sqlc.AddProductReviewHelpfulCountParams{ID: reviewID, Delta: delta})
+		if err != nil {
+			return fmt.Errorf("failed to update helpful count: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HelpfulResponse{HelpfulCount: count}, nil
+}
+
+// ReplyReview sets or replaces the merchant reply on a review
+func (s *service) ReplyReview(ctx context.Context, reviewID int64, req ReplyReviewRequest) (*ReviewResponse, error) {
+	review, err := s.repo.ReplyProductReview(ctx, sqlc.ReplyProductReviewParams{
+		ID:    reviewID,
+		Reply: &req.Reply,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("review not found")
+		}
+		return nil, fmt.Errorf("failed to reply to review: %w", err)
+	}
+
+	response := toReviewResponse(review)
+	return &response, nil
+}
@@ -0,0 +1,113 @@
+package review
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "gomall/db/mock"
+	"gomall/db/sqlc"
+	"gomall/internal/config"
+)
+
+// newTestService returns a service whose transactions run on tx
+func newTestService(t *testing.T, cfg config.ReviewConfig) (Service, *mockdb.MockStore, *mockdb.MockStore) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	tx := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ExecTx(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(ctx context.Context, fn func(sqlc.Querier) error) error {
+		return fn(tx)
+	})
+	return NewService(store, cfg), store, tx
+}
+
+func TestCreateReviewPublishesCleanReview(t *testing.T) {
+	s, _, tx := newTestService(t, config.ReviewConfig{})
+
+	gomock.InOrder(
+		tx.EXPECT().GetReviewableOrderItem(gomock.Any(), int64(30)).Return(sqlc.GetReviewableOrderItemRow{ID: 30, ProductID: 5, UserID: 1, Status: "completed"}, nil),
+		tx.EXPECT().ReviewExistsForOrderItem(gomock.Any(), int64(30)).Return(false, nil),
+		tx.EXPECT().CreateProductReview(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg sqlc.CreateProductReviewParams) (sqlc.ProductReview, error) {
+			require.Equal(t, StatusApproved, arg.Status)
+			require.Equal(t, []string{}, arg.Images)
+			return sqlc.ProductReview{ID: 9, ProductID: 5, UserID: 1, Rating: arg.Rating, Content: arg.Content, Status: arg.Status}, nil
+		}),
+		tx.EXPECT().CreateReviewModerationLog(gomock.Any(), sqlc.CreateReviewModerationLogParams{
+			ReviewID: 9, Action: ActionAutoApproved, ToStatus: StatusApproved, Reasons: []string{},
+		}).Return(sqlc.ProductReviewModerationLog{}, nil),
+		tx.EXPECT().RefreshProductRating(gomock.Any(), int64(5)).Return(nil),
+	)
+
+	review, err := s.CreateReview(context.Background(), 1, 5, CreateReviewRequest{OrderItemID: 30, Rating: 4, Content: "Fits well"})
+	require.NoError(t, err)
+	require.Equal(t, int64(9), review.ID)
+	require.Equal(t, StatusApproved, review.Status)
+}
+
+func TestCreateReviewHoldsFlaggedReview(t *testing.T) {
+	s, _, tx := newTestService(t, config.ReviewConfig{Moderation: config.ReviewModerationConfig{BlockLinks: true}})
+
+	tx.EXPECT().GetReviewableOrderItem(gomock.Any(), int64(30)).Return(sqlc.GetReviewableOrderItemRow{ID: 30, ProductID: 5, UserID: 1, Status: "completed"}, nil)
+	tx.EXPECT().ReviewExistsForOrderItem(gomock.Any(), int64(30)).Return(false, nil)
+	tx.EXPECT().CreateProductReview(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg sqlc.CreateProductReviewParams) (sqlc.ProductReview, error) {
+		require.Equal(t, StatusPending, arg.Status)
+		require.Equal(t, []string{FlagLink}, arg.FlagReasons)
+		return sqlc.ProductReview{ID: 9, ProductID: 5, UserID: 1, Status: arg.Status}, nil
+	})
+	tx.EXPECT().CreateReviewModerationLog(gomock.Any(), gomock.Any()).Return(sqlc.ProductReviewModerationLog{}, nil)
+	// A pending review does not count toward the rating, so RefreshProductRating is not called
+
+	review, err := s.CreateReview(context.Background(), 1, 5, CreateReviewRequest{OrderItemID: 30, Rating: 5, Content: "cheaper at shop-deals.com"})
+	require.NoError(t, err)
+	require.Equal(t, StatusPending, review.Status)
+}
+
+func TestCreateReviewRequiresOwnCompletedOrder(t *testing.T) {
+	s, _, tx := newTestService(t, config.ReviewConfig{})
+	req := CreateReviewRequest{OrderItemID: 30, Rating: 5, Content: "ok"}
+
+	tx.EXPECT().GetReviewableOrderItem(gomock.Any(), int64(30)).Return(sqlc.GetReviewableOrderItemRow{ID: 30, ProductID: 5, UserID: 2, Status: "completed"}, nil)
+	_, err := s.CreateReview(context.Background(), 1, 5, req)
+	require.EqualError(t, err, "order item not found")
+
+	tx.EXPECT().GetReviewableOrderItem(gomock.Any(), int64(30)).Return(sqlc.GetReviewableOrderItemRow{ID: 30, ProductID: 5, UserID: 1, Status: "shipped"}, nil)
+	_, err = s.CreateReview(context.Background(), 1, 5, req)
+	require.EqualError(t, err, "order is not completed")
+
+	tx.EXPECT().GetReviewableOrderItem(gomock.Any(), int64(30)).Return(sqlc.GetReviewableOrderItemRow{ID: 30, ProductID: 5, UserID: 1, Status: "completed"}, nil)
+	tx.EXPECT().ReviewExistsForOrderItem(gomock.Any(), int64(30)).Return(true, nil)
+	_, err = s.CreateReview(context.Background(), 1, 5, req)
+	require.EqualError(t, err, "order item already reviewed")
+}
+
+func TestVoteHelpful(t *testing.T) {
+	s, store, tx := newTestService(t, config.ReviewConfig{})
+	review := sqlc.ProductReview{ID: 9, UserID: 1, Status: StatusApproved, HelpfulCount: 3}
+
+	// The author cannot vote on their own review
+	store.EXPECT().GetProductReview(gomock.Any(), int64(9)).Return(review, nil)
+	_, err := s.VoteHelpful(context.Background(), 1, 9)
+	require.EqualError(t, err, "cannot vote on own review")
+
+	store.EXPECT().GetProductReview(gomock.Any(), int64(9)).Return(review, nil)
+	tx.EXPECT().CreateProductReviewVote(gomock.Any(), sqlc.CreateProductReviewVoteParams{ReviewID: 9, UserID: 2}).Return(int64(1), nil)
+	tx.EXPECT().AddProductReviewHelpfulCount(gomock.Any(), sqlc.AddProductReviewHelpfulCountParams{ID: 9, Delta: 1}).Return(int32(4), nil)
+	resp, err := s.VoteHelpful(context.Background(), 2, 9)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), resp.HelpfulCount)
+
+	// Voting again changes nothing
+	review.HelpfulCount = 4
+	store.EXPECT().GetProductReview(gomock.Any(), int64(9)).Return(review, nil)
+	tx.EXPECT().CreateProductReviewVote(gomock.Any(), sqlc.CreateProductReviewVoteParams{ReviewID: 9, UserID: 2}).Return(int64(0), nil)
+	resp, err = s.VoteHelpful(context.Background(), 2, 9)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), resp.HelpfulCount)
+
+	// Pending reviews are hidden
+	store.EXPECT().GetProductReview(gomock.Any(), int64(10)).Return(sqlc.ProductReview{ID: 10, UserID: 1, Status: StatusPending}, nil)
+	_, err = s.VoteHelpful(context.Background(), 2, 10)
+	require.EqualError(t, err, "review not found")
+}
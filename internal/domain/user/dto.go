@@ -10,6 +10,7 @@ type LoginContext struct {
 	Password  string
 	UserAgent string
 	ClientIP  string
+	GuestID   string // 登录前的访客 ID（guest_id Cookie），其浏览记录在登录后并入用户
 }
 
 type SessionInfo struct {
@@ -71,6 +72,7 @@ type UserResponse struct {
 	Avatar          string    `json:"avatar,omitempty"`
 	Gender          string    `json:"gender"`
 	IsEmailVerified bool      `json:"is_email_verified"`
+	Role            string    `json:"role"`
 	CreatedAt       time.Time `json:"created_at"`
 }
 
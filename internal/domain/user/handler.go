@@ -92,6 +92,7 @@ func (h *Handler) Register(c *gin.Context) {
 // @Success      200      {object}  response.Response{data=LoginResponse}
 // @Failure      400      {object}  response.Response
 // @Failure      401      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Rate limit exceeded"
 // @Router       /users/login [post]
 func (h *Handler) Login(c *gin.Context) {
 	var req LoginContext
@@ -104,6 +105,7 @@ func (h *Handler) Login(c *gin.Context) {
 		Password:  req.Password,
 		UserAgent: c.Request.UserAgent(),
 		ClientIP:  c.ClientIP(),
+		GuestID:   middleware.RequestGuestID(c),
 	}
 
 	result, err := h.service.Login(c.Request.Context(), loginCtx)
@@ -111,6 +113,9 @@ func (h *Handler) Login(c *gin.Context) {
 		response.Error(c, http.StatusUnauthorized, err.Error())
 		return
 	}
+	if loginCtx.GuestID != "" {
+		middleware.ClearGuestID(c) // 浏览记录已并入用户
+	}
 
 	response.Success(c, result)
 }
@@ -279,6 +284,7 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 // @Failure      400      {object}  response.Response
 // @Failure      404      {object}  response.Response
 // @Failure      500      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Rate limit exceeded"
 // @Router       /users/email/send-verification [post]
 func (h *Handler) SendEmailVerification(c *gin.Context) {
 	var req SendEmailVerificationRequest
@@ -333,6 +339,7 @@ func (h *Handler) VerifyEmail(c *gin.Context) {
 // @Success      200      {object}  response.Response
 // @Failure      400      {object}  response.Response
 // @Failure      500      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Rate limit exceeded"
 // @Router       /users/password/forgot [post]
 func (h *Handler) ForgotPassword(c *gin.Context) {
 	var req ForgotPasswordRequest
@@ -387,6 +394,7 @@ func (h *Handler) ResetPassword(c *gin.Context) {
 // @Success      200      {object}  response.Response{data=LoginResponse}
 // @Failure      400      {object}  response.Response
 // @Failure      401      {object}  response.Response
+// @Failure      429      {object}  response.Response  "Rate limit exceeded"
 // @Router       /users/login/username [post]
 func (h *Handler) LoginWithUsername(c *gin.Context) {
 	var req LoginWithUsernameRequest
@@ -400,6 +408,7 @@ func (h *Handler) LoginWithUsername(c *gin.Context) {
 		Password:  req.Password,
 		UserAgent: c.Request.UserAgent(),
 		ClientIP:  c.ClientIP(),
+		GuestID:   middleware.RequestGuestID(c),
 	}
 
 	result, err := h.service.LoginWithUsername(c.Request.Context(), loginCtx)
@@ -407,6 +416,9 @@ func (h *Handler) LoginWithUsername(c *gin.Context) {
 		response.Error(c, http.StatusUnauthorized, err.Error())
 		return
 	}
+	if loginCtx.GuestID != "" {
+		middleware.ClearGuestID(c) // 浏览记录已并入用户
+	}
 
 	response.Success(c, result)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -38,20 +39,27 @@ type Service interface {
 	RevokeAllOtherSessions(ctx context.Context, userID int64, currentSessionID string) error
 }
 
+// GuestHistory 合并访客登录前的浏览记录，由商品领域实现
+type GuestHistory interface {
+	MergeGuestHistory(ctx context.Context, guestID string, userID int64) error
+}
+
 type service struct {
-	repo        Repository
-	tokenMaker  token.Maker
-	config      *config.Config
-	emailSender mail.Sender
+	repo         Repository
+	tokenMaker   token.Maker
+	config       *config.Config
+	emailSender  mail.Sender
+	guestHistory GuestHistory
 }
 
-// NewService 创建 Service 实例
-func NewService(config *config.Config, repo Repository, maker token.Maker, emailSender mail.Sender) Service {
+// NewService 创建 Service 实例；guestHistory 可为 nil，此时登录不合并浏览记录
+func NewService(config *config.Config, repo Repository, maker token.Maker, emailSender mail.Sender, guestHistory GuestHistory) Service {
 	return &service{
-		config:      config,
-		repo:        repo,
-		tokenMaker:  maker,
-		emailSender: emailSender,
+		config:       config,
+		repo:         repo,
+		tokenMaker:   maker,
+		emailSender:  emailSender,
+		guestHistory: guestHistory,
 	}
 }
 
@@ -119,12 +127,12 @@ func (s *service) Login(ctx context.Context, loginCtx LoginContext) (*LoginRespo
 	}
 
 	// 3. Generate Access Token
-	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, "user", s.config.JWT.AccessTokenDuration)
+	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, user.Role, s.config.JWT.AccessTokenDuration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create access token: %w", err)
 	}
 
-	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, "user", s.config.JWT.RefreshTokenDuration)
+	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, user.Role, s.config.JWT.RefreshTokenDuration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create refresh token: %w", err)
 	}
@@ -149,6 +157,8 @@ func (s *service) Login(ctx context.Context, loginCtx LoginContext) (*LoginRespo
 		ID:          user.ID,
 	})
 
+	s.mergeGuestHistory(ctx, loginCtx, user.ID)
+
 	return &LoginResponse{
 		SessionID:             session.ID.String(),
 		AccessToken:           accessToken,
@@ -172,12 +182,12 @@ func (s *service) LoginWithUsername(ctx context.Context, loginCtx LoginContext)
 	}
 
 	// 3. Generate Access Token
-	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, "user", s.config.JWT.AccessTokenDuration)
+	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, user.Role, s.config.JWT.AccessTokenDuration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create access token: %w", err)
 	}
 
-	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, "user", s.config.JWT.RefreshTokenDuration)
+	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, user.Role, s.config.JWT.RefreshTokenDuration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create refresh token: %w", err)
 	}
@@ -202,6 +212,8 @@ func (s *service) LoginWithUsername(ctx context.Context, loginCtx LoginContext)
 		ID:          user.ID,
 	})
 
+	s.mergeGuestHistory(ctx, loginCtx, user.ID)
+
 	return &LoginResponse{
 		SessionID:             session.ID.String(),
 		AccessToken:           accessToken,
@@ -212,6 +224,16 @@ func (s *service) LoginWithUsername(ctx context.Context, loginCtx LoginContext)
 	}, nil
 }
 
+// mergeGuestHistory 将访客 Cookie 对应的登录前浏览记录并入用户的浏览记录，失败不影响登录
+func (s *service) mergeGuestHistory(ctx context.Context, loginCtx LoginContext, userID int64) {
+	if s.guestHistory == nil || loginCtx.GuestID == "" {
+		return
+	}
+	if err := s.guestHistory.MergeGuestHistory(ctx, loginCtx.GuestID, userID); err != nil {
+		log.Printf("failed to merge guest browsing history of user %d: %v", userID, err)
+	}
+}
+
 func (s *service) GetProfile(ctx context.Context, userID int64) (*UserResponse, error) {
 	user, err := s.repo.GetUserByID(ctx, userID)
 	if err != nil {
@@ -285,7 +307,7 @@ func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*Login
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, "user", s.config.JWT.AccessTokenDuration)
+	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.ID, user.Username, user.Role, s.config.JWT.AccessTokenDuration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create access token: %w", err)
 	}
@@ -586,6 +608,7 @@ func toUserResponse(user sqlc.User) *UserResponse {
 		Avatar:          stringPtrToString(user.Avatar),
 		Gender:          user.Gender,
 		IsEmailVerified: user.IsEmailVerified,
+		Role:            user.Role,
 		CreatedAt:       user.CreatedAt,
 	}
 }
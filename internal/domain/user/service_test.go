@@ -11,6 +11,9 @@ import (
 
 	mockdb "gomall/db/mock"
 	"gomall/db/sqlc"
+	"gomall/internal/config"
+	"gomall/utils/password"
+	"gomall/utils/token"
 )
 
 func TestGetProfile(t *testing.T) {
@@ -35,7 +38,7 @@ func TestGetProfile(t *testing.T) {
 		Return(expectedUser, nil)
 
 	// 创建 service（注入 mock）
-	service := NewService(nil, mockStore, nil, nil)
+	service := NewService(nil, mockStore, nil, nil, nil)
 
 	// 执行测试
 	user, err := service.GetProfile(context.Background(), 1)
@@ -59,7 +62,7 @@ func TestGetProfile_NotFound(t *testing.T) {
 		Times(1).
 		Return(sqlc.User{}, sql.ErrNoRows)
 
-	service := NewService(nil, mockStore, nil, nil)
+	service := NewService(nil, mockStore, nil, nil, nil)
 
 	user, err := service.GetProfile(context.Background(), 999)
 
@@ -110,7 +113,7 @@ func TestVerifyEmail_Success(t *testing.T) {
 		})
 
 	// 创建 service
-	service := NewService(nil, mockStore, nil, nil)
+	service := NewService(nil, mockStore, nil, nil, nil)
 
 	// 执行测试
 	req := VerifyEmailRequest{
@@ -136,7 +139,7 @@ func TestVerifyEmail_InvalidCode(t *testing.T) {
 		Times(1).
 		Return(sqlc.VerificationCode{}, sql.ErrNoRows)
 
-	service := NewService(nil, mockStore, nil, nil)
+	service := NewService(nil, mockStore, nil, nil, nil)
 
 	req := VerifyEmailRequest{
 		Email: "test@example.com",
@@ -173,7 +176,7 @@ func TestVerifyEmail_Expired(t *testing.T) {
 
 	// 不应该调用 ExecTx，因为在验证码过期检查时就会失败
 
-	service := NewService(nil, mockStore, nil, nil)
+	service := NewService(nil, mockStore, nil, nil, nil)
 
 	req := VerifyEmailRequest{
 		Email: "test@example.com",
@@ -221,7 +224,7 @@ func TestResetPassword_Success(t *testing.T) {
 			return nil
 		})
 
-	service := NewService(nil, mockStore, nil, nil)
+	service := NewService(nil, mockStore, nil, nil, nil)
 
 	req := ResetPasswordRequest{
 		Email:       "test@example.com",
@@ -261,7 +264,7 @@ func TestResetPassword_TransactionFailed(t *testing.T) {
 		Times(1).
 		Return(sql.ErrConnDone)
 
-	service := NewService(nil, mockStore, nil, nil)
+	service := NewService(nil, mockStore, nil, nil, nil)
 
 	req := ResetPasswordRequest{
 		Email:       "test@example.com",
@@ -273,3 +276,78 @@ func TestResetPassword_TransactionFailed(t *testing.T) {
 	// 断言：应该返回错误
 	require.Error(t, err)
 }
+
+func TestRefreshToken_IssuesStoredRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	maker, err := token.NewJWTMaker("01234567890123456789012345678901")
+	require.NoError(t, err)
+
+	refreshToken, refreshPayload, err := maker.CreateToken(1, "staffer", token.RoleUser, time.Hour)
+	require.NoError(t, err)
+
+	mockStore.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+		Return(sqlc.Session{ID: refreshPayload.ID, UserID: 1, RefreshToken: refreshToken, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	// 用户已被提升为 staff，新签发的 access token 使用数据库中的角色
+	mockStore.EXPECT().
+		GetUserByID(gomock.Any(), gomock.Eq(int64(1))).
+		Return(sqlc.User{ID: 1, Username: "staffer", Role: token.RoleStaff}, nil)
+
+	cfg := &config.Config{JWT: config.JWTConfig{AccessTokenDuration: time.Minute}}
+	service := NewService(cfg, mockStore, maker, nil, nil)
+
+	resp, err := service.RefreshToken(context.Background(), refreshToken)
+	require.NoError(t, err)
+	require.Equal(t, token.RoleStaff, resp.User.Role)
+
+	accessPayload, err := maker.VerifyToken(resp.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, token.RoleStaff, accessPayload.Role)
+}
+
+// recordingGuestHistory 记录合并请求：访客 ID -> 用户 ID
+type recordingGuestHistory struct {
+	merged map[string]int64
+}
+
+func (h *recordingGuestHistory) MergeGuestHistory(ctx context.Context, guestID string, userID int64) error {
+	h.merged[guestID] = userID
+	return nil
+}
+
+func TestLogin_MergesGuestHistoryByCookie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	maker, err := token.NewJWTMaker("01234567890123456789012345678901")
+	require.NoError(t, err)
+	hashed, err := password.HashPassword("secret123")
+	require.NoError(t, err)
+
+	mockStore.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Eq("alice@example.com")).
+		Return(sqlc.User{ID: 1, Username: "alice", Password: hashed, Role: token.RoleUser}, nil).
+		Times(2)
+	mockStore.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Return(sqlc.Session{}, nil).Times(2)
+	mockStore.EXPECT().UpdateUserLastLogin(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	history := &recordingGuestHistory{merged: map[string]int64{}}
+	cfg := &config.Config{JWT: config.JWTConfig{AccessTokenDuration: time.Minute, RefreshTokenDuration: time.Hour}}
+	service := NewService(cfg, mockStore, maker, nil, history)
+
+	// 同一 NAT 出口、同一浏览器型号的另一位访客：没有 guest_id Cookie 就不合并任何记录
+	loginCtx := LoginContext{Email: "alice@example.com", Password: "secret123", UserAgent: "Mozilla/5.0", ClientIP: "203.0.113.7"}
+	_, err = service.Login(context.Background(), loginCtx)
+	require.NoError(t, err)
+	require.Empty(t, history.merged)
+
+	// 带着自己的 guest_id 登录，只合并该访客的记录
+	loginCtx.GuestID = "9b2f6a3e-5a4d-4c1e-8f3b-2d6c7e8f9a01"
+	_, err = service.Login(context.Background(), loginCtx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{loginCtx.GuestID: 1}, history.merged)
+}
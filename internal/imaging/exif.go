@@ -0,0 +1,123 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"image"
+)
+
+// EXIF orientation values; 1 is upright, 2-8 are the mirrored and rotated variants
+const (
+	orientationNormal     = 1
+	orientationFlipH      = 2
+	orientationRotate180  = 3
+	orientationFlipV      = 4
+	orientationTranspose  = 5
+	orientationRotate90   = 6 // rotate 90° clockwise to display
+	orientationTransverse = 7
+	orientationRotate270  = 8 // rotate 90° counter-clockwise to display
+)
+
+const exifOrientationTag = 0x0112
+
+// exifOrientation reads the orientation tag from a JPEG's EXIF segment, defaulting to upright
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return orientationNormal
+	}
+
+	// Walk the marker segments up to the start of the image data
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xFF {
+			return orientationNormal
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 { // start of scan, end of image
+			return orientationNormal
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return orientationNormal
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && len(segment) >= 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+	return orientationNormal
+}
+
+// tiffOrientation finds the orientation tag in IFD0 of a TIFF header
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return orientationNormal
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return orientationNormal
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return orientationNormal
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value < orientationNormal || value > orientationRotate270 {
+				return orientationNormal
+			}
+			return value
+		}
+	}
+	return orientationNormal
+}
+
+// orient transforms img so it displays upright for the given EXIF orientation
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= orientationNormal || orientation > orientationRotate270 {
+		return img
+	}
+
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= orientationTranspose {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case orientationFlipH:
+				dx, dy = width-1-x, y
+			case orientationRotate180:
+				dx, dy = width-1-x, height-1-y
+			case orientationFlipV:
+				dx, dy = x, height-1-y
+			case orientationTranspose:
+				dx, dy = y, x
+			case orientationRotate90:
+				dx, dy = height-1-y, x
+			case orientationTransverse:
+				dx, dy = height-1-y, width-1-x
+			case orientationRotate270:
+				dx, dy = y, width-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):][:4], img.Pix[img.PixOffset(x, y):][:4])
+		}
+	}
+	return dst
+}
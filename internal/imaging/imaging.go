@@ -0,0 +1,171 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif" // register decoders
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"gomall/internal/config"
+)
+
+// RenditionOriginal names the full-size rendition
+const RenditionOriginal = "original"
+
+const (
+	defaultMaxPixels   = 40_000_000
+	defaultJPEGQuality = 85
+)
+
+// Upload errors; the messages are shown to clients as is
+var (
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrInvalidImage    = errors.New("invalid image")
+	ErrTooManyPixels   = errors.New("image dimensions too large")
+)
+
+// accepted lists the upload types, as sniffed from the file header
+var accepted = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// Rendition is one encoded size of an uploaded image
+type Rendition struct {
+	Name        string
+	Data        []byte
+	ContentType string
+	Ext         string
+	Width       int
+	Height      int
+}
+
+// Processor validates uploads and renders their thumbnails
+type Processor struct {
+	maxPixels  int64
+	quality    int
+	thumbnails []config.ThumbnailConfig
+}
+
+// NewProcessor creates a Processor; zero limits fall back to defaults
+func NewProcessor(cfg config.ImageConfig) *Processor {
+	p := &Processor{
+		maxPixels:  cfg.MaxPixels,
+		quality:    cfg.JPEGQuality,
+		thumbnails: cfg.Thumbnails,
+	}
+	if p.maxPixels <= 0 {
+		p.maxPixels = defaultMaxPixels
+	}
+	if p.quality <= 0 || p.quality > 100 {
+		p.quality = defaultJPEGQuality
+	}
+	return p
+}
+
+// Process renders an uploaded image as the original followed by one rendition per configured thumbnail.
+//
+// The type is sniffed from the content rather than trusted from the client, and the pixel count is
+// checked before decoding. Every rendition is re-encoded from the decoded pixels, which drops EXIF and
+// all other metadata; the EXIF orientation is applied first so photos keep their intended rotation.
+// Opaque images are encoded as JPEG and images with transparency as PNG.
+// Thumbnails fit within their size and are never upscaled.
+func (p *Processor) Process(data []byte) ([]Rendition, error) {
+	if !accepted[http.DetectContentType(data)] {
+		return nil, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > p.maxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	img := orient(toRGBA(src), exifOrientation(data))
+
+	renditions := make([]Rendition, 0, len(p.thumbnails)+1)
+	original, err := p.encode(RenditionOriginal, img)
+	if err != nil {
+		return nil, err
+	}
+	renditions = append(renditions, original)
+
+	bounds := img.Bounds()
+	for _, thumb := range p.thumbnails {
+		width, height := fit(bounds.Dx(), bounds.Dy(), thumb.Size)
+		scaled := img
+		if width != bounds.Dx() || height != bounds.Dy() {
+			scaled = image.NewRGBA(image.Rect(0, 0, width, height))
+			xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), img, bounds, xdraw.Src, nil)
+		}
+
+		rendition, err := p.encode(thumb.Name, scaled)
+		if err != nil {
+			return nil, err
+		}
+		renditions = append(renditions, rendition)
+	}
+	return renditions, nil
+}
+
+// encode writes img as JPEG, or as PNG when it has transparent pixels
+func (p *Processor) encode(name string, img *image.RGBA) (Rendition, error) {
+	var buf bytes.Buffer
+	rendition := Rendition{
+		Name:   name,
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}
+
+	if img.Opaque() {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: p.quality}); err != nil {
+			return Rendition{}, err
+		}
+		rendition.ContentType, rendition.Ext = "image/jpeg", ".jpg"
+	} else {
+		if err := png.Encode(&buf, img); err != nil {
+			return Rendition{}, err
+		}
+		rendition.ContentType, rendition.Ext = "image/png", ".png"
+	}
+
+	rendition.Data = buf.Bytes()
+	return rendition, nil
+}
+
+// fit scales width x height down to fit within size x size, keeping the aspect ratio
+func fit(width, height, size int) (int, int) {
+	if size <= 0 || (width <= size && height <= size) {
+		return width, height
+	}
+	if width >= height {
+		return size, max(1, height*size/width)
+	}
+	return max(1, width*size/height), size
+}
+
+// toRGBA copies img into an RGBA image with its origin at (0, 0)
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,135 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gomall/internal/config"
+)
+
+// withOrientation inserts an EXIF segment holding only the orientation tag after the JPEG SOI marker
+func withOrientation(t *testing.T, jpg []byte, orientation uint16) []byte {
+	t.Helper()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")  // big endian, IFD0 at offset 8
+	tiff = binary.BigEndian.AppendUint16(tiff, 1) // one entry
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0) // value padding, no next IFD
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	out := append([]byte{0xFF, 0xD8, 0xFF, 0xE1}, binary.BigEndian.AppendUint16(nil, uint16(len(segment)+2))...)
+	out = append(out, segment...)
+	return append(out, jpg[2:]...)
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 80, B: 40, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+func TestProcess(t *testing.T) {
+	p := NewProcessor(config.ImageConfig{
+		Thumbnails: []config.ThumbnailConfig{{Name: "small", Size: 50}, {Name: "large", Size: 1000}},
+	})
+
+	data := withOrientation(t, encodeJPEG(t, 400, 200), orientationRotate90)
+	require.Equal(t, orientationRotate90, exifOrientation(data))
+
+	renditions, err := p.Process(data)
+	require.NoError(t, err)
+	require.Len(t, renditions, 3)
+
+	// Rotated upright, metadata stripped
+	original := renditions[0]
+	require.Equal(t, RenditionOriginal, original.Name)
+	require.Equal(t, "image/jpeg", original.ContentType)
+	require.Equal(t, []int{200, 400}, []int{original.Width, original.Height})
+	require.False(t, bytes.Contains(original.Data, []byte("Exif")))
+	require.Equal(t, orientationNormal, exifOrientation(original.Data))
+
+	// Scaled to fit, never upscaled
+	require.Equal(t, []int{25, 50}, []int{renditions[1].Width, renditions[1].Height})
+	require.Equal(t, []int{200, 400}, []int{renditions[2].Width, renditions[2].Height})
+
+	decoded, err := jpeg.DecodeConfig(bytes.NewReader(renditions[1].Data))
+	require.NoError(t, err)
+	require.Equal(t, []int{25, 50}, []int{decoded.Width, decoded.Height})
+}
+
+func TestProcessTransparentPNG(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	renditions, err := NewProcessor(config.ImageConfig{}).Process(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "image/png", renditions[0].ContentType)
+	require.Equal(t, ".png", renditions[0].Ext)
+}
+
+func TestProcessRejects(t *testing.T) {
+	p := NewProcessor(config.ImageConfig{MaxPixels: 100})
+
+	_, err := p.Process([]byte("%PDF-1.7 not an image"))
+	require.ErrorIs(t, err, ErrUnsupportedType)
+
+	_, err = p.Process(encodeJPEG(t, 20, 20))
+	require.ErrorIs(t, err, ErrTooManyPixels)
+
+	_, err = p.Process([]byte{0xFF, 0xD8, 0xFF, 0xDB, 0x00})
+	require.ErrorIs(t, err, ErrInvalidImage)
+}
+
+func TestFit(t *testing.T) {
+	require.Equal(t, []int{150, 75}, pair(fit(1600, 800, 150)))
+	require.Equal(t, []int{75, 150}, pair(fit(800, 1600, 150)))
+	require.Equal(t, []int{100, 80}, pair(fit(100, 80, 150)))
+	require.Equal(t, []int{150, 1}, pair(fit(3000, 10, 150)))
+}
+
+func pair(a, b int) []int {
+	return []int{a, b}
+}
+
+func TestOrient(t *testing.T) {
+	// 3x2 image with its top-left pixel marked
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, color.White)
+
+	marked := func(img *image.RGBA) []int {
+		for y := 0; y < img.Bounds().Dy(); y++ {
+			for x := 0; x < img.Bounds().Dx(); x++ {
+				if img.RGBAAt(x, y) == (color.RGBA{255, 255, 255, 255}) {
+					return []int{x, y}
+				}
+			}
+		}
+		return nil
+	}
+
+	require.Equal(t, []int{0, 0}, marked(orient(img, orientationNormal)))
+	require.Equal(t, []int{2, 0}, marked(orient(img, orientationFlipH)))
+	require.Equal(t, []int{2, 1}, marked(orient(img, orientationRotate180)))
+	require.Equal(t, []int{1, 0}, marked(orient(img, orientationRotate90)))
+	require.Equal(t, []int{0, 2}, marked(orient(img, orientationRotate270)))
+	require.Equal(t, image.Rect(0, 0, 2, 3), orient(img, orientationTranspose).Bounds())
+}
@@ -0,0 +1,422 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Field weights mirror the ts_rank defaults for the A (name), B (brand) and C (description) labels
+const (
+	nameWeight        = 1.0
+	brandWeight       = 0.4
+	descriptionWeight = 0.2
+)
+
+// EmbeddedIndex is an in-process inverted index persisted to a single file after every change.
+// The file is owned by one process, so run a full reindex while the API is stopped.
+type EmbeddedIndex struct {
+	mu        sync.RWMutex
+	path      string
+	segmenter Segmenter
+	docs      map[int64]*Document
+	postings  map[string]map[int64]float64
+}
+
+// embeddedSnapshot is the on-disk format; postings are rebuilt on load
+type embeddedSnapshot struct {
+	Docs []Document
+}
+
+// OpenEmbeddedIndex loads the index stored at path, starting empty when the file does not exist yet
+func OpenEmbeddedIndex(path string, segmenter Segmenter) (*EmbeddedIndex, error) {
+	if path == "" {
+		return nil, errors.New("search.embedded_path is required for the embedded backend")
+	}
+
+	idx := &EmbeddedIndex{
+		path:      path,
+		segmenter: segmenter,
+		docs:      make(map[int64]*Document),
+		postings:  make(map[string]map[int64]float64),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search index: %w", err)
+	}
+
+	var snapshot embeddedSnapshot
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode search index: %w", err)
+	}
+	for _, doc := range snapshot.Docs {
+		idx.add(doc)
+	}
+
+	return idx, nil
+}
+
+func (e *EmbeddedIndex) Index(ctx context.Context, docs ...Document) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, doc := range docs {
+		e.add(doc)
+	}
+	return e.persist()
+}
+
+func (e *EmbeddedIndex) Delete(ctx context.Context, ids ...int64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, id := range ids {
+		e.remove(id)
+	}
+	return e.persist()
+}
+
+func (e *EmbeddedIndex) Rebuild(ctx context.Context, docs []Document) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.docs = make(map[int64]*Document, len(docs))
+	e.postings = make(map[string]map[int64]float64)
+	for _, doc := range docs {
+		e.add(doc)
+	}
+	return e.persist()
+}
+
+func (e *EmbeddedIndex) Close() error {
+	return nil
+}
+
+// tokens splits text into the lowercase terms stored in postings
+func (e *EmbeddedIndex) tokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(e.segmenter.Segment(text)), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+// weightedTerms returns the field-weighted frequency of every term in a document
+func (e *EmbeddedIndex) weightedTerms(doc *Document) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{doc.Name, nameWeight},
+		{doc.Brand, brandWeight},
+		{doc.Description, descriptionWeight},
+	} {
+		for _, term := range e.tokens(field.text) {
+			weights[term] += field.weight
+		}
+	}
+	return weights
+}
+
+func (e *EmbeddedIndex) add(doc Document) {
+	e.remove(doc.ID)
+
+	e.docs[doc.ID] = &doc
+	for term, weight := range e.weightedTerms(&doc) {
+		if e.postings[term] == nil {
+			e.postings[term] = make(map[int64]float64)
+		}
+		e.postings[term][doc.ID] = weight
+	}
+}
+
+func (e *EmbeddedIndex) remove(id int64) {
+	doc, ok := e.docs[id]
+	if !ok {
+		return
+	}
+
+	for term := range e.weightedTerms(doc) {
+		delete(e.postings[term], id)
+		if len(e.postings[term]) == 0 {
+			delete(e.postings, term)
+		}
+	}
+	delete(e.docs, id)
+}
+
+// persist atomically replaces the index file with the current documents
+func (e *EmbeddedIndex) persist() error {
+	snapshot := embeddedSnapshot{Docs: make([]Document, 0, len(e.docs))}
+	for _, doc := range e.docs {
+		snapshot.Docs = append(snapshot.Docs, *doc)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to encode search index: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(e.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create search index directory: %w", err)
+	}
+	tmp := e.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	if err := os.Rename(tmp, e.path); err != nil {
+		return fmt.Errorf("failed to replace search index: %w", err)
+	}
+	return nil
+}
+
+// embeddedQuery is web search syntax reduced to OR-ed clauses of required terms plus excluded terms
+type embeddedQuery struct {
+	clauses  [][]string
+	excluded []string
+}
+
+func (e *EmbeddedIndex) parseQuery(segmented string) embeddedQuery {
+	var parsed embeddedQuery
+	var clause []string
+
+	for _, word := range strings.Fields(segmented) {
+		switch {
+		case strings.EqualFold(word, "or"):
+			if len(clause) > 0 {
+				parsed.clauses = append(parsed.clauses, clause)
+				clause = nil
+			}
+		case strings.HasPrefix(word, "-"):
+			parsed.excluded = append(parsed.excluded, e.tokens(word[1:])...)
+		default:
+			clause = append(clause, e.tokens(word)...)
+		}
+	}
+	if len(clause) > 0 {
+		parsed.clauses = append(parsed.clauses, clause)
+	}
+
+	return parsed
+}
+
+// match scores the documents matching the text of a query; nil means every document
+func (e *EmbeddedIndex) match(text string) map[int64]float64 {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	parsed := e.parseQuery(e.segmenter.Segment(text))
+	scores := make(map[int64]float64)
+
+	for _, clause := range parsed.clauses {
+		var matched map[int64]bool
+		for _, term := range clause {
+			next := make(map[int64]bool)
+			for id := range e.postings[term] {
+				if matched == nil || matched[id] {
+					next[id] = true
+				}
+			}
+			matched = next
+		}
+
+		for id := range matched {
+			score := 0.0
+			for _, term := range clause {
+				idf := math.Log(1 + float64(len(e.docs))/float64(len(e.postings[term])))
+				score += e.postings[term][id] * idf
+			}
+			scores[id] = max(scores[id], score)
+		}
+	}
+
+	for _, term := range parsed.excluded {
+		for id := range e.postings[term] {
+			delete(scores, id)
+		}
+	}
+
+	return scores
+}
+
+// filterSkip names the filter a facet ignores
+type filterSkip struct {
+	brand bool
+	price bool
+	spec  string
+}
+
+func matchesFilters(doc *Document, q Query, skip filterSkip) bool {
+	if q.CategoryID != nil && doc.CategoryID != *q.CategoryID {
+		return false
+	}
+	if q.Status != nil && doc.Status != *q.Status {
+		return false
+	}
+	if !skip.brand && len(q.Brands) > 0 && !slices.Contains(q.Brands, doc.Brand) {
+		return false
+	}
+	if !skip.price {
+		if q.MinPrice != nil && doc.Price < *q.MinPrice {
+			return false
+		}
+		if q.MaxPrice != nil && doc.Price >= *q.MaxPrice {
+			return false
+		}
+	}
+	for name, values := range q.Specs {
+		if name == skip.spec {
+			continue
+		}
+		value, ok := doc.Specs[name]
+		if !ok || !slices.Contains(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Search runs the query in memory
+func (e *EmbeddedIndex) Search(ctx context.Context, q Query) (*Result, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	scores := e.match(q.Text)
+	candidates := make([]*Document, 0, len(e.docs))
+	for id, doc := range e.docs {
+		if scores != nil {
+			if _, ok := scores[id]; !ok {
+				continue
+			}
+		}
+		candidates = append(candidates, doc)
+	}
+
+	var matched []*Document
+	for _, doc := range candidates {
+		if matchesFilters(doc, q, filterSkip{}) {
+			matched = append(matched, doc)
+		}
+	}
+	sortDocuments(matched, scores, sortOrDefault(q))
+
+	result := &Result{
+		Hits:  []Hit{},
+		Total: int64(len(matched)),
+	}
+	if q.Text != "" {
+		result.Terms = Terms(e.segmenter.Segment(q.Text))
+	}
+
+	start := min(int(max(q.Offset, 0)), len(matched))
+	end := len(matched)
+	if q.Limit > 0 {
+		end = min(start+int(q.Limit), len(matched))
+	}
+	for _, doc := range matched[start:end] {
+		result.Hits = append(result.Hits, Hit{ID: doc.ID, Score: float32(scores[doc.ID])})
+	}
+
+	if q.Facets {
+		result.Facets = countFacets(candidates, q)
+	}
+
+	return result, nil
+}
+
+func sortDocuments(docs []*Document, scores map[int64]float64, order Sort) {
+	sort.Slice(docs, func(i, j int) bool {
+		a, b := docs[i], docs[j]
+		switch order {
+		case SortRelevance:
+			if scores[a.ID] != scores[b.ID] {
+				return scores[a.ID] > scores[b.ID]
+			}
+			if a.SalesCount != b.SalesCount {
+				return a.SalesCount > b.SalesCount
+			}
+		case SortPriceAsc:
+			if a.Price != b.Price {
+				return a.Price < b.Price
+			}
+		case SortPriceDesc:
+			if a.Price != b.Price {
+				return a.Price > b.Price
+			}
+		case SortSales:
+			if a.SalesCount != b.SalesCount {
+				return a.SalesCount > b.SalesCount
+			}
+		case SortNewest:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		}
+		return a.ID > b.ID
+	})
+}
+
+// countFacets counts spec values, brands and price buckets over the text matches, each ignoring its own filter
+func countFacets(candidates []*Document, q Query) *Facets {
+	specCounts := make(map[string]map[string]int64)
+	brandCounts := make(map[string]int64)
+	priceCounts := make(map[int]int64)
+
+	for _, doc := range candidates {
+		for name, value := range doc.Specs {
+			if matchesFilters(doc, q, filterSkip{spec: name}) {
+				if specCounts[name] == nil {
+					specCounts[name] = make(map[string]int64)
+				}
+				specCounts[name][value]++
+			}
+		}
+		if doc.Brand != "" && matchesFilters(doc, q, filterSkip{brand: true}) {
+			brandCounts[doc.Brand]++
+		}
+		if matchesFilters(doc, q, filterSkip{price: true}) {
+			// Same bucketing as width_bucket: the number of boundaries at or below the price
+			bucket := sort.Search(len(q.PriceBuckets), func(i int) bool { return q.PriceBuckets[i] > doc.Price })
+			priceCounts[bucket]++
+		}
+	}
+
+	facets := &Facets{}
+	for _, name := range slices.Sorted(maps.Keys(specCounts)) {
+		facets.Specs = append(facets.Specs, SpecFacet{Name: name, Values: sortedCounts(specCounts[name])})
+	}
+	facets.Brands = sortedCounts(brandCounts)
+	for _, bucket := range slices.Sorted(maps.Keys(priceCounts)) {
+		facets.Prices = append(facets.Prices, BucketCount{Bucket: bucket, Count: priceCounts[bucket]})
+	}
+	return facets
+}
+
+// sortedCounts orders facet values by count, most common first, then by value
+func sortedCounts(counts map[string]int64) []FacetCount {
+	values := make([]FacetCount, 0, len(counts))
+	for value, count := range counts {
+		values = append(values, FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Value < values[j].Value
+	})
+	return values
+}
@@ -0,0 +1,94 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightOpen  = "<em>"
+	highlightClose = "</em>"
+	ellipsis       = "…"
+)
+
+// Highlight wraps every occurrence of terms in text with <em> tags and trims the result to a window of
+// at most maxRunes characters around the first match (0 keeps the whole text). The text is HTML-escaped.
+// It returns an empty string when nothing matches.
+func Highlight(text string, terms []string, maxRunes int) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	matched := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		termRunes := []rune(term)
+		if len(termRunes) == 0 {
+			continue
+		}
+		for i := 0; i+len(termRunes) <= len(lower); i++ {
+			if !hasPrefixAt(lower, termRunes, i) {
+				continue
+			}
+			// Latin terms match at word starts only so "phone" does not light up "iphone"
+			if !isCJK(termRunes[0]) && i > 0 && isWordRune(lower[i-1]) {
+				continue
+			}
+			for j := i; j < i+len(termRunes); j++ {
+				matched[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+	if first == -1 {
+		return ""
+	}
+
+	start, end := 0, len(runes)
+	if maxRunes > 0 && len(runes) > maxRunes {
+		start = max(first-maxRunes/4, 0)
+		end = min(start+maxRunes, len(runes))
+		start = max(end-maxRunes, 0)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString(ellipsis)
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && matched[j] == matched[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if matched[i] {
+			b.WriteString(highlightOpen + segment + highlightClose)
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	if end < len(runes) {
+		b.WriteString(ellipsis)
+	}
+
+	return b.String()
+}
+
+func hasPrefixAt(s, prefix []rune, at int) bool {
+	for k, r := range prefix {
+		if s[at+k] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
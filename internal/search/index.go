@@ -0,0 +1,155 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gomall/db/sqlc"
+	"gomall/internal/config"
+)
+
+// Sort orders search hits
+type Sort string
+
+const (
+	SortRelevance Sort = "relevance"
+	SortNewest    Sort = "newest"
+	SortPriceAsc  Sort = "price_asc"
+	SortPriceDesc Sort = "price_desc"
+	SortSales     Sort = "sales"
+)
+
+// Document is the searchable view of a product
+type Document struct {
+	ID          int64
+	Name        string
+	Brand       string
+	Description string
+	CategoryID  int64
+	Status      string
+	Price       int64
+	SalesCount  int32
+	Specs       map[string]string
+	CreatedAt   time.Time
+}
+
+// Query selects, sorts and pages documents. Empty filters match everything.
+type Query struct {
+	Text       string // free text in web search syntax; empty lists without text matching
+	CategoryID *int64
+	Status     *string
+	Brands     []string
+	MinPrice   *int64 // inclusive
+	MaxPrice   *int64 // exclusive
+	Specs      map[string][]string
+	Sort       Sort // defaults to relevance with text, newest without
+	Offset     int32
+	Limit      int32
+
+	// Facets requests facet counts; price facets are bucketed by the ascending PriceBuckets boundaries
+	Facets       bool
+	PriceBuckets []int64
+}
+
+// Hit is one matching document
+type Hit struct {
+	ID    int64
+	Score float32
+}
+
+// Result is a page of hits
+type Result struct {
+	Hits  []Hit
+	Total int64
+	// Terms are the normalized query terms, for highlighting
+	Terms  []string
+	Facets *Facets
+}
+
+// Facets counts matching documents per attribute value.
+// Each facet ignores its own filter so unselected siblings still show how many documents they would add.
+type Facets struct {
+	Specs  []SpecFacet
+	Brands []FacetCount
+	Prices []BucketCount
+}
+
+type SpecFacet struct {
+	Name   string
+	Values []FacetCount
+}
+
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// BucketCount counts documents in price bucket Bucket: 0 is below the first boundary, len(boundaries) above the last
+type BucketCount struct {
+	Bucket int
+	Count  int64
+}
+
+// Index is a product search backend
+type Index interface {
+	// Index adds or replaces documents
+	Index(ctx context.Context, docs ...Document) error
+	// Delete removes documents by ID
+	Delete(ctx context.Context, ids ...int64) error
+	// Rebuild replaces the whole index with docs
+	Rebuild(ctx context.Context, docs []Document) error
+	// Search runs a query
+	Search(ctx context.Context, q Query) (*Result, error)
+	// Close releases resources held by the index
+	Close() error
+}
+
+// NewIndex opens the backend selected by cfg.Backend
+func NewIndex(cfg config.SearchConfig, q sqlc.Querier) (Index, error) {
+	segmenter, err := NewSegmenter(cfg.Segmenter)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Backend {
+	case "", "postgres":
+		return NewPostgresIndex(q, segmenter, cfg.TSConfig), nil
+	case "embedded":
+		return OpenEmbeddedIndex(cfg.EmbeddedPath, segmenter)
+	default:
+		return nil, fmt.Errorf("unknown search backend: %s", cfg.Backend)
+	}
+}
+
+// SpecsFromJSON flattens a product specifications object the way jsonb_each_text does:
+// strings as is, other values as their JSON text. Anything but an object yields no specs.
+func SpecsFromJSON(raw []byte) map[string]string {
+	var object map[string]json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &object) != nil {
+		return nil
+	}
+
+	specs := make(map[string]string, len(object))
+	for name, value := range object {
+		var text string
+		if json.Unmarshal(value, &text) == nil {
+			specs[name] = text
+		} else {
+			specs[name] = string(value)
+		}
+	}
+	return specs
+}
+
+// sortOrDefault resolves an empty sort for a query
+func sortOrDefault(q Query) Sort {
+	if q.Sort != "" {
+		return q.Sort
+	}
+	if q.Text != "" {
+		return SortRelevance
+	}
+	return SortNewest
+}
@@ -0,0 +1,222 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gomall/db/sqlc"
+)
+
+// PostgresIndex searches the products table directly. search_vector is maintained by a trigger,
+// so Index, Delete and Rebuild have nothing to do.
+type PostgresIndex struct {
+	q         sqlc.Querier
+	segmenter Segmenter
+	tsConfig  string
+}
+
+// NewPostgresIndex creates a PostgresIndex; tsConfig must match the update_products_search_vector trigger
+func NewPostgresIndex(q sqlc.Querier, segmenter Segmenter, tsConfig string) *PostgresIndex {
+	if tsConfig == "" {
+		tsConfig = "simple"
+	}
+	return &PostgresIndex{
+		q:         q,
+		segmenter: segmenter,
+		tsConfig:  tsConfig,
+	}
+}
+
+func (p *PostgresIndex) Index(ctx context.Context, docs ...Document) error {
+	return nil
+}
+
+func (p *PostgresIndex) Delete(ctx context.Context, ids ...int64) error {
+	return nil
+}
+
+func (p *PostgresIndex) Rebuild(ctx context.Context, docs []Document) error {
+	return nil
+}
+
+func (p *PostgresIndex) Close() error {
+	return nil
+}
+
+// Search runs the query as SQL against products
+func (p *PostgresIndex) Search(ctx context.Context, q Query) (*Result, error) {
+	var text *string
+	var terms []string
+	if strings.TrimSpace(q.Text) != "" {
+		segmented := p.segmenter.Segment(q.Text)
+		text = &segmented
+		terms = Terms(segmented)
+	}
+
+	specFilters, specContains, err := buildSpecFilters(q.Specs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := p.q.SearchProductIndex(ctx, sqlc.SearchProductIndexParams{
+		Query:        text,
+		TsConfig:     p.tsConfig,
+		CategoryID:   q.CategoryID,
+		Status:       q.Status,
+		Brands:       q.Brands,
+		MinPrice:     q.MinPrice,
+		MaxPrice:     q.MaxPrice,
+		SpecContains: specContains,
+		SpecFilters:  specFilters,
+		Sort:         string(sortOrDefault(q)),
+		PageLimit:    q.Limit,
+		PageOffset:   q.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+
+	total, err := p.q.CountProductIndex(ctx, sqlc.CountProductIndexParams{
+		Query:        text,
+		TsConfig:     p.tsConfig,
+		CategoryID:   q.CategoryID,
+		Status:       q.Status,
+		Brands:       q.Brands,
+		MinPrice:     q.MinPrice,
+		MaxPrice:     q.MaxPrice,
+		SpecContains: specContains,
+		SpecFilters:  specFilters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	result := &Result{
+		Hits:  make([]Hit, len(rows)),
+		Total: total,
+		Terms: terms,
+	}
+	for i, row := range rows {
+		result.Hits[i] = Hit{ID: row.ID, Score: row.Score}
+	}
+
+	if q.Facets {
+		result.Facets, err = p.facets(ctx, q, text, specFilters, specContains)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (p *PostgresIndex) facets(ctx context.Context, q Query, text *string, specFilters, specContains []byte) (*Facets, error) {
+	facets := &Facets{}
+
+	specRows, err := p.q.SearchIndexSpecFacets(ctx, sqlc.SearchIndexSpecFacetsParams{
+		Query:       text,
+		TsConfig:    p.tsConfig,
+		CategoryID:  q.CategoryID,
+		Status:      q.Status,
+		Brands:      q.Brands,
+		MinPrice:    q.MinPrice,
+		MaxPrice:    q.MaxPrice,
+		SpecFilters: specFilters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spec facets: %w", err)
+	}
+	for _, row := range specRows {
+		if n := len(facets.Specs); n == 0 || facets.Specs[n-1].Name != row.Name {
+			facets.Specs = append(facets.Specs, SpecFacet{Name: row.Name})
+		}
+		current := &facets.Specs[len(facets.Specs)-1]
+		current.Values = append(current.Values, FacetCount{Value: row.Value, Count: row.ProductCount})
+	}
+
+	brandRows, err := p.q.SearchIndexBrandFacets(ctx, sqlc.SearchIndexBrandFacetsParams{
+		Query:        text,
+		TsConfig:     p.tsConfig,
+		CategoryID:   q.CategoryID,
+		Status:       q.Status,
+		MinPrice:     q.MinPrice,
+		MaxPrice:     q.MaxPrice,
+		SpecContains: specContains,
+		SpecFilters:  specFilters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand facets: %w", err)
+	}
+	for _, row := range brandRows {
+		facets.Brands = append(facets.Brands, FacetCount{Value: row.Brand, Count: row.ProductCount})
+	}
+
+	priceRows, err := p.q.SearchIndexPriceFacets(ctx, sqlc.SearchIndexPriceFacetsParams{
+		Boundaries:   q.PriceBuckets,
+		Query:        text,
+		TsConfig:     p.tsConfig,
+		CategoryID:   q.CategoryID,
+		Status:       q.Status,
+		Brands:       q.Brands,
+		SpecContains: specContains,
+		SpecFilters:  specFilters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price facets: %w", err)
+	}
+	for _, row := range priceRows {
+		facets.Prices = append(facets.Prices, BucketCount{Bucket: int(row.Bucket), Count: row.ProductCount})
+	}
+
+	return facets, nil
+}
+
+// buildSpecFilters encodes spec filters for the search queries.
+// filters maps every attribute to the documents a product may contain for it: {"color": [{"color": "red"}, ...]}.
+// Numeric and boolean values also match their typed JSON form, so "8" matches both "8" and 8.
+// contains merges the single-valued string attributes into one document the GIN index can use.
+func buildSpecFilters(specs map[string][]string) (filters []byte, contains []byte, err error) {
+	if len(specs) == 0 {
+		return nil, nil, nil
+	}
+
+	docs := make(map[string][]map[string]any, len(specs))
+	merged := make(map[string]any)
+	for name, values := range specs {
+		for _, value := range values {
+			docs[name] = append(docs[name], map[string]any{name: value})
+			if typed, ok := typedSpecValue(value); ok {
+				docs[name] = append(docs[name], map[string]any{name: typed})
+			}
+		}
+		if len(docs[name]) == 1 {
+			merged[name] = values[0]
+		}
+	}
+
+	if filters, err = json.Marshal(docs); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode spec filters: %w", err)
+	}
+	if len(merged) > 0 {
+		if contains, err = json.Marshal(merged); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode spec filters: %w", err)
+		}
+	}
+	return filters, contains, nil
+}
+
+// typedSpecValue reports the JSON number or boolean a filter value also stands for
+func typedSpecValue(value string) (json.RawMessage, bool) {
+	var decoded any
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, false
+	}
+	switch decoded.(type) {
+	case float64, bool:
+		return json.RawMessage(value), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,94 @@
+package search
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigramSegmenter(t *testing.T) {
+	s := BigramSegmenter{}
+
+	require.Equal(t, []string{"红色", "色连", "连衣", "衣裙"}, Terms(s.Segment("红色连衣裙")))
+	require.Equal(t, []string{"iphone", "15", "手机", "壳"}, Terms(s.Segment("iPhone 15手机 壳")))
+	require.Equal(t, "Wool Coat", s.Segment("Wool Coat"))
+	require.Equal(t, "-红色 色连 连衣 衣裙 \"羊毛\"", s.Segment("-红色连衣裙 \"羊毛\""))
+}
+
+func TestTermsSkipsOperators(t *testing.T) {
+	require.Equal(t, []string{"wool", "coat", "jacket"}, Terms(`"wool coat" or jacket -red`))
+}
+
+func TestHighlight(t *testing.T) {
+	require.Equal(t, "<em>Wool</em> coat", Highlight("Wool coat", []string{"wool"}, 0))
+	require.Equal(t, "", Highlight("iPhone case", []string{"phone"}, 0))
+	require.Equal(t, "<em>红色连衣</em>裙", Highlight("红色连衣裙", []string{"红色", "连衣"}, 0))
+	require.Equal(t, "&lt;b&gt; <em>tag</em>", Highlight("<b> tag", []string{"tag"}, 0))
+	require.Equal(t, "…d <em>eee</em> fff …", Highlight("aaa bbb ccc ddd eee fff ggg", []string{"eee"}, 10))
+}
+
+func TestBuildSpecFilters(t *testing.T) {
+	filters, contains, err := buildSpecFilters(map[string][]string{
+		"color": {"red", "blue"},
+		"ram":   {"8"},
+		"size":  {"M"},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"color": [{"color": "red"}, {"color": "blue"}],
+		"ram":   [{"ram": "8"}, {"ram": 8}],
+		"size":  [{"size": "M"}]
+	}`, string(filters))
+	// Only unambiguous single values go into the index-backed containment document
+	require.JSONEq(t, `{"size": "M"}`, string(contains))
+
+	filters, contains, err = buildSpecFilters(nil)
+	require.NoError(t, err)
+	require.Nil(t, filters)
+	require.Nil(t, contains)
+}
+
+func TestEmbeddedIndex(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "search.idx")
+
+	idx, err := OpenEmbeddedIndex(path, BigramSegmenter{})
+	require.NoError(t, err)
+	require.NoError(t, idx.Rebuild(ctx, []Document{
+		{ID: 1, Name: "Wool Coat", Brand: "Acme", Status: "published", Price: 20000, Specs: map[string]string{"color": "red"}},
+		{ID: 2, Name: "红色连衣裙", Brand: "Bloom", Status: "published", Price: 8000, Specs: map[string]string{"color": "red"}},
+		{ID: 3, Name: "Cotton Shirt", Description: "wool blend", Brand: "Acme", Status: "published", Price: 3000, Specs: map[string]string{"color": "blue"}},
+	}))
+
+	result, err := idx.Search(ctx, Query{Text: "wool"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Total)
+	require.Equal(t, int64(1), result.Hits[0].ID, "name matches outrank description matches")
+
+	result, err = idx.Search(ctx, Query{Text: "连衣裙"})
+	require.NoError(t, err)
+	require.Equal(t, []Hit{{ID: 2, Score: result.Hits[0].Score}}, result.Hits)
+
+	result, err = idx.Search(ctx, Query{
+		Specs:        map[string][]string{"color": {"red"}},
+		Sort:         SortPriceAsc,
+		Facets:       true,
+		PriceBuckets: []int64{5000, 10000},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Hits[0].ID)
+	require.Equal(t, int64(1), result.Hits[1].ID)
+	require.Equal(t, []SpecFacet{{Name: "color", Values: []FacetCount{{"red", 2}, {"blue", 1}}}}, result.Facets.Specs)
+	require.Equal(t, []FacetCount{{"Acme", 1}, {"Bloom", 1}}, result.Facets.Brands)
+	require.Equal(t, []BucketCount{{1, 1}, {2, 1}}, result.Facets.Prices)
+
+	require.NoError(t, idx.Delete(ctx, 1))
+	reopened, err := OpenEmbeddedIndex(path, BigramSegmenter{})
+	require.NoError(t, err)
+	result, err = reopened.Search(ctx, Query{Text: "wool"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Total)
+	require.Equal(t, int64(3), result.Hits[0].ID)
+}
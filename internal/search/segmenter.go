@@ -0,0 +1,111 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Segmenter prepares free text for the database's text search parser.
+// The same segmentation must be applied when indexing (search_segment in SQL) and when querying.
+type Segmenter interface {
+	Segment(text string) string
+}
+
+// NewSegmenter returns the segmenter registered under name
+func NewSegmenter(name string) (Segmenter, error) {
+	switch name {
+	case "", "bigram":
+		return BigramSegmenter{}, nil
+	case "none":
+		return NoopSegmenter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown search segmenter: %s", name)
+	}
+}
+
+// BigramSegmenter splits runs of CJK characters into overlapping bigrams and leaves other text untouched,
+// so a whitespace-based parser such as 'simple' can match Chinese, Japanese and Korean words.
+type BigramSegmenter struct{}
+
+// Segment mirrors the search_segment SQL function. A run is only separated from adjacent
+// letters or digits, so websearch operators such as "-" and quotes stay attached to it.
+func (BigramSegmenter) Segment(text string) string {
+	var b strings.Builder
+	var run []rune
+	var prev rune
+
+	flush := func(next rune) {
+		if len(run) == 0 {
+			return
+		}
+		if isWordRune(prev) {
+			b.WriteByte(' ')
+		}
+		if len(run) == 1 {
+			b.WriteString(string(run))
+		}
+		for i := 0; i+1 < len(run); i++ {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(string(run[i : i+2]))
+		}
+		if isWordRune(next) {
+			b.WriteByte(' ')
+		}
+		run = run[:0]
+	}
+
+	for _, r := range text {
+		if isCJK(r) {
+			run = append(run, r)
+			continue
+		}
+		flush(r)
+		b.WriteRune(r)
+		prev = r
+	}
+	flush(0)
+
+	return b.String()
+}
+
+// NoopSegmenter passes text through unchanged, for parsers that understand CJK themselves (e.g. zhparser)
+type NoopSegmenter struct{}
+
+// Segment returns text as is
+func (NoopSegmenter) Segment(text string) string {
+	return text
+}
+
+// isCJK reports whether r falls in the ranges search_segment treats as CJK
+func isCJK(r rune) bool {
+	return (r >= 0x3040 && r <= 0x30ff) ||
+		(r >= 0x3400 && r <= 0x4dbf) ||
+		(r >= 0x4e00 && r <= 0x9fff) ||
+		(r >= 0xac00 && r <= 0xd7af) ||
+		(r >= 0xf900 && r <= 0xfaff)
+}
+
+// Terms extracts the lowercase search terms of a segmented query, skipping websearch operators
+// (quotes, "or" and excluded "-term" words), for highlighting matches in results.
+func Terms(segmented string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+
+	for _, word := range strings.Fields(segmented) {
+		if strings.HasPrefix(word, "-") || strings.EqualFold(word, "or") {
+			continue
+		}
+		for _, term := range strings.FieldsFunc(strings.ToLower(word), func(r rune) bool {
+			return !isWordRune(r)
+		}) {
+			if !seen[term] {
+				seen[term] = true
+				terms = append(terms, term)
+			}
+		}
+	}
+
+	return terms
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"gomall/internal/config"
+)
+
+// Blob stores named binary objects such as uploaded images.
+// Keys are slash-separated relative paths, e.g. "products/42/<uuid>/small.jpg".
+type Blob interface {
+	// Put writes an object of size bytes, replacing any object with the same key
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Delete removes an object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of an object
+	URL(key string) string
+}
+
+// NewBlob opens the backend selected by cfg.Backend
+func NewBlob(cfg config.StorageConfig) (Blob, error) {
+	switch cfg.Backend {
+	case "", "local":
+		return NewLocalBlob(cfg.Local.Root, cfg.Local.BaseURL)
+	case "s3":
+		return NewS3Blob(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", cfg.Backend)
+	}
+}
+
+// joinURL appends a key to a base URL
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalBlob stores objects as files under a root directory.
+// The files are expected to be served at baseURL, e.g. by the API server itself.
+type LocalBlob struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalBlob creates the root directory if needed
+func NewLocalBlob(root, baseURL string) (*LocalBlob, error) {
+	if root == "" {
+		return nil, errors.New("local storage root is required")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage root: %w", err)
+	}
+	return &LocalBlob{root: root, baseURL: baseURL}, nil
+}
+
+// Root returns the directory holding the objects
+func (b *LocalBlob) Root() string {
+	return b.root
+}
+
+// Put writes the object to a temporary file and renames it into place,
+// so readers never see a partially written object
+func (b *LocalBlob) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("failed to write %s: wrote %d of %d bytes", key, written, size)
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *LocalBlob) Delete(ctx context.Context, key string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *LocalBlob) URL(key string) string {
+	return joinURL(b.baseURL, key)
+}
+
+// path maps a key to a file under root, rejecting keys that would escape it
+func (b *LocalBlob) path(key string) (string, error) {
+	name := filepath.FromSlash(key)
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("invalid storage key: %q", key)
+	}
+	return filepath.Join(b.root, name), nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBlob(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	blob, err := NewLocalBlob(root, "http://localhost:8080/uploads/")
+	require.NoError(t, err)
+
+	key := "products/1/abc/small.jpg"
+	require.NoError(t, blob.Put(ctx, key, strings.NewReader("jpeg"), 4, "image/jpeg"))
+	data, err := os.ReadFile(filepath.Join(root, "products", "1", "abc", "small.jpg"))
+	require.NoError(t, err)
+	require.Equal(t, "jpeg", string(data))
+	require.Equal(t, "http://localhost:8080/uploads/products/1/abc/small.jpg", blob.URL(key))
+
+	require.Error(t, blob.Put(ctx, "products/2/short.jpg", strings.NewReader("jp"), 4, "image/jpeg"))
+	require.Error(t, blob.Put(ctx, "../escape.jpg", strings.NewReader("x"), 1, "image/jpeg"))
+	require.Error(t, blob.Put(ctx, "/etc/escape.jpg", strings.NewReader("x"), 1, "image/jpeg"))
+
+	require.NoError(t, blob.Delete(ctx, key))
+	require.NoError(t, blob.Delete(ctx, key))
+	_, err = os.Stat(filepath.Join(root, "products", "1", "abc", "small.jpg"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"gomall/internal/config"
+)
+
+// S3Blob stores objects in a bucket of an S3-compatible object store (AWS S3, MinIO, OSS, ...)
+type S3Blob struct {
+	client  *minio.Client
+	bucket  string
+	baseURL string
+}
+
+// NewS3Blob connects to the object store; the bucket must already exist and allow public reads
+func NewS3Blob(cfg config.S3StorageConfig) (*S3Blob, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("s3 storage endpoint and bucket are required")
+	}
+
+	lookup := minio.BucketLookupAuto
+	if cfg.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       cfg.UseSSL,
+		Region:       cfg.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		endpoint := *client.EndpointURL()
+		if cfg.PathStyle {
+			baseURL = endpoint.JoinPath(cfg.Bucket).String()
+		} else {
+			endpoint.Host = cfg.Bucket + "." + endpoint.Host
+			baseURL = endpoint.String()
+		}
+	}
+
+	return &S3Blob{
+		client:  client,
+		bucket:  cfg.Bucket,
+		baseURL: baseURL,
+	}, nil
+}
+
+// Put uploads the object. Keys are never reused, so the object may be cached forever.
+func (b *S3Blob) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := b.client.PutObject(ctx, b.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType:  contentType,
+		CacheControl: "public, max-age=31536000, immutable",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *S3Blob) Delete(ctx context.Context, key string) error {
+	if err := b.client.RemoveObject(ctx, b.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *S3Blob) URL(key string) string {
+	return joinURL(b.baseURL, key)
+}
@@ -1,6 +1,9 @@
 package mail
 
-import "fmt"
+import (
+	"fmt"
+	"html"
+)
 
 func EmailVerificationTemplate(username, code string) string {
 	return fmt.Sprintf(`
@@ -78,3 +81,74 @@ func PasswordResetTemplate(username, code string) string {
   </html>
   `, username, code)
 }
+
+// LowStockAlertTemplate 低库存告警邮件模板
+func LowStockAlertTemplate(productName string, productID int64, available, threshold int32) string {
+	return fmt.Sprintf(`
+  <!DOCTYPE html>
+  <html>
+  <head>
+      <style>
+          body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+          .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+          .header { background-color: #F44336; color: white; padding: 20px; text-align: center; }
+          .content { padding: 20px; background-color: #f9f9f9; }
+          .stock { font-size: 32px; font-weight: bold; color: #F44336; text-align: center; padding: 20px; background-color: white; margin: 20px 0; border-radius: 
+  5px; }
+          .footer { text-align: center; padding: 20px; font-size: 12px; color: #888; }
+      </style>
+  </head>
+  <body>
+      <div class="container">
+          <div class="header">
+              <h1>GoMall - 低库存告警</h1>
+          </div>
+          <div class="content">
+              <p>商品 <strong>%s</strong> (ID: %d) 的可用库存已低于告警阈值：</p>
+              <div class="stock">%d / %d</div>
+              <p>请尽快安排补货。补货后告警将自动解除。</p>
+          </div>
+          <div class="footer">
+              <p>&copy; 2024 GoMall. All rights reserved.</p>
+          </div>
+      </div>
+  </body>
+  </html>
+  `, productName, productID, available, threshold)
+}
+
+// QuestionAnsweredTemplate 商品问答新回答通知邮件模板
+func QuestionAnsweredTemplate(username, productName, question, answer string) string {
+	return fmt.Sprintf(`
+  <!DOCTYPE html>
+  <html>
+  <head>
+      <style>
+          body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+          .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+          .header { background-color: #2196F3; color: white; padding: 20px; text-align: center; }
+          .content { padding: 20px; background-color: #f9f9f9; }
+          .answer { padding: 20px; background-color: white; margin: 20px 0; border-left: 4px solid #2196F3; border-radius: 5px; white-space: pre-wrap; }
+          .footer { text-align: center; padding: 20px; font-size: 12px; color: #888; }
+      </style>
+  </head>
+  <body>
+      <div class="container">
+          <div class="header">
+              <h1>GoMall - 你的提问有新回答</h1>
+          </div>
+          <div class="content">
+              <p>你好 %s,</p>
+              <p>你在商品 <strong>%s</strong> 下的提问：</p>
+              <p><em>%s</em></p>
+              <p>收到了新的回答：</p>
+              <div class="answer">%s</div>
+          </div>
+          <div class="footer">
+              <p>&copy; 2024 GoMall. All rights reserved.</p>
+          </div>
+      </div>
+  </body>
+  </html>
+  `, html.EscapeString(username), html.EscapeString(productName), html.EscapeString(question), html.EscapeString(answer))
+}
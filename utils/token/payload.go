@@ -7,6 +7,13 @@ import (
 	"time"
 )
 
+// Roles carried in token payloads, issued from users.role
+const (
+	RoleUser  = "user"
+	RoleStaff = "staff"
+	RoleAdmin = "admin"
+)
+
 var (
 	ErrInvalidToken = errors.New("invalid token")
 	ErrExpiredToken = errors.New("expired token")
@@ -0,0 +1,12 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+)
+
+// VisitorID identifies an anonymous visitor by client IP and user agent, without storing either
+func VisitorID(clientIP, userAgent string) string {
+	sum := sha1.Sum([]byte(clientIP + "|" + userAgent))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type Sender interface {
+	Send(ctx context.Context, event string, payload interface{}) error
+}
+
+// Event is the envelope posted to the webhook endpoint
+type Event struct {
+	Event     string      `json:"event"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+type HTTPSender struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSender(url string, timeout time.Duration) Sender {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &HTTPSender{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (sender *HTTPSender) Send(ctx context.Context, event string, payload interface{}) error {
+	body, err := json.Marshal(Event{
+		Event:     event,
+		Timestamp: time.Now(),
+		Data:      payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sender.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := sender.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}